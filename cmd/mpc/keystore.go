@@ -0,0 +1,92 @@
+package mpc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Web3 Secret Storage v3 标准 scrypt 参数，与 geth keystore.StandardScryptN/StandardScryptP 一致
+const (
+	keystoreScryptN     = 1 << 18
+	keystoreScryptR     = 8
+	keystoreScryptP     = 1
+	keystoreScryptDKLen = 32
+)
+
+type keystoreJSON struct {
+	Address string         `json:"address"`
+	Crypto  keystoreCrypto `json:"crypto"`
+	ID      string         `json:"id"`
+	Version int            `json:"version"`
+}
+
+type keystoreCrypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams keystoreCipherParams   `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type keystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+// encryptKeystore 把 secp256k1 私钥加密为 Web3 Secret Storage v3 JSON（geth/MetaMask 可直接导入）
+// scrypt 派生 32 字节密钥，前 16 字节用于 AES-128-CTR，后 16 字节与密文一起计算 Keccak-256 MAC
+func encryptKeystore(privateKey []byte, password string) ([]byte, error) {
+	key, err := crypto.ToECDSA(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid secp256k1 private key")
+	}
+
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(password), salt, keystoreScryptN, keystoreScryptR, keystoreScryptP, keystoreScryptDKLen)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive keystore key")
+	}
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, err
+	}
+	ciphertext := make([]byte, len(privateKey))
+	cipher.NewCTR(block, iv).XORKeyStream(ciphertext, privateKey)
+	mac := crypto.Keccak256(derivedKey[16:32], ciphertext)
+
+	return json.Marshal(&keystoreJSON{
+		Address: hex.EncodeToString(crypto.PubkeyToAddress(key.PublicKey).Bytes()),
+		Crypto: keystoreCrypto{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(ciphertext),
+			CipherParams: keystoreCipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+			KDFParams: map[string]interface{}{
+				"dklen": keystoreScryptDKLen,
+				"n":     keystoreScryptN,
+				"p":     keystoreScryptP,
+				"r":     keystoreScryptR,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(mac),
+		},
+		ID:      uuid.New().String(),
+		Version: 3,
+	})
+}
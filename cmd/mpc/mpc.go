@@ -0,0 +1,33 @@
+package mpc
+
+import (
+	"os"
+
+	"github.com/kashguard/go-mpc-wallet/internal/util/command"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outFlag string = "out"
+)
+
+func New() *cobra.Command {
+	return command.NewSubcommandGroup("mpc",
+		newRecover(),
+		newRecoveryKeygen(),
+		newVerifyAudit(),
+	)
+}
+
+// writeSecretFile 以 0600 权限写入新文件，不覆盖已有文件（私钥、恢复私钥等敏感输出）
+func writeSecretFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,214 @@
+package mpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	formatHex      string = "hex"
+	formatWIF      string = "wif"
+	formatKeystore string = "keystore"
+
+	networkMainnet string = "mainnet"
+	networkTestnet string = "testnet"
+)
+
+type RecoverFlags struct {
+	PublicKey            string
+	RecoveryKeyFile      string
+	Format               string
+	Network              string
+	KeystorePasswordFile string
+	Out                  string
+}
+
+func newRecover() *cobra.Command {
+	var flags RecoverFlags
+
+	cmd := &cobra.Command{
+		Use:   "recover [flags] <backup.json>...",
+		Short: "Reconstructs a private key from share backups (break-glass)",
+		Long: `Reconstructs a private key from threshold-many share backups
+
+		Break-glass disaster recovery, see docs/mpc-disaster-recovery.md.
+		Runs fully offline and needs neither the database nor the
+		other nodes. Each argument is one node's share backup:
+		either the encrypted backup exported from key_share_backups
+		(decrypted with --recovery-key-file) or an already decrypted
+		plaintext.
+
+		The shares are interpolated with the Lagrange coefficients of
+		their share identifiers (tss-lib ShareID, FROST/CGGMP21
+		identifier) and the result is checked against --public-key.
+		Nothing is written if the reconstructed key does not match.
+
+		WIF and keystore output are only available for secp256k1 keys;
+		ed25519 keys are printed as a hex scalar (little-endian, not an
+		RFC 8032 seed).`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			recoverCmdFunc(flags, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.PublicKey, "public-key", "", "Expected group public key (hex) as stored in the key metadata (required).")
+	cmd.Flags().StringVar(&flags.RecoveryKeyFile, "recovery-key-file", "", "File containing the X25519 recovery private key (hex), needed for encrypted backups.")
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", formatHex, "Output format: hex, wif or keystore.")
+	cmd.Flags().StringVar(&flags.Network, "network", networkMainnet, "Network of the WIF output: mainnet or testnet.")
+	cmd.Flags().StringVar(&flags.KeystorePasswordFile, "keystore-password-file", "", "File containing the password of the keystore output.")
+	cmd.Flags().StringVarP(&flags.Out, outFlag, "o", "", "File to write the private key to (mode 0600). Defaults to stdout.")
+	_ = cmd.MarkFlagRequired("public-key")
+
+	return cmd
+}
+
+func recoverCmdFunc(flags RecoverFlags, files []string) {
+	recovered, output, err := runRecover(flags, files)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to recover private key")
+	}
+
+	log.Warn().
+		Str("key_id", recovered.KeyID).
+		Int("share_epoch", recovered.ShareEpoch).
+		Str("curve", recovered.Curve).
+		Strs("node_ids", recovered.NodeIDs).
+		Str("public_key", hex.EncodeToString(recovered.PublicKey)).
+		Msg("Private key reconstructed, move the funds to a new key and destroy this copy")
+
+	if flags.Out != "" {
+		if err := writeSecretFile(flags.Out, output); err != nil {
+			log.Fatal().Err(err).Msg("Failed to write private key")
+		}
+		return
+	}
+
+	//nolint:forbidigo
+	fmt.Print(string(output))
+}
+
+func runRecover(flags RecoverFlags, files []string) (*protocol.RecoveredKey, []byte, error) {
+	publicKey, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(flags.PublicKey), "0x"))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid public key")
+	}
+
+	var recoveryKey []byte
+	if flags.RecoveryKeyFile != "" {
+		recoveryKey, err = readHexFile(flags.RecoveryKeyFile)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to read recovery private key")
+		}
+	}
+
+	plaintexts := make([]*protocol.ShareBackupPlaintext, 0, len(files))
+	for _, file := range files {
+		plaintext, err := readShareBackup(file, recoveryKey)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read share backup %s", file)
+		}
+		plaintexts = append(plaintexts, plaintext)
+	}
+
+	recovered, err := protocol.RecoverPrivateKey(plaintexts, publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	output, err := formatPrivateKey(recovered, flags)
+	if err != nil {
+		return nil, nil, err
+	}
+	return recovered, output, nil
+}
+
+// readShareBackup 读取单个节点的备份：带密文的加密备份先校验证明再解密，否则按解密后的明文处理
+func readShareBackup(file string, recoveryKey []byte) (*protocol.ShareBackupPlaintext, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var backup protocol.ShareBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, errors.Wrap(err, "invalid backup json")
+	}
+	if len(backup.Ciphertext) == 0 {
+		var plaintext protocol.ShareBackupPlaintext
+		if err := json.Unmarshal(data, &plaintext); err != nil {
+			return nil, errors.Wrap(err, "invalid backup json")
+		}
+		if len(plaintext.SecretShare) == 0 {
+			return nil, errors.New("neither an encrypted backup nor a decrypted share")
+		}
+		return &plaintext, nil
+	}
+
+	if recoveryKey == nil {
+		return nil, errors.New("encrypted backup requires --recovery-key-file")
+	}
+	if err := backup.Verify(); err != nil {
+		return nil, err
+	}
+	return backup.Decrypt(recoveryKey)
+}
+
+// formatPrivateKey 按 --format 编码私钥，输出以换行结尾
+func formatPrivateKey(recovered *protocol.RecoveredKey, flags RecoverFlags) ([]byte, error) {
+	switch flags.Format {
+	case formatHex:
+		return []byte(hex.EncodeToString(recovered.PrivateKey) + "\n"), nil
+	case formatWIF:
+		if recovered.Curve != "secp256k1" {
+			return nil, errors.Errorf("wif output is not supported for %s keys", recovered.Curve)
+		}
+		var version byte
+		switch flags.Network {
+		case networkMainnet:
+			version = 0x80
+		case networkTestnet:
+			version = 0xef
+		default:
+			return nil, errors.Errorf("unsupported network %q", flags.Network)
+		}
+		// 私钥 || 0x01：对应压缩公钥
+		payload := append(append([]byte{}, recovered.PrivateKey...), 0x01)
+		return []byte(base58.CheckEncode(payload, version) + "\n"), nil
+	case formatKeystore:
+		if recovered.Curve != "secp256k1" {
+			return nil, errors.Errorf("keystore output is not supported for %s keys", recovered.Curve)
+		}
+		if flags.KeystorePasswordFile == "" {
+			return nil, errors.New("keystore output requires --keystore-password-file")
+		}
+		password, err := os.ReadFile(flags.KeystorePasswordFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read keystore password")
+		}
+		encrypted, err := encryptKeystore(recovered.PrivateKey, strings.TrimRight(string(password), "\r\n"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encrypt keystore")
+		}
+		return append(encrypted, '\n'), nil
+	default:
+		return nil, errors.Errorf("unsupported format %q", flags.Format)
+	}
+}
+
+func readHexFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+}
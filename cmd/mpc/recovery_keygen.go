@@ -0,0 +1,54 @@
+package mpc
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+type RecoveryKeygenFlags struct {
+	Out string
+}
+
+func newRecoveryKeygen() *cobra.Command {
+	var flags RecoveryKeygenFlags
+
+	cmd := &cobra.Command{
+		Use:   "recovery-keygen",
+		Short: "Generates an offline recovery key pair for share backups",
+		Long: `Generates an X25519 recovery key pair for share backups
+
+		Run this on an air-gapped machine. The private key (hex)
+		is written to --out with mode 0600 and must never leave
+		the offline environment; the public key (hex) is printed
+		to stdout and is configured on every node through
+		MPC_BACKUP_RECOVERY_PUBLIC_KEY.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			recoveryKeygenCmdFunc(flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.Out, outFlag, "o", "", "File to write the recovery private key to (required).")
+	_ = cmd.MarkFlagRequired(outFlag)
+
+	return cmd
+}
+
+func recoveryKeygenCmdFunc(flags RecoveryKeygenFlags) {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to generate recovery key")
+	}
+
+	if err := writeSecretFile(flags.Out, []byte(hex.EncodeToString(priv.Bytes())+"\n")); err != nil {
+		log.Fatal().Err(err).Msg("Failed to write recovery private key")
+	}
+
+	//nolint:forbidigo
+	fmt.Println(hex.EncodeToString(priv.PublicKey().Bytes()))
+}
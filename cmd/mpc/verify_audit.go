@@ -0,0 +1,61 @@
+package mpc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/kashguard/go-mpc-wallet/internal/config"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func newVerifyAudit() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify-audit",
+		Short: "Verifies the hash chain of the MPC audit log",
+		Long: `Verifies the hash chain of the MPC audit log
+
+		Walks audit_logs in id order and recomputes the hash of
+		every entry. Reports the first entry whose content was
+		modified or whose predecessor was deleted or replaced,
+		and exits with status 1 if the chain is broken.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			verifyAuditCmdFunc()
+		},
+	}
+}
+
+func verifyAuditCmdFunc() {
+	result, err := verifyAuditChain(context.Background(), config.DefaultServiceConfigFromEnv())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to verify audit log")
+	}
+
+	if !result.Valid() {
+		//nolint:forbidigo
+		fmt.Printf("audit log chain broken at entry %d: %s (%d entries checked)\n", result.BrokenID, result.Reason, result.Checked)
+		os.Exit(1)
+	}
+
+	//nolint:forbidigo
+	fmt.Printf("audit log chain intact (%d entries checked)\n", result.Checked)
+}
+
+func verifyAuditChain(ctx context.Context, serviceConfig config.Server) (*audit.VerifyResult, error) {
+	db, err := sql.Open("postgres", serviceConfig.Database.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the database: %w", err)
+	}
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ping the database: %w", err)
+	}
+
+	return audit.Verify(ctx, storage.NewPostgreSQLStore(db))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kashguard/go-mpc-wallet/cmd/db"
 	"github.com/kashguard/go-mpc-wallet/cmd/env"
+	"github.com/kashguard/go-mpc-wallet/cmd/mpc"
 	"github.com/kashguard/go-mpc-wallet/cmd/probe"
 	"github.com/kashguard/go-mpc-wallet/cmd/server"
 	"github.com/kashguard/go-mpc-wallet/internal/config"
@@ -33,6 +34,7 @@ func Execute() {
 	rootCmd.AddCommand(
 		db.New(),
 		env.New(),
+		mpc.New(),
 		probe.New(),
 		server.New(),
 	)
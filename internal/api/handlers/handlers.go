@@ -5,10 +5,13 @@ import (
 	"github.com/kashguard/go-mpc-wallet/internal/api"
 	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/auth"
 	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/common"
+	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/mpc/audit"
 	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/mpc/keys"
 	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/mpc/nodes"
+	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/mpc/policies"
 	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/mpc/sessions"
 	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/mpc/signing"
+	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/mpc/webhooks"
 	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/push"
 	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/wellknown"
 	"github.com/labstack/echo/v4"
@@ -28,26 +31,51 @@ func AttachAllRoutes(s *api.Server) {
 		auth.PostLogoutRoute(s),
 		auth.PostRefreshRoute(s),
 		auth.PostRegisterRoute(s),
+		audit.GetListAuditLogsRoute(s),
 		common.GetHealthyRoute(s),
 		common.GetReadyRoute(s),
 		common.GetSwaggerRoute(s),
 		common.GetVersionRoute(s),
 		keys.DeleteKeyRoute(s),
+		keys.GetDeriveKeyRoute(s),
+		keys.GetKeyApproversRoute(s),
+		keys.GetKeyBackupsRoute(s),
+		keys.GetKeyRefreshesRoute(s),
 		keys.GetKeyRoute(s),
+		keys.GetKeyValidationRoute(s),
 		keys.GetListKeysRoute(s),
+		keys.PostCancelKeyDeletionRoute(s),
 		keys.PostCreateKeyRoute(s),
+		keys.PostDisableKeyRoute(s),
+		keys.PostEnableKeyRoute(s),
 		keys.PostGenerateAddressRoute(s),
+		keys.PostKeyBackupsRoute(s),
+		keys.PostKeyValidationRoute(s),
+		keys.PutKeyApproversRoute(s),
 		nodes.GetListNodesRoute(s),
 		nodes.GetNodeHealthRoute(s),
 		nodes.GetNodeRoute(s),
 		nodes.PostRegisterNodeRoute(s),
+		policies.DeletePolicyRoute(s),
+		policies.GetListPoliciesRoute(s),
+		policies.PostCreatePolicyRoute(s),
 		sessions.GetSessionRoute(s),
 		sessions.PostCancelSessionRoute(s),
 		sessions.PostCreateSessionRoute(s),
 		sessions.PostJoinSessionRoute(s),
+		signing.GetApprovalRoute(s),
+		signing.GetListApprovalsRoute(s),
+		signing.GetSigningRequestRoute(s),
+		signing.PostApproveApprovalRoute(s),
 		signing.PostBatchSignRoute(s),
+		signing.PostRejectApprovalRoute(s),
 		signing.PostSignRoute(s),
 		signing.PostVerifyRoute(s),
+		webhooks.DeleteWebhookRoute(s),
+		webhooks.GetListWebhookDeliveriesRoute(s),
+		webhooks.GetListWebhooksRoute(s),
+		webhooks.PostCreateWebhookRoute(s),
+		webhooks.PostRedeliverWebhookDeliveryRoute(s),
 		push.PutUpdatePushTokenRoute(s),
 		wellknown.GetAndroidDigitalAssetLinksRoute(s),
 		wellknown.GetAppleAppSiteAssociationRoute(s),
@@ -0,0 +1,185 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/types/m_p_c_audit"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+// 导出格式
+const (
+	formatJSONL = "jsonl"
+	formatCSV   = "csv"
+)
+
+// csvHeader CSV 导出的列，与 auditLogEntry 的字段一致
+var csvHeader = []string{
+	"id", "timestamp", "event_type", "operation", "result", "user_id", "key_id",
+	"node_id", "session_id", "ip_address", "details", "prev_hash", "hash",
+}
+
+func GetListAuditLogsRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPCAudit.GET("", getListAuditLogsHandler(s))
+}
+
+func getListAuditLogsHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		params := m_p_c_audit.NewGetMpcAuditLogsParams()
+		if err := util.BindAndValidateQueryParams(c, &params); err != nil {
+			return err
+		}
+
+		filter := &storage.AuditLogFilter{
+			KeyID:     swag.StringValue(params.KeyID),
+			NodeID:    swag.StringValue(params.NodeID),
+			SessionID: swag.StringValue(params.SessionID),
+			UserID:    swag.StringValue(params.UserID),
+			EventType: swag.StringValue(params.EventType),
+			Result:    swag.StringValue(params.Result),
+		}
+		if params.From != nil {
+			filter.From = time.Time(*params.From)
+		}
+		if params.To != nil {
+			filter.To = time.Time(*params.To)
+		}
+		if cursor := swag.StringValue(params.Cursor); cursor != "" {
+			afterID, err := strconv.ParseInt(cursor, 10, 64)
+			if err != nil || afterID < 0 {
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "Invalid cursor")
+			}
+			filter.AfterID = afterID
+		}
+
+		switch swag.StringValue(params.Format) {
+		case formatJSONL, formatCSV:
+			return exportAuditLogs(c, s, filter, swag.StringValue(params.Format))
+		}
+
+		// 多取一条判断是否还有下一页
+		limit := int(swag.Int64Value(params.Limit))
+		filter.Limit = limit + 1
+		entries, err := s.AuditLogger.List(ctx, filter)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list audit logs")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to list audit logs")
+		}
+
+		response := &types.ListAuditLogsResponse{
+			Entries: make([]*types.AuditLogEntry, 0, len(entries)),
+		}
+		if len(entries) > limit {
+			entries = entries[:limit]
+			response.NextCursor = strconv.FormatInt(entries[limit-1].ID, 10)
+		}
+		for _, entry := range entries {
+			response.Entries = append(response.Entries, convertAuditLogEntry(entry))
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, response)
+	}
+}
+
+// exportAuditLogs 以 JSON Lines 或 CSV 流式导出匹配的全部审计日志，每批写入后刷新。
+// 响应开始后出错只能中断输出，错误记录在日志中
+func exportAuditLogs(c echo.Context, s *api.Server, filter *storage.AuditLogFilter, format string) error {
+	ctx := c.Request().Context()
+	log := util.LogFromContext(ctx)
+
+	res := c.Response()
+	contentType := "application/x-ndjson"
+	if format == formatCSV {
+		contentType = "text/csv; charset=UTF-8"
+	}
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit-logs.`+format+`"`)
+	res.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(res)
+	csvWriter := csv.NewWriter(res)
+	if format == formatCSV {
+		if err := csvWriter.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+
+	err := s.AuditLogger.Stream(ctx, filter, func(entries []*storage.AuditLog) error {
+		for _, entry := range entries {
+			var err error
+			if format == formatCSV {
+				err = csvWriter.Write(csvRecord(entry))
+			} else {
+				err = encoder.Encode(convertAuditLogEntry(entry))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("format", format).Msg("Failed to export audit logs")
+	}
+
+	return nil
+}
+
+func convertAuditLogEntry(entry *storage.AuditLog) *types.AuditLogEntry {
+	timestamp := strfmt.DateTime(entry.Timestamp)
+	response := &types.AuditLogEntry{
+		ID:        swag.Int64(entry.ID),
+		Timestamp: &timestamp,
+		EventType: swag.String(entry.EventType),
+		Operation: swag.String(entry.Operation),
+		Result:    swag.String(entry.Result),
+		UserID:    entry.UserID,
+		KeyID:     entry.KeyID,
+		NodeID:    entry.NodeID,
+		SessionID: entry.SessionID,
+		IPAddress: entry.IPAddress,
+		PrevHash:  swag.String(entry.PrevHash),
+		Hash:      swag.String(entry.Hash),
+	}
+	if len(entry.Details) > 0 {
+		response.Details = json.RawMessage(entry.Details)
+	}
+	return response
+}
+
+func csvRecord(entry *storage.AuditLog) []string {
+	return []string{
+		strconv.FormatInt(entry.ID, 10),
+		entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		entry.EventType,
+		entry.Operation,
+		entry.Result,
+		entry.UserID,
+		entry.KeyID,
+		entry.NodeID,
+		entry.SessionID,
+		entry.IPAddress,
+		string(entry.Details),
+		entry.PrevHash,
+		entry.Hash,
+	}
+}
@@ -1,11 +1,14 @@
 package keys
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/kashguard/go-mpc-wallet/internal/api"
 	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
 	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/types/m_p_c_keys"
 	"github.com/kashguard/go-mpc-wallet/internal/util"
 	"github.com/labstack/echo/v4"
 )
@@ -19,16 +22,39 @@ func deleteKeyHandler(s *api.Server) echo.HandlerFunc {
 		ctx := c.Request().Context()
 		log := util.LogFromContext(ctx)
 
-		keyID := c.Param("keyId")
-		if keyID == "" {
+		params := m_p_c_keys.NewDeleteMpcKeyParams()
+		if err := util.BindAndValidatePathAndQueryParams(c, &params); err != nil {
+			return err
+		}
+
+		if params.KeyID == "" {
 			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "key_id is required")
 		}
 
-		if err := s.KeyService.DeleteKey(ctx, keyID); err != nil {
-			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to delete key")
-			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to delete key")
+		keyMetadata, err := s.KeyService.ScheduleKeyDeletion(ctx, params.KeyID, int(*params.PendingWindowDays))
+		if err != nil {
+			if httpErr := keyLifecycleError(err); httpErr != nil {
+				log.Debug().Err(err).Str("key_id", params.KeyID).Msg("Key deletion refused")
+				return httpErr
+			}
+			log.Error().Err(err).Str("key_id", params.KeyID).Msg("Failed to schedule key deletion")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to schedule key deletion")
 		}
 
-		return c.NoContent(http.StatusOK)
+		return util.ValidateAndReturn(c, http.StatusOK, convertKeyResponse(keyMetadata))
+	}
+}
+
+// keyLifecycleError 将密钥状态变更的错误转换为 HTTP 错误
+func keyLifecycleError(err error) *httperrors.HTTPError {
+	switch {
+	case errors.Is(err, key.ErrKeyNotFound):
+		return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key not found")
+	case errors.Is(err, key.ErrInvalidPendingWindow):
+		return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+	case errors.Is(err, key.ErrInvalidKeyState):
+		return httperrors.NewHTTPError(http.StatusConflict, types.PublicHTTPErrorTypeGeneric, err.Error())
+	default:
+		return nil
 	}
 }
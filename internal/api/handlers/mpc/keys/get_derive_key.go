@@ -0,0 +1,56 @@
+package keys
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetDeriveKeyRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/keys/:keyId/derive", getDeriveKeyHandler(s))
+}
+
+func getDeriveKeyHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		keyID := c.Param("keyId")
+		if keyID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "key_id is required")
+		}
+
+		path := c.QueryParam("path")
+		if path == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "path is required")
+		}
+		if _, err := protocol.ParseDerivationPath(path); err != nil {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+		}
+
+		chainType := c.QueryParam("chain_type")
+
+		derived, err := s.KeyService.DeriveChildKey(ctx, keyID, path, chainType)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Str("path", path).Str("chain_type", chainType).Msg("Failed to derive child key")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to derive child key")
+		}
+
+		response := &types.DeriveKeyResponse{
+			KeyID:             swag.String(derived.KeyID),
+			Path:              swag.String(derived.Path),
+			PublicKey:         swag.String(derived.PublicKey),
+			ExtendedPublicKey: swag.String(derived.ExtendedPublicKey),
+			ChainType:         derived.ChainType,
+			Address:           derived.Address,
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, response)
+	}
+}
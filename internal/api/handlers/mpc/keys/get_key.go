@@ -7,6 +7,7 @@ import (
 	"github.com/go-openapi/swag"
 	"github.com/kashguard/go-mpc-wallet/internal/api"
 	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
 	"github.com/kashguard/go-mpc-wallet/internal/types"
 	"github.com/kashguard/go-mpc-wallet/internal/util"
 	"github.com/labstack/echo/v4"
@@ -32,23 +33,27 @@ func getKeyHandler(s *api.Server) echo.HandlerFunc {
 			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key not found")
 		}
 
-		response := &types.GetKeyResponse{
-			KeyID:       swag.String(keyMetadata.KeyID),
-			PublicKey:   swag.String(keyMetadata.PublicKey),
-			Algorithm:   swag.String(keyMetadata.Algorithm),
-			Curve:       swag.String(keyMetadata.Curve),
-			Threshold:   util.IntPtrToInt64Ptr(&keyMetadata.Threshold),
-			TotalNodes:  util.IntPtrToInt64Ptr(&keyMetadata.TotalNodes),
-			ChainType:   swag.String(keyMetadata.ChainType),
-			Address:     keyMetadata.Address,
-			Status:      swag.String(keyMetadata.Status),
-			Description: keyMetadata.Description,
-			Tags:        convertTagsToTypes(keyMetadata.Tags),
-			CreatedAt:   strfmt.DateTime(keyMetadata.CreatedAt),
-			UpdatedAt:   strfmt.DateTime(keyMetadata.UpdatedAt),
-		}
+		return util.ValidateAndReturn(c, http.StatusOK, convertKeyResponse(keyMetadata))
+	}
+}
 
-		return util.ValidateAndReturn(c, http.StatusOK, response)
+// convertKeyResponse 转换密钥元数据为 API 响应
+func convertKeyResponse(keyMetadata *key.KeyMetadata) *types.GetKeyResponse {
+	return &types.GetKeyResponse{
+		KeyID:        swag.String(keyMetadata.KeyID),
+		PublicKey:    swag.String(keyMetadata.PublicKey),
+		Algorithm:    swag.String(keyMetadata.Algorithm),
+		Curve:        swag.String(keyMetadata.Curve),
+		Threshold:    util.IntPtrToInt64Ptr(&keyMetadata.Threshold),
+		TotalNodes:   util.IntPtrToInt64Ptr(&keyMetadata.TotalNodes),
+		ChainType:    swag.String(keyMetadata.ChainType),
+		Address:      keyMetadata.Address,
+		Status:       swag.String(keyMetadata.Status),
+		Description:  keyMetadata.Description,
+		Tags:         convertTagsToTypes(keyMetadata.Tags),
+		CreatedAt:    strfmt.DateTime(keyMetadata.CreatedAt),
+		UpdatedAt:    strfmt.DateTime(keyMetadata.UpdatedAt),
+		DeletionDate: (*strfmt.DateTime)(keyMetadata.DeletionDate),
 	}
 }
 
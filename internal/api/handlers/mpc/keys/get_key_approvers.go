@@ -0,0 +1,52 @@
+package keys
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetKeyApproversRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/keys/:keyId/approvers", getKeyApproversHandler(s))
+}
+
+func getKeyApproversHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		keyID := c.Param("keyId")
+		if keyID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "key_id is required")
+		}
+
+		if _, err := s.KeyService.GetKey(ctx, keyID); err != nil {
+			log.Debug().Err(err).Str("key_id", keyID).Msg("Key not found")
+			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key not found")
+		}
+
+		quorum, err := s.ApprovalService.GetKeyQuorum(ctx, keyID)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to get key approvers")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to get key approvers")
+		}
+		if quorum == nil {
+			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key approvers not configured")
+		}
+
+		response := &types.KeyApproversResponse{
+			KeyID:             swag.String(keyID),
+			Approvers:         quorum.Approvers,
+			RequiredApprovals: swag.Int64(int64(quorum.RequiredApprovals)),
+			UpdatedAt:         strfmt.DateTime(quorum.UpdatedAt),
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,66 @@
+package keys
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetKeyBackupsRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/keys/:keyId/backups", getKeyBackupsHandler(s))
+}
+
+func getKeyBackupsHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		keyID := c.Param("keyId")
+		if keyID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "key_id is required")
+		}
+
+		if _, err := s.KeyService.GetKey(ctx, keyID); err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to get key")
+			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key not found")
+		}
+
+		status, err := s.KeyService.GetKeyBackupStatus(ctx, keyID)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to get key backup status")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to get key backup status")
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, convertKeyBackupStatus(status))
+	}
+}
+
+func convertKeyBackupStatus(status *key.KeyBackupStatus) *types.KeyBackupStatusResponse {
+	backups := make([]*types.KeyShareBackupInfo, 0, len(status.Backups))
+	for _, backup := range status.Backups {
+		createdAt := strfmt.DateTime(backup.CreatedAt)
+		backups = append(backups, &types.KeyShareBackupInfo{
+			NodeID:            swag.String(backup.NodeID),
+			PublicShare:       swag.String(backup.PublicShare),
+			RecoveryPublicKey: swag.String(backup.RecoveryPublicKey),
+			CreatedAt:         &createdAt,
+		})
+	}
+
+	return &types.KeyBackupStatusResponse{
+		KeyID:          swag.String(status.KeyID),
+		ShareEpoch:     util.IntPtrToInt64Ptr(&status.ShareEpoch),
+		Status:         swag.String(status.Status),
+		Recoverable:    swag.Bool(status.Recoverable),
+		Backups:        backups,
+		MissingNodeIds: status.MissingNodeIDs,
+		FailedNodeIds:  status.FailedNodeIDs,
+	}
+}
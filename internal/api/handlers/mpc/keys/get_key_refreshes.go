@@ -0,0 +1,65 @@
+package keys
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetKeyRefreshesRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/keys/:keyId/refreshes", getKeyRefreshesHandler(s))
+}
+
+func getKeyRefreshesHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		keyID := c.Param("keyId")
+		if keyID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "key_id is required")
+		}
+
+		if _, err := s.KeyService.GetKey(ctx, keyID); err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to get key")
+			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key not found")
+		}
+
+		refreshes, err := s.KeyService.ListKeyRefreshes(ctx, keyID)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to list key refreshes")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to list key refreshes")
+		}
+
+		response := &types.KeyRefreshHistoryResponse{
+			KeyID:     swag.String(keyID),
+			Refreshes: make([]*types.KeyRefresh, 0, len(refreshes)),
+		}
+		for _, refresh := range refreshes {
+			response.Refreshes = append(response.Refreshes, convertKeyRefresh(refresh))
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, response)
+	}
+}
+
+func convertKeyRefresh(refresh *key.KeyRefresh) *types.KeyRefresh {
+	startedAt := strfmt.DateTime(refresh.StartedAt)
+	completedAt := strfmt.DateTime(refresh.CompletedAt)
+	return &types.KeyRefresh{
+		RefreshID:   swag.String(refresh.RefreshID),
+		Status:      swag.String(refresh.Status),
+		OldEpoch:    util.IntPtrToInt64Ptr(&refresh.OldEpoch),
+		NewEpoch:    util.IntPtrToInt64Ptr(&refresh.NewEpoch),
+		Error:       refresh.Error,
+		StartedAt:   &startedAt,
+		CompletedAt: &completedAt,
+	}
+}
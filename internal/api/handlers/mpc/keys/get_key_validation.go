@@ -0,0 +1,67 @@
+package keys
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetKeyValidationRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/keys/:keyId/validation", getKeyValidationHandler(s))
+}
+
+func getKeyValidationHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		keyID := c.Param("keyId")
+		if keyID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "key_id is required")
+		}
+
+		if _, err := s.KeyService.GetKey(ctx, keyID); err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to get key")
+			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key not found")
+		}
+
+		validation, err := s.KeyService.GetKeyShareValidation(ctx, keyID)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to get key share validation")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to get key share validation")
+		}
+		if validation == nil {
+			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key shares have not been validated yet")
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, convertKeyShareValidation(validation))
+	}
+}
+
+func convertKeyShareValidation(validation *key.KeyShareValidation) *types.KeyShareValidationResponse {
+	nodes := make([]*types.NodeShareValidation, 0, len(validation.Nodes))
+	for _, node := range validation.Nodes {
+		nodes = append(nodes, &types.NodeShareValidation{
+			NodeID:      swag.String(node.NodeID),
+			Status:      swag.String(node.Status),
+			PublicShare: node.PublicShare,
+			Error:       node.Error,
+		})
+	}
+
+	validatedAt := strfmt.DateTime(validation.ValidatedAt)
+	return &types.KeyShareValidationResponse{
+		KeyID:       swag.String(validation.KeyID),
+		ShareEpoch:  util.IntPtrToInt64Ptr(&validation.ShareEpoch),
+		Status:      swag.String(validation.Status),
+		Nodes:       nodes,
+		ValidatedAt: &validatedAt,
+	}
+}
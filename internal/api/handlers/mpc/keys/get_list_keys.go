@@ -4,8 +4,6 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/go-openapi/strfmt"
-	"github.com/go-openapi/swag"
 	"github.com/kashguard/go-mpc-wallet/internal/api"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
 	"github.com/kashguard/go-mpc-wallet/internal/types"
@@ -52,21 +50,7 @@ func getListKeysHandler(s *api.Server) echo.HandlerFunc {
 
 		responseKeys := make([]*types.GetKeyResponse, len(keys))
 		for i, k := range keys {
-			responseKeys[i] = &types.GetKeyResponse{
-				KeyID:       swag.String(k.KeyID),
-				PublicKey:   swag.String(k.PublicKey),
-				Algorithm:   swag.String(k.Algorithm),
-				Curve:       swag.String(k.Curve),
-				Threshold:   util.IntPtrToInt64Ptr(&k.Threshold),
-				TotalNodes:  util.IntPtrToInt64Ptr(&k.TotalNodes),
-				ChainType:   swag.String(k.ChainType),
-				Address:     k.Address,
-				Status:      swag.String(k.Status),
-				Description: k.Description,
-				Tags:        convertTagsToTypes(k.Tags),
-				CreatedAt:   strfmt.DateTime(k.CreatedAt),
-				UpdatedAt:   strfmt.DateTime(k.UpdatedAt),
-			}
+			responseKeys[i] = convertKeyResponse(k)
 		}
 
 		response := &types.ListKeysResponse{
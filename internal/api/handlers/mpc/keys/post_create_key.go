@@ -35,6 +35,19 @@ func postCreateKeyHandler(s *api.Server) echo.HandlerFunc {
 			return httperrors.NewHTTPError(http.StatusForbidden, types.PublicHTTPErrorTypeGeneric, "Key creation is only allowed on coordinator nodes")
 		}
 
+		// 校验请求的协议与算法是否匹配（为空时使用默认协议）
+		keyProtocol, err := key.ResolveProtocol(swag.StringValue(body.Algorithm), body.Protocol, s.Config.MPC.DefaultProtocol)
+		if err != nil {
+			log.Debug().Err(err).Str("protocol", body.Protocol).Msg("Unsupported protocol for key algorithm")
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+		}
+
+		// 校验链类型支持请求的算法和曲线（例如 ethereum 不接受 ed25519 密钥）
+		if err := s.KeyService.ValidateChainType(swag.StringValue(body.ChainType), swag.StringValue(body.Algorithm), swag.StringValue(body.Curve)); err != nil {
+			log.Debug().Err(err).Str("chain_type", swag.StringValue(body.ChainType)).Msg("Unsupported key type for chain")
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+		}
+
 		// 如果 Coordinator 服务可用，使用 DKG 会话管理
 		var keyMetadata *key.KeyMetadata
 
@@ -64,6 +77,7 @@ func postCreateKeyHandler(s *api.Server) echo.HandlerFunc {
 				Threshold:   int(swag.Int64Value(body.Threshold)),
 				TotalNodes:  int(swag.Int64Value(body.TotalNodes)),
 				ChainType:   swag.StringValue(body.ChainType),
+				Protocol:    keyProtocol,
 				Description: body.Description,
 				Tags:        convertTags(body.Tags),
 			}
@@ -81,7 +95,7 @@ func postCreateKeyHandler(s *api.Server) echo.HandlerFunc {
 				KeyID:      keyID,
 				Algorithm:  swag.StringValue(body.Algorithm),
 				Curve:      swag.StringValue(body.Curve),
-				Protocol:   "", // 使用默认协议
+				Protocol:   placeholderKey.Protocol,
 				Threshold:  int(swag.Int64Value(body.Threshold)),
 				TotalNodes: int(swag.Int64Value(body.TotalNodes)),
 				NodeIDs:    []string{}, // 自动发现节点
@@ -114,7 +128,7 @@ func postCreateKeyHandler(s *api.Server) echo.HandlerFunc {
 			// 3. DKG 完成后更新 session 状态
 
 			// 返回 Pending 状态的占位符密钥
-			// DKG 完成后，密钥状态会异步更新为 Active
+			// DKG 完成后，密钥状态会异步更新为 Enabled
 			keyMetadata = placeholderKey
 
 			log.Info().
@@ -122,9 +136,6 @@ func postCreateKeyHandler(s *api.Server) echo.HandlerFunc {
 				Str("status", keyMetadata.Status).
 				Strs("participants", dkgSession.ParticipatingNodes).
 				Msg("Returning placeholder key - DKG will be executed by participants asynchronously")
-
-			// 为了满足响应校验（Status 枚举不包含 Pending），响应中先返回 Inactive
-			keyMetadata.Status = "Inactive"
 		} else {
 			// 如果没有 Coordinator 服务，使用原有的方式（直接执行 DKG，不使用会话管理）
 			req := &key.CreateKeyRequest{
@@ -133,11 +144,11 @@ func postCreateKeyHandler(s *api.Server) echo.HandlerFunc {
 				Threshold:   int(swag.Int64Value(body.Threshold)),
 				TotalNodes:  int(swag.Int64Value(body.TotalNodes)),
 				ChainType:   swag.StringValue(body.ChainType),
+				Protocol:    keyProtocol,
 				Description: body.Description,
 				Tags:        convertTags(body.Tags),
 			}
 
-			var err error
 			keyMetadata, err = s.KeyService.CreateKey(ctx, req)
 			if err != nil {
 				log.Error().Err(err).Msg("Failed to create key")
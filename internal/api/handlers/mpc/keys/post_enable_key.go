@@ -0,0 +1,39 @@
+package keys
+
+import (
+	"net/http"
+
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func PostEnableKeyRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.POST("/keys/:keyId/enable", postEnableKeyHandler(s))
+}
+
+func postEnableKeyHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		keyID := c.Param("keyId")
+		if keyID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "key_id is required")
+		}
+
+		keyMetadata, err := s.KeyService.EnableKey(ctx, keyID)
+		if err != nil {
+			if httpErr := keyLifecycleError(err); httpErr != nil {
+				log.Debug().Err(err).Str("key_id", keyID).Msg("Key enable refused")
+				return httpErr
+			}
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to enable key")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to enable key")
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, convertKeyResponse(keyMetadata))
+	}
+}
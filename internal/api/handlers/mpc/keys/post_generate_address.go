@@ -3,8 +3,11 @@ package keys
 import (
 	"net/http"
 
+	"github.com/go-openapi/swag"
 	"github.com/kashguard/go-mpc-wallet/internal/api"
 	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
 	"github.com/kashguard/go-mpc-wallet/internal/types"
 	"github.com/kashguard/go-mpc-wallet/internal/util"
 	"github.com/labstack/echo/v4"
@@ -29,16 +32,33 @@ func postGenerateAddressHandler(s *api.Server) echo.HandlerFunc {
 			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "chain_type is required")
 		}
 
-		address, err := s.KeyService.GenerateAddress(ctx, keyID, chainType)
+		opts := &key.AddressOptions{
+			AddressType: c.QueryParam("address_type"),
+			Network:     c.QueryParam("network"),
+		}
+		if opts.AddressType != "" {
+			if err := chain.ValidateBitcoinAddressType(opts.AddressType); err != nil {
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+			}
+		}
+		if opts.Network != "" {
+			if _, err := chain.BitcoinNetworkParams(opts.Network); err != nil {
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+			}
+		}
+
+		address, err := s.KeyService.GenerateAddress(ctx, keyID, chainType, opts)
 		if err != nil {
 			log.Error().Err(err).Str("key_id", keyID).Str("chain_type", chainType).Msg("Failed to generate address")
 			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to generate address")
 		}
 
 		response := &types.GenerateAddressResponse{
-			KeyID:     &keyID,
-			ChainType: &chainType,
-			Address:   &address,
+			KeyID:       swag.String(address.KeyID),
+			ChainType:   swag.String(address.ChainType),
+			Address:     swag.String(address.Address),
+			AddressType: address.AddressType,
+			Network:     address.Network,
 		}
 
 		return util.ValidateAndReturn(c, http.StatusOK, response)
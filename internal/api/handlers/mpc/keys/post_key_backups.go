@@ -0,0 +1,40 @@
+package keys
+
+import (
+	"net/http"
+
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func PostKeyBackupsRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.POST("/keys/:keyId/backups", postKeyBackupsHandler(s))
+}
+
+func postKeyBackupsHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		keyID := c.Param("keyId")
+		if keyID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "key_id is required")
+		}
+
+		if _, err := s.KeyService.GetKey(ctx, keyID); err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to get key")
+			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key not found")
+		}
+
+		status, err := s.KeyService.BackupKeyShares(ctx, keyID)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to collect key share backups")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to collect key share backups")
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, convertKeyBackupStatus(status))
+	}
+}
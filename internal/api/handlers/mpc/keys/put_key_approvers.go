@@ -0,0 +1,80 @@
+package keys
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/models"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/approval"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func PutKeyApproversRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPCKeyApprovers.PUT("", putKeyApproversHandler(s))
+}
+
+func putKeyApproversHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		keyID := c.Param("keyId")
+		if keyID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "key_id is required")
+		}
+
+		var body types.PutKeyApproversPayload
+		if err := util.BindAndValidateBody(c, &body); err != nil {
+			return err
+		}
+
+		quorum := &approval.Quorum{
+			Approvers:         body.Approvers,
+			RequiredApprovals: int(swag.Int64Value(body.RequiredApprovals)),
+		}
+		if err := policy.ValidateQuorum(quorum.Approvers, quorum.RequiredApprovals); err != nil {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+		}
+
+		if _, err := s.KeyService.GetKey(ctx, keyID); err != nil {
+			log.Debug().Err(err).Str("key_id", keyID).Msg("Key not found")
+			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Key not found")
+		}
+
+		// 审批人必须是已注册的用户
+		for _, approverID := range quorum.Approvers {
+			exists, err := models.UserExists(ctx, s.DB, approverID)
+			if err != nil || !exists {
+				log.Debug().Err(err).Str("approver_id", approverID).Msg("Approver not found")
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "Approver "+approverID+" not found")
+			}
+		}
+
+		saved, err := s.ApprovalService.SetKeyQuorum(ctx, keyID, quorum)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to set key approvers")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to set key approvers")
+		}
+
+		log.Info().
+			Str("key_id", keyID).
+			Strs("approvers", saved.Approvers).
+			Int("required_approvals", saved.RequiredApprovals).
+			Msg("Key approvers updated")
+
+		response := &types.KeyApproversResponse{
+			KeyID:             swag.String(keyID),
+			Approvers:         saved.Approvers,
+			RequiredApprovals: swag.Int64(int64(saved.RequiredApprovals)),
+			UpdatedAt:         strfmt.DateTime(saved.UpdatedAt),
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,39 @@
+package policies
+
+import (
+	"net/http"
+
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func DeletePolicyRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPCPolicies.DELETE("/:ruleId", deletePolicyHandler(s))
+}
+
+func deletePolicyHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		ruleID := c.Param("ruleId")
+		if ruleID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "rule_id is required")
+		}
+
+		if _, err := s.PolicyEngine.GetRule(ctx, ruleID); err != nil {
+			log.Debug().Err(err).Str("rule_id", ruleID).Msg("Failed to get policy rule")
+			return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Policy rule not found")
+		}
+
+		if err := s.PolicyEngine.DeleteRule(ctx, ruleID); err != nil {
+			log.Error().Err(err).Str("rule_id", ruleID).Msg("Failed to delete policy rule")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to delete policy rule")
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
@@ -0,0 +1,42 @@
+package policies
+
+import (
+	"net/http"
+
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetListPoliciesRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPCPolicies.GET("", getListPoliciesHandler(s))
+}
+
+func getListPoliciesHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		keyID := c.QueryParam("key_id")
+
+		rules, err := s.PolicyEngine.ListRules(ctx, keyID)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", keyID).Msg("Failed to list policy rules")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to list policy rules")
+		}
+
+		responseRules := make([]*types.PolicyRuleResponse, len(rules))
+		for i, rule := range rules {
+			responseRules[i] = convertRule(rule)
+		}
+
+		response := &types.ListPolicyRulesResponse{
+			Rules: responseRules,
+			Total: int64(len(rules)),
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,123 @@
+package policies
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func PostCreatePolicyRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPCPolicies.POST("", postCreatePolicyHandler(s))
+}
+
+func postCreatePolicyHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		var body types.PostCreatePolicyPayload
+		if err := util.BindAndValidateBody(c, &body); err != nil {
+			return err
+		}
+
+		rule := &policy.Rule{
+			KeyID:       body.KeyID,
+			RuleType:    swag.StringValue(body.RuleType),
+			Action:      swag.StringValue(body.Action),
+			Params:      convertParams(body.Params),
+			Enabled:     true,
+			Description: body.Description,
+		}
+
+		// 链类型统一为注册表中的规范名称，与签名时的策略请求一致
+		if body.ChainType != "" {
+			spec, err := s.KeyService.Chains().Get(body.ChainType)
+			if err != nil {
+				log.Debug().Err(err).Str("chain_type", body.ChainType).Msg("Unsupported chain type for policy rule")
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+			}
+			rule.ChainType = spec.ChainType
+		}
+
+		if err := policy.ValidateRule(rule); err != nil {
+			log.Debug().Err(err).Str("rule_type", rule.RuleType).Msg("Invalid policy rule")
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+		}
+
+		if rule.KeyID != "" {
+			if _, err := s.KeyService.GetKey(ctx, rule.KeyID); err != nil {
+				log.Debug().Err(err).Str("key_id", rule.KeyID).Msg("Key of policy rule not found")
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "Key not found")
+			}
+		}
+
+		created, err := s.PolicyEngine.CreateRule(ctx, rule)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", rule.KeyID).Msg("Failed to create policy rule")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to create policy rule")
+		}
+
+		return util.ValidateAndReturn(c, http.StatusCreated, convertRule(created))
+	}
+}
+
+func convertParams(params *types.PolicyRuleParams) *policy.RuleParams {
+	if params == nil {
+		return &policy.RuleParams{}
+	}
+	return &policy.RuleParams{
+		Asset:       params.Asset,
+		MaxAmount:   params.MaxAmount,
+		WindowHours: int(params.WindowHours),
+		Addresses:   params.Addresses,
+		Contracts:   params.Contracts,
+		Methods:     params.Methods,
+		Timezone:    params.Timezone,
+		StartTime:   params.StartTime,
+		EndTime:     params.EndTime,
+		Weekdays:    params.Weekdays,
+
+		Approvers:         params.Approvers,
+		RequiredApprovals: int(params.RequiredApprovals),
+	}
+}
+
+func convertRule(rule *policy.Rule) *types.PolicyRuleResponse {
+	params := rule.Params
+	if params == nil {
+		params = &policy.RuleParams{}
+	}
+	return &types.PolicyRuleResponse{
+		RuleID:    swag.String(rule.RuleID),
+		KeyID:     rule.KeyID,
+		ChainType: rule.ChainType,
+		RuleType:  swag.String(rule.RuleType),
+		Action:    swag.String(rule.Action),
+		Params: &types.PolicyRuleParams{
+			Asset:       params.Asset,
+			MaxAmount:   params.MaxAmount,
+			WindowHours: int64(params.WindowHours),
+			Addresses:   params.Addresses,
+			Contracts:   params.Contracts,
+			Methods:     params.Methods,
+			Timezone:    params.Timezone,
+			StartTime:   params.StartTime,
+			EndTime:     params.EndTime,
+			Weekdays:    params.Weekdays,
+
+			Approvers:         params.Approvers,
+			RequiredApprovals: int64(params.RequiredApprovals),
+		},
+		Enabled:     rule.Enabled,
+		Description: rule.Description,
+		CreatedAt:   strfmt.DateTime(rule.CreatedAt),
+		UpdatedAt:   strfmt.DateTime(rule.UpdatedAt),
+	}
+}
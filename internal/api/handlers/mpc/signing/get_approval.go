@@ -0,0 +1,91 @@
+package signing
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/approval"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetApprovalRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/approvals/:approvalId", getApprovalHandler(s))
+}
+
+func getApprovalHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		approvalID := c.Param("approvalId")
+		if approvalID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "approval_id is required")
+		}
+
+		result, err := s.ApprovalService.Get(ctx, approvalID)
+		if err != nil {
+			if errors.Is(err, approval.ErrNotFound) {
+				return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Signing approval not found")
+			}
+			log.Error().Err(err).Str("approval_id", approvalID).Msg("Failed to get signing approval")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to get signing approval")
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, convertApprovalResponse(result))
+	}
+}
+
+// convertApprovalResponse 将签名审批转换为 API 响应，执行成功后包含签名结果
+func convertApprovalResponse(a *approval.Approval) *types.SigningApprovalResponse {
+	response := &types.SigningApprovalResponse{
+		ApprovalID:        swag.String(a.ApprovalID),
+		KeyID:             swag.String(a.KeyID),
+		Status:            swag.String(a.Status),
+		Reason:            a.Reason,
+		RuleID:            a.RuleID,
+		DecisionID:        a.DecisionID,
+		RequestedBy:       a.RequestedBy,
+		Approvers:         a.Approvers,
+		RequiredApprovals: swag.Int64(int64(a.RequiredApprovals)),
+		Approvals:         int64(a.Approvals()),
+		Rejections:        int64(a.Rejections()),
+		Votes:             make([]*types.SigningApprovalVote, len(a.Votes)),
+		Error:             a.Error,
+		ExpiresAt:         (*strfmt.DateTime)(&a.ExpiresAt),
+		CreatedAt:         strfmt.DateTime(a.CreatedAt),
+		UpdatedAt:         strfmt.DateTime(a.UpdatedAt),
+	}
+	for i, vote := range a.Votes {
+		createdAt := strfmt.DateTime(vote.CreatedAt)
+		response.Votes[i] = &types.SigningApprovalVote{
+			ApproverID: swag.String(vote.ApproverID),
+			Vote:       swag.String(vote.Vote),
+			Comment:    vote.Comment,
+			CreatedAt:  &createdAt,
+		}
+	}
+	if a.Result != nil {
+		response.Signature = convertSignResponse(a.Result)
+	}
+	return response
+}
+
+// approvalError 将审批投票的错误转换为 HTTP 错误
+func approvalError(err error) *httperrors.HTTPError {
+	switch {
+	case errors.Is(err, approval.ErrNotFound):
+		return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Signing approval not found")
+	case errors.Is(err, approval.ErrNotApprover), errors.Is(err, approval.ErrSelfApproval):
+		return httperrors.NewHTTPError(http.StatusForbidden, types.PublicHTTPErrorTypeGeneric, err.Error())
+	case errors.Is(err, approval.ErrNotPending), errors.Is(err, approval.ErrAlreadyVoted):
+		return httperrors.NewHTTPError(http.StatusConflict, types.PublicHTTPErrorTypeGeneric, err.Error())
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package signing
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetListApprovalsRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/approvals", getListApprovalsHandler(s))
+}
+
+func getListApprovalsHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		filter := &storage.SigningApprovalFilter{
+			KeyID:  c.QueryParam("key_id"),
+			Status: c.QueryParam("status"),
+			Limit:  50,
+			Offset: 0,
+		}
+
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			if l, err := strconv.Atoi(limitStr); err == nil {
+				filter.Limit = l
+			}
+		}
+
+		if offsetStr := c.QueryParam("offset"); offsetStr != "" {
+			if o, err := strconv.Atoi(offsetStr); err == nil {
+				filter.Offset = o
+			}
+		}
+
+		approvals, err := s.ApprovalService.List(ctx, filter)
+		if err != nil {
+			log.Error().Err(err).Str("key_id", filter.KeyID).Msg("Failed to list signing approvals")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to list signing approvals")
+		}
+
+		responseApprovals := make([]*types.SigningApprovalResponse, len(approvals))
+		for i, a := range approvals {
+			responseApprovals[i] = convertApprovalResponse(a)
+		}
+
+		response := &types.ListSigningApprovalsResponse{
+			Approvals: responseApprovals,
+			Total:     int64(len(approvals)),
+			Limit:     int64(filter.Limit),
+			Offset:    int64(filter.Offset),
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, response)
+	}
+}
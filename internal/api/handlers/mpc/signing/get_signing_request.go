@@ -0,0 +1,58 @@
+package signing
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/signing"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetSigningRequestRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/sign/:sessionId", getSigningRequestHandler(s))
+}
+
+func getSigningRequestHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		sessionID := c.Param("sessionId")
+		if sessionID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "session_id is required")
+		}
+
+		result, err := s.SigningService.GetSigningRequest(ctx, sessionID)
+		if err != nil {
+			if errors.Is(err, signing.ErrSigningRequestNotFound) {
+				return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Signing request not found")
+			}
+			log.Error().Err(err).Str("session_id", sessionID).Msg("Failed to get signing request")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to get signing request")
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, convertSigningRequestResponse(result))
+	}
+}
+
+// convertSigningRequestResponse 将异步签名请求转换为 API 响应，签名完成后包含签名结果
+func convertSigningRequestResponse(r *signing.SigningRequest) *types.SigningRequestResponse {
+	response := &types.SigningRequestResponse{
+		SessionID: swag.String(r.SessionID),
+		KeyID:     swag.String(r.KeyID),
+		Status:    swag.String(r.Status),
+		Error:     r.Error,
+		CreatedAt: (*strfmt.DateTime)(&r.CreatedAt),
+		UpdatedAt: (*strfmt.DateTime)(&r.UpdatedAt),
+	}
+	if r.Result != nil {
+		response.Signature = convertSignResponse(r.Result)
+	}
+	return response
+}
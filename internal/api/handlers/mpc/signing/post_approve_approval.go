@@ -0,0 +1,50 @@
+package signing
+
+import (
+	"net/http"
+
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/auth"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func PostApproveApprovalRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.POST("/approvals/:approvalId/approve", postApproveApprovalHandler(s))
+}
+
+func postApproveApprovalHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		user := auth.UserFromEchoContext(c)
+		if user == nil {
+			return httperrors.NewHTTPError(http.StatusUnauthorized, types.PublicHTTPErrorTypeGeneric, "Authentication required")
+		}
+
+		approvalID := c.Param("approvalId")
+		if approvalID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "approval_id is required")
+		}
+
+		var body types.PostApprovalVotePayload
+		if err := util.BindAndValidateBody(c, &body); err != nil {
+			return err
+		}
+
+		result, err := s.ApprovalService.Approve(ctx, approvalID, user.ID, body.Comment)
+		if err != nil {
+			if httpErr := approvalError(err); httpErr != nil {
+				log.Debug().Err(err).Str("approval_id", approvalID).Str("user_id", user.ID).Msg("Signing approval vote refused")
+				return httpErr
+			}
+			log.Error().Err(err).Str("approval_id", approvalID).Msg("Failed to approve signing request")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to approve signing request")
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, convertApprovalResponse(result))
+	}
+}
@@ -3,9 +3,7 @@ package signing
 import (
 	"encoding/hex"
 	"net/http"
-	"time"
 
-	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/kashguard/go-mpc-wallet/internal/api"
 	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
@@ -40,11 +38,13 @@ func postBatchSignHandler(s *api.Server) echo.HandlerFunc {
 			}
 
 			messages = append(messages, &signing.SignRequest{
-				KeyID:       swag.StringValue(body.KeyID),
-				Message:     messageBytes,
-				MessageHex:  hex.EncodeToString(messageBytes),
-				MessageType: msg.MessageType,
-				ChainType:   body.ChainType,
+				KeyID:           swag.StringValue(body.KeyID),
+				Message:         messageBytes,
+				MessageHex:      hex.EncodeToString(messageBytes),
+				MessageType:     msg.MessageType,
+				ChainType:       body.ChainType,
+				SignatureFormat: body.SignatureFormat,
+				SighashType:     byte(body.SighashType),
 			})
 		}
 
@@ -67,20 +67,7 @@ func postBatchSignHandler(s *api.Server) echo.HandlerFunc {
 		// 构建响应
 		responseSignatures := make([]*types.SignResponse, len(resp.Signatures))
 		for i, sig := range resp.Signatures {
-			responseSignatures[i] = &types.SignResponse{
-				Signature:          swag.String(sig.Signature),
-				KeyID:              swag.String(sig.KeyID),
-				PublicKey:          swag.String(sig.PublicKey),
-				Message:            swag.String(sig.Message),
-				ChainType:          swag.String(sig.ChainType),
-				SessionID:          swag.String(sig.SessionID),
-				ParticipatingNodes: sig.ParticipatingNodes,
-			}
-			if sig.SignedAt != "" {
-				if ts, err := time.Parse(time.RFC3339, sig.SignedAt); err == nil {
-					responseSignatures[i].SignedAt = strfmt.DateTime(ts)
-				}
-			}
+			responseSignatures[i] = convertSignResponse(sig)
 		}
 
 		total := int64(resp.Total)
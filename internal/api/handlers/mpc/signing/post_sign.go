@@ -2,6 +2,7 @@ package signing
 
 import (
 	"encoding/hex"
+	"errors"
 	"net/http"
 	"time"
 
@@ -9,6 +10,12 @@ import (
 	"github.com/go-openapi/swag"
 	"github.com/kashguard/go-mpc-wallet/internal/api"
 	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/auth"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/approval"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/signing"
 	"github.com/kashguard/go-mpc-wallet/internal/types"
 	"github.com/kashguard/go-mpc-wallet/internal/util"
@@ -29,42 +36,146 @@ func postSignHandler(s *api.Server) echo.HandlerFunc {
 			return err
 		}
 
-		var message []byte
-		if body.Message != nil {
-			message = []byte(*body.Message)
-		} else {
+		message := []byte(body.Message)
+		if body.MessageType == signing.MessageTypeTypedData {
+			if body.TypedData == "" {
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "typed_data is required")
+			}
+			typedData, err := chain.ParseTypedData([]byte(body.TypedData))
+			if err != nil {
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+			}
+			if _, err := chain.TypedDataHash(typedData); err != nil {
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+			}
+		} else if len(message) == 0 {
 			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "message is required")
 		}
 
+		if body.DerivationPath != "" {
+			if _, err := protocol.ParseDerivationPath(body.DerivationPath); err != nil {
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+			}
+		}
+
 		req := &signing.SignRequest{
-			KeyID:       swag.StringValue(body.KeyID),
-			Message:     message,
-			MessageHex:  hex.EncodeToString(message),
-			MessageType: body.MessageType,
-			ChainType:   body.ChainType,
+			KeyID:           swag.StringValue(body.KeyID),
+			Message:         message,
+			MessageHex:      hex.EncodeToString(message),
+			MessageType:     body.MessageType,
+			TypedData:       []byte(body.TypedData),
+			ChainType:       body.ChainType,
+			DerivationPath:  body.DerivationPath,
+			SignatureFormat: body.SignatureFormat,
+			SighashType:     byte(body.SighashType),
+			UnsignedTx:      body.UnsignedTx,
+		}
+
+		if body.Async {
+			result, err := s.SigningService.StartThresholdSign(ctx, req)
+			if err != nil {
+				return signError(c, s, req, err)
+			}
+			log.Info().
+				Str("key_id", req.KeyID).
+				Str("session_id", result.SessionID).
+				Msg("Asynchronous signing started")
+			return util.ValidateAndReturn(c, http.StatusAccepted, convertSigningRequestResponse(result))
 		}
 
 		resp, err := s.SigningService.ThresholdSign(ctx, req)
 		if err != nil {
-			log.Error().Err(err).Msg("Failed to sign")
-			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to sign")
+			return signError(c, s, req, err)
 		}
 
-		response := &types.SignResponse{
-			Signature:          swag.String(resp.Signature),
-			KeyID:              swag.String(resp.KeyID),
-			PublicKey:          swag.String(resp.PublicKey),
-			Message:            swag.String(resp.Message),
-			ChainType:          swag.String(resp.ChainType),
-			SessionID:          swag.String(resp.SessionID),
-			ParticipatingNodes: resp.ParticipatingNodes,
-		}
-		if resp.SignedAt != "" {
-			if ts, err := time.Parse(time.RFC3339, resp.SignedAt); err == nil {
-				response.SignedAt = strfmt.DateTime(ts)
-			}
+		return util.ValidateAndReturn(c, http.StatusOK, convertSignResponse(resp))
+	}
+}
+
+// signError 处理签名失败：策略要求审批时提交审批，其他错误转换为 HTTP 错误
+func signError(c echo.Context, s *api.Server, req *signing.SignRequest, err error) error {
+	log := util.LogFromContext(c.Request().Context())
+
+	// 交易策略拒绝签名或要求审批
+	var decisionErr *policy.DecisionError
+	if errors.As(err, &decisionErr) && decisionErr.RequiresApproval() && s.ApprovalService != nil {
+		return submitForApproval(c, s, req, decisionErr.Decision)
+	}
+	if errors.As(err, &decisionErr) {
+		log.Warn().
+			Str("key_id", req.KeyID).
+			Str("decision_id", decisionErr.Decision.DecisionID).
+			Str("decision", decisionErr.Decision.Decision).
+			Str("rule_id", decisionErr.Decision.RuleID).
+			Msg("Signing rejected by policy")
+		return httperrors.NewHTTPError(http.StatusForbidden, types.PublicHTTPErrorTypeGeneric, decisionErr.Error())
+	}
+	// 禁用或等待删除的密钥不能签名
+	if errors.Is(err, key.ErrKeyNotEnabled) {
+		return httperrors.NewHTTPError(http.StatusConflict, types.PublicHTTPErrorTypeGeneric, "Key is not enabled")
+	}
+	log.Error().Err(err).Msg("Failed to sign")
+	return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to sign")
+}
+
+// submitForApproval 策略要求审批时提交签名审批，返回 202；没有配置审批人时拒绝签名
+func submitForApproval(c echo.Context, s *api.Server, req *signing.SignRequest, decision *policy.Decision) error {
+	ctx := c.Request().Context()
+	log := util.LogFromContext(ctx)
+
+	// 发起人不能审批自己的请求，审批必须关联已认证的用户
+	user := auth.UserFromEchoContext(c)
+	if user == nil {
+		return httperrors.NewHTTPError(http.StatusUnauthorized, types.PublicHTTPErrorTypeGeneric, "Authentication required")
+	}
+
+	result, err := s.ApprovalService.Submit(ctx, req, decision, user.ID)
+	if err != nil {
+		if errors.Is(err, approval.ErrNoQuorum) {
+			log.Warn().
+				Str("key_id", req.KeyID).
+				Str("decision_id", decision.DecisionID).
+				Str("rule_id", decision.RuleID).
+				Str("user_id", user.ID).
+				Msg("Signing requires approval but no approvers other than the requester are configured")
+			return httperrors.NewHTTPError(http.StatusForbidden, types.PublicHTTPErrorTypeGeneric, "signing requires approval but no approvers other than the requester are configured: "+decision.Reason)
 		}
+		log.Error().Err(err).Str("key_id", req.KeyID).Msg("Failed to submit signing approval")
+		return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to submit signing approval")
+	}
+
+	log.Info().
+		Str("key_id", req.KeyID).
+		Str("approval_id", result.ApprovalID).
+		Str("decision_id", decision.DecisionID).
+		Msg("Signing request submitted for approval")
+
+	return util.ValidateAndReturn(c, http.StatusAccepted, convertApprovalResponse(result))
+}
 
-		return util.ValidateAndReturn(c, http.StatusOK, response)
+// convertSignResponse 将签名服务响应转换为 API 响应，EdDSA/Schnorr 签名不返回 v 和 recovery_id
+func convertSignResponse(resp *signing.SignResponse) *types.SignResponse {
+	response := &types.SignResponse{
+		Signature:          swag.String(resp.Signature),
+		SignatureFormat:    resp.SignatureFormat,
+		R:                  resp.R,
+		S:                  resp.S,
+		KeyID:              swag.String(resp.KeyID),
+		PublicKey:          swag.String(resp.PublicKey),
+		Message:            swag.String(resp.Message),
+		Digest:             resp.Digest,
+		ChainType:          swag.String(resp.ChainType),
+		SessionID:          swag.String(resp.SessionID),
+		ParticipatingNodes: resp.ParticipatingNodes,
+	}
+	if resp.RecoveryID >= 0 {
+		response.RecoveryID = swag.Int64(int64(resp.RecoveryID))
+		response.V = swag.Int64(int64(resp.V))
+	}
+	if resp.SignedAt != "" {
+		if ts, err := time.Parse(time.RFC3339, resp.SignedAt); err == nil {
+			response.SignedAt = strfmt.DateTime(ts)
+		}
 	}
+	return response
 }
@@ -0,0 +1,44 @@
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/auth"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func DeleteWebhookRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.DELETE("/webhooks/:webhookId", deleteWebhookHandler(s))
+}
+
+func deleteWebhookHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		user := auth.UserFromEchoContext(c)
+		if user == nil {
+			return httperrors.NewHTTPError(http.StatusUnauthorized, types.PublicHTTPErrorTypeGeneric, "Authentication required")
+		}
+
+		webhookID := c.Param("webhookId")
+		if webhookID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "webhook_id is required")
+		}
+
+		if err := s.WebhookService.DeleteEndpoint(ctx, webhookID, user.ID); err != nil {
+			if httpErr := webhookError(err); httpErr != nil {
+				log.Debug().Err(err).Str("webhook_id", webhookID).Msg("Webhook not found")
+				return httpErr
+			}
+			log.Error().Err(err).Str("webhook_id", webhookID).Msg("Failed to delete webhook")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to delete webhook")
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/auth"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/webhook"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetListWebhookDeliveriesRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/webhooks/:webhookId/deliveries", getListWebhookDeliveriesHandler(s))
+}
+
+func getListWebhookDeliveriesHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		user := auth.UserFromEchoContext(c)
+		if user == nil {
+			return httperrors.NewHTTPError(http.StatusUnauthorized, types.PublicHTTPErrorTypeGeneric, "Authentication required")
+		}
+
+		filter := &storage.WebhookDeliveryFilter{
+			WebhookID: c.Param("webhookId"),
+			Status:    c.QueryParam("status"),
+			Limit:     50,
+			Offset:    0,
+		}
+		if filter.WebhookID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "webhook_id is required")
+		}
+
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			if l, err := strconv.Atoi(limitStr); err == nil {
+				filter.Limit = l
+			}
+		}
+
+		if offsetStr := c.QueryParam("offset"); offsetStr != "" {
+			if o, err := strconv.Atoi(offsetStr); err == nil {
+				filter.Offset = o
+			}
+		}
+
+		deliveries, err := s.WebhookService.ListDeliveries(ctx, user.ID, filter)
+		if err != nil {
+			if httpErr := webhookError(err); httpErr != nil {
+				log.Debug().Err(err).Str("webhook_id", filter.WebhookID).Msg("Webhook not found")
+				return httpErr
+			}
+			log.Error().Err(err).Str("webhook_id", filter.WebhookID).Msg("Failed to list webhook deliveries")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to list webhook deliveries")
+		}
+
+		responseDeliveries := make([]*types.WebhookDeliveryResponse, len(deliveries))
+		for i, delivery := range deliveries {
+			responseDeliveries[i] = convertDeliveryResponse(delivery)
+		}
+
+		response := &types.ListWebhookDeliveriesResponse{
+			Deliveries: responseDeliveries,
+			Limit:      int64(filter.Limit),
+			Offset:     int64(filter.Offset),
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, response)
+	}
+}
+
+func convertDeliveryResponse(delivery *webhook.Delivery) *types.WebhookDeliveryResponse {
+	response := &types.WebhookDeliveryResponse{
+		DeliveryID:     swag.String(delivery.DeliveryID),
+		WebhookID:      swag.String(delivery.WebhookID),
+		EventID:        swag.String(delivery.EventID),
+		EventType:      swag.String(delivery.EventType),
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         swag.String(delivery.Status),
+		Attempts:       swag.Int64(int64(delivery.Attempts)),
+		ResponseStatus: int64(delivery.ResponseStatus),
+		LastError:      delivery.LastError,
+		CreatedAt:      (*strfmt.DateTime)(&delivery.CreatedAt),
+		UpdatedAt:      (*strfmt.DateTime)(&delivery.UpdatedAt),
+	}
+	if delivery.Status == storage.WebhookDeliveryStatusPending {
+		response.NextAttemptAt = strfmt.DateTime(delivery.NextAttemptAt)
+	}
+	if delivery.LastAttemptAt != nil {
+		response.LastAttemptAt = strfmt.DateTime(*delivery.LastAttemptAt)
+	}
+	return response
+}
@@ -0,0 +1,45 @@
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/auth"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func GetListWebhooksRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.GET("/webhooks", getListWebhooksHandler(s))
+}
+
+func getListWebhooksHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		user := auth.UserFromEchoContext(c)
+		if user == nil {
+			return httperrors.NewHTTPError(http.StatusUnauthorized, types.PublicHTTPErrorTypeGeneric, "Authentication required")
+		}
+
+		endpoints, err := s.WebhookService.ListEndpoints(ctx, user.ID)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", user.ID).Msg("Failed to list webhooks")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to list webhooks")
+		}
+
+		responseWebhooks := make([]*types.WebhookResponse, len(endpoints))
+		for i, endpoint := range endpoints {
+			responseWebhooks[i] = convertWebhookResponse(endpoint)
+		}
+
+		response := &types.ListWebhooksResponse{
+			Webhooks: responseWebhooks,
+		}
+
+		return util.ValidateAndReturn(c, http.StatusOK, response)
+	}
+}
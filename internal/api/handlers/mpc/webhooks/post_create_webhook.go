@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/auth"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/webhook"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func PostCreateWebhookRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.POST("/webhooks", postCreateWebhookHandler(s))
+}
+
+func postCreateWebhookHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		user := auth.UserFromEchoContext(c)
+		if user == nil {
+			return httperrors.NewHTTPError(http.StatusUnauthorized, types.PublicHTTPErrorTypeGeneric, "Authentication required")
+		}
+
+		var body types.PostCreateWebhookPayload
+		if err := util.BindAndValidateBody(c, &body); err != nil {
+			return err
+		}
+
+		if body.KeyID != "" {
+			if _, err := s.KeyService.GetKey(ctx, body.KeyID); err != nil {
+				log.Debug().Err(err).Str("key_id", body.KeyID).Msg("Key of webhook not found")
+				return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "Key not found")
+			}
+		}
+
+		created, err := s.WebhookService.CreateEndpoint(ctx, &webhook.Endpoint{
+			OwnerID:     user.ID,
+			URL:         swag.StringValue(body.URL),
+			EventTypes:  body.EventTypes,
+			KeyID:       body.KeyID,
+			Description: body.Description,
+		})
+		if err != nil {
+			if httpErr := webhookError(err); httpErr != nil {
+				log.Debug().Err(err).Str("user_id", user.ID).Msg("Invalid webhook endpoint")
+				return httpErr
+			}
+			log.Error().Err(err).Str("user_id", user.ID).Msg("Failed to create webhook")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to create webhook")
+		}
+
+		// 签名密钥只在注册时返回
+		response := convertWebhookResponse(created)
+		response.Secret = created.Secret
+
+		return util.ValidateAndReturn(c, http.StatusCreated, response)
+	}
+}
+
+// convertWebhookResponse 转换 webhook，不包含签名密钥
+func convertWebhookResponse(endpoint *webhook.Endpoint) *types.WebhookResponse {
+	eventTypes := endpoint.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return &types.WebhookResponse{
+		WebhookID:   swag.String(endpoint.WebhookID),
+		URL:         swag.String(endpoint.URL),
+		EventTypes:  eventTypes,
+		KeyID:       endpoint.KeyID,
+		Description: endpoint.Description,
+		Enabled:     swag.Bool(endpoint.Enabled),
+		CreatedAt:   (*strfmt.DateTime)(&endpoint.CreatedAt),
+		UpdatedAt:   (*strfmt.DateTime)(&endpoint.UpdatedAt),
+	}
+}
+
+// webhookError 将 webhook 服务的错误转换为 HTTP 错误
+func webhookError(err error) *httperrors.HTTPError {
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Webhook not found")
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		return httperrors.NewHTTPError(http.StatusNotFound, types.PublicHTTPErrorTypeGeneric, "Webhook delivery not found")
+	case errors.Is(err, webhook.ErrInvalidURL), errors.Is(err, webhook.ErrInvalidEventType):
+		return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, err.Error())
+	default:
+		return nil
+	}
+}
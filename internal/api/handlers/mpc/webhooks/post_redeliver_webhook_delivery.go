@@ -0,0 +1,46 @@
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/kashguard/go-mpc-wallet/internal/api"
+	"github.com/kashguard/go-mpc-wallet/internal/api/httperrors"
+	"github.com/kashguard/go-mpc-wallet/internal/auth"
+	"github.com/kashguard/go-mpc-wallet/internal/types"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+func PostRedeliverWebhookDeliveryRoute(s *api.Server) *echo.Route {
+	return s.Router.APIV1MPC.POST("/webhooks/:webhookId/deliveries/:deliveryId/redeliver", postRedeliverWebhookDeliveryHandler(s))
+}
+
+func postRedeliverWebhookDeliveryHandler(s *api.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		log := util.LogFromContext(ctx)
+
+		user := auth.UserFromEchoContext(c)
+		if user == nil {
+			return httperrors.NewHTTPError(http.StatusUnauthorized, types.PublicHTTPErrorTypeGeneric, "Authentication required")
+		}
+
+		webhookID := c.Param("webhookId")
+		deliveryID := c.Param("deliveryId")
+		if webhookID == "" || deliveryID == "" {
+			return httperrors.NewHTTPError(http.StatusBadRequest, types.PublicHTTPErrorTypeGeneric, "webhook_id and delivery_id are required")
+		}
+
+		delivery, err := s.WebhookService.Redeliver(ctx, webhookID, deliveryID, user.ID)
+		if err != nil {
+			if httpErr := webhookError(err); httpErr != nil {
+				log.Debug().Err(err).Str("webhook_id", webhookID).Str("delivery_id", deliveryID).Msg("Webhook delivery not found")
+				return httpErr
+			}
+			log.Error().Err(err).Str("webhook_id", webhookID).Str("delivery_id", deliveryID).Msg("Failed to redeliver webhook event")
+			return httperrors.NewHTTPError(http.StatusInternalServerError, types.PublicHTTPErrorTypeGeneric, "Failed to redeliver webhook event")
+		}
+
+		return util.ValidateAndReturn(c, http.StatusAccepted, convertDeliveryResponse(delivery))
+	}
+}
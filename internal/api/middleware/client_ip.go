@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/labstack/echo/v4"
+)
+
+// ClientIP stores the real IP address of the client (see echo.Context.RealIP) in the request context,
+// making it available to services outside of the HTTP layer (e.g. the MPC audit log) via util.ClientIPFromContext.
+func ClientIP() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := context.WithValue(c.Request().Context(), util.CTXKeyClientIP, c.RealIP())
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,16 +14,21 @@ import (
 	"github.com/kashguard/go-mpc-wallet/internal/config"
 	"github.com/kashguard/go-mpc-wallet/internal/i18n"
 	"github.com/kashguard/go-mpc-wallet/internal/mailer"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/approval"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/coordinator"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/discovery"
 	mpcgrpc "github.com/kashguard/go-mpc-wallet/internal/mpc/grpc"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/node"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/participant"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/session"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/signing"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/webhook"
 	"github.com/kashguard/go-mpc-wallet/internal/persistence"
 	"github.com/kashguard/go-mpc-wallet/internal/push"
 	"github.com/kashguard/go-mpc-wallet/internal/push/provider"
@@ -137,17 +144,35 @@ func NewMPCGRPCClient(cfg config.Server, nodeManager *node.Manager) (*mpcgrpc.GR
 func NewMPCGRPCServer(
 	cfg config.Server,
 	protocolEngine protocol.Engine,
+	protocolRegistry *protocol.ProtocolRegistry,
 	sessionManager *session.Manager,
+	nodeManager *node.Manager,
 	keyShareStorage storage.KeyShareStorage,
 ) (*mpcgrpc.GRPCServer, error) {
 	nodeID := cfg.MPC.NodeID
 	if nodeID == "" {
 		nodeID = "default-node"
 	}
-	return mpcgrpc.NewGRPCServer(cfg, protocolEngine, sessionManager, keyShareStorage, nodeID), nil
+	return mpcgrpc.NewGRPCServerWithRegistry(cfg, protocolEngine, protocolRegistry, sessionManager, nodeManager, keyShareStorage, nodeID), nil
+}
+
+// NewPreParamsPool 创建 ECDSA keygen 预参数池（后台生成由 Server.Start 启动）
+// coordinator 不参与 DKG，不需要预参数
+func NewPreParamsPool(cfg config.Server, keyShareStorage storage.KeyShareStorage) *protocol.PreParamsPool {
+	thisNodeID := cfg.MPC.NodeID
+	if thisNodeID == "" {
+		thisNodeID = "default-node"
+	}
+	poolSize := cfg.MPC.PreParamsPoolSize
+	if cfg.MPC.NodeType == "coordinator" {
+		poolSize = 0
+	}
+	return protocol.NewPreParamsPool(thisNodeID, keyShareStorage, poolSize, time.Duration(cfg.MPC.PreParamsGenerationTimeout)*time.Second)
 }
 
-func NewProtocolEngine(cfg config.Server, grpcClient *mpcgrpc.GRPCClient, keyShareStorage storage.KeyShareStorage) protocol.Engine {
+// NewProtocolRegistry 创建协议注册表，按配置注册支持的协议引擎（共享同一个消息路由器和预参数池）
+// 节点根据会话的协议从注册表中选择引擎，因此同一节点可以同时持有 ECDSA 和 Schnorr 密钥
+func NewProtocolRegistry(cfg config.Server, grpcClient *mpcgrpc.GRPCClient, keyShareStorage storage.KeyShareStorage, preParamsPool *protocol.PreParamsPool) *protocol.ProtocolRegistry {
 	curve := "secp256k1"
 	thisNodeID := cfg.MPC.NodeID
 	if thisNodeID == "" {
@@ -161,6 +186,10 @@ func NewProtocolEngine(cfg config.Server, grpcClient *mpcgrpc.GRPCClient, keySha
 		// 根据会话ID判断消息类型（DKG或签名）
 		// 如果sessionID是keyID格式（以"key-"开头），则作为DKG消息处理
 		// 否则作为签名消息处理
+		if strings.HasPrefix(sessionID, session.ResharingSessionPrefix) {
+			// resharing 消息：与 DKG 消息相同的传输方式，保留广播标记
+			return grpcClient.SendKeygenMessage(ctx, nodeID, msg, sessionID, isBroadcast)
+		}
 		if len(sessionID) > 0 && sessionID[:4] == "key-" {
 			// DKG消息
 			log.Error().
@@ -189,25 +218,84 @@ func NewProtocolEngine(cfg config.Server, grpcClient *mpcgrpc.GRPCClient, keySha
 		defaultProtocol = "gg20"
 	}
 
-	switch defaultProtocol {
-	case "gg18":
-		return protocol.NewGG18Protocol(curve, thisNodeID, messageRouter, keyShareStorage)
-	case "gg20":
-		return protocol.NewGG20Protocol(curve, thisNodeID, messageRouter, keyShareStorage)
-	case "frost":
-		return protocol.NewFROSTProtocol(curve, thisNodeID, messageRouter, keyShareStorage)
-	default:
-		// 默认使用GG20
-		return protocol.NewGG20Protocol(curve, thisNodeID, messageRouter, keyShareStorage)
+	supported := make(map[string]bool)
+	for _, name := range cfg.MPC.SupportedProtocols {
+		supported[strings.ToLower(name)] = true
+	}
+	// 默认协议总是注册
+	supported[defaultProtocol] = true
+
+	registry := protocol.NewProtocolRegistry()
+	if supported["gg18"] {
+		engine := protocol.NewGG18Protocol(curve, thisNodeID, messageRouter, keyShareStorage)
+		engine.SetPreParamsPool(preParamsPool)
+		registry.Register("gg18", engine)
+	}
+	if supported["gg20"] {
+		engine := protocol.NewGG20Protocol(curve, thisNodeID, messageRouter, keyShareStorage)
+		engine.SetPreParamsPool(preParamsPool)
+		registry.Register("gg20", engine)
+	}
+	if supported["cggmp21"] {
+		engine := protocol.NewCGGMPProtocol(curve, thisNodeID, messageRouter, keyShareStorage)
+		engine.SetPreParamsPool(preParamsPool)
+		registry.Register("cggmp21", engine)
+	}
+	if supported["frost"] {
+		registry.Register("frost", protocol.NewFROSTProtocol(curve, thisNodeID, messageRouter, keyShareStorage))
+	}
+
+	if _, err := registry.Get(defaultProtocol); err != nil {
+		// 未知的默认协议：使用GG20
+		log.Warn().Str("default_protocol", defaultProtocol).Msg("Unknown default protocol, falling back to gg20")
+		defaultProtocol = "gg20"
+		if _, err := registry.Get(defaultProtocol); err != nil {
+			engine := protocol.NewGG20Protocol(curve, thisNodeID, messageRouter, keyShareStorage)
+			engine.SetPreParamsPool(preParamsPool)
+			registry.Register(defaultProtocol, engine)
+		}
+	}
+	registry.SetDefault(defaultProtocol)
+
+	log.Info().
+		Strs("protocols", registry.List()).
+		Str("default_protocol", defaultProtocol).
+		Msg("Protocol registry initialized")
+
+	return registry
+}
+
+// NewProtocolEngine 返回注册表中的默认协议引擎
+func NewProtocolEngine(registry *protocol.ProtocolRegistry) (protocol.Engine, error) {
+	return registry.GetDefault()
+}
+
+// NewAuditLogger 创建 MPC 审计日志，MPC_ENABLE_AUDIT 关闭时不记录
+func NewAuditLogger(cfg config.Server, metadataStore storage.MetadataStore) *audit.Logger {
+	return audit.NewLogger(metadataStore, cfg.MPC.EnableAudit)
+}
+
+// NewWebhookService 创建 webhook 服务，MPC_ENABLE_WEBHOOKS 关闭时不发布事件
+func NewWebhookService(cfg config.Server, metadataStore storage.MetadataStore) *webhook.Service {
+	return webhook.NewService(metadataStore, cfg.MPC.EnableWebhooks)
+}
+
+// NewWebhookDispatcher 创建 webhook 投递任务（后台运行由 Server.Start 启动）
+// 只有 coordinator 负责投递，participant 写入的事件也由 coordinator 从发件箱取出；多个 coordinator 通过 SKIP LOCKED 分担
+func NewWebhookDispatcher(cfg config.Server, metadataStore storage.MetadataStore) *webhook.Dispatcher {
+	interval := time.Duration(cfg.MPC.WebhookDispatchInterval) * time.Second
+	if cfg.MPC.NodeType != "coordinator" || !cfg.MPC.EnableWebhooks {
+		interval = 0
 	}
+	return webhook.NewDispatcher(metadataStore, interval, time.Duration(cfg.MPC.WebhookTimeout)*time.Second, cfg.MPC.WebhookMaxAttempts)
 }
 
-func NewNodeManager(metadataStore storage.MetadataStore, cfg config.Server) *node.Manager {
+func NewNodeManager(metadataStore storage.MetadataStore, cfg config.Server, auditLogger *audit.Logger) *node.Manager {
 	heartbeat := time.Duration(cfg.MPC.SessionTimeout)
 	if heartbeat <= 0 {
 		heartbeat = 30
 	}
-	return node.NewManager(metadataStore, heartbeat*time.Second)
+	return node.NewManager(metadataStore, heartbeat*time.Second, cfg.MPC.NodeFaultThreshold, auditLogger)
 }
 
 func NewNodeRegistry(manager *node.Manager) *node.Registry {
@@ -231,22 +319,59 @@ func NewNodeDiscovery(manager *node.Manager, discoveryService *discovery.Service
 	return node.NewDiscovery(manager, discoveryService)
 }
 
-func NewSessionManager(metadataStore storage.MetadataStore, sessionStore storage.SessionStore, cfg config.Server) *session.Manager {
+func NewSessionManager(metadataStore storage.MetadataStore, sessionStore storage.SessionStore, cfg config.Server, auditLogger *audit.Logger, webhooks *webhook.Service) *session.Manager {
 	timeout := time.Duration(cfg.MPC.SessionTimeout)
 	if timeout <= 0 {
 		timeout = 300
 	}
-	return session.NewManager(metadataStore, sessionStore, timeout*time.Second)
+	return session.NewManager(metadataStore, sessionStore, timeout*time.Second, auditLogger, webhooks)
 }
 
 func NewDKGServiceProvider(
+	cfg config.Server,
 	metadataStore storage.MetadataStore,
 	keyShareStorage storage.KeyShareStorage,
 	protocolEngine protocol.Engine,
 	nodeManager *node.Manager,
 	nodeDiscovery *node.Discovery,
+	sessionManager *session.Manager,
+	grpcClient *mpcgrpc.GRPCClient,
 ) *key.DKGService {
-	return key.NewDKGService(metadataStore, keyShareStorage, protocolEngine, nodeManager, nodeDiscovery)
+	dkgService := key.NewDKGService(metadataStore, keyShareStorage, protocolEngine, nodeManager, nodeDiscovery, sessionManager, grpcClient)
+	dkgService.SetBackupRecoveryPublicKey(cfg.MPC.BackupRecoveryPublicKey)
+	return dkgService
+}
+
+// NewChainRegistry 按配置注册启用的链及其网络参数，密钥服务通过注册表生成地址并校验链与密钥曲线是否匹配
+func NewChainRegistry(cfg config.Server) (*chain.Registry, error) {
+	if _, err := chain.BitcoinNetworkParams(cfg.MPC.BitcoinNetwork); err != nil {
+		return nil, err
+	}
+	evmChainID := func(id int) *big.Int {
+		if id <= 0 {
+			return nil
+		}
+		return big.NewInt(int64(id))
+	}
+	specs := map[string]*chain.ChainSpec{
+		"bitcoin":   chain.BitcoinChainSpec(cfg.MPC.BitcoinNetwork),
+		"ethereum":  chain.EthereumChainSpec(evmChainID(cfg.MPC.EthereumChainID)),
+		"bsc":       chain.BSCChainSpec(evmChainID(cfg.MPC.BSCChainID)),
+		"avalanche": chain.AvalancheChainSpec(evmChainID(cfg.MPC.AvalancheChainID)),
+		"solana":    chain.SolanaChainSpec(),
+	}
+
+	registry := chain.NewRegistry()
+	for _, name := range cfg.MPC.Chains {
+		spec, ok := specs[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported chain %s in MPC_CHAINS", name)
+		}
+		if err := registry.Register(spec); err != nil {
+			return nil, fmt.Errorf("failed to register chain %s: %w", name, err)
+		}
+	}
+	return registry, nil
 }
 
 func NewKeyServiceProvider(
@@ -254,16 +379,64 @@ func NewKeyServiceProvider(
 	keyShareStorage storage.KeyShareStorage,
 	protocolEngine protocol.Engine,
 	dkgService *key.DKGService,
+	chains *chain.Registry,
+	auditLogger *audit.Logger,
 ) *key.Service {
-	return key.NewService(metadataStore, keyShareStorage, protocolEngine, dkgService)
+	return key.NewService(metadataStore, keyShareStorage, protocolEngine, dkgService, chains, auditLogger)
 }
 
-func NewSigningServiceProvider(keyService *key.Service, protocolEngine protocol.Engine, sessionManager *session.Manager, nodeDiscovery *node.Discovery, cfg config.Server, grpcClient *mpcgrpc.GRPCClient) *signing.Service {
+// NewKeyDeletionReaper 创建密钥删除任务（后台运行由 Server.Start 启动）
+// 只有 coordinator 负责销毁到期密钥的分片，participant 上的任务始终关闭；多个 coordinator 通过分布式锁互斥
+func NewKeyDeletionReaper(cfg config.Server, keyService *key.Service, sessionStore storage.SessionStore) *key.DeletionReaper {
+	interval := time.Duration(cfg.MPC.KeyDeletionReapInterval) * time.Second
+	if cfg.MPC.NodeType != "coordinator" {
+		interval = 0
+	}
+	return key.NewDeletionReaper(keyService, sessionStore, interval)
+}
+
+// NewKeyRefreshScheduler 创建密钥分片刷新任务（后台运行由 Server.Start 启动）
+// 只有 coordinator 负责刷新，participant 上的任务始终关闭；多个 coordinator 通过分布式锁互斥
+func NewKeyRefreshScheduler(cfg config.Server, keyService *key.Service, sessionStore storage.SessionStore) *key.RefreshScheduler {
+	period := time.Duration(cfg.MPC.KeyRotationDays) * 24 * time.Hour
+	if cfg.MPC.NodeType != "coordinator" {
+		period = 0
+	}
+	return key.NewRefreshScheduler(keyService, sessionStore, period, time.Duration(cfg.MPC.KeyRefreshCheckInterval)*time.Second)
+}
+
+// NewPresignPool 创建 GG20 预签名池（后台补充由 Server.Start 启动）
+// 只有 coordinator 负责调度签名，participant 上的池始终关闭
+func NewPresignPool(
+	cfg config.Server,
+	metadataStore storage.MetadataStore,
+	sessionManager *session.Manager,
+	nodeDiscovery *node.Discovery,
+	grpcClient *mpcgrpc.GRPCClient,
+) *signing.PresignPool {
+	target := cfg.MPC.PresignPoolTarget
+	if cfg.MPC.NodeType != "coordinator" {
+		target = 0
+	}
+	return signing.NewPresignPool(metadataStore, sessionManager, nodeDiscovery, grpcClient, target, cfg.MPC.PresignPoolLowWatermark, time.Duration(cfg.MPC.PresignRefillInterval)*time.Second)
+}
+
+// NewPolicyEngine 创建交易策略引擎，MPC_ENABLE_POLICY 关闭时签名不做策略检查
+func NewPolicyEngine(cfg config.Server, metadataStore storage.MetadataStore, auditLogger *audit.Logger) *policy.Engine {
+	return policy.NewEngine(metadataStore, cfg.MPC.EnablePolicy, auditLogger)
+}
+
+func NewSigningServiceProvider(keyService *key.Service, protocolEngine protocol.Engine, protocolRegistry *protocol.ProtocolRegistry, sessionManager *session.Manager, nodeDiscovery *node.Discovery, cfg config.Server, grpcClient *mpcgrpc.GRPCClient, presignPool *signing.PresignPool, policyEngine *policy.Engine, auditLogger *audit.Logger, metadataStore storage.MetadataStore, webhooks *webhook.Service) *signing.Service {
 	defaultProtocol := cfg.MPC.DefaultProtocol
 	if defaultProtocol == "" {
 		defaultProtocol = "gg20"
 	}
-	return signing.NewService(keyService, protocolEngine, sessionManager, nodeDiscovery, defaultProtocol, grpcClient)
+	return signing.NewService(keyService, protocolEngine, protocolRegistry, sessionManager, nodeDiscovery, defaultProtocol, grpcClient, presignPool, policyEngine, auditLogger, metadataStore, webhooks)
+}
+
+func NewApprovalService(cfg config.Server, db *sql.DB, metadataStore storage.MetadataStore, policyEngine *policy.Engine, signingService *signing.Service, pusher *push.Service, mail *mailer.Mailer) *approval.Service {
+	notifier := approval.NewMessageNotifier(db, pusher, mail)
+	return approval.NewService(metadataStore, policyEngine, signingService, notifier, time.Duration(cfg.MPC.ApprovalExpiryHours)*time.Hour)
 }
 
 func NewCoordinatorServiceProvider(
@@ -15,6 +15,7 @@ import (
 	"github.com/kashguard/go-mpc-wallet/internal/api/handlers/constants"
 	"github.com/kashguard/go-mpc-wallet/internal/api/middleware"
 	"github.com/kashguard/go-mpc-wallet/internal/api/router/templates"
+	"github.com/kashguard/go-mpc-wallet/internal/auth"
 	"github.com/labstack/echo-contrib/echoprometheus"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
@@ -237,7 +238,28 @@ func Init(s *api.Server) error {
 
 		// Your other endpoints, typically secured by bearer auth, available at /api/v1/**
 		APIV1Push: s.Echo.Group("/api/v1/push", middleware.Auth(s)),
-		APIV1MPC:  s.Echo.Group("/api/v1/mpc", middleware.Auth(s)),
+		APIV1MPC:  s.Echo.Group("/api/v1/mpc", middleware.Auth(s), middleware.ClientIP()),
+
+		// MPC audit log, secured by bearer auth with the auditor scope, available at /api/v1/mpc/audit/**
+		APIV1MPCAudit: s.Echo.Group("/api/v1/mpc/audit", middleware.AuthWithConfig(middleware.AuthConfig{
+			S:      s,
+			Mode:   middleware.AuthModeRequired,
+			Scopes: []string{auth.ScopeAuditor.String()},
+		}), middleware.ClientIP()),
+
+		// MPC transaction policies, secured by bearer auth with the policy_admin scope, available at /api/v1/mpc/policies/**
+		APIV1MPCPolicies: s.Echo.Group("/api/v1/mpc/policies", middleware.AuthWithConfig(middleware.AuthConfig{
+			S:      s,
+			Mode:   middleware.AuthModeRequired,
+			Scopes: []string{auth.ScopePolicyAdmin.String()},
+		}), middleware.ClientIP()),
+
+		// MPC key approver settings, secured by bearer auth with the policy_admin scope, available at /api/v1/mpc/keys/:keyId/approvers
+		APIV1MPCKeyApprovers: s.Echo.Group("/api/v1/mpc/keys/:keyId/approvers", middleware.AuthWithConfig(middleware.AuthConfig{
+			S:      s,
+			Mode:   middleware.AuthModeRequired,
+			Scopes: []string{auth.ScopePolicyAdmin.String()},
+		}), middleware.ClientIP()),
 	}
 
 	// 注册健康检查路由（已移除旧的 internal/grpc 实现）
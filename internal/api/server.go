@@ -20,27 +20,37 @@ import (
 	"github.com/rs/zerolog/log"
 
 	// MPC imports
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/approval"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/coordinator"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/discovery"
 	mpcgrpc "github.com/kashguard/go-mpc-wallet/internal/mpc/grpc"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/node"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/participant"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/session"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/signing"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/webhook"
 
 	// Import postgres driver for database/sql package
 	_ "github.com/lib/pq"
 )
 
 type Router struct {
-	Routes     []*echo.Route
-	Root       *echo.Group
-	Management *echo.Group
-	APIV1Auth  *echo.Group
-	APIV1Push  *echo.Group
-	APIV1MPC   *echo.Group
-	WellKnown  *echo.Group
+	Routes        []*echo.Route
+	Root          *echo.Group
+	Management    *echo.Group
+	APIV1Auth     *echo.Group
+	APIV1Push     *echo.Group
+	APIV1MPC      *echo.Group
+	APIV1MPCAudit *echo.Group
+	// APIV1MPCPolicies 交易策略管理，需要 policy_admin scope
+	APIV1MPCPolicies *echo.Group
+	// APIV1MPCKeyApprovers 设置密钥审批人，需要 policy_admin scope
+	APIV1MPCKeyApprovers *echo.Group
+	WellKnown            *echo.Group
 }
 
 // Server is a central struct keeping all the dependencies.
@@ -82,6 +92,16 @@ type Server struct {
 	// gRPC services (unified MPC gRPC)
 	MPCGRPCServer *mpcgrpc.GRPCServer // MPC gRPC 服务端（统一实现）
 	MPCGRPCClient *mpcgrpc.GRPCClient // MPC gRPC 客户端（用于节点间通信）
+
+	PreParamsPool     *protocol.PreParamsPool // ECDSA keygen 预参数池
+	PresignPool       *signing.PresignPool    // GG20 预签名池（coordinator）
+	DeletionReaper    *key.DeletionReaper     // 销毁到期待删除密钥的分片（coordinator）
+	RefreshScheduler  *key.RefreshScheduler   // 定期刷新密钥分片（coordinator）
+	PolicyEngine      *policy.Engine          // 签名前的交易策略引擎
+	ApprovalService   *approval.Service       // 策略要求审批的签名请求
+	AuditLogger       *audit.Logger           // MPC 操作审计日志
+	WebhookService    *webhook.Service        // webhook 注册和事件发布
+	WebhookDispatcher *webhook.Dispatcher     // 投递 webhook 发件箱中的事件（coordinator）
 }
 
 // newServerWithComponents is used by wire to initialize the server components.
@@ -108,6 +128,15 @@ func newServerWithComponents(
 	mpcGRPCServer *mpcgrpc.GRPCServer, // ✅ 统一的 MPC gRPC 服务端
 	mpcGRPCClient *mpcgrpc.GRPCClient, // ✅ 统一的 MPC gRPC 客户端
 	discoveryService *discovery.Service, // ✅ 新的统一服务发现
+	preParamsPool *protocol.PreParamsPool,
+	presignPool *signing.PresignPool,
+	deletionReaper *key.DeletionReaper,
+	refreshScheduler *key.RefreshScheduler,
+	policyEngine *policy.Engine,
+	approvalService *approval.Service,
+	auditLogger *audit.Logger,
+	webhookService *webhook.Service,
+	webhookDispatcher *webhook.Dispatcher,
 ) *Server {
 	s := &Server{
 		Config:  cfg,
@@ -129,9 +158,18 @@ func newServerWithComponents(
 		NodeDiscovery:      nodeDiscovery,
 		SessionManager:     sessionManager,
 
-		MPCGRPCServer:    mpcGRPCServer,    // ✅ 统一的 MPC gRPC 服务端
-		MPCGRPCClient:    mpcGRPCClient,    // ✅ 统一的 MPC gRPC 客户端
-		DiscoveryService: discoveryService, // ✅ 新的统一服务发现
+		MPCGRPCServer:     mpcGRPCServer,    // ✅ 统一的 MPC gRPC 服务端
+		MPCGRPCClient:     mpcGRPCClient,    // ✅ 统一的 MPC gRPC 客户端
+		DiscoveryService:  discoveryService, // ✅ 新的统一服务发现
+		PreParamsPool:     preParamsPool,
+		PresignPool:       presignPool,
+		DeletionReaper:    deletionReaper,
+		RefreshScheduler:  refreshScheduler,
+		PolicyEngine:      policyEngine,
+		ApprovalService:   approvalService,
+		AuditLogger:       auditLogger,
+		WebhookService:    webhookService,
+		WebhookDispatcher: webhookDispatcher,
 	}
 
 	// 设置 NodeDiscovery 到 MPCGRPCClient，使其能够从 Consul 获取节点信息
@@ -227,7 +265,24 @@ func (s *Server) Start() error {
 			Msg("MPC gRPC server started in background")
 	}
 
-	// 3. 启动 HTTP 服务器
+	// 3. 启动预参数池后台生成、预签名池后台补充、密钥删除、分片刷新和 webhook 投递任务（在 Shutdown 中停止）
+	if s.PreParamsPool != nil {
+		go s.PreParamsPool.Run(context.Background())
+	}
+	if s.PresignPool != nil {
+		go s.PresignPool.Run(context.Background())
+	}
+	if s.DeletionReaper != nil {
+		go s.DeletionReaper.Run(context.Background())
+	}
+	if s.RefreshScheduler != nil {
+		go s.RefreshScheduler.Run(context.Background())
+	}
+	if s.WebhookDispatcher != nil {
+		go s.WebhookDispatcher.Run(context.Background())
+	}
+
+	// 4. 启动 HTTP 服务器
 	if err := s.Echo.Start(s.Config.Echo.ListenAddress); err != nil {
 		return fmt.Errorf("failed to start echo server: %w", err)
 	}
@@ -263,7 +318,24 @@ func (s *Server) Shutdown(ctx context.Context) []error {
 		}
 	}
 
-	// 3. 关闭 HTTP 服务器
+	// 3. 停止预参数池后台生成和预签名池后台补充
+	if s.PreParamsPool != nil {
+		s.PreParamsPool.Stop()
+	}
+	if s.PresignPool != nil {
+		s.PresignPool.Stop()
+	}
+	if s.DeletionReaper != nil {
+		s.DeletionReaper.Stop()
+	}
+	if s.RefreshScheduler != nil {
+		s.RefreshScheduler.Stop()
+	}
+	if s.WebhookDispatcher != nil {
+		s.WebhookDispatcher.Stop()
+	}
+
+	// 4. 关闭 HTTP 服务器
 	if s.Echo != nil {
 		log.Debug().Msg("Shutting down echo server")
 		if err := s.Echo.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -272,7 +344,7 @@ func (s *Server) Shutdown(ctx context.Context) []error {
 		}
 	}
 
-	// 4. 关闭数据库连接
+	// 5. 关闭数据库连接
 	if s.DB != nil {
 		log.Debug().Msg("Closing database connection")
 		if err := s.DB.Close(); err != nil && !errors.Is(err, sql.ErrConnDone) {
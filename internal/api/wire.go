@@ -40,6 +40,9 @@ var mpcServiceSet = wire.NewSet(
 	NewRedisClient,
 	NewSessionStore,
 	NewKeyShareStorage,
+	NewAuditLogger,
+	NewWebhookService,
+	NewWebhookDispatcher,
 	NewNodeManager,
 	NewNodeRegistry,
 	NewNodeDiscovery,
@@ -47,10 +50,18 @@ var mpcServiceSet = wire.NewSet(
 	// gRPC communication (must be before NewProtocolEngine)
 	NewMPCGRPCClient,
 	NewMPCGRPCServer,
+	NewPreParamsPool,
+	NewProtocolRegistry,
 	NewProtocolEngine,
-	// DKG service (must be before NewKeyServiceProvider)
+	// DKG service and chain registry (must be before NewKeyServiceProvider)
 	NewDKGServiceProvider,
+	NewChainRegistry,
 	NewKeyServiceProvider,
+	NewKeyDeletionReaper,
+	NewKeyRefreshScheduler,
+	NewPresignPool,
+	NewPolicyEngine,
+	NewApprovalService,
 	NewSigningServiceProvider,
 	NewCoordinatorServiceProvider,
 	NewParticipantServiceProvider,
@@ -53,34 +53,51 @@ func InitNewServer(server config.Server) (*Server, error) {
 	if err != nil {
 		return nil, err
 	}
-	manager := NewNodeManager(metadataStore, server)
+	auditLogger := NewAuditLogger(server, metadataStore)
+	webhookService := NewWebhookService(server, metadataStore)
+	manager := NewNodeManager(metadataStore, server, auditLogger)
 	grpcClient, err := NewMPCGRPCClient(server, manager)
 	if err != nil {
 		return nil, err
 	}
-	engine := NewProtocolEngine(server, grpcClient, keyShareStorage)
+	preParamsPool := NewPreParamsPool(server, keyShareStorage)
+	protocolRegistry := NewProtocolRegistry(server, grpcClient, keyShareStorage, preParamsPool)
+	engine, err := NewProtocolEngine(protocolRegistry)
+	if err != nil {
+		return nil, err
+	}
 	discoveryService, err := NewMPCDiscoveryService(server)
 	if err != nil {
 		return nil, err
 	}
 	discovery := NewNodeDiscovery(manager, discoveryService)
-	dkgService := NewDKGServiceProvider(metadataStore, keyShareStorage, engine, manager, discovery)
-	keyService := NewKeyServiceProvider(metadataStore, keyShareStorage, engine, dkgService)
 	client, err := NewRedisClient(server)
 	if err != nil {
 		return nil, err
 	}
 	sessionStore := NewSessionStore(client)
-	sessionManager := NewSessionManager(metadataStore, sessionStore, server)
-	signingService := NewSigningServiceProvider(keyService, engine, sessionManager, discovery, server, grpcClient)
+	sessionManager := NewSessionManager(metadataStore, sessionStore, server, auditLogger, webhookService)
+	dkgService := NewDKGServiceProvider(server, metadataStore, keyShareStorage, engine, manager, discovery, sessionManager, grpcClient)
+	chainRegistry, err := NewChainRegistry(server)
+	if err != nil {
+		return nil, err
+	}
+	keyService := NewKeyServiceProvider(metadataStore, keyShareStorage, engine, dkgService, chainRegistry, auditLogger)
+	deletionReaper := NewKeyDeletionReaper(server, keyService, sessionStore)
+	refreshScheduler := NewKeyRefreshScheduler(server, keyService, sessionStore)
+	presignPool := NewPresignPool(server, metadataStore, sessionManager, discovery, grpcClient)
+	policyEngine := NewPolicyEngine(server, metadataStore, auditLogger)
+	signingService := NewSigningServiceProvider(keyService, engine, protocolRegistry, sessionManager, discovery, server, grpcClient, presignPool, policyEngine, auditLogger, metadataStore, webhookService)
+	approvalService := NewApprovalService(server, db, metadataStore, policyEngine, signingService, service, mailer)
 	coordinatorService := NewCoordinatorServiceProvider(server, keyService, sessionManager, discovery, engine, grpcClient)
 	participantService := NewParticipantServiceProvider(server, keyShareStorage, engine)
 	registry := NewNodeRegistry(manager)
-	grpcServer, err := NewMPCGRPCServer(server, engine, sessionManager, keyShareStorage)
+	grpcServer, err := NewMPCGRPCServer(server, engine, protocolRegistry, sessionManager, manager, keyShareStorage)
 	if err != nil {
 		return nil, err
 	}
-	apiServer := newServerWithComponents(server, db, mailer, service, i18nService, clock, authService, localService, metricsService, keyService, signingService, coordinatorService, participantService, manager, registry, discovery, sessionManager, grpcServer, grpcClient, discoveryService)
+	dispatcher := NewWebhookDispatcher(server, metadataStore)
+	apiServer := newServerWithComponents(server, db, mailer, service, i18nService, clock, authService, localService, metricsService, keyService, signingService, coordinatorService, participantService, manager, registry, discovery, sessionManager, grpcServer, grpcClient, discoveryService, preParamsPool, presignPool, deletionReaper, refreshScheduler, policyEngine, approvalService, auditLogger, webhookService, dispatcher)
 	return apiServer, nil
 }
 
@@ -111,34 +128,51 @@ func InitNewServerWithDB(server config.Server, db *sql.DB, t ...*testing.T) (*Se
 	if err != nil {
 		return nil, err
 	}
-	manager := NewNodeManager(metadataStore, server)
+	auditLogger := NewAuditLogger(server, metadataStore)
+	webhookService := NewWebhookService(server, metadataStore)
+	manager := NewNodeManager(metadataStore, server, auditLogger)
 	grpcClient, err := NewMPCGRPCClient(server, manager)
 	if err != nil {
 		return nil, err
 	}
-	engine := NewProtocolEngine(server, grpcClient, keyShareStorage)
+	preParamsPool := NewPreParamsPool(server, keyShareStorage)
+	protocolRegistry := NewProtocolRegistry(server, grpcClient, keyShareStorage, preParamsPool)
+	engine, err := NewProtocolEngine(protocolRegistry)
+	if err != nil {
+		return nil, err
+	}
 	discoveryService, err := NewMPCDiscoveryService(server)
 	if err != nil {
 		return nil, err
 	}
 	discovery := NewNodeDiscovery(manager, discoveryService)
-	dkgService := NewDKGServiceProvider(metadataStore, keyShareStorage, engine, manager, discovery)
-	keyService := NewKeyServiceProvider(metadataStore, keyShareStorage, engine, dkgService)
 	client, err := NewRedisClient(server)
 	if err != nil {
 		return nil, err
 	}
 	sessionStore := NewSessionStore(client)
-	sessionManager := NewSessionManager(metadataStore, sessionStore, server)
-	signingService := NewSigningServiceProvider(keyService, engine, sessionManager, discovery, server, grpcClient)
+	sessionManager := NewSessionManager(metadataStore, sessionStore, server, auditLogger, webhookService)
+	dkgService := NewDKGServiceProvider(server, metadataStore, keyShareStorage, engine, manager, discovery, sessionManager, grpcClient)
+	chainRegistry, err := NewChainRegistry(server)
+	if err != nil {
+		return nil, err
+	}
+	keyService := NewKeyServiceProvider(metadataStore, keyShareStorage, engine, dkgService, chainRegistry, auditLogger)
+	deletionReaper := NewKeyDeletionReaper(server, keyService, sessionStore)
+	refreshScheduler := NewKeyRefreshScheduler(server, keyService, sessionStore)
+	presignPool := NewPresignPool(server, metadataStore, sessionManager, discovery, grpcClient)
+	policyEngine := NewPolicyEngine(server, metadataStore, auditLogger)
+	signingService := NewSigningServiceProvider(keyService, engine, protocolRegistry, sessionManager, discovery, server, grpcClient, presignPool, policyEngine, auditLogger, metadataStore, webhookService)
+	approvalService := NewApprovalService(server, db, metadataStore, policyEngine, signingService, service, mailer)
 	coordinatorService := NewCoordinatorServiceProvider(server, keyService, sessionManager, discovery, engine, grpcClient)
 	participantService := NewParticipantServiceProvider(server, keyShareStorage, engine)
 	registry := NewNodeRegistry(manager)
-	grpcServer, err := NewMPCGRPCServer(server, engine, sessionManager, keyShareStorage)
+	grpcServer, err := NewMPCGRPCServer(server, engine, protocolRegistry, sessionManager, manager, keyShareStorage)
 	if err != nil {
 		return nil, err
 	}
-	apiServer := newServerWithComponents(server, db, mailer, service, i18nService, clock, authService, localService, metricsService, keyService, signingService, coordinatorService, participantService, manager, registry, discovery, sessionManager, grpcServer, grpcClient, discoveryService)
+	dispatcher := NewWebhookDispatcher(server, metadataStore)
+	apiServer := newServerWithComponents(server, db, mailer, service, i18nService, clock, authService, localService, metricsService, keyService, signingService, coordinatorService, participantService, manager, registry, discovery, sessionManager, grpcServer, grpcClient, discoveryService, preParamsPool, presignPool, deletionReaper, refreshScheduler, policyEngine, approvalService, auditLogger, webhookService, dispatcher)
 	return apiServer, nil
 }
 
@@ -163,6 +197,7 @@ var mpcServiceSet = wire.NewSet(
 	NewRedisClient,
 	NewSessionStore,
 	NewKeyShareStorage,
+	NewAuditLogger,
 	NewNodeManager,
 	NewNodeRegistry,
 	NewNodeDiscovery,
@@ -170,10 +205,18 @@ var mpcServiceSet = wire.NewSet(
 
 	NewMPCGRPCClient,
 	NewMPCGRPCServer,
+	NewPreParamsPool,
+	NewProtocolRegistry,
 	NewProtocolEngine,
 
 	NewDKGServiceProvider,
+	NewChainRegistry,
 	NewKeyServiceProvider,
+	NewKeyDeletionReaper,
+	NewKeyRefreshScheduler,
+	NewPresignPool,
+	NewPolicyEngine,
+	NewApprovalService,
 	NewSigningServiceProvider,
 	NewCoordinatorServiceProvider,
 	NewParticipantServiceProvider,
@@ -4,6 +4,10 @@ type Scope string
 
 const (
 	ScopeApp Scope = "app"
+	// ScopeAuditor 只读访问 MPC 审计日志（合规审计）
+	ScopeAuditor Scope = "auditor"
+	// ScopePolicyAdmin 管理交易策略规则和密钥审批人（与签名调用方的 app scope 分离，签名方不能修改约束自己的规则和审批人）
+	ScopePolicyAdmin Scope = "policy_admin"
 )
 
 func (s Scope) String() string {
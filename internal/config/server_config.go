@@ -127,12 +127,49 @@ type MPC struct {
 	// 功能配置
 	EnableAudit     bool
 	EnablePolicy    bool
-	KeyRotationDays int
+	KeyRotationDays int // 密钥分片刷新周期（天），0 表示不自动刷新
+
+	// 签名审批：策略要求审批的签名请求的有效期（小时）
+	ApprovalExpiryHours int
 
 	// 性能配置
 	MaxConcurrentSessions int
 	MaxConcurrentSignings int
 	SessionTimeout        int
+
+	// 预参数池配置（ECDSA keygen 的 Paillier/安全素数预计算）
+	PreParamsPoolSize          int
+	PreParamsGenerationTimeout int
+
+	// GG20 预签名池配置（协调者按密钥维护，0 表示关闭）
+	PresignPoolTarget       int // 每个密钥保持的可用预签名数量
+	PresignPoolLowWatermark int // 可用数量低于该值时开始补充
+	PresignRefillInterval   int // 定期检查间隔（秒）
+
+	// 密钥删除：协调者定期销毁删除日期已到的密钥（检查间隔，秒，0 表示关闭）
+	KeyDeletionReapInterval int
+
+	// 密钥分片刷新：协调者定期刷新超过 KeyRotationDays 天未刷新的密钥（检查间隔，秒，0 表示关闭）
+	KeyRefreshCheckInterval int
+
+	// webhook：MPC 事件写入发件箱，协调者定期投递（检查间隔，秒，0 表示不投递），失败后按指数退避重试
+	EnableWebhooks          bool
+	WebhookDispatchInterval int
+	WebhookTimeout          int // 单次投递的请求超时（秒）
+	WebhookMaxAttempts      int // 每次投递的最大尝试次数
+
+	// 节点故障评分：被可识别中止判定为责任方的次数达到该值后自动标记为 faulty（0 表示关闭）
+	NodeFaultThreshold int
+
+	// 分片备份：离线恢复公钥（hex 编码的 X25519 公钥），参与节点只把分片加密到该公钥，为空表示关闭备份
+	BackupRecoveryPublicKey string
+
+	// 链配置：启用的链类型及其网络参数（EVM 链 ID 为 0 时使用主网）
+	Chains           []string
+	BitcoinNetwork   string
+	EthereumChainID  int
+	BSCChainID       int
+	AvalancheChainID int
 }
 
 type Server struct {
@@ -298,7 +335,7 @@ func DefaultServiceConfigFromEnv() Server {
 			KeyShareStoragePath:   util.GetEnv("MPC_KEY_SHARE_STORAGE_PATH", filepath.Join(util.GetProjectRootDir(), "/var/lib/mpc/key-shares")),
 			KeyShareEncryptionKey: util.GetEnv("MPC_KEY_SHARE_ENCRYPTION_KEY", ""),
 			ConsulAddress:         util.GetEnv("MPC_CONSUL_ADDRESS", "localhost:8500"),
-			SupportedProtocols:    util.GetEnvAsStringArr("MPC_SUPPORTED_PROTOCOLS", []string{"gg18", "gg20", "frost"}),
+			SupportedProtocols:    util.GetEnvAsStringArr("MPC_SUPPORTED_PROTOCOLS", []string{"gg18", "gg20", "cggmp21", "frost"}),
 			DefaultProtocol:       util.GetEnv("MPC_DEFAULT_PROTOCOL", "gg20"),
 			HTTPPort:              util.GetEnvAsInt("MPC_HTTP_PORT", 8080),
 			GRPCPort:              util.GetEnvAsInt("MPC_GRPC_PORT", 9090),
@@ -306,9 +343,35 @@ func DefaultServiceConfigFromEnv() Server {
 			EnableAudit:           util.GetEnvAsBool("MPC_ENABLE_AUDIT", true),
 			EnablePolicy:          util.GetEnvAsBool("MPC_ENABLE_POLICY", true),
 			KeyRotationDays:       util.GetEnvAsInt("MPC_KEY_ROTATION_DAYS", 0),
+			ApprovalExpiryHours:   util.GetEnvAsInt("MPC_APPROVAL_EXPIRY_HOURS", 24),
 			MaxConcurrentSessions: util.GetEnvAsInt("MPC_MAX_CONCURRENT_SESSIONS", 100),
 			MaxConcurrentSignings: util.GetEnvAsInt("MPC_MAX_CONCURRENT_SIGNINGS", 50),
 			SessionTimeout:        util.GetEnvAsInt("MPC_SESSION_TIMEOUT", 300),
+
+			PreParamsPoolSize:          util.GetEnvAsInt("MPC_PREPARAMS_POOL_SIZE", 2),
+			PreParamsGenerationTimeout: util.GetEnvAsInt("MPC_PREPARAMS_GENERATION_TIMEOUT", 600),
+
+			PresignPoolTarget:       util.GetEnvAsInt("MPC_PRESIGN_POOL_TARGET", 0),
+			PresignPoolLowWatermark: util.GetEnvAsInt("MPC_PRESIGN_POOL_LOW_WATERMARK", 0),
+			PresignRefillInterval:   util.GetEnvAsInt("MPC_PRESIGN_REFILL_INTERVAL", 30),
+
+			KeyDeletionReapInterval: util.GetEnvAsInt("MPC_KEY_DELETION_REAP_INTERVAL", 300),
+			KeyRefreshCheckInterval: util.GetEnvAsInt("MPC_KEY_REFRESH_CHECK_INTERVAL", 3600),
+
+			EnableWebhooks:          util.GetEnvAsBool("MPC_ENABLE_WEBHOOKS", true),
+			WebhookDispatchInterval: util.GetEnvAsInt("MPC_WEBHOOK_DISPATCH_INTERVAL", 5),
+			WebhookTimeout:          util.GetEnvAsInt("MPC_WEBHOOK_TIMEOUT", 10),
+			WebhookMaxAttempts:      util.GetEnvAsInt("MPC_WEBHOOK_MAX_ATTEMPTS", 8),
+
+			NodeFaultThreshold: util.GetEnvAsInt("MPC_NODE_FAULT_THRESHOLD", 3),
+
+			BackupRecoveryPublicKey: util.GetEnv("MPC_BACKUP_RECOVERY_PUBLIC_KEY", ""),
+
+			Chains:           util.GetEnvAsStringArrTrimmed("MPC_CHAINS", []string{"bitcoin", "ethereum", "bsc", "avalanche", "solana"}),
+			BitcoinNetwork:   util.GetEnv("MPC_BITCOIN_NETWORK", "mainnet"),
+			EthereumChainID:  util.GetEnvAsInt("MPC_ETHEREUM_CHAIN_ID", 1),
+			BSCChainID:       util.GetEnvAsInt("MPC_BSC_CHAIN_ID", 56),
+			AvalancheChainID: util.GetEnvAsInt("MPC_AVALANCHE_CHAIN_ID", 43114),
 		},
 	}
 }
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+type SigningApprovalNotificationPayload struct {
+	ApprovalID        string
+	KeyID             string
+	Reason            string
+	RequiredApprovals int
+	ExpiresAt         time.Time
+}
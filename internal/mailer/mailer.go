@@ -8,6 +8,7 @@ import (
 	"html/template"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/jordan-wright/email"
 	"github.com/kashguard/go-mpc-wallet/internal/config"
@@ -21,6 +22,7 @@ var (
 	ErrEmailTemplateNotFound         = errors.New("email template not found")
 	emailTemplatePasswordReset       = "password_reset"       // /app/templates/email/password_reset/**.
 	emailTemplateAccountConfirmation = "account_confirmation" // /app/templates/email/account_confirmation/**
+	emailTemplateSigningApproval     = "signing_approval"     // /app/templates/email/signing_approval/**
 )
 
 type Mailer struct {
@@ -160,3 +162,48 @@ func (m *Mailer) SendAccountConfirmation(ctx context.Context, to string, payload
 
 	return nil
 }
+
+func (m *Mailer) SendSigningApproval(ctx context.Context, to string, payload dto.SigningApprovalNotificationPayload) error {
+	log := util.LogFromContext(ctx).With().Str("component", "mailer").Str("email_template", emailTemplateSigningApproval).Logger()
+
+	tmpl, ok := m.Templates[emailTemplateSigningApproval]
+	if !ok {
+		log.Error().Msg("Signing approval email template not found")
+		return ErrEmailTemplateNotFound
+	}
+
+	data := map[string]interface{}{
+		"approvalID":        payload.ApprovalID,
+		"keyID":             payload.KeyID,
+		"reason":            payload.Reason,
+		"requiredApprovals": payload.RequiredApprovals,
+		"expiresAt":         payload.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		log.Error().Err(err).Msg("Failed to execute signing approval email template")
+		return fmt.Errorf("failed to execute signing approval email template: %w", err)
+	}
+
+	mail := email.NewEmail()
+
+	mail.From = m.Config.DefaultSender
+	mail.To = []string{to}
+	mail.Subject = "Signing approval required"
+	mail.HTML = buf.Bytes()
+
+	if !m.Config.Send {
+		log.Warn().Str("to", to).Str("approvalID", payload.ApprovalID).Msg("Sending has been disabled in mailer config, skipping signing approval email")
+		return nil
+	}
+
+	if err := m.Transport.Send(mail); err != nil {
+		log.Debug().Err(err).Msg("Failed to send signing approval email")
+		return fmt.Errorf("failed to send signing approval email: %w", err)
+	}
+
+	log.Debug().Msg("Successfully sent signing approval email")
+
+	return nil
+}
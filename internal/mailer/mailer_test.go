@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kashguard/go-mpc-wallet/internal/data/dto"
 	"github.com/kashguard/go-mpc-wallet/internal/test"
 	"github.com/kashguard/go-mpc-wallet/internal/test/fixtures"
 	"github.com/stretchr/testify/assert"
@@ -36,3 +37,30 @@ func TestMailerSendPasswordReset(t *testing.T) {
 	assert.Equal(t, "Password reset", mail.Subject)
 	assert.Contains(t, string(mail.HTML), passwordResetLink)
 }
+
+func TestMailerSendSigningApproval(t *testing.T) {
+	ctx := t.Context()
+	fix := fixtures.Fixtures()
+
+	mailer := test.NewTestMailer(t)
+	mailTransport := test.GetTestMailerMockTransport(t, mailer)
+	mailTransport.Expect(1)
+
+	err := mailer.SendSigningApproval(ctx, fix.User1.Username.String, dto.SigningApprovalNotificationPayload{
+		ApprovalID:        "approval-12345",
+		KeyID:             "key-12345",
+		Reason:            "transfer amount exceeds limit",
+		RequiredApprovals: 2,
+		ExpiresAt:         time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	mailTransport.WaitWithTimeout(time.Second)
+
+	mail := mailTransport.GetLastSentMail()
+	require.NotNil(t, mail)
+	assert.Equal(t, fix.User1.Username.String, mail.To[0])
+	assert.Equal(t, "Signing approval required", mail.Subject)
+	assert.Contains(t, string(mail.HTML), "approval-12345")
+	assert.Contains(t, string(mail.HTML), "key-12345")
+}
@@ -0,0 +1,23 @@
+package approval
+
+import (
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotFound 签名审批不存在
+	ErrNotFound = errors.New("signing approval not found")
+	// ErrNotPending 签名审批已经结束（通过、拒绝或过期）
+	ErrNotPending = errors.New("signing approval is not pending")
+	// ErrNotApprover 用户不是该签名请求的审批人
+	ErrNotApprover = errors.New("user is not an approver of the signing approval")
+	// ErrSelfApproval 发起签名的用户不能审批自己的请求
+	ErrSelfApproval = errors.New("requester cannot vote on own signing approval")
+	// ErrAlreadyVoted 审批人已经投过票（由存储的唯一约束保证，并发投票时同样生效）
+	ErrAlreadyVoted = storage.ErrDuplicateVote
+	// ErrNoRequester 签名审批必须记录发起人，否则无法排除发起人自己审批
+	ErrNoRequester = errors.New("signing approval requires an authenticated requester")
+	// ErrNoQuorum 策略规则和密钥都没有配置审批人，无法发起审批
+	ErrNoQuorum = errors.New("no approvers configured for key or policy rule")
+)
@@ -0,0 +1,72 @@
+package approval
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/kashguard/go-mpc-wallet/internal/data/dto"
+	"github.com/kashguard/go-mpc-wallet/internal/data/mapper"
+	"github.com/kashguard/go-mpc-wallet/internal/mailer"
+	"github.com/kashguard/go-mpc-wallet/internal/models"
+	"github.com/kashguard/go-mpc-wallet/internal/push"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// MessageNotifier 通过推送（push.Service）和邮件（mailer.Mailer）通知审批人
+type MessageNotifier struct {
+	db     *sql.DB
+	push   *push.Service
+	mailer *mailer.Mailer
+}
+
+// NewMessageNotifier 创建审批通知，push 或 mailer 为空时跳过对应渠道
+func NewMessageNotifier(db *sql.DB, pusher *push.Service, mail *mailer.Mailer) *MessageNotifier {
+	return &MessageNotifier{
+		db:     db,
+		push:   pusher,
+		mailer: mail,
+	}
+}
+
+// NotifyApprovers 向每个审批人发送推送和邮件，单个审批人通知失败不影响其他审批人
+func (n *MessageNotifier) NotifyApprovers(ctx context.Context, approval *Approval) error {
+	title := "Signing approval required"
+	message := fmt.Sprintf("Signing request %s for key %s requires approval: %s", approval.ApprovalID, approval.KeyID, approval.Reason)
+
+	failed := 0
+	for _, approverID := range approval.Approvers {
+		user, err := models.FindUser(ctx, n.db, approverID)
+		if err != nil {
+			log.Warn().Err(err).Str("approver_id", approverID).Msg("Failed to load approver")
+			failed++
+			continue
+		}
+
+		if n.push != nil && n.push.GetProviderCount() > 0 {
+			if err := n.push.SendToUser(ctx, mapper.LocalUserToDTO(user).Ptr(), title, message); err != nil {
+				log.Warn().Err(err).Str("approver_id", approverID).Msg("Failed to send approval push notification")
+				failed++
+			}
+		}
+
+		if n.mailer != nil && user.Username.Valid {
+			if err := n.mailer.SendSigningApproval(ctx, user.Username.String, dto.SigningApprovalNotificationPayload{
+				ApprovalID:        approval.ApprovalID,
+				KeyID:             approval.KeyID,
+				Reason:            approval.Reason,
+				RequiredApprovals: approval.RequiredApprovals,
+				ExpiresAt:         approval.ExpiresAt,
+			}); err != nil {
+				log.Warn().Err(err).Str("approver_id", approverID).Msg("Failed to send approval email")
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d approver notifications failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,394 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/signing"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// Signer 达到法定人数后执行签名（signing.Service）
+type Signer interface {
+	ThresholdSign(ctx context.Context, req *signing.SignRequest) (*signing.SignResponse, error)
+}
+
+// Notifier 通知审批人有新的签名请求等待审批
+type Notifier interface {
+	NotifyApprovers(ctx context.Context, approval *Approval) error
+}
+
+// Service 签名审批服务：保存策略要求审批的签名请求，收集审批人的投票，达到法定人数后自动执行签名
+type Service struct {
+	metadataStore storage.MetadataStore
+	policyEngine  *policy.Engine
+	signer        Signer
+	notifier      Notifier
+	expiry        time.Duration
+	now           func() time.Time
+	// execute 执行已批准的签名（默认在后台 goroutine 中，签名可能持续数分钟）
+	execute func(func())
+}
+
+// NewService 创建签名审批服务，expiry 为 0 时使用 DefaultExpiry，notifier 为空时不发送通知
+func NewService(metadataStore storage.MetadataStore, policyEngine *policy.Engine, signer Signer, notifier Notifier, expiry time.Duration) *Service {
+	if expiry <= 0 {
+		expiry = DefaultExpiry
+	}
+	return &Service{
+		metadataStore: metadataStore,
+		policyEngine:  policyEngine,
+		signer:        signer,
+		notifier:      notifier,
+		expiry:        expiry,
+		now:           time.Now,
+		execute:       func(f func()) { go f() },
+	}
+}
+
+// Submit 为策略要求审批的签名请求创建审批并通知审批人。审批人优先取决策规则的设置，其次取密钥的设置，都没有时返回 ErrNoQuorum；
+// 发起人不计入审批人，剩余审批人不足法定人数时同样返回 ErrNoQuorum
+func (s *Service) Submit(ctx context.Context, req *signing.SignRequest, decision *policy.Decision, requestedBy string) (*Approval, error) {
+	if requestedBy == "" {
+		return nil, ErrNoRequester
+	}
+	quorum, err := s.resolveQuorum(ctx, req.KeyID, decision.RuleID)
+	if err != nil {
+		return nil, err
+	}
+	approvers := make([]string, 0, len(quorum.Approvers))
+	for _, approverID := range quorum.Approvers {
+		if approverID != requestedBy {
+			approvers = append(approvers, approverID)
+		}
+	}
+	if len(approvers) < quorum.RequiredApprovals {
+		return nil, errors.Wrapf(ErrNoQuorum, "requester %s is an approver and the remaining approvers cannot reach the quorum", requestedBy)
+	}
+
+	pending := *req
+	pending.ApprovalID = ""
+	request, err := json.Marshal(&pending)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal sign request")
+	}
+
+	now := s.now().UTC()
+	stored := &storage.SigningApproval{
+		ApprovalID:        "approval-" + uuid.New().String(),
+		KeyID:             req.KeyID,
+		DecisionID:        decision.DecisionID,
+		RuleID:            decision.RuleID,
+		Reason:            decision.Reason,
+		RequestedBy:       requestedBy,
+		Approvers:         approvers,
+		RequiredApprovals: quorum.RequiredApprovals,
+		Request:           request,
+		Status:            storage.SigningApprovalStatusPending,
+		ExpiresAt:         now.Add(s.expiry),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := s.metadataStore.SaveSigningApproval(ctx, stored); err != nil {
+		return nil, errors.Wrap(err, "failed to save signing approval")
+	}
+
+	approval, err := fromStorage(stored, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("approval_id", approval.ApprovalID).
+		Str("key_id", approval.KeyID).
+		Str("decision_id", approval.DecisionID).
+		Int("required_approvals", approval.RequiredApprovals).
+		Strs("approvers", approval.Approvers).
+		Msg("Signing approval requested")
+
+	if s.notifier != nil {
+		if err := s.notifier.NotifyApprovers(ctx, approval); err != nil {
+			log.Warn().Err(err).Str("approval_id", approval.ApprovalID).Msg("Failed to notify approvers")
+		}
+	}
+
+	return approval, nil
+}
+
+// Get 获取签名审批及投票，已过期的待审批请求会被标记为 expired
+func (s *Service) Get(ctx context.Context, approvalID string) (*Approval, error) {
+	stored, err := s.load(ctx, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withVotes(ctx, stored)
+}
+
+// List 列出签名审批
+func (s *Service) List(ctx context.Context, filter *storage.SigningApprovalFilter) ([]*Approval, error) {
+	stored, err := s.metadataStore.ListSigningApprovals(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list signing approvals")
+	}
+	approvals := make([]*Approval, 0, len(stored))
+	for _, st := range stored {
+		if err := s.expireIfDue(ctx, st); err != nil {
+			return nil, err
+		}
+		approval, err := s.withVotes(ctx, st)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, approval)
+	}
+	return approvals, nil
+}
+
+// Approve 审批人批准签名请求，达到法定人数时自动执行签名
+func (s *Service) Approve(ctx context.Context, approvalID string, approverID string, comment string) (*Approval, error) {
+	return s.vote(ctx, approvalID, approverID, VoteApprove, comment)
+}
+
+// Reject 审批人拒绝签名请求，剩余审批人不足以达到法定人数时请求被拒绝
+func (s *Service) Reject(ctx context.Context, approvalID string, approverID string, comment string) (*Approval, error) {
+	return s.vote(ctx, approvalID, approverID, VoteReject, comment)
+}
+
+// SetKeyQuorum 设置密钥的审批法定人数
+func (s *Service) SetKeyQuorum(ctx context.Context, keyID string, quorum *Quorum) (*Quorum, error) {
+	if err := policy.ValidateQuorum(quorum.Approvers, quorum.RequiredApprovals); err != nil {
+		return nil, err
+	}
+	stored := &storage.KeyApprovalQuorum{
+		KeyID:             keyID,
+		Approvers:         quorum.Approvers,
+		RequiredApprovals: quorum.RequiredApprovals,
+		UpdatedAt:         s.now().UTC(),
+	}
+	if err := s.metadataStore.SaveKeyApprovalQuorum(ctx, stored); err != nil {
+		return nil, errors.Wrap(err, "failed to save key approval quorum")
+	}
+	return &Quorum{Approvers: stored.Approvers, RequiredApprovals: stored.RequiredApprovals, UpdatedAt: stored.UpdatedAt}, nil
+}
+
+// GetKeyQuorum 获取密钥的审批法定人数，未设置时返回 nil
+func (s *Service) GetKeyQuorum(ctx context.Context, keyID string) (*Quorum, error) {
+	stored, err := s.metadataStore.GetKeyApprovalQuorum(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key approval quorum")
+	}
+	if stored == nil {
+		return nil, nil
+	}
+	return &Quorum{Approvers: stored.Approvers, RequiredApprovals: stored.RequiredApprovals, UpdatedAt: stored.UpdatedAt}, nil
+}
+
+func (s *Service) resolveQuorum(ctx context.Context, keyID string, ruleID string) (*Quorum, error) {
+	if ruleID != "" {
+		rule, err := s.policyEngine.GetRule(ctx, ruleID)
+		if err == nil && rule.Params != nil && len(rule.Params.Approvers) > 0 {
+			return &Quorum{Approvers: rule.Params.Approvers, RequiredApprovals: rule.Params.RequiredApprovals}, nil
+		}
+	}
+	quorum, err := s.GetKeyQuorum(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if quorum == nil {
+		return nil, ErrNoQuorum
+	}
+	return quorum, nil
+}
+
+func (s *Service) vote(ctx context.Context, approvalID string, approverID string, vote string, comment string) (*Approval, error) {
+	stored, err := s.load(ctx, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	approval, err := s.withVotes(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+	if approval.Status != storage.SigningApprovalStatusPending {
+		return nil, ErrNotPending
+	}
+	if approverID == approval.RequestedBy {
+		return nil, ErrSelfApproval
+	}
+	if !approval.IsApprover(approverID) {
+		return nil, ErrNotApprover
+	}
+
+	// 重复投票由存储的唯一约束拒绝，不依赖先读后写的检查
+	now := s.now().UTC()
+	if err := s.metadataStore.SaveSigningApprovalVote(ctx, &storage.SigningApprovalVote{
+		ApprovalID: approvalID,
+		ApproverID: approverID,
+		Vote:       vote,
+		Comment:    comment,
+		CreatedAt:  now,
+	}); err != nil {
+		if errors.Is(err, storage.ErrDuplicateVote) {
+			return nil, ErrAlreadyVoted
+		}
+		return nil, errors.Wrap(err, "failed to save approval vote")
+	}
+	// 并发投票时先前读取的投票已过时，写入后重新统计
+	approval, err = s.withVotes(ctx, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("approval_id", approvalID).
+		Str("approver_id", approverID).
+		Str("vote", vote).
+		Int("approvals", approval.Approvals()).
+		Int("rejections", approval.Rejections()).
+		Msg("Signing approval vote recorded")
+
+	switch {
+	case approval.Approvals() >= approval.RequiredApprovals:
+		stored.Status = storage.SigningApprovalStatusApproved
+		stored.UpdatedAt = now
+		transitioned, err := s.metadataStore.TransitionSigningApproval(ctx, stored, storage.SigningApprovalStatusPending)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to approve signing approval")
+		}
+		// 并发投票时只有完成状态转换的一方执行签名
+		if transitioned {
+			s.execute(func() { s.run(stored) })
+		}
+	case approval.Rejections() > len(approval.Approvers)-approval.RequiredApprovals:
+		stored.Status = storage.SigningApprovalStatusRejected
+		stored.UpdatedAt = now
+		if _, err := s.metadataStore.TransitionSigningApproval(ctx, stored, storage.SigningApprovalStatusPending); err != nil {
+			return nil, errors.Wrap(err, "failed to reject signing approval")
+		}
+	}
+
+	return s.Get(ctx, approvalID)
+}
+
+// run 执行已批准的签名请求并记录结果
+func (s *Service) run(stored *storage.SigningApproval) {
+	ctx := context.Background()
+	logger := log.With().Str("approval_id", stored.ApprovalID).Str("key_id", stored.KeyID).Logger()
+
+	result := *stored
+	result.Status = storage.SigningApprovalStatusFailed
+
+	var req signing.SignRequest
+	if err := json.Unmarshal(stored.Request, &req); err != nil {
+		result.Error = errors.Wrap(err, "failed to unmarshal sign request").Error()
+	} else {
+		req.ApprovalID = stored.ApprovalID
+		resp, err := s.signer.ThresholdSign(ctx, &req)
+		if err != nil {
+			result.Error = err.Error()
+		} else if result.Result, err = json.Marshal(resp); err != nil {
+			result.Error = errors.Wrap(err, "failed to marshal sign response").Error()
+		} else {
+			result.Status = storage.SigningApprovalStatusExecuted
+		}
+	}
+
+	result.UpdatedAt = s.now().UTC()
+	if _, err := s.metadataStore.TransitionSigningApproval(ctx, &result, storage.SigningApprovalStatusApproved); err != nil {
+		logger.Error().Err(err).Msg("Failed to record signing approval result")
+		return
+	}
+	if result.Status == storage.SigningApprovalStatusFailed {
+		logger.Error().Str("error", result.Error).Msg("Approved signing request failed")
+		return
+	}
+	logger.Info().Msg("Approved signing request executed")
+}
+
+// load 读取签名审批，过期的待审批请求标记为 expired
+func (s *Service) load(ctx context.Context, approvalID string) (*storage.SigningApproval, error) {
+	stored, err := s.metadataStore.GetSigningApproval(ctx, approvalID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get signing approval")
+	}
+	if stored == nil {
+		return nil, ErrNotFound
+	}
+	if err := s.expireIfDue(ctx, stored); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (s *Service) expireIfDue(ctx context.Context, stored *storage.SigningApproval) error {
+	now := s.now().UTC()
+	if stored.Status != storage.SigningApprovalStatusPending || now.Before(stored.ExpiresAt) {
+		return nil
+	}
+	expired := *stored
+	expired.Status = storage.SigningApprovalStatusExpired
+	expired.UpdatedAt = now
+	transitioned, err := s.metadataStore.TransitionSigningApproval(ctx, &expired, storage.SigningApprovalStatusPending)
+	if err != nil {
+		return errors.Wrap(err, "failed to expire signing approval")
+	}
+	if transitioned {
+		*stored = expired
+		return nil
+	}
+	// 状态已被并发请求改变，重新读取
+	current, err := s.metadataStore.GetSigningApproval(ctx, stored.ApprovalID)
+	if err != nil {
+		return errors.Wrap(err, "failed to reload signing approval")
+	}
+	if current == nil {
+		return ErrNotFound
+	}
+	*stored = *current
+	return nil
+}
+
+func (s *Service) withVotes(ctx context.Context, stored *storage.SigningApproval) (*Approval, error) {
+	votes, err := s.metadataStore.ListSigningApprovalVotes(ctx, stored.ApprovalID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list approval votes")
+	}
+	return fromStorage(stored, votes)
+}
+
+func fromStorage(stored *storage.SigningApproval, votes []*storage.SigningApprovalVote) (*Approval, error) {
+	approval := &Approval{
+		ApprovalID:        stored.ApprovalID,
+		KeyID:             stored.KeyID,
+		DecisionID:        stored.DecisionID,
+		RuleID:            stored.RuleID,
+		Reason:            stored.Reason,
+		RequestedBy:       stored.RequestedBy,
+		Approvers:         stored.Approvers,
+		RequiredApprovals: stored.RequiredApprovals,
+		Status:            stored.Status,
+		Error:             stored.Error,
+		ExpiresAt:         stored.ExpiresAt,
+		CreatedAt:         stored.CreatedAt,
+		UpdatedAt:         stored.UpdatedAt,
+	}
+	approval.Request = &signing.SignRequest{}
+	if err := json.Unmarshal(stored.Request, approval.Request); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal sign request of approval %s", stored.ApprovalID)
+	}
+	if len(stored.Result) > 0 {
+		approval.Result = &signing.SignResponse{}
+		if err := json.Unmarshal(stored.Result, approval.Result); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal sign response of approval %s", stored.ApprovalID)
+		}
+	}
+	for _, v := range votes {
+		approval.Votes = append(approval.Votes, &Vote{ApproverID: v.ApproverID, Vote: v.Vote, Comment: v.Comment, CreatedAt: v.CreatedAt})
+	}
+	return approval, nil
+}
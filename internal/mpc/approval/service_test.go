@@ -0,0 +1,307 @@
+package approval
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/signing"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore 只实现审批和策略规则相关方法的内存存储
+type memoryStore struct {
+	storage.MetadataStore
+	rules     map[string]*storage.PolicyRule
+	approvals map[string]*storage.SigningApproval
+	votes     map[string][]*storage.SigningApprovalVote
+	quorums   map[string]*storage.KeyApprovalQuorum
+	// beforeVote 在写入投票前调用，模拟其他审批人的并发投票
+	beforeVote func(vote *storage.SigningApprovalVote)
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		rules:     map[string]*storage.PolicyRule{},
+		approvals: map[string]*storage.SigningApproval{},
+		votes:     map[string][]*storage.SigningApprovalVote{},
+		quorums:   map[string]*storage.KeyApprovalQuorum{},
+	}
+}
+
+func (m *memoryStore) GetPolicyRule(_ context.Context, ruleID string) (*storage.PolicyRule, error) {
+	return m.rules[ruleID], nil
+}
+
+func (m *memoryStore) SaveSigningApproval(_ context.Context, approval *storage.SigningApproval) error {
+	stored := *approval
+	m.approvals[approval.ApprovalID] = &stored
+	return nil
+}
+
+func (m *memoryStore) GetSigningApproval(_ context.Context, approvalID string) (*storage.SigningApproval, error) {
+	stored, ok := m.approvals[approvalID]
+	if !ok {
+		return nil, nil
+	}
+	approval := *stored
+	return &approval, nil
+}
+
+func (m *memoryStore) TransitionSigningApproval(_ context.Context, approval *storage.SigningApproval, fromStatus string) (bool, error) {
+	stored, ok := m.approvals[approval.ApprovalID]
+	if !ok || stored.Status != fromStatus {
+		return false, nil
+	}
+	updated := *approval
+	m.approvals[approval.ApprovalID] = &updated
+	return true, nil
+}
+
+func (m *memoryStore) SaveSigningApprovalVote(_ context.Context, vote *storage.SigningApprovalVote) error {
+	if m.beforeVote != nil {
+		hook := m.beforeVote
+		m.beforeVote = nil
+		hook(vote)
+	}
+	for _, v := range m.votes[vote.ApprovalID] {
+		if v.ApproverID == vote.ApproverID {
+			return storage.ErrDuplicateVote
+		}
+	}
+	m.votes[vote.ApprovalID] = append(m.votes[vote.ApprovalID], vote)
+	return nil
+}
+
+func (m *memoryStore) ListSigningApprovalVotes(_ context.Context, approvalID string) ([]*storage.SigningApprovalVote, error) {
+	return m.votes[approvalID], nil
+}
+
+func (m *memoryStore) SaveKeyApprovalQuorum(_ context.Context, quorum *storage.KeyApprovalQuorum) error {
+	m.quorums[quorum.KeyID] = quorum
+	return nil
+}
+
+func (m *memoryStore) GetKeyApprovalQuorum(_ context.Context, keyID string) (*storage.KeyApprovalQuorum, error) {
+	return m.quorums[keyID], nil
+}
+
+// fakeSigner 记录收到的签名请求
+type fakeSigner struct {
+	requests []*signing.SignRequest
+	err      error
+}
+
+func (f *fakeSigner) ThresholdSign(_ context.Context, req *signing.SignRequest) (*signing.SignResponse, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &signing.SignResponse{KeyID: req.KeyID, Signature: "0xsignature", RecoveryID: -1}, nil
+}
+
+func newTestService(t *testing.T, store *memoryStore, signer *fakeSigner) *Service {
+	t.Helper()
+	service := NewService(store, policy.NewEngine(store, true, nil), signer, nil, time.Hour)
+	service.execute = func(f func()) { f() }
+	return service
+}
+
+func approvalDecision(ruleID string) *policy.Decision {
+	return &policy.Decision{
+		DecisionID: "decision-1",
+		KeyID:      "key-1",
+		Decision:   policy.DecisionRequireApproval,
+		RuleID:     ruleID,
+		Reason:     "amount exceeds limit",
+	}
+}
+
+func signRequest() *signing.SignRequest {
+	return &signing.SignRequest{KeyID: "key-1", Message: []byte("hello"), MessageHex: "68656c6c6f"}
+}
+
+// TestService_SubmitQuorum 审批人优先取规则的设置，其次取密钥的设置，都没有时无法提交
+func TestService_SubmitQuorum(t *testing.T) {
+	store := newMemoryStore()
+	service := newTestService(t, store, &fakeSigner{})
+	ctx := context.Background()
+
+	_, err := service.Submit(ctx, signRequest(), approvalDecision(""), "requester")
+	assert.True(t, errors.Is(err, ErrNoQuorum))
+
+	_, err = service.SetKeyQuorum(ctx, "key-1", &Quorum{Approvers: []string{"alice", "bob"}, RequiredApprovals: 3})
+	assert.Error(t, err)
+	_, err = service.SetKeyQuorum(ctx, "key-1", &Quorum{Approvers: []string{"alice", "bob"}, RequiredApprovals: 1})
+	require.NoError(t, err)
+
+	approval, err := service.Submit(ctx, signRequest(), approvalDecision(""), "requester")
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningApprovalStatusPending, approval.Status)
+	assert.Equal(t, []string{"alice", "bob"}, approval.Approvers)
+	assert.Equal(t, 1, approval.RequiredApprovals)
+	assert.Equal(t, []byte("hello"), approval.Request.Message)
+
+	params := `{"max_amount":"100","approvers":["carol","dave","erin"],"required_approvals":2}`
+	store.rules["rule-1"] = &storage.PolicyRule{RuleID: "rule-1", RuleType: policy.RuleTypeAmountLimit, Action: policy.DecisionRequireApproval, Params: []byte(params), Enabled: true}
+	approval, err = service.Submit(ctx, signRequest(), approvalDecision("rule-1"), "requester")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carol", "dave", "erin"}, approval.Approvers)
+	assert.Equal(t, 2, approval.RequiredApprovals)
+
+	// 发起人不计入审批人，剩余审批人不足时无法提交；未认证的请求无法提交
+	_, err = service.SetKeyQuorum(ctx, "key-1", &Quorum{Approvers: []string{"alice", "requester"}, RequiredApprovals: 2})
+	require.NoError(t, err)
+	_, err = service.Submit(ctx, signRequest(), approvalDecision(""), "requester")
+	assert.True(t, errors.Is(err, ErrNoQuorum))
+	_, err = service.Submit(ctx, signRequest(), approvalDecision(""), "")
+	assert.True(t, errors.Is(err, ErrNoRequester))
+}
+
+// TestService_Approve 达到法定人数后以审批 ID 执行签名并记录结果，发起人和非审批人不能投票，每人只能投一次
+func TestService_Approve(t *testing.T) {
+	store := newMemoryStore()
+	signer := &fakeSigner{}
+	service := newTestService(t, store, signer)
+	ctx := context.Background()
+
+	_, err := service.SetKeyQuorum(ctx, "key-1", &Quorum{Approvers: []string{"alice", "bob", "requester"}, RequiredApprovals: 2})
+	require.NoError(t, err)
+	submitted, err := service.Submit(ctx, signRequest(), approvalDecision(""), "requester")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, submitted.Approvers)
+	id := submitted.ApprovalID
+
+	_, err = service.Approve(ctx, id, "requester", "")
+	assert.True(t, errors.Is(err, ErrSelfApproval))
+	_, err = service.Approve(ctx, id, "mallory", "")
+	assert.True(t, errors.Is(err, ErrNotApprover))
+	_, err = service.Approve(ctx, "approval-missing", "alice", "")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	approval, err := service.Approve(ctx, id, "alice", "looks good")
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningApprovalStatusPending, approval.Status)
+	assert.Equal(t, 1, approval.Approvals())
+	assert.Empty(t, signer.requests)
+
+	_, err = service.Approve(ctx, id, "alice", "")
+	assert.True(t, errors.Is(err, ErrAlreadyVoted))
+
+	approval, err = service.Approve(ctx, id, "bob", "")
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningApprovalStatusExecuted, approval.Status)
+	require.Len(t, signer.requests, 1)
+	assert.Equal(t, id, signer.requests[0].ApprovalID)
+	require.NotNil(t, approval.Result)
+	assert.Equal(t, "0xsignature", approval.Result.Signature)
+
+	_, err = service.Reject(ctx, id, "requester", "")
+	assert.True(t, errors.Is(err, ErrNotPending))
+}
+
+// TestService_ConcurrentVotes 投票后按存储中的投票重新统计：读取投票之后写入的并发投票同样计入法定人数，
+// 同一审批人的并发重复投票被唯一约束拒绝
+func TestService_ConcurrentVotes(t *testing.T) {
+	store := newMemoryStore()
+	signer := &fakeSigner{}
+	service := newTestService(t, store, signer)
+	ctx := context.Background()
+
+	_, err := service.SetKeyQuorum(ctx, "key-1", &Quorum{Approvers: []string{"alice", "bob", "carol"}, RequiredApprovals: 2})
+	require.NoError(t, err)
+	submitted, err := service.Submit(ctx, signRequest(), approvalDecision(""), "requester")
+	require.NoError(t, err)
+	id := submitted.ApprovalID
+
+	// alice 的投票在 bob 读取投票之后、写入之前完成
+	store.beforeVote = func(vote *storage.SigningApprovalVote) {
+		store.votes[id] = append(store.votes[id], &storage.SigningApprovalVote{ApprovalID: id, ApproverID: "alice", Vote: VoteApprove})
+	}
+	approval, err := service.Approve(ctx, id, "bob", "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, approval.Approvals())
+	assert.Equal(t, storage.SigningApprovalStatusExecuted, approval.Status)
+	require.Len(t, signer.requests, 1)
+
+	// carol 的重复投票在读取投票之后写入
+	submitted, err = service.Submit(ctx, signRequest(), approvalDecision(""), "requester")
+	require.NoError(t, err)
+	store.beforeVote = func(vote *storage.SigningApprovalVote) {
+		store.votes[vote.ApprovalID] = append(store.votes[vote.ApprovalID], &storage.SigningApprovalVote{ApprovalID: vote.ApprovalID, ApproverID: "carol", Vote: VoteReject})
+	}
+	_, err = service.Approve(ctx, submitted.ApprovalID, "carol", "")
+	assert.True(t, errors.Is(err, ErrAlreadyVoted))
+}
+
+// TestService_ApproveFailed 签名失败时审批记录失败原因
+func TestService_ApproveFailed(t *testing.T) {
+	store := newMemoryStore()
+	service := newTestService(t, store, &fakeSigner{err: errors.New("signing nodes unavailable")})
+	ctx := context.Background()
+
+	_, err := service.SetKeyQuorum(ctx, "key-1", &Quorum{Approvers: []string{"alice"}, RequiredApprovals: 1})
+	require.NoError(t, err)
+	submitted, err := service.Submit(ctx, signRequest(), approvalDecision(""), "requester")
+	require.NoError(t, err)
+
+	approval, err := service.Approve(ctx, submitted.ApprovalID, "alice", "")
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningApprovalStatusFailed, approval.Status)
+	assert.Contains(t, approval.Error, "signing nodes unavailable")
+	assert.Nil(t, approval.Result)
+}
+
+// TestService_Reject 剩余审批人不足以达到法定人数时请求被拒绝，不执行签名
+func TestService_Reject(t *testing.T) {
+	store := newMemoryStore()
+	signer := &fakeSigner{}
+	service := newTestService(t, store, signer)
+	ctx := context.Background()
+
+	_, err := service.SetKeyQuorum(ctx, "key-1", &Quorum{Approvers: []string{"alice", "bob", "carol"}, RequiredApprovals: 2})
+	require.NoError(t, err)
+	submitted, err := service.Submit(ctx, signRequest(), approvalDecision(""), "requester")
+	require.NoError(t, err)
+	id := submitted.ApprovalID
+
+	approval, err := service.Reject(ctx, id, "alice", "unknown recipient")
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningApprovalStatusPending, approval.Status)
+
+	approval, err = service.Reject(ctx, id, "bob", "")
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningApprovalStatusRejected, approval.Status)
+	assert.Equal(t, 2, approval.Rejections())
+
+	_, err = service.Approve(ctx, id, "carol", "")
+	assert.True(t, errors.Is(err, ErrNotPending))
+	assert.Empty(t, signer.requests)
+}
+
+// TestService_Expire 过期的待审批请求在读取时标记为 expired，不能再投票
+func TestService_Expire(t *testing.T) {
+	store := newMemoryStore()
+	service := newTestService(t, store, &fakeSigner{})
+	ctx := context.Background()
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	_, err := service.SetKeyQuorum(ctx, "key-1", &Quorum{Approvers: []string{"alice"}, RequiredApprovals: 1})
+	require.NoError(t, err)
+	submitted, err := service.Submit(ctx, signRequest(), approvalDecision(""), "requester")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), submitted.ExpiresAt)
+
+	now = now.Add(2 * time.Hour)
+	approval, err := service.Get(ctx, submitted.ApprovalID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningApprovalStatusExpired, approval.Status)
+
+	_, err = service.Approve(ctx, submitted.ApprovalID, "alice", "")
+	assert.True(t, errors.Is(err, ErrNotPending))
+}
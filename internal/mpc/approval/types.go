@@ -0,0 +1,82 @@
+package approval
+
+import (
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/signing"
+)
+
+// 审批人的投票
+const (
+	VoteApprove = "approve"
+	VoteReject  = "reject"
+)
+
+// DefaultExpiry 签名审批的默认有效期
+const DefaultExpiry = 24 * time.Hour
+
+// Approval 策略要求人工审批的签名请求：Approvers 中至少 RequiredApprovals 人批准后自动执行签名，
+// 拒绝人数使剩余审批人不足以达到法定人数时请求被拒绝
+type Approval struct {
+	ApprovalID        string
+	KeyID             string
+	DecisionID        string
+	RuleID            string
+	Reason            string
+	RequestedBy       string
+	Approvers         []string
+	RequiredApprovals int
+	Request           *signing.SignRequest
+	Status            string
+	Result            *signing.SignResponse // 执行成功后的签名结果
+	Error             string                // 执行失败的原因
+	Votes             []*Vote
+	ExpiresAt         time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Vote 审批人的批准或拒绝
+type Vote struct {
+	ApproverID string
+	Vote       string
+	Comment    string
+	CreatedAt  time.Time
+}
+
+// Quorum 审批法定人数（M-of-N）
+type Quorum struct {
+	Approvers         []string
+	RequiredApprovals int
+	UpdatedAt         time.Time
+}
+
+// Approvals 批准人数
+func (a *Approval) Approvals() int {
+	return a.countVotes(VoteApprove)
+}
+
+// Rejections 拒绝人数
+func (a *Approval) Rejections() int {
+	return a.countVotes(VoteReject)
+}
+
+func (a *Approval) countVotes(vote string) int {
+	count := 0
+	for _, v := range a.Votes {
+		if v.Vote == vote {
+			count++
+		}
+	}
+	return count
+}
+
+// IsApprover 用户是否为审批人
+func (a *Approval) IsApprover(userID string) bool {
+	for _, approver := range a.Approvers {
+		if approver == userID {
+			return true
+		}
+	}
+	return false
+}
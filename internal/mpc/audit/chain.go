@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+)
+
+// sealedContent 参与哈希计算的记录内容（字段顺序固定）
+type sealedContent struct {
+	PrevHash  string          `json:"prev_hash"`
+	Timestamp string          `json:"timestamp"`
+	EventType string          `json:"event_type"`
+	UserID    string          `json:"user_id"`
+	KeyID     string          `json:"key_id"`
+	NodeID    string          `json:"node_id"`
+	SessionID string          `json:"session_id"`
+	Operation string          `json:"operation"`
+	Result    string          `json:"result"`
+	Details   json.RawMessage `json:"details"`
+	IPAddress string          `json:"ip_address"`
+}
+
+// Hash 计算记录的哈希：SHA-256(上一条记录的哈希 + 记录内容)。时间戳按数据库精度（微秒）截断，
+// 详情规范化为键有序的 JSON，使写入前和从 jsonb 读回后的哈希一致
+func Hash(entry *storage.AuditLog) (string, error) {
+	details, err := canonicalJSON(entry.Details)
+	if err != nil {
+		return "", err
+	}
+	content, err := json.Marshal(&sealedContent{
+		PrevHash:  entry.PrevHash,
+		Timestamp: entry.Timestamp.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		EventType: entry.EventType,
+		UserID:    entry.UserID,
+		KeyID:     entry.KeyID,
+		NodeID:    entry.NodeID,
+		SessionID: entry.SessionID,
+		Operation: entry.Operation,
+		Result:    entry.Result,
+		Details:   details,
+		IPAddress: entry.IPAddress,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal audit log content")
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON 解析并重新序列化 JSON（对象键排序、去除空白），空值为 null
+func canonicalJSON(data []byte) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return json.RawMessage("null"), nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, errors.Wrap(err, "failed to parse audit log details")
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal audit log details")
+	}
+	return canonical, nil
+}
+
+// VerifyResult 哈希链校验结果，链完整时 BrokenID 为 0
+type VerifyResult struct {
+	// Checked 已校验的记录数（包括第一条断开的记录）
+	Checked int
+	// BrokenID 第一条断开的记录
+	BrokenID int64
+	// Reason 断开的原因
+	Reason string
+}
+
+// Valid 哈希链是否完整
+func (r *VerifyResult) Valid() bool {
+	return r.BrokenID == 0
+}
+
+// Verify 按 ID 顺序遍历审计日志并校验哈希链，返回第一条断开的记录：
+// 记录的 prev_hash 与上一条记录的 hash 不一致（中间记录被删除或插入）或记录内容与 hash 不一致（记录被修改）
+func Verify(ctx context.Context, metadataStore storage.MetadataStore) (*VerifyResult, error) {
+	result := &VerifyResult{}
+	prevHash := ""
+	var afterID int64
+	for {
+		entries, err := metadataStore.ListAuditLogs(ctx, &storage.AuditLogFilter{AfterID: afterID, Limit: streamBatchSize})
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			result.Checked++
+			if entry.PrevHash != prevHash {
+				result.BrokenID = entry.ID
+				result.Reason = "prev_hash does not match the hash of the previous entry"
+				return result, nil
+			}
+			hash, err := Hash(entry)
+			if err != nil {
+				result.BrokenID = entry.ID
+				result.Reason = err.Error()
+				return result, nil
+			}
+			if hash != entry.Hash {
+				result.BrokenID = entry.ID
+				result.Reason = "hash does not match the entry content"
+				return result, nil
+			}
+			prevHash = entry.Hash
+			afterID = entry.ID
+		}
+		if len(entries) < streamBatchSize {
+			return result, nil
+		}
+	}
+}
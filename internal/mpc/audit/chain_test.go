@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore 只实现审计日志相关方法的内存存储
+type memoryStore struct {
+	storage.MetadataStore
+	logs []*storage.AuditLog
+}
+
+func (m *memoryStore) AppendAuditLog(_ context.Context, entry *storage.AuditLog, seal func(entry *storage.AuditLog) (string, error)) error {
+	if len(m.logs) > 0 {
+		entry.PrevHash = m.logs[len(m.logs)-1].Hash
+	}
+	hash, err := seal(entry)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+	entry.ID = int64(len(m.logs) + 1)
+	m.logs = append(m.logs, entry)
+	return nil
+}
+
+func (m *memoryStore) ListAuditLogs(_ context.Context, filter *storage.AuditLogFilter) ([]*storage.AuditLog, error) {
+	var logs []*storage.AuditLog
+	for _, entry := range m.logs {
+		if entry.ID <= filter.AfterID {
+			continue
+		}
+		if filter.Limit > 0 && len(logs) >= filter.Limit {
+			break
+		}
+		logs = append(logs, entry)
+	}
+	return logs, nil
+}
+
+func newTestLogger(store *memoryStore) *Logger {
+	logger := NewLogger(store, true)
+	now := time.Date(2026, 1, 12, 9, 0, 0, 123456789, time.UTC)
+	logger.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	return logger
+}
+
+func recordEvents(logger *Logger, count int) {
+	for i := 0; i < count; i++ {
+		logger.Record(context.Background(), &Event{
+			EventType: EventTypeSigning,
+			Operation: OperationSign,
+			Result:    ResultSuccess,
+			KeyID:     "key-1",
+			Details:   map[string]interface{}{"message_type": "transaction", "index": i},
+		})
+	}
+}
+
+// TestVerify 完整的哈希链校验通过，执行者默认为 system
+func TestVerify(t *testing.T) {
+	store := &memoryStore{}
+	recordEvents(newTestLogger(store), 3)
+	require.Len(t, store.logs, 3)
+	assert.Empty(t, store.logs[0].PrevHash)
+	assert.Equal(t, store.logs[0].Hash, store.logs[1].PrevHash)
+	assert.Equal(t, ActorSystem, store.logs[0].UserID)
+
+	result, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	assert.True(t, result.Valid())
+	assert.Equal(t, 3, result.Checked)
+}
+
+// TestVerifyModified 记录被修改时在该记录处断开
+func TestVerifyModified(t *testing.T) {
+	store := &memoryStore{}
+	recordEvents(newTestLogger(store), 3)
+	store.logs[1].Result = ResultFailure
+
+	result, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+	assert.Equal(t, store.logs[1].ID, result.BrokenID)
+	assert.Equal(t, 2, result.Checked)
+}
+
+// TestVerifyDeleted 中间记录被删除时在下一条记录处断开
+func TestVerifyDeleted(t *testing.T) {
+	store := &memoryStore{}
+	recordEvents(newTestLogger(store), 3)
+	store.logs = append(store.logs[:1], store.logs[2:]...)
+
+	result, err := Verify(context.Background(), store)
+	require.NoError(t, err)
+	assert.False(t, result.Valid())
+	assert.Equal(t, int64(3), result.BrokenID)
+}
+
+// TestHashCanonicalDetails 详情的键顺序和空白（jsonb 读回后会规范化）不影响哈希
+func TestHashCanonicalDetails(t *testing.T) {
+	entry := &storage.AuditLog{
+		Timestamp: time.Date(2026, 1, 12, 9, 0, 0, 123456789, time.UTC),
+		EventType: EventTypeKey,
+		Operation: OperationCreateKey,
+		Details:   []byte(`{"threshold": 2, "chain_type": "ethereum", "amount": 12345678901234567890}`),
+	}
+	hash, err := Hash(entry)
+	require.NoError(t, err)
+
+	stored := *entry
+	stored.Timestamp = time.Date(2026, 1, 12, 9, 0, 0, 123456000, time.UTC)
+	stored.Details = []byte(`{"amount":12345678901234567890,"chain_type":"ethereum","threshold":2}`)
+	storedHash, err := Hash(&stored)
+	require.NoError(t, err)
+	assert.Equal(t, hash, storedHash)
+}
+
+// TestLogger_Stream 分批读取从游标之后的全部记录，忽略 Limit
+func TestLogger_Stream(t *testing.T) {
+	store := &memoryStore{}
+	logger := newTestLogger(store)
+	recordEvents(logger, streamBatchSize+5)
+
+	var ids []int64
+	batches := 0
+	err := logger.Stream(context.Background(), &storage.AuditLogFilter{AfterID: 3, Limit: 1}, func(entries []*storage.AuditLog) error {
+		batches++
+		for _, entry := range entries {
+			ids = append(ids, entry.ID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, batches)
+	require.Len(t, ids, streamBatchSize+2)
+	assert.Equal(t, int64(4), ids[0])
+	assert.Equal(t, int64(streamBatchSize+5), ids[len(ids)-1])
+}
@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/auth"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+// streamBatchSize 导出审计日志时每次读取的记录数
+const streamBatchSize = 1000
+
+// Logger MPC 审计日志：记录密钥、签名、会话和节点操作，写入 audit_logs 并与上一条记录哈希链接
+type Logger struct {
+	metadataStore storage.MetadataStore
+	enabled       bool
+	now           func() time.Time
+}
+
+// NewLogger 创建审计日志，enabled 为 false 时不记录
+func NewLogger(metadataStore storage.MetadataStore, enabled bool) *Logger {
+	return &Logger{
+		metadataStore: metadataStore,
+		enabled:       enabled,
+		now:           time.Now,
+	}
+}
+
+// Enabled 审计日志是否启用（日志为空时视为未启用）
+func (l *Logger) Enabled() bool {
+	return l != nil && l.enabled
+}
+
+// Record 记录审计事件，执行者为上下文中的认证用户（没有时为 system），IP 为请求方地址。
+// 写入失败只记录错误日志，不影响被审计的操作
+func (l *Logger) Record(ctx context.Context, event *Event) {
+	if !l.Enabled() {
+		return
+	}
+
+	entry := &storage.AuditLog{
+		Timestamp: l.now().UTC().Truncate(time.Microsecond),
+		EventType: event.EventType,
+		UserID:    ActorSystem,
+		KeyID:     event.KeyID,
+		NodeID:    event.NodeID,
+		SessionID: event.SessionID,
+		Operation: event.Operation,
+		Result:    event.Result,
+		IPAddress: util.ClientIPFromContext(ctx),
+	}
+	if user := auth.UserFromContext(ctx); user != nil {
+		entry.UserID = user.ID
+	}
+	if len(event.Details) > 0 {
+		details, err := json.Marshal(event.Details)
+		if err != nil {
+			log.Error().Err(err).Str("operation", event.Operation).Msg("Failed to marshal audit log details")
+			return
+		}
+		entry.Details = details
+	}
+
+	if err := l.metadataStore.AppendAuditLog(ctx, entry, Hash); err != nil {
+		log.Error().
+			Err(err).
+			Str("event_type", event.EventType).
+			Str("operation", event.Operation).
+			Str("key_id", event.KeyID).
+			Str("session_id", event.SessionID).
+			Msg("Failed to write audit log")
+	}
+}
+
+// List 按过滤条件列出审计日志，未启用审计时也可查询已有记录
+func (l *Logger) List(ctx context.Context, filter *storage.AuditLogFilter) ([]*storage.AuditLog, error) {
+	return l.metadataStore.ListAuditLogs(ctx, filter)
+}
+
+// Stream 按 ID 顺序分批读取过滤条件匹配的全部审计日志（从 filter.AfterID 之后开始，忽略 filter.Limit），
+// 每批调用一次 fn，用于导出
+func (l *Logger) Stream(ctx context.Context, filter *storage.AuditLogFilter, fn func(entries []*storage.AuditLog) error) error {
+	batch := *filter
+	batch.Limit = streamBatchSize
+	for {
+		entries, err := l.metadataStore.ListAuditLogs(ctx, &batch)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			if err := fn(entries); err != nil {
+				return err
+			}
+			batch.AfterID = entries[len(entries)-1].ID
+		}
+		if len(entries) < streamBatchSize {
+			return nil
+		}
+	}
+}
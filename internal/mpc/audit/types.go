@@ -0,0 +1,59 @@
+package audit
+
+// 事件类型
+const (
+	EventTypeKey     = "key"
+	EventTypeSigning = "signing"
+	EventTypeSession = "session"
+	EventTypeNode    = "node"
+	EventTypePolicy  = "policy"
+)
+
+// 操作
+const (
+	OperationCreateKey           = "create_key"
+	OperationDeleteKey           = "delete_key"
+	OperationDisableKey          = "disable_key"
+	OperationEnableKey           = "enable_key"
+	OperationScheduleKeyDeletion = "schedule_key_deletion"
+	OperationCancelKeyDeletion   = "cancel_key_deletion"
+	OperationDestroyKey          = "destroy_key"
+	OperationRefreshKey          = "refresh_key"
+	OperationGenerateAddress     = "generate_address"
+	OperationSign                = "sign"
+	OperationSessionState        = "session_state_change"
+	OperationRegisterNode        = "register_node"
+	OperationCreatePolicyRule    = "create_policy_rule"
+	OperationDeletePolicyRule    = "delete_policy_rule"
+)
+
+// 结果
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	// ResultDenied 被交易策略拒绝或要求审批
+	ResultDenied = "denied"
+)
+
+// ActorSystem 没有认证用户的操作（后台任务、节点间 gRPC 调用）的执行者
+const ActorSystem = "system"
+
+// Event 待记录的审计事件，执行者和 IP 从请求上下文中读取
+type Event struct {
+	EventType string
+	Operation string
+	Result    string
+	KeyID     string
+	NodeID    string
+	SessionID string
+	// Details 事件详情，序列化为 JSON
+	Details map[string]interface{}
+}
+
+// ResultOf 根据操作的错误返回结果
+func ResultOf(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
@@ -1,90 +1,569 @@
 package chain
 
 import (
-	"crypto/sha256"
+	"bytes"
+	"context"
 	"encoding/hex"
-	"fmt"
+	"math/big"
+	"strings"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
-	"github.com/btcsuite/btcutil/base58"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/pkg/errors"
-	"golang.org/x/crypto/ripemd160"
 )
 
+// Bitcoin 输入脚本类型
+const (
+	BitcoinScriptTypeP2WPKH = "p2wpkh"
+	BitcoinScriptTypeP2TR   = "p2tr"
+)
+
+// bitcoinDustLimit 输出金额下限（satoshi），低于该值的输出不会被节点转发
+const bitcoinDustLimit = 546
+
+// Bitcoin 地址类型
+const (
+	BitcoinAddressTypeP2PKH      = "p2pkh"
+	BitcoinAddressTypeP2SHP2WPKH = "p2sh-p2wpkh"
+	BitcoinAddressTypeP2WPKH     = "p2wpkh"
+	BitcoinAddressTypeP2TR       = "p2tr"
+)
+
+// Bitcoin 网络
+const (
+	BitcoinNetworkMainnet = "mainnet"
+	BitcoinNetworkTestnet = "testnet"
+	BitcoinNetworkSignet  = "signet"
+	BitcoinNetworkRegtest = "regtest"
+)
+
+// BitcoinNetworkParams 返回网络名称对应的链参数，为空时使用主网
+func BitcoinNetworkParams(network string) (*chaincfg.Params, error) {
+	switch strings.ToLower(network) {
+	case "", BitcoinNetworkMainnet:
+		return &chaincfg.MainNetParams, nil
+	case BitcoinNetworkTestnet:
+		return &chaincfg.TestNet3Params, nil
+	case BitcoinNetworkSignet:
+		return &chaincfg.SigNetParams, nil
+	case BitcoinNetworkRegtest:
+		return &chaincfg.RegressionNetParams, nil
+	default:
+		return nil, errors.Errorf("unsupported bitcoin network %s", network)
+	}
+}
+
 // BitcoinAdapter 基于 btcsuite 的简单实现
 type BitcoinAdapter struct {
-	params *chaincfg.Params
+	params      *chaincfg.Params
+	addressType string
 }
 
-// NewBitcoinAdapter 创建一个 Bitcoin 适配器
+// NewBitcoinAdapter 创建一个 Bitcoin 适配器，默认生成原生 SegWit（P2WPKH）地址
 func NewBitcoinAdapter(params *chaincfg.Params) *BitcoinAdapter {
 	if params == nil {
 		params = &chaincfg.MainNetParams
 	}
-	return &BitcoinAdapter{params: params}
+	return &BitcoinAdapter{params: params, addressType: BitcoinAddressTypeP2WPKH}
+}
+
+// ValidateBitcoinAddressType 校验地址类型是否受支持
+func ValidateBitcoinAddressType(addressType string) error {
+	switch strings.ToLower(addressType) {
+	case BitcoinAddressTypeP2PKH, BitcoinAddressTypeP2SHP2WPKH, BitcoinAddressTypeP2WPKH, BitcoinAddressTypeP2TR:
+		return nil
+	default:
+		return errors.Errorf("unsupported bitcoin address type %s", addressType)
+	}
 }
 
-// GenerateAddress 根据公钥生成标准的 Bitcoin P2PKH 地址（Base58 编码）
+// WithAddressType 返回生成指定类型地址的适配器，为空时保持默认类型
+func (a *BitcoinAdapter) WithAddressType(addressType string) (*BitcoinAdapter, error) {
+	if addressType == "" {
+		return a, nil
+	}
+	if err := ValidateBitcoinAddressType(addressType); err != nil {
+		return nil, err
+	}
+	return &BitcoinAdapter{params: a.params, addressType: strings.ToLower(addressType)}, nil
+}
+
+// GenerateAddress 根据公钥和适配器的地址类型生成地址：
+// p2pkh 为 Base58 地址，p2sh-p2wpkh 为嵌套 SegWit 地址，p2wpkh 为 bech32 地址，
+// p2tr 为按 BIP-86 调整（无脚本路径）输出公钥的 bech32m 地址。SegWit 地址总是使用压缩公钥
 func (a *BitcoinAdapter) GenerateAddress(pubKey []byte) (string, error) {
 	if len(pubKey) == 0 {
 		return "", errors.New("public key is required")
 	}
 
-	// 1. 计算公钥哈希：SHA256 -> RIPEMD160
-	sha := sha256.Sum256(pubKey)
-	ripemd := ripemd160.New()
-	if _, err := ripemd.Write(sha[:]); err != nil {
-		return "", errors.Wrap(err, "failed to hash public key")
+	var (
+		address btcutil.Address
+		err     error
+	)
+	switch a.addressType {
+	case BitcoinAddressTypeP2PKH:
+		address, err = btcutil.NewAddressPubKeyHash(btcutil.Hash160(pubKey), a.params)
+	case BitcoinAddressTypeP2SHP2WPKH, BitcoinAddressTypeP2WPKH:
+		key, parseErr := btcec.ParsePubKey(pubKey)
+		if parseErr != nil {
+			return "", errors.Wrap(parseErr, "invalid secp256k1 public key")
+		}
+		witnessProgram := btcutil.Hash160(key.SerializeCompressed())
+		if a.addressType == BitcoinAddressTypeP2WPKH {
+			address, err = btcutil.NewAddressWitnessPubKeyHash(witnessProgram, a.params)
+			break
+		}
+		// 赎回脚本：OP_0 <20 字节公钥哈希>
+		redeemScript := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, witnessProgram...)
+		address, err = btcutil.NewAddressScriptHash(redeemScript, a.params)
+	case BitcoinAddressTypeP2TR:
+		internalKey, parseErr := parseTaprootInternalKey(pubKey)
+		if parseErr != nil {
+			return "", parseErr
+		}
+		outputKey := txscript.ComputeTaprootKeyNoScript(internalKey)
+		address, err = btcutil.NewAddressTaproot(schnorr.SerializePubKey(outputKey), a.params)
+	default:
+		return "", errors.Errorf("unsupported bitcoin address type %s", a.addressType)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate address")
 	}
-	hash160 := ripemd.Sum(nil)
+	return address.EncodeAddress(), nil
+}
 
-	// 2. 获取版本字节（根据网络参数）
-	// chaincfg.Params.PubKeyHashAddrID 包含 P2PKH 地址的版本字节
-	versionByte := byte(0x00) // 主网默认
-	if a.params != nil && a.params.PubKeyHashAddrID != 0 {
-		versionByte = a.params.PubKeyHashAddrID
+// BuildTransaction 按 BIP-174 构建未签名的 PSBT，并计算每个输入的签名摘要
+// （P2WPKH 输入使用 BIP-143 SIGHASH_ALL，P2TR 输入使用 BIP-341 SIGHASH_DEFAULT）。
+// FeeRate（sat/vB）必填，按估算的交易大小计算手续费，找零超过粉尘阈值时输出到 From 地址，
+// 不足粉尘阈值的余额计入手续费（多付的手续费不超过粉尘阈值）
+func (a *BitcoinAdapter) BuildTransaction(req *BuildTxRequest) (*Transaction, error) {
+	if req == nil {
+		return nil, errors.New("build request is nil")
+	}
+	if len(req.Inputs) == 0 {
+		return nil, errors.New("at least one input is required")
 	}
 
-	// 3. 添加版本字节
-	versionedPayload := append([]byte{versionByte}, hash160...)
+	outPoints := make([]*wire.OutPoint, 0, len(req.Inputs))
+	sequences := make([]uint32, 0, len(req.Inputs))
+	var totalIn int64
+	for i, utxo := range req.Inputs {
+		if utxo == nil {
+			return nil, errors.Errorf("input %d is nil", i)
+		}
+		if utxo.Amount <= 0 {
+			return nil, errors.Errorf("input %d amount must be positive", i)
+		}
+		if _, err := bitcoinScriptType(utxo.ScriptPubKey); err != nil {
+			return nil, errors.Wrapf(err, "input %d", i)
+		}
+		hash, err := chainhash.NewHashFromStr(utxo.TxID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "input %d has invalid txid", i)
+		}
+		outPoints = append(outPoints, wire.NewOutPoint(hash, utxo.Vout))
+		sequence := utxo.Sequence
+		if sequence == 0 {
+			sequence = wire.MaxTxInSequenceNum - 2
+		}
+		sequences = append(sequences, sequence)
+		totalIn += utxo.Amount
+	}
 
-	// 4. 计算校验和：SHA256(SHA256(version + hash160)) 的前4字节
-	firstSHA := sha256.Sum256(versionedPayload)
-	secondSHA := sha256.Sum256(firstSHA[:])
-	checksum := secondSHA[:4]
+	outputs := req.Outputs
+	if req.To != "" && req.Amount != nil {
+		if !req.Amount.IsInt64() {
+			return nil, errors.New("amount exceeds the satoshi range")
+		}
+		outputs = append(append([]*TxOutput(nil), outputs...), &TxOutput{Address: req.To, Amount: req.Amount.Int64()})
+	}
+	if len(outputs) == 0 {
+		return nil, errors.New("at least one output is required")
+	}
+	txOuts := make([]*wire.TxOut, 0, len(outputs)+1)
+	var totalOut int64
+	for i, output := range outputs {
+		if output == nil {
+			return nil, errors.Errorf("output %d is nil", i)
+		}
+		pkScript, err := a.addressScript(output.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "output %d", i)
+		}
+		if output.Amount < bitcoinDustLimit {
+			return nil, errors.Errorf("output %d amount %d is below the dust limit", i, output.Amount)
+		}
+		txOuts = append(txOuts, wire.NewTxOut(output.Amount, pkScript))
+		totalOut += output.Amount
+	}
+	if totalOut > totalIn {
+		return nil, errors.Errorf("outputs (%d) exceed inputs (%d)", totalOut, totalIn)
+	}
+
+	// 没有费率时无法计算找零，输入超出输出的部分会全部成为矿工费
+	if req.FeeRate == 0 {
+		return nil, errors.New("fee rate is required")
+	}
+	changeScript, err := a.addressScript(req.From)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid change address")
+	}
+	withChange := append(append([]*wire.TxOut(nil), txOuts...), wire.NewTxOut(0, changeScript))
+	fee := int64(req.FeeRate) * estimateBitcoinVSize(req.Inputs, withChange)
+	change := totalIn - totalOut - fee
+	if change >= bitcoinDustLimit {
+		withChange[len(withChange)-1].Value = change
+		txOuts = withChange
+	} else if fee = int64(req.FeeRate) * estimateBitcoinVSize(req.Inputs, txOuts); totalIn-totalOut < fee {
+		return nil, errors.Errorf("insufficient funds: inputs %d, outputs %d, fee %d", totalIn, totalOut, fee)
+	}
 
-	// 5. 拼接：版本字节 + hash160 + 校验和
-	fullPayload := append(versionedPayload, checksum...)
+	packet, err := psbt.New(outPoints, txOuts, 2, req.LockTime, sequences)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create psbt")
+	}
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create psbt updater")
+	}
+	for i, utxo := range req.Inputs {
+		if err := updater.AddInWitnessUtxo(wire.NewTxOut(utxo.Amount, utxo.ScriptPubKey), i); err != nil {
+			return nil, errors.Wrapf(err, "failed to add witness utxo for input %d", i)
+		}
+		scriptType, _ := bitcoinScriptType(utxo.ScriptPubKey)
+		switch scriptType {
+		case BitcoinScriptTypeP2WPKH:
+			if err := updater.AddInSighashType(txscript.SigHashAll, i); err != nil {
+				return nil, errors.Wrapf(err, "failed to add sighash type for input %d", i)
+			}
+		case BitcoinScriptTypeP2TR:
+			internalKey, err := parseTaprootInternalKey(utxo.PublicKey)
+			if err != nil {
+				return nil, errors.Wrapf(err, "input %d", i)
+			}
+			packet.Inputs[i].TaprootInternalKey = schnorr.SerializePubKey(internalKey)
+			packet.Inputs[i].TaprootMerkleRoot = utxo.TaprootMerkleRoot
+		}
+	}
 
-	// 6. Base58 编码生成最终地址
-	address := base58.Encode(fullPayload)
+	keys := make([][]byte, len(req.Inputs))
+	for i, utxo := range req.Inputs {
+		keys[i] = utxo.PublicKey
+	}
+	sigHashes, err := bitcoinInputSigHashes(packet, keys)
+	if err != nil {
+		return nil, err
+	}
 
-	return address, nil
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode psbt")
+	}
+	var raw bytes.Buffer
+	if err := packet.UnsignedTx.SerializeNoWitness(&raw); err != nil {
+		return nil, errors.Wrap(err, "failed to serialize unsigned transaction")
+	}
+	return &Transaction{
+		Raw:       hex.EncodeToString(raw.Bytes()),
+		Hash:      packet.UnsignedTx.TxHash().String(),
+		PSBT:      encoded,
+		SigHashes: sigHashes,
+	}, nil
 }
 
-// BuildTransaction 构建一个简单的原始交易描述并返回双哈希
-func (a *BitcoinAdapter) BuildTransaction(req *BuildTxRequest) (*Transaction, error) {
-	if req == nil {
-		return nil, errors.New("build request is nil")
+// SignTransaction 对 BuildTransaction 生成的 PSBT 逐个输入门限签名，验证签名后完成（finalize）PSBT
+// 并提取可广播的原始交易。签名摘要根据 PSBT 重新计算，签名公钥取自 tx.SigHashes 并与输入脚本核对
+func (a *BitcoinAdapter) SignTransaction(ctx context.Context, tx *Transaction, signer InputSigner) (*Transaction, error) {
+	if tx == nil || tx.PSBT == "" {
+		return nil, errors.New("psbt is required")
+	}
+	if signer == nil {
+		return nil, errors.New("input signer is required")
 	}
-	if req.Amount == nil {
-		return nil, errors.New("amount is required")
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(tx.PSBT), true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode psbt")
 	}
 
-	raw := fmt.Sprintf(
-		"btc-tx|from:%s|to:%s|amount:%s|nonce:%d|feerate:%d|data:%s",
-		req.From,
-		req.To,
-		req.Amount.String(),
-		req.Nonce,
-		req.FeeRate,
-		hex.EncodeToString(req.Data),
-	)
+	keys := make([][]byte, len(packet.Inputs))
+	for _, sigHash := range tx.SigHashes {
+		if sigHash == nil || sigHash.Index < 0 || sigHash.Index >= len(keys) {
+			return nil, errors.New("signature hash refers to an unknown input")
+		}
+		keys[sigHash.Index] = sigHash.PublicKey
+	}
+	sigHashes, err := bitcoinInputSigHashes(packet, keys)
+	if err != nil {
+		return nil, err
+	}
 
-	hash := chainhash.DoubleHashH([]byte(raw)).String()
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create psbt updater")
+	}
+	for _, sigHash := range sigHashes {
+		signature, err := signer(ctx, sigHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to sign input %d", sigHash.Index)
+		}
+		switch sigHash.ScriptType {
+		case BitcoinScriptTypeP2WPKH:
+			der, err := verifyWitnessSignature(signature, sigHash)
+			if err != nil {
+				return nil, errors.Wrapf(err, "input %d", sigHash.Index)
+			}
+			outcome, err := updater.Sign(sigHash.Index, append(der, byte(txscript.SigHashAll)), sigHash.PublicKey, nil, nil)
+			if err != nil || outcome != psbt.SignSuccesful {
+				return nil, errors.Wrapf(err, "failed to add signature for input %d", sigHash.Index)
+			}
+		case BitcoinScriptTypeP2TR:
+			if err := verifyTaprootSignature(signature, sigHash); err != nil {
+				return nil, errors.Wrapf(err, "input %d", sigHash.Index)
+			}
+			packet.Inputs[sigHash.Index].TaprootKeySpendSig = signature
+		}
+	}
+
+	if err := psbt.MaybeFinalizeAll(packet); err != nil {
+		return nil, errors.Wrap(err, "failed to finalize psbt")
+	}
+	signedTx, err := psbt.Extract(packet)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract transaction")
+	}
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode psbt")
+	}
+	var raw bytes.Buffer
+	if err := signedTx.Serialize(&raw); err != nil {
+		return nil, errors.Wrap(err, "failed to serialize transaction")
+	}
 	return &Transaction{
-		Raw:  raw,
-		Hash: hash,
+		Raw:  hex.EncodeToString(raw.Bytes()),
+		Hash: signedTx.TxHash().String(),
+		PSBT: encoded,
 	}, nil
 }
+
+// DecodeTransaction 解析 BuildTransaction 生成的 PSBT：每个非找零输出记为一笔 Transfer
+// （输出脚本与某个输入相同视为找零），输入总额与输出总额之差记为 Fee，SigningPayloads 为各输入的签名摘要
+func (a *BitcoinAdapter) DecodeTransaction(unsigned string) (*DecodedTransaction, error) {
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(unsigned), true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode psbt")
+	}
+	tx := packet.UnsignedTx
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut, len(tx.TxIn))
+	for i, txIn := range tx.TxIn {
+		if packet.Inputs[i].WitnessUtxo == nil {
+			return nil, errors.Errorf("input %d has no witness utxo", i)
+		}
+		prevOuts[txIn.PreviousOutPoint] = packet.Inputs[i].WitnessUtxo
+	}
+	fetcher := txscript.NewMultiPrevOutFetcher(prevOuts)
+	txSigHashes := txscript.NewTxSigHashes(tx, fetcher)
+
+	// 签名摘要承诺了输入金额（BIP-143/BIP-341），PSBT 中的 WitnessUtxo 金额与实际不符时签名无效
+	var totalIn, totalOut int64
+	decoded := &DecodedTransaction{}
+	for i := range tx.TxIn {
+		prevOut := packet.Inputs[i].WitnessUtxo
+		totalIn += prevOut.Value
+		scriptType, err := bitcoinScriptType(prevOut.PkScript)
+		if err != nil {
+			return nil, errors.Wrapf(err, "input %d", i)
+		}
+		var sigHash []byte
+		if scriptType == BitcoinScriptTypeP2WPKH {
+			sigHash, err = txscript.CalcWitnessSigHash(prevOut.PkScript, txSigHashes, txscript.SigHashAll, tx, i, prevOut.Value)
+		} else {
+			sigHash, err = txscript.CalcTaprootSignatureHash(txSigHashes, txscript.SigHashDefault, tx, i, fetcher)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute signature hash for input %d", i)
+		}
+		decoded.SigningPayloads = append(decoded.SigningPayloads, sigHash)
+	}
+
+outputs:
+	for i, txOut := range tx.TxOut {
+		totalOut += txOut.Value
+		for _, prevOut := range prevOuts {
+			if bytes.Equal(txOut.PkScript, prevOut.PkScript) {
+				continue outputs
+			}
+		}
+		_, addresses, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, a.params)
+		if err != nil || len(addresses) != 1 {
+			return nil, errors.Errorf("output %d has an unsupported script", i)
+		}
+		decoded.Transfers = append(decoded.Transfers, &Transfer{To: addresses[0].EncodeAddress(), Amount: big.NewInt(txOut.Value)})
+	}
+	if totalIn < totalOut {
+		return nil, errors.Errorf("outputs %d exceed inputs %d", totalOut, totalIn)
+	}
+	decoded.Fee = big.NewInt(totalIn - totalOut)
+	return decoded, nil
+}
+
+// addressScript 解析当前网络的地址并返回其输出脚本
+func (a *BitcoinAdapter) addressScript(address string) ([]byte, error) {
+	if address == "" {
+		return nil, errors.New("address is required")
+	}
+	decoded, err := btcutil.DecodeAddress(address, a.params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address %s", address)
+	}
+	if !decoded.IsForNet(a.params) {
+		return nil, errors.Errorf("address %s is not for network %s", address, a.params.Name)
+	}
+	return txscript.PayToAddrScript(decoded)
+}
+
+// bitcoinScriptType 返回输出脚本类型，仅支持 P2WPKH 和 P2TR
+func bitcoinScriptType(pkScript []byte) (string, error) {
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(pkScript):
+		return BitcoinScriptTypeP2WPKH, nil
+	case txscript.IsPayToTaproot(pkScript):
+		return BitcoinScriptTypeP2TR, nil
+	default:
+		return "", errors.New("unsupported script type, only P2WPKH and P2TR inputs are supported")
+	}
+}
+
+// parseTaprootInternalKey 解析 33 字节压缩或 32 字节 x-only 的 Taproot 内部公钥
+func parseTaprootInternalKey(pubKey []byte) (*btcec.PublicKey, error) {
+	switch len(pubKey) {
+	case schnorr.PubKeyBytesLen:
+		return schnorr.ParsePubKey(pubKey)
+	case btcec.PubKeyBytesLenCompressed:
+		key, err := btcec.ParsePubKey(pubKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid taproot internal key")
+		}
+		return key, nil
+	default:
+		return nil, errors.Errorf("invalid taproot internal key length %d", len(pubKey))
+	}
+}
+
+// bitcoinInputSigHashes 计算 PSBT 每个输入的签名摘要，并核对公钥与输入脚本一致
+func bitcoinInputSigHashes(packet *psbt.Packet, keys [][]byte) ([]*InputSigHash, error) {
+	tx := packet.UnsignedTx
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut, len(tx.TxIn))
+	for i, txIn := range tx.TxIn {
+		if packet.Inputs[i].WitnessUtxo == nil {
+			return nil, errors.Errorf("input %d has no witness utxo", i)
+		}
+		prevOuts[txIn.PreviousOutPoint] = packet.Inputs[i].WitnessUtxo
+	}
+	fetcher := txscript.NewMultiPrevOutFetcher(prevOuts)
+	txSigHashes := txscript.NewTxSigHashes(tx, fetcher)
+
+	sigHashes := make([]*InputSigHash, 0, len(tx.TxIn))
+	for i := range tx.TxIn {
+		prevOut := packet.Inputs[i].WitnessUtxo
+		scriptType, err := bitcoinScriptType(prevOut.PkScript)
+		if err != nil {
+			return nil, errors.Wrapf(err, "input %d", i)
+		}
+		sigHash := &InputSigHash{Index: i, ScriptType: scriptType, PublicKey: keys[i]}
+
+		switch scriptType {
+		case BitcoinScriptTypeP2WPKH:
+			if len(keys[i]) != btcec.PubKeyBytesLenCompressed {
+				return nil, errors.Errorf("input %d requires a compressed public key", i)
+			}
+			if !bytes.Equal(prevOut.PkScript[2:], btcutil.Hash160(keys[i])) {
+				return nil, errors.Errorf("input %d public key does not match its script", i)
+			}
+			sigHash.SigHash, err = txscript.CalcWitnessSigHash(prevOut.PkScript, txSigHashes, txscript.SigHashAll, tx, i, prevOut.Value)
+		case BitcoinScriptTypeP2TR:
+			if len(keys[i]) == 0 {
+				keys[i] = packet.Inputs[i].TaprootInternalKey
+				sigHash.PublicKey = keys[i]
+			}
+			internalKey, keyErr := parseTaprootInternalKey(keys[i])
+			if keyErr != nil {
+				return nil, errors.Wrapf(keyErr, "input %d", i)
+			}
+			if len(packet.Inputs[i].TaprootInternalKey) > 0 && !bytes.Equal(packet.Inputs[i].TaprootInternalKey, schnorr.SerializePubKey(internalKey)) {
+				return nil, errors.Errorf("input %d internal key does not match the psbt", i)
+			}
+			sigHash.TaprootMerkleRoot = packet.Inputs[i].TaprootMerkleRoot
+			outputKey := txscript.ComputeTaprootOutputKey(internalKey, sigHash.TaprootMerkleRoot)
+			if !bytes.Equal(prevOut.PkScript[2:], schnorr.SerializePubKey(outputKey)) {
+				return nil, errors.Errorf("input %d internal key does not match its script", i)
+			}
+			sigHash.SigHash, err = txscript.CalcTaprootSignatureHash(txSigHashes, txscript.SigHashDefault, tx, i, fetcher)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute signature hash for input %d", i)
+		}
+		sigHashes = append(sigHashes, sigHash)
+	}
+	return sigHashes, nil
+}
+
+// verifyWitnessSignature 验证 P2WPKH 输入的 ECDSA 签名，返回规范化（low-S）的 DER 编码
+func verifyWitnessSignature(signature []byte, sigHash *InputSigHash) ([]byte, error) {
+	pubKey, err := btcec.ParsePubKey(sigHash.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	sig, err := btcecdsa.ParseDERSignature(signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid DER signature")
+	}
+	if !sig.Verify(sigHash.SigHash, pubKey) {
+		return nil, errors.New("ECDSA signature verification failed")
+	}
+	return sig.Serialize(), nil
+}
+
+// verifyTaprootSignature 使用调整后的输出公钥验证 P2TR key-path 的 BIP-340 签名
+func verifyTaprootSignature(signature []byte, sigHash *InputSigHash) error {
+	if len(signature) != schnorr.SignatureSize {
+		return errors.Errorf("invalid schnorr signature length %d", len(signature))
+	}
+	sig, err := schnorr.ParseSignature(signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid schnorr signature")
+	}
+	internalKey, err := parseTaprootInternalKey(sigHash.PublicKey)
+	if err != nil {
+		return err
+	}
+	outputKey := txscript.ComputeTaprootOutputKey(internalKey, sigHash.TaprootMerkleRoot)
+	if !sig.Verify(sigHash.SigHash, outputKey) {
+		return errors.New("schnorr signature verification failed")
+	}
+	return nil
+}
+
+// estimateBitcoinVSize 估算签名后交易的虚拟大小（vbyte）
+func estimateBitcoinVSize(inputs []*UTXO, outputs []*wire.TxOut) int64 {
+	// version、locktime、输入输出数量和 segwit 标记
+	vsize := int64(11)
+	for _, utxo := range inputs {
+		if txscript.IsPayToTaproot(utxo.ScriptPubKey) {
+			vsize += 58
+		} else {
+			vsize += 68
+		}
+	}
+	for _, output := range outputs {
+		vsize += int64(8 + 1 + len(output.PkScript))
+	}
+	return vsize
+}
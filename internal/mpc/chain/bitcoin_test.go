@@ -0,0 +1,319 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBitcoinKey 测试用的本地密钥，代替门限签名
+type testBitcoinKey struct {
+	priv     *btcec.PrivateKey
+	pkScript []byte
+	address  string
+}
+
+func newP2WPKHKey(t *testing.T, params *chaincfg.Params) *testBitcoinKey {
+	t.Helper()
+	priv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	address, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(priv.PubKey().SerializeCompressed()), params)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(address)
+	require.NoError(t, err)
+	return &testBitcoinKey{priv: priv, pkScript: pkScript, address: address.EncodeAddress()}
+}
+
+func newP2TRKey(t *testing.T, params *chaincfg.Params) *testBitcoinKey {
+	t.Helper()
+	priv, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	outputKey := txscript.ComputeTaprootKeyNoScript(priv.PubKey())
+	address, err := btcutil.NewAddressTaproot(schnorr.SerializePubKey(outputKey), params)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(address)
+	require.NoError(t, err)
+	return &testBitcoinKey{priv: priv, pkScript: pkScript, address: address.EncodeAddress()}
+}
+
+// localInputSigner 按输入类型使用本地私钥签名（P2TR 使用 BIP-86 调整后的私钥）
+func localInputSigner(keys map[int]*btcec.PrivateKey) InputSigner {
+	return func(_ context.Context, input *InputSigHash) ([]byte, error) {
+		priv := keys[input.Index]
+		if input.ScriptType == BitcoinScriptTypeP2TR {
+			sig, err := schnorr.Sign(txscript.TweakTaprootPrivKey(*priv, input.TaprootMerkleRoot), input.SigHash)
+			if err != nil {
+				return nil, err
+			}
+			return sig.Serialize(), nil
+		}
+		return btcecdsa.Sign(priv, input.SigHash).Serialize(), nil
+	}
+}
+
+func testTxID(b byte) string {
+	return chainhash.DoubleHashH([]byte{b}).String()
+}
+
+// TestBitcoinAdapter_SignTransaction 构建包含 P2WPKH 和 P2TR 输入的 PSBT，签名后用 btcd 脚本引擎验证每个输入
+func TestBitcoinAdapter_SignTransaction(t *testing.T) {
+	params := &chaincfg.RegressionNetParams
+	adapter := NewBitcoinAdapter(params)
+	segwit := newP2WPKHKey(t, params)
+	taproot := newP2TRKey(t, params)
+	recipient := newP2WPKHKey(t, params)
+
+	req := &BuildTxRequest{
+		From:   taproot.address,
+		To:     recipient.address,
+		Amount: big.NewInt(120_000),
+		Inputs: []*UTXO{
+			{TxID: testTxID(1), Vout: 0, Amount: 100_000, ScriptPubKey: segwit.pkScript, PublicKey: segwit.priv.PubKey().SerializeCompressed()},
+			{TxID: testTxID(2), Vout: 3, Amount: 50_000, ScriptPubKey: taproot.pkScript, PublicKey: taproot.priv.PubKey().SerializeCompressed()},
+		},
+		FeeRate: 5,
+	}
+	unsigned, err := adapter.BuildTransaction(req)
+	require.NoError(t, err)
+	require.Len(t, unsigned.SigHashes, 2)
+	assert.Equal(t, BitcoinScriptTypeP2WPKH, unsigned.SigHashes[0].ScriptType)
+	assert.Equal(t, BitcoinScriptTypeP2TR, unsigned.SigHashes[1].ScriptType)
+	assert.NotEmpty(t, unsigned.PSBT)
+
+	signer := localInputSigner(map[int]*btcec.PrivateKey{0: segwit.priv, 1: taproot.priv})
+	signed, err := adapter.SignTransaction(context.Background(), unsigned, signer)
+	require.NoError(t, err)
+	assert.Equal(t, unsigned.Hash, signed.Hash, "segwit txid must not change after signing")
+
+	rawTx, err := hex.DecodeString(signed.Raw)
+	require.NoError(t, err)
+	tx := wire.NewMsgTx(wire.TxVersion)
+	require.NoError(t, tx.Deserialize(bytes.NewReader(rawTx)))
+	require.Len(t, tx.TxOut, 2)
+	assert.Equal(t, int64(120_000), tx.TxOut[0].Value)
+	assert.Equal(t, recipient.pkScript, tx.TxOut[0].PkScript)
+	assert.Equal(t, taproot.pkScript, tx.TxOut[1].PkScript)
+
+	// 手续费不低于费率 × 实际虚拟大小
+	fee := int64(150_000) - tx.TxOut[0].Value - tx.TxOut[1].Value
+	vsize := (int64(tx.SerializeSizeStripped())*3 + int64(tx.SerializeSize()) + 3) / 4
+	assert.GreaterOrEqual(t, fee, int64(req.FeeRate)*vsize)
+
+	prevOuts := map[wire.OutPoint]*wire.TxOut{}
+	for i, utxo := range req.Inputs {
+		prevOuts[tx.TxIn[i].PreviousOutPoint] = wire.NewTxOut(utxo.Amount, utxo.ScriptPubKey)
+	}
+	fetcher := txscript.NewMultiPrevOutFetcher(prevOuts)
+	sigHashes := txscript.NewTxSigHashes(tx, fetcher)
+	for i, utxo := range req.Inputs {
+		engine, err := txscript.NewEngine(utxo.ScriptPubKey, tx, i, txscript.StandardVerifyFlags, nil, sigHashes, utxo.Amount, fetcher)
+		require.NoError(t, err)
+		assert.NoError(t, engine.Execute(), "input %d", i)
+	}
+}
+
+// TestBitcoinAdapter_SignTransaction_WrongKey 门限签名返回的签名与输入公钥不符时拒绝
+func TestBitcoinAdapter_SignTransaction_WrongKey(t *testing.T) {
+	params := &chaincfg.RegressionNetParams
+	adapter := NewBitcoinAdapter(params)
+	segwit := newP2WPKHKey(t, params)
+	taproot := newP2TRKey(t, params)
+	other := newP2WPKHKey(t, params)
+
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{
+		Inputs: []*UTXO{
+			{TxID: testTxID(1), Amount: 10_000, ScriptPubKey: segwit.pkScript, PublicKey: segwit.priv.PubKey().SerializeCompressed()},
+			{TxID: testTxID(2), Amount: 10_000, ScriptPubKey: taproot.pkScript, PublicKey: taproot.priv.PubKey().SerializeCompressed()},
+		},
+		Outputs: []*TxOutput{{Address: other.address, Amount: 19_000}},
+		From:    segwit.address,
+		FeeRate: 1,
+	})
+	require.NoError(t, err)
+
+	_, err = adapter.SignTransaction(context.Background(), unsigned, localInputSigner(map[int]*btcec.PrivateKey{0: other.priv, 1: taproot.priv}))
+	assert.Error(t, err)
+	_, err = adapter.SignTransaction(context.Background(), unsigned, localInputSigner(map[int]*btcec.PrivateKey{0: segwit.priv, 1: other.priv}))
+	assert.Error(t, err)
+}
+
+// TestBitcoinAdapter_BuildTransaction_Invalid 不支持的脚本、公钥不匹配、余额不足和其他网络的地址被拒绝
+func TestBitcoinAdapter_BuildTransaction_Invalid(t *testing.T) {
+	params := &chaincfg.RegressionNetParams
+	adapter := NewBitcoinAdapter(params)
+	segwit := newP2WPKHKey(t, params)
+	other := newP2WPKHKey(t, params)
+	mainnet := newP2WPKHKey(t, &chaincfg.MainNetParams)
+	p2pkh, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(segwit.priv.PubKey().SerializeCompressed()), params)
+	require.NoError(t, err)
+	p2pkhScript, err := txscript.PayToAddrScript(p2pkh)
+	require.NoError(t, err)
+
+	input := func(pkScript, pubKey []byte) []*UTXO {
+		return []*UTXO{{TxID: testTxID(1), Amount: 10_000, ScriptPubKey: pkScript, PublicKey: pubKey}}
+	}
+	outputs := []*TxOutput{{Address: other.address, Amount: 9_000}}
+
+	for name, req := range map[string]*BuildTxRequest{
+		"p2pkh input":         {Inputs: input(p2pkhScript, segwit.priv.PubKey().SerializeCompressed()), Outputs: outputs},
+		"mismatched key":      {Inputs: input(segwit.pkScript, other.priv.PubKey().SerializeCompressed()), Outputs: outputs},
+		"overspend":           {Inputs: input(segwit.pkScript, segwit.priv.PubKey().SerializeCompressed()), Outputs: []*TxOutput{{Address: other.address, Amount: 20_000}}},
+		"insufficient fee":    {Inputs: input(segwit.pkScript, segwit.priv.PubKey().SerializeCompressed()), Outputs: outputs, From: segwit.address, FeeRate: 100},
+		"dust output":         {Inputs: input(segwit.pkScript, segwit.priv.PubKey().SerializeCompressed()), Outputs: []*TxOutput{{Address: other.address, Amount: 100}}},
+		"wrong network":       {Inputs: input(segwit.pkScript, segwit.priv.PubKey().SerializeCompressed()), Outputs: []*TxOutput{{Address: mainnet.address, Amount: 9_000}}},
+		"no inputs":           {Outputs: outputs},
+		"no outputs":          {Inputs: input(segwit.pkScript, segwit.priv.PubKey().SerializeCompressed())},
+		"invalid txid":        {Inputs: []*UTXO{{TxID: "zz", Amount: 10_000, ScriptPubKey: segwit.pkScript, PublicKey: segwit.priv.PubKey().SerializeCompressed()}}, Outputs: outputs},
+		"missing change addr": {Inputs: input(segwit.pkScript, segwit.priv.PubKey().SerializeCompressed()), Outputs: outputs, FeeRate: 1},
+		"missing fee rate":    {Inputs: input(segwit.pkScript, segwit.priv.PubKey().SerializeCompressed()), Outputs: outputs, From: segwit.address},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := adapter.BuildTransaction(req)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestBitcoinAdapter_BuildTransaction_Change 输入超出输出和手续费的部分找零到 From 地址，不会成为矿工费
+func TestBitcoinAdapter_BuildTransaction_Change(t *testing.T) {
+	params := &chaincfg.RegressionNetParams
+	adapter := NewBitcoinAdapter(params)
+	segwit := newP2WPKHKey(t, params)
+	other := newP2WPKHKey(t, params)
+
+	req := &BuildTxRequest{
+		From:    segwit.address,
+		Inputs:  []*UTXO{{TxID: testTxID(1), Amount: 1_000_000, ScriptPubKey: segwit.pkScript, PublicKey: segwit.priv.PubKey().SerializeCompressed()}},
+		Outputs: []*TxOutput{{Address: other.address, Amount: 10_000}},
+		FeeRate: 2,
+	}
+	unsigned, err := adapter.BuildTransaction(req)
+	require.NoError(t, err)
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(unsigned.PSBT), true)
+	require.NoError(t, err)
+	require.Len(t, packet.UnsignedTx.TxOut, 2)
+	change := packet.UnsignedTx.TxOut[1]
+	assert.Equal(t, segwit.pkScript, change.PkScript)
+	fee := int64(1_000_000) - 10_000 - change.Value
+	assert.Equal(t, int64(req.FeeRate)*estimateBitcoinVSize(req.Inputs, packet.UnsignedTx.TxOut), fee)
+
+	// 不足粉尘阈值的余额不找零，多付的手续费不超过粉尘阈值
+	req.Outputs[0].Amount = 1_000_000 - 400
+	unsigned, err = adapter.BuildTransaction(req)
+	require.NoError(t, err)
+	packet, err = psbt.NewFromRawBytes(strings.NewReader(unsigned.PSBT), true)
+	require.NoError(t, err)
+	require.Len(t, packet.UnsignedTx.TxOut, 1)
+	assert.Less(t, int64(400)-int64(req.FeeRate)*estimateBitcoinVSize(req.Inputs, packet.UnsignedTx.TxOut), int64(bitcoinDustLimit))
+}
+
+// TestBitcoinAdapter_GenerateAddress 各地址类型和网络与 BIP-173/BIP-86 测试向量一致
+func TestBitcoinAdapter_GenerateAddress(t *testing.T) {
+	// secp256k1 生成元 G（私钥 1）的压缩公钥
+	generator := mustHex(t, "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	// BIP-86 m/86'/0'/0'/0/0 的内部公钥（x-only）
+	bip86InternalKey := mustHex(t, "cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+
+	for _, tc := range []struct {
+		network     string
+		addressType string
+		pubKey      []byte
+		expected    string
+	}{
+		{BitcoinNetworkMainnet, BitcoinAddressTypeP2PKH, generator, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"},
+		{BitcoinNetworkMainnet, BitcoinAddressTypeP2SHP2WPKH, generator, "3JvL6Ymt8MVWiCNHC7oWU6nLeHNJKLZGLN"},
+		{BitcoinNetworkMainnet, BitcoinAddressTypeP2WPKH, generator, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{BitcoinNetworkMainnet, "", generator, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{BitcoinNetworkTestnet, BitcoinAddressTypeP2WPKH, generator, "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx"},
+		{BitcoinNetworkSignet, BitcoinAddressTypeP2WPKH, generator, "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx"},
+		{BitcoinNetworkRegtest, BitcoinAddressTypeP2WPKH, generator, "bcrt1qw508d6qejxtdg4y5r3zarvary0c5xw7kygt080"},
+		{BitcoinNetworkMainnet, BitcoinAddressTypeP2TR, bip86InternalKey, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{BitcoinNetworkMainnet, BitcoinAddressTypeP2TR, append([]byte{0x02}, bip86InternalKey...), "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+	} {
+		t.Run(tc.network+"/"+tc.addressType, func(t *testing.T) {
+			params, err := BitcoinNetworkParams(tc.network)
+			require.NoError(t, err)
+			adapter, err := NewBitcoinAdapter(params).WithAddressType(tc.addressType)
+			require.NoError(t, err)
+			address, err := adapter.GenerateAddress(tc.pubKey)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, address)
+		})
+	}
+
+	_, err := BitcoinNetworkParams("testnet4")
+	assert.Error(t, err)
+	_, err = NewBitcoinAdapter(nil).WithAddressType("p2sh")
+	assert.Error(t, err)
+	_, err = NewBitcoinAdapter(nil).GenerateAddress(generator[:32])
+	assert.Error(t, err)
+}
+
+// TestBitcoinAdapter_DecodeTransaction 解析 PSBT：找零输出不计入转账，手续费为输入减输出，签名摘要与 BuildTransaction 一致
+func TestBitcoinAdapter_DecodeTransaction(t *testing.T) {
+	params := &chaincfg.RegressionNetParams
+	adapter := NewBitcoinAdapter(params)
+	segwit := newP2WPKHKey(t, params)
+	taproot := newP2TRKey(t, params)
+	recipient := newP2WPKHKey(t, params)
+
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{
+		From:   taproot.address,
+		To:     recipient.address,
+		Amount: big.NewInt(120_000),
+		Inputs: []*UTXO{
+			{TxID: testTxID(1), Vout: 0, Amount: 100_000, ScriptPubKey: segwit.pkScript, PublicKey: segwit.priv.PubKey().SerializeCompressed()},
+			{TxID: testTxID(2), Vout: 3, Amount: 50_000, ScriptPubKey: taproot.pkScript, PublicKey: taproot.priv.PubKey().SerializeCompressed()},
+		},
+		FeeRate: 5,
+	})
+	require.NoError(t, err)
+
+	decoded, err := adapter.DecodeTransaction(unsigned.PSBT)
+	require.NoError(t, err)
+	assert.Equal(t, []*Transfer{{To: recipient.address, Amount: big.NewInt(120_000)}}, decoded.Transfers)
+	assert.Empty(t, decoded.Calls)
+	require.Len(t, decoded.SigningPayloads, len(unsigned.SigHashes))
+	for i, sigHash := range unsigned.SigHashes {
+		assert.Equal(t, sigHash.SigHash, decoded.SigningPayloads[i], "input %d", i)
+	}
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(unsigned.PSBT), true)
+	require.NoError(t, err)
+	require.Len(t, packet.UnsignedTx.TxOut, 2)
+	change := packet.UnsignedTx.TxOut[1]
+	assert.Equal(t, big.NewInt(150_000-120_000-change.Value), decoded.Fee)
+
+	// 减少找零不改变转账，多出的金额作为手续费支付给矿工
+	change.Value = 1_000
+	tampered, err := packet.B64Encode()
+	require.NoError(t, err)
+	decoded, err = adapter.DecodeTransaction(tampered)
+	require.NoError(t, err)
+	assert.Equal(t, []*Transfer{{To: recipient.address, Amount: big.NewInt(120_000)}}, decoded.Transfers)
+	assert.Equal(t, big.NewInt(29_000), decoded.Fee)
+
+	// 输出总额超过输入总额
+	change.Value = 30_001
+	tampered, err = packet.B64Encode()
+	require.NoError(t, err)
+	_, err = adapter.DecodeTransaction(tampered)
+	assert.Error(t, err)
+
+	_, err = adapter.DecodeTransaction("not a psbt")
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/pkg/errors"
+)
+
+// eip712DomainType EIP-712 域类型名
+const eip712DomainType = "EIP712Domain"
+
+// eip712DomainFields 未在 types 中声明 EIP712Domain 时，按 EIP-712 规定的顺序使用 domain 中出现的字段
+var eip712DomainFields = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+// 链上 permit 的函数选择器：EIP-2612 permit(address,address,uint256,uint256,uint8,bytes32,bytes32)
+// 和 DAI permit(address,address,uint256,uint256,bool,uint8,bytes32,bytes32)
+var (
+	eip2612PermitSelector = []byte{0xd5, 0x05, 0xac, 0xcf}
+	daiPermitSelector     = []byte{0x8f, 0xcb, 0xaf, 0x0c}
+)
+
+// EIP191Hash 以太坊 personal_sign 消息摘要：keccak256("\x19Ethereum Signed Message:\n" || len(message) || message)
+func EIP191Hash(message []byte) []byte {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	return crypto.Keccak256([]byte(prefix), message)
+}
+
+// ParseTypedData 解析 eth_signTypedData_v4 格式的 JSON
+// 超出 int64 的整数需要以字符串给出，JSON 数字无法无损表示时编码会失败
+func ParseTypedData(data []byte) (*apitypes.TypedData, error) {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(data, &typedData); err != nil {
+		return nil, errors.Wrap(err, "invalid typed data json")
+	}
+	if typedData.PrimaryType == "" {
+		return nil, errors.New("typed data has no primaryType")
+	}
+	if typedData.Types == nil {
+		typedData.Types = make(apitypes.Types)
+	}
+	if _, ok := typedData.Types[eip712DomainType]; !ok {
+		// ethers 等库不在 types 中声明 EIP712Domain，按 domain 中出现的字段补全
+		domain := typedData.Domain.Map()
+		fields := make([]apitypes.Type, 0, len(eip712DomainFields))
+		for _, field := range eip712DomainFields {
+			if _, ok := domain[field.Name]; ok {
+				fields = append(fields, field)
+			}
+		}
+		typedData.Types[eip712DomainType] = fields
+	}
+	if _, ok := typedData.Types[typedData.PrimaryType]; !ok {
+		return nil, errors.Errorf("primaryType %s is not defined in types", typedData.PrimaryType)
+	}
+	return &typedData, nil
+}
+
+// TypedDataHash 计算 EIP-712 签名摘要 keccak256(0x19 0x01 || domainSeparator || hashStruct(message))
+func TypedDataHash(typedData *apitypes.TypedData) ([]byte, error) {
+	digest, _, err := apitypes.TypedDataAndHash(*typedData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash typed data")
+	}
+	return digest, nil
+}
+
+// DecodeTypedData 将授权类 EIP-712 数据解析为策略检查使用的交易：EIP-2612 Permit 记为向 spender 转移 value，
+// DAI 风格 Permit 的 allowed 为 true 时记为无限额转移，并都记为对代币合约 permit 方法的调用。
+// 无法识别的类型（如交易所订单）返回 nil，由策略按未能解析的交易处理
+func DecodeTypedData(typedData *apitypes.TypedData, digest []byte) *DecodedTransaction {
+	if typedData.PrimaryType != "Permit" || !common.IsHexAddress(typedData.Domain.VerifyingContract) {
+		return nil
+	}
+	spender, ok := typedData.Message["spender"].(string)
+	if !ok || !common.IsHexAddress(spender) {
+		return nil
+	}
+	token := hexutil.Encode(common.HexToAddress(typedData.Domain.VerifyingContract).Bytes())
+	decoded := &DecodedTransaction{SigningPayloads: [][]byte{digest}}
+	transfer := &Transfer{To: hexutil.Encode(common.HexToAddress(spender).Bytes()), Asset: token}
+
+	if value, ok := typedData.Message["value"]; ok {
+		amount, ok := typedDataUint(value)
+		if !ok {
+			return nil
+		}
+		transfer.Amount = amount
+		decoded.Calls = append(decoded.Calls, &ContractCall{Contract: token, Method: hexutil.Encode(eip2612PermitSelector)})
+	} else {
+		allowed, ok := typedData.Message["allowed"].(bool)
+		if !ok {
+			return nil
+		}
+		transfer.Amount = new(big.Int)
+		if allowed {
+			transfer.Amount = new(big.Int).Set(math.MaxBig256)
+		}
+		decoded.Calls = append(decoded.Calls, &ContractCall{Contract: token, Method: hexutil.Encode(daiPermitSelector)})
+	}
+	if transfer.Amount.Sign() > 0 {
+		decoded.Transfers = append(decoded.Transfers, transfer)
+	}
+	return decoded
+}
+
+// typedDataUint 解析类型化数据中的 uint256 字段（十进制或 0x 前缀 hex 字符串，或 JSON 整数）
+func typedDataUint(value interface{}) (*big.Int, bool) {
+	switch v := value.(type) {
+	case string:
+		return math.ParseBig256(strings.TrimSpace(v))
+	case float64:
+		// 超出 2^53 的 JSON 数字已丢失精度
+		if v < 0 || v > 1<<53 || v != float64(int64(v)) {
+			return nil, false
+		}
+		return big.NewInt(int64(v)), true
+	default:
+		return nil, false
+	}
+}
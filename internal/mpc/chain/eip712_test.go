@@ -0,0 +1,185 @@
+package chain
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eip712MailExample EIP-712 规范中的示例
+const eip712MailExample = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// TestTypedDataHash_MailExample 与 EIP-712 规范示例的类型编码、域分隔符、结构体哈希、摘要和签名一致
+func TestTypedDataHash_MailExample(t *testing.T) {
+	typedData, err := ParseTypedData([]byte(eip712MailExample))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", string(typedData.EncodeType("Mail")))
+
+	domainSeparator, err := typedData.HashStruct(eip712DomainType, typedData.Domain.Map())
+	require.NoError(t, err)
+	assert.Equal(t, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToString(domainSeparator))
+	structHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	require.NoError(t, err)
+	assert.Equal(t, "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hex.EncodeToString(structHash))
+
+	digest, err := TypedDataHash(typedData)
+	require.NoError(t, err)
+	assert.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(digest))
+
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	require.NoError(t, err)
+	sig, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+	assert.Equal(t, mustHex(t, "4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"), sig[:32])
+	assert.Equal(t, mustHex(t, "07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"), sig[32:64])
+	assert.Equal(t, byte(1), sig[64])
+}
+
+// eip2612PermitExample 未声明 EIP712Domain 的 USDC permit（无限额授权）
+const eip2612PermitExample = `{
+  "types": {"Permit": [
+    {"name": "owner", "type": "address"},
+    {"name": "spender", "type": "address"},
+    {"name": "value", "type": "uint256"},
+    {"name": "nonce", "type": "uint256"},
+    {"name": "deadline", "type": "uint256"}
+  ]},
+  "primaryType": "Permit",
+  "domain": {"name": "USD Coin", "version": "2", "chainId": "0x1", "verifyingContract": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"},
+  "message": {
+    "owner": "0x1111111111111111111111111111111111111111",
+    "spender": "0x2222222222222222222222222222222222222222",
+    "value": "115792089237316195423570985008687907853269984665640564039457584007913129639935",
+    "nonce": 0,
+    "deadline": 1700000000
+  }
+}`
+
+// TestTypedDataHash_ImplicitDomain 未声明 EIP712Domain 时按 domain 字段推断，结果与显式声明一致
+func TestTypedDataHash_ImplicitDomain(t *testing.T) {
+	typedData, err := ParseTypedData([]byte(eip2612PermitExample))
+	require.NoError(t, err)
+	assert.Len(t, typedData.Types[eip712DomainType], 4)
+	implicit, err := TypedDataHash(typedData)
+	require.NoError(t, err)
+
+	typedData.Types[eip712DomainType] = eip712DomainFields[:4]
+	explicit, err := TypedDataHash(typedData)
+	require.NoError(t, err)
+	assert.Equal(t, explicit, implicit)
+
+	// uint256 溢出
+	typedData.Message["value"] = "115792089237316195423570985008687907853269984665640564039457584007913129639936"
+	_, err = TypedDataHash(typedData)
+	assert.Error(t, err)
+}
+
+// TestTypedDataHash_Invalid 缺少或多余的成员、类型不符、未定义的类型都被拒绝
+func TestTypedDataHash_Invalid(t *testing.T) {
+	for name, mutate := range map[string]func(*apitypes.TypedData){
+		"missing field":   func(d *apitypes.TypedData) { delete(d.Message, "contents") },
+		"extra field":     func(d *apitypes.TypedData) { d.Message["extra"] = "x" },
+		"bad address":     func(d *apitypes.TypedData) { d.Message["from"].(map[string]interface{})["wallet"] = "0x1234" },
+		"undefined type":  func(d *apitypes.TypedData) { d.Types["Mail"][2].Type = "Letter" },
+		"string mismatch": func(d *apitypes.TypedData) { d.Message["contents"] = true },
+	} {
+		t.Run(name, func(t *testing.T) {
+			typedData, err := ParseTypedData([]byte(eip712MailExample))
+			require.NoError(t, err)
+			mutate(typedData)
+			_, err = TypedDataHash(typedData)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := ParseTypedData([]byte(`{"types": {}, "primaryType": "Mail", "domain": {}}`))
+	assert.Error(t, err)
+}
+
+// TestDecodeTypedData permit 解析为对 spender 的代币转移和 permit 调用，其他类型不解析
+func TestDecodeTypedData(t *testing.T) {
+	typedData, err := ParseTypedData([]byte(eip2612PermitExample))
+	require.NoError(t, err)
+	digest, err := TypedDataHash(typedData)
+	require.NoError(t, err)
+
+	token := "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	decoded := DecodeTypedData(typedData, digest)
+	require.NotNil(t, decoded)
+	require.Len(t, decoded.Transfers, 1)
+	assert.Equal(t, "0x2222222222222222222222222222222222222222", decoded.Transfers[0].To)
+	assert.Equal(t, token, decoded.Transfers[0].Asset)
+	assert.Equal(t, "115792089237316195423570985008687907853269984665640564039457584007913129639935", decoded.Transfers[0].Amount.String())
+	assert.Equal(t, []*ContractCall{{Contract: token, Method: "0xd505accf"}}, decoded.Calls)
+	assert.Equal(t, [][]byte{digest}, decoded.SigningPayloads)
+
+	typedData.Message["value"] = float64(1000)
+	decoded = DecodeTypedData(typedData, digest)
+	require.NotNil(t, decoded)
+	assert.Equal(t, int64(1000), decoded.Transfers[0].Amount.Int64())
+
+	// DAI 风格 permit：allowed 为 true 时视为无限额
+	delete(typedData.Message, "value")
+	typedData.Message["allowed"] = true
+	decoded = DecodeTypedData(typedData, digest)
+	require.NotNil(t, decoded)
+	assert.Equal(t, 256, decoded.Transfers[0].Amount.BitLen())
+	assert.Equal(t, []*ContractCall{{Contract: token, Method: "0x8fcbaf0c"}}, decoded.Calls)
+
+	typedData.Message["allowed"] = false
+	decoded = DecodeTypedData(typedData, digest)
+	require.NotNil(t, decoded)
+	assert.Empty(t, decoded.Transfers)
+
+	mail, err := ParseTypedData([]byte(eip712MailExample))
+	require.NoError(t, err)
+	assert.Nil(t, DecodeTypedData(mail, digest))
+}
+
+// TestEIP191Hash personal_sign 摘要
+func TestEIP191Hash(t *testing.T) {
+	assert.Equal(t, "50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750", hex.EncodeToString(EIP191Hash([]byte("hello"))))
+	assert.Equal(t, crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n11hello world")), EIP191Hash([]byte("hello world")))
+}
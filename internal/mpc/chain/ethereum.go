@@ -1,70 +1,303 @@
 package chain
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
 	"github.com/pkg/errors"
 )
 
+// EVM 交易类型（EIP-2718）
+const (
+	EthereumTxTypeLegacy     uint8 = types.LegacyTxType
+	EthereumTxTypeAccessList uint8 = types.AccessListTxType
+	EthereumTxTypeDynamicFee uint8 = types.DynamicFeeTxType
+)
+
+// ethereumTransferGas 普通转账的 gas 用量
+const ethereumTransferGas = 21000
+
+// ERC-20 函数选择器：transfer(address,uint256) 和 transferFrom(address,address,uint256)
+var (
+	erc20TransferSelector     = []byte{0xa9, 0x05, 0x9c, 0xbb}
+	erc20TransferFromSelector = []byte{0x23, 0xb8, 0x72, 0xdd}
+)
+
 // EthereumAdapter 实现 EVM 链基础能力
 type EthereumAdapter struct {
 	chainID *big.Int
+	signer  types.Signer
 }
 
-// NewEthereumAdapter 创建以太坊适配器
+// NewEthereumAdapter 创建以太坊适配器，交易按 go-ethereum 对该链 ID 的最新规则签名
 func NewEthereumAdapter(chainID *big.Int) *EthereumAdapter {
-	if chainID == nil {
+	if chainID == nil || chainID.Sign() <= 0 {
 		chainID = big.NewInt(1) // mainnet
 	}
-	return &EthereumAdapter{chainID: chainID}
+	return &EthereumAdapter{chainID: chainID, signer: types.LatestSignerForChainID(chainID)}
 }
 
-// GenerateAddress 通过 Keccak256(pubKey[1:]) 生成地址
+// GenerateAddress 通过 Keccak256(未压缩公钥去掉 0x04 前缀) 生成地址，接受压缩（33 字节）、
+// 未压缩（65 字节）和去掉前缀的 64 字节 secp256k1 公钥
 func (a *EthereumAdapter) GenerateAddress(pubKey []byte) (string, error) {
 	if len(pubKey) == 0 {
 		return "", errors.New("public key is required")
 	}
-	uncompressed := pubKey
-	if pubKey[0] == 0x04 && len(pubKey) == 65 {
+	var uncompressed []byte
+	switch {
+	case len(pubKey) == 33:
+		key, err := crypto.DecompressPubkey(pubKey)
+		if err != nil {
+			return "", errors.Wrap(err, "invalid secp256k1 public key")
+		}
+		uncompressed = crypto.FromECDSAPub(key)[1:]
+	case len(pubKey) == 65 && pubKey[0] == 0x04:
 		uncompressed = pubKey[1:]
+	case len(pubKey) == 64:
+		uncompressed = pubKey
+	default:
+		return "", errors.Errorf("invalid secp256k1 public key length: %d", len(pubKey))
 	}
-	hash := crypto.Keccak256(uncompressed[len(uncompressed)-64:])
+	hash := crypto.Keccak256(uncompressed)
 	return fmt.Sprintf("0x%s", hex.EncodeToString(hash[12:])), nil
 }
 
-// BuildTransaction 构建一个简化的 RLP 交易负载
+// BuildTransaction 构建未签名的 EVM 交易（类型 0 按 EIP-155、类型 1 按 EIP-2930、类型 2 按 EIP-1559），
+// Raw 为未签名交易的编码（legacy 交易按 EIP-155 约定 V 为链 ID、R 和 S 为 0，类型化交易 V、R、S 为 0），
+// SigningHash 为按配置的链 ID 计算的签名哈希
 func (a *EthereumAdapter) BuildTransaction(req *BuildTxRequest) (*Transaction, error) {
 	if req == nil {
 		return nil, errors.New("build request is nil")
 	}
-	if req.Amount == nil {
-		return nil, errors.New("amount is required")
+	tx, err := a.newEthereumTx(req)
+	if err != nil {
+		return nil, err
 	}
 
-	txPayload := []interface{}{
-		req.Nonce,
-		req.FeeRate, // 这里复用 FeeRate 作为 gas price
-		uint64(21000),
-		req.To,
-		req.Amount,
-		req.Data,
-		a.chainID,
-		uint(0),
-		uint(0),
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode transaction")
 	}
+	return &Transaction{
+		Raw:         hexutil.Encode(raw),
+		SigningHash: a.signer.Hash(tx).Hex(),
+	}, nil
+}
 
-	raw, err := rlp.EncodeToBytes(txPayload)
+// AssembleTransaction 将 MPC 签名插入 BuildTransaction 生成的未签名交易，返回可广播的原始交易及交易哈希。
+// 签名接受 DER、r||s 和 r||s||v 编码，S 规范化为 low-S；recovery id 通过公钥恢复确定，
+// legacy 交易的 v 为 recovery_id + 35 + 2 * chainID，类型化交易的 v 为 recovery_id
+func (a *EthereumAdapter) AssembleTransaction(unsigned *Transaction, signature []byte, publicKey []byte) (*Transaction, error) {
+	if unsigned == nil || unsigned.Raw == "" {
+		return nil, errors.New("unsigned transaction is required")
+	}
+	tx, err := a.decodeUnsignedTx(unsigned.Raw)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to RLP encode tx payload")
+		return nil, err
 	}
 
-	hash := crypto.Keccak256Hash(raw).Hex()
+	signingHash := a.signer.Hash(tx)
+	encoded, err := protocol.EncodeECDSASignature(protocol.SignatureFormatRSV, signature, signingHash.Bytes(), publicKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid transaction signature")
+	}
+
+	// WithSignature 需要 r || s || recovery_id，按交易类型计算 v
+	sig := append(append(append(make([]byte, 0, crypto.SignatureLength), encoded.R...), encoded.S...), byte(encoded.RecoveryID))
+	signed, err := tx.WithSignature(a.signer, sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to attach transaction signature")
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode signed transaction")
+	}
 	return &Transaction{
-		Raw:  fmt.Sprintf("0x%s", hex.EncodeToString(raw)),
-		Hash: hash,
+		Raw:  hexutil.Encode(raw),
+		Hash: signed.Hash().Hex(),
 	}, nil
 }
+
+// DecodeTransaction 解析 BuildTransaction 生成的未签名交易：原生币转账记为 Transfer，
+// ERC-20 transfer/transferFrom 记为代币 Transfer，其他调用数据记为 ContractCall
+func (a *EthereumAdapter) DecodeTransaction(unsigned string) (*DecodedTransaction, error) {
+	tx, err := a.decodeUnsignedTx(unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &DecodedTransaction{SigningPayloads: [][]byte{a.signer.Hash(tx).Bytes()}}
+	var to string
+	if tx.To() != nil {
+		to = hexutil.Encode(tx.To().Bytes())
+	}
+	if tx.Value().Sign() > 0 {
+		decoded.Transfers = append(decoded.Transfers, &Transfer{To: to, Amount: tx.Value()})
+	}
+	if data := tx.Data(); len(data) >= 4 {
+		// ABI 解码忽略参数之后多余的字节，追加填充的调用数据仍按转账执行；参数不足时
+		// 部分合约会补零执行（short address），无法确定实际金额，直接拒绝解析
+		selector, args := data[:4], data[4:]
+		switch {
+		case bytes.Equal(selector, erc20TransferSelector):
+			if len(args) < 64 {
+				return nil, errors.Errorf("erc20 transfer calldata too short: %d bytes", len(args))
+			}
+			decoded.Transfers = append(decoded.Transfers, &Transfer{To: hexutil.Encode(args[12:32]), Amount: new(big.Int).SetBytes(args[32:64]), Asset: to})
+		case bytes.Equal(selector, erc20TransferFromSelector):
+			if len(args) < 96 {
+				return nil, errors.Errorf("erc20 transferFrom calldata too short: %d bytes", len(args))
+			}
+			decoded.Transfers = append(decoded.Transfers, &Transfer{To: hexutil.Encode(args[44:64]), Amount: new(big.Int).SetBytes(args[64:96]), Asset: to})
+		default:
+			decoded.Calls = append(decoded.Calls, &ContractCall{Contract: to, Method: hexutil.Encode(selector)})
+		}
+	}
+	return decoded, nil
+}
+
+// newEthereumTx 校验构建请求，确定交易类型和费用字段
+func (a *EthereumAdapter) newEthereumTx(req *BuildTxRequest) (*types.Transaction, error) {
+	txType := req.TxType
+	if txType == EthereumTxTypeLegacy {
+		if req.MaxFeePerGas != nil {
+			txType = EthereumTxTypeDynamicFee
+		} else if len(req.AccessList) > 0 {
+			txType = EthereumTxTypeAccessList
+		}
+	}
+
+	value := new(big.Int)
+	if req.Amount != nil {
+		if req.Amount.Sign() < 0 {
+			return nil, errors.New("amount must not be negative")
+		}
+		value.Set(req.Amount)
+	}
+	var to *common.Address
+	if req.To != "" {
+		if !common.IsHexAddress(req.To) {
+			return nil, errors.Errorf("invalid to address %s", req.To)
+		}
+		address := common.HexToAddress(req.To)
+		to = &address
+	} else if len(req.Data) == 0 {
+		return nil, errors.New("to address is required unless deploying a contract")
+	}
+	gas := req.GasLimit
+	if gas == 0 {
+		if len(req.Data) > 0 || to == nil {
+			return nil, errors.New("gas limit is required for contract calls and deployments")
+		}
+		gas = ethereumTransferGas
+	}
+
+	var gasPrice, gasTipCap, gasFeeCap *big.Int
+	switch txType {
+	case EthereumTxTypeLegacy, EthereumTxTypeAccessList:
+		if req.MaxFeePerGas != nil || req.MaxPriorityFeePerGas != nil {
+			return nil, errors.Errorf("transaction type %d uses fee rate as gas price, max fees are not allowed", txType)
+		}
+		if req.FeeRate == 0 {
+			return nil, errors.New("fee rate (gas price) is required")
+		}
+		gasPrice = new(big.Int).SetUint64(req.FeeRate)
+	case EthereumTxTypeDynamicFee:
+		if req.MaxFeePerGas == nil || req.MaxFeePerGas.Sign() <= 0 {
+			return nil, errors.New("max fee per gas is required")
+		}
+		gasFeeCap = new(big.Int).Set(req.MaxFeePerGas)
+		gasTipCap = new(big.Int)
+		if req.MaxPriorityFeePerGas != nil {
+			if req.MaxPriorityFeePerGas.Sign() < 0 {
+				return nil, errors.New("max priority fee per gas must not be negative")
+			}
+			gasTipCap.Set(req.MaxPriorityFeePerGas)
+		}
+		if gasTipCap.Cmp(gasFeeCap) > 0 {
+			return nil, errors.Errorf("max priority fee per gas %s exceeds max fee per gas %s", gasTipCap, gasFeeCap)
+		}
+	default:
+		return nil, errors.Errorf("unsupported transaction type %d", txType)
+	}
+
+	accessList := make(types.AccessList, 0, len(req.AccessList))
+	for i, tuple := range req.AccessList {
+		if tuple == nil || !common.IsHexAddress(tuple.Address) {
+			return nil, errors.Errorf("access list entry %d has an invalid address", i)
+		}
+		entry := types.AccessTuple{Address: common.HexToAddress(tuple.Address), StorageKeys: make([]common.Hash, 0, len(tuple.StorageKeys))}
+		for _, storageKey := range tuple.StorageKeys {
+			key, err := hex.DecodeString(strings.TrimPrefix(storageKey, "0x"))
+			if err != nil || len(key) != common.HashLength {
+				return nil, errors.Errorf("access list entry %d has an invalid storage key %s", i, storageKey)
+			}
+			entry.StorageKeys = append(entry.StorageKeys, common.BytesToHash(key))
+		}
+		accessList = append(accessList, entry)
+	}
+
+	switch txType {
+	case EthereumTxTypeLegacy:
+		// EIP-155 未签名交易：V 为链 ID，R 和 S 为 0
+		return types.NewTx(&types.LegacyTx{
+			Nonce: req.Nonce, GasPrice: gasPrice, Gas: gas, To: to, Value: value, Data: req.Data,
+			V: new(big.Int).Set(a.chainID), R: new(big.Int), S: new(big.Int),
+		}), nil
+	case EthereumTxTypeAccessList:
+		return types.NewTx(&types.AccessListTx{
+			ChainID: new(big.Int).Set(a.chainID), Nonce: req.Nonce, GasPrice: gasPrice, Gas: gas,
+			To: to, Value: value, Data: req.Data, AccessList: accessList,
+		}), nil
+	default:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID: new(big.Int).Set(a.chainID), Nonce: req.Nonce, GasTipCap: gasTipCap, GasFeeCap: gasFeeCap, Gas: gas,
+			To: to, Value: value, Data: req.Data, AccessList: accessList,
+		}), nil
+	}
+}
+
+// decodeUnsignedTx 解析 BuildTransaction 生成的未签名交易，检查交易类型、未签名且链 ID 与适配器一致
+func (a *EthereumAdapter) decodeUnsignedTx(unsigned string) (*types.Transaction, error) {
+	payload, err := hex.DecodeString(strings.TrimPrefix(unsigned, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode unsigned transaction")
+	}
+	if len(payload) == 0 {
+		return nil, errors.New("unsigned transaction is empty")
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(payload); err != nil {
+		return nil, errors.Wrap(err, "failed to decode transaction")
+	}
+
+	v, r, s := tx.RawSignatureValues()
+	if r.Sign() != 0 || s.Sign() != 0 {
+		return nil, errors.New("transaction is already signed")
+	}
+	chainID := tx.ChainId()
+	switch tx.Type() {
+	case EthereumTxTypeLegacy:
+		// EIP-155 未签名交易的 V 为链 ID
+		chainID = v
+	case EthereumTxTypeAccessList, EthereumTxTypeDynamicFee:
+		if v.Sign() != 0 {
+			return nil, errors.New("transaction is already signed")
+		}
+	default:
+		return nil, errors.Errorf("unsupported transaction type %d", tx.Type())
+	}
+	if chainID.Cmp(a.chainID) != 0 {
+		return nil, errors.Errorf("transaction chain id %s does not match adapter chain id %s", chainID, a.chainID)
+	}
+	return tx, nil
+}
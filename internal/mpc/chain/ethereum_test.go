@@ -0,0 +1,234 @@
+package chain
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEthereumAdapter_LegacyEIP155 EIP-155 规范中的示例交易
+func TestEthereumAdapter_LegacyEIP155(t *testing.T) {
+	adapter := NewEthereumAdapter(big.NewInt(1))
+	amount, _ := new(big.Int).SetString("1000000000000000000", 10)
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{
+		To:      "0x3535353535353535353535353535353535353535",
+		Amount:  amount,
+		Nonce:   9,
+		FeeRate: 20_000_000_000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0xec098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080018080", unsigned.Raw)
+	assert.Equal(t, "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53", unsigned.SigningHash)
+
+	key, err := crypto.HexToECDSA(strings.Repeat("46", 32))
+	require.NoError(t, err)
+	signature, err := crypto.Sign(hexutil.MustDecode(unsigned.SigningHash), key)
+	require.NoError(t, err)
+
+	// 只提供 r||s 时通过公钥确定 recovery id
+	signed, err := adapter.AssembleTransaction(unsigned, signature[:64], crypto.CompressPubkey(&key.PublicKey))
+	require.NoError(t, err)
+	assert.Equal(t, "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83", signed.Raw)
+	assert.Equal(t, "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", signed.Hash)
+}
+
+// TestEthereumAdapter_AccessListTx 与 go-ethereum 的 EIP-2930 测试向量一致
+func TestEthereumAdapter_AccessListTx(t *testing.T) {
+	adapter := NewEthereumAdapter(big.NewInt(1))
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{
+		TxType:   EthereumTxTypeAccessList,
+		To:       "0xb94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+		Amount:   big.NewInt(10),
+		Nonce:    3,
+		FeeRate:  1,
+		GasLimit: 25000,
+		Data:     hexutil.MustDecode("0x5544"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0x49b486f0ec0a60dfbbca2d30cb07c9e8ffb2a2ff41f29a1ab6737475f6ff69f3", unsigned.SigningHash)
+
+	signature := hexutil.MustDecode("0xc9519f4f2b30335884581971573fadf60c6204f59a911df35ee8a540456b266032f1e8e2c5dd761f9e4f88f41c8310aeaba26a8bfcdacfedfa12ec3862d3752101")
+	publicKey, err := crypto.SigToPub(hexutil.MustDecode(unsigned.SigningHash), signature)
+	require.NoError(t, err)
+
+	signed, err := adapter.AssembleTransaction(unsigned, signature, crypto.CompressPubkey(publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, "0x01f8630103018261a894b94f5374fce5edbc8e2a8697c15331677e6ebf0b0a825544c001a0c9519f4f2b30335884581971573fadf60c6204f59a911df35ee8a540456b2660a032f1e8e2c5dd761f9e4f88f41c8310aeaba26a8bfcdacfedfa12ec3862d37521", signed.Raw)
+}
+
+// TestEthereumAdapter_DynamicFeeTx 重建主网上的一笔 EIP-1559 交易
+func TestEthereumAdapter_DynamicFeeTx(t *testing.T) {
+	const mainnetTx = "0x02f8b4018312acfc8459682f00851a46bcf47a8302b1a194ffa397285ce46fb78c588a9e993286aac68c37cd80b844fb90b3200000000000000000000000002a549b4af9ec39b03142da6dc32221fc390b553300000000000000000000000000000000000000000000000000000000000cb3d5c001a03002079d2873f7963c4278200c43aa71efad262b2150bc8524480acfc38b5faaa077d44aa09d56b9cf99443c7f55aaad1bbae9cfb5bbb9de31eaf7a8f9e623e980"
+
+	adapter := NewEthereumAdapter(big.NewInt(1))
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{
+		To:                   "0xffa397285ce46fb78c588a9e993286aac68c37cd",
+		Nonce:                0x12acfc,
+		GasLimit:             0x2b1a1,
+		MaxFeePerGas:         big.NewInt(0x1a46bcf47a),
+		MaxPriorityFeePerGas: big.NewInt(0x59682f00),
+		Data:                 hexutil.MustDecode("0xfb90b3200000000000000000000000002a549b4af9ec39b03142da6dc32221fc390b553300000000000000000000000000000000000000000000000000000000000cb3d5"),
+	})
+	require.NoError(t, err)
+
+	signature := hexutil.MustDecode("0x3002079d2873f7963c4278200c43aa71efad262b2150bc8524480acfc38b5faa77d44aa09d56b9cf99443c7f55aaad1bbae9cfb5bbb9de31eaf7a8f9e623e98001")
+	publicKey, err := crypto.SigToPub(hexutil.MustDecode(unsigned.SigningHash), signature)
+	require.NoError(t, err)
+
+	signed, err := adapter.AssembleTransaction(unsigned, signature, crypto.FromECDSAPub(publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, mainnetTx, signed.Raw)
+	assert.Equal(t, hexutil.Encode(crypto.Keccak256(hexutil.MustDecode(mainnetTx))), signed.Hash)
+}
+
+// TestEthereumAdapter_AccessListSignature 带访问列表的 EIP-1559 交易：high-S 签名被规范化，且可从已签名交易恢复发送方
+func TestEthereumAdapter_AccessListSignature(t *testing.T) {
+	adapter := NewEthereumAdapter(big.NewInt(11155111))
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{
+		To:           "0x1111111111111111111111111111111111111111",
+		Amount:       big.NewInt(1),
+		GasLimit:     60000,
+		MaxFeePerGas: big.NewInt(30_000_000_000),
+		AccessList: []*AccessTuple{{
+			Address:     "0x2222222222222222222222222222222222222222",
+			StorageKeys: []string{"0x" + strings.Repeat("00", 31) + "01"},
+		}},
+	})
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signingHash := hexutil.MustDecode(unsigned.SigningHash)
+	signature, err := crypto.Sign(signingHash, key)
+	require.NoError(t, err)
+	n := crypto.S256().Params().N
+	highS := new(big.Int).Sub(n, new(big.Int).SetBytes(signature[32:64]))
+	highSSig := append(append([]byte(nil), signature[:32]...), common.LeftPadBytes(highS.Bytes(), 32)...)
+
+	signed, err := adapter.AssembleTransaction(unsigned, highSSig, crypto.CompressPubkey(&key.PublicKey))
+	require.NoError(t, err)
+
+	var tx types.Transaction
+	require.NoError(t, tx.UnmarshalBinary(hexutil.MustDecode(signed.Raw)))
+	require.Equal(t, EthereumTxTypeDynamicFee, tx.Type())
+	require.Len(t, tx.AccessList(), 1)
+	assert.Equal(t, big.NewInt(11155111), tx.ChainId())
+	_, _, s := tx.RawSignatureValues()
+	assert.True(t, s.Cmp(new(big.Int).Rsh(n, 1)) <= 0)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(11155111)), &tx)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), sender)
+}
+
+// TestEthereumAdapter_Invalid 费用、gas、访问列表、链 ID 和签名公钥不符时报错
+func TestEthereumAdapter_Invalid(t *testing.T) {
+	adapter := NewEthereumAdapter(big.NewInt(1))
+	to := "0x1111111111111111111111111111111111111111"
+
+	for name, req := range map[string]*BuildTxRequest{
+		"priority above max fee": {To: to, MaxFeePerGas: big.NewInt(1), MaxPriorityFeePerGas: big.NewInt(2)},
+		"contract call no gas":   {To: to, FeeRate: 1, Data: []byte{1}},
+		"max fee on type 1":      {To: to, FeeRate: 1, TxType: EthereumTxTypeAccessList, MaxPriorityFeePerGas: big.NewInt(1)},
+		"missing gas price":      {To: to},
+		"invalid to":             {To: "0x1234", FeeRate: 1},
+		"invalid storage key":    {To: to, FeeRate: 1, AccessList: []*AccessTuple{{Address: to, StorageKeys: []string{"0x01"}}}},
+		"unsupported type":       {To: to, FeeRate: 1, TxType: 3},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := adapter.BuildTransaction(req)
+			assert.Error(t, err)
+		})
+	}
+
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{To: to, FeeRate: 1})
+	require.NoError(t, err)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signature, err := crypto.Sign(hexutil.MustDecode(unsigned.SigningHash), key)
+	require.NoError(t, err)
+
+	_, err = adapter.AssembleTransaction(unsigned, signature, crypto.CompressPubkey(&other.PublicKey))
+	assert.Error(t, err, "signature from another key")
+	_, err = NewEthereumAdapter(big.NewInt(5)).AssembleTransaction(unsigned, signature, crypto.CompressPubkey(&key.PublicKey))
+	assert.Error(t, err, "chain id mismatch")
+}
+
+// TestEthereumAdapter_DecodeTransaction 解析原生转账和 ERC-20 transfer 调用
+func TestEthereumAdapter_DecodeTransaction(t *testing.T) {
+	adapter := NewEthereumAdapter(big.NewInt(1))
+	native, err := adapter.BuildTransaction(&BuildTxRequest{
+		To:      "0x3535353535353535353535353535353535353535",
+		Amount:  big.NewInt(1_000),
+		FeeRate: 1,
+	})
+	require.NoError(t, err)
+	decoded, err := adapter.DecodeTransaction(native.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, []*Transfer{{To: "0x3535353535353535353535353535353535353535", Amount: big.NewInt(1_000)}}, decoded.Transfers)
+	assert.Empty(t, decoded.Calls)
+	assert.Equal(t, [][]byte{hexutil.MustDecode(native.SigningHash)}, decoded.SigningPayloads)
+
+	const token = "0xdac17f958d2ee523a2206206994597c13d831ec7"
+	data := hexutil.MustDecode("0xa9059cbb" +
+		"000000000000000000000000b94f5374fce5edbc8e2a8697c15331677e6ebf0b" +
+		"00000000000000000000000000000000000000000000000000000000000f4240")
+	erc20, err := adapter.BuildTransaction(&BuildTxRequest{
+		TxType:               EthereumTxTypeDynamicFee,
+		To:                   token,
+		GasLimit:             60_000,
+		MaxFeePerGas:         big.NewInt(30_000_000_000),
+		MaxPriorityFeePerGas: big.NewInt(1_000_000_000),
+		Data:                 data,
+	})
+	require.NoError(t, err)
+	decoded, err = adapter.DecodeTransaction(erc20.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, []*Transfer{{To: "0xb94f5374fce5edbc8e2a8697c15331677e6ebf0b", Amount: big.NewInt(1_000_000), Asset: token}}, decoded.Transfers)
+	assert.Empty(t, decoded.Calls)
+
+	// 参数后追加填充字节仍按转账解析，参数不足时拒绝
+	for _, tc := range []struct {
+		data  []byte
+		valid bool
+	}{
+		{append(append([]byte{}, data...), make([]byte, 32)...), true},
+		{data[:len(data)-1], false},
+	} {
+		padded, err := adapter.BuildTransaction(&BuildTxRequest{To: token, FeeRate: 1, GasLimit: 60_000, Data: tc.data})
+		require.NoError(t, err)
+		decoded, err = adapter.DecodeTransaction(padded.Raw)
+		if !tc.valid {
+			assert.Error(t, err, "short calldata")
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, []*Transfer{{To: "0xb94f5374fce5edbc8e2a8697c15331677e6ebf0b", Amount: big.NewInt(1_000_000), Asset: token}}, decoded.Transfers)
+		assert.Empty(t, decoded.Calls)
+	}
+
+	// 未知方法记为合约调用
+	approve, err := adapter.BuildTransaction(&BuildTxRequest{
+		To:       token,
+		FeeRate:  1,
+		GasLimit: 60_000,
+		Data:     append(hexutil.MustDecode("0x095ea7b3"), data[4:]...),
+	})
+	require.NoError(t, err)
+	decoded, err = adapter.DecodeTransaction(approve.Raw)
+	require.NoError(t, err)
+	assert.Empty(t, decoded.Transfers)
+	assert.Equal(t, []*ContractCall{{Contract: token, Method: "0x095ea7b3"}}, decoded.Calls)
+
+	_, err = NewEthereumAdapter(big.NewInt(56)).DecodeTransaction(erc20.Raw)
+	assert.Error(t, err, "chain id mismatch")
+}
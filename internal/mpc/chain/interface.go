@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"context"
 	"math/big"
 )
 
@@ -12,12 +13,92 @@ type BuildTxRequest struct {
 	Nonce   uint64
 	FeeRate uint64
 	Data    []byte
+
+	// Inputs 花费的 UTXO（仅 Bitcoin）
+	Inputs []*UTXO
+	// Outputs 交易输出（仅 Bitcoin），To/Amount 非空时追加为一个输出
+	Outputs []*TxOutput
+	// LockTime 交易 nLockTime（仅 Bitcoin）
+	LockTime uint32
+
+	// TxType EVM 交易类型：0 legacy、1 EIP-2930、2 EIP-1559；为 0 时设置了 MaxFeePerGas 按 2、设置了 AccessList 按 1 处理
+	TxType uint8
+	// GasLimit EVM 交易 gas 上限，为 0 时普通转账使用 21000
+	GasLimit uint64
+	// MaxFeePerGas EIP-1559 交易的 maxFeePerGas（wei）；legacy/EIP-2930 交易使用 FeeRate 作为 gasPrice
+	MaxFeePerGas *big.Int
+	// MaxPriorityFeePerGas EIP-1559 交易的 maxPriorityFeePerGas（wei）
+	MaxPriorityFeePerGas *big.Int
+	// AccessList EIP-2930 访问列表（类型 1 和 2）
+	AccessList []*AccessTuple
+
+	// RecentBlockhash 最近的区块哈希（Base58），仅 Solana，由调用方从节点获取
+	RecentBlockhash string
+	// TokenMint SPL Token 铸币地址（仅 Solana），非空时在 From 和 To 的关联代币账户之间转账
+	TokenMint string
+	// TokenDecimals SPL Token 精度，TransferChecked 指令会校验
+	TokenDecimals uint8
+	// CreateTokenAccount 转账前幂等创建接收方的关联代币账户（仅 Solana SPL Token）
+	CreateTokenAccount bool
+}
+
+// AccessTuple EIP-2930 访问列表项
+type AccessTuple struct {
+	Address     string
+	StorageKeys []string
+}
+
+// UTXO 待花费的 Bitcoin 输出
+type UTXO struct {
+	TxID string
+	Vout uint32
+	// Amount 输出金额（satoshi）
+	Amount int64
+	// ScriptPubKey 输出脚本，支持 P2WPKH 和 P2TR
+	ScriptPubKey []byte
+	// PublicKey P2WPKH 为压缩公钥；P2TR 为内部公钥（33 字节压缩或 32 字节 x-only）
+	PublicKey []byte
+	// TaprootMerkleRoot P2TR 脚本树根，为空表示 BIP-86 无脚本路径
+	TaprootMerkleRoot []byte
+	// Sequence 输入 nSequence，为 0 时使用 0xfffffffd（允许 RBF）
+	Sequence uint32
+}
+
+// TxOutput Bitcoin 交易输出
+type TxOutput struct {
+	Address string
+	// Amount 输出金额（satoshi）
+	Amount int64
+}
+
+// InputSigHash 交易输入待门限签名的摘要
+type InputSigHash struct {
+	Index int
+	// ScriptType p2wpkh（BIP-143，ECDSA）或 p2tr（BIP-341 key-path，BIP-340 Schnorr）
+	ScriptType string
+	// SigHash 签名摘要，ECDSA 以预哈希方式签名，Schnorr 直接签名
+	SigHash []byte
+	// PublicKey 签名公钥：P2WPKH 为压缩公钥，P2TR 为内部公钥
+	PublicKey         []byte
+	TaprootMerkleRoot []byte
 }
 
+// InputSigner 对单个输入的签名摘要进行门限签名：P2WPKH 返回 DER 编码的 ECDSA 签名，P2TR 返回 64 字节 BIP-340 签名
+type InputSigner func(ctx context.Context, input *InputSigHash) ([]byte, error)
+
 // Transaction 统一封装原始交易和其哈希
 type Transaction struct {
 	Raw  string
 	Hash string
+
+	// PSBT BIP-174 PSBT（base64），仅 Bitcoin
+	PSBT string
+	// SigHashes 每个输入待签名的摘要，仅 Bitcoin 未签名交易
+	SigHashes []*InputSigHash
+	// SigningHash 待签名的交易哈希（hex），仅 EVM 未签名交易
+	SigningHash string
+	// SigningMessage 待签名的交易消息（hex），仅 Solana 未签名交易，Ed25519 直接签名消息
+	SigningMessage string
 }
 
 // Adapter 定义链适配器需要实现的最小能力
@@ -25,3 +106,36 @@ type Adapter interface {
 	GenerateAddress(pubKey []byte) (string, error)
 	BuildTransaction(req *BuildTxRequest) (*Transaction, error)
 }
+
+// DecodedTransaction 从未签名交易中解析出的资产转移和合约调用，供签名前的策略检查使用
+type DecodedTransaction struct {
+	Transfers []*Transfer
+	// Calls 未能解析为资产转移的合约（程序）调用
+	Calls []*ContractCall
+	// SigningPayloads 交易的待签名数据：EVM 为签名哈希，Bitcoin 为各输入的 sighash，Solana 为交易消息
+	SigningPayloads [][]byte
+	// Fee 交易支付的链原生资产手续费（Bitcoin 为输入总额减输出总额），为空表示未解析
+	Fee *big.Int
+}
+
+// Transfer 交易中的一笔资产转移
+type Transfer struct {
+	To string
+	// Amount 最小单位的数量（wei、satoshi、lamport 或代币最小单位）
+	Amount *big.Int
+	// Asset 为空表示链原生资产，否则为代币合约（EVM）或铸币（Solana）地址
+	Asset string
+}
+
+// ContractCall 交易中的合约（程序）调用
+type ContractCall struct {
+	Contract string
+	// Method EVM 为 4 字节函数选择器，Solana 为指令数据的首字节（0x 前缀 hex）
+	Method string
+}
+
+// TransactionDecoder 由支持策略检查的适配器实现，解析 BuildTransaction 生成的未签名交易：
+// EVM 为 Raw（hex），Bitcoin 为 PSBT（base64），Solana 为 Raw（base64）
+type TransactionDecoder interface {
+	DecodeTransaction(unsigned string) (*DecodedTransaction, error)
+}
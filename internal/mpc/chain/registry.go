@@ -0,0 +1,291 @@
+package chain
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// 密钥曲线和签名算法（与密钥元数据中的取值一致，比较时不区分大小写）
+const (
+	CurveSecp256k1 = "secp256k1"
+	CurveEd25519   = "ed25519"
+
+	AlgorithmECDSA   = "ECDSA"
+	AlgorithmEdDSA   = "EdDSA"
+	AlgorithmSchnorr = "Schnorr"
+)
+
+// AddressOptions 地址生成选项，目前只有 Bitcoin 支持；为空的字段使用链的默认值
+type AddressOptions struct {
+	AddressType string
+	Network     string
+}
+
+// AdapterFactory 按密钥算法和地址选项创建链适配器，返回适配器和补全默认值后的地址选项
+type AdapterFactory func(algorithm string, opts *AddressOptions) (Adapter, *AddressOptions, error)
+
+// ChainSpec 注册到 Registry 的链：链类型及别名、支持的曲线和算法、网络参数和适配器工厂
+type ChainSpec struct {
+	// ChainType 规范链类型，如 bitcoin、ethereum
+	ChainType string
+	// Aliases 链类型别名，如 btc、eth
+	Aliases []string
+	// Curves 链支持的密钥曲线
+	Curves []string
+	// Algorithms 链支持的签名算法
+	Algorithms []string
+	// Network 网络名称，如 mainnet、testnet
+	Network string
+	// ChainID EVM 链 ID，其他链为空
+	ChainID *big.Int
+	// NewAdapter 创建链适配器
+	NewAdapter AdapterFactory
+}
+
+// Registry 链适配器注册表，按链类型或别名（不区分大小写）查找链
+type Registry struct {
+	mu      sync.RWMutex
+	chains  map[string]*ChainSpec
+	aliases map[string]string
+}
+
+// NewRegistry 创建空的链注册表
+func NewRegistry() *Registry {
+	return &Registry{
+		chains:  make(map[string]*ChainSpec),
+		aliases: make(map[string]string),
+	}
+}
+
+// NewDefaultRegistry 创建注册了内置链（主网参数）的注册表：bitcoin、ethereum、bsc、avalanche 和 solana
+func NewDefaultRegistry() *Registry {
+	registry := NewRegistry()
+	for _, spec := range []*ChainSpec{
+		BitcoinChainSpec(BitcoinNetworkMainnet),
+		EthereumChainSpec(nil),
+		BSCChainSpec(nil),
+		AvalancheChainSpec(nil),
+		SolanaChainSpec(),
+	} {
+		if err := registry.Register(spec); err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// Register 注册链，链类型或别名已被占用时报错
+func (r *Registry) Register(spec *ChainSpec) error {
+	if spec == nil || spec.ChainType == "" {
+		return errors.New("chain type is required")
+	}
+	if spec.NewAdapter == nil {
+		return errors.Errorf("chain %s has no adapter factory", spec.ChainType)
+	}
+	if len(spec.Curves) == 0 || len(spec.Algorithms) == 0 {
+		return errors.Errorf("chain %s must declare supported curves and algorithms", spec.ChainType)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chainType := strings.ToLower(spec.ChainType)
+	names := []string{chainType}
+	for _, alias := range spec.Aliases {
+		names = append(names, strings.ToLower(alias))
+	}
+	for _, name := range names {
+		if existing, ok := r.aliases[name]; ok {
+			return errors.Errorf("chain type %s is already registered by %s", name, existing)
+		}
+	}
+
+	r.chains[chainType] = spec
+	for _, name := range names {
+		r.aliases[name] = chainType
+	}
+	return nil
+}
+
+// Get 按链类型或别名查找链
+func (r *Registry) Get(chainType string) (*ChainSpec, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name, ok := r.aliases[strings.ToLower(chainType)]
+	if !ok {
+		return nil, errors.Errorf("unsupported chain type: %s", chainType)
+	}
+	return r.chains[name], nil
+}
+
+// List 返回已注册的规范链类型（按名称排序）
+func (r *Registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.chains))
+	for name := range r.chains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateKey 检查密钥的算法和曲线是否适用于链，例如 ethereum 不接受 ed25519 密钥
+func (r *Registry) ValidateKey(chainType, algorithm, curve string) error {
+	spec, err := r.Get(chainType)
+	if err != nil {
+		return err
+	}
+	return spec.ValidateKey(algorithm, curve)
+}
+
+// NewAdapter 校验密钥算法和曲线后创建链适配器，返回补全默认值后的地址选项
+func (r *Registry) NewAdapter(chainType, algorithm, curve string, opts *AddressOptions) (Adapter, *AddressOptions, error) {
+	spec, err := r.Get(chainType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := spec.ValidateKey(algorithm, curve); err != nil {
+		return nil, nil, err
+	}
+	return spec.NewAdapter(algorithm, opts)
+}
+
+// ValidateKey 检查算法和曲线是否在链支持的范围内，曲线为空时只检查算法
+func (s *ChainSpec) ValidateKey(algorithm, curve string) error {
+	if !containsFold(s.Algorithms, algorithm) {
+		return errors.Errorf("chain %s does not support %s keys (supported: %s)", s.ChainType, algorithm, strings.Join(s.Algorithms, ", "))
+	}
+	if curve != "" && !containsFold(s.Curves, curve) {
+		return errors.Errorf("chain %s does not support curve %s (supported: %s)", s.ChainType, curve, strings.Join(s.Curves, ", "))
+	}
+	return nil
+}
+
+// BitcoinChainSpec Bitcoin 链：secp256k1 ECDSA 和 Schnorr（Taproot）密钥，network 为默认网络，
+// 地址选项中的网络可以覆盖它。未指定地址类型时 Schnorr 密钥使用 P2TR，ECDSA 密钥使用 P2WPKH；
+// P2TR 地址只能由 Schnorr 密钥按 key-path 花费，其他类型只能由 ECDSA 密钥花费
+func BitcoinChainSpec(network string) *ChainSpec {
+	if network == "" {
+		network = BitcoinNetworkMainnet
+	}
+	return &ChainSpec{
+		ChainType:  "bitcoin",
+		Aliases:    []string{"btc"},
+		Curves:     []string{CurveSecp256k1},
+		Algorithms: []string{AlgorithmECDSA, AlgorithmSchnorr},
+		Network:    strings.ToLower(network),
+		NewAdapter: func(algorithm string, opts *AddressOptions) (Adapter, *AddressOptions, error) {
+			resolved := &AddressOptions{Network: strings.ToLower(network)}
+			if opts != nil {
+				resolved.AddressType = strings.ToLower(opts.AddressType)
+				if opts.Network != "" {
+					resolved.Network = strings.ToLower(opts.Network)
+				}
+			}
+			params, err := BitcoinNetworkParams(resolved.Network)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			schnorrKey := strings.EqualFold(algorithm, AlgorithmSchnorr)
+			switch {
+			case resolved.AddressType == "" && schnorrKey:
+				resolved.AddressType = BitcoinAddressTypeP2TR
+			case resolved.AddressType == "":
+				resolved.AddressType = BitcoinAddressTypeP2WPKH
+			case resolved.AddressType == BitcoinAddressTypeP2TR && !schnorrKey:
+				return nil, nil, errors.Errorf("p2tr addresses require a Schnorr key, got %s", algorithm)
+			case resolved.AddressType != BitcoinAddressTypeP2TR && schnorrKey:
+				return nil, nil, errors.Errorf("%s addresses require an ECDSA key, got %s", resolved.AddressType, algorithm)
+			}
+			adapter, err := NewBitcoinAdapter(params).WithAddressType(resolved.AddressType)
+			if err != nil {
+				return nil, nil, err
+			}
+			return adapter, resolved, nil
+		},
+	}
+}
+
+// EVMChainSpec EVM 兼容链：secp256k1 ECDSA 密钥，地址与以太坊相同，chainID 用于交易签名
+func EVMChainSpec(chainType string, aliases []string, chainID *big.Int) *ChainSpec {
+	return &ChainSpec{
+		ChainType:  chainType,
+		Aliases:    aliases,
+		Curves:     []string{CurveSecp256k1},
+		Algorithms: []string{AlgorithmECDSA},
+		Network:    chainID.String(),
+		ChainID:    chainID,
+		NewAdapter: func(_ string, opts *AddressOptions) (Adapter, *AddressOptions, error) {
+			if err := rejectAddressOptions(chainType, opts); err != nil {
+				return nil, nil, err
+			}
+			return NewEthereumAdapter(chainID), &AddressOptions{}, nil
+		},
+	}
+}
+
+// EthereumChainSpec 以太坊，chainID 为空时使用主网（1）
+func EthereumChainSpec(chainID *big.Int) *ChainSpec {
+	if chainID == nil {
+		chainID = big.NewInt(1)
+	}
+	return EVMChainSpec("ethereum", []string{"eth", "evm"}, chainID)
+}
+
+// BSCChainSpec BNB Smart Chain，chainID 为空时使用主网（56）
+func BSCChainSpec(chainID *big.Int) *ChainSpec {
+	if chainID == nil {
+		chainID = big.NewInt(56)
+	}
+	return EVMChainSpec("bsc", []string{"bnb"}, chainID)
+}
+
+// AvalancheChainSpec Avalanche C-Chain，chainID 为空时使用主网（43114）
+func AvalancheChainSpec(chainID *big.Int) *ChainSpec {
+	if chainID == nil {
+		chainID = big.NewInt(43114)
+	}
+	return EVMChainSpec("avalanche", []string{"avax"}, chainID)
+}
+
+// SolanaChainSpec Solana 链：Ed25519 EdDSA 密钥
+func SolanaChainSpec() *ChainSpec {
+	return &ChainSpec{
+		ChainType:  "solana",
+		Aliases:    []string{"sol"},
+		Curves:     []string{CurveEd25519},
+		Algorithms: []string{AlgorithmEdDSA},
+		Network:    "mainnet-beta",
+		NewAdapter: func(_ string, opts *AddressOptions) (Adapter, *AddressOptions, error) {
+			if err := rejectAddressOptions("solana", opts); err != nil {
+				return nil, nil, err
+			}
+			return NewSolanaAdapter(), &AddressOptions{}, nil
+		},
+	}
+}
+
+// rejectAddressOptions 不支持地址选项的链在指定了选项时报错
+func rejectAddressOptions(chainType string, opts *AddressOptions) error {
+	if opts != nil && (opts.AddressType != "" || opts.Network != "") {
+		return errors.Errorf("address type and network options are not supported for chain type %s", chainType)
+	}
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
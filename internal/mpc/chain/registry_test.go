@@ -0,0 +1,103 @@
+package chain
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegistry_Lookup 按链类型和别名（不区分大小写）查找链，重复注册报错
+func TestRegistry_Lookup(t *testing.T) {
+	registry := NewDefaultRegistry()
+	assert.Equal(t, []string{"avalanche", "bitcoin", "bsc", "ethereum", "solana"}, registry.List())
+
+	spec, err := registry.Get("ETH")
+	require.NoError(t, err)
+	assert.Equal(t, "ethereum", spec.ChainType)
+	assert.Equal(t, big.NewInt(1), spec.ChainID)
+
+	_, err = registry.Get("tron")
+	assert.Error(t, err)
+
+	assert.Error(t, registry.Register(BSCChainSpec(big.NewInt(97))), "duplicate chain type")
+	assert.Error(t, registry.Register(EVMChainSpec("polygon", []string{"evm"}, big.NewInt(137))), "duplicate alias")
+	require.NoError(t, registry.Register(EVMChainSpec("polygon", []string{"matic"}, big.NewInt(137))))
+	_, err = registry.Get("matic")
+	assert.NoError(t, err)
+}
+
+// TestRegistry_ValidateKey 链只接受声明的曲线和算法
+func TestRegistry_ValidateKey(t *testing.T) {
+	registry := NewDefaultRegistry()
+	for _, tc := range []struct {
+		chainType, algorithm, curve string
+		ok                          bool
+	}{
+		{"ethereum", "ECDSA", "secp256k1", true},
+		{"ethereum", "EdDSA", "ed25519", false},
+		{"ethereum", "ECDSA", "Ed25519", false},
+		{"bitcoin", "Schnorr", "secp256k1", true},
+		{"bitcoin", "ECDSA", "secp256r1", false},
+		{"solana", "EdDSA", "Ed25519", true},
+		{"solana", "ECDSA", "secp256k1", false},
+		{"cosmos", "ECDSA", "secp256k1", false},
+	} {
+		err := registry.ValidateKey(tc.chainType, tc.algorithm, tc.curve)
+		if tc.ok {
+			assert.NoError(t, err, "%s %s %s", tc.chainType, tc.algorithm, tc.curve)
+		} else {
+			assert.Error(t, err, "%s %s %s", tc.chainType, tc.algorithm, tc.curve)
+		}
+	}
+}
+
+// TestRegistry_NewAdapter 按链配置的网络和地址选项生成地址
+func TestRegistry_NewAdapter(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(BitcoinChainSpec(BitcoinNetworkTestnet)))
+	require.NoError(t, registry.Register(EthereumChainSpec(nil)))
+	require.NoError(t, registry.Register(SolanaChainSpec()))
+
+	privKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	compressed := privKey.PubKey().SerializeCompressed()
+
+	adapter, resolved, err := registry.NewAdapter("btc", "ECDSA", "secp256k1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, &AddressOptions{AddressType: BitcoinAddressTypeP2WPKH, Network: BitcoinNetworkTestnet}, resolved)
+	address, err := adapter.GenerateAddress(compressed)
+	require.NoError(t, err)
+	assert.Regexp(t, "^tb1q", address)
+
+	_, resolved, err = registry.NewAdapter("bitcoin", "Schnorr", "secp256k1", &AddressOptions{Network: BitcoinNetworkMainnet})
+	require.NoError(t, err)
+	assert.Equal(t, &AddressOptions{AddressType: BitcoinAddressTypeP2TR, Network: BitcoinNetworkMainnet}, resolved)
+
+	_, _, err = registry.NewAdapter("bitcoin", "ECDSA", "secp256k1", &AddressOptions{AddressType: BitcoinAddressTypeP2TR})
+	assert.Error(t, err, "p2tr requires a Schnorr key")
+	_, _, err = registry.NewAdapter("ethereum", "ECDSA", "secp256k1", &AddressOptions{Network: BitcoinNetworkTestnet})
+	assert.Error(t, err, "address options on ethereum")
+	_, _, err = registry.NewAdapter("ethereum", "EdDSA", "ed25519", nil)
+	assert.Error(t, err, "ed25519 key on ethereum")
+
+	// 压缩和未压缩公钥生成相同的以太坊地址
+	adapter, _, err = registry.NewAdapter("ethereum", "ECDSA", "secp256k1", nil)
+	require.NoError(t, err)
+	fromCompressed, err := adapter.GenerateAddress(compressed)
+	require.NoError(t, err)
+	fromUncompressed, err := adapter.GenerateAddress(privKey.PubKey().SerializeUncompressed())
+	require.NoError(t, err)
+	assert.Equal(t, fromUncompressed, fromCompressed)
+	assert.Equal(t, strings.ToLower(crypto.PubkeyToAddress(*privKey.PubKey().ToECDSA()).Hex()), fromCompressed)
+
+	adapter, _, err = registry.NewAdapter("sol", "EdDSA", "ed25519", nil)
+	require.NoError(t, err)
+	_, err = adapter.GenerateAddress(ed25519.NewKeyFromSeed(make([]byte, 32)).Public().(ed25519.PublicKey))
+	assert.NoError(t, err)
+}
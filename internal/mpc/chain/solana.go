@@ -0,0 +1,581 @@
+package chain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/pkg/errors"
+)
+
+// Solana 程序地址
+const (
+	SolanaSystemProgramID                 = "11111111111111111111111111111111"
+	SolanaTokenProgramID                  = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	SolanaAssociatedTokenAccountProgramID = "ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL"
+)
+
+// Solana 指令编号
+const (
+	solanaSystemInstructionTransfer       uint32 = 2
+	solanaTokenInstructionTransferChecked byte   = 12
+	solanaAssociatedTokenCreateIdempotent byte   = 1
+)
+
+const (
+	solanaSignatureSize = ed25519.SignatureSize
+	// solanaMaxPacketSize 交易序列化后的大小上限（IPv6 MTU 减去头部）
+	solanaMaxPacketSize = 1232
+	// solanaProgramDerivedAddressMarker 程序派生地址哈希的后缀
+	solanaProgramDerivedAddressMarker = "ProgramDerivedAddress"
+)
+
+// solanaPublicKey Solana 账户地址（32 字节 Ed25519 公钥或程序派生地址）
+type solanaPublicKey [32]byte
+
+// SolanaAdapter 实现 Solana 链基础能力：地址生成、SOL/SPL Token 转账构建与签名组装
+type SolanaAdapter struct{}
+
+// NewSolanaAdapter 创建 Solana 适配器
+func NewSolanaAdapter() *SolanaAdapter {
+	return &SolanaAdapter{}
+}
+
+// GenerateAddress Solana 地址为 32 字节 Ed25519 公钥的 Base58 编码
+func (a *SolanaAdapter) GenerateAddress(pubKey []byte) (string, error) {
+	if len(pubKey) == 0 {
+		return "", errors.New("public key is required")
+	}
+	if len(pubKey) != ed25519.PublicKeySize {
+		return "", errors.Errorf("solana requires a 32-byte Ed25519 public key, got %d bytes", len(pubKey))
+	}
+	if _, err := edwards.ParsePubKey(pubKey); err != nil {
+		return "", errors.Wrap(err, "invalid Ed25519 public key")
+	}
+	return base58.Encode(pubKey), nil
+}
+
+// BuildTransaction 构建由 From 付费并签名的未签名 legacy 交易，RecentBlockhash 由调用方提供。
+// 未设置 TokenMint 时为 System Program SOL 转账（Amount 单位为 lamport）；
+// 设置时为 SPL Token TransferChecked，在 From 和 To 的关联代币账户之间转账（Amount 为代币最小单位），
+// CreateTokenAccount 为 true 时先幂等创建接收方的关联代币账户。
+// Raw 为签名位置填零的交易（base64），SigningMessage 为待签名的交易消息
+func (a *SolanaAdapter) BuildTransaction(req *BuildTxRequest) (*Transaction, error) {
+	if req == nil {
+		return nil, errors.New("build request is nil")
+	}
+	if len(req.Data) > 0 {
+		return nil, errors.New("solana transactions do not support data")
+	}
+	from, err := parseSolanaAddress(req.From)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid from address")
+	}
+	to, err := parseSolanaAddress(req.To)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid to address")
+	}
+	if req.RecentBlockhash == "" {
+		return nil, errors.New("recent blockhash is required")
+	}
+	blockhash, err := parseSolanaAddress(req.RecentBlockhash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid recent blockhash")
+	}
+	if req.Amount == nil || req.Amount.Sign() <= 0 || !req.Amount.IsUint64() {
+		return nil, errors.New("amount must be a positive 64-bit integer")
+	}
+	amount := req.Amount.Uint64()
+
+	var instructions []*solanaInstruction
+	if req.TokenMint == "" {
+		if req.CreateTokenAccount {
+			return nil, errors.New("create token account requires a token mint")
+		}
+		instructions = append(instructions, solanaTransferInstruction(from, to, amount))
+	} else {
+		mint, err := parseSolanaAddress(req.TokenMint)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid token mint")
+		}
+		source, err := findAssociatedTokenAddress(from, mint)
+		if err != nil {
+			return nil, err
+		}
+		destination, err := findAssociatedTokenAddress(to, mint)
+		if err != nil {
+			return nil, err
+		}
+		if req.CreateTokenAccount {
+			instructions = append(instructions, solanaCreateAssociatedTokenAccountInstruction(from, destination, to, mint))
+		}
+		instructions = append(instructions, solanaTransferCheckedInstruction(source, mint, destination, from, amount, req.TokenDecimals))
+	}
+
+	message, err := compileSolanaMessage(from, instructions, blockhash)
+	if err != nil {
+		return nil, err
+	}
+	raw := encodeSolanaTransaction([][]byte{make([]byte, solanaSignatureSize)}, message)
+	if len(raw) > solanaMaxPacketSize {
+		return nil, errors.Errorf("transaction size %d exceeds %d bytes", len(raw), solanaMaxPacketSize)
+	}
+	return &Transaction{
+		Raw:            base64.StdEncoding.EncodeToString(raw),
+		SigningMessage: hex.EncodeToString(message),
+	}, nil
+}
+
+// AssembleTransaction 将 MPC Ed25519 签名插入 BuildTransaction 生成的未签名交易，
+// 返回可广播的交易（base64）及交易签名（Base58，即交易 ID）。签名须由付费账户的公钥验证通过
+func (a *SolanaAdapter) AssembleTransaction(unsigned *Transaction, signature []byte) (*Transaction, error) {
+	if unsigned == nil || unsigned.Raw == "" {
+		return nil, errors.New("unsigned transaction is required")
+	}
+	if len(signature) != solanaSignatureSize {
+		return nil, errors.Errorf("invalid signature length: %d", len(signature))
+	}
+	raw, err := base64.StdEncoding.DecodeString(unsigned.Raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode unsigned transaction")
+	}
+	signatureCount, n, err := decodeCompactU16(raw)
+	if err != nil {
+		return nil, err
+	}
+	if signatureCount != 1 {
+		return nil, errors.Errorf("expected 1 signature, transaction requires %d", signatureCount)
+	}
+	if len(raw) < n+solanaSignatureSize {
+		return nil, errors.New("transaction is truncated")
+	}
+	message := raw[n+solanaSignatureSize:]
+	feePayer, err := solanaMessageFeePayer(message)
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(ed25519.PublicKey(feePayer[:]), message, signature) {
+		return nil, errors.Errorf("signature does not match fee payer %s", base58.Encode(feePayer[:]))
+	}
+
+	signed := encodeSolanaTransaction([][]byte{signature}, message)
+	return &Transaction{
+		Raw:  base64.StdEncoding.EncodeToString(signed),
+		Hash: base58.Encode(signature),
+	}, nil
+}
+
+// DecodeTransaction 解析 BuildTransaction 生成的交易：System Program 转账和 SPL Token TransferChecked 记为 Transfer
+// （接收方关联代币账户在同一交易中创建时记为其所有者，否则为代币账户地址），关联代币账户的创建不单独记录，
+// 其他指令记为 ContractCall
+func (a *SolanaAdapter) DecodeTransaction(unsigned string) (*DecodedTransaction, error) {
+	raw, err := base64.StdEncoding.DecodeString(unsigned)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode unsigned transaction")
+	}
+	signatureCount, n, err := decodeCompactU16(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < n+signatureCount*solanaSignatureSize {
+		return nil, errors.New("transaction is truncated")
+	}
+	message := raw[n+signatureCount*solanaSignatureSize:]
+	instructions, err := parseSolanaMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	systemProgram := mustSolanaAddress(SolanaSystemProgramID)
+	tokenProgram := mustSolanaAddress(SolanaTokenProgramID)
+	ataProgram := mustSolanaAddress(SolanaAssociatedTokenAccountProgramID)
+	// 同一交易中创建的关联代币账户及其所有者
+	tokenAccountOwners := make(map[solanaPublicKey]solanaPublicKey)
+	for _, instruction := range instructions {
+		if isSolanaCreateTokenAccount(instruction, ataProgram) {
+			tokenAccountOwners[instruction.Accounts[1].PublicKey] = instruction.Accounts[2].PublicKey
+		}
+	}
+
+	decoded := &DecodedTransaction{SigningPayloads: [][]byte{message}}
+	for _, instruction := range instructions {
+		data := instruction.Data
+		switch {
+		case instruction.ProgramID == systemProgram && len(data) == 12 && binary.LittleEndian.Uint32(data[:4]) == solanaSystemInstructionTransfer && len(instruction.Accounts) >= 2:
+			decoded.Transfers = append(decoded.Transfers, &Transfer{
+				To:     base58.Encode(instruction.Accounts[1].PublicKey[:]),
+				Amount: new(big.Int).SetUint64(binary.LittleEndian.Uint64(data[4:])),
+			})
+			continue
+		case isSolanaCreateTokenAccount(instruction, ataProgram):
+			continue
+		case instruction.ProgramID == tokenProgram && len(data) == 10 && data[0] == solanaTokenInstructionTransferChecked && len(instruction.Accounts) >= 4:
+			destination := instruction.Accounts[2].PublicKey
+			if owner, ok := tokenAccountOwners[destination]; ok {
+				destination = owner
+			}
+			decoded.Transfers = append(decoded.Transfers, &Transfer{
+				To:     base58.Encode(destination[:]),
+				Amount: new(big.Int).SetUint64(binary.LittleEndian.Uint64(data[1:9])),
+				Asset:  base58.Encode(instruction.Accounts[1].PublicKey[:]),
+			})
+			continue
+		}
+		call := &ContractCall{Contract: base58.Encode(instruction.ProgramID[:])}
+		if len(data) > 0 {
+			call.Method = "0x" + hex.EncodeToString(data[:1])
+		}
+		decoded.Calls = append(decoded.Calls, call)
+	}
+	return decoded, nil
+}
+
+// FindAssociatedTokenAddress 计算 owner 持有 mint 代币的关联代币账户地址
+func FindAssociatedTokenAddress(owner string, mint string) (string, error) {
+	ownerKey, err := parseSolanaAddress(owner)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid owner address")
+	}
+	mintKey, err := parseSolanaAddress(mint)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid token mint")
+	}
+	address, err := findAssociatedTokenAddress(ownerKey, mintKey)
+	if err != nil {
+		return "", err
+	}
+	return base58.Encode(address[:]), nil
+}
+
+// solanaAccountMeta 指令引用的账户及其权限
+type solanaAccountMeta struct {
+	PublicKey solanaPublicKey
+	Signer    bool
+	Writable  bool
+}
+
+// solanaInstruction 未编译的指令
+type solanaInstruction struct {
+	ProgramID solanaPublicKey
+	Accounts  []solanaAccountMeta
+	Data      []byte
+}
+
+// solanaTransferInstruction System Program Transfer：u32 指令编号 || u64 lamports
+func solanaTransferInstruction(from, to solanaPublicKey, lamports uint64) *solanaInstruction {
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data[:4], solanaSystemInstructionTransfer)
+	binary.LittleEndian.PutUint64(data[4:], lamports)
+	return &solanaInstruction{
+		ProgramID: mustSolanaAddress(SolanaSystemProgramID),
+		Accounts: []solanaAccountMeta{
+			{PublicKey: from, Signer: true, Writable: true},
+			{PublicKey: to, Writable: true},
+		},
+		Data: data,
+	}
+}
+
+// solanaTransferCheckedInstruction SPL Token TransferChecked：u8 指令编号 || u64 数量 || u8 精度
+func solanaTransferCheckedInstruction(source, mint, destination, owner solanaPublicKey, amount uint64, decimals uint8) *solanaInstruction {
+	data := make([]byte, 10)
+	data[0] = solanaTokenInstructionTransferChecked
+	binary.LittleEndian.PutUint64(data[1:9], amount)
+	data[9] = decimals
+	return &solanaInstruction{
+		ProgramID: mustSolanaAddress(SolanaTokenProgramID),
+		Accounts: []solanaAccountMeta{
+			{PublicKey: source, Writable: true},
+			{PublicKey: mint},
+			{PublicKey: destination, Writable: true},
+			{PublicKey: owner, Signer: true},
+		},
+		Data: data,
+	}
+}
+
+// solanaCreateAssociatedTokenAccountInstruction 关联代币账户程序 CreateIdempotent，账户已存在时不报错
+func solanaCreateAssociatedTokenAccountInstruction(payer, account, owner, mint solanaPublicKey) *solanaInstruction {
+	return &solanaInstruction{
+		ProgramID: mustSolanaAddress(SolanaAssociatedTokenAccountProgramID),
+		Accounts: []solanaAccountMeta{
+			{PublicKey: payer, Signer: true, Writable: true},
+			{PublicKey: account, Writable: true},
+			{PublicKey: owner},
+			{PublicKey: mint},
+			{PublicKey: mustSolanaAddress(SolanaSystemProgramID)},
+			{PublicKey: mustSolanaAddress(SolanaTokenProgramID)},
+		},
+		Data: []byte{solanaAssociatedTokenCreateIdempotent},
+	}
+}
+
+// compileSolanaMessage 按 legacy 消息格式编译指令：付费账户排在首位，其余账户按
+// 签名可写、签名只读、非签名可写、非签名只读排序，同类账户保持首次出现的顺序
+func compileSolanaMessage(feePayer solanaPublicKey, instructions []*solanaInstruction, blockhash solanaPublicKey) ([]byte, error) {
+	metas := []*solanaAccountMeta{{PublicKey: feePayer, Signer: true, Writable: true}}
+	index := map[solanaPublicKey]*solanaAccountMeta{feePayer: metas[0]}
+	addAccount := func(account solanaAccountMeta) {
+		if existing, ok := index[account.PublicKey]; ok {
+			existing.Signer = existing.Signer || account.Signer
+			existing.Writable = existing.Writable || account.Writable
+			return
+		}
+		meta := account
+		metas = append(metas, &meta)
+		index[account.PublicKey] = &meta
+	}
+	for _, instruction := range instructions {
+		for _, account := range instruction.Accounts {
+			addAccount(account)
+		}
+		addAccount(solanaAccountMeta{PublicKey: instruction.ProgramID})
+	}
+
+	var ordered []*solanaAccountMeta
+	for _, category := range []struct{ signer, writable bool }{{true, true}, {true, false}, {false, true}, {false, false}} {
+		for _, meta := range metas {
+			if meta.Signer == category.signer && meta.Writable == category.writable {
+				ordered = append(ordered, meta)
+			}
+		}
+	}
+
+	var numSigners, numReadonlySigned, numReadonlyUnsigned int
+	positions := make(map[solanaPublicKey]int, len(ordered))
+	for i, meta := range ordered {
+		positions[meta.PublicKey] = i
+		switch {
+		case meta.Signer && !meta.Writable:
+			numSigners++
+			numReadonlySigned++
+		case meta.Signer:
+			numSigners++
+		case !meta.Writable:
+			numReadonlyUnsigned++
+		}
+	}
+	if numSigners != 1 {
+		return nil, errors.Errorf("only the fee payer may sign, transaction requires %d signers", numSigners)
+	}
+	if len(ordered) > 256 {
+		return nil, errors.Errorf("too many accounts: %d", len(ordered))
+	}
+
+	var buf bytes.Buffer
+	buf.Write([]byte{byte(numSigners), byte(numReadonlySigned), byte(numReadonlyUnsigned)})
+	buf.Write(encodeCompactU16(len(ordered)))
+	for _, meta := range ordered {
+		buf.Write(meta.PublicKey[:])
+	}
+	buf.Write(blockhash[:])
+	buf.Write(encodeCompactU16(len(instructions)))
+	for _, instruction := range instructions {
+		buf.WriteByte(byte(positions[instruction.ProgramID]))
+		buf.Write(encodeCompactU16(len(instruction.Accounts)))
+		for _, account := range instruction.Accounts {
+			buf.WriteByte(byte(positions[account.PublicKey]))
+		}
+		buf.Write(encodeCompactU16(len(instruction.Data)))
+		buf.Write(instruction.Data)
+	}
+	return buf.Bytes(), nil
+}
+
+// isSolanaCreateTokenAccount 判断指令是否为创建关联代币账户（Create 或 CreateIdempotent）
+func isSolanaCreateTokenAccount(instruction *solanaInstruction, ataProgram solanaPublicKey) bool {
+	return instruction.ProgramID == ataProgram && len(instruction.Accounts) >= 3 &&
+		(len(instruction.Data) == 0 || (len(instruction.Data) == 1 && instruction.Data[0] == solanaAssociatedTokenCreateIdempotent))
+}
+
+// parseSolanaMessage 解析 legacy 消息中的指令（账户权限按消息头计算）
+func parseSolanaMessage(message []byte) ([]*solanaInstruction, error) {
+	if _, err := solanaMessageFeePayer(message); err != nil {
+		return nil, err
+	}
+	numSigners, numReadonlySigned, numReadonlyUnsigned := int(message[0]), int(message[1]), int(message[2])
+	offset := 3
+	accountCount, n, err := decodeCompactU16(message[offset:])
+	if err != nil {
+		return nil, err
+	}
+	offset += n
+	if len(message) < offset+32*accountCount+32 {
+		return nil, errors.New("message is truncated")
+	}
+	accounts := make([]solanaPublicKey, accountCount)
+	for i := range accounts {
+		copy(accounts[i][:], message[offset:offset+32])
+		offset += 32
+	}
+	offset += 32 // recent blockhash
+
+	readBytes := func() ([]byte, error) {
+		length, n, err := decodeCompactU16(message[offset:])
+		if err != nil {
+			return nil, err
+		}
+		offset += n
+		if len(message) < offset+length {
+			return nil, errors.New("message is truncated")
+		}
+		value := message[offset : offset+length]
+		offset += length
+		return value, nil
+	}
+	instructionCount, n, err := decodeCompactU16(message[offset:])
+	if err != nil {
+		return nil, err
+	}
+	offset += n
+	instructions := make([]*solanaInstruction, 0, instructionCount)
+	for i := 0; i < instructionCount; i++ {
+		if offset >= len(message) {
+			return nil, errors.New("message is truncated")
+		}
+		programIndex := int(message[offset])
+		offset++
+		indices, err := readBytes()
+		if err != nil {
+			return nil, err
+		}
+		data, err := readBytes()
+		if err != nil {
+			return nil, err
+		}
+		if programIndex >= accountCount {
+			return nil, errors.Errorf("instruction %d refers to unknown program account %d", i, programIndex)
+		}
+		instruction := &solanaInstruction{ProgramID: accounts[programIndex], Data: data}
+		for _, index := range indices {
+			if int(index) >= accountCount {
+				return nil, errors.Errorf("instruction %d refers to unknown account %d", i, index)
+			}
+			idx := int(index)
+			instruction.Accounts = append(instruction.Accounts, solanaAccountMeta{
+				PublicKey: accounts[idx],
+				Signer:    idx < numSigners,
+				Writable:  (idx < numSigners-numReadonlySigned) || (idx >= numSigners && idx < accountCount-numReadonlyUnsigned),
+			})
+		}
+		instructions = append(instructions, instruction)
+	}
+	return instructions, nil
+}
+
+// solanaMessageFeePayer 解析 legacy 消息头并返回付费账户（第一个账户）
+func solanaMessageFeePayer(message []byte) (solanaPublicKey, error) {
+	var feePayer solanaPublicKey
+	if len(message) < 3 {
+		return feePayer, errors.New("message is truncated")
+	}
+	if message[0]&0x80 != 0 {
+		return feePayer, errors.New("versioned messages are not supported")
+	}
+	if message[0] != 1 {
+		return feePayer, errors.Errorf("expected 1 required signature, message requires %d", message[0])
+	}
+	accountCount, n, err := decodeCompactU16(message[3:])
+	if err != nil {
+		return feePayer, err
+	}
+	if accountCount == 0 || len(message) < 3+n+32 {
+		return feePayer, errors.New("message has no accounts")
+	}
+	copy(feePayer[:], message[3+n:3+n+32])
+	return feePayer, nil
+}
+
+// encodeSolanaTransaction 交易线格式：compact-u16 签名数量 || 签名 || 消息
+func encodeSolanaTransaction(signatures [][]byte, message []byte) []byte {
+	var buf bytes.Buffer
+	buf.Write(encodeCompactU16(len(signatures)))
+	for _, signature := range signatures {
+		buf.Write(signature)
+	}
+	buf.Write(message)
+	return buf.Bytes()
+}
+
+// findAssociatedTokenAddress 关联代币账户为种子 [owner, token program, mint] 在关联代币账户程序下的程序派生地址
+func findAssociatedTokenAddress(owner, mint solanaPublicKey) (solanaPublicKey, error) {
+	tokenProgram := mustSolanaAddress(SolanaTokenProgramID)
+	return findProgramAddress([][]byte{owner[:], tokenProgram[:], mint[:]}, mustSolanaAddress(SolanaAssociatedTokenAccountProgramID))
+}
+
+// findProgramAddress 从 bump 255 开始递减，返回第一个不在 Ed25519 曲线上的
+// SHA-256(seeds || bump || programID || "ProgramDerivedAddress")
+func findProgramAddress(seeds [][]byte, programID solanaPublicKey) (solanaPublicKey, error) {
+	for bump := 255; bump >= 0; bump-- {
+		h := sha256.New()
+		for _, seed := range seeds {
+			h.Write(seed)
+		}
+		h.Write([]byte{byte(bump)})
+		h.Write(programID[:])
+		h.Write([]byte(solanaProgramDerivedAddressMarker))
+
+		var address solanaPublicKey
+		copy(address[:], h.Sum(nil))
+		if _, err := edwards.ParsePubKey(address[:]); err != nil {
+			return address, nil
+		}
+	}
+	return solanaPublicKey{}, errors.New("unable to find a viable program address")
+}
+
+// parseSolanaAddress 解析 Base58 编码的 32 字节地址或区块哈希
+func parseSolanaAddress(address string) (solanaPublicKey, error) {
+	var key solanaPublicKey
+	if address == "" {
+		return key, errors.New("address is required")
+	}
+	decoded := base58.Decode(address)
+	if len(decoded) != len(key) {
+		return key, errors.Errorf("invalid solana address %s", address)
+	}
+	copy(key[:], decoded)
+	return key, nil
+}
+
+func mustSolanaAddress(address string) solanaPublicKey {
+	key, err := parseSolanaAddress(address)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// encodeCompactU16 Solana shortvec 编码：每字节 7 位，最高位表示后续还有字节
+func encodeCompactU16(n int) []byte {
+	var out []byte
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+// decodeCompactU16 解码 shortvec，返回数值和占用的字节数
+func decodeCompactU16(data []byte) (int, int, error) {
+	value := 0
+	for i := 0; i < 3; i++ {
+		if i >= len(data) {
+			return 0, 0, errors.New("compact-u16 is truncated")
+		}
+		value |= int(data[i]&0x7f) << (7 * i)
+		if data[i]&0x80 == 0 {
+			return value, i + 1, nil
+		}
+	}
+	return 0, 0, errors.New("compact-u16 is too long")
+}
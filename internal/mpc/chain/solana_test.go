@@ -0,0 +1,195 @@
+package chain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSolanaBlockhash = "EETubP5AKHgjPAhzPAFcb8BAY1hMH639CWCFTqi3hq1k"
+
+func newSolanaKey(t *testing.T, seed byte) (ed25519.PrivateKey, string) {
+	t.Helper()
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+	address, err := NewSolanaAdapter().GenerateAddress(key.Public().(ed25519.PublicKey))
+	require.NoError(t, err)
+	return key, address
+}
+
+// TestSolanaAdapter_GenerateAddress 零种子 Ed25519 公钥的 Base58 地址，以及 SPL 关联代币账户测试向量
+func TestSolanaAdapter_GenerateAddress(t *testing.T) {
+	_, address := newSolanaKey(t, 0)
+	assert.Equal(t, "4zvwRjXUKGfvwnParsHAS3HuSVzV5cA4McphgmoCtajS", address)
+
+	_, err := NewSolanaAdapter().GenerateAddress(make([]byte, 33))
+	assert.Error(t, err, "secp256k1 compressed key")
+
+	ata, err := FindAssociatedTokenAddress("B8UwBUUnKwCyKuGMbFKWaG7exYdDk2ozZrPg72NyVbfj", "7o36UsWR1JQLpZ9PE2gn9L4SQ69CNNiWAXd4Jt7rqz9Z")
+	require.NoError(t, err)
+	assert.Equal(t, "DShWnroshVbeUp28oopA3Pu7oFPDBtC1DBmPECXXAQ9n", ata)
+}
+
+// TestSolanaAdapter_Transfer SOL 转账的消息布局、签名组装和交易 ID
+func TestSolanaAdapter_Transfer(t *testing.T) {
+	adapter := NewSolanaAdapter()
+	key, from := newSolanaKey(t, 1)
+	_, to := newSolanaKey(t, 2)
+
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{
+		From:            from,
+		To:              to,
+		Amount:          big.NewInt(1_000_000_000),
+		RecentBlockhash: testSolanaBlockhash,
+	})
+	require.NoError(t, err)
+
+	var expected bytes.Buffer
+	expected.Write([]byte{1, 0, 1, 3})
+	expected.Write(base58.Decode(from))
+	expected.Write(base58.Decode(to))
+	expected.Write(make([]byte, 32)) // system program
+	expected.Write(base58.Decode(testSolanaBlockhash))
+	expected.Write([]byte{1, 2, 2, 0, 1, 12, 2, 0, 0, 0})
+	expected.Write([]byte{0x00, 0xca, 0x9a, 0x3b, 0, 0, 0, 0})
+	assert.Equal(t, hex.EncodeToString(expected.Bytes()), unsigned.SigningMessage)
+
+	message, err := hex.DecodeString(unsigned.SigningMessage)
+	require.NoError(t, err)
+	signature := ed25519.Sign(key, message)
+	signed, err := adapter.AssembleTransaction(unsigned, signature)
+	require.NoError(t, err)
+	assert.Equal(t, base58.Encode(signature), signed.Hash)
+
+	raw, err := base64.StdEncoding.DecodeString(signed.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, append(append([]byte{1}, signature...), message...), raw)
+}
+
+// TestSolanaAdapter_TokenTransfer SPL Token 转账：创建接收方关联代币账户后 TransferChecked
+func TestSolanaAdapter_TokenTransfer(t *testing.T) {
+	adapter := NewSolanaAdapter()
+	key, from := newSolanaKey(t, 1)
+	_, to := newSolanaKey(t, 2)
+	const mint = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{
+		From:               from,
+		To:                 to,
+		Amount:             big.NewInt(2_500_000),
+		RecentBlockhash:    testSolanaBlockhash,
+		TokenMint:          mint,
+		TokenDecimals:      6,
+		CreateTokenAccount: true,
+	})
+	require.NoError(t, err)
+
+	source, err := FindAssociatedTokenAddress(from, mint)
+	require.NoError(t, err)
+	destination, err := FindAssociatedTokenAddress(to, mint)
+	require.NoError(t, err)
+
+	message, err := hex.DecodeString(unsigned.SigningMessage)
+	require.NoError(t, err)
+	// 1 个签名账户，0 个签名只读账户，5 个非签名只读账户（接收方、mint、system、token、ATA 程序）
+	require.Equal(t, []byte{1, 0, 5, 8}, message[:4])
+	accounts := make([]string, 8)
+	for i := range accounts {
+		accounts[i] = base58.Encode(message[4+32*i : 4+32*(i+1)])
+	}
+	assert.Equal(t, []string{
+		from, destination, source,
+		to, mint, SolanaSystemProgramID, SolanaTokenProgramID, SolanaAssociatedTokenAccountProgramID,
+	}, accounts)
+
+	instructions := message[4+32*8+32:]
+	assert.Equal(t, []byte{
+		2,                            // 指令数量
+		7, 6, 0, 1, 3, 4, 5, 6, 1, 1, // CreateIdempotent
+		6, 4, 2, 4, 1, 0, 10, 12, 0xa0, 0x25, 0x26, 0, 0, 0, 0, 0, 6, // TransferChecked
+	}, instructions)
+
+	signed, err := adapter.AssembleTransaction(unsigned, ed25519.Sign(key, message))
+	require.NoError(t, err)
+	assert.NotEmpty(t, signed.Raw)
+}
+
+// TestSolanaAdapter_Invalid 地址、区块哈希、金额不合法以及签名来自其他密钥时报错
+func TestSolanaAdapter_Invalid(t *testing.T) {
+	adapter := NewSolanaAdapter()
+	_, from := newSolanaKey(t, 1)
+	_, to := newSolanaKey(t, 2)
+	amount := big.NewInt(1)
+
+	for name, req := range map[string]*BuildTxRequest{
+		"invalid from":        {From: "0x1234", To: to, Amount: amount, RecentBlockhash: testSolanaBlockhash},
+		"invalid to":          {From: from, To: "abc", Amount: amount, RecentBlockhash: testSolanaBlockhash},
+		"missing blockhash":   {From: from, To: to, Amount: amount},
+		"zero amount":         {From: from, To: to, Amount: big.NewInt(0), RecentBlockhash: testSolanaBlockhash},
+		"amount overflow":     {From: from, To: to, Amount: new(big.Int).Lsh(big.NewInt(1), 64), RecentBlockhash: testSolanaBlockhash},
+		"invalid mint":        {From: from, To: to, Amount: amount, RecentBlockhash: testSolanaBlockhash, TokenMint: "mint"},
+		"create without mint": {From: from, To: to, Amount: amount, RecentBlockhash: testSolanaBlockhash, CreateTokenAccount: true},
+		"data is unsupported": {From: from, To: to, Amount: amount, RecentBlockhash: testSolanaBlockhash, Data: []byte{1}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := adapter.BuildTransaction(req)
+			assert.Error(t, err)
+		})
+	}
+
+	unsigned, err := adapter.BuildTransaction(&BuildTxRequest{From: from, To: to, Amount: amount, RecentBlockhash: testSolanaBlockhash})
+	require.NoError(t, err)
+	message, err := hex.DecodeString(unsigned.SigningMessage)
+	require.NoError(t, err)
+	other, _ := newSolanaKey(t, 3)
+
+	_, err = adapter.AssembleTransaction(unsigned, ed25519.Sign(other, message))
+	assert.Error(t, err, "signature from another key")
+	_, err = adapter.AssembleTransaction(unsigned, make([]byte, 10))
+	assert.Error(t, err, "short signature")
+}
+
+// TestSolanaAdapter_DecodeTransaction 解析 SOL 转账和 SPL Token 转账，代币接收方为关联代币账户的所有者
+func TestSolanaAdapter_DecodeTransaction(t *testing.T) {
+	adapter := NewSolanaAdapter()
+	_, from := newSolanaKey(t, 1)
+	_, to := newSolanaKey(t, 2)
+	const mint = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+
+	transfer, err := adapter.BuildTransaction(&BuildTxRequest{From: from, To: to, Amount: big.NewInt(1_000_000_000), RecentBlockhash: testSolanaBlockhash})
+	require.NoError(t, err)
+	decoded, err := adapter.DecodeTransaction(transfer.Raw)
+	require.NoError(t, err)
+	require.Len(t, decoded.Transfers, 1)
+	assert.Equal(t, to, decoded.Transfers[0].To)
+	assert.Equal(t, "1000000000", decoded.Transfers[0].Amount.String())
+	assert.Empty(t, decoded.Transfers[0].Asset)
+	assert.Empty(t, decoded.Calls)
+	require.Len(t, decoded.SigningPayloads, 1)
+	assert.Equal(t, transfer.SigningMessage, hex.EncodeToString(decoded.SigningPayloads[0]))
+
+	token, err := adapter.BuildTransaction(&BuildTxRequest{
+		From:               from,
+		To:                 to,
+		Amount:             big.NewInt(2_500_000),
+		RecentBlockhash:    testSolanaBlockhash,
+		TokenMint:          mint,
+		TokenDecimals:      6,
+		CreateTokenAccount: true,
+	})
+	require.NoError(t, err)
+	decoded, err = adapter.DecodeTransaction(token.Raw)
+	require.NoError(t, err)
+	require.Len(t, decoded.Transfers, 1)
+	assert.Equal(t, &Transfer{To: to, Amount: big.NewInt(2_500_000), Asset: mint}, decoded.Transfers[0])
+	assert.Empty(t, decoded.Calls)
+
+	_, err = adapter.DecodeTransaction("not base64")
+	assert.Error(t, err)
+}
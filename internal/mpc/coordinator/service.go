@@ -64,9 +64,15 @@ func (s *Service) CreateSigningSession(ctx context.Context, req *CreateSessionRe
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get key")
 	}
+	if !keyMetadata.Enabled() {
+		return nil, errors.Wrapf(key.ErrKeyNotEnabled, "key %s is %s", req.KeyID, keyMetadata.Status)
+	}
 
-	// 选择协议
+	// 选择协议：未指定时使用密钥记录的协议
 	protocol := req.Protocol
+	if protocol == "" {
+		protocol = keyMetadata.Protocol
+	}
 	if protocol == "" {
 		protocol = s.protocolEngine.DefaultProtocol()
 	}
@@ -151,11 +157,12 @@ func (s *Service) CreateDKGSession(ctx context.Context, req *CreateDKGSessionReq
 			Msg("Final participant node IDs for DKG session (coordinator does NOT participate, sorted)")
 	}
 
-	// 2. 选择协议
-	protocol := req.Protocol
-	if protocol == "" {
-		protocol = s.protocolEngine.DefaultProtocol()
+	// 2. 选择协议：按算法确定（EdDSA/Schnorr 使用 FROST），并回写到请求中随 StartDKG 下发
+	protocol, err := key.ResolveProtocol(req.Algorithm, req.Protocol, s.protocolEngine.DefaultProtocol())
+	if err != nil {
+		return nil, err
 	}
+	req.Protocol = protocol
 
 	// 3. 创建DKG会话
 	// 记录传递给 CreateKeyGenSession 的节点列表（用于调试）
@@ -232,6 +239,7 @@ func (s *Service) NotifyParticipantsForDKG(ctx context.Context, req *CreateDKGSe
 		Threshold:  int32(req.Threshold),
 		TotalNodes: int32(req.TotalNodes),
 		NodeIds:    nodeIDs,
+		Protocol:   req.Protocol,
 	}
 
 	// 异步调用 StartDKG，避免阻塞 HTTP 请求
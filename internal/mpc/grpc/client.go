@@ -250,6 +250,179 @@ func (c *GRPCClient) SendStartSign(ctx context.Context, nodeID string, req *pb.S
 	return resp, nil
 }
 
+// SendStartResharing 调用参与者的 StartResharing RPC（同步等待该节点完成 resharing）
+func (c *GRPCClient) SendStartResharing(ctx context.Context, nodeID string, req *pb.StartResharingRequest) (*pb.StartResharingResponse, error) {
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Str("session_id", req.SessionId).
+		Msg("Sending StartResharing RPC to participant")
+
+	client, err := c.getOrCreateConnection(ctx, nodeID)
+	if err != nil {
+		log.Error().Err(err).Str("node_id", nodeID).Msg("Failed to get gRPC connection")
+		return nil, errors.Wrapf(err, "failed to get connection to node %s", nodeID)
+	}
+
+	resp, err := client.StartResharing(ctx, req)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", nodeID).
+			Str("key_id", req.KeyId).
+			Str("session_id", req.SessionId).
+			Msg("StartResharing RPC call failed")
+		return nil, err
+	}
+
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Str("session_id", req.SessionId).
+		Bool("success", resp.Success).
+		Str("message", resp.Message).
+		Msg("StartResharing RPC call succeeded")
+
+	return resp, nil
+}
+
+// SendStartPresign 调用参与者的 StartPresign RPC（同步等待预签名完成）
+func (c *GRPCClient) SendStartPresign(ctx context.Context, nodeID string, req *pb.StartPresignRequest) (*pb.StartPresignResponse, error) {
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Str("session_id", req.SessionId).
+		Msg("Sending StartPresign RPC to participant")
+
+	client, err := c.getOrCreateConnection(ctx, nodeID)
+	if err != nil {
+		log.Error().Err(err).Str("node_id", nodeID).Msg("Failed to get gRPC connection")
+		return nil, errors.Wrapf(err, "failed to get connection to node %s", nodeID)
+	}
+
+	resp, err := client.StartPresign(ctx, req)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", nodeID).
+			Str("key_id", req.KeyId).
+			Str("session_id", req.SessionId).
+			Msg("StartPresign RPC call failed")
+		return nil, err
+	}
+
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Str("session_id", req.SessionId).
+		Bool("success", resp.Success).
+		Str("message", resp.Message).
+		Msg("StartPresign RPC call succeeded")
+
+	return resp, nil
+}
+
+// SendExportShareBackup 请求参与节点导出其分片的加密备份
+func (c *GRPCClient) SendExportShareBackup(ctx context.Context, nodeID string, req *pb.ExportShareBackupRequest) (*pb.ExportShareBackupResponse, error) {
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Int32("share_epoch", req.ShareEpoch).
+		Msg("Sending ExportShareBackup RPC to participant")
+
+	client, err := c.getOrCreateConnection(ctx, nodeID)
+	if err != nil {
+		log.Error().Err(err).Str("node_id", nodeID).Msg("Failed to get gRPC connection")
+		return nil, errors.Wrapf(err, "failed to get connection to node %s", nodeID)
+	}
+
+	resp, err := client.ExportShareBackup(ctx, req)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", nodeID).
+			Str("key_id", req.KeyId).
+			Msg("ExportShareBackup RPC call failed")
+		return nil, err
+	}
+
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Bool("success", resp.Success).
+		Str("message", resp.Message).
+		Msg("ExportShareBackup RPC call succeeded")
+
+	return resp, nil
+}
+
+// SendProveKeyShare 请求参与节点证明其持有密钥分片
+func (c *GRPCClient) SendProveKeyShare(ctx context.Context, nodeID string, req *pb.ProveKeyShareRequest) (*pb.ProveKeyShareResponse, error) {
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Int32("share_epoch", req.ShareEpoch).
+		Msg("Sending ProveKeyShare RPC to participant")
+
+	client, err := c.getOrCreateConnection(ctx, nodeID)
+	if err != nil {
+		log.Error().Err(err).Str("node_id", nodeID).Msg("Failed to get gRPC connection")
+		return nil, errors.Wrapf(err, "failed to get connection to node %s", nodeID)
+	}
+
+	resp, err := client.ProveKeyShare(ctx, req)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", nodeID).
+			Str("key_id", req.KeyId).
+			Msg("ProveKeyShare RPC call failed")
+		return nil, err
+	}
+
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Bool("success", resp.Success).
+		Str("message", resp.Message).
+		Msg("ProveKeyShare RPC call succeeded")
+
+	return resp, nil
+}
+
+// SendDestroyKeyShare 请求参与节点销毁其密钥分片
+func (c *GRPCClient) SendDestroyKeyShare(ctx context.Context, nodeID string, req *pb.DestroyKeyShareRequest) (*pb.DestroyKeyShareResponse, error) {
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Msg("Sending DestroyKeyShare RPC to participant")
+
+	client, err := c.getOrCreateConnection(ctx, nodeID)
+	if err != nil {
+		log.Error().Err(err).Str("node_id", nodeID).Msg("Failed to get gRPC connection")
+		return nil, errors.Wrapf(err, "failed to get connection to node %s", nodeID)
+	}
+
+	resp, err := client.DestroyKeyShare(ctx, req)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("node_id", nodeID).
+			Str("key_id", req.KeyId).
+			Msg("DestroyKeyShare RPC call failed")
+		return nil, err
+	}
+
+	log.Debug().
+		Str("node_id", nodeID).
+		Str("key_id", req.KeyId).
+		Bool("success", resp.Success).
+		Str("message", resp.Message).
+		Msg("DestroyKeyShare RPC call succeeded")
+
+	return resp, nil
+}
+
 // SendSigningMessage 发送签名协议消息到目标节点
 func (c *GRPCClient) SendSigningMessage(ctx context.Context, nodeID string, msg tss.Message, sessionID string) error {
 	// 防止节点向自己发送消息
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"encoding/hex"
+	"encoding/json"
 
 	"github.com/kashguard/go-mpc-wallet/internal/config"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/node"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/session"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
@@ -57,6 +59,7 @@ type GRPCServer struct {
 	protocolEngine   protocol.Engine            // 默认协议引擎
 	protocolRegistry *protocol.ProtocolRegistry // 协议注册表（用于动态选择协议）
 	sessionManager   *session.Manager
+	nodeManager      *node.Manager           // 记录可识别中止的责任节点（可选）
 	keyShareStorage  storage.KeyShareStorage // 用于存储密钥分片
 	nodeID           string
 	cfg              *ServerConfig
@@ -81,6 +84,9 @@ type ServerConfig struct {
 	TLSCACertFile string
 	MaxConnAge    time.Duration
 	KeepAlive     time.Duration
+
+	// BackupRecoveryPublicKey 分片备份的离线恢复公钥（hex），为空时拒绝导出备份
+	BackupRecoveryPublicKey string
 }
 
 // NewGRPCServer 创建gRPC服务端
@@ -91,7 +97,7 @@ func NewGRPCServer(
 	keyShareStorage storage.KeyShareStorage,
 	nodeID string,
 ) *GRPCServer {
-	return NewGRPCServerWithRegistry(cfg, protocolEngine, nil, sessionManager, keyShareStorage, nodeID)
+	return NewGRPCServerWithRegistry(cfg, protocolEngine, nil, sessionManager, nil, keyShareStorage, nodeID)
 }
 
 // NewGRPCServerWithRegistry 创建gRPC服务端（带协议注册表）
@@ -100,6 +106,7 @@ func NewGRPCServerWithRegistry(
 	protocolEngine protocol.Engine,
 	protocolRegistry *protocol.ProtocolRegistry, // 协议注册表（可选，用于动态选择协议）
 	sessionManager *session.Manager,
+	nodeManager *node.Manager, // 节点管理器（可选，用于更新节点故障评分）
 	keyShareStorage storage.KeyShareStorage,
 	nodeID string,
 ) *GRPCServer {
@@ -108,12 +115,15 @@ func NewGRPCServerWithRegistry(
 		TLSEnabled: cfg.MPC.TLSEnabled,
 		MaxConnAge: 2 * time.Hour,
 		KeepAlive:  30 * time.Second,
+
+		BackupRecoveryPublicKey: cfg.MPC.BackupRecoveryPublicKey,
 	}
 
 	srv := &GRPCServer{
 		protocolEngine:   protocolEngine,
 		protocolRegistry: protocolRegistry,
 		sessionManager:   sessionManager,
+		nodeManager:      nodeManager,
 		keyShareStorage:  keyShareStorage,
 		nodeID:           nodeID,
 		cfg:              serverCfg,
@@ -306,8 +316,11 @@ func (s *GRPCServer) StartDKG(ctx context.Context, req *pb.StartDKGRequest) (*pb
 			// EdDSA/Schnorr + ed25519/secp256k1 -> FROST
 			var selectedEngine protocol.Engine
 			if s.protocolRegistry != nil {
-				// 根据算法和曲线推断协议
-				protocolName := inferProtocolForDKG(req.Algorithm, req.Curve)
+				// coordinator 指定了协议时直接使用，否则根据算法和曲线推断
+				protocolName := strings.ToLower(req.Protocol)
+				if protocolName == "" {
+					protocolName = inferProtocolForDKG(req.Algorithm, req.Curve)
+				}
 				engine, err := s.protocolRegistry.Get(protocolName)
 				if err != nil {
 					log.Warn().
@@ -351,6 +364,7 @@ func (s *GRPCServer) StartDKG(ctx context.Context, req *pb.StartDKGRequest) (*pb
 					Str("session_id", sessionID).
 					Str("this_node_id", s.nodeID).
 					Msg("GenerateKeyShare failed in StartDKG RPC goroutine")
+				s.reportSessionFailure(sessionID, err)
 			} else if resp != nil && resp.PublicKey != nil && resp.PublicKey.Hex != "" {
 				log.Info().
 					Str("key_id", req.KeyId).
@@ -464,6 +478,11 @@ func (s *GRPCServer) StartSign(ctx context.Context, req *pb.StartSignRequest) (*
 		return &pb.StartSignResponse{Started: false, Message: msg}, nil
 	}
 
+	// 使用预签名：只有一轮在线签名，同步执行并直接返回签名，避免协调者轮询会话状态
+	if req.PresignatureId != "" {
+		return s.startPresignedSign(ctx, sessionID, req)
+	}
+
 	onceInterface, _ := s.signStartOnce.LoadOrStore(sessionID, &sync.Once{})
 	once := onceInterface.(*sync.Once)
 
@@ -499,10 +518,15 @@ func (s *GRPCServer) StartSign(ctx context.Context, req *pb.StartSignRequest) (*
 			}
 
 			signReq := &protocol.SignRequest{
-				KeyID:      req.KeyId,
-				Message:    msg,
-				MessageHex: req.MessageHex,
-				NodeIDs:    req.NodeIds,
+				KeyID:             req.KeyId,
+				Message:           msg,
+				MessageHex:        req.MessageHex,
+				NodeIDs:           req.NodeIds,
+				TaprootKeySpend:   req.TaprootKeySpend,
+				TaprootMerkleRoot: req.TaprootMerkleRoot,
+				DerivationPath:    req.DerivationPath,
+				ChainCode:         req.ChainCode,
+				Prehashed:         req.Prehashed,
 			}
 
 			// 根据请求中的 Protocol 字段选择协议引擎
@@ -559,16 +583,8 @@ func (s *GRPCServer) StartSign(ctx context.Context, req *pb.StartSignRequest) (*
 					Str("this_node_id", s.nodeID).
 					Msg("ThresholdSign failed in StartSign RPC goroutine")
 
-				// ✅ 更新会话状态为失败
-				if sess, getErr := s.sessionManager.GetSession(signCtx, sessionID); getErr == nil {
-					sess.Status = "failed"
-					if updateErr := s.sessionManager.UpdateSession(signCtx, sess); updateErr != nil {
-						log.Error().
-							Err(updateErr).
-							Str("session_id", sessionID).
-							Msg("Failed to update session status to failed")
-					}
-				}
+				// ✅ 更新会话状态为失败，并记录失败原因和责任节点
+				s.reportSessionFailure(sessionID, err)
 				return
 			}
 
@@ -628,6 +644,344 @@ func (s *GRPCServer) StartSign(ctx context.Context, req *pb.StartSignRequest) (*
 	return &pb.StartSignResponse{Started: true, Message: "Signing started in background"}, nil
 }
 
+// StartResharing 由协调者调用以启动本节点的 resharing
+// 与 StartSign 不同，该调用同步等待本节点完成 resharing 并返回公钥，协调者据此确认所有节点结果一致
+func (s *GRPCServer) StartResharing(ctx context.Context, req *pb.StartResharingRequest) (*pb.StartResharingResponse, error) {
+	log.Info().
+		Str("key_id", req.KeyId).
+		Str("session_id", req.SessionId).
+		Str("protocol", req.Protocol).
+		Strs("old_node_ids", req.OldNodeIds).
+		Strs("new_node_ids", req.NewNodeIds).
+		Int32("new_epoch", req.NewEpoch).
+		Str("this_node_id", s.nodeID).
+		Msg("StartResharing RPC received")
+
+	engine := s.protocolEngine
+	if s.protocolRegistry != nil && req.Protocol != "" {
+		if regEngine, err := s.protocolRegistry.Get(strings.ToLower(req.Protocol)); err == nil {
+			engine = regEngine
+		} else {
+			log.Warn().
+				Err(err).
+				Str("session_id", req.SessionId).
+				Str("requested_protocol", req.Protocol).
+				Str("this_node_id", s.nodeID).
+				Msg("Failed to get protocol from registry for resharing, using default engine")
+		}
+	}
+
+	reshareReq := &protocol.ReshareRequest{
+		SessionID:    req.SessionId,
+		KeyID:        req.KeyId,
+		OldNodeIDs:   req.OldNodeIds,
+		OldThreshold: int(req.OldThreshold),
+		NewNodeIDs:   req.NewNodeIds,
+		NewThreshold: int(req.NewThreshold),
+		OldEpoch:     int(req.OldEpoch),
+		NewEpoch:     int(req.NewEpoch),
+	}
+
+	resp, err := engine.RotateKey(ctx, reshareReq)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("key_id", req.KeyId).
+			Str("session_id", req.SessionId).
+			Str("this_node_id", s.nodeID).
+			Msg("RotateKey failed in StartResharing RPC")
+		return &pb.StartResharingResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	publicKeyHex := ""
+	if resp.PublicKey != nil {
+		publicKeyHex = resp.PublicKey.Hex
+	}
+
+	log.Info().
+		Str("key_id", req.KeyId).
+		Str("session_id", req.SessionId).
+		Str("public_key", publicKeyHex).
+		Bool("holds_new_share", resp.KeyShare != nil).
+		Str("this_node_id", s.nodeID).
+		Msg("Resharing completed in StartResharing RPC")
+
+	return &pb.StartResharingResponse{
+		Success:   true,
+		Message:   "resharing completed",
+		PublicKey: publicKeyHex,
+	}, nil
+}
+
+// startPresignedSign 使用预签名同步执行单轮在线签名
+func (s *GRPCServer) startPresignedSign(ctx context.Context, sessionID string, req *pb.StartSignRequest) (*pb.StartSignResponse, error) {
+	engine := s.protocolEngine
+	if s.protocolRegistry != nil && req.Protocol != "" {
+		if regEngine, err := s.protocolRegistry.Get(strings.ToLower(req.Protocol)); err == nil {
+			engine = regEngine
+		} else {
+			log.Warn().
+				Err(err).
+				Str("session_id", sessionID).
+				Str("requested_protocol", req.Protocol).
+				Str("this_node_id", s.nodeID).
+				Msg("Failed to get protocol from registry for presigned signing, using default engine")
+		}
+	}
+
+	signReq := &protocol.SignRequest{
+		KeyID:             req.KeyId,
+		Message:           req.Message,
+		MessageHex:        req.MessageHex,
+		NodeIDs:           req.NodeIds,
+		PresignatureID:    req.PresignatureId,
+		TaprootKeySpend:   req.TaprootKeySpend,
+		TaprootMerkleRoot: req.TaprootMerkleRoot,
+		DerivationPath:    req.DerivationPath,
+		ChainCode:         req.ChainCode,
+		Prehashed:         req.Prehashed,
+	}
+
+	resp, err := engine.ThresholdSign(ctx, sessionID, signReq)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("key_id", req.KeyId).
+			Str("session_id", sessionID).
+			Str("presignature_id", req.PresignatureId).
+			Str("this_node_id", s.nodeID).
+			Msg("Presigned signing failed in StartSign RPC")
+		s.reportSessionFailure(sessionID, err)
+		return &pb.StartSignResponse{Started: false, Message: err.Error()}, nil
+	}
+	if resp == nil || resp.Signature == nil || resp.Signature.Hex == "" {
+		return &pb.StartSignResponse{Started: false, Message: "presigned signing returned empty signature"}, nil
+	}
+
+	if err := s.sessionManager.CompleteSession(ctx, sessionID, resp.Signature.Hex); err != nil {
+		log.Warn().
+			Err(err).
+			Str("session_id", sessionID).
+			Str("this_node_id", s.nodeID).
+			Msg("Failed to complete session (may be completed by another participant)")
+	}
+
+	log.Info().
+		Str("key_id", req.KeyId).
+		Str("session_id", sessionID).
+		Str("presignature_id", req.PresignatureId).
+		Str("this_node_id", s.nodeID).
+		Msg("Presigned signing completed in StartSign RPC")
+
+	return &pb.StartSignResponse{
+		Started:   true,
+		Message:   "presigned signing completed",
+		Signature: resp.Signature.Hex,
+	}, nil
+}
+
+// StartPresign 由协调者调用以生成预签名（GG20 离线阶段或 FROST nonce 承诺）
+// 同步等待本节点完成离线阶段，预签名份额保存在本节点，协调者只记录预签名ID
+func (s *GRPCServer) StartPresign(ctx context.Context, req *pb.StartPresignRequest) (*pb.StartPresignResponse, error) {
+	log.Info().
+		Str("key_id", req.KeyId).
+		Str("session_id", req.SessionId).
+		Str("protocol", req.Protocol).
+		Strs("node_ids", req.NodeIds).
+		Str("this_node_id", s.nodeID).
+		Msg("StartPresign RPC received")
+
+	engine := s.protocolEngine
+	if s.protocolRegistry != nil && req.Protocol != "" {
+		if regEngine, err := s.protocolRegistry.Get(strings.ToLower(req.Protocol)); err == nil {
+			engine = regEngine
+		} else {
+			log.Warn().
+				Err(err).
+				Str("session_id", req.SessionId).
+				Str("requested_protocol", req.Protocol).
+				Str("this_node_id", s.nodeID).
+				Msg("Failed to get protocol from registry for presign, using default engine")
+		}
+	}
+
+	resp, err := engine.Presign(ctx, req.SessionId, &protocol.PresignRequest{
+		KeyID:   req.KeyId,
+		NodeIDs: req.NodeIds,
+	})
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("key_id", req.KeyId).
+			Str("session_id", req.SessionId).
+			Str("this_node_id", s.nodeID).
+			Msg("Presign failed in StartPresign RPC")
+		s.reportSessionFailure(req.SessionId, err)
+		return &pb.StartPresignResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	log.Info().
+		Str("key_id", req.KeyId).
+		Str("presignature_id", resp.PresignatureID).
+		Str("this_node_id", s.nodeID).
+		Msg("Presign completed in StartPresign RPC")
+
+	return &pb.StartPresignResponse{Success: true, Message: "presign completed"}, nil
+}
+
+// ExportShareBackup 由协调者调用以导出本节点分片的加密备份
+// 备份只加密到本节点配置的恢复公钥，协调者无法把分片重定向到其他公钥
+func (s *GRPCServer) ExportShareBackup(ctx context.Context, req *pb.ExportShareBackupRequest) (*pb.ExportShareBackupResponse, error) {
+	log.Info().
+		Str("key_id", req.KeyId).
+		Int32("share_epoch", req.ShareEpoch).
+		Str("this_node_id", s.nodeID).
+		Msg("ExportShareBackup RPC received")
+
+	if s.cfg.BackupRecoveryPublicKey == "" {
+		return &pb.ExportShareBackupResponse{Success: false, Message: "share backup is not configured on this node"}, nil
+	}
+	recoveryPublicKey, err := protocol.ParseRecoveryPublicKey(s.cfg.BackupRecoveryPublicKey)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "invalid backup recovery public key: %v", err)
+	}
+
+	keyData, err := s.keyShareStorage.GetKeyData(ctx, req.KeyId, s.nodeID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "key data not found: %v", err)
+	}
+
+	backup, err := protocol.NewShareBackup(req.KeyId, s.nodeID, int(req.ShareEpoch), keyData, recoveryPublicKey)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("key_id", req.KeyId).
+			Str("this_node_id", s.nodeID).
+			Msg("Failed to create share backup")
+		return &pb.ExportShareBackupResponse{Success: false, Message: err.Error()}, nil
+	}
+	backupJSON, err := json.Marshal(backup)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal share backup: %v", err)
+	}
+
+	log.Info().
+		Str("key_id", req.KeyId).
+		Int32("share_epoch", req.ShareEpoch).
+		Str("public_share", hex.EncodeToString(backup.PublicShare)).
+		Str("this_node_id", s.nodeID).
+		Msg("Share backup exported")
+
+	return &pb.ExportShareBackupResponse{
+		Success: true,
+		Message: "share backup exported",
+		Backup:  backupJSON,
+	}, nil
+}
+
+// ProveKeyShare 由协调者调用以证明本节点持有密钥分片（分片一致性校验）
+// 只返回公开分片、公开分片向量和知识证明，分片本身不离开节点
+func (s *GRPCServer) ProveKeyShare(ctx context.Context, req *pb.ProveKeyShareRequest) (*pb.ProveKeyShareResponse, error) {
+	log.Info().
+		Str("key_id", req.KeyId).
+		Int32("share_epoch", req.ShareEpoch).
+		Str("this_node_id", s.nodeID).
+		Msg("ProveKeyShare RPC received")
+
+	keyData, err := s.keyShareStorage.GetKeyData(ctx, req.KeyId, s.nodeID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "key data not found: %v", err)
+	}
+
+	proof, err := protocol.NewShareProof(req.KeyId, s.nodeID, int(req.ShareEpoch), keyData, req.Challenge)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("key_id", req.KeyId).
+			Str("this_node_id", s.nodeID).
+			Msg("Failed to create share proof")
+		return &pb.ProveKeyShareResponse{Success: false, Message: err.Error()}, nil
+	}
+	proofJSON, err := json.Marshal(proof)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal share proof: %v", err)
+	}
+
+	return &pb.ProveKeyShareResponse{
+		Success: true,
+		Message: "share proof created",
+		Proof:   proofJSON,
+	}, nil
+}
+
+// DestroyKeyShare 由协调者在密钥删除等待期结束后调用，销毁本节点保存的分片、密钥数据和预签名
+// 分片不存在时同样视为成功，协调者可以安全重试
+func (s *GRPCServer) DestroyKeyShare(ctx context.Context, req *pb.DestroyKeyShareRequest) (*pb.DestroyKeyShareResponse, error) {
+	log.Info().
+		Str("key_id", req.KeyId).
+		Str("this_node_id", s.nodeID).
+		Msg("DestroyKeyShare RPC received")
+
+	if req.KeyId == "" {
+		return nil, status.Error(codes.InvalidArgument, "key_id is required")
+	}
+
+	// 不信任请求方：只有本节点读取的元数据显示密钥已进入销毁（Destroying，不能再取消）且删除日期已到时才销毁
+	keyMeta, err := s.sessionManager.GetKeyMetadata(ctx, req.KeyId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to get key metadata: %v", err)
+	}
+	if keyMeta.Status != storage.KeyStatusDestroying {
+		log.Warn().
+			Str("key_id", req.KeyId).
+			Str("status", keyMeta.Status).
+			Str("this_node_id", s.nodeID).
+			Msg("DestroyKeyShare rejected: key is not being destroyed")
+		return nil, status.Errorf(codes.FailedPrecondition, "key %s is not being destroyed (status: %s)", req.KeyId, keyMeta.Status)
+	}
+	if keyMeta.DeletionDate == nil || keyMeta.DeletionDate.After(time.Now()) {
+		log.Warn().
+			Str("key_id", req.KeyId).
+			Str("this_node_id", s.nodeID).
+			Msg("DestroyKeyShare rejected: deletion date has not passed")
+		return nil, status.Errorf(codes.FailedPrecondition, "deletion of key %s is not due yet", req.KeyId)
+	}
+
+	// 先丢弃协议引擎的内存缓存，避免销毁后仍能用缓存的分片签名
+	engines := []protocol.Engine{s.protocolEngine}
+	if s.protocolRegistry != nil {
+		for _, name := range s.protocolRegistry.List() {
+			if engine, err := s.protocolRegistry.Get(name); err == nil {
+				engines = append(engines, engine)
+			}
+		}
+	}
+	for _, engine := range engines {
+		if forgetter, ok := engine.(protocol.KeyForgetter); ok {
+			forgetter.ForgetKey(req.KeyId)
+		}
+	}
+
+	if err := s.keyShareStorage.DestroyKeyMaterial(ctx, req.KeyId, s.nodeID); err != nil {
+		log.Error().
+			Err(err).
+			Str("key_id", req.KeyId).
+			Str("this_node_id", s.nodeID).
+			Msg("Failed to destroy key share")
+		return &pb.DestroyKeyShareResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	log.Info().
+		Str("key_id", req.KeyId).
+		Str("this_node_id", s.nodeID).
+		Msg("Key share destroyed")
+
+	return &pb.DestroyKeyShareResponse{
+		Success: true,
+		Message: "key share destroyed",
+	}, nil
+}
+
 // handleProtocolMessage 处理协议消息（DKG或签名）
 func (s *GRPCServer) handleProtocolMessage(ctx context.Context, sessionID string, fromNodeID string, shareMsg *pb.ShareMessage) error {
 	// 从会话中判断消息类型
@@ -643,6 +997,30 @@ func (s *GRPCServer) handleProtocolMessage(ctx context.Context, sessionID string
 		return errors.Wrapf(err, "failed to get session %s for protocol message from node %s (this node: %s). Possible causes: 1) session was not created by coordinator, 2) session was created but not yet visible due to database replication lag, 3) session expired or was deleted", sessionID, fromNodeID, s.nodeID)
 	}
 
+	// resharing 消息：由 StartResharing 启动的协议实例消费，不走 DKG 自动启动逻辑
+	if strings.HasPrefix(sessionID, session.ResharingSessionPrefix) {
+		engine := s.protocolEngine
+		if s.protocolRegistry != nil && sess.Protocol != "" {
+			if regEngine, err := s.protocolRegistry.Get(strings.ToLower(sess.Protocol)); err == nil {
+				engine = regEngine
+			} else {
+				log.Warn().
+					Err(err).
+					Str("session_id", sessionID).
+					Str("from_node_id", fromNodeID).
+					Str("requested_protocol", sess.Protocol).
+					Str("this_node_id", s.nodeID).
+					Msg("Failed to get protocol from registry for resharing message, fallback to default protocolEngine")
+			}
+		}
+
+		isBroadcast := shareMsg.Round == -1
+		if err := engine.ProcessIncomingResharingMessage(ctx, sessionID, fromNodeID, shareMsg.ShareData, isBroadcast); err != nil {
+			return errors.Wrap(err, "failed to process resharing message")
+		}
+		return nil
+	}
+
 	// 根据会话判断 DKG 还是签名：
 	// - DKG: sessionID 等于 keyID 或以 key- 开头
 	// - 签名: 其他情况一律视为签名（避免签名消息误入 DKG 逻辑）
@@ -725,9 +1103,20 @@ func (s *GRPCServer) handleProtocolMessage(ctx context.Context, sessionID string
 					curve := "secp256k1"
 					protocolLower := strings.ToLower(sess.Protocol)
 					if protocolLower == "frost" {
+						// FROST 支持 Ed25519 和 secp256k1，以密钥元数据中的曲线为准
 						algorithm = "EdDSA"
 						curve = "ed25519"
-					} else if protocolLower == "gg18" || protocolLower == "gg20" {
+						if keyMeta, err := s.sessionManager.GetKeyMetadata(keygenCtx, sess.KeyID); err == nil && keyMeta.Curve != "" {
+							algorithm = keyMeta.Algorithm
+							curve = keyMeta.Curve
+						} else if err != nil {
+							log.Warn().
+								Err(err).
+								Str("session_id", sessionID).
+								Str("key_id", sess.KeyID).
+								Msg("Auto-start DKG: failed to get key metadata, assuming ed25519")
+						}
+					} else if protocolLower == "gg18" || protocolLower == "gg20" || protocolLower == "cggmp21" {
 						algorithm = "ECDSA"
 						curve = "secp256k1"
 					}
@@ -775,6 +1164,7 @@ func (s *GRPCServer) handleProtocolMessage(ctx context.Context, sessionID string
 							Str("key_id", sess.KeyID).
 							Str("this_node_id", s.nodeID).
 							Msg("DKG protocol failed on participant")
+						s.reportSessionFailure(sessionID, err)
 					} else if resp != nil && resp.PublicKey != nil && resp.PublicKey.Hex != "" {
 						log.Info().
 							Str("session_id", sessionID).
@@ -885,6 +1275,47 @@ func (s *GRPCServer) handleProtocolMessage(ctx context.Context, sessionID string
 	return nil
 }
 
+// reportSessionFailure 记录会话失败原因；协议以可识别中止结束时同时持久化责任节点并更新其故障评分
+// 每个诚实参与方都会独立上报，会话的责任节点取并集，节点故障评分按会话去重
+func (s *GRPCServer) reportSessionFailure(sessionID string, err error) {
+	// 协议上下文可能已超时或取消，使用独立的上下文写入失败状态
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	culprits := protocol.Culprits(err)
+	if failErr := s.sessionManager.FailSession(ctx, sessionID, err.Error(), culprits); failErr != nil {
+		log.Error().
+			Err(failErr).
+			Str("session_id", sessionID).
+			Msg("Failed to update session status to failed")
+	}
+
+	if len(culprits) == 0 {
+		return
+	}
+	log.Warn().
+		Str("session_id", sessionID).
+		Str("this_node_id", s.nodeID).
+		Strs("culprits", culprits).
+		Msg("Identifiable abort: recording faults for culprit nodes")
+
+	if s.nodeManager == nil {
+		return
+	}
+	for _, culprit := range culprits {
+		if culprit == s.nodeID {
+			continue
+		}
+		if _, faultErr := s.nodeManager.RecordFault(ctx, culprit, sessionID, err.Error()); faultErr != nil {
+			log.Error().
+				Err(faultErr).
+				Str("session_id", sessionID).
+				Str("culprit_node_id", culprit).
+				Msg("Failed to record node fault")
+		}
+	}
+}
+
 // SubmitSignatureShare 提交签名分片（单向RPC）
 // 这个方法同时用于DKG和签名消息
 func (s *GRPCServer) SubmitSignatureShare(ctx context.Context, req *pb.ShareRequest) (*pb.ShareResponse, error) {
@@ -0,0 +1,205 @@
+package key
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	pb "github.com/kashguard/go-mpc-wallet/internal/pb/mpc/v1"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// CollectShareBackups 向当前委员会的所有节点收集加密分片备份
+// 每个节点把分片加密到自己配置的离线恢复公钥；协调者只校验并保存密文、公开分片和知识证明，
+// 加密到其他公钥、公开分片或证明不匹配的备份会被拒绝
+func (s *DKGService) CollectShareBackups(ctx context.Context, keyID string) (*KeyBackupStatus, error) {
+	if s.grpcClient == nil {
+		return nil, errors.New("share backup requires grpc client")
+	}
+	if s.backupRecoveryPublicKey == "" {
+		return nil, errors.New("backup recovery public key is not configured")
+	}
+	recoveryPublicKey, err := protocol.ParseRecoveryPublicKey(s.backupRecoveryPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keyMeta, err := s.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	if !hasKeyShares(keyMeta.Status) {
+		return nil, errors.Wrapf(ErrInvalidKeyState, "key %s has no key shares (status: %s)", keyID, keyMeta.Status)
+	}
+	nodeIDs, err := s.committeeNodeIDs(ctx, keyMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	req := &pb.ExportShareBackupRequest{
+		KeyId:      keyID,
+		ShareEpoch: int32(keyMeta.ShareEpoch),
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed []string
+	for _, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+			err := s.collectShareBackup(rpcCtx, keyMeta, nodeID, req, recoveryPublicKey)
+			if err != nil {
+				log.Warn().
+					Err(err).
+					Str("key_id", keyID).
+					Str("node_id", nodeID).
+					Msg("CollectShareBackups: failed to collect share backup")
+				mu.Lock()
+				failed = append(failed, nodeID)
+				mu.Unlock()
+			}
+		}(nodeID)
+	}
+	wg.Wait()
+
+	status, err := s.GetShareBackupStatus(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	status.FailedNodeIDs = failed
+
+	log.Info().
+		Str("key_id", keyID).
+		Int("share_epoch", keyMeta.ShareEpoch).
+		Str("status", status.Status).
+		Strs("failed_node_ids", failed).
+		Msg("CollectShareBackups: share backups collected")
+
+	return status, nil
+}
+
+// collectShareBackup 导出并校验单个节点的备份，校验通过后保存
+func (s *DKGService) collectShareBackup(ctx context.Context, keyMeta *storage.KeyMetadata, nodeID string, req *pb.ExportShareBackupRequest, recoveryPublicKey []byte) error {
+	resp, err := s.grpcClient.SendExportShareBackup(ctx, nodeID, req)
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Message)
+	}
+
+	var backup protocol.ShareBackup
+	if err := json.Unmarshal(resp.Backup, &backup); err != nil {
+		return errors.Wrap(err, "failed to unmarshal share backup")
+	}
+	if backup.KeyID != keyMeta.KeyID || backup.NodeID != nodeID || backup.ShareEpoch != keyMeta.ShareEpoch {
+		return errors.Errorf("backup is for key %s node %s epoch %d", backup.KeyID, backup.NodeID, backup.ShareEpoch)
+	}
+	if !strings.EqualFold(hex.EncodeToString(backup.GroupPublicKey), keyMeta.PublicKey) {
+		return errors.New("backup group public key does not match the key")
+	}
+	if string(backup.RecoveryPublicKey) != string(recoveryPublicKey) {
+		return errors.New("backup is encrypted to a different recovery public key")
+	}
+	if err := backup.Verify(); err != nil {
+		return err
+	}
+
+	return s.metadataStore.SaveKeyShareBackup(ctx, &storage.KeyShareBackup{
+		KeyID:             keyMeta.KeyID,
+		NodeID:            nodeID,
+		ShareEpoch:        keyMeta.ShareEpoch,
+		PublicShare:       hex.EncodeToString(backup.PublicShare),
+		RecoveryPublicKey: hex.EncodeToString(backup.RecoveryPublicKey),
+		Backup:            resp.Backup,
+		CreatedAt:         time.Now(),
+	})
+}
+
+// GetShareBackupStatus 检查密钥当前分片轮次的备份是否覆盖所有节点、是否足以重建私钥
+func (s *DKGService) GetShareBackupStatus(ctx context.Context, keyID string) (*KeyBackupStatus, error) {
+	keyMeta, err := s.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	nodeIDs, err := s.committeeNodeIDs(ctx, keyMeta)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.metadataStore.ListKeyShareBackups(ctx, keyID, keyMeta.ShareEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	committee := make(map[string]bool, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		committee[nodeID] = true
+	}
+
+	status := &KeyBackupStatus{
+		KeyID:      keyID,
+		ShareEpoch: keyMeta.ShareEpoch,
+		Backups:    make([]*NodeShareBackup, 0, len(stored)),
+	}
+	backedUp := make(map[string]bool, len(stored))
+	backups := make([]*protocol.ShareBackup, 0, len(stored))
+	for _, record := range stored {
+		if !committee[record.NodeID] {
+			continue
+		}
+		var backup protocol.ShareBackup
+		if err := json.Unmarshal(record.Backup, &backup); err != nil {
+			log.Warn().Err(err).Str("key_id", keyID).Str("node_id", record.NodeID).Msg("GetShareBackupStatus: invalid stored backup")
+			continue
+		}
+		backedUp[record.NodeID] = true
+		backups = append(backups, &backup)
+		status.Backups = append(status.Backups, &NodeShareBackup{
+			NodeID:            record.NodeID,
+			PublicShare:       record.PublicShare,
+			RecoveryPublicKey: record.RecoveryPublicKey,
+			CreatedAt:         record.CreatedAt,
+		})
+	}
+	for _, nodeID := range nodeIDs {
+		if !backedUp[nodeID] {
+			status.MissingNodeIDs = append(status.MissingNodeIDs, nodeID)
+		}
+	}
+
+	switch {
+	case len(backups) == 0:
+		status.Status = BackupStatusMissing
+	case protocol.VerifyShareBackupSet(backups) != nil:
+		status.Status = BackupStatusInsufficient
+	default:
+		status.Recoverable = true
+		status.Status = BackupStatusComplete
+		if len(status.MissingNodeIDs) > 0 {
+			status.Status = BackupStatusPartial
+		}
+	}
+
+	return status, nil
+}
+
+// committeeNodeIDs 当前持有分片的节点：优先使用元数据中记录的节点，兼容旧数据时回退到 DKG 会话的参与节点
+func (s *DKGService) committeeNodeIDs(ctx context.Context, keyMeta *storage.KeyMetadata) ([]string, error) {
+	if len(keyMeta.NodeIDs) > 0 {
+		return keyMeta.NodeIDs, nil
+	}
+	if dkgSession, err := s.metadataStore.GetSigningSession(ctx, keyMeta.KeyID); err == nil && len(dkgSession.ParticipatingNodes) > 0 {
+		return dkgSession.ParticipatingNodes, nil
+	}
+	return nil, errors.Errorf("cannot determine current committee of key %s", keyMeta.KeyID)
+}
@@ -2,14 +2,28 @@ package key
 
 import (
 	"context"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/node"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/session"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	pb "github.com/kashguard/go-mpc-wallet/internal/pb/mpc/v1"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
+// GRPCClient DKGService 调用参与者节点所需的 gRPC 客户端接口
+type GRPCClient interface {
+	SendStartResharing(ctx context.Context, nodeID string, req *pb.StartResharingRequest) (*pb.StartResharingResponse, error)
+	SendExportShareBackup(ctx context.Context, nodeID string, req *pb.ExportShareBackupRequest) (*pb.ExportShareBackupResponse, error)
+	SendProveKeyShare(ctx context.Context, nodeID string, req *pb.ProveKeyShareRequest) (*pb.ProveKeyShareResponse, error)
+	SendDestroyKeyShare(ctx context.Context, nodeID string, req *pb.DestroyKeyShareRequest) (*pb.DestroyKeyShareResponse, error)
+}
+
 // DKGService 分布式密钥生成服务
 type DKGService struct {
 	metadataStore   storage.MetadataStore
@@ -17,6 +31,11 @@ type DKGService struct {
 	protocolEngine  protocol.Engine
 	nodeManager     *node.Manager
 	nodeDiscovery   *node.Discovery
+	sessionManager  *session.Manager
+	grpcClient      GRPCClient
+
+	// backupRecoveryPublicKey 分片备份的离线恢复公钥（hex），收集备份时要求所有备份加密到该公钥
+	backupRecoveryPublicKey string
 }
 
 // NewDKGService 创建DKG服务
@@ -26,6 +45,8 @@ func NewDKGService(
 	protocolEngine protocol.Engine,
 	nodeManager *node.Manager,
 	nodeDiscovery *node.Discovery,
+	sessionManager *session.Manager,
+	grpcClient GRPCClient,
 ) *DKGService {
 	return &DKGService{
 		metadataStore:   metadataStore,
@@ -33,9 +54,16 @@ func NewDKGService(
 		protocolEngine:  protocolEngine,
 		nodeManager:     nodeManager,
 		nodeDiscovery:   nodeDiscovery,
+		sessionManager:  sessionManager,
+		grpcClient:      grpcClient,
 	}
 }
 
+// SetBackupRecoveryPublicKey 设置分片备份的离线恢复公钥（hex 编码的 X25519 公钥）
+func (s *DKGService) SetBackupRecoveryPublicKey(recoveryPublicKey string) {
+	s.backupRecoveryPublicKey = recoveryPublicKey
+}
+
 // ExecuteDKG 执行分布式密钥生成
 func (s *DKGService) ExecuteDKG(ctx context.Context, keyID string, req *CreateKeyRequest) (*protocol.KeyGenResponse, error) {
 	log.Error().
@@ -123,51 +151,227 @@ func (s *DKGService) DistributeKeyShares(ctx context.Context, keyID string, keyS
 	return nil
 }
 
-// RecoverKeyShare 恢复密钥分片（阈值恢复）
+// RecoverKeyShare 恢复完整私钥（阈值恢复）
+// 从本地分片存储读取 nodeIDs 的密钥数据，按分片标识符的 Lagrange 系数重建私钥并校验公钥。
+// 仅适用于各节点分片已恢复到同一存储的恢复环境；生产节点之间不共享分片，离线恢复使用 `app mpc recover`
 func (s *DKGService) RecoverKeyShare(ctx context.Context, keyID string, nodeIDs []string, threshold int) (*protocol.KeyShare, error) {
-	// 收集阈值数量的密钥分片
-	shares := make([][]byte, 0, threshold)
-	indices := make([]int, 0, threshold)
-
-	for i, nodeID := range nodeIDs {
-		if i >= threshold {
-			break
-		}
+	keyMeta, err := s.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	publicKey, err := hex.DecodeString(keyMeta.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode public key")
+	}
 
-		share, err := s.keyShareStorage.GetKeyShare(ctx, keyID, nodeID)
+	shares := make([]*protocol.ShareBackupPlaintext, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		keyData, err := s.keyShareStorage.GetKeyData(ctx, keyID, nodeID)
 		if err != nil {
 			continue // 跳过无法获取的分片
 		}
-
+		share, err := protocol.ShareFromKeyData(keyID, nodeID, keyMeta.ShareEpoch, keyData)
+		if err != nil {
+			log.Warn().Err(err).Str("key_id", keyID).Str("node_id", nodeID).Msg("RecoverKeyShare: failed to parse key data")
+			continue
+		}
 		shares = append(shares, share)
-		indices = append(indices, i+1) // 索引从1开始
 	}
 
 	if len(shares) < threshold {
 		return nil, errors.Errorf("insufficient shares for recovery: need %d, have %d", threshold, len(shares))
 	}
 
-	// TODO: 使用Shamir秘密共享恢复完整密钥
-	// 注意：这仅用于恢复场景，恢复后应立即重新生成分片
+	recovered, err := protocol.RecoverPrivateKey(shares, publicKey)
+	if err != nil {
+		return nil, err
+	}
 
-	return nil, errors.New("key share recovery not yet implemented - requires Shamir secret sharing")
+	// 注意：这仅用于恢复场景，恢复后应立即把资产转移到新密钥
+	return &protocol.KeyShare{
+		ShareID: keyID,
+		Share:   recovered.PrivateKey,
+	}, nil
 }
 
-// ValidateKeyShares 验证密钥分片一致性
-func (s *DKGService) ValidateKeyShares(ctx context.Context, keyID string, publicKey *protocol.PublicKey) error {
-	// TODO: 验证所有节点的密钥分片是否与公钥一致
-	// 这需要实现Shamir秘密共享的验证逻辑
+// RotateKey 密钥重分享（resharing）
+// 将密钥从当前委员会（节点集合+阈值）迁移到新委员会，公钥和地址保持不变。
+// 完成后旧委员会中不在新委员会的节点会删除本地分片，新分片使用新的 share epoch，旧分片无法再参与签名。
+func (s *DKGService) RotateKey(ctx context.Context, req *RotateKeyRequest) (*storage.KeyMetadata, error) {
+	if req == nil || req.KeyID == "" {
+		return nil, errors.New("key id is required")
+	}
+	if s.grpcClient == nil || s.sessionManager == nil {
+		return nil, errors.New("key rotation requires grpc client and session manager")
+	}
 
-	return nil
+	keyMeta, err := s.metadataStore.GetKeyMetadata(ctx, req.KeyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	if keyMeta.Status != storage.KeyStatusEnabled {
+		return nil, errors.Wrapf(ErrKeyNotEnabled, "key %s is %s", req.KeyID, keyMeta.Status)
+	}
+
+	// 1. 确定旧委员会：优先使用元数据中记录的节点，兼容旧数据时回退到 DKG 会话的参与节点
+	oldNodeIDs := keyMeta.NodeIDs
+	protocolName := keyMeta.Protocol
+	if dkgSession, err := s.metadataStore.GetSigningSession(ctx, req.KeyID); err == nil {
+		if len(oldNodeIDs) == 0 {
+			oldNodeIDs = dkgSession.ParticipatingNodes
+		}
+		if protocolName == "" {
+			protocolName = dkgSession.Protocol
+		}
+	}
+	if len(oldNodeIDs) == 0 {
+		return nil, errors.Errorf("cannot determine current committee of key %s", req.KeyID)
+	}
+	if protocolName == "" {
+		protocolName = inferProtocolForKey(keyMeta.Algorithm)
+	}
+
+	newThreshold := req.NewThreshold
+	if newThreshold == 0 {
+		newThreshold = keyMeta.Threshold
+	}
+	newNodeIDs := dedupNodeIDs(req.NewNodeIDs)
+	if minNodes := minCommitteeSize(protocolName, newThreshold); newThreshold < 1 || len(newNodeIDs) < minNodes {
+		return nil, errors.Errorf("new committee needs at least %d nodes, got %d", minNodes, len(newNodeIDs))
+	}
+
+	// 2. 创建 resharing 会话，参与节点为新旧委员会的并集
+	allNodeIDs := dedupNodeIDs(append(append([]string{}, oldNodeIDs...), newNodeIDs...))
+	reshareSession, err := s.sessionManager.CreateResharingSession(ctx, req.KeyID, protocolName, newThreshold, len(newNodeIDs), allNodeIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create resharing session")
+	}
+
+	log.Info().
+		Str("key_id", req.KeyID).
+		Str("session_id", reshareSession.SessionID).
+		Str("protocol", protocolName).
+		Strs("old_node_ids", oldNodeIDs).
+		Int("old_threshold", keyMeta.Threshold).
+		Strs("new_node_ids", newNodeIDs).
+		Int("new_threshold", newThreshold).
+		Int("new_epoch", keyMeta.ShareEpoch+1).
+		Msg("RotateKey: starting resharing on all participants")
+
+	startReq := &pb.StartResharingRequest{
+		SessionId:    reshareSession.SessionID,
+		KeyId:        req.KeyID,
+		Protocol:     protocolName,
+		OldNodeIds:   oldNodeIDs,
+		OldThreshold: int32(keyMeta.Threshold),
+		NewNodeIds:   newNodeIDs,
+		NewThreshold: int32(newThreshold),
+		OldEpoch:     int32(keyMeta.ShareEpoch),
+		NewEpoch:     int32(keyMeta.ShareEpoch + 1),
+	}
+
+	// 3. 并行启动所有节点（StartResharing 为同步调用，返回时该节点已完成）
+	rpcCtx, cancel := context.WithTimeout(ctx, 15*time.Minute)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for _, nodeID := range allNodeIDs {
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+			resp, err := s.grpcClient.SendStartResharing(rpcCtx, nodeID, startReq)
+			if err == nil && !resp.Success {
+				err = errors.New(resp.Message)
+			}
+			if err == nil && !strings.EqualFold(resp.PublicKey, keyMeta.PublicKey) {
+				err = errors.Errorf("public key mismatch: expected %s, got %s", keyMeta.PublicKey, resp.PublicKey)
+			}
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = errors.Wrapf(err, "resharing failed on node %s", nodeID)
+				}
+				mu.Unlock()
+				cancel()
+			}
+		}(nodeID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		if cancelErr := s.sessionManager.CancelSession(ctx, reshareSession.SessionID); cancelErr != nil {
+			log.Warn().Err(cancelErr).Str("session_id", reshareSession.SessionID).Msg("RotateKey: failed to cancel resharing session")
+		}
+		return nil, firstErr
+	}
+
+	// 4. 更新密钥元数据：新委员会、新阈值、新 share epoch
+	keyMeta.Threshold = newThreshold
+	keyMeta.TotalNodes = len(newNodeIDs)
+	keyMeta.NodeIDs = newNodeIDs
+	keyMeta.ShareEpoch++
+	keyMeta.UpdatedAt = time.Now()
+	if err := s.metadataStore.UpdateKeyMetadata(ctx, keyMeta); err != nil {
+		return nil, errors.Wrap(err, "failed to update key metadata after resharing")
+	}
+
+	// 旧分片生成的预签名随之失效（ClaimPresignature 也会按 share epoch 过滤）
+	if invalidated, err := s.metadataStore.InvalidatePresignatures(ctx, req.KeyID); err != nil {
+		log.Warn().Err(err).Str("key_id", req.KeyID).Msg("RotateKey: failed to invalidate presignatures")
+	} else if invalidated > 0 {
+		log.Info().Str("key_id", req.KeyID).Int("invalidated", invalidated).Msg("RotateKey: presignatures invalidated")
+	}
+
+	if err := s.sessionManager.CompleteSession(ctx, reshareSession.SessionID, ""); err != nil {
+		log.Warn().Err(err).Str("session_id", reshareSession.SessionID).Msg("RotateKey: failed to complete resharing session")
+	}
+
+	log.Info().
+		Str("key_id", req.KeyID).
+		Str("session_id", reshareSession.SessionID).
+		Int("share_epoch", keyMeta.ShareEpoch).
+		Msg("RotateKey: resharing completed")
+
+	return keyMeta, nil
+}
+
+// inferProtocolForKey 根据密钥算法推断 resharing 使用的协议
+func inferProtocolForKey(algorithm string) string {
+	switch strings.ToLower(algorithm) {
+	case "eddsa", "schnorr":
+		return "frost"
+	default:
+		return "gg20"
+	}
 }
 
-// RotateKey 密钥轮换
-func (s *DKGService) RotateKey(ctx context.Context, keyID string) error {
-	// TODO: 实现密钥轮换协议
-	// 1. 获取当前密钥信息
-	// 2. 执行密钥轮换DKG
-	// 3. 生成新的密钥分片
-	// 4. 更新密钥元数据
+// minCommitteeSize 委员会的最少节点数
+// FROST 和 CGGMP21 的 Threshold 为最少签名者数量，委员会可以只有 Threshold 个节点；
+// GG18/GG20（tss-lib）的 Threshold 为 t，需要 t+1 个节点才能签名
+func minCommitteeSize(protocolName string, threshold int) int {
+	switch strings.ToLower(protocolName) {
+	case "frost", "cggmp21":
+		return threshold
+	default:
+		return threshold + 1
+	}
+}
 
-	return errors.New("key rotation not yet implemented")
+// dedupNodeIDs 去除重复节点，保持原有顺序
+func dedupNodeIDs(nodeIDs []string) []string {
+	seen := make(map[string]struct{}, len(nodeIDs))
+	result := make([]string, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if nodeID == "" {
+			continue
+		}
+		if _, ok := seen[nodeID]; ok {
+			continue
+		}
+		seen[nodeID] = struct{}{}
+		result = append(result, nodeID)
+	}
+	return result
 }
@@ -0,0 +1,41 @@
+package key
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateKeyCommitteeSize(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+
+	// FROST 和 CGGMP21 的 Threshold 为最少签名者数量，3-of-3 密钥可以在同一委员会中重分享
+	for _, protocolName := range []string{"frost", "cggmp21"} {
+		k := refreshTestKey("key-"+protocolName, now)
+		k.Protocol = protocolName
+		k.Threshold = 3
+		scheduler, store, _, client, _ := newRefreshScheduler(t, k)
+
+		rotated, err := scheduler.keyService.dkgService.RotateKey(ctx, &RotateKeyRequest{KeyID: k.KeyID, NewNodeIDs: k.NodeIDs})
+		require.NoError(t, err, protocolName)
+		assert.Equal(t, 1, rotated.ShareEpoch)
+		assert.Equal(t, 1, store.keys[k.KeyID].ShareEpoch)
+		assert.Equal(t, 3, client.calls())
+
+		_, err = scheduler.keyService.dkgService.RotateKey(ctx, &RotateKeyRequest{KeyID: k.KeyID, NewNodeIDs: []string{"node-1", "node-2"}})
+		assert.ErrorContains(t, err, "new committee needs at least 3 nodes, got 2")
+	}
+
+	// GG18/GG20 的 Threshold 为 t，需要 t+1 个节点
+	k := refreshTestKey("key-gg20", now)
+	k.Protocol = "gg20"
+	k.Threshold = 3
+	scheduler, _, _, client, _ := newRefreshScheduler(t, k)
+	_, err := scheduler.keyService.dkgService.RotateKey(ctx, &RotateKeyRequest{KeyID: k.KeyID, NewNodeIDs: k.NodeIDs})
+	assert.ErrorContains(t, err, "new committee needs at least 4 nodes, got 3")
+	assert.Equal(t, 0, client.calls())
+}
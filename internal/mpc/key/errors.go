@@ -0,0 +1,17 @@
+package key
+
+import (
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrKeyNotFound 密钥不存在
+	ErrKeyNotFound = storage.ErrKeyNotFound
+	// ErrInvalidKeyState 密钥当前状态不允许该状态变更（或状态已被并发修改）
+	ErrInvalidKeyState = errors.New("key state does not allow this operation")
+	// ErrKeyNotEnabled 密钥未启用，不能用于签名等密钥操作
+	ErrKeyNotEnabled = errors.New("key is not enabled")
+	// ErrInvalidPendingWindow 删除等待期不在允许范围内
+	ErrInvalidPendingWindow = errors.Errorf("pending window must be between %d and %d days", MinPendingWindowDays, MaxPendingWindowDays)
+)
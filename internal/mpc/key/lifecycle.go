@@ -0,0 +1,266 @@
+package key
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	pb "github.com/kashguard/go-mpc-wallet/internal/pb/mpc/v1"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// 删除等待期（天）
+const (
+	MinPendingWindowDays     = 7
+	MaxPendingWindowDays     = 30
+	DefaultPendingWindowDays = 30
+)
+
+// keyTransitions 允许的密钥状态变更
+// 取消删除后密钥回到 Disabled，需要显式启用后才能再次签名；开始销毁分片（Destroying）后不能再取消
+var keyTransitions = map[string][]string{
+	storage.KeyStatusPending:         {storage.KeyStatusEnabled, storage.KeyStatusDeleted},
+	storage.KeyStatusEnabled:         {storage.KeyStatusDisabled, storage.KeyStatusPendingDeletion},
+	storage.KeyStatusDisabled:        {storage.KeyStatusEnabled, storage.KeyStatusPendingDeletion},
+	storage.KeyStatusPendingDeletion: {storage.KeyStatusDisabled, storage.KeyStatusDestroying},
+	storage.KeyStatusDestroying:      {storage.KeyStatusDeleted},
+}
+
+// CanTransition 判断密钥能否从 from 状态变更为 to 状态
+func CanTransition(from, to string) bool {
+	for _, status := range keyTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// hasKeyShares 判断该状态的密钥在节点上是否持有分片（DKG 已完成且尚未销毁）
+func hasKeyShares(status string) bool {
+	switch status {
+	case storage.KeyStatusEnabled, storage.KeyStatusDisabled, storage.KeyStatusPendingDeletion:
+		return true
+	default:
+		return false
+	}
+}
+
+// DisableKey 禁用密钥，禁用的密钥不能签名，分片保留
+func (s *Service) DisableKey(ctx context.Context, keyID string) (*KeyMetadata, error) {
+	keyMetadata, err := s.transitionKey(ctx, keyID, storage.KeyStatusDisabled, nil)
+	s.recordLifecycle(ctx, audit.OperationDisableKey, keyID, keyMetadata, err)
+	return keyMetadata, err
+}
+
+// EnableKey 重新启用被禁用的密钥
+func (s *Service) EnableKey(ctx context.Context, keyID string) (*KeyMetadata, error) {
+	keyMetadata, err := s.transitionKey(ctx, keyID, storage.KeyStatusEnabled, nil)
+	s.recordLifecycle(ctx, audit.OperationEnableKey, keyID, keyMetadata, err)
+	return keyMetadata, err
+}
+
+// ScheduleKeyDeletion 安排在 pendingWindowDays 天后删除密钥（0 表示默认等待期），
+// 等待期内密钥不能签名，可以取消删除；等待期结束后由 DeletionReaper 销毁所有节点的分片
+func (s *Service) ScheduleKeyDeletion(ctx context.Context, keyID string, pendingWindowDays int) (*KeyMetadata, error) {
+	keyMetadata, err := s.scheduleKeyDeletion(ctx, keyID, pendingWindowDays)
+	s.recordLifecycle(ctx, audit.OperationScheduleKeyDeletion, keyID, keyMetadata, err)
+	return keyMetadata, err
+}
+
+func (s *Service) scheduleKeyDeletion(ctx context.Context, keyID string, pendingWindowDays int) (*KeyMetadata, error) {
+	if pendingWindowDays == 0 {
+		pendingWindowDays = DefaultPendingWindowDays
+	}
+	if pendingWindowDays < MinPendingWindowDays || pendingWindowDays > MaxPendingWindowDays {
+		return nil, ErrInvalidPendingWindow
+	}
+
+	deletionDate := s.now().AddDate(0, 0, pendingWindowDays)
+	return s.transitionKey(ctx, keyID, storage.KeyStatusPendingDeletion, &deletionDate)
+}
+
+// CancelKeyDeletion 取消计划中的删除，密钥变为 Disabled
+func (s *Service) CancelKeyDeletion(ctx context.Context, keyID string) (*KeyMetadata, error) {
+	keyMetadata, err := s.cancelKeyDeletion(ctx, keyID)
+	s.recordLifecycle(ctx, audit.OperationCancelKeyDeletion, keyID, keyMetadata, err)
+	return keyMetadata, err
+}
+
+func (s *Service) cancelKeyDeletion(ctx context.Context, keyID string) (*KeyMetadata, error) {
+	storageKey, err := s.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	// PendingDeletion -> Disabled 只用于取消删除，DisableKey 不能用于取消
+	if storageKey.Status != storage.KeyStatusPendingDeletion {
+		return nil, errors.Wrapf(ErrInvalidKeyState, "key %s is not pending deletion (status: %s)", keyID, storageKey.Status)
+	}
+	return s.transitionKey(ctx, keyID, storage.KeyStatusDisabled, nil)
+}
+
+// DestroyKey 销毁删除日期已到的 PendingDeletion 密钥：先将密钥变为 Destroying（不能再取消删除），
+// 再销毁委员会所有节点的分片、作废预签名，全部节点确认销毁后密钥变为 Deleted；
+// 任一节点失败时密钥保持 Destroying，下次重试
+func (s *Service) DestroyKey(ctx context.Context, keyID string) (*KeyMetadata, error) {
+	keyMetadata, nodeIDs, err := s.destroyKey(ctx, keyID)
+	event := &audit.Event{
+		EventType: audit.EventTypeKey,
+		Operation: audit.OperationDestroyKey,
+		Result:    audit.ResultOf(err),
+		KeyID:     keyID,
+		Details:   map[string]interface{}{"node_ids": nodeIDs},
+	}
+	if err != nil {
+		event.Details["error"] = err.Error()
+	}
+	s.auditLogger.Record(ctx, event)
+	return keyMetadata, err
+}
+
+func (s *Service) destroyKey(ctx context.Context, keyID string) (*KeyMetadata, []string, error) {
+	storageKey, err := s.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	if storageKey.Status != storage.KeyStatusPendingDeletion && storageKey.Status != storage.KeyStatusDestroying {
+		return nil, nil, errors.Wrapf(ErrInvalidKeyState, "key %s is not pending deletion (status: %s)", keyID, storageKey.Status)
+	}
+	now := s.now()
+	if storageKey.DeletionDate == nil || storageKey.DeletionDate.After(now) {
+		return nil, nil, errors.Wrapf(ErrInvalidKeyState, "deletion of key %s is not due yet", keyID)
+	}
+	if s.dkgService == nil {
+		return nil, nil, errors.New("key destruction requires dkg service")
+	}
+
+	// 先以比较交换的方式进入 Destroying，与并发的取消删除互斥：取消成功时这里失败，不销毁任何分片；
+	// Destroying 的密钥是上次销毁未完成，直接重试
+	if storageKey.Status == storage.KeyStatusPendingDeletion {
+		updated, err := s.metadataStore.TransitionKeyStatus(ctx, keyID, storage.KeyStatusPendingDeletion, storage.KeyStatusDestroying, storageKey.DeletionDate, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !updated {
+			return nil, nil, errors.Wrapf(ErrInvalidKeyState, "key %s was modified concurrently", keyID)
+		}
+		storageKey.Status = storage.KeyStatusDestroying
+		log.Info().
+			Str("key_id", keyID).
+			Msg("Key destruction started")
+	}
+
+	nodeIDs, err := s.dkgService.DestroyKeyShares(ctx, storageKey)
+	if err != nil {
+		return nil, nodeIDs, err
+	}
+	if _, err := s.metadataStore.InvalidatePresignatures(ctx, keyID); err != nil {
+		return nil, nodeIDs, errors.Wrap(err, "failed to invalidate presignatures")
+	}
+
+	keyMetadata, err := s.transitionKey(ctx, keyID, storage.KeyStatusDeleted, &now)
+	return keyMetadata, nodeIDs, err
+}
+
+// transitionKey 检查状态变更是否允许并以比较交换的方式更新状态和删除日期
+func (s *Service) transitionKey(ctx context.Context, keyID string, toStatus string, deletionDate *time.Time) (*KeyMetadata, error) {
+	storageKey, err := s.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	if !CanTransition(storageKey.Status, toStatus) {
+		return nil, errors.Wrapf(ErrInvalidKeyState, "cannot change key %s from %s to %s", keyID, storageKey.Status, toStatus)
+	}
+
+	updated, err := s.metadataStore.TransitionKeyStatus(ctx, keyID, storageKey.Status, toStatus, deletionDate, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.Wrapf(ErrInvalidKeyState, "key %s was modified concurrently", keyID)
+	}
+
+	log.Info().
+		Str("key_id", keyID).
+		Str("from_status", storageKey.Status).
+		Str("to_status", toStatus).
+		Msg("Key status changed")
+
+	return s.GetKey(ctx, keyID)
+}
+
+// recordLifecycle 记录密钥状态变更的审计日志
+func (s *Service) recordLifecycle(ctx context.Context, operation string, keyID string, keyMetadata *KeyMetadata, err error) {
+	event := &audit.Event{
+		EventType: audit.EventTypeKey,
+		Operation: operation,
+		Result:    audit.ResultOf(err),
+		KeyID:     keyID,
+		Details:   map[string]interface{}{},
+	}
+	if keyMetadata != nil {
+		event.Details["status"] = keyMetadata.Status
+		if keyMetadata.DeletionDate != nil {
+			event.Details["deletion_date"] = keyMetadata.DeletionDate.UTC()
+		}
+	}
+	if err != nil {
+		event.Details["error"] = err.Error()
+	}
+	s.auditLogger.Record(ctx, event)
+}
+
+// DestroyKeyShares 通知密钥委员会的所有节点销毁分片，返回委员会节点；任一节点失败时返回错误
+func (s *DKGService) DestroyKeyShares(ctx context.Context, keyMeta *storage.KeyMetadata) ([]string, error) {
+	if s.grpcClient == nil {
+		return nil, errors.New("key share destruction requires grpc client")
+	}
+	nodeIDs, err := s.committeeNodeIDs(ctx, keyMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	req := &pb.DestroyKeyShareRequest{KeyId: keyMeta.KeyID}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed []string
+	for _, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+			resp, err := s.grpcClient.SendDestroyKeyShare(rpcCtx, nodeID, req)
+			if err == nil && !resp.Success {
+				err = errors.New(resp.Message)
+			}
+			if err != nil {
+				log.Warn().
+					Err(err).
+					Str("key_id", keyMeta.KeyID).
+					Str("node_id", nodeID).
+					Msg("DestroyKeyShares: failed to destroy key share")
+				mu.Lock()
+				failed = append(failed, nodeID)
+				mu.Unlock()
+			}
+		}(nodeID)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return nodeIDs, errors.Errorf("failed to destroy key shares on nodes %v", failed)
+	}
+
+	log.Info().
+		Str("key_id", keyMeta.KeyID).
+		Strs("node_ids", nodeIDs).
+		Msg("DestroyKeyShares: key shares destroyed on all nodes")
+
+	return nodeIDs, nil
+}
@@ -0,0 +1,298 @@
+package key
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	pb "github.com/kashguard/go-mpc-wallet/internal/pb/mpc/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore 只实现密钥生命周期相关方法的内存存储
+type memoryStore struct {
+	storage.MetadataStore
+	keys        map[string]*storage.KeyMetadata
+	invalidated map[string]bool
+	// nextDeletionAttempt 销毁失败后的下一次重试时间
+	nextDeletionAttempt map[string]time.Time
+}
+
+func newMemoryStore(keys ...*storage.KeyMetadata) *memoryStore {
+	m := &memoryStore{
+		keys:                map[string]*storage.KeyMetadata{},
+		invalidated:         map[string]bool{},
+		nextDeletionAttempt: map[string]time.Time{},
+	}
+	for _, k := range keys {
+		m.keys[k.KeyID] = k
+	}
+	return m
+}
+
+func (m *memoryStore) GetKeyMetadata(_ context.Context, keyID string) (*storage.KeyMetadata, error) {
+	stored, ok := m.keys[keyID]
+	if !ok {
+		return nil, storage.ErrKeyNotFound
+	}
+	k := *stored
+	return &k, nil
+}
+
+func (m *memoryStore) TransitionKeyStatus(_ context.Context, keyID string, fromStatus string, toStatus string, deletionDate *time.Time, updatedAt time.Time) (bool, error) {
+	stored, ok := m.keys[keyID]
+	if !ok || stored.Status != fromStatus {
+		return false, nil
+	}
+	stored.Status = toStatus
+	stored.DeletionDate = deletionDate
+	stored.UpdatedAt = updatedAt
+	return true, nil
+}
+
+func (m *memoryStore) ListKeysDueForDeletion(_ context.Context, before time.Time, limit int) ([]*storage.KeyMetadata, error) {
+	var due []*storage.KeyMetadata
+	for _, k := range m.keys {
+		deleting := k.Status == storage.KeyStatusPendingDeletion || k.Status == storage.KeyStatusDestroying
+		backingOff := m.nextDeletionAttempt[k.KeyID].After(before)
+		if deleting && !backingOff && k.DeletionDate != nil && !k.DeletionDate.After(before) {
+			due = append(due, k)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].DeletionDate.Before(*due[j].DeletionDate) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (m *memoryStore) RecordKeyDeletionFailure(_ context.Context, keyID string, nextAttemptAt time.Time) error {
+	m.keys[keyID].DeletionAttempts++
+	m.nextDeletionAttempt[keyID] = nextAttemptAt
+	return nil
+}
+
+func (m *memoryStore) InvalidatePresignatures(_ context.Context, keyID string) (int, error) {
+	m.invalidated[keyID] = true
+	return 0, nil
+}
+
+// fakeGRPCClient 记录分片销毁请求，failing 中的节点返回错误
+type fakeGRPCClient struct {
+	GRPCClient
+	mu        sync.Mutex
+	failing   map[string]bool
+	destroyed map[string][]string
+}
+
+func newFakeGRPCClient() *fakeGRPCClient {
+	return &fakeGRPCClient{
+		failing:   map[string]bool{},
+		destroyed: map[string][]string{},
+	}
+}
+
+func (f *fakeGRPCClient) SendDestroyKeyShare(_ context.Context, nodeID string, req *pb.DestroyKeyShareRequest) (*pb.DestroyKeyShareResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failing[nodeID] {
+		return nil, errors.New("node unavailable")
+	}
+	f.destroyed[req.KeyId] = append(f.destroyed[req.KeyId], nodeID)
+	return &pb.DestroyKeyShareResponse{Success: true}, nil
+}
+
+func newLifecycleService(t *testing.T, keys ...*storage.KeyMetadata) (*Service, *memoryStore, *fakeGRPCClient, *time.Time) {
+	t.Helper()
+
+	store := newMemoryStore(keys...)
+	client := newFakeGRPCClient()
+	service := NewService(store, nil, nil, NewDKGService(store, nil, nil, nil, nil, nil, client), nil, nil)
+
+	now := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	return service, store, client, &now
+}
+
+func testKey(keyID string, status string) *storage.KeyMetadata {
+	return &storage.KeyMetadata{
+		KeyID:   keyID,
+		Status:  status,
+		NodeIDs: []string{"node-1", "node-2", "node-3"},
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	assert.True(t, CanTransition(storage.KeyStatusPending, storage.KeyStatusEnabled))
+	assert.True(t, CanTransition(storage.KeyStatusEnabled, storage.KeyStatusDisabled))
+	assert.True(t, CanTransition(storage.KeyStatusDisabled, storage.KeyStatusPendingDeletion))
+	assert.True(t, CanTransition(storage.KeyStatusPendingDeletion, storage.KeyStatusDestroying))
+	assert.True(t, CanTransition(storage.KeyStatusDestroying, storage.KeyStatusDeleted))
+
+	assert.False(t, CanTransition(storage.KeyStatusEnabled, storage.KeyStatusDeleted))
+	assert.False(t, CanTransition(storage.KeyStatusPendingDeletion, storage.KeyStatusEnabled))
+	assert.False(t, CanTransition(storage.KeyStatusPendingDeletion, storage.KeyStatusDeleted))
+	assert.False(t, CanTransition(storage.KeyStatusDestroying, storage.KeyStatusDisabled))
+	assert.False(t, CanTransition(storage.KeyStatusDeleted, storage.KeyStatusEnabled))
+	assert.False(t, CanTransition(storage.KeyStatusPending, storage.KeyStatusDisabled))
+}
+
+func TestDisableAndEnableKey(t *testing.T) {
+	ctx := context.Background()
+	service, _, _, _ := newLifecycleService(t, testKey("key-1", storage.KeyStatusEnabled))
+
+	keyMetadata, err := service.DisableKey(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, storage.KeyStatusDisabled, keyMetadata.Status)
+	assert.False(t, keyMetadata.Enabled())
+
+	_, err = service.DisableKey(ctx, "key-1")
+	assert.True(t, errors.Is(err, ErrInvalidKeyState))
+
+	keyMetadata, err = service.EnableKey(ctx, "key-1")
+	require.NoError(t, err)
+	assert.True(t, keyMetadata.Enabled())
+
+	_, err = service.EnableKey(ctx, "missing")
+	assert.True(t, errors.Is(err, ErrKeyNotFound))
+}
+
+func TestScheduleKeyDeletion(t *testing.T) {
+	ctx := context.Background()
+	service, _, _, now := newLifecycleService(t,
+		testKey("key-1", storage.KeyStatusEnabled),
+		testKey("key-2", storage.KeyStatusDisabled),
+		testKey("key-3", storage.KeyStatusPending),
+	)
+
+	_, err := service.ScheduleKeyDeletion(ctx, "key-1", 6)
+	assert.True(t, errors.Is(err, ErrInvalidPendingWindow))
+	_, err = service.ScheduleKeyDeletion(ctx, "key-1", 31)
+	assert.True(t, errors.Is(err, ErrInvalidPendingWindow))
+
+	keyMetadata, err := service.ScheduleKeyDeletion(ctx, "key-1", 0)
+	require.NoError(t, err)
+	assert.Equal(t, storage.KeyStatusPendingDeletion, keyMetadata.Status)
+	require.NotNil(t, keyMetadata.DeletionDate)
+	assert.Equal(t, now.AddDate(0, 0, DefaultPendingWindowDays), *keyMetadata.DeletionDate)
+
+	keyMetadata, err = service.ScheduleKeyDeletion(ctx, "key-2", 7)
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, 7), *keyMetadata.DeletionDate)
+
+	// DKG 未完成的密钥没有分片，不能计划删除
+	_, err = service.ScheduleKeyDeletion(ctx, "key-3", 7)
+	assert.True(t, errors.Is(err, ErrInvalidKeyState))
+}
+
+func TestCancelKeyDeletion(t *testing.T) {
+	ctx := context.Background()
+	service, _, _, _ := newLifecycleService(t, testKey("key-1", storage.KeyStatusEnabled))
+
+	_, err := service.CancelKeyDeletion(ctx, "key-1")
+	assert.True(t, errors.Is(err, ErrInvalidKeyState))
+
+	_, err = service.ScheduleKeyDeletion(ctx, "key-1", 7)
+	require.NoError(t, err)
+
+	keyMetadata, err := service.CancelKeyDeletion(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, storage.KeyStatusDisabled, keyMetadata.Status)
+	assert.Nil(t, keyMetadata.DeletionDate)
+}
+
+func TestDestroyKey(t *testing.T) {
+	ctx := context.Background()
+	service, store, client, now := newLifecycleService(t, testKey("key-1", storage.KeyStatusEnabled))
+
+	_, err := service.ScheduleKeyDeletion(ctx, "key-1", 7)
+	require.NoError(t, err)
+
+	// 等待期未结束
+	_, err = service.DestroyKey(ctx, "key-1")
+	assert.True(t, errors.Is(err, ErrInvalidKeyState))
+	assert.Empty(t, client.destroyed["key-1"])
+
+	*now = now.AddDate(0, 0, 7)
+
+	// 开始销毁后密钥变为 Destroying，任一节点失败时保持 Destroying 且不能再取消删除
+	client.failing["node-2"] = true
+	_, err = service.DestroyKey(ctx, "key-1")
+	require.Error(t, err)
+	assert.Equal(t, storage.KeyStatusDestroying, store.keys["key-1"].Status)
+	assert.False(t, store.invalidated["key-1"])
+	_, err = service.CancelKeyDeletion(ctx, "key-1")
+	assert.True(t, errors.Is(err, ErrInvalidKeyState))
+
+	client.failing["node-2"] = false
+	client.destroyed = map[string][]string{}
+	keyMetadata, err := service.DestroyKey(ctx, "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, storage.KeyStatusDeleted, keyMetadata.Status)
+	assert.Equal(t, *now, *keyMetadata.DeletionDate)
+	assert.ElementsMatch(t, []string{"node-1", "node-2", "node-3"}, client.destroyed["key-1"])
+	assert.True(t, store.invalidated["key-1"])
+}
+
+func TestDeletionReaperReap(t *testing.T) {
+	ctx := context.Background()
+	service, store, client, now := newLifecycleService(t,
+		testKey("key-1", storage.KeyStatusEnabled),
+		testKey("key-2", storage.KeyStatusEnabled),
+		testKey("key-3", storage.KeyStatusEnabled),
+	)
+	sessionStore := newMemorySessionStore()
+	reaper := NewDeletionReaper(service, sessionStore, time.Minute)
+
+	_, err := service.ScheduleKeyDeletion(ctx, "key-1", 7)
+	require.NoError(t, err)
+	_, err = service.ScheduleKeyDeletion(ctx, "key-2", 30)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, reaper.Reap(ctx))
+
+	*now = now.AddDate(0, 0, 7)
+	assert.Equal(t, 1, reaper.Reap(ctx))
+	assert.Equal(t, storage.KeyStatusDeleted, store.keys["key-1"].Status)
+	assert.Equal(t, storage.KeyStatusPendingDeletion, store.keys["key-2"].Status)
+	assert.Equal(t, storage.KeyStatusEnabled, store.keys["key-3"].Status)
+
+	// 失败的密钥退避后重试，连续失败时退避时间翻倍
+	*now = now.AddDate(0, 0, 30)
+	client.failing["node-3"] = true
+	assert.Equal(t, 0, reaper.Reap(ctx))
+	assert.Equal(t, storage.KeyStatusDestroying, store.keys["key-2"].Status)
+	assert.Equal(t, 1, store.keys["key-2"].DeletionAttempts)
+	assert.Equal(t, now.Add(5*time.Minute), store.nextDeletionAttempt["key-2"])
+
+	client.destroyed = map[string][]string{}
+	assert.Equal(t, 0, reaper.Reap(ctx))
+	assert.Empty(t, client.destroyed["key-2"], "backing off")
+
+	*now = now.Add(5 * time.Minute)
+	assert.Equal(t, 0, reaper.Reap(ctx))
+	assert.Equal(t, 2, store.keys["key-2"].DeletionAttempts)
+	assert.Equal(t, now.Add(10*time.Minute), store.nextDeletionAttempt["key-2"])
+
+	// 其他协调者持有锁时跳过
+	*now = now.Add(10 * time.Minute)
+	client.failing["node-3"] = false
+	acquired, err := sessionStore.AcquireLock(ctx, reapLockKey, "other-coordinator", reapLockTTL)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	assert.Equal(t, 0, reaper.Reap(ctx))
+	assert.Equal(t, storage.KeyStatusDestroying, store.keys["key-2"].Status)
+	require.NoError(t, sessionStore.ReleaseLock(ctx, reapLockKey, "other-coordinator"))
+
+	assert.Equal(t, 1, reaper.Reap(ctx))
+	assert.Equal(t, storage.KeyStatusDeleted, store.keys["key-2"].Status)
+	assert.Empty(t, sessionStore.lockHolder(reapLockKey))
+
+	assert.False(t, NewDeletionReaper(service, sessionStore, 0).Enabled())
+	assert.Equal(t, 24*time.Hour, deletionBackoff(20))
+}
@@ -0,0 +1,181 @@
+package key
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// reapBatchSize 每次检查最多处理的到期密钥数量
+	reapBatchSize = 50
+	// reapLockKey 删除任务的分布式锁，多个协调者中同一时间只有一个销毁密钥
+	reapLockKey = "key-deletion-reaper"
+	// reapLockTTL 锁的有效期，覆盖单个密钥的分片销毁（DestroyKeyShare RPC 超时 1 分钟）
+	reapLockTTL = 5 * time.Minute
+	// reapLockRenewInterval 销毁期间续期锁的间隔
+	reapLockRenewInterval = reapLockTTL / 4
+	// 销毁失败后的重试退避：首次 5 分钟，每次连续失败翻倍，最长 24 小时
+	reapRetryBackoff    = 5 * time.Minute
+	reapMaxRetryBackoff = 24 * time.Hour
+)
+
+// DeletionReaper 定期销毁删除日期已到的 PendingDeletion 密钥（协调者）
+// 销毁失败的密钥保持 Destroying，退避后重试；多个协调者通过分布式锁互斥
+type DeletionReaper struct {
+	keyService   *Service
+	sessionStore storage.SessionStore
+	interval     time.Duration
+	// lockRenewInterval 销毁期间续期锁的间隔
+	lockRenewInterval time.Duration
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewDeletionReaper 创建密钥删除任务，interval 为 0 时关闭
+func NewDeletionReaper(keyService *Service, sessionStore storage.SessionStore, interval time.Duration) *DeletionReaper {
+	return &DeletionReaper{
+		keyService:        keyService,
+		sessionStore:      sessionStore,
+		interval:          interval,
+		lockRenewInterval: reapLockRenewInterval,
+		stopCh:            make(chan struct{}),
+	}
+}
+
+// Enabled 密钥删除任务是否开启
+func (r *DeletionReaper) Enabled() bool {
+	return r != nil && r.interval > 0
+}
+
+// Run 在后台定期销毁到期的密钥，直到 ctx 取消或调用 Stop
+func (r *DeletionReaper) Run(ctx context.Context) {
+	if !r.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.Reap(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop 停止后台任务
+func (r *DeletionReaper) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+}
+
+// Reap 持有分布式锁时销毁一批到期的密钥，返回成功销毁的数量；其他协调者持有锁时跳过本次检查
+func (r *DeletionReaper) Reap(ctx context.Context) int {
+	token := uuid.New().String()
+	acquired, err := r.sessionStore.AcquireLock(ctx, reapLockKey, token, reapLockTTL)
+	if err != nil {
+		log.Error().Err(err).Msg("DeletionReaper: failed to acquire lock")
+		return 0
+	}
+	if !acquired {
+		log.Debug().Msg("DeletionReaper: another coordinator is destroying keys")
+		return 0
+	}
+	defer func() {
+		if err := r.sessionStore.ReleaseLock(context.Background(), reapLockKey, token); err != nil {
+			log.Warn().Err(err).Msg("DeletionReaper: failed to release lock")
+		}
+	}()
+
+	renewCtx, stopRenew := context.WithCancel(ctx)
+	defer stopRenew()
+	lost := make(chan struct{})
+	go r.renewLock(renewCtx, token, lost)
+
+	keys, err := r.keyService.metadataStore.ListKeysDueForDeletion(ctx, r.keyService.now(), reapBatchSize)
+	if err != nil {
+		log.Error().Err(err).Msg("DeletionReaper: failed to list keys due for deletion")
+		return 0
+	}
+
+	destroyed := 0
+	for _, keyMeta := range keys {
+		// 锁丢失后其他协调者可能开始销毁，不再处理剩余的密钥
+		select {
+		case <-lost:
+			log.Error().Msg("DeletionReaper: lock lost, stopping")
+			return destroyed
+		default:
+		}
+
+		if _, err := r.keyService.DestroyKey(ctx, keyMeta.KeyID); err != nil {
+			attempts := keyMeta.DeletionAttempts + 1
+			retryAt := r.keyService.now().Add(deletionBackoff(attempts))
+			log.Error().
+				Err(err).
+				Str("key_id", keyMeta.KeyID).
+				Int("attempts", attempts).
+				Time("retry_at", retryAt).
+				Msg("DeletionReaper: failed to destroy key, will retry with backoff")
+			if err := r.keyService.metadataStore.RecordKeyDeletionFailure(ctx, keyMeta.KeyID, retryAt); err != nil {
+				log.Error().Err(err).Str("key_id", keyMeta.KeyID).Msg("DeletionReaper: failed to record deletion failure")
+			}
+			continue
+		}
+		destroyed++
+		log.Info().
+			Str("key_id", keyMeta.KeyID).
+			Msg("DeletionReaper: key destroyed")
+	}
+
+	return destroyed
+}
+
+// renewLock 定期续期删除锁直到 ctx 结束，锁丢失时关闭 lost
+func (r *DeletionReaper) renewLock(ctx context.Context, token string, lost chan<- struct{}) {
+	ticker := time.NewTicker(r.lockRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		renewed, err := r.sessionStore.RenewLock(ctx, reapLockKey, token, reapLockTTL)
+		if err != nil {
+			log.Warn().Err(err).Msg("DeletionReaper: failed to renew lock")
+			continue
+		}
+		if !renewed {
+			close(lost)
+			return
+		}
+	}
+}
+
+// deletionBackoff 连续失败 attempts 次后的重试等待时间
+func deletionBackoff(attempts int) time.Duration {
+	backoff := reapRetryBackoff
+	for i := 1; i < attempts && backoff < reapMaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > reapMaxRetryBackoff {
+		backoff = reapMaxRetryBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,120 @@
+package key
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// refreshHistoryLimit 查询分片刷新历史时返回的最大记录数
+const refreshHistoryLimit = 50
+
+// RefreshKey 主动刷新密钥分片：当前委员会以相同阈值重新分享，公钥和地址不变，
+// share epoch 加一，旧分片及其预签名作废。成功和失败都会记录到刷新历史
+func (s *Service) RefreshKey(ctx context.Context, keyID string) (*KeyRefresh, error) {
+	refresh, err := s.refreshKey(ctx, keyID)
+	event := &audit.Event{
+		EventType: audit.EventTypeKey,
+		Operation: audit.OperationRefreshKey,
+		Result:    audit.ResultOf(err),
+		KeyID:     keyID,
+		Details:   map[string]interface{}{},
+	}
+	if refresh != nil {
+		event.Details["refresh_id"] = refresh.RefreshID
+		event.Details["old_epoch"] = refresh.OldEpoch
+		event.Details["new_epoch"] = refresh.NewEpoch
+	}
+	if err != nil {
+		event.Details["error"] = err.Error()
+	}
+	s.auditLogger.Record(ctx, event)
+	return refresh, err
+}
+
+func (s *Service) refreshKey(ctx context.Context, keyID string) (*KeyRefresh, error) {
+	if s.dkgService == nil {
+		return nil, errors.New("key refresh requires dkg service")
+	}
+
+	keyMeta, err := s.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	if keyMeta.Status != storage.KeyStatusEnabled {
+		return nil, errors.Wrapf(ErrKeyNotEnabled, "key %s is %s", keyID, keyMeta.Status)
+	}
+
+	refresh := &storage.KeyRefresh{
+		RefreshID: "refresh-" + uuid.New().String(),
+		KeyID:     keyID,
+		OldEpoch:  keyMeta.ShareEpoch,
+		NewEpoch:  keyMeta.ShareEpoch,
+		StartedAt: s.now(),
+	}
+
+	refreshed, refreshErr := s.dkgService.RefreshKeyShares(ctx, keyMeta)
+	refresh.CompletedAt = s.now()
+	if refreshErr != nil {
+		refresh.Status = storage.KeyRefreshStatusFailed
+		refresh.Error = refreshErr.Error()
+	} else {
+		refresh.Status = storage.KeyRefreshStatusCompleted
+		refresh.NewEpoch = refreshed.ShareEpoch
+	}
+
+	// 刷新历史写入失败不影响刷新结果，最多导致下一次检查时重复刷新
+	if err := s.metadataStore.SaveKeyRefresh(ctx, refresh); err != nil {
+		log.Error().
+			Err(err).
+			Str("key_id", keyID).
+			Str("refresh_id", refresh.RefreshID).
+			Msg("Failed to save key refresh")
+	}
+
+	return convertKeyRefresh(refresh), refreshErr
+}
+
+// ListKeyRefreshes 获取密钥最近的分片刷新历史（最新的在前）
+func (s *Service) ListKeyRefreshes(ctx context.Context, keyID string) ([]*KeyRefresh, error) {
+	refreshes, err := s.metadataStore.ListKeyRefreshes(ctx, keyID, refreshHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*KeyRefresh, 0, len(refreshes))
+	for _, refresh := range refreshes {
+		result = append(result, convertKeyRefresh(refresh))
+	}
+	return result, nil
+}
+
+func convertKeyRefresh(refresh *storage.KeyRefresh) *KeyRefresh {
+	return &KeyRefresh{
+		RefreshID:   refresh.RefreshID,
+		KeyID:       refresh.KeyID,
+		Status:      refresh.Status,
+		OldEpoch:    refresh.OldEpoch,
+		NewEpoch:    refresh.NewEpoch,
+		Error:       refresh.Error,
+		StartedAt:   refresh.StartedAt,
+		CompletedAt: refresh.CompletedAt,
+	}
+}
+
+// RefreshKeyShares 在密钥当前委员会上以相同阈值执行 resharing（主动分片刷新），返回更新后的密钥元数据
+func (s *DKGService) RefreshKeyShares(ctx context.Context, keyMeta *storage.KeyMetadata) (*storage.KeyMetadata, error) {
+	nodeIDs, err := s.committeeNodeIDs(ctx, keyMeta)
+	if err != nil {
+		return nil, err
+	}
+	return s.RotateKey(ctx, &RotateKeyRequest{
+		KeyID:        keyMeta.KeyID,
+		NewThreshold: keyMeta.Threshold,
+		NewNodeIDs:   nodeIDs,
+	})
+}
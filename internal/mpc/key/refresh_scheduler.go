@@ -0,0 +1,248 @@
+package key
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// refreshBatchSize 每次检查最多处理的到期密钥数量
+	refreshBatchSize = 50
+	// refreshLockKey 刷新任务的分布式锁，多个协调者中同一时间只有一个执行刷新
+	refreshLockKey = "key-refresh-scheduler"
+	// refreshLockTTL 锁的有效期，覆盖单个密钥的刷新（resharing RPC 超时 15 分钟）
+	refreshLockTTL = 20 * time.Minute
+	// refreshLockRenewInterval 刷新期间续期锁的间隔，刷新超过 TTL 时锁不会过期
+	refreshLockRenewInterval = refreshLockTTL / 4
+	// 刷新失败后的重试退避：首次 15 分钟，每次连续失败翻倍，最长 24 小时
+	refreshRetryBackoff    = 15 * time.Minute
+	refreshMaxRetryBackoff = 24 * time.Hour
+)
+
+// errRefreshLockHeld 其他协调者正在执行刷新
+var errRefreshLockHeld = errors.New("key refresh lock is held by another coordinator")
+
+// RefreshScheduler 定期刷新超过刷新周期未刷新的密钥分片（协调者）
+// 每个密钥在持有分布式锁时刷新（刷新期间定期续期），失败后按指数退避重试
+type RefreshScheduler struct {
+	keyService   *Service
+	sessionStore storage.SessionStore
+	period       time.Duration
+	interval     time.Duration
+	// lockRenewInterval 刷新期间续期锁的间隔
+	lockRenewInterval time.Duration
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewRefreshScheduler 创建密钥刷新任务，period 为刷新周期，interval 为检查间隔，任一为 0 时关闭
+func NewRefreshScheduler(keyService *Service, sessionStore storage.SessionStore, period time.Duration, interval time.Duration) *RefreshScheduler {
+	return &RefreshScheduler{
+		keyService:        keyService,
+		sessionStore:      sessionStore,
+		period:            period,
+		interval:          interval,
+		lockRenewInterval: refreshLockRenewInterval,
+		stopCh:            make(chan struct{}),
+	}
+}
+
+// Enabled 密钥刷新任务是否开启
+func (r *RefreshScheduler) Enabled() bool {
+	return r != nil && r.period > 0 && r.interval > 0
+}
+
+// Run 在后台定期刷新到期的密钥，直到 ctx 取消或调用 Stop
+func (r *RefreshScheduler) Run(ctx context.Context) {
+	if !r.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.RefreshDueKeys(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop 停止后台任务
+func (r *RefreshScheduler) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+}
+
+// RefreshDueKeys 刷新一批到期的密钥，返回成功刷新的数量
+func (r *RefreshScheduler) RefreshDueKeys(ctx context.Context) int {
+	now := r.keyService.now()
+	keys, err := r.keyService.metadataStore.ListKeysDueForRefresh(ctx, &storage.KeyRefreshDueFilter{
+		RefreshedBefore: now.Add(-r.period),
+		Now:             now,
+		RetryBackoff:    refreshRetryBackoff,
+		MaxRetryBackoff: refreshMaxRetryBackoff,
+		Limit:           refreshBatchSize,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("RefreshScheduler: failed to list keys due for refresh")
+		return 0
+	}
+
+	refreshed := 0
+	for _, keyMeta := range keys {
+		select {
+		case <-ctx.Done():
+			return refreshed
+		case <-r.stopCh:
+			return refreshed
+		default:
+		}
+
+		ok, err := r.refreshDueKey(ctx, keyMeta)
+		if errors.Is(err, errRefreshLockHeld) {
+			log.Debug().Msg("RefreshScheduler: another coordinator is refreshing keys")
+			return refreshed
+		}
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("key_id", keyMeta.KeyID).
+				Msg("RefreshScheduler: failed to refresh key, will retry with backoff")
+			continue
+		}
+		if ok {
+			refreshed++
+		}
+	}
+
+	return refreshed
+}
+
+// refreshDueKey 持有分布式锁时重新检查密钥的刷新历史并刷新，返回是否执行了刷新
+func (r *RefreshScheduler) refreshDueKey(ctx context.Context, keyMeta *storage.KeyMetadata) (bool, error) {
+	token := uuid.New().String()
+	acquired, err := r.sessionStore.AcquireLock(ctx, refreshLockKey, token, refreshLockTTL)
+	if err != nil {
+		return false, err
+	}
+	if !acquired {
+		return false, errRefreshLockHeld
+	}
+	defer func() {
+		if err := r.sessionStore.ReleaseLock(context.Background(), refreshLockKey, token); err != nil {
+			log.Warn().Err(err).Msg("RefreshScheduler: failed to release lock")
+		}
+	}()
+
+	renewCtx, stopRenew := context.WithCancel(ctx)
+	defer stopRenew()
+	go r.renewLock(renewCtx, token)
+
+	// 列表查询和加锁之间其他协调者可能已经刷新了该密钥
+	history, err := r.keyService.metadataStore.ListKeyRefreshes(ctx, keyMeta.KeyID, refreshHistoryLimit)
+	if err != nil {
+		return false, err
+	}
+	now := r.keyService.now()
+	if lastRefreshedAt(keyMeta, history).After(now.Add(-r.period)) {
+		return false, nil
+	}
+	if retryAt, failures := nextRetryAt(history); failures > 0 && now.Before(retryAt) {
+		log.Debug().
+			Str("key_id", keyMeta.KeyID).
+			Int("failures", failures).
+			Time("retry_at", retryAt).
+			Msg("RefreshScheduler: key refresh is backing off")
+		return false, nil
+	}
+
+	refresh, err := r.keyService.RefreshKey(ctx, keyMeta.KeyID)
+	if err != nil {
+		return false, err
+	}
+
+	log.Info().
+		Str("key_id", keyMeta.KeyID).
+		Str("refresh_id", refresh.RefreshID).
+		Int("share_epoch", refresh.NewEpoch).
+		Msg("RefreshScheduler: key shares refreshed")
+
+	return true, nil
+}
+
+// renewLock 定期续期刷新锁直到 ctx 结束。锁丢失时不中断刷新：节点上的 resharing 已在进行，
+// 中断会使密钥元数据与节点分片的轮次不一致
+func (r *RefreshScheduler) renewLock(ctx context.Context, token string) {
+	ticker := time.NewTicker(r.lockRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		renewed, err := r.sessionStore.RenewLock(ctx, refreshLockKey, token, refreshLockTTL)
+		if err != nil {
+			log.Warn().Err(err).Msg("RefreshScheduler: failed to renew lock")
+			continue
+		}
+		if !renewed {
+			log.Error().Msg("RefreshScheduler: lock lost during key refresh")
+			return
+		}
+	}
+}
+
+// lastRefreshedAt 密钥最近一次成功刷新的时间，从未刷新时为创建时间（history 按开始时间降序）
+func lastRefreshedAt(keyMeta *storage.KeyMetadata, history []*storage.KeyRefresh) time.Time {
+	for _, refresh := range history {
+		if refresh.Status == storage.KeyRefreshStatusCompleted {
+			return refresh.CompletedAt
+		}
+	}
+	return keyMeta.CreatedAt
+}
+
+// nextRetryAt 根据最近一次成功刷新之后的连续失败次数计算下一次允许重试的时间
+func nextRetryAt(history []*storage.KeyRefresh) (time.Time, int) {
+	failures := 0
+	for _, refresh := range history {
+		if refresh.Status != storage.KeyRefreshStatusFailed {
+			break
+		}
+		failures++
+	}
+	if failures == 0 {
+		return time.Time{}, 0
+	}
+	return history[0].CompletedAt.Add(refreshBackoff(failures)), failures
+}
+
+// refreshBackoff 连续失败 failures 次后的重试等待时间
+func refreshBackoff(failures int) time.Duration {
+	backoff := refreshRetryBackoff
+	for i := 1; i < failures && backoff < refreshMaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > refreshMaxRetryBackoff {
+		backoff = refreshMaxRetryBackoff
+	}
+	return backoff
+}
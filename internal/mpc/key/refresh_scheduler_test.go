@@ -0,0 +1,389 @@
+package key
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/session"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	pb "github.com/kashguard/go-mpc-wallet/internal/pb/mpc/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// refreshStore 在 memoryStore 基础上实现分片刷新和 resharing 会话相关方法
+type refreshStore struct {
+	*memoryStore
+	refreshes map[string][]*storage.KeyRefresh
+	sessions  map[string]*storage.SigningSession
+	// dueFilter 最近一次到期查询的条件
+	dueFilter *storage.KeyRefreshDueFilter
+}
+
+func newRefreshStore(keys ...*storage.KeyMetadata) *refreshStore {
+	return &refreshStore{
+		memoryStore: newMemoryStore(keys...),
+		refreshes:   map[string][]*storage.KeyRefresh{},
+		sessions:    map[string]*storage.SigningSession{},
+	}
+}
+
+func (m *refreshStore) UpdateKeyMetadata(_ context.Context, key *storage.KeyMetadata) error {
+	stored := *key
+	m.keys[key.KeyID] = &stored
+	return nil
+}
+
+func (m *refreshStore) SaveKeyRefresh(_ context.Context, refresh *storage.KeyRefresh) error {
+	stored := *refresh
+	m.refreshes[refresh.KeyID] = append([]*storage.KeyRefresh{&stored}, m.refreshes[refresh.KeyID]...)
+	return nil
+}
+
+func (m *refreshStore) ListKeyRefreshes(_ context.Context, keyID string, limit int) ([]*storage.KeyRefresh, error) {
+	refreshes := m.refreshes[keyID]
+	if len(refreshes) > limit {
+		refreshes = refreshes[:limit]
+	}
+	return refreshes, nil
+}
+
+func (m *refreshStore) ListKeysDueForRefresh(_ context.Context, filter *storage.KeyRefreshDueFilter) ([]*storage.KeyMetadata, error) {
+	m.dueFilter = filter
+	var due []*storage.KeyMetadata
+	for _, k := range m.keys {
+		if k.Status != storage.KeyStatusEnabled || lastRefreshedAt(k, m.refreshes[k.KeyID]).After(filter.RefreshedBefore) {
+			continue
+		}
+		// 与 SQL 相同的退避条件
+		if retryAt, failures := nextRetryAt(m.refreshes[k.KeyID]); failures > 0 && filter.Now.Before(retryAt) {
+			continue
+		}
+		stored := *k
+		due = append(due, &stored)
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return lastRefreshedAt(due[i], m.refreshes[due[i].KeyID]).Before(lastRefreshedAt(due[j], m.refreshes[due[j].KeyID]))
+	})
+	if len(due) > filter.Limit {
+		due = due[:filter.Limit]
+	}
+	return due, nil
+}
+
+func (m *refreshStore) SaveSigningSession(_ context.Context, s *storage.SigningSession) error {
+	stored := *s
+	m.sessions[s.SessionID] = &stored
+	return nil
+}
+
+func (m *refreshStore) GetSigningSession(_ context.Context, sessionID string) (*storage.SigningSession, error) {
+	stored, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, errors.New("session not found")
+	}
+	s := *stored
+	return &s, nil
+}
+
+func (m *refreshStore) UpdateSigningSession(ctx context.Context, s *storage.SigningSession) error {
+	return m.SaveSigningSession(ctx, s)
+}
+
+// memorySessionStore 只实现会话缓存和分布式锁的内存会话存储，locks 记录锁的持有者
+type memorySessionStore struct {
+	storage.SessionStore
+	sessions map[string]*storage.SigningSession
+
+	mu       sync.Mutex
+	locks    map[string]string
+	renewals int
+}
+
+func newMemorySessionStore() *memorySessionStore {
+	return &memorySessionStore{
+		sessions: map[string]*storage.SigningSession{},
+		locks:    map[string]string{},
+	}
+}
+
+func (m *memorySessionStore) SaveSession(_ context.Context, s *storage.SigningSession, _ time.Duration) error {
+	stored := *s
+	m.sessions[s.SessionID] = &stored
+	return nil
+}
+
+func (m *memorySessionStore) GetSession(_ context.Context, sessionID string) (*storage.SigningSession, error) {
+	stored, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, errors.New("session not found")
+	}
+	s := *stored
+	return &s, nil
+}
+
+func (m *memorySessionStore) UpdateSession(ctx context.Context, s *storage.SigningSession, ttl time.Duration) error {
+	return m.SaveSession(ctx, s, ttl)
+}
+
+func (m *memorySessionStore) AcquireLock(_ context.Context, key string, token string, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.locks[key]; ok {
+		return false, nil
+	}
+	m.locks[key] = token
+	return true, nil
+}
+
+func (m *memorySessionStore) RenewLock(_ context.Context, key string, token string, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locks[key] != token {
+		return false, nil
+	}
+	m.renewals++
+	return true, nil
+}
+
+func (m *memorySessionStore) ReleaseLock(_ context.Context, key string, token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locks[key] == token {
+		delete(m.locks, key)
+	}
+	return nil
+}
+
+// lockHolder 锁的当前持有者，未加锁时为空
+func (m *memorySessionStore) lockHolder(key string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.locks[key]
+}
+
+func (m *memorySessionStore) renewCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.renewals
+}
+
+// resharingClient 模拟节点执行 resharing，failing 中的节点返回错误
+type resharingClient struct {
+	GRPCClient
+	publicKey string
+	mu        sync.Mutex
+	failing   map[string]bool
+	requests  []*pb.StartResharingRequest
+	// onResharing 在每次 StartResharing 时调用，模拟 resharing 期间的外部事件
+	onResharing func()
+}
+
+func (c *resharingClient) SendStartResharing(_ context.Context, nodeID string, req *pb.StartResharingRequest) (*pb.StartResharingResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+	if c.onResharing != nil {
+		c.onResharing()
+	}
+	if c.failing[nodeID] {
+		return nil, errors.New("node unavailable")
+	}
+	return &pb.StartResharingResponse{Success: true, PublicKey: c.publicKey}, nil
+}
+
+func (c *resharingClient) calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.requests)
+}
+
+const refreshPeriod = 90 * 24 * time.Hour
+
+func newRefreshScheduler(t *testing.T, keys ...*storage.KeyMetadata) (*RefreshScheduler, *refreshStore, *memorySessionStore, *resharingClient, *time.Time) {
+	t.Helper()
+
+	store := newRefreshStore(keys...)
+	sessionStore := newMemorySessionStore()
+	client := &resharingClient{publicKey: "02abcdef", failing: map[string]bool{}}
+	sessionManager := session.NewManager(store, sessionStore, time.Minute, nil, nil)
+	service := NewService(store, nil, nil, NewDKGService(store, nil, nil, nil, nil, sessionManager, client), nil, nil)
+
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	return NewRefreshScheduler(service, sessionStore, refreshPeriod, time.Hour), store, sessionStore, client, &now
+}
+
+func refreshTestKey(keyID string, createdAt time.Time) *storage.KeyMetadata {
+	key := testKey(keyID, storage.KeyStatusEnabled)
+	key.PublicKey = "02abcdef"
+	key.Algorithm = "ECDSA"
+	key.Threshold = 1
+	key.TotalNodes = 3
+	key.CreatedAt = createdAt
+	return key
+}
+
+func TestRefreshSchedulerRefreshesDueKeys(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	scheduler, store, sessionStore, client, _ := newRefreshScheduler(t,
+		refreshTestKey("key-1", now.AddDate(0, 0, -100)),
+		refreshTestKey("key-2", now.AddDate(0, 0, -10)),
+	)
+	require.True(t, scheduler.Enabled())
+
+	assert.Equal(t, 1, scheduler.RefreshDueKeys(ctx))
+
+	assert.Equal(t, 1, store.keys["key-1"].ShareEpoch)
+	assert.Equal(t, 0, store.keys["key-2"].ShareEpoch)
+	assert.Empty(t, sessionStore.locks)
+
+	// 同一委员会、同一阈值
+	require.Len(t, client.requests, 3)
+	req := client.requests[0]
+	assert.Equal(t, "key-1", req.KeyId)
+	assert.Equal(t, req.OldNodeIds, req.NewNodeIds)
+	assert.Equal(t, req.OldThreshold, req.NewThreshold)
+	assert.Equal(t, int32(0), req.OldEpoch)
+	assert.Equal(t, int32(1), req.NewEpoch)
+
+	refreshes, err := scheduler.keyService.ListKeyRefreshes(ctx, "key-1")
+	require.NoError(t, err)
+	require.Len(t, refreshes, 1)
+	assert.Equal(t, storage.KeyRefreshStatusCompleted, refreshes[0].Status)
+	assert.Equal(t, 0, refreshes[0].OldEpoch)
+	assert.Equal(t, 1, refreshes[0].NewEpoch)
+
+	// 刚刷新过的密钥不再到期
+	assert.Equal(t, 0, scheduler.RefreshDueKeys(ctx))
+	assert.Equal(t, 3, client.calls())
+}
+
+func TestRefreshSchedulerBacksOffAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	scheduler, store, _, client, now := newRefreshScheduler(t, refreshTestKey("key-1", start.AddDate(0, 0, -100)))
+
+	client.failing["node-2"] = true
+	assert.Equal(t, 0, scheduler.RefreshDueKeys(ctx))
+	require.Len(t, store.refreshes["key-1"], 1)
+	failed := store.refreshes["key-1"][0]
+	assert.Equal(t, storage.KeyRefreshStatusFailed, failed.Status)
+	assert.Equal(t, failed.OldEpoch, failed.NewEpoch)
+	assert.NotEmpty(t, failed.Error)
+	assert.Equal(t, 0, store.keys["key-1"].ShareEpoch)
+
+	// 退避期内不重试，退避在到期查询中过滤（退避中的密钥不占用批次）
+	calls := client.calls()
+	assert.Equal(t, 0, scheduler.RefreshDueKeys(ctx))
+	assert.Equal(t, calls, client.calls())
+	assert.Equal(t, &storage.KeyRefreshDueFilter{
+		RefreshedBefore: now.Add(-refreshPeriod),
+		Now:             *now,
+		RetryBackoff:    refreshRetryBackoff,
+		MaxRetryBackoff: refreshMaxRetryBackoff,
+		Limit:           refreshBatchSize,
+	}, store.dueFilter)
+
+	// 第一次退避结束后重试，再次失败后退避翻倍
+	*now = now.Add(refreshRetryBackoff)
+	assert.Equal(t, 0, scheduler.RefreshDueKeys(ctx))
+	assert.Len(t, store.refreshes["key-1"], 2)
+
+	*now = now.Add(refreshRetryBackoff)
+	assert.Equal(t, 0, scheduler.RefreshDueKeys(ctx))
+	assert.Len(t, store.refreshes["key-1"], 2)
+
+	client.failing["node-2"] = false
+	*now = now.Add(refreshRetryBackoff)
+	assert.Equal(t, 1, scheduler.RefreshDueKeys(ctx))
+	assert.Equal(t, 1, store.keys["key-1"].ShareEpoch)
+	assert.Equal(t, storage.KeyRefreshStatusCompleted, store.refreshes["key-1"][0].Status)
+}
+
+func TestRefreshSchedulerSkipsWhenLockHeld(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	scheduler, store, sessionStore, client, _ := newRefreshScheduler(t, refreshTestKey("key-1", start.AddDate(0, 0, -100)))
+
+	acquired, err := sessionStore.AcquireLock(ctx, refreshLockKey, "other-coordinator", refreshLockTTL)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	assert.Equal(t, 0, scheduler.RefreshDueKeys(ctx))
+	assert.Equal(t, 0, client.calls())
+	assert.Empty(t, store.refreshes["key-1"])
+	assert.Equal(t, "other-coordinator", sessionStore.lockHolder(refreshLockKey))
+}
+
+func TestRefreshSchedulerRenewsAndReleasesOwnLock(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	scheduler, store, sessionStore, client, _ := newRefreshScheduler(t, refreshTestKey("key-1", start.AddDate(0, 0, -100)))
+
+	// resharing 超过续期间隔时锁被续期
+	scheduler.lockRenewInterval = time.Millisecond
+	client.onResharing = func() {
+		require.Eventually(t, func() bool { return sessionStore.renewCount() > 0 }, time.Second, time.Millisecond)
+	}
+	assert.Equal(t, 1, scheduler.RefreshDueKeys(ctx))
+	assert.Empty(t, sessionStore.lockHolder(refreshLockKey))
+
+	// 锁在刷新期间过期并被其他协调者获取，刷新结束后不释放其他协调者的锁
+	*store.keys["key-1"] = *refreshTestKey("key-1", start.AddDate(0, 0, -100))
+	store.refreshes["key-1"] = nil
+	scheduler.lockRenewInterval = time.Hour
+	client.onResharing = func() {
+		sessionStore.mu.Lock()
+		sessionStore.locks[refreshLockKey] = "other-coordinator"
+		sessionStore.mu.Unlock()
+	}
+	assert.Equal(t, 1, scheduler.RefreshDueKeys(ctx))
+	assert.Equal(t, "other-coordinator", sessionStore.lockHolder(refreshLockKey))
+}
+
+// TestRefreshSchedulerFullThresholdCommittee FROST/CGGMP21 的阈值为最少签名者数量，阈值等于节点数的密钥也可以刷新
+func TestRefreshSchedulerFullThresholdCommittee(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	for _, protocolName := range []string{"frost", "cggmp21"} {
+		t.Run(protocolName, func(t *testing.T) {
+			keyMeta := refreshTestKey("key-1", start.AddDate(0, 0, -100))
+			keyMeta.Protocol = protocolName
+			keyMeta.Threshold = 3
+			scheduler, store, _, client, _ := newRefreshScheduler(t, keyMeta)
+
+			assert.Equal(t, 1, scheduler.RefreshDueKeys(ctx))
+			assert.Equal(t, 1, store.keys["key-1"].ShareEpoch)
+			require.Len(t, client.requests, 3)
+			assert.Equal(t, int32(3), client.requests[0].NewThreshold)
+			assert.Equal(t, []string{"node-1", "node-2", "node-3"}, client.requests[0].NewNodeIds)
+		})
+	}
+}
+
+func TestRefreshSchedulerSkipsDisabledKeys(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	disabled := refreshTestKey("key-1", start.AddDate(0, 0, -100))
+	disabled.Status = storage.KeyStatusDisabled
+	scheduler, _, _, client, _ := newRefreshScheduler(t, disabled)
+
+	assert.Equal(t, 0, scheduler.RefreshDueKeys(ctx))
+	assert.Equal(t, 0, client.calls())
+
+	assert.False(t, NewRefreshScheduler(scheduler.keyService, nil, 0, time.Hour).Enabled())
+}
+
+func TestRefreshBackoff(t *testing.T) {
+	assert.Equal(t, 15*time.Minute, refreshBackoff(1))
+	assert.Equal(t, 30*time.Minute, refreshBackoff(2))
+	assert.Equal(t, 2*time.Hour, refreshBackoff(4))
+	assert.Equal(t, refreshMaxRetryBackoff, refreshBackoff(8))
+	assert.Equal(t, refreshMaxRetryBackoff, refreshBackoff(1000))
+}
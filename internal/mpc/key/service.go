@@ -3,11 +3,11 @@ package key
 import (
 	"context"
 	"encoding/hex"
-	"math/big"
+	"strings"
 	"time"
 
-	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/google/uuid"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
@@ -21,30 +21,50 @@ type Service struct {
 	keyShareStorage storage.KeyShareStorage
 	protocolEngine  protocol.Engine
 	dkgService      *DKGService
+	chains          *chain.Registry
+	auditLogger     *audit.Logger
+	now             func() time.Time
 }
 
-// NewService 创建密钥服务
+// NewService 创建密钥服务，chains 为空时使用内置链的主网配置
 func NewService(
 	metadataStore storage.MetadataStore,
 	keyShareStorage storage.KeyShareStorage,
 	protocolEngine protocol.Engine,
 	dkgService *DKGService,
+	chains *chain.Registry,
+	auditLogger *audit.Logger,
 ) *Service {
+	if chains == nil {
+		chains = chain.NewDefaultRegistry()
+	}
 	return &Service{
 		metadataStore:   metadataStore,
 		keyShareStorage: keyShareStorage,
 		protocolEngine:  protocolEngine,
 		dkgService:      dkgService,
+		chains:          chains,
+		auditLogger:     auditLogger,
+		now:             time.Now,
 	}
 }
 
 // CreateKey 创建密钥（执行DKG）
 func (s *Service) CreateKey(ctx context.Context, req *CreateKeyRequest) (*KeyMetadata, error) {
+	keyMetadata, err := s.createKey(ctx, req)
+	s.recordKeyCreated(ctx, req, keyMetadata, err)
+	return keyMetadata, err
+}
+
+func (s *Service) createKey(ctx context.Context, req *CreateKeyRequest) (*KeyMetadata, error) {
 	// 生成密钥ID（如果请求中未提供）
 	keyID := req.KeyID
 	if keyID == "" {
 		keyID = "key-" + uuid.New().String()
 	}
+	if err := s.ValidateChainType(req.ChainType, req.Algorithm, req.Curve); err != nil {
+		return nil, err
+	}
 
 	// 使用DKGService执行DKG（如果可用）
 	var dkgResp *protocol.KeyGenResponse
@@ -82,6 +102,15 @@ func (s *Service) CreateKey(ctx context.Context, req *CreateKeyRequest) (*KeyMet
 		}
 	}
 
+	chainCode, err := newChainCode(req.Curve)
+	if err != nil {
+		return nil, err
+	}
+	keyProtocol, err := ResolveProtocol(req.Algorithm, req.Protocol, s.protocolEngine.DefaultProtocol())
+	if err != nil {
+		return nil, err
+	}
+
 	// 保存密钥元数据
 	now := time.Now()
 	keyMetadata := &KeyMetadata{
@@ -92,9 +121,11 @@ func (s *Service) CreateKey(ctx context.Context, req *CreateKeyRequest) (*KeyMet
 		Threshold:   req.Threshold,
 		TotalNodes:  req.TotalNodes,
 		ChainType:   req.ChainType,
-		Status:      "Active",
+		Status:      storage.KeyStatusEnabled,
 		Description: req.Description,
 		Tags:        req.Tags,
+		ChainCode:   chainCode,
+		Protocol:    keyProtocol,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -111,6 +142,10 @@ func (s *Service) CreateKey(ctx context.Context, req *CreateKeyRequest) (*KeyMet
 		Status:       keyMetadata.Status,
 		Description:  keyMetadata.Description,
 		Tags:         keyMetadata.Tags,
+		ShareEpoch:   keyMetadata.ShareEpoch,
+		NodeIDs:      keyMetadata.NodeIDs,
+		ChainCode:    keyMetadata.ChainCode,
+		Protocol:     keyMetadata.Protocol,
 		CreatedAt:    keyMetadata.CreatedAt,
 		UpdatedAt:    keyMetadata.UpdatedAt,
 		DeletionDate: keyMetadata.DeletionDate,
@@ -126,10 +161,30 @@ func (s *Service) CreateKey(ctx context.Context, req *CreateKeyRequest) (*KeyMet
 // CreatePlaceholderKey 创建占位符密钥（不执行DKG，只创建元数据）
 // 用于在DKG会话创建前满足外键约束
 func (s *Service) CreatePlaceholderKey(ctx context.Context, req *CreateKeyRequest) (*KeyMetadata, error) {
+	keyMetadata, err := s.createPlaceholderKey(ctx, req)
+	s.recordKeyCreated(ctx, req, keyMetadata, err)
+	return keyMetadata, err
+}
+
+func (s *Service) createPlaceholderKey(ctx context.Context, req *CreateKeyRequest) (*KeyMetadata, error) {
 	keyID := req.KeyID
 	if keyID == "" {
 		keyID = "key-" + uuid.New().String()
 	}
+	if err := s.ValidateChainType(req.ChainType, req.Algorithm, req.Curve); err != nil {
+		return nil, err
+	}
+
+	// 链码随密钥一起生成，DKG 完成后由根公钥和链码派生子密钥
+	chainCode, err := newChainCode(req.Curve)
+	if err != nil {
+		return nil, err
+	}
+	// 协议随密钥记录，之后的签名、预签名和 resharing 都使用同一协议
+	keyProtocol, err := ResolveProtocol(req.Algorithm, req.Protocol, s.protocolEngine.DefaultProtocol())
+	if err != nil {
+		return nil, err
+	}
 
 	now := time.Now()
 	keyMetadata := &KeyMetadata{
@@ -140,9 +195,11 @@ func (s *Service) CreatePlaceholderKey(ctx context.Context, req *CreateKeyReques
 		Threshold:   req.Threshold,
 		TotalNodes:  req.TotalNodes,
 		ChainType:   req.ChainType,
-		Status:      "Pending", // 占位符状态
+		Status:      storage.KeyStatusPending, // 占位符状态
 		Description: req.Description,
 		Tags:        req.Tags,
+		ChainCode:   chainCode,
+		Protocol:    keyProtocol,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -159,6 +216,10 @@ func (s *Service) CreatePlaceholderKey(ctx context.Context, req *CreateKeyReques
 		Status:       keyMetadata.Status,
 		Description:  keyMetadata.Description,
 		Tags:         keyMetadata.Tags,
+		ShareEpoch:   keyMetadata.ShareEpoch,
+		NodeIDs:      keyMetadata.NodeIDs,
+		ChainCode:    keyMetadata.ChainCode,
+		Protocol:     keyMetadata.Protocol,
 		CreatedAt:    keyMetadata.CreatedAt,
 		UpdatedAt:    keyMetadata.UpdatedAt,
 		DeletionDate: keyMetadata.DeletionDate,
@@ -211,7 +272,7 @@ func (s *Service) CreateKeyWithExistingMetadata(ctx context.Context, req *Create
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get existing key metadata")
 	}
-	if existingKey.Status != "Pending" {
+	if existingKey.Status != storage.KeyStatusPending {
 		return nil, errors.Errorf("key %s is not in Pending status", keyID)
 	}
 
@@ -247,7 +308,23 @@ func (s *Service) CreateKeyWithExistingMetadata(ctx context.Context, req *Create
 		}
 	}
 
-	// 更新密钥元数据（添加公钥，更新状态为Active）
+	// 保留占位符密钥的链码（旧数据没有链码时补充生成）
+	chainCode := existingKey.ChainCode
+	if chainCode == "" {
+		chainCode, err = newChainCode(req.Curve)
+		if err != nil {
+			return nil, err
+		}
+	}
+	keyProtocol := existingKey.Protocol
+	if keyProtocol == "" {
+		keyProtocol, err = ResolveProtocol(req.Algorithm, req.Protocol, s.protocolEngine.DefaultProtocol())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 更新密钥元数据（添加公钥，更新状态为Enabled）
 	now := time.Now()
 	storageKey := &storage.KeyMetadata{
 		KeyID:        keyID,
@@ -258,9 +335,13 @@ func (s *Service) CreateKeyWithExistingMetadata(ctx context.Context, req *Create
 		TotalNodes:   req.TotalNodes,
 		ChainType:    req.ChainType,
 		Address:      existingKey.Address, // 保持原有地址（如果有）
-		Status:       "Active",
+		Status:       storage.KeyStatusEnabled,
 		Description:  req.Description,
 		Tags:         req.Tags,
+		ShareEpoch:   existingKey.ShareEpoch,
+		NodeIDs:      existingKey.NodeIDs,
+		ChainCode:    chainCode,
+		Protocol:     keyProtocol,
 		CreatedAt:    existingKey.CreatedAt, // 保持原有创建时间
 		UpdatedAt:    now,
 		DeletionDate: existingKey.DeletionDate,
@@ -271,18 +352,9 @@ func (s *Service) CreateKeyWithExistingMetadata(ctx context.Context, req *Create
 		// 解析公钥
 		pubKeyBytes, err := hex.DecodeString(dkgResp.PublicKey.Hex)
 		if err == nil {
-			// 根据链类型选择适配器
-			var adapter chain.Adapter
-			switch req.ChainType {
-			case "bitcoin", "btc":
-				adapter = chain.NewBitcoinAdapter(&chaincfg.MainNetParams)
-			case "ethereum", "eth", "evm":
-				adapter = chain.NewEthereumAdapter(big.NewInt(1)) // mainnet
-			default:
-				// 不支持的链类型，跳过地址生成
-				adapter = nil
-			}
-			if adapter != nil {
+			// 根据链类型选择适配器（不支持的链类型跳过地址生成）
+			adapter, _, err := s.addressAdapter(&KeyMetadata{Algorithm: req.Algorithm, Curve: req.Curve}, req.ChainType, nil)
+			if err == nil {
 				address, err := adapter.GenerateAddress(pubKeyBytes)
 				if err == nil {
 					storageKey.Address = address
@@ -307,6 +379,10 @@ func (s *Service) CreateKeyWithExistingMetadata(ctx context.Context, req *Create
 		Status:       storageKey.Status,
 		Description:  storageKey.Description,
 		Tags:         storageKey.Tags,
+		ShareEpoch:   storageKey.ShareEpoch,
+		NodeIDs:      storageKey.NodeIDs,
+		ChainCode:    storageKey.ChainCode,
+		Protocol:     storageKey.Protocol,
 		CreatedAt:    storageKey.CreatedAt,
 		UpdatedAt:    storageKey.UpdatedAt,
 		DeletionDate: storageKey.DeletionDate,
@@ -334,6 +410,10 @@ func (s *Service) GetKey(ctx context.Context, keyID string) (*KeyMetadata, error
 		Status:       storageKey.Status,
 		Description:  storageKey.Description,
 		Tags:         storageKey.Tags,
+		ShareEpoch:   storageKey.ShareEpoch,
+		NodeIDs:      storageKey.NodeIDs,
+		ChainCode:    storageKey.ChainCode,
+		Protocol:     storageKey.Protocol,
 		CreatedAt:    storageKey.CreatedAt,
 		UpdatedAt:    storageKey.UpdatedAt,
 		DeletionDate: storageKey.DeletionDate,
@@ -342,52 +422,22 @@ func (s *Service) GetKey(ctx context.Context, keyID string) (*KeyMetadata, error
 	return keyMetadata, nil
 }
 
-// DeleteKey 删除密钥
+// DeleteKey 立即删除 DKG 未完成的占位符密钥（Pending，没有需要销毁的分片）
+// 已生成分片的密钥只能通过 ScheduleKeyDeletion 在等待期结束后删除
 func (s *Service) DeleteKey(ctx context.Context, keyID string) error {
-	// 获取密钥信息
-	key, err := s.GetKey(ctx, keyID)
-	if err != nil {
-		return errors.Wrap(err, "failed to get key")
-	}
-
-	// 删除所有节点的密钥分片
-	// TODO: 从节点管理器获取所有节点ID
-	nodeIDs := []string{} // 需要实现
-	for _, nodeID := range nodeIDs {
-		if err := s.keyShareStorage.DeleteKeyShare(ctx, keyID, nodeID); err != nil {
-			// 记录错误但继续删除其他分片
-			// log error
-		}
-	}
-
-	// 更新密钥状态为删除
-	now := time.Now()
-	key.Status = "Deleted"
-	key.DeletionDate = &now
-	key.UpdatedAt = now
-
-	storageKey := &storage.KeyMetadata{
-		KeyID:        key.KeyID,
-		PublicKey:    key.PublicKey,
-		Algorithm:    key.Algorithm,
-		Curve:        key.Curve,
-		Threshold:    key.Threshold,
-		TotalNodes:   key.TotalNodes,
-		ChainType:    key.ChainType,
-		Address:      key.Address,
-		Status:       key.Status,
-		Description:  key.Description,
-		Tags:         key.Tags,
-		CreatedAt:    key.CreatedAt,
-		UpdatedAt:    key.UpdatedAt,
-		DeletionDate: key.DeletionDate,
+	now := s.now()
+	_, err := s.transitionKey(ctx, keyID, storage.KeyStatusDeleted, &now)
+	event := &audit.Event{
+		EventType: audit.EventTypeKey,
+		Operation: audit.OperationDeleteKey,
+		Result:    audit.ResultOf(err),
+		KeyID:     keyID,
 	}
-
-	if err := s.metadataStore.UpdateKeyMetadata(ctx, storageKey); err != nil {
-		return errors.Wrap(err, "failed to update key status")
+	if err != nil {
+		event.Details = map[string]interface{}{"error": err.Error()}
 	}
-
-	return nil
+	s.auditLogger.Record(ctx, event)
+	return err
 }
 
 // ListKeys 列出密钥
@@ -420,6 +470,10 @@ func (s *Service) ListKeys(ctx context.Context, filter *KeyFilter) ([]*KeyMetada
 			Status:       storageKey.Status,
 			Description:  storageKey.Description,
 			Tags:         storageKey.Tags,
+			ShareEpoch:   storageKey.ShareEpoch,
+			NodeIDs:      storageKey.NodeIDs,
+			ChainCode:    storageKey.ChainCode,
+			Protocol:     storageKey.Protocol,
 			CreatedAt:    storageKey.CreatedAt,
 			UpdatedAt:    storageKey.UpdatedAt,
 			DeletionDate: storageKey.DeletionDate,
@@ -429,40 +483,64 @@ func (s *Service) ListKeys(ctx context.Context, filter *KeyFilter) ([]*KeyMetada
 	return keys, nil
 }
 
-// GenerateAddress 生成区块链地址
-func (s *Service) GenerateAddress(ctx context.Context, keyID string, chainType string) (string, error) {
-	// 获取密钥信息
-	keyMetadata, err := s.GetKey(ctx, keyID)
+// GenerateAddress 生成区块链地址，opts 指定 Bitcoin 地址类型和网络（为空时使用默认值）
+// 地址由公钥确定性生成，与元数据中记录的地址不同时更新元数据
+func (s *Service) GenerateAddress(ctx context.Context, keyID string, chainType string, opts *AddressOptions) (*KeyAddress, error) {
+	keyAddress, err := s.generateAddress(ctx, keyID, chainType, opts)
+	event := &audit.Event{
+		EventType: audit.EventTypeKey,
+		Operation: audit.OperationGenerateAddress,
+		Result:    audit.ResultOf(err),
+		KeyID:     keyID,
+		Details:   map[string]interface{}{"chain_type": chainType},
+	}
+	if keyAddress != nil {
+		event.Details["address"] = keyAddress.Address
+		event.Details["address_type"] = keyAddress.AddressType
+		event.Details["network"] = keyAddress.Network
+	}
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get key")
+		event.Details["error"] = err.Error()
 	}
+	s.auditLogger.Record(ctx, event)
+	return keyAddress, err
+}
 
-	// 如果地址已存在且链类型匹配，直接返回
-	if keyMetadata.Address != "" && keyMetadata.ChainType == chainType {
-		return keyMetadata.Address, nil
+func (s *Service) generateAddress(ctx context.Context, keyID string, chainType string, opts *AddressOptions) (*KeyAddress, error) {
+	// 获取密钥信息
+	keyMetadata, err := s.GetKey(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key")
 	}
 
 	// 解析公钥
 	pubKeyBytes, err := hex.DecodeString(keyMetadata.PublicKey)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to decode public key")
+		return nil, errors.Wrap(err, "failed to decode public key")
 	}
 
-	// 根据链类型选择适配器
-	var adapter chain.Adapter
-	switch chainType {
-	case "bitcoin", "btc":
-		adapter = chain.NewBitcoinAdapter(&chaincfg.MainNetParams)
-	case "ethereum", "eth", "evm":
-		adapter = chain.NewEthereumAdapter(big.NewInt(1)) // mainnet
-	default:
-		return "", errors.Errorf("unsupported chain type: %s", chainType)
+	// 根据链类型和地址选项选择适配器
+	adapter, resolved, err := s.addressAdapter(keyMetadata, chainType, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	// 生成地址
 	address, err := adapter.GenerateAddress(pubKeyBytes)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to generate address")
+		return nil, errors.Wrap(err, "failed to generate address")
+	}
+	keyAddress := &KeyAddress{
+		KeyID:       keyID,
+		ChainType:   chainType,
+		Address:     address,
+		AddressType: resolved.AddressType,
+		Network:     resolved.Network,
+	}
+
+	// 地址和链类型都未变化时无需更新
+	if keyMetadata.Address == address && keyMetadata.ChainType == chainType {
+		return keyAddress, nil
 	}
 
 	// 更新密钥元数据中的地址
@@ -482,14 +560,185 @@ func (s *Service) GenerateAddress(ctx context.Context, keyID string, chainType s
 		Status:       keyMetadata.Status,
 		Description:  keyMetadata.Description,
 		Tags:         keyMetadata.Tags,
+		ShareEpoch:   keyMetadata.ShareEpoch,
+		NodeIDs:      keyMetadata.NodeIDs,
+		ChainCode:    keyMetadata.ChainCode,
+		Protocol:     keyMetadata.Protocol,
 		CreatedAt:    keyMetadata.CreatedAt,
 		UpdatedAt:    keyMetadata.UpdatedAt,
 		DeletionDate: keyMetadata.DeletionDate,
 	}
 
 	if err := s.metadataStore.UpdateKeyMetadata(ctx, storageKey); err != nil {
-		return "", errors.Wrap(err, "failed to update key metadata with address")
+		return nil, errors.Wrap(err, "failed to update key metadata with address")
 	}
 
-	return address, nil
+	return keyAddress, nil
+}
+
+// DeriveChildKey 按非强化 BIP-32 路径从根公钥派生子公钥，指定链类型时同时生成地址
+// 派生只需要根公钥和链码，不需要 MPC 节点参与，一次 DKG 即可为任意数量的地址服务
+func (s *Service) DeriveChildKey(ctx context.Context, keyID string, path string, chainType string) (*DerivedKey, error) {
+	keyMetadata, err := s.GetKey(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key")
+	}
+	if !keyMetadata.Enabled() {
+		return nil, errors.Wrapf(ErrKeyNotEnabled, "key %s is %s", keyID, keyMetadata.Status)
+	}
+	if keyMetadata.ChainCode == "" {
+		return nil, errors.Errorf("key %s has no chain code and does not support derivation", keyID)
+	}
+
+	rootPublicKey, err := hex.DecodeString(keyMetadata.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode public key")
+	}
+	chainCode, err := hex.DecodeString(keyMetadata.ChainCode)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode chain code")
+	}
+
+	derived, err := protocol.DeriveChildPublicKey(keyMetadata.Curve, rootPublicKey, chainCode, path)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey := &DerivedKey{
+		KeyID:             keyID,
+		Path:              path,
+		PublicKey:         hex.EncodeToString(derived.PublicKey),
+		ExtendedPublicKey: derived.ExtendedPublicKey,
+		ChainType:         chainType,
+	}
+	if chainType != "" {
+		adapter, _, err := s.addressAdapter(keyMetadata, chainType, nil)
+		if err != nil {
+			return nil, err
+		}
+		derivedKey.Address, err = adapter.GenerateAddress(derived.PublicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate address")
+		}
+	}
+
+	return derivedKey, nil
+}
+
+// BackupKeyShares 收集密钥当前委员会所有节点的加密分片备份，返回收集后的备份状态
+func (s *Service) BackupKeyShares(ctx context.Context, keyID string) (*KeyBackupStatus, error) {
+	return s.dkgService.CollectShareBackups(ctx, keyID)
+}
+
+// GetKeyBackupStatus 查询密钥当前分片轮次的备份状态
+func (s *Service) GetKeyBackupStatus(ctx context.Context, keyID string) (*KeyBackupStatus, error) {
+	return s.dkgService.GetShareBackupStatus(ctx, keyID)
+}
+
+// ValidateKeyShares 校验密钥当前委员会所有节点的分片一致性，返回并记录校验结果
+func (s *Service) ValidateKeyShares(ctx context.Context, keyID string) (*KeyShareValidation, error) {
+	return s.dkgService.ValidateKeyShares(ctx, keyID)
+}
+
+// GetKeyShareValidation 查询密钥最近一次分片一致性校验结果，从未校验过时返回 nil
+func (s *Service) GetKeyShareValidation(ctx context.Context, keyID string) (*KeyShareValidation, error) {
+	return s.dkgService.GetKeyShareValidation(ctx, keyID)
+}
+
+// addressAdapter 从链注册表中选择适配器，同时校验密钥的算法和曲线适用于该链，返回补全默认值后的地址选项
+func (s *Service) addressAdapter(keyMetadata *KeyMetadata, chainType string, opts *AddressOptions) (chain.Adapter, *AddressOptions, error) {
+	var chainOpts *chain.AddressOptions
+	if opts != nil {
+		chainOpts = &chain.AddressOptions{AddressType: opts.AddressType, Network: opts.Network}
+	}
+	adapter, resolved, err := s.chains.NewAdapter(chainType, keyMetadata.Algorithm, keyMetadata.Curve, chainOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return adapter, &AddressOptions{AddressType: resolved.AddressType, Network: resolved.Network}, nil
+}
+
+// recordKeyCreated 记录密钥创建的审计日志，失败时密钥 ID 为请求中的 ID（可能为空）
+func (s *Service) recordKeyCreated(ctx context.Context, req *CreateKeyRequest, keyMetadata *KeyMetadata, err error) {
+	event := &audit.Event{
+		EventType: audit.EventTypeKey,
+		Operation: audit.OperationCreateKey,
+		Result:    audit.ResultOf(err),
+		KeyID:     req.KeyID,
+		Details: map[string]interface{}{
+			"algorithm":   req.Algorithm,
+			"curve":       req.Curve,
+			"threshold":   req.Threshold,
+			"total_nodes": req.TotalNodes,
+			"chain_type":  req.ChainType,
+		},
+	}
+	if keyMetadata != nil {
+		event.KeyID = keyMetadata.KeyID
+		event.Details["status"] = keyMetadata.Status
+		event.Details["protocol"] = keyMetadata.Protocol
+	}
+	if err != nil {
+		event.Details["error"] = err.Error()
+	}
+	s.auditLogger.Record(ctx, event)
+}
+
+// Chains 返回密钥服务使用的链注册表
+func (s *Service) Chains() *chain.Registry {
+	return s.chains
+}
+
+// ValidateChainType 检查链类型已注册且支持指定的算法和曲线，链类型为空时不检查
+func (s *Service) ValidateChainType(chainType, algorithm, curve string) error {
+	if chainType == "" {
+		return nil
+	}
+	return s.chains.ValidateKey(chainType, algorithm, curve)
+}
+
+// ResolveProtocol 确定新密钥使用的协议：EdDSA/Schnorr 使用 FROST，ECDSA 使用请求指定的协议，
+// 未指定时使用默认协议（默认协议不支持 ECDSA 时使用 gg20）
+func ResolveProtocol(algorithm, requested, defaultProtocol string) (string, error) {
+	requested = strings.ToLower(requested)
+	switch strings.ToLower(algorithm) {
+	case "eddsa", "schnorr":
+		if requested != "" && requested != "frost" {
+			return "", errors.Errorf("protocol %s does not support algorithm %s", requested, algorithm)
+		}
+		return "frost", nil
+	default:
+		if requested == "" {
+			requested = strings.ToLower(defaultProtocol)
+			if !isECDSAProtocol(requested) {
+				requested = "gg20"
+			}
+		}
+		if !isECDSAProtocol(requested) {
+			return "", errors.Errorf("protocol %s does not support algorithm %s", requested, algorithm)
+		}
+		return requested, nil
+	}
+}
+
+// isECDSAProtocol 判断协议是否为 ECDSA 门限签名协议
+func isECDSAProtocol(name string) bool {
+	switch name {
+	case "gg18", "gg20", "cggmp21":
+		return true
+	default:
+		return false
+	}
+}
+
+// newChainCode 为支持 BIP-32 派生的曲线（secp256k1）生成链码，其他曲线返回空
+func newChainCode(curve string) (string, error) {
+	if !strings.EqualFold(curve, "secp256k1") {
+		return "", nil
+	}
+	chainCode, err := protocol.NewChainCode()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(chainCode), nil
 }
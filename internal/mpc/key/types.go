@@ -1,6 +1,10 @@
 package key
 
-import "time"
+import (
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+)
 
 // KeyMetadata 密钥元数据
 type KeyMetadata struct {
@@ -15,11 +19,20 @@ type KeyMetadata struct {
 	Status       string
 	Description  string
 	Tags         map[string]string
+	ShareEpoch   int      // 分片轮次，每次 resharing 后递增
+	NodeIDs      []string // 当前持有分片的节点（委员会）
+	ChainCode    string   // BIP-32 链码（hex），用于非强化派生子密钥
+	Protocol     string   // DKG 使用的协议（gg18 / gg20 / frost / cggmp21）
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletionDate *time.Time
 }
 
+// Enabled 密钥是否可用于签名（禁用、等待删除、已删除和 DKG 未完成的密钥不可用）
+func (k *KeyMetadata) Enabled() bool {
+	return k.Status == storage.KeyStatusEnabled
+}
+
 // KeyShare 密钥分片
 type KeyShare struct {
 	KeyID  string
@@ -36,10 +49,18 @@ type CreateKeyRequest struct {
 	Threshold   int
 	TotalNodes  int
 	ChainType   string
+	Protocol    string // 可选，ECDSA 密钥使用的协议（gg18 / gg20 / cggmp21），为空时使用默认协议
 	Description string
 	Tags        map[string]string
 }
 
+// RotateKeyRequest 密钥重分享（resharing）请求
+type RotateKeyRequest struct {
+	KeyID        string
+	NewThreshold int      // 新阈值，为0时沿用当前阈值
+	NewNodeIDs   []string // 新委员会节点列表
+}
+
 // KeyFilter 密钥过滤条件
 type KeyFilter struct {
 	ChainType string
@@ -49,3 +70,99 @@ type KeyFilter struct {
 	Limit     int
 	Offset    int
 }
+
+// AddressOptions 地址生成选项（仅 Bitcoin），为空时使用链注册表中配置的默认网络
+type AddressOptions struct {
+	AddressType string // p2pkh, p2sh-p2wpkh, p2wpkh, p2tr；默认 Schnorr 密钥为 p2tr，其他为 p2wpkh
+	Network     string // mainnet, testnet, signet, regtest
+}
+
+// KeyAddress 密钥在指定链上的地址
+type KeyAddress struct {
+	KeyID       string
+	ChainType   string
+	Address     string
+	AddressType string // 仅 Bitcoin
+	Network     string // 仅 Bitcoin
+}
+
+// DerivedKey 从根密钥非强化派生出的子密钥（BIP-32）
+type DerivedKey struct {
+	KeyID             string
+	Path              string
+	PublicKey         string // 子公钥（hex，33 字节压缩格式）
+	ExtendedPublicKey string // 子节点扩展公钥（xpub）
+	ChainType         string
+	Address           string // 指定链类型时生成的地址
+}
+
+// 分片备份状态
+const (
+	BackupStatusComplete     = "complete"     // 所有委员会节点都有备份，且可以重建私钥
+	BackupStatusPartial      = "partial"      // 部分节点缺少备份，但已有备份足以重建私钥
+	BackupStatusInsufficient = "insufficient" // 已有备份不足以重建私钥
+	BackupStatusMissing      = "missing"      // 当前分片轮次没有任何备份
+)
+
+// NodeShareBackup 单个节点的分片备份摘要（不包含密文）
+type NodeShareBackup struct {
+	NodeID            string
+	PublicShare       string // hex 编码的公开分片
+	RecoveryPublicKey string // hex 编码的 X25519 恢复公钥
+	CreatedAt         time.Time
+}
+
+// KeyBackupStatus 密钥当前分片轮次的备份状态
+type KeyBackupStatus struct {
+	KeyID          string
+	ShareEpoch     int
+	Status         string
+	Recoverable    bool // 已有备份解密后是否足以重建私钥（公开分片插值等于公钥）
+	Backups        []*NodeShareBackup
+	MissingNodeIDs []string
+	FailedNodeIDs  []string // 本次收集失败的节点（仅收集时返回）
+}
+
+// 分片一致性校验状态
+const (
+	ShareValidationHealthy  = "healthy"  // 所有委员会节点证明持有分片，且公开分片一致、插值等于公钥
+	ShareValidationDegraded = "degraded" // 部分节点无响应、证明无效或分片不一致，但一致的分片仍足以签名
+	ShareValidationBroken   = "broken"   // 一致的分片不足门限，或公开分片向量与公钥不符
+)
+
+// 单个节点的分片校验状态
+const (
+	NodeShareValid        = "valid"         // 证明有效，公开分片与其他节点一致
+	NodeShareUnreachable  = "unreachable"   // 节点无响应或无法读取分片
+	NodeShareInvalidProof = "invalid_proof" // 持有证明无效
+	NodeShareInconsistent = "inconsistent"  // 证明有效但公开分片或公开分片向量与其他节点不一致
+)
+
+// NodeShareValidation 单个节点的分片校验结果
+type NodeShareValidation struct {
+	NodeID      string `json:"node_id"`
+	Status      string `json:"status"`
+	PublicShare string `json:"public_share,omitempty"` // hex 编码的公开分片
+	Error       string `json:"error,omitempty"`
+}
+
+// KeyShareValidation 密钥分片一致性校验结果
+type KeyShareValidation struct {
+	KeyID       string
+	ShareEpoch  int
+	Status      string
+	Nodes       []*NodeShareValidation
+	ValidatedAt time.Time
+}
+
+// KeyRefresh 密钥分片刷新记录
+type KeyRefresh struct {
+	RefreshID   string
+	KeyID       string
+	Status      string // storage.KeyRefreshStatusCompleted 或 storage.KeyRefreshStatusFailed
+	OldEpoch    int
+	NewEpoch    int
+	Error       string
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
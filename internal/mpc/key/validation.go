@@ -0,0 +1,195 @@
+package key
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	pb "github.com/kashguard/go-mpc-wallet/internal/pb/mpc/v1"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// ValidateKeyShares 校验密钥当前委员会所有节点的分片一致性，并记录结果
+// 每个节点对协调者的随机挑战返回公开分片、本地保存的公开分片向量（BigXj）和 Schnorr 知识证明；
+// 协调者检查各节点的向量一致且插值等于公钥、节点公开分片与向量一致，一致的分片插值等于公钥时密钥仍可签名
+func (s *DKGService) ValidateKeyShares(ctx context.Context, keyID string) (*KeyShareValidation, error) {
+	if s.grpcClient == nil {
+		return nil, errors.New("share validation requires grpc client")
+	}
+
+	keyMeta, err := s.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	if !hasKeyShares(keyMeta.Status) {
+		return nil, errors.Wrapf(ErrInvalidKeyState, "key %s has no key shares (status: %s)", keyID, keyMeta.Status)
+	}
+	groupPublicKey, err := hex.DecodeString(keyMeta.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode public key")
+	}
+	nodeIDs, err := s.committeeNodeIDs(ctx, keyMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := make([]byte, protocol.ShareProofChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, errors.Wrap(err, "failed to generate challenge")
+	}
+	req := &pb.ProveKeyShareRequest{
+		KeyId:      keyID,
+		ShareEpoch: int32(keyMeta.ShareEpoch),
+		Challenge:  challenge,
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	results := make(map[string]*NodeShareValidation, len(nodeIDs))
+	proofs := make([]*protocol.ShareProof, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+			proof, result := s.proveKeyShare(rpcCtx, keyMeta, nodeID, req, groupPublicKey)
+			mu.Lock()
+			results[nodeID] = result
+			if proof != nil {
+				proofs = append(proofs, proof)
+			}
+			mu.Unlock()
+		}(nodeID)
+	}
+	wg.Wait()
+
+	validation := &KeyShareValidation{
+		KeyID:       keyID,
+		ShareEpoch:  keyMeta.ShareEpoch,
+		Status:      ShareValidationBroken,
+		ValidatedAt: time.Now(),
+	}
+	if len(proofs) > 0 {
+		check, err := protocol.CheckShareProofs(proofs, groupPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, nodeID := range check.InconsistentNodeIDs {
+			results[nodeID].Status = NodeShareInconsistent
+			if !check.VectorInterpolates {
+				results[nodeID].Error = "public share vector does not interpolate to the public key"
+			} else {
+				results[nodeID].Error = "public share does not match the other nodes"
+			}
+		}
+		switch {
+		case check.Recoverable && len(check.ConsistentNodeIDs) == len(nodeIDs):
+			validation.Status = ShareValidationHealthy
+		case check.Recoverable:
+			validation.Status = ShareValidationDegraded
+		}
+	}
+
+	for _, nodeID := range nodeIDs {
+		validation.Nodes = append(validation.Nodes, results[nodeID])
+	}
+	sort.Slice(validation.Nodes, func(i, j int) bool { return validation.Nodes[i].NodeID < validation.Nodes[j].NodeID })
+
+	if err := s.saveKeyShareValidation(ctx, validation); err != nil {
+		return nil, err
+	}
+
+	logEvent := log.Info()
+	if validation.Status != ShareValidationHealthy {
+		logEvent = log.Warn()
+	}
+	logEvent.
+		Str("key_id", keyID).
+		Int("share_epoch", keyMeta.ShareEpoch).
+		Str("status", validation.Status).
+		Msg("ValidateKeyShares: key share validation completed")
+
+	return validation, nil
+}
+
+// proveKeyShare 请求单个节点的分片持有证明并校验证明本身；证明有效时返回证明，一致性由 CheckShareProofs 统一判断
+func (s *DKGService) proveKeyShare(ctx context.Context, keyMeta *storage.KeyMetadata, nodeID string, req *pb.ProveKeyShareRequest, groupPublicKey []byte) (*protocol.ShareProof, *NodeShareValidation) {
+	result := &NodeShareValidation{NodeID: nodeID, Status: NodeShareUnreachable}
+
+	resp, err := s.grpcClient.SendProveKeyShare(ctx, nodeID, req)
+	if err != nil {
+		result.Error = err.Error()
+		return nil, result
+	}
+	if !resp.Success {
+		result.Error = resp.Message
+		return nil, result
+	}
+
+	result.Status = NodeShareInvalidProof
+	var proof protocol.ShareProof
+	if err := json.Unmarshal(resp.Proof, &proof); err != nil {
+		result.Error = "failed to unmarshal share proof"
+		return nil, result
+	}
+	result.PublicShare = hex.EncodeToString(proof.PublicShare)
+	if proof.KeyID != keyMeta.KeyID || proof.NodeID != nodeID || proof.ShareEpoch != keyMeta.ShareEpoch {
+		result.Error = "proof is for a different key, node or share epoch"
+		return nil, result
+	}
+	if string(proof.GroupPublicKey) != string(groupPublicKey) {
+		result.Status = NodeShareInconsistent
+		result.Error = "share belongs to a different public key"
+		return nil, result
+	}
+	if err := proof.Verify(req.Challenge); err != nil {
+		result.Error = err.Error()
+		return nil, result
+	}
+
+	result.Status = NodeShareValid
+	return &proof, result
+}
+
+// GetKeyShareValidation 查询密钥最近一次分片一致性校验结果，从未校验过时返回 nil
+func (s *DKGService) GetKeyShareValidation(ctx context.Context, keyID string) (*KeyShareValidation, error) {
+	record, err := s.metadataStore.GetKeyShareValidation(ctx, keyID)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	var nodes []*NodeShareValidation
+	if err := json.Unmarshal(record.Nodes, &nodes); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal key share validation nodes")
+	}
+	return &KeyShareValidation{
+		KeyID:       record.KeyID,
+		ShareEpoch:  record.ShareEpoch,
+		Status:      record.Status,
+		Nodes:       nodes,
+		ValidatedAt: record.ValidatedAt,
+	}, nil
+}
+
+func (s *DKGService) saveKeyShareValidation(ctx context.Context, validation *KeyShareValidation) error {
+	nodes, err := json.Marshal(validation.Nodes)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal key share validation nodes")
+	}
+	return s.metadataStore.SaveKeyShareValidation(ctx, &storage.KeyShareValidation{
+		KeyID:       validation.KeyID,
+		ShareEpoch:  validation.ShareEpoch,
+		Status:      validation.Status,
+		Nodes:       nodes,
+		ValidatedAt: validation.ValidatedAt,
+	})
+}
@@ -73,6 +73,12 @@ func (d *Discovery) DiscoverNodes(ctx context.Context, nodeType NodeType, status
 			Int("required_nodes", limit).
 			Msg("Discovered participants from Consul")
 
+		// Consul 只反映服务存活，被标记为 faulty 的节点仍会注册在 Consul 中，需要排除
+		faulty, err := d.manager.faultyNodeIDs(ctx, nodeType)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list faulty nodes")
+		}
+
 		// 4. 转换 discovery.ServiceInfo → node.Node
 		consulNodes := make([]*Node, 0, len(services))
 		for _, svc := range services {
@@ -85,6 +91,12 @@ func (d *Discovery) DiscoverNodes(ctx context.Context, nodeType NodeType, status
 					Msg("Failed to extract node ID from service, skipping")
 				continue
 			}
+			if _, ok := faulty[nodeID]; ok {
+				log.Warn().
+					Str("node_id", nodeID).
+					Msg("Skipping faulty node discovered from Consul")
+				continue
+			}
 
 			// 构建 endpoint
 			endpoint := fmt.Sprintf("%s:%d", svc.Address, svc.Port)
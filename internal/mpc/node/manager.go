@@ -4,21 +4,28 @@ import (
 	"context"
 	"time"
 
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 )
 
 // Manager 节点管理器
 type Manager struct {
 	metadataStore     storage.MetadataStore
 	heartbeatInterval time.Duration
+	// faultThreshold 故障评分达到该值时自动将节点标记为 faulty（0 表示不自动标记）
+	faultThreshold int
+	auditLogger    *audit.Logger
 }
 
 // NewManager 创建节点管理器
-func NewManager(metadataStore storage.MetadataStore, heartbeatInterval time.Duration) *Manager {
+func NewManager(metadataStore storage.MetadataStore, heartbeatInterval time.Duration, faultThreshold int, auditLogger *audit.Logger) *Manager {
 	return &Manager{
 		metadataStore:     metadataStore,
 		heartbeatInterval: heartbeatInterval,
+		faultThreshold:    faultThreshold,
+		auditLogger:       auditLogger,
 	}
 }
 
@@ -35,7 +42,22 @@ func (m *Manager) RegisterNode(ctx context.Context, node *Node) error {
 		RegisteredAt: node.RegisteredAt,
 	}
 
-	if err := m.metadataStore.SaveNode(ctx, nodeInfo); err != nil {
+	err := m.metadataStore.SaveNode(ctx, nodeInfo)
+	event := &audit.Event{
+		EventType: audit.EventTypeNode,
+		Operation: audit.OperationRegisterNode,
+		Result:    audit.ResultOf(err),
+		NodeID:    node.NodeID,
+		Details: map[string]interface{}{
+			"node_type": node.NodeType,
+			"endpoint":  node.Endpoint,
+		},
+	}
+	if err != nil {
+		event.Details["error"] = err.Error()
+	}
+	m.auditLogger.Record(ctx, event)
+	if err != nil {
 		return errors.Wrap(err, "failed to register node")
 	}
 
@@ -59,6 +81,7 @@ func (m *Manager) GetNode(ctx context.Context, nodeID string) (*Node, error) {
 		Metadata:      nodeInfo.Metadata,
 		RegisteredAt:  nodeInfo.RegisteredAt,
 		LastHeartbeat: nodeInfo.LastHeartbeat,
+		FaultScore:    nodeInfo.FaultScore,
 	}, nil
 }
 
@@ -81,6 +104,7 @@ func (m *Manager) ListNodes(ctx context.Context, filter *storage.NodeFilter) ([]
 			Metadata:      nodeInfo.Metadata,
 			RegisteredAt:  nodeInfo.RegisteredAt,
 			LastHeartbeat: nodeInfo.LastHeartbeat,
+			FaultScore:    nodeInfo.FaultScore,
 		}
 	}
 
@@ -111,9 +135,81 @@ func (m *Manager) UpdateNodeStatus(ctx context.Context, nodeID string, status No
 		return errors.Wrap(err, "failed to update node status")
 	}
 
+	// 节点被手动恢复为 active 时清零故障评分，避免下一次故障立即再次触发阈值
+	if status == NodeStatusActive && node.FaultScore > 0 {
+		if err := m.ResetFaultScore(ctx, nodeID); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// RecordFault 记录节点在某个会话中被判定为中止责任方，评分达到阈值时将节点标记为 faulty
+// 同一会话对同一节点的重复上报只计一次，返回最新故障评分
+func (m *Manager) RecordFault(ctx context.Context, nodeID string, sessionID string, reason string) (int, error) {
+	score, err := m.metadataStore.RecordNodeFault(ctx, nodeID, sessionID, reason)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to record fault for node %s", nodeID)
+	}
+
+	log.Warn().
+		Str("node_id", nodeID).
+		Str("session_id", sessionID).
+		Str("reason", reason).
+		Int("fault_score", score).
+		Int("fault_threshold", m.faultThreshold).
+		Msg("Recorded node fault")
+
+	if m.faultThreshold <= 0 || score < m.faultThreshold {
+		return score, nil
+	}
+
+	node, err := m.GetNode(ctx, nodeID)
+	if err != nil {
+		return score, errors.Wrap(err, "failed to get node")
+	}
+	if node.Status == string(NodeStatusFaulty) {
+		return score, nil
+	}
+	if err := m.UpdateNodeStatus(ctx, nodeID, NodeStatusFaulty); err != nil {
+		return score, errors.Wrapf(err, "failed to mark node %s as faulty", nodeID)
+	}
+
+	log.Error().
+		Str("node_id", nodeID).
+		Int("fault_score", score).
+		Int("fault_threshold", m.faultThreshold).
+		Msg("Node marked as faulty and excluded from participant selection")
+
+	return score, nil
+}
+
+// ResetFaultScore 清零节点故障评分
+func (m *Manager) ResetFaultScore(ctx context.Context, nodeID string) error {
+	if err := m.metadataStore.ResetNodeFaultScore(ctx, nodeID); err != nil {
+		return errors.Wrap(err, "failed to reset fault score")
+	}
+	return nil
+}
+
+// faultyNodeIDs 返回被标记为 faulty 的节点 ID 集合
+func (m *Manager) faultyNodeIDs(ctx context.Context, nodeType NodeType) (map[string]struct{}, error) {
+	nodes, err := m.ListNodes(ctx, &storage.NodeFilter{
+		NodeType: string(nodeType),
+		Status:   string(NodeStatusFaulty),
+		Limit:    1000,
+	})
+	if err != nil {
+		return nil, err
+	}
+	faulty := make(map[string]struct{}, len(nodes))
+	for _, n := range nodes {
+		faulty[n.NodeID] = struct{}{}
+	}
+	return faulty, nil
+}
+
 // UpdateHeartbeat 更新节点心跳
 func (m *Manager) UpdateHeartbeat(ctx context.Context, nodeID string) error {
 	if err := m.metadataStore.UpdateNodeHeartbeat(ctx, nodeID); err != nil {
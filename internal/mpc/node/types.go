@@ -13,6 +13,7 @@ type Node struct {
 	Metadata      map[string]interface{}
 	RegisteredAt  time.Time
 	LastHeartbeat *time.Time
+	FaultScore    int // 被判定为协议中止责任方的累计次数
 }
 
 // NodeStatus 节点状态
@@ -0,0 +1,341 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// Engine 交易策略引擎：签名前按密钥和链加载规则评估请求，并记录每次决策
+type Engine struct {
+	metadataStore storage.MetadataStore
+	enabled       bool
+	auditLogger   *audit.Logger
+	now           func() time.Time
+}
+
+// NewEngine 创建策略引擎，enabled 为 false 时签名流程不做策略检查；规则的创建和删除记录到审计日志
+func NewEngine(metadataStore storage.MetadataStore, enabled bool, auditLogger *audit.Logger) *Engine {
+	return &Engine{
+		metadataStore: metadataStore,
+		enabled:       enabled,
+		auditLogger:   auditLogger,
+		now:           time.Now,
+	}
+}
+
+// Enabled 策略检查是否启用（引擎为空时视为未启用）
+func (e *Engine) Enabled() bool {
+	return e != nil && e.enabled
+}
+
+// Evaluate 评估签名请求并记录决策：任一 deny 规则命中即拒绝，否则任一 require_approval 规则命中即要求审批
+// （请求已通过审批时允许），否则允许。交易签名未能解析时，对该密钥生效的交易规则一律视为命中
+func (e *Engine) Evaluate(ctx context.Context, req *Request) (*Decision, error) {
+	rules, err := e.ListRules(ctx, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := e.now().UTC()
+	var denied, approval *matchedRule
+	for _, rule := range rules {
+		if !rule.appliesTo(req) || (rule.transactional() && !req.Transactional) {
+			continue
+		}
+		reason, err := e.evaluateRule(ctx, rule, req, now)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate policy rule %s", rule.RuleID)
+		}
+		if reason == "" {
+			continue
+		}
+		if rule.Action == DecisionDeny && denied == nil {
+			denied = &matchedRule{rule: rule, reason: reason}
+		}
+		if rule.Action == DecisionRequireApproval && approval == nil {
+			approval = &matchedRule{rule: rule, reason: reason}
+		}
+	}
+
+	decision := &Decision{
+		DecisionID:  "decision-" + uuid.New().String(),
+		KeyID:       req.KeyID,
+		ChainType:   req.ChainType,
+		MessageType: req.MessageType,
+		Decision:    DecisionAllow,
+		TxHash:      req.TxHash,
+		CreatedAt:   now,
+	}
+	for _, matched := range []*matchedRule{denied, approval} {
+		if matched != nil {
+			decision.Decision = matched.rule.Action
+			decision.RuleID = matched.rule.RuleID
+			decision.Reason = matched.reason
+			break
+		}
+	}
+	// 已审批的请求按 allow 记录，计入滚动限额
+	if decision.Decision == DecisionRequireApproval && req.ApprovalID != "" {
+		decision.Decision = DecisionAllow
+		decision.Reason = fmt.Sprintf("approved by signing approval %s: %s", req.ApprovalID, decision.Reason)
+	}
+
+	if err := e.saveDecision(ctx, decision, req.Transaction); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("key_id", decision.KeyID).
+		Str("decision_id", decision.DecisionID).
+		Str("decision", decision.Decision).
+		Str("rule_id", decision.RuleID).
+		Str("reason", decision.Reason).
+		Msg("Policy decision recorded")
+
+	return decision, nil
+}
+
+// CreateRule 校验并保存规则
+func (e *Engine) CreateRule(ctx context.Context, rule *Rule) (*Rule, error) {
+	created, err := e.createRule(ctx, rule)
+	details := map[string]interface{}{
+		"rule_type":  rule.RuleType,
+		"action":     rule.Action,
+		"chain_type": rule.ChainType,
+	}
+	if created != nil {
+		details["rule_id"] = created.RuleID
+	}
+	e.recordRuleEvent(ctx, audit.OperationCreatePolicyRule, rule.KeyID, details, err)
+	return created, err
+}
+
+func (e *Engine) createRule(ctx context.Context, rule *Rule) (*Rule, error) {
+	if err := ValidateRule(rule); err != nil {
+		return nil, err
+	}
+	if rule.KeyID != "" {
+		keyMetadata, err := e.metadataStore.GetKeyMetadata(ctx, rule.KeyID)
+		if err != nil || keyMetadata == nil {
+			return nil, errors.Errorf("key %s not found", rule.KeyID)
+		}
+	}
+
+	now := e.now().UTC()
+	rule.RuleID = "rule-" + uuid.New().String()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	stored, err := toStorageRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.metadataStore.SavePolicyRule(ctx, stored); err != nil {
+		return nil, errors.Wrap(err, "failed to save policy rule")
+	}
+	return rule, nil
+}
+
+// GetRule 获取规则，不存在时返回错误
+func (e *Engine) GetRule(ctx context.Context, ruleID string) (*Rule, error) {
+	stored, err := e.metadataStore.GetPolicyRule(ctx, ruleID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get policy rule")
+	}
+	if stored == nil {
+		return nil, errors.Errorf("policy rule %s not found", ruleID)
+	}
+	return fromStorageRule(stored)
+}
+
+// ListRules 列出对密钥生效的规则（包括全局规则），keyID 为空时列出所有规则
+func (e *Engine) ListRules(ctx context.Context, keyID string) ([]*Rule, error) {
+	stored, err := e.metadataStore.ListPolicyRules(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list policy rules")
+	}
+	rules := make([]*Rule, 0, len(stored))
+	for _, s := range stored {
+		rule, err := fromStorageRule(s)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// DeleteRule 删除规则
+func (e *Engine) DeleteRule(ctx context.Context, ruleID string) error {
+	rule, err := e.GetRule(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+	err = e.metadataStore.DeletePolicyRule(ctx, ruleID)
+	if err != nil {
+		err = errors.Wrap(err, "failed to delete policy rule")
+	}
+	e.recordRuleEvent(ctx, audit.OperationDeletePolicyRule, rule.KeyID, map[string]interface{}{
+		"rule_id":    rule.RuleID,
+		"rule_type":  rule.RuleType,
+		"action":     rule.Action,
+		"chain_type": rule.ChainType,
+	}, err)
+	return err
+}
+
+// recordRuleEvent 记录规则变更的审计事件
+func (e *Engine) recordRuleEvent(ctx context.Context, operation string, keyID string, details map[string]interface{}, err error) {
+	if err != nil {
+		details["error"] = err.Error()
+	}
+	e.auditLogger.Record(ctx, &audit.Event{
+		EventType: audit.EventTypePolicy,
+		Operation: operation,
+		Result:    audit.ResultOf(err),
+		KeyID:     keyID,
+		Details:   details,
+	})
+}
+
+// matchedRule 命中的规则及原因
+type matchedRule struct {
+	rule   *Rule
+	reason string
+}
+
+// evaluateRule 评估单条规则，返回命中原因，未命中时返回空字符串
+func (e *Engine) evaluateRule(ctx context.Context, rule *Rule, req *Request, now time.Time) (string, error) {
+	if !rule.transactional() {
+		return checkTimeWindow(rule, now)
+	}
+	if req.Transaction == nil {
+		return "transaction could not be decoded for policy evaluation", nil
+	}
+	if rule.RuleType != RuleTypeVelocityLimit {
+		return checkTransaction(rule, req.Transaction)
+	}
+
+	maxAmount, err := parseAmount(rule.Params.MaxAmount)
+	if err != nil {
+		return "", err
+	}
+	window := DefaultVelocityWindow
+	if rule.Params.WindowHours > 0 {
+		window = time.Duration(rule.Params.WindowHours) * time.Hour
+	}
+	current := transferTotal(req.Transaction, rule.Params.Asset)
+	if current.Sign() == 0 {
+		return "", nil
+	}
+	spent, err := e.metadataStore.SumAllowedTransfers(ctx, req.KeyID, req.ChainType, rule.Params.Asset, now.Add(-window), req.TxHash)
+	if err != nil {
+		return "", err
+	}
+	total, ok := new(big.Int).SetString(spent, 10)
+	if !ok {
+		return "", errors.Errorf("invalid transfer total %q", spent)
+	}
+	total.Add(total, current)
+	if total.Cmp(maxAmount) > 0 {
+		return fmt.Sprintf("transfers of %s within %s would reach %s, exceeding limit %s", assetName(rule.Params.Asset), window, total, maxAmount), nil
+	}
+	return "", nil
+}
+
+// transferRecord 决策记录中的资产转移，用于滚动限额统计
+type transferRecord struct {
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+	Asset  string `json:"asset"`
+}
+
+// saveDecision 记录决策及交易中的资产转移，手续费记为无接收方的链原生资产转移
+func (e *Engine) saveDecision(ctx context.Context, decision *Decision, tx *chain.DecodedTransaction) error {
+	records := []*transferRecord{}
+	if tx != nil {
+		if tx.Fee != nil && tx.Fee.Sign() > 0 {
+			records = append(records, &transferRecord{Amount: tx.Fee.String()})
+		}
+		for _, transfer := range tx.Transfers {
+			if transfer.Amount == nil {
+				continue
+			}
+			records = append(records, &transferRecord{To: transfer.To, Amount: transfer.Amount.String(), Asset: transfer.Asset})
+		}
+	}
+	transfers, err := json.Marshal(records)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal transfers")
+	}
+
+	if err := e.metadataStore.SavePolicyDecision(ctx, &storage.PolicyDecision{
+		DecisionID:  decision.DecisionID,
+		KeyID:       decision.KeyID,
+		ChainType:   decision.ChainType,
+		MessageType: decision.MessageType,
+		Decision:    decision.Decision,
+		RuleID:      decision.RuleID,
+		Reason:      decision.Reason,
+		Transfers:   transfers,
+		TxHash:      decision.TxHash,
+		CreatedAt:   decision.CreatedAt,
+	}); err != nil {
+		return errors.Wrap(err, "failed to save policy decision")
+	}
+	return nil
+}
+
+func toStorageRule(rule *Rule) (*storage.PolicyRule, error) {
+	params := rule.Params
+	if params == nil {
+		params = &RuleParams{}
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal rule params")
+	}
+	return &storage.PolicyRule{
+		RuleID:      rule.RuleID,
+		KeyID:       rule.KeyID,
+		ChainType:   rule.ChainType,
+		RuleType:    rule.RuleType,
+		Action:      rule.Action,
+		Params:      paramsJSON,
+		Enabled:     rule.Enabled,
+		Description: rule.Description,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}, nil
+}
+
+func fromStorageRule(stored *storage.PolicyRule) (*Rule, error) {
+	params := &RuleParams{}
+	if len(stored.Params) > 0 {
+		if err := json.Unmarshal(stored.Params, params); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal params of policy rule %s", stored.RuleID)
+		}
+	}
+	return &Rule{
+		RuleID:      stored.RuleID,
+		KeyID:       stored.KeyID,
+		ChainType:   stored.ChainType,
+		RuleType:    stored.RuleType,
+		Action:      stored.Action,
+		Params:      params,
+		Enabled:     stored.Enabled,
+		Description: stored.Description,
+		CreatedAt:   stored.CreatedAt,
+		UpdatedAt:   stored.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,289 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore 只实现策略相关方法的内存存储
+type memoryStore struct {
+	storage.MetadataStore
+	rules     []*storage.PolicyRule
+	decisions []*storage.PolicyDecision
+	auditLogs []*storage.AuditLog
+}
+
+func (m *memoryStore) SavePolicyRule(_ context.Context, rule *storage.PolicyRule) error {
+	m.rules = append(m.rules, rule)
+	return nil
+}
+
+func (m *memoryStore) GetPolicyRule(_ context.Context, ruleID string) (*storage.PolicyRule, error) {
+	for _, rule := range m.rules {
+		if rule.RuleID == ruleID {
+			return rule, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryStore) DeletePolicyRule(_ context.Context, ruleID string) error {
+	for i, rule := range m.rules {
+		if rule.RuleID == ruleID {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) AppendAuditLog(_ context.Context, entry *storage.AuditLog, _ func(entry *storage.AuditLog) (string, error)) error {
+	m.auditLogs = append(m.auditLogs, entry)
+	return nil
+}
+
+func (m *memoryStore) ListPolicyRules(_ context.Context, keyID string) ([]*storage.PolicyRule, error) {
+	var rules []*storage.PolicyRule
+	for _, rule := range m.rules {
+		if keyID == "" || rule.KeyID == "" || rule.KeyID == keyID {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (m *memoryStore) SavePolicyDecision(_ context.Context, decision *storage.PolicyDecision) error {
+	m.decisions = append(m.decisions, decision)
+	return nil
+}
+
+func (m *memoryStore) SumAllowedTransfers(_ context.Context, keyID string, chainType string, asset string, since time.Time, excludeTxHash string) (string, error) {
+	total := new(big.Int)
+	counted := map[string]bool{}
+	for _, decision := range m.decisions {
+		if decision.KeyID != keyID || decision.ChainType != chainType || decision.Decision != DecisionAllow || decision.CreatedAt.Before(since) {
+			continue
+		}
+		if decision.TxHash != "" && (decision.TxHash == excludeTxHash || counted[decision.TxHash]) {
+			continue
+		}
+		counted[decision.TxHash] = true
+		var records []*transferRecord
+		if err := json.Unmarshal(decision.Transfers, &records); err != nil {
+			return "", err
+		}
+		for _, record := range records {
+			if sameAddress(record.Asset, asset) {
+				amount, _ := new(big.Int).SetString(record.Amount, 10)
+				total.Add(total, amount)
+			}
+		}
+	}
+	return total.String(), nil
+}
+
+func (m *memoryStore) addRule(t *testing.T, rule *Rule) {
+	t.Helper()
+	rule.Enabled = true
+	stored, err := toStorageRule(rule)
+	require.NoError(t, err)
+	m.rules = append(m.rules, stored)
+}
+
+func transferRequest(amount int64) *Request {
+	return &Request{
+		KeyID:         "key-1",
+		ChainType:     "ethereum",
+		MessageType:   "transaction",
+		Transactional: true,
+		Transaction: &chain.DecodedTransaction{
+			Transfers: []*chain.Transfer{{To: testRecipient, Amount: big.NewInt(amount)}},
+		},
+	}
+}
+
+// TestEngine_Evaluate deny 优先于 require_approval，每次决策都被记录
+func TestEngine_Evaluate(t *testing.T) {
+	store := &memoryStore{}
+	store.addRule(t, &Rule{RuleID: "rule-approval", RuleType: RuleTypeAmountLimit, Action: DecisionRequireApproval, Params: &RuleParams{MaxAmount: "100"}})
+	store.addRule(t, &Rule{RuleID: "rule-deny", KeyID: "key-1", RuleType: RuleTypeAmountLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "1000"}})
+	store.addRule(t, &Rule{RuleID: "rule-other-key", KeyID: "key-2", RuleType: RuleTypeAmountLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "0"}})
+	store.addRule(t, &Rule{RuleID: "rule-other-chain", ChainType: "solana", RuleType: RuleTypeAmountLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "0"}})
+	engine := NewEngine(store, true, nil)
+	ctx := context.Background()
+
+	decision, err := engine.Evaluate(ctx, transferRequest(50))
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed())
+	assert.Empty(t, decision.RuleID)
+
+	decision, err = engine.Evaluate(ctx, transferRequest(500))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionRequireApproval, decision.Decision)
+	assert.Equal(t, "rule-approval", decision.RuleID)
+
+	decision, err = engine.Evaluate(ctx, transferRequest(5000))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionDeny, decision.Decision)
+	assert.Equal(t, "rule-deny", decision.RuleID)
+	assert.NotEmpty(t, decision.Reason)
+
+	require.Len(t, store.decisions, 3)
+	assert.Equal(t, []string{DecisionAllow, DecisionRequireApproval, DecisionDeny},
+		[]string{store.decisions[0].Decision, store.decisions[1].Decision, store.decisions[2].Decision})
+	assert.JSONEq(t, `[{"to":"`+testRecipient+`","amount":"5000","asset":""}]`, string(store.decisions[2].Transfers))
+}
+
+// TestEngine_RuleChangesAudited 规则的创建和删除记录到审计日志
+func TestEngine_RuleChangesAudited(t *testing.T) {
+	store := &memoryStore{}
+	engine := NewEngine(store, true, audit.NewLogger(store, true))
+	ctx := context.Background()
+
+	rule, err := engine.CreateRule(ctx, &Rule{RuleType: RuleTypeAmountLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "100"}})
+	require.NoError(t, err)
+	require.NoError(t, engine.DeleteRule(ctx, rule.RuleID))
+	assert.Empty(t, store.rules)
+
+	require.Len(t, store.auditLogs, 2)
+	for i, operation := range []string{audit.OperationCreatePolicyRule, audit.OperationDeletePolicyRule} {
+		entry := store.auditLogs[i]
+		assert.Equal(t, audit.EventTypePolicy, entry.EventType)
+		assert.Equal(t, operation, entry.Operation)
+		assert.Equal(t, audit.ResultSuccess, entry.Result)
+		assert.Contains(t, string(entry.Details), rule.RuleID)
+	}
+
+	// 无效规则的创建失败也会记录
+	_, err = engine.CreateRule(ctx, &Rule{RuleType: RuleTypeAmountLimit, Action: "allow"})
+	require.Error(t, err)
+	require.Len(t, store.auditLogs, 3)
+	assert.Equal(t, audit.ResultFailure, store.auditLogs[2].Result)
+}
+
+// TestEngine_EvaluateNonTransactional 消息签名只检查时间段规则，交易未能解析时交易规则命中
+func TestEngine_EvaluateNonTransactional(t *testing.T) {
+	store := &memoryStore{}
+	store.addRule(t, &Rule{RuleID: "rule-allowlist", RuleType: RuleTypeAllowlist, Action: DecisionDeny, Params: &RuleParams{Addresses: []string{testRecipient}}})
+	engine := NewEngine(store, true, nil)
+	ctx := context.Background()
+
+	decision, err := engine.Evaluate(ctx, &Request{KeyID: "key-1", ChainType: "ethereum", MessageType: "message"})
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed())
+
+	decision, err = engine.Evaluate(ctx, &Request{KeyID: "key-1", ChainType: "ethereum", MessageType: "transaction", Transactional: true})
+	require.NoError(t, err)
+	assert.Equal(t, DecisionDeny, decision.Decision)
+	assert.Equal(t, "rule-allowlist", decision.RuleID)
+
+	store.addRule(t, &Rule{RuleID: "rule-closed", RuleType: RuleTypeTimeWindow, Action: DecisionDeny, Params: &RuleParams{StartTime: "09:00", EndTime: "17:00"}})
+	engine.now = func() time.Time { return time.Date(2026, 1, 7, 20, 0, 0, 0, time.UTC) }
+	decision, err = engine.Evaluate(ctx, &Request{KeyID: "key-1", ChainType: "ethereum", MessageType: "message"})
+	require.NoError(t, err)
+	assert.Equal(t, "rule-closed", decision.RuleID)
+}
+
+// TestEngine_EvaluateVelocity 滚动窗口内已允许的转账计入限额，窗口外的不计入
+func TestEngine_EvaluateVelocity(t *testing.T) {
+	store := &memoryStore{}
+	store.addRule(t, &Rule{RuleID: "rule-velocity", RuleType: RuleTypeVelocityLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "1000"}})
+	engine := NewEngine(store, true, nil)
+	ctx := context.Background()
+	now := time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC)
+	engine.now = func() time.Time { return now }
+
+	for _, amount := range []int64{400, 400} {
+		decision, err := engine.Evaluate(ctx, transferRequest(amount))
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed())
+	}
+
+	decision, err := engine.Evaluate(ctx, transferRequest(300))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionDeny, decision.Decision)
+
+	// 被拒绝的请求不计入，200 恰好达到限额
+	decision, err = engine.Evaluate(ctx, transferRequest(200))
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed())
+
+	now = now.Add(DefaultVelocityWindow + time.Minute)
+	decision, err = engine.Evaluate(ctx, transferRequest(1000))
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed())
+}
+
+// TestEngine_EvaluateVelocityFee 交易手续费计入链原生资产的滚动限额
+func TestEngine_EvaluateVelocityFee(t *testing.T) {
+	store := &memoryStore{}
+	store.addRule(t, &Rule{RuleID: "rule-velocity", RuleType: RuleTypeVelocityLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "1000"}})
+	engine := NewEngine(store, true, nil)
+	ctx := context.Background()
+
+	req := transferRequest(100)
+	req.Transaction.Fee = big.NewInt(800)
+	decision, err := engine.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed())
+	assert.JSONEq(t, `[{"to":"","amount":"800","asset":""},{"to":"`+testRecipient+`","amount":"100","asset":""}]`, string(store.decisions[0].Transfers))
+
+	decision, err = engine.Evaluate(ctx, transferRequest(200))
+	require.NoError(t, err)
+	assert.Equal(t, DecisionDeny, decision.Decision)
+}
+
+// TestEngine_EvaluateVelocitySameTransaction 同一交易的多次签名（Bitcoin 各输入）只计一次
+func TestEngine_EvaluateVelocitySameTransaction(t *testing.T) {
+	store := &memoryStore{}
+	store.addRule(t, &Rule{RuleID: "rule-velocity", RuleType: RuleTypeVelocityLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "1000"}})
+	engine := NewEngine(store, true, nil)
+	ctx := context.Background()
+
+	for _, txHash := range []string{"tx-1", "tx-1", "tx-1", "tx-2"} {
+		req := transferRequest(500)
+		req.TxHash = txHash
+		decision, err := engine.Evaluate(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed(), txHash)
+	}
+
+	req := transferRequest(1)
+	req.TxHash = "tx-3"
+	decision, err := engine.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, DecisionDeny, decision.Decision)
+}
+
+// TestEngine_EvaluateApproved 已审批的请求满足 require_approval 规则并计入滚动限额，deny 规则仍然生效
+func TestEngine_EvaluateApproved(t *testing.T) {
+	store := &memoryStore{}
+	store.addRule(t, &Rule{RuleID: "rule-approval", RuleType: RuleTypeAmountLimit, Action: DecisionRequireApproval, Params: &RuleParams{MaxAmount: "100"}})
+	store.addRule(t, &Rule{RuleID: "rule-velocity", RuleType: RuleTypeVelocityLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "1000"}})
+	engine := NewEngine(store, true, nil)
+	ctx := context.Background()
+
+	req := transferRequest(600)
+	req.ApprovalID = "approval-1"
+	decision, err := engine.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed())
+	assert.Equal(t, "rule-approval", decision.RuleID)
+	assert.Contains(t, decision.Reason, "approval-1")
+
+	req = transferRequest(600)
+	req.ApprovalID = "approval-2"
+	decision, err = engine.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, DecisionDeny, decision.Decision)
+	assert.Equal(t, "rule-velocity", decision.RuleID)
+}
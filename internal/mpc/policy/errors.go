@@ -0,0 +1,23 @@
+package policy
+
+import (
+	"fmt"
+)
+
+// DecisionError 策略拒绝签名或要求审批，调用方通过 errors.As 取出决策
+type DecisionError struct {
+	Decision *Decision
+}
+
+// Error 实现 error 接口
+func (e *DecisionError) Error() string {
+	if e.Decision.Decision == DecisionRequireApproval {
+		return fmt.Sprintf("signing requires approval by policy rule %s: %s", e.Decision.RuleID, e.Decision.Reason)
+	}
+	return fmt.Sprintf("signing denied by policy rule %s: %s", e.Decision.RuleID, e.Decision.Reason)
+}
+
+// RequiresApproval 决策是否为需要审批（而非直接拒绝）
+func (e *DecisionError) RequiresApproval() bool {
+	return e.Decision.Decision == DecisionRequireApproval
+}
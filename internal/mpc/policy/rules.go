@@ -0,0 +1,295 @@
+package policy
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/pkg/errors"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ValidateRule 校验规则类型、决策和参数
+func ValidateRule(rule *Rule) error {
+	if rule.Action != DecisionDeny && rule.Action != DecisionRequireApproval {
+		return errors.Errorf("invalid rule action %q (expected %s or %s)", rule.Action, DecisionDeny, DecisionRequireApproval)
+	}
+	params := rule.Params
+	if params == nil {
+		params = &RuleParams{}
+	}
+	if err := validateQuorum(rule.Action, params); err != nil {
+		return err
+	}
+
+	switch rule.RuleType {
+	case RuleTypeAmountLimit, RuleTypeVelocityLimit:
+		if _, err := parseAmount(params.MaxAmount); err != nil {
+			return errors.Wrap(err, "invalid max_amount")
+		}
+		if params.WindowHours < 0 {
+			return errors.New("window_hours must not be negative")
+		}
+	case RuleTypeAllowlist, RuleTypeDenylist:
+		if len(params.Addresses) == 0 {
+			return errors.New("addresses are required")
+		}
+	case RuleTypeContractMethod:
+		if len(params.Methods) == 0 {
+			return errors.New("methods are required")
+		}
+	case RuleTypeTimeWindow:
+		if _, err := loadLocation(params.Timezone); err != nil {
+			return err
+		}
+		if _, err := parseClock(params.StartTime); err != nil {
+			return errors.Wrap(err, "invalid start_time")
+		}
+		if _, err := parseClock(params.EndTime); err != nil {
+			return errors.Wrap(err, "invalid end_time")
+		}
+		for _, day := range params.Weekdays {
+			if _, ok := weekdays[strings.ToLower(day)]; !ok {
+				return errors.Errorf("invalid weekday %q", day)
+			}
+		}
+	default:
+		return errors.Errorf("unsupported rule type %q", rule.RuleType)
+	}
+	return nil
+}
+
+// validateQuorum 校验审批人和审批人数：只有 require_approval 规则可以指定，且需要的审批人数不能超过审批人数量
+func validateQuorum(action string, params *RuleParams) error {
+	if len(params.Approvers) == 0 && params.RequiredApprovals == 0 {
+		return nil
+	}
+	if action != DecisionRequireApproval {
+		return errors.Errorf("approvers are only allowed for %s rules", DecisionRequireApproval)
+	}
+	return ValidateQuorum(params.Approvers, params.RequiredApprovals)
+}
+
+// ValidateQuorum 校验审批法定人数（M-of-N）：审批人不能重复，1 <= M <= N
+func ValidateQuorum(approvers []string, requiredApprovals int) error {
+	if len(approvers) == 0 {
+		return errors.New("approvers are required")
+	}
+	seen := make(map[string]bool, len(approvers))
+	for _, approver := range approvers {
+		if approver == "" || seen[approver] {
+			return errors.Errorf("invalid or duplicate approver %q", approver)
+		}
+		seen[approver] = true
+	}
+	if requiredApprovals < 1 || requiredApprovals > len(approvers) {
+		return errors.Errorf("required_approvals must be between 1 and %d", len(approvers))
+	}
+	return nil
+}
+
+// appliesTo 规则是否对请求的密钥和链生效
+func (r *Rule) appliesTo(req *Request) bool {
+	if !r.Enabled {
+		return false
+	}
+	if r.KeyID != "" && r.KeyID != req.KeyID {
+		return false
+	}
+	return r.ChainType == "" || strings.EqualFold(r.ChainType, req.ChainType)
+}
+
+// transactional 规则是否需要解析后的交易
+func (r *Rule) transactional() bool {
+	return r.RuleType != RuleTypeTimeWindow
+}
+
+// checkTransaction 检查不依赖历史记录的交易规则，返回命中原因，未命中时返回空字符串
+func checkTransaction(rule *Rule, tx *chain.DecodedTransaction) (string, error) {
+	params := rule.Params
+	if params == nil {
+		params = &RuleParams{}
+	}
+
+	switch rule.RuleType {
+	case RuleTypeAmountLimit:
+		maxAmount, err := parseAmount(params.MaxAmount)
+		if err != nil {
+			return "", err
+		}
+		if total := transferTotal(tx, params.Asset); total.Cmp(maxAmount) > 0 {
+			return fmt.Sprintf("transfer amount %s of %s exceeds limit %s", total, assetName(params.Asset), maxAmount), nil
+		}
+	case RuleTypeAllowlist:
+		for _, destination := range destinations(tx) {
+			if !containsAddress(params.Addresses, destination) {
+				return fmt.Sprintf("destination %s is not in the allowlist", destination), nil
+			}
+		}
+	case RuleTypeDenylist:
+		for _, destination := range destinations(tx) {
+			if containsAddress(params.Addresses, destination) {
+				return fmt.Sprintf("destination %s is in the denylist", destination), nil
+			}
+		}
+	case RuleTypeContractMethod:
+		for _, call := range tx.Calls {
+			if len(params.Contracts) > 0 && !containsAddress(params.Contracts, call.Contract) {
+				continue
+			}
+			if !containsFold(params.Methods, call.Method) {
+				return fmt.Sprintf("method %s of contract %s is not allowed", call.Method, call.Contract), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// checkTimeWindow 检查当前时间是否在允许的时间段内，返回命中原因
+func checkTimeWindow(rule *Rule, now time.Time) (string, error) {
+	params := rule.Params
+	if params == nil {
+		params = &RuleParams{}
+	}
+	location, err := loadLocation(params.Timezone)
+	if err != nil {
+		return "", err
+	}
+	start, err := parseClock(params.StartTime)
+	if err != nil {
+		return "", err
+	}
+	end, err := parseClock(params.EndTime)
+	if err != nil {
+		return "", err
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	day := local.Weekday()
+	var inWindow bool
+	switch {
+	case start == end:
+		inWindow = true
+	case start < end:
+		inWindow = minute >= start && minute < end
+	default:
+		// 跨越午夜：午夜之后的部分属于前一天的时间段
+		inWindow = minute >= start || minute < end
+		if minute < end {
+			day = (day + 6) % 7
+		}
+	}
+	if inWindow && len(params.Weekdays) > 0 {
+		inWindow = false
+		for _, name := range params.Weekdays {
+			if weekdays[strings.ToLower(name)] == day {
+				inWindow = true
+				break
+			}
+		}
+	}
+	if !inWindow {
+		return fmt.Sprintf("signing is not allowed at %s", local.Format("Mon 15:04 MST")), nil
+	}
+	return "", nil
+}
+
+// destinations 交易的目的地址：资产接收方和被调用的合约
+func destinations(tx *chain.DecodedTransaction) []string {
+	var addresses []string
+	for _, transfer := range tx.Transfers {
+		addresses = append(addresses, transfer.To)
+	}
+	for _, call := range tx.Calls {
+		addresses = append(addresses, call.Contract)
+	}
+	return addresses
+}
+
+// transferTotal 交易中某资产的转移总量，链原生资产包含交易手续费
+func transferTotal(tx *chain.DecodedTransaction, asset string) *big.Int {
+	total := new(big.Int)
+	if asset == "" && tx.Fee != nil {
+		total.Add(total, tx.Fee)
+	}
+	for _, transfer := range tx.Transfers {
+		if sameAddress(transfer.Asset, asset) && transfer.Amount != nil {
+			total.Add(total, transfer.Amount)
+		}
+	}
+	return total
+}
+
+// sameAddress 比较地址，0x 前缀的 EVM 地址不区分大小写，其他链的地址按原样比较
+func sameAddress(a, b string) bool {
+	if strings.HasPrefix(a, "0x") || strings.HasPrefix(a, "0X") {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+func containsAddress(addresses []string, address string) bool {
+	for _, a := range addresses {
+		if sameAddress(a, address) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func assetName(asset string) string {
+	if asset == "" {
+		return "native asset"
+	}
+	return asset
+}
+
+// parseAmount 解析非负的十进制数量
+func parseAmount(value string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, errors.Errorf("amount must be a non-negative integer, got %q", value)
+	}
+	return amount, nil
+}
+
+// parseClock 解析 HH:MM，返回当天的分钟数
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, errors.Errorf("time must be in HH:MM format, got %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid timezone %q", timezone)
+	}
+	return location, nil
+}
@@ -0,0 +1,144 @@
+package policy
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testToken     = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	testRecipient = "0xb94f5374fce5edbc8e2a8697c15331677e6ebf0b"
+)
+
+func testTransaction() *chain.DecodedTransaction {
+	return &chain.DecodedTransaction{
+		Transfers: []*chain.Transfer{
+			{To: testRecipient, Amount: big.NewInt(600)},
+			{To: testRecipient, Amount: big.NewInt(1_000_000), Asset: "0xdac17f958d2ee523a2206206994597c13d831ec7"},
+		},
+		Calls: []*chain.ContractCall{
+			{Contract: "0x3535353535353535353535353535353535353535", Method: "0x095ea7b3"},
+		},
+	}
+}
+
+// TestCheckTransaction 金额、地址名单和合约方法规则，EVM 地址不区分大小写
+func TestCheckTransaction(t *testing.T) {
+	tx := testTransaction()
+	tests := []struct {
+		name    string
+		rule    *Rule
+		matched bool
+	}{
+		{"native amount within limit", &Rule{RuleType: RuleTypeAmountLimit, Params: &RuleParams{MaxAmount: "600"}}, false},
+		{"native amount over limit", &Rule{RuleType: RuleTypeAmountLimit, Params: &RuleParams{MaxAmount: "599"}}, true},
+		{"token amount over limit", &Rule{RuleType: RuleTypeAmountLimit, Params: &RuleParams{Asset: testToken, MaxAmount: "999999"}}, true},
+		{"all destinations allowed", &Rule{RuleType: RuleTypeAllowlist, Params: &RuleParams{Addresses: []string{
+			"0xB94F5374FCE5EDBC8E2A8697C15331677E6EBF0B", "0x3535353535353535353535353535353535353535",
+		}}}, false},
+		{"called contract not allowed", &Rule{RuleType: RuleTypeAllowlist, Params: &RuleParams{Addresses: []string{testRecipient}}}, true},
+		{"recipient denied", &Rule{RuleType: RuleTypeDenylist, Params: &RuleParams{Addresses: []string{"0xB94F5374FCE5EDBC8E2A8697C15331677E6EBF0B"}}}, true},
+		{"recipient not denied", &Rule{RuleType: RuleTypeDenylist, Params: &RuleParams{Addresses: []string{testToken}}}, false},
+		{"method allowed", &Rule{RuleType: RuleTypeContractMethod, Params: &RuleParams{Methods: []string{"0x095EA7B3"}}}, false},
+		{"method not allowed", &Rule{RuleType: RuleTypeContractMethod, Params: &RuleParams{Methods: []string{"0xa9059cbb"}}}, true},
+		{"other contract unrestricted", &Rule{RuleType: RuleTypeContractMethod, Params: &RuleParams{Contracts: []string{testToken}, Methods: []string{"0xa9059cbb"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, err := checkTransaction(tt.rule, tx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matched, reason != "", reason)
+		})
+	}
+}
+
+// TestCheckTransactionFee 链原生资产的金额限额包含交易手续费，代币限额和地址名单不受手续费影响
+func TestCheckTransactionFee(t *testing.T) {
+	tx := testTransaction()
+	tx.Fee = big.NewInt(50_000)
+
+	reason, err := checkTransaction(&Rule{RuleType: RuleTypeAmountLimit, Params: &RuleParams{MaxAmount: "10000"}}, tx)
+	require.NoError(t, err)
+	assert.Contains(t, reason, "50600")
+
+	reason, err = checkTransaction(&Rule{RuleType: RuleTypeAmountLimit, Params: &RuleParams{Asset: testToken, MaxAmount: "1000000"}}, tx)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+
+	reason, err = checkTransaction(&Rule{RuleType: RuleTypeAllowlist, Params: &RuleParams{Addresses: []string{
+		testRecipient, "0x3535353535353535353535353535353535353535",
+	}}}, tx)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+}
+
+// TestCheckTimeWindow 工作日白天和跨越午夜的时间段
+func TestCheckTimeWindow(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	office := &Rule{RuleType: RuleTypeTimeWindow, Params: &RuleParams{
+		Timezone:  "Asia/Shanghai",
+		StartTime: "09:00",
+		EndTime:   "18:00",
+		Weekdays:  []string{"mon", "tue", "wed", "thu", "fri"},
+	}}
+	overnight := &Rule{RuleType: RuleTypeTimeWindow, Params: &RuleParams{StartTime: "22:00", EndTime: "02:00", Weekdays: []string{"fri"}}}
+
+	tests := []struct {
+		name    string
+		rule    *Rule
+		now     time.Time
+		matched bool
+	}{
+		{"weekday office hours", office, time.Date(2026, 1, 7, 10, 30, 0, 0, shanghai), false},
+		{"weekday after hours", office, time.Date(2026, 1, 7, 18, 0, 0, 0, shanghai), true},
+		{"weekend", office, time.Date(2026, 1, 10, 10, 30, 0, 0, shanghai), true},
+		{"office hours in utc", office, time.Date(2026, 1, 7, 2, 0, 0, 0, time.UTC), false},
+		{"friday night", overnight, time.Date(2026, 1, 9, 23, 0, 0, 0, time.UTC), false},
+		{"after midnight belongs to friday", overnight, time.Date(2026, 1, 10, 1, 0, 0, 0, time.UTC), false},
+		{"thursday night", overnight, time.Date(2026, 1, 8, 23, 0, 0, 0, time.UTC), true},
+		{"friday afternoon", overnight, time.Date(2026, 1, 9, 15, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, err := checkTimeWindow(tt.rule, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matched, reason != "", reason)
+		})
+	}
+}
+
+// TestValidateRule 规则类型、决策和参数不合法时报错
+func TestValidateRule(t *testing.T) {
+	valid := []*Rule{
+		{RuleType: RuleTypeVelocityLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "1000", WindowHours: 12}},
+		{RuleType: RuleTypeTimeWindow, Action: DecisionRequireApproval, Params: &RuleParams{Timezone: "Europe/Berlin", StartTime: "08:00", EndTime: "20:00"}},
+		{RuleType: RuleTypeAmountLimit, Action: DecisionRequireApproval, Params: &RuleParams{MaxAmount: "1", Approvers: []string{"user-1", "user-2", "user-3"}, RequiredApprovals: 2}},
+	}
+	for _, rule := range valid {
+		assert.NoError(t, ValidateRule(rule), rule.RuleType)
+	}
+
+	invalid := []*Rule{
+		{RuleType: RuleTypeAmountLimit, Action: "allow", Params: &RuleParams{MaxAmount: "1"}},
+		{RuleType: "unknown", Action: DecisionDeny},
+		{RuleType: RuleTypeAmountLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "-1"}},
+		{RuleType: RuleTypeAmountLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "1.5"}},
+		{RuleType: RuleTypeAllowlist, Action: DecisionDeny},
+		{RuleType: RuleTypeContractMethod, Action: DecisionDeny, Params: &RuleParams{Contracts: []string{testToken}}},
+		{RuleType: RuleTypeTimeWindow, Action: DecisionDeny, Params: &RuleParams{StartTime: "9:00pm", EndTime: "10:00"}},
+		{RuleType: RuleTypeTimeWindow, Action: DecisionDeny, Params: &RuleParams{StartTime: "09:00", EndTime: "10:00", Weekdays: []string{"monday"}}},
+		{RuleType: RuleTypeTimeWindow, Action: DecisionDeny, Params: &RuleParams{Timezone: "Mars/Olympus", StartTime: "09:00", EndTime: "10:00"}},
+		{RuleType: RuleTypeAmountLimit, Action: DecisionDeny, Params: &RuleParams{MaxAmount: "1", Approvers: []string{"user-1"}, RequiredApprovals: 1}},
+		{RuleType: RuleTypeAmountLimit, Action: DecisionRequireApproval, Params: &RuleParams{MaxAmount: "1", Approvers: []string{"user-1", "user-2"}, RequiredApprovals: 3}},
+		{RuleType: RuleTypeAmountLimit, Action: DecisionRequireApproval, Params: &RuleParams{MaxAmount: "1", Approvers: []string{"user-1", "user-1"}, RequiredApprovals: 1}},
+	}
+	for _, rule := range invalid {
+		assert.Error(t, ValidateRule(rule), "%s %+v", rule.RuleType, rule.Params)
+	}
+}
@@ -0,0 +1,107 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+)
+
+// 规则类型
+const (
+	// RuleTypeAmountLimit 单笔交易中某资产的转移总量上限
+	RuleTypeAmountLimit = "amount_limit"
+	// RuleTypeAllowlist 交易的所有目的地址（接收方和被调用合约）必须在列表中
+	RuleTypeAllowlist = "allowlist"
+	// RuleTypeDenylist 交易的目的地址不能在列表中
+	RuleTypeDenylist = "denylist"
+	// RuleTypeContractMethod 合约调用只能使用列出的方法
+	RuleTypeContractMethod = "contract_method"
+	// RuleTypeTimeWindow 只允许在指定时间段内签名
+	RuleTypeTimeWindow = "time_window"
+	// RuleTypeVelocityLimit 滚动窗口内某资产的转移总量上限
+	RuleTypeVelocityLimit = "velocity_limit"
+)
+
+// 策略决策，规则的 Action 为 DecisionDeny 或 DecisionRequireApproval
+const (
+	DecisionAllow           = "allow"
+	DecisionDeny            = "deny"
+	DecisionRequireApproval = "require_approval"
+)
+
+// DefaultVelocityWindow 滚动限额的默认窗口
+const DefaultVelocityWindow = 24 * time.Hour
+
+// Rule 交易策略规则：KeyID 为空时对所有密钥生效，ChainType 为空时对所有链生效
+type Rule struct {
+	RuleID      string
+	KeyID       string
+	ChainType   string
+	RuleType    string
+	Action      string
+	Params      *RuleParams
+	Enabled     bool
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// RuleParams 规则参数，各规则类型使用的字段不同
+type RuleParams struct {
+	// Asset 限额针对的资产（代币合约或铸币地址），为空表示链原生资产，包含已解析的交易手续费（amount_limit、velocity_limit）
+	Asset string `json:"asset,omitempty"`
+	// MaxAmount 最小单位的数量上限（十进制字符串，amount_limit、velocity_limit）
+	MaxAmount string `json:"max_amount,omitempty"`
+	// WindowHours 滚动窗口小时数，为 0 时为 24（velocity_limit）
+	WindowHours int `json:"window_hours,omitempty"`
+	// Addresses 地址列表（allowlist、denylist）
+	Addresses []string `json:"addresses,omitempty"`
+	// Contracts 规则约束的合约，为空表示所有合约（contract_method）
+	Contracts []string `json:"contracts,omitempty"`
+	// Methods 允许的方法：EVM 为 4 字节函数选择器，Solana 为指令首字节（contract_method）
+	Methods []string `json:"methods,omitempty"`
+	// Timezone IANA 时区名，为空时为 UTC（time_window）
+	Timezone string `json:"timezone,omitempty"`
+	// StartTime、EndTime 允许签名的时间段（HH:MM），结束时间早于开始时间表示跨越午夜（time_window）
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+	// Weekdays 允许签名的星期（mon、tue ... sun），为空表示每天（time_window）
+	Weekdays []string `json:"weekdays,omitempty"`
+
+	// Approvers、RequiredApprovals require_approval 规则的审批法定人数（审批人用户 ID，M-of-N），为空时使用密钥的审批设置
+	Approvers         []string `json:"approvers,omitempty"`
+	RequiredApprovals int      `json:"required_approvals,omitempty"`
+}
+
+// Request 签名前的策略检查请求
+type Request struct {
+	KeyID       string
+	ChainType   string
+	MessageType string
+	// Transactional 签名数据是否可能授权资产转移；非交易签名（ECDSA 的 EIP-191 消息）只检查时间段规则
+	Transactional bool
+	// Transaction 链适配器解析出的交易，交易签名未能解析时为空，此时交易相关规则一律命中
+	Transaction *chain.DecodedTransaction
+	// TxHash 未签名交易的标识，同一交易的多次签名（如 Bitcoin 各输入）在滚动限额中只计一次
+	TxHash string
+	// ApprovalID 已通过人工审批的签名请求，设置时 require_approval 规则视为已满足（deny 规则仍然生效）
+	ApprovalID string
+}
+
+// Decision 策略决策，RuleID 为决定结果的规则（allow 时为空）
+type Decision struct {
+	DecisionID  string
+	KeyID       string
+	ChainType   string
+	MessageType string
+	Decision    string
+	RuleID      string
+	Reason      string
+	TxHash      string
+	CreatedAt   time.Time
+}
+
+// Allowed 决策是否允许签名
+func (d *Decision) Allowed() bool {
+	return d.Decision == DecisionAllow
+}
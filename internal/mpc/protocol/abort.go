@@ -0,0 +1,107 @@
+package protocol
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+)
+
+// AbortError 协议因可识别的恶意/故障节点而中止
+// Culprits 为节点 ID（而非 tss PartyID），调用方据此持久化失败原因并更新节点故障评分
+type AbortError struct {
+	Protocol  string
+	SessionID string
+	Culprits  []string
+	Reason    string
+
+	cause error
+}
+
+// Error 实现 error 接口
+func (e *AbortError) Error() string {
+	msg := fmt.Sprintf("%s session %s aborted: %s (culprits: %s)", e.Protocol, e.SessionID, e.Reason, strings.Join(e.Culprits, ","))
+	if e.cause != nil {
+		msg += ": " + e.cause.Error()
+	}
+	return msg
+}
+
+// Cause 兼容 github.com/pkg/errors 的错误链
+func (e *AbortError) Cause() error {
+	return e.cause
+}
+
+// Unwrap 兼容标准库的错误链
+func (e *AbortError) Unwrap() error {
+	return e.cause
+}
+
+// newAbortError 创建可识别中止错误，culprits 为空时返回 nil
+func newAbortError(protocol, sessionID, reason string, culprits []string, cause error) *AbortError {
+	if len(culprits) == 0 {
+		return nil
+	}
+	return &AbortError{
+		Protocol:  protocol,
+		SessionID: sessionID,
+		Culprits:  dedupeStrings(culprits),
+		Reason:    reason,
+		cause:     cause,
+	}
+}
+
+// Culprits 从错误链中提取可识别中止的责任节点 ID，不是可识别中止时返回 nil
+func Culprits(err error) []string {
+	var abortErr *AbortError
+	if errors.As(err, &abortErr) {
+		return abortErr.Culprits
+	}
+	return nil
+}
+
+// culpritNodeIDs 将 tss-lib 报告的责任方 PartyID 映射回节点 ID
+// 映射缺失时（例如会话已清理）退回到 PartyID 的 Moniker，DKG/签名时 Moniker 即节点 ID
+func (m *tssPartyManager) culpritNodeIDs(tssErr *tss.Error) []string {
+	if tssErr == nil {
+		return nil
+	}
+	culprits := tssErr.Culprits()
+	nodeIDs := make([]string, 0, len(culprits))
+	for _, culprit := range culprits {
+		if culprit == nil {
+			continue
+		}
+		if nodeID, ok := m.getNodeID(culprit.Id); ok {
+			nodeIDs = append(nodeIDs, nodeID)
+			continue
+		}
+		if culprit.Moniker != "" {
+			nodeIDs = append(nodeIDs, culprit.Moniker)
+		}
+	}
+	return nodeIDs
+}
+
+// wrapTSSError 包装 tss-lib 错误；若包含责任方则返回 *AbortError 以便上层识别
+func (m *tssPartyManager) wrapTSSError(tssErr *tss.Error, protocol, sessionID, msg string) error {
+	if abortErr := newAbortError(protocol, sessionID, msg, m.culpritNodeIDs(tssErr), tssErr); abortErr != nil {
+		return abortErr
+	}
+	return errors.Wrap(tssErr, msg)
+}
+
+// dedupeStrings 去重并保持原有顺序
+func dedupeStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
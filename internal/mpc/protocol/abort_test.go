@@ -0,0 +1,48 @@
+package protocol
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWrapTSSError_MapsCulpritsToNodeIDs tss-lib 责任方 PartyID 映射回节点 ID
+func TestWrapTSSError_MapsCulpritsToNodeIDs(t *testing.T) {
+	manager := newTSSPartyManager(mockMessageRouter)
+	require.NoError(t, manager.setupPartyIDs([]string{"node-1", "node-2", "node-3"}))
+
+	manager.mu.RLock()
+	culprit := manager.nodeIDToPartyID["node-2"]
+	manager.mu.RUnlock()
+	require.NotNil(t, culprit)
+	// 不在映射中的 PartyID 退回到 Moniker
+	unknown := tss.NewPartyID("unknown-party", "node-9", big.NewInt(99))
+
+	tssErr := tss.NewError(errors.New("bad commitment"), "signing", 3, nil, culprit, unknown, culprit)
+	err := manager.wrapTSSError(tssErr, "GG20", "session-1", "GG20 signing error")
+
+	var abortErr *AbortError
+	require.True(t, errors.As(err, &abortErr))
+	assert.Equal(t, "GG20", abortErr.Protocol)
+	assert.Equal(t, "session-1", abortErr.SessionID)
+	assert.Equal(t, []string{"node-2", "node-9"}, abortErr.Culprits)
+	assert.Contains(t, err.Error(), "bad commitment")
+
+	// 经过多层包装后仍能提取责任节点
+	assert.Equal(t, []string{"node-2", "node-9"}, Culprits(errors.Wrap(err, "threshold sign")))
+}
+
+func TestWrapTSSError_NoCulprits(t *testing.T) {
+	manager := newTSSPartyManager(mockMessageRouter)
+	tssErr := tss.NewError(errors.New("timeout"), "keygen", 1, nil)
+
+	err := manager.wrapTSSError(tssErr, "keygen", "key-1", "keygen error")
+	require.Error(t, err)
+	assert.Nil(t, Culprits(err))
+	assert.Contains(t, err.Error(), "keygen error")
+	assert.Nil(t, Culprits(errors.New("plain error")))
+}
@@ -0,0 +1,190 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecoveryKey(t *testing.T) *ecdh.PrivateKey {
+	t.Helper()
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return priv
+}
+
+// reconstructBackupSecret 用解密出的分片在 0 处插值得到私钥
+func reconstructBackupSecret(t *testing.T, cs *frostCiphersuite, plaintexts []*ShareBackupPlaintext) *big.Int {
+	t.Helper()
+	ids := make([]*big.Int, 0, len(plaintexts))
+	for _, p := range plaintexts {
+		id, err := cs.deserializeScalar(p.Identifier)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	modN := common.ModInt(cs.order())
+	secret := big.NewInt(0)
+	for i, p := range plaintexts {
+		share, err := cs.deserializeScalar(p.SecretShare)
+		require.NoError(t, err)
+		lambda, err := cs.lagrangeCoefficient(ids[i], ids)
+		require.NoError(t, err)
+		secret = modN.Add(secret, modN.Mul(lambda, share))
+	}
+	return secret
+}
+
+// TestShareBackup_FROSTRoundTrip 备份加密、证明校验、解密以及门限数量备份重建私钥
+func TestShareBackup_FROSTRoundTrip(t *testing.T) {
+	for _, curve := range []string{"ed25519", "secp256k1"} {
+		t.Run(curve, func(t *testing.T) {
+			nodeIDs := []string{"node-1", "node-2", "node-3"}
+			c := newFROSTTestCluster(nodeIDs...)
+			keyID := "key-backup-" + curve
+			publicKey := c.keygen(t, keyID, curve, 2, nodeIDs)
+			recovery := newRecoveryKey(t)
+
+			backups := make([]*ShareBackup, 0, len(nodeIDs))
+			for _, nodeID := range nodeIDs {
+				keyData, err := c.storage.GetKeyData(context.Background(), keyID, nodeID)
+				require.NoError(t, err)
+				backup, err := NewShareBackup(keyID, nodeID, 0, keyData, recovery.PublicKey().Bytes())
+				require.NoError(t, err)
+				require.NoError(t, backup.Verify())
+				assert.Equal(t, publicKey.Bytes, backup.GroupPublicKey)
+				backups = append(backups, backup)
+			}
+			require.NoError(t, VerifyShareBackupSet(backups))
+			require.NoError(t, VerifyShareBackupSet(backups[1:]), "threshold backups are enough to recover")
+			require.Error(t, VerifyShareBackupSet(backups[:1]), "a single backup cannot recover a 2-of-3 key")
+
+			// JSON 往返后仍可校验和解密
+			encoded, err := json.Marshal(backups[0])
+			require.NoError(t, err)
+			var decoded ShareBackup
+			require.NoError(t, json.Unmarshal(encoded, &decoded))
+			require.NoError(t, decoded.Verify())
+
+			plaintexts := make([]*ShareBackupPlaintext, 0, 2)
+			for _, backup := range []*ShareBackup{&decoded, backups[2]} {
+				plaintext, err := backup.Decrypt(recovery.Bytes())
+				require.NoError(t, err)
+				assert.Equal(t, backup.NodeID, plaintext.NodeID)
+				plaintexts = append(plaintexts, plaintext)
+			}
+			cs, err := getFROSTCiphersuite(curve)
+			require.NoError(t, err)
+			secret := reconstructBackupSecret(t, cs, plaintexts)
+			assert.Equal(t, publicKey.Bytes, cs.serializeElement(cs.scalarBaseMult(secret)))
+		})
+	}
+}
+
+// TestShareBackup_TSSKeyData tss-lib（EdDSA LocalPartySaveData）格式的分片同样可以备份和重建
+func TestShareBackup_TSSKeyData(t *testing.T) {
+	cs := frostEd25519Suite
+	modN := common.ModInt(cs.order())
+	a0, err := cs.randomScalar()
+	require.NoError(t, err)
+	a1, err := cs.randomScalar()
+	require.NoError(t, err)
+	groupPublicKey := cs.scalarBaseMult(a0)
+	recovery := newRecoveryKey(t)
+
+	// f(x) = a0 + a1·x，节点 i 的分片为 f(shareID_i)
+	shareIDs := []*big.Int{big.NewInt(11), big.NewInt(12), big.NewInt(13)}
+	shares := make([]*big.Int, len(shareIDs))
+	bigXj := make([]*crypto.ECPoint, len(shareIDs))
+	for i, shareID := range shareIDs {
+		shares[i] = modN.Add(a0, modN.Mul(a1, shareID))
+		bigXj[i] = cs.scalarBaseMult(shares[i])
+	}
+
+	backups := make([]*ShareBackup, 0, 3)
+	for i, nodeID := range []string{"node-1", "node-2", "node-3"} {
+		saveData := keygen.NewLocalPartySaveData(3)
+		saveData.ShareID = shareIDs[i]
+		saveData.Xi = shares[i]
+		saveData.Ks = shareIDs
+		saveData.BigXj = bigXj
+		saveData.EDDSAPub = groupPublicKey
+		keyData, err := serializeEdDSALocalPartySaveData(&saveData)
+		require.NoError(t, err)
+
+		backup, err := NewShareBackup("key-tss", nodeID, 3, keyData, recovery.PublicKey().Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, "ed25519", backup.Curve)
+		backups = append(backups, backup)
+	}
+	require.NoError(t, VerifyShareBackupSet(backups[:2]))
+
+	plaintexts := make([]*ShareBackupPlaintext, 0, 2)
+	for _, backup := range backups[1:] {
+		plaintext, err := backup.Decrypt(recovery.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, 3, plaintext.ShareEpoch)
+		plaintexts = append(plaintexts, plaintext)
+	}
+	assert.Equal(t, 0, a0.Cmp(reconstructBackupSecret(t, cs, plaintexts)))
+}
+
+// TestShareBackup_RejectsTampering 篡改密文、证明或头部字段后校验/解密失败
+func TestShareBackup_RejectsTampering(t *testing.T) {
+	nodeIDs := []string{"node-1", "node-2", "node-3"}
+	c := newFROSTTestCluster(nodeIDs...)
+	keyID := "key-backup-tamper"
+	c.keygen(t, keyID, "secp256k1", 2, nodeIDs)
+	recovery := newRecoveryKey(t)
+
+	newBackup := func(nodeID string) *ShareBackup {
+		keyData, err := c.storage.GetKeyData(context.Background(), keyID, nodeID)
+		require.NoError(t, err)
+		backup, err := NewShareBackup(keyID, nodeID, 0, keyData, recovery.PublicKey().Bytes())
+		require.NoError(t, err)
+		return backup
+	}
+
+	// 密文被篡改：证明挑战绑定密文
+	backup := newBackup("node-1")
+	backup.Ciphertext[len(backup.Ciphertext)-1] ^= 0x01
+	assert.Error(t, backup.Verify())
+
+	// 证明响应被篡改
+	backup = newBackup("node-1")
+	backup.ProofResponse = frostSecp256k1Suite.serializeScalar(big.NewInt(1))
+	assert.Error(t, backup.Verify())
+
+	// 公开分片被替换为其他节点的分片
+	backup = newBackup("node-1")
+	backup.PublicShare = newBackup("node-2").PublicShare
+	assert.Error(t, backup.Verify())
+
+	// 错误的恢复私钥
+	backup = newBackup("node-1")
+	_, err := backup.Decrypt(newRecoveryKey(t).Bytes())
+	assert.Error(t, err)
+
+	// 同一分片重复出现不能凑够门限
+	assert.Error(t, VerifyShareBackupSet([]*ShareBackup{newBackup("node-1"), newBackup("node-1")}))
+
+	// 不同轮次的备份不能混用
+	other := newBackup("node-2")
+	other.ShareEpoch = 1
+	assert.Error(t, VerifyShareBackupSet([]*ShareBackup{newBackup("node-1"), other}))
+
+	_, err = ParseRecoveryPublicKey("0x" + "00")
+	assert.Error(t, err)
+	parsed, err := ParseRecoveryPublicKey("0x" + hex.EncodeToString(recovery.PublicKey().Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, recovery.PublicKey().Bytes(), parsed)
+}
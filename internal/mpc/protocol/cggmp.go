@@ -0,0 +1,429 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// cggmpWireProtocol CGGMP21 消息的协议标记（与 FROST 共用 JSON 消息格式）
+const cggmpWireProtocol = "cggmp21"
+
+// CGGMP21 协议轮次
+const (
+	cggmpRoundKeygenCommit = "cggmp-keygen-commit" // keygen 第一轮：广播 Feldman 承诺、知识证明和 aux info
+	cggmpRoundKeygenShare  = "cggmp-keygen-share"  // keygen 第二轮：点对点发送秘密分片和 Π^fac
+	cggmpRoundRefreshShare = "cggmp-refresh-share" // 刷新：旧委员会向新委员会发送子分片
+	cggmpRoundRefreshAux   = "cggmp-refresh-aux"   // 刷新：新委员会广播新的 aux info
+	cggmpRoundRefreshFac   = "cggmp-refresh-fac"   // 刷新：新委员会点对点发送 Π^fac
+	cggmpRoundPresign1     = "cggmp-presign-1"     // 预签名第一轮：广播 K_i、G_i 和 Π^enc
+	cggmpRoundPresign2     = "cggmp-presign-2"     // 预签名第二轮：广播 Γ_i 和 MtA 密文及证明
+	cggmpRoundPresign3     = "cggmp-presign-3"     // 预签名第三轮：广播 Δ_i、δ_i 和 Π^log*
+	cggmpRoundPresignBlame = "cggmp-presign-blame" // 预签名校验失败：广播 δ_i 的正确性证明
+	cggmpRoundSign         = "cggmp-sign"          // 在线签名：广播签名分片 σ_i
+	cggmpRoundSignBlame    = "cggmp-sign-blame"    // 签名校验失败：广播 σ_i 的正确性证明
+)
+
+// CGGMPProtocol CGGMP21 协议实现（ECDSA secp256k1，UC 安全的阈值 ECDSA）
+// 与 GG18/GG20 相比：
+//  1. 预签名 3 轮、在线签名 1 轮，预签名与消息无关
+//  2. 预签名和签名失败时均可识别作恶节点（identifiable abort），不依赖 tss-lib 的报告
+//  3. keygen 同时生成 aux info（Paillier 密钥 + 环 Pedersen 参数，附 Π^mod/Π^prm/Π^fac 证明），
+//     密钥刷新时 aux info 一并更换，旧分片和旧 Paillier 密钥同时失效
+//  4. Threshold 为最少签名者数量，与 FROST 一致
+type CGGMPProtocol struct {
+	curve string
+
+	mu         sync.RWMutex
+	keyRecords map[string]*cggmpKeyMaterial
+
+	// hub 协议消息收件箱（与 FROST 相同的实现）
+	hub *frostMessageHub
+
+	// presignMu 串行化预签名的读取与删除，保证同一预签名在本节点只被使用一次
+	presignMu sync.Mutex
+
+	// preParamsPool keygen/刷新时使用的预参数池（Paillier 密钥和安全素数）
+	preParamsPool *PreParamsPool
+
+	// 当前节点ID（用于参与协议）
+	thisNodeID string
+
+	// 消息路由函数（用于节点间通信）
+	messageRouter func(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error
+
+	// 密钥数据存储（用于持久化分片和 aux info）
+	keyShareStorage KeyShareStorage
+}
+
+// NewCGGMPProtocol 创建 CGGMP21 协议实例
+func NewCGGMPProtocol(curve string, thisNodeID string, messageRouter func(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error, keyShareStorage KeyShareStorage) *CGGMPProtocol {
+	return &CGGMPProtocol{
+		curve:           curve,
+		keyRecords:      make(map[string]*cggmpKeyMaterial),
+		hub:             newFROSTMessageHub(),
+		thisNodeID:      thisNodeID,
+		messageRouter:   messageRouter,
+		keyShareStorage: keyShareStorage,
+	}
+}
+
+// SetPreParamsPool 设置 keygen 预参数池
+func (p *CGGMPProtocol) SetPreParamsPool(pool *PreParamsPool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.preParamsPool = pool
+}
+
+// takePreParams 取出一个预参数（优先使用预参数池，池为空时在线生成）
+func (p *CGGMPProtocol) takePreParams(ctx context.Context, sessionID string) (*keygen.LocalPreParams, error) {
+	p.mu.RLock()
+	pool := p.preParamsPool
+	p.mu.RUnlock()
+	if pool != nil {
+		if preParams, ok := pool.Take(ctx); ok {
+			log.Info().
+				Str("session_id", sessionID).
+				Str("node_id", p.thisNodeID).
+				Msg("Using pooled pre-params for CGGMP21 aux info")
+			return preParams, nil
+		}
+	}
+	log.Warn().
+		Str("session_id", sessionID).
+		Str("node_id", p.thisNodeID).
+		Msg("Pre-params pool empty or disabled, generating CGGMP21 aux info inline")
+	preParams, err := keygen.GeneratePreParamsWithContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate pre-params")
+	}
+	return preParams, nil
+}
+
+// getKeyRecord 获取密钥记录
+func (p *CGGMPProtocol) getKeyRecord(keyID string) (*cggmpKeyMaterial, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	record, ok := p.keyRecords[keyID]
+	return record, ok
+}
+
+func (p *CGGMPProtocol) saveKeyRecord(keyID string, record *cggmpKeyMaterial) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keyRecords[keyID] = record
+}
+
+// loadKeyRecord 获取密钥（优先从内存，如果不存在则从 keyShareStorage 加载）
+func (p *CGGMPProtocol) loadKeyRecord(ctx context.Context, keyID string) (*cggmpKeyMaterial, error) {
+	if record, ok := p.getKeyRecord(keyID); ok {
+		return record, nil
+	}
+	if p.keyShareStorage == nil {
+		return nil, errors.Errorf("key %s not found in memory and keyShareStorage is nil", keyID)
+	}
+	data, err := p.keyShareStorage.GetKeyData(ctx, keyID, p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "key %s not found in memory or storage", keyID)
+	}
+	keyData, ok := parseCGGMPKeyData(data)
+	if !ok {
+		return nil, errors.Errorf("key %s was not generated by CGGMP21", keyID)
+	}
+	record, err := keyData.decode(p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode CGGMP21 key data")
+	}
+	p.saveKeyRecord(keyID, record)
+	return record, nil
+}
+
+// storeKeyData 持久化密钥数据到 keyShareStorage
+func (p *CGGMPProtocol) storeKeyData(ctx context.Context, keyID string, keyData *cggmpKeyData) error {
+	if p.keyShareStorage == nil {
+		return errors.New("keyShareStorage is nil, cannot store CGGMP21 key data")
+	}
+	data, err := json.Marshal(keyData)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal CGGMP21 key data")
+	}
+	if err := p.keyShareStorage.StoreKeyData(ctx, keyID, p.thisNodeID, data); err != nil {
+		return errors.Wrap(err, "failed to store CGGMP21 key data")
+	}
+	return nil
+}
+
+// ForgetKey 丢弃内存中缓存的密钥数据（keyShareStorage 中的数据由调用方删除）
+func (p *CGGMPProtocol) ForgetKey(keyID string) {
+	p.mu.Lock()
+	delete(p.keyRecords, keyID)
+	p.mu.Unlock()
+}
+
+// discardKeyData 删除本节点的密钥数据（内存和 keyShareStorage）
+func (p *CGGMPProtocol) discardKeyData(ctx context.Context, keyID string) error {
+	p.mu.Lock()
+	delete(p.keyRecords, keyID)
+	p.mu.Unlock()
+
+	if p.keyShareStorage == nil {
+		return nil
+	}
+	if err := p.keyShareStorage.DeleteKeyData(ctx, keyID, p.thisNodeID); err != nil {
+		return errors.Wrap(err, "failed to delete CGGMP21 key data")
+	}
+	log.Info().
+		Str("key_id", keyID).
+		Str("node_id", p.thisNodeID).
+		Msg("Old key share discarded after refresh")
+	return nil
+}
+
+// GenerateKeyShare 分布式密钥生成（Feldman VSS + aux info）
+// req.Threshold 为最少签名者数量
+func (p *CGGMPProtocol) GenerateKeyShare(ctx context.Context, req *KeyGenRequest) (*KeyGenResponse, error) {
+	if err := p.ValidateKeyGenRequest(req); err != nil {
+		return nil, errors.Wrap(err, "invalid key generation request")
+	}
+
+	keyID := req.KeyID
+	if keyID == "" {
+		keyID = fmt.Sprintf("cggmp-key-%s", generateKeyID())
+	}
+
+	nodeIDs, err := normalizeNodeIDs(req.NodeIDs, req.TotalNodes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid node IDs")
+	}
+
+	keyData, err := p.executeKeygen(ctx, keyID, nodeIDs, req.Threshold)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute CGGMP21 keygen")
+	}
+	material, err := keyData.decode(p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode CGGMP21 key data")
+	}
+
+	if err := p.storeKeyData(ctx, keyID, keyData); err != nil {
+		return nil, err
+	}
+	p.saveKeyRecord(keyID, material)
+	logCGGMPKeyData("CGGMP21 keygen completed", p.thisNodeID, keyData)
+
+	return &KeyGenResponse{
+		KeyShares: cggmpKeyShares(keyID, keyData),
+		PublicKey: material.publicKey(),
+	}, nil
+}
+
+// ThresholdSign 阈值签名
+// 指定预签名时只执行一轮在线签名；否则在同一会话中先执行 3 轮预签名再签名
+func (p *CGGMPProtocol) ThresholdSign(ctx context.Context, sessionID string, req *SignRequest) (*SignResponse, error) {
+	if err := p.ValidateSignRequest(req); err != nil {
+		return nil, errors.Wrap(err, "invalid sign request")
+	}
+	if req.TaprootKeySpend {
+		return nil, errors.New("taproot key-path signing requires a FROST key")
+	}
+
+	key, err := p.loadKeyRecord(ctx, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := resolveMessagePayload(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve message payload")
+	}
+
+	sig, publicKey, err := p.sign(ctx, sessionID, req, key, message)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute CGGMP21 signing")
+	}
+	return &SignResponse{
+		Signature: sig,
+		PublicKey: publicKey,
+	}, nil
+}
+
+// VerifySignature 签名验证（标准 ECDSA，DER 编码）
+func (p *CGGMPProtocol) VerifySignature(ctx context.Context, sig *Signature, msg []byte, pubKey *PublicKey) (bool, error) {
+	return verifyECDSASignature(sig, msg, pubKey)
+}
+
+// RotateKey 密钥刷新：更换委员会和/或阈值，同时更换所有节点的 aux info，公钥保持不变
+// 仅属于旧委员会的节点在完成后作废本地分片
+func (p *CGGMPProtocol) RotateKey(ctx context.Context, req *ReshareRequest) (*ReshareResponse, error) {
+	if err := validateReshareRequest(req); err != nil {
+		return nil, errors.Wrap(err, "invalid reshare request")
+	}
+	if req.NewThreshold < 2 {
+		return nil, errors.New("new threshold must be at least 2")
+	}
+
+	var oldKey *cggmpKeyMaterial
+	if containsNodeID(req.OldNodeIDs, p.thisNodeID) {
+		key, err := p.loadKeyRecord(ctx, req.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		if req.NewEpoch <= key.data.Epoch {
+			return nil, errors.Errorf("new epoch %d must be greater than current epoch %d", req.NewEpoch, key.data.Epoch)
+		}
+		oldKey = key
+	}
+
+	keyData, err := p.executeRefresh(ctx, req, oldKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute CGGMP21 refresh")
+	}
+
+	// 不在新委员会：旧分片已失效，删除本地数据
+	if keyData == nil {
+		if err := p.discardKeyData(ctx, req.KeyID); err != nil {
+			return nil, err
+		}
+		return &ReshareResponse{PublicKey: oldKey.publicKey()}, nil
+	}
+
+	material, err := keyData.decode(p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode CGGMP21 key data")
+	}
+	publicKey := material.publicKey()
+	if oldKey != nil && oldKey.publicKey().Hex != publicKey.Hex {
+		return nil, errors.Errorf("public key changed after refresh: %s -> %s", oldKey.publicKey().Hex, publicKey.Hex)
+	}
+
+	if err := p.storeKeyData(ctx, req.KeyID, keyData); err != nil {
+		return nil, err
+	}
+	p.saveKeyRecord(req.KeyID, material)
+	logCGGMPKeyData("CGGMP21 refresh completed", p.thisNodeID, keyData)
+
+	return &ReshareResponse{
+		PublicKey: publicKey,
+		KeyShare:  cggmpKeyShares(req.KeyID, keyData)[p.thisNodeID],
+	}, nil
+}
+
+// Presign 预签名（3 轮，与消息无关），结果保存在本节点的 keyShareStorage 中
+// sessionID 即预签名ID，在线签名时由协调者通过 SignRequest.PresignatureID 指定
+func (p *CGGMPProtocol) Presign(ctx context.Context, sessionID string, req *PresignRequest) (*PresignResponse, error) {
+	if req == nil || req.KeyID == "" {
+		return nil, errors.New("key ID is required")
+	}
+	if !presignIDPattern.MatchString(sessionID) {
+		return nil, errors.Errorf("invalid presignature ID: %q", sessionID)
+	}
+	if p.keyShareStorage == nil {
+		return nil, errors.New("key share storage is required for presignatures")
+	}
+
+	key, err := p.loadKeyRecord(ctx, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	presig, err := p.presign(ctx, sessionID, req.KeyID, req.NodeIDs, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute CGGMP21 presign")
+	}
+	if err := p.storePresignature(ctx, presig); err != nil {
+		return nil, err
+	}
+	return &PresignResponse{PresignatureID: sessionID}, nil
+}
+
+// ProcessIncomingKeygenMessage 处理接收到的 keygen 消息
+func (p *CGGMPProtocol) ProcessIncomingKeygenMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte, isBroadcast bool) error {
+	return p.deliver(sessionID, fromNodeID, msgBytes)
+}
+
+// ProcessIncomingSigningMessage 处理接收到的签名/预签名消息
+func (p *CGGMPProtocol) ProcessIncomingSigningMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte, isBroadcast bool) error {
+	return p.deliver(sessionID, fromNodeID, msgBytes)
+}
+
+// ProcessIncomingResharingMessage 处理接收到的刷新消息
+func (p *CGGMPProtocol) ProcessIncomingResharingMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte, isBroadcast bool) error {
+	return p.deliver(sessionID, fromNodeID, msgBytes)
+}
+
+// deliver 解析消息并放入会话收件箱
+func (p *CGGMPProtocol) deliver(sessionID string, fromNodeID string, msgBytes []byte) error {
+	msg, ok := parseWireMessage(msgBytes, cggmpWireProtocol)
+	if !ok {
+		return errors.Errorf("received a non-CGGMP21 message from node %s in session %s", fromNodeID, sessionID)
+	}
+	return p.hub.deliver(sessionID, fromNodeID, msg)
+}
+
+// SupportedProtocols 支持的协议
+func (p *CGGMPProtocol) SupportedProtocols() []string {
+	return []string{"cggmp21"}
+}
+
+// DefaultProtocol 默认协议
+func (p *CGGMPProtocol) DefaultProtocol() string {
+	return "cggmp21"
+}
+
+// GetCurve 获取曲线类型
+func (p *CGGMPProtocol) GetCurve() string {
+	return p.curve
+}
+
+// ValidateKeyGenRequest 验证密钥生成请求
+func (p *CGGMPProtocol) ValidateKeyGenRequest(req *KeyGenRequest) error {
+	if req == nil {
+		return errors.New("key generation request is nil")
+	}
+	if req.Algorithm != "" && !strings.EqualFold(req.Algorithm, "ECDSA") {
+		return errors.Errorf("unsupported algorithm for CGGMP21: %s (supported: ECDSA)", req.Algorithm)
+	}
+	if req.Curve != "" && !strings.EqualFold(req.Curve, "secp256k1") {
+		return errors.Errorf("unsupported curve for CGGMP21: %s (supported: secp256k1)", req.Curve)
+	}
+	if req.Threshold < 2 {
+		return errors.New("threshold must be at least 2")
+	}
+	if req.TotalNodes < req.Threshold {
+		return errors.New("total nodes must be at least threshold")
+	}
+	return nil
+}
+
+// ValidateSignRequest 验证签名请求
+func (p *CGGMPProtocol) ValidateSignRequest(req *SignRequest) error {
+	return validateSignRequest(req)
+}
+
+// sendCGGMPMessage 向 toNodeIDs 发送同一轮次消息；broadcast 为 true 时所有接收方收到相同内容
+func (p *CGGMPProtocol) sendCGGMPMessage(sessionID string, round string, toNodeIDs []string, payload interface{}, broadcast bool) error {
+	return sendWireMessage(p.messageRouter, cggmpWireProtocol, p.thisNodeID, sessionID, round, toNodeIDs, payload, broadcast)
+}
+
+// sendCGGMPDirect 向单个节点发送点对点消息
+func (p *CGGMPProtocol) sendCGGMPDirect(sessionID string, round string, toNodeID string, payload interface{}) error {
+	return p.sendCGGMPMessage(sessionID, round, []string{toNodeID}, payload, false)
+}
+
+// otherNodeIDs 返回 nodeIDs 中除本节点外的节点
+func (p *CGGMPProtocol) otherNodeIDs(nodeIDs []string) []string {
+	others := make([]string, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if nodeID != p.thisNodeID {
+			others = append(others, nodeID)
+		}
+	}
+	return others
+}
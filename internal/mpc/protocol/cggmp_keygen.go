@@ -0,0 +1,588 @@
+package protocol
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/dlnproof"
+	"github.com/kashguard/tss-lib/crypto/facproof"
+	"github.com/kashguard/tss-lib/crypto/modproof"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// cggmpKeyDataVersion CGGMP21 密钥数据格式版本
+const cggmpKeyDataVersion = 1
+
+// cggmpMinModulusBits Paillier 模数和环 Pedersen 模数的最小位数
+const cggmpMinModulusBits = 2046
+
+// cggmpAuxInfo 节点的公开辅助参数：Paillier 公钥 N 和环 Pedersen 参数 (N̂, s, t)
+type cggmpAuxInfo struct {
+	PaillierN *big.Int `json:"paillier_n"`
+	NTilde    *big.Int `json:"ntilde"`
+	H1        *big.Int `json:"h1"`
+	H2        *big.Int `json:"h2"`
+}
+
+// pedersen 以该节点为验证方时使用的环 Pedersen 参数
+func (a *cggmpAuxInfo) pedersen() *cggmpPedersen {
+	return &cggmpPedersen{N: a.NTilde, S: a.H1, T: a.H2}
+}
+
+// cggmpKeyData 本节点的 CGGMP21 密钥数据（持久化到 keyShareStorage）
+// Share 复用 FROST 的 Feldman 分片格式（secp256k1），Threshold 为最少签名者数量
+type cggmpKeyData struct {
+	Version    int                      `json:"cggmp_version"`
+	Share      *frostKeyData            `json:"share"`
+	PaillierSK *paillier.PrivateKey     `json:"paillier_sk"`
+	AuxInfo    map[string]*cggmpAuxInfo `json:"aux_info"`
+}
+
+// cggmpKeyMaterial 解码后的 CGGMP21 密钥数据
+type cggmpKeyMaterial struct {
+	*frostKeyMaterial
+	keyData    *cggmpKeyData
+	paillierSK *paillier.PrivateKey
+	auxInfo    map[string]*cggmpAuxInfo
+}
+
+// parseCGGMPKeyData 解析 CGGMP21 格式的密钥数据，其他格式返回 false
+func parseCGGMPKeyData(data []byte) (*cggmpKeyData, bool) {
+	var keyData cggmpKeyData
+	if err := json.Unmarshal(data, &keyData); err != nil || keyData.Version == 0 || keyData.Share == nil {
+		return nil, false
+	}
+	return &keyData, true
+}
+
+// decode 校验并解码密钥数据
+func (d *cggmpKeyData) decode(thisNodeID string) (*cggmpKeyMaterial, error) {
+	if d.Share.Ciphersuite != frostCiphersuiteSecp256k1 {
+		return nil, errors.Errorf("unsupported CGGMP21 curve: %s", d.Share.Ciphersuite)
+	}
+	share, err := d.Share.decode(thisNodeID)
+	if err != nil {
+		return nil, err
+	}
+	if d.PaillierSK == nil || d.PaillierSK.N == nil || d.PaillierSK.PhiN == nil || d.PaillierSK.LambdaN == nil {
+		return nil, errors.New("paillier private key is missing")
+	}
+	for _, nodeID := range d.Share.NodeIDs {
+		aux, ok := d.AuxInfo[nodeID]
+		if !ok || !cggmpNonNil(aux.PaillierN, aux.NTilde, aux.H1, aux.H2) {
+			return nil, errors.Errorf("aux info for node %s is missing", nodeID)
+		}
+	}
+	if d.AuxInfo[thisNodeID].PaillierN.Cmp(d.PaillierSK.N) != 0 {
+		return nil, errors.New("paillier private key does not match this node's aux info")
+	}
+	return &cggmpKeyMaterial{
+		frostKeyMaterial: share,
+		keyData:          d,
+		paillierSK:       d.PaillierSK,
+		auxInfo:          d.AuxInfo,
+	}, nil
+}
+
+// cggmpAuxPayload aux info 广播内容：公开参数及其证明
+// Π^mod 证明 N 为 Paillier-Blum 模数，两个 dlnproof 证明 s、t 互相生成同一子群（环 Pedersen 参数正确）
+type cggmpAuxPayload struct {
+	AuxInfo   *cggmpAuxInfo      `json:"aux_info"`
+	ModProof  *modproof.ProofMod `json:"mod_proof"`
+	DLNProof1 *dlnproof.Proof    `json:"dln_proof_1"`
+	DLNProof2 *dlnproof.Proof    `json:"dln_proof_2"`
+}
+
+// cggmpAuxContext aux info 证明的上下文（绑定会话和证明方）
+func cggmpAuxContext(sessionID string, nodeID string) []byte {
+	return []byte("cggmp21/aux/" + sessionID + "/" + nodeID)
+}
+
+// newCGGMPAuxPayload 由 keygen 预参数生成 aux info 及其证明
+func newCGGMPAuxPayload(sessionID string, nodeID string, preParams *keygen.LocalPreParams) (*cggmpAuxPayload, error) {
+	if preParams == nil || preParams.PaillierSK == nil || !preParams.Validate() {
+		return nil, errors.New("invalid pre-params for CGGMP21 aux info")
+	}
+	sk := preParams.PaillierSK
+	modProof, err := modproof.NewProof(cggmpAuxContext(sessionID, nodeID), sk.N, sk.P, sk.Q, rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create paillier-blum modulus proof")
+	}
+	return &cggmpAuxPayload{
+		AuxInfo: &cggmpAuxInfo{
+			PaillierN: sk.N,
+			NTilde:    preParams.NTildei,
+			H1:        preParams.H1i,
+			H2:        preParams.H2i,
+		},
+		ModProof:  modProof,
+		DLNProof1: dlnproof.NewDLNProof(preParams.H1i, preParams.H2i, preParams.Alpha, preParams.P, preParams.Q, preParams.NTildei, rand.Reader),
+		DLNProof2: dlnproof.NewDLNProof(preParams.H2i, preParams.H1i, preParams.Beta, preParams.P, preParams.Q, preParams.NTildei, rand.Reader),
+	}, nil
+}
+
+// verify 校验其他节点广播的 aux info
+func (a *cggmpAuxPayload) verify(sessionID string, nodeID string) error {
+	aux := a.AuxInfo
+	if aux == nil || !cggmpNonNil(aux.PaillierN, aux.NTilde, aux.H1, aux.H2) {
+		return errors.New("aux info is incomplete")
+	}
+	if aux.PaillierN.BitLen() < cggmpMinModulusBits || aux.NTilde.BitLen() < cggmpMinModulusBits {
+		return errors.New("aux info moduli are too small")
+	}
+	if !cggmpInUnitGroup(aux.H1, aux.NTilde) || !cggmpInUnitGroup(aux.H2, aux.NTilde) || aux.H1.Cmp(aux.H2) == 0 {
+		return errors.New("aux info has invalid ring-pedersen parameters")
+	}
+	if !a.ModProof.Verify(cggmpAuxContext(sessionID, nodeID), aux.PaillierN) {
+		return errors.New("paillier-blum modulus proof failed")
+	}
+	if !cggmpDLNProofComplete(a.DLNProof1) || !a.DLNProof1.Verify(aux.H1, aux.H2, aux.NTilde) ||
+		!cggmpDLNProofComplete(a.DLNProof2) || !a.DLNProof2.Verify(aux.H2, aux.H1, aux.NTilde) {
+		return errors.New("ring-pedersen parameter proof failed")
+	}
+	return nil
+}
+
+// cggmpDLNProofComplete 检查 dlnproof 字段完整（tss-lib 的 Verify 不检查 nil）
+func cggmpDLNProofComplete(pf *dlnproof.Proof) bool {
+	if pf == nil {
+		return false
+	}
+	for i := range pf.Alpha {
+		if pf.Alpha[i] == nil || pf.T[i] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// cggmpFacContext Π^fac 的上下文（绑定会话、证明方和验证方）
+func cggmpFacContext(sessionID string, fromNodeID string, toNodeID string) []byte {
+	return []byte("cggmp21/fac/" + sessionID + "/" + fromNodeID + "/" + toNodeID)
+}
+
+// newCGGMPFacProof 使用验证方的环 Pedersen 参数证明本节点的 Paillier 模数没有小因子
+func newCGGMPFacProof(sessionID, fromNodeID, toNodeID string, sk *paillier.PrivateKey, verifier *cggmpAuxInfo) (*facproof.ProofFac, error) {
+	pf, err := facproof.NewProof(cggmpFacContext(sessionID, fromNodeID, toNodeID), tss.S256(), sk.N, verifier.NTilde, verifier.H1, verifier.H2, sk.P, sk.Q, rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create no-small-factor proof")
+	}
+	return pf, nil
+}
+
+// verifyCGGMPFacProof 校验其他节点 Paillier 模数的 Π^fac 证明（使用本节点的环 Pedersen 参数）
+func verifyCGGMPFacProof(pf *facproof.ProofFac, sessionID, fromNodeID, toNodeID string, prover *cggmpAuxInfo, verifier *cggmpAuxInfo) bool {
+	return pf.Verify(cggmpFacContext(sessionID, fromNodeID, toNodeID), tss.S256(), prover.PaillierN, verifier.NTilde, verifier.H1, verifier.H2)
+}
+
+// cggmpKeygenCommitPayload keygen 第一轮广播：Feldman 承诺、常数项知识证明和 aux info
+type cggmpKeygenCommitPayload struct {
+	Commitments [][]byte         `json:"commitments"`
+	ProofR      []byte           `json:"proof_r"`
+	ProofMu     []byte           `json:"proof_mu"`
+	Aux         *cggmpAuxPayload `json:"aux"`
+}
+
+// cggmpKeygenSharePayload keygen 第二轮点对点内容：秘密分片和 Π^fac（依赖节点间 gRPC 的 TLS 保证机密性）
+type cggmpKeygenSharePayload struct {
+	Share    []byte             `json:"share"`
+	FacProof *facproof.ProofFac `json:"fac_proof"`
+}
+
+// cggmpFacPayload 重分享时新委员会成员之间交换的 Π^fac
+type cggmpFacPayload struct {
+	FacProof *facproof.ProofFac `json:"fac_proof"`
+}
+
+// decodeCGGMPCommitments 解码 Feldman 承诺
+func decodeCGGMPCommitments(encoded [][]byte, expected int) ([]*crypto.ECPoint, error) {
+	if len(encoded) != expected {
+		return nil, errors.Errorf("got %d commitments, expected %d", len(encoded), expected)
+	}
+	cs := frostSecp256k1Suite
+	points := make([]*crypto.ECPoint, 0, len(encoded))
+	for _, e := range encoded {
+		point, err := cs.deserializeElement(e)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// executeKeygen 执行 CGGMP21 密钥生成
+// 第一轮广播 Feldman 承诺、知识证明和 aux info（Paillier 公钥 + 环 Pedersen 参数及其证明），
+// 第二轮点对点发送分片和 Π^fac；任一校验失败都会中止并指出作恶节点
+func (p *CGGMPProtocol) executeKeygen(ctx context.Context, keyID string, nodeIDs []string, threshold int) (*cggmpKeyData, error) {
+	cs := frostSecp256k1Suite
+	sortedNodeIDs, identifiers := assignFROSTIdentifiers(nodeIDs)
+	index, ok := identifiers[p.thisNodeID]
+	if !ok {
+		return nil, errors.Errorf("this node %s is not a keygen participant", p.thisNodeID)
+	}
+	myIdentifier := frostIdentifier(index)
+	others := p.otherNodeIDs(sortedNodeIDs)
+	proofContext := []byte("cggmp21/keygen/" + keyID)
+
+	inbox := p.hub.claim(keyID)
+	defer p.hub.release(keyID)
+
+	preParams, err := p.takePreParams(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	auxPayload, err := newCGGMPAuxPayload(keyID, p.thisNodeID, preParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// 第一轮：广播承诺、知识证明和 aux info
+	secret, err := cs.randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	coefficients, err := cs.newPolynomial(secret, threshold-1)
+	if err != nil {
+		return nil, err
+	}
+	myCommitments := cs.commitPolynomial(coefficients)
+	proofR, proofMu, err := cs.proveKnowledge(proofContext, myIdentifier, secret, myCommitments[0])
+	if err != nil {
+		return nil, err
+	}
+	commitPayload := &cggmpKeygenCommitPayload{
+		ProofR:  cs.serializeElement(proofR),
+		ProofMu: cs.serializeScalar(proofMu),
+		Aux:     auxPayload,
+	}
+	for _, c := range myCommitments {
+		commitPayload.Commitments = append(commitPayload.Commitments, cs.serializeElement(c))
+	}
+	if err := p.sendCGGMPMessage(keyID, cggmpRoundKeygenCommit, others, commitPayload, true); err != nil {
+		return nil, err
+	}
+
+	received, err := inbox.collect(ctx, cggmpRoundKeygenCommit, others)
+	if err != nil {
+		return nil, err
+	}
+	commitments := map[string][]*crypto.ECPoint{p.thisNodeID: myCommitments}
+	auxInfo := map[string]*cggmpAuxInfo{p.thisNodeID: auxPayload.AuxInfo}
+	for _, nodeID := range others {
+		var payload cggmpKeygenCommitPayload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed keygen commitment", nodeID)
+		}
+		points, err := decodeCGGMPCommitments(payload.Commitments, threshold)
+		if err != nil {
+			return nil, newAbortError("CGGMP21 keygen", keyID, fmt.Sprintf("node %s sent invalid commitments", nodeID), []string{nodeID}, err)
+		}
+		r, err := cs.deserializeElement(payload.ProofR)
+		if err == nil {
+			var mu *big.Int
+			if mu, err = cs.deserializeScalar(payload.ProofMu); err == nil {
+				err = cs.verifyKnowledge(proofContext, frostIdentifier(identifiers[nodeID]), points[0], r, mu)
+			}
+		}
+		if err != nil {
+			return nil, newAbortError("CGGMP21 keygen", keyID, fmt.Sprintf("node %s failed the keygen proof of knowledge", nodeID), []string{nodeID}, err)
+		}
+		if payload.Aux == nil {
+			return nil, newAbortError("CGGMP21 keygen", keyID, fmt.Sprintf("node %s sent no aux info", nodeID), []string{nodeID}, nil)
+		}
+		if err := payload.Aux.verify(keyID, nodeID); err != nil {
+			return nil, newAbortError("CGGMP21 keygen", keyID, fmt.Sprintf("node %s sent invalid aux info", nodeID), []string{nodeID}, err)
+		}
+		commitments[nodeID] = points
+		auxInfo[nodeID] = payload.Aux.AuxInfo
+	}
+
+	// 第二轮：向每个节点发送 f_i(j) 和针对其环 Pedersen 参数的 Π^fac
+	for _, nodeID := range others {
+		facProof, err := newCGGMPFacProof(keyID, p.thisNodeID, nodeID, preParams.PaillierSK, auxInfo[nodeID])
+		if err != nil {
+			return nil, err
+		}
+		share := cs.evalPolynomial(coefficients, frostIdentifier(identifiers[nodeID]))
+		payload := &cggmpKeygenSharePayload{Share: cs.serializeScalar(share), FacProof: facProof}
+		if err := p.sendCGGMPDirect(keyID, cggmpRoundKeygenShare, nodeID, payload); err != nil {
+			return nil, err
+		}
+	}
+
+	received, err = inbox.collect(ctx, cggmpRoundKeygenShare, others)
+	if err != nil {
+		return nil, err
+	}
+	modN := common.ModInt(cs.order())
+	secretShare := cs.evalPolynomial(coefficients, myIdentifier)
+	for _, nodeID := range others {
+		var payload cggmpKeygenSharePayload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed keygen share", nodeID)
+		}
+		share, err := cs.deserializeScalar(payload.Share)
+		if err == nil {
+			err = cs.verifyVSSShare(share, myIdentifier, commitments[nodeID])
+		}
+		if err != nil {
+			return nil, newAbortError("CGGMP21 keygen", keyID, fmt.Sprintf("node %s sent a keygen share that does not match its commitment", nodeID), []string{nodeID}, err)
+		}
+		if !verifyCGGMPFacProof(payload.FacProof, keyID, nodeID, p.thisNodeID, auxInfo[nodeID], auxInfo[p.thisNodeID]) {
+			return nil, newAbortError("CGGMP21 keygen", keyID, fmt.Sprintf("node %s failed the no-small-factor proof", nodeID), []string{nodeID}, nil)
+		}
+		secretShare = modN.Add(secretShare, share)
+	}
+
+	share, err := buildFROSTKeyData(cs, keyID, threshold, 0, sortedNodeIDs, identifiers, secretShare, commitments)
+	if err != nil {
+		return nil, err
+	}
+	return &cggmpKeyData{
+		Version:    cggmpKeyDataVersion,
+		Share:      share,
+		PaillierSK: preParams.PaillierSK,
+		AuxInfo:    auxInfo,
+	}, nil
+}
+
+// cggmpRefreshSharePayload 旧委员会成员发给新委员会成员的子分片（对 λ_i·s_i 的 Feldman 秘密分享）
+type cggmpRefreshSharePayload struct {
+	GroupPublicKey []byte   `json:"group_public_key"`
+	Commitments    [][]byte `json:"commitments"`
+	Share          []byte   `json:"share"`
+}
+
+// executeRefresh 执行 CGGMP21 密钥刷新（可同时更换委员会和阈值，公钥保持不变）
+// 旧委员会成员对 λ_i·s_i 做 Feldman 秘密分享；新委员会成员生成新的 aux info 并交换 Π^mod/Π^prm/Π^fac，
+// 因此刷新后旧分片和旧 Paillier 密钥都不再可用
+// oldKey 为本节点在旧委员会中的密钥（不在旧委员会时为 nil）；本节点不在新委员会时返回 nil
+func (p *CGGMPProtocol) executeRefresh(ctx context.Context, req *ReshareRequest, oldKey *cggmpKeyMaterial) (*cggmpKeyData, error) {
+	cs := frostSecp256k1Suite
+	sessionID := req.SessionID
+	inbox := p.hub.claim(sessionID)
+	defer p.hub.release(sessionID)
+
+	sortedNewNodeIDs, newIdentifiers := assignFROSTIdentifiers(req.NewNodeIDs)
+	oldNodeIDs := append([]string(nil), req.OldNodeIDs...)
+	sort.Strings(oldNodeIDs)
+
+	// 旧委员会成员：分享 λ_i·s_i
+	if oldKey != nil {
+		oldIdentifiers := make([]*big.Int, 0, len(oldNodeIDs))
+		for _, nodeID := range oldNodeIDs {
+			identifier, err := oldKey.identifierOf(nodeID)
+			if err != nil {
+				return nil, err
+			}
+			oldIdentifiers = append(oldIdentifiers, identifier)
+		}
+		lambda, err := cs.lagrangeCoefficient(oldKey.identifier, oldIdentifiers)
+		if err != nil {
+			return nil, err
+		}
+		coefficients, err := cs.newPolynomial(common.ModInt(cs.order()).Mul(lambda, oldKey.secretShare), req.NewThreshold-1)
+		if err != nil {
+			return nil, err
+		}
+		var encodedCommitments [][]byte
+		for _, c := range cs.commitPolynomial(coefficients) {
+			encodedCommitments = append(encodedCommitments, cs.serializeElement(c))
+		}
+		for _, nodeID := range sortedNewNodeIDs {
+			payload := &cggmpRefreshSharePayload{
+				GroupPublicKey: oldKey.data.GroupPublicKey,
+				Commitments:    encodedCommitments,
+				Share:          cs.serializeScalar(cs.evalPolynomial(coefficients, frostIdentifier(newIdentifiers[nodeID]))),
+			}
+			if nodeID == p.thisNodeID {
+				data, err := json.Marshal(payload)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to marshal refresh payload")
+				}
+				if err := inbox.put(&frostWireMessage{Protocol: cggmpWireProtocol, Round: cggmpRoundRefreshShare, From: p.thisNodeID, Payload: data}); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := p.sendCGGMPDirect(sessionID, cggmpRoundRefreshShare, nodeID, payload); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	myIndex, ok := newIdentifiers[p.thisNodeID]
+	if !ok {
+		return nil, nil
+	}
+	myIdentifier := frostIdentifier(myIndex)
+	newOthers := p.otherNodeIDs(sortedNewNodeIDs)
+
+	// 新委员会成员：生成并交换新的 aux info
+	preParams, err := p.takePreParams(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	auxPayload, err := newCGGMPAuxPayload(sessionID, p.thisNodeID, preParams)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.sendCGGMPMessage(sessionID, cggmpRoundRefreshAux, newOthers, auxPayload, true); err != nil {
+		return nil, err
+	}
+	received, err := inbox.collect(ctx, cggmpRoundRefreshAux, newOthers)
+	if err != nil {
+		return nil, err
+	}
+	auxInfo := map[string]*cggmpAuxInfo{p.thisNodeID: auxPayload.AuxInfo}
+	for _, nodeID := range newOthers {
+		var payload cggmpAuxPayload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent malformed aux info", nodeID)
+		}
+		if err := payload.verify(sessionID, nodeID); err != nil {
+			return nil, newAbortError("CGGMP21 refresh", sessionID, fmt.Sprintf("node %s sent invalid aux info", nodeID), []string{nodeID}, err)
+		}
+		auxInfo[nodeID] = payload.AuxInfo
+	}
+	for _, nodeID := range newOthers {
+		facProof, err := newCGGMPFacProof(sessionID, p.thisNodeID, nodeID, preParams.PaillierSK, auxInfo[nodeID])
+		if err != nil {
+			return nil, err
+		}
+		if err := p.sendCGGMPDirect(sessionID, cggmpRoundRefreshFac, nodeID, &cggmpFacPayload{FacProof: facProof}); err != nil {
+			return nil, err
+		}
+	}
+	received, err = inbox.collect(ctx, cggmpRoundRefreshFac, newOthers)
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeID := range newOthers {
+		var payload cggmpFacPayload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed no-small-factor proof", nodeID)
+		}
+		if !verifyCGGMPFacProof(payload.FacProof, sessionID, nodeID, p.thisNodeID, auxInfo[nodeID], auxInfo[p.thisNodeID]) {
+			return nil, newAbortError("CGGMP21 refresh", sessionID, fmt.Sprintf("node %s failed the no-small-factor proof", nodeID), []string{nodeID}, nil)
+		}
+	}
+
+	// 收集所有旧成员的子分片
+	received, err = inbox.collect(ctx, cggmpRoundRefreshShare, oldNodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	var groupPublicKey []byte
+	if oldKey != nil {
+		groupPublicKey = oldKey.data.GroupPublicKey
+	}
+	commitments := make(map[string][]*crypto.ECPoint, len(received))
+	secretShare := big.NewInt(0)
+	modN := common.ModInt(cs.order())
+	for _, nodeID := range oldNodeIDs {
+		var payload cggmpRefreshSharePayload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed refresh message", nodeID)
+		}
+		if groupPublicKey == nil {
+			groupPublicKey = payload.GroupPublicKey
+		}
+		if hex.EncodeToString(payload.GroupPublicKey) != hex.EncodeToString(groupPublicKey) {
+			return nil, newAbortError("CGGMP21 refresh", sessionID, fmt.Sprintf("node %s disagrees on the key being refreshed", nodeID), []string{nodeID}, nil)
+		}
+		points, err := decodeCGGMPCommitments(payload.Commitments, req.NewThreshold)
+		if err != nil {
+			return nil, newAbortError("CGGMP21 refresh", sessionID, fmt.Sprintf("node %s sent invalid commitments", nodeID), []string{nodeID}, err)
+		}
+		share, err := cs.deserializeScalar(payload.Share)
+		if err == nil {
+			err = cs.verifyVSSShare(share, myIdentifier, points)
+		}
+		if err != nil {
+			return nil, newAbortError("CGGMP21 refresh", sessionID, fmt.Sprintf("node %s sent a refresh share that does not match its commitment", nodeID), []string{nodeID}, err)
+		}
+		// 同时属于旧委员会的节点可以逐个校验常数项承诺 C_0 = λ_j·X_j
+		if oldKey != nil {
+			if err := cggmpCheckDealerCommitment(oldKey.frostKeyMaterial, oldNodeIDs, nodeID, points[0]); err != nil {
+				return nil, newAbortError("CGGMP21 refresh", sessionID, fmt.Sprintf("node %s did not reshare its own key share", nodeID), []string{nodeID}, err)
+			}
+		}
+		commitments[nodeID] = points
+		secretShare = modN.Add(secretShare, share)
+	}
+
+	share, err := buildFROSTKeyData(cs, req.KeyID, req.NewThreshold, req.NewEpoch, sortedNewNodeIDs, newIdentifiers, secretShare, commitments)
+	if err != nil {
+		return nil, err
+	}
+	// Σ λ_i·s_i = s：子分片常数项之和必须等于原群公钥
+	if hex.EncodeToString(share.GroupPublicKey) != hex.EncodeToString(groupPublicKey) {
+		return nil, errors.New("refreshed commitments do not reconstruct the group public key")
+	}
+	return &cggmpKeyData{
+		Version:    cggmpKeyDataVersion,
+		Share:      share,
+		PaillierSK: preParams.PaillierSK,
+		AuxInfo:    auxInfo,
+	}, nil
+}
+
+// cggmpCheckDealerCommitment 校验旧成员 dealer 的常数项承诺等于 λ_dealer·X_dealer
+func cggmpCheckDealerCommitment(oldKey *frostKeyMaterial, oldNodeIDs []string, dealer string, commitment0 *crypto.ECPoint) error {
+	cs := oldKey.cs
+	identifiers := make([]*big.Int, 0, len(oldNodeIDs))
+	for _, nodeID := range oldNodeIDs {
+		identifier, err := oldKey.identifierOf(nodeID)
+		if err != nil {
+			return err
+		}
+		identifiers = append(identifiers, identifier)
+	}
+	dealerIdentifier, err := oldKey.identifierOf(dealer)
+	if err != nil {
+		return err
+	}
+	lambda, err := cs.lagrangeCoefficient(dealerIdentifier, identifiers)
+	if err != nil {
+		return err
+	}
+	verifyingShare, ok := oldKey.verifyingShares[dealer]
+	if !ok {
+		return errors.Errorf("no verifying share for node %s", dealer)
+	}
+	if !cs.scalarMult(verifyingShare, lambda).Equals(commitment0) {
+		return errors.New("constant term commitment does not match the dealer's verifying share")
+	}
+	return nil
+}
+
+// cggmpKeyShares 为所有节点生成 KeyShare 描述（分片本身只保存在各节点的 keyShareStorage 中）
+func cggmpKeyShares(keyID string, keyData *cggmpKeyData) map[string]*KeyShare {
+	return frostKeyShares(keyID, keyData.Share)
+}
+
+// logCGGMPKeyData 记录密钥生成/刷新结果
+func logCGGMPKeyData(msg string, thisNodeID string, keyData *cggmpKeyData) {
+	log.Info().
+		Str("key_id", keyData.Share.KeyID).
+		Str("node_id", thisNodeID).
+		Int("threshold", keyData.Share.Threshold).
+		Int("epoch", keyData.Share.Epoch).
+		Strs("node_ids", keyData.Share.NodeIDs).
+		Int("paillier_bits", keyData.PaillierSK.N.BitLen()).
+		Str("group_public_key", hex.EncodeToString(keyData.Share.GroupPublicKey)).
+		Msg(msg)
+}
@@ -0,0 +1,557 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// cggmpPresignature 本节点的预签名 (R, k_i, χ_i)，只能使用一次
+// Transcript 保存所有签名者的 K_j 和 Ĝ_j = enc_j(Σ_l α̂_{j,l} + β̂_{j,l})，签名失败时用于定位作恶节点
+type cggmpPresignature struct {
+	ID         string                             `json:"id"`
+	KeyID      string                             `json:"key_id"`
+	Epoch      int                                `json:"epoch"`
+	NodeIDs    []string                           `json:"node_ids"`
+	R          []byte                             `json:"r"`
+	K          []byte                             `json:"k"`
+	Chi        []byte                             `json:"chi"`
+	Transcript map[string]*cggmpPresignTranscript `json:"transcript"`
+}
+
+// cggmpPresignTranscript 签名者 j 的公开预签名数据
+type cggmpPresignTranscript struct {
+	K    *big.Int `json:"k"`
+	GHat *big.Int `json:"g_hat"`
+}
+
+// cggmpPresign1Payload 预签名第一轮广播：K_i = enc_i(k_i)、G_i = enc_i(γ_i)，以及针对每个验证方的 Π^enc
+type cggmpPresign1Payload struct {
+	K         *big.Int                  `json:"k"`
+	G         *big.Int                  `json:"g"`
+	EncProofs map[string]*cggmpEncProof `json:"enc_proofs"`
+}
+
+// cggmpMtAPayload 发送方 i 与接收方 j 的 MtA 数据
+// D = K_j^γ_i · enc_j(y)、F = enc_i(y)（D̂、F̂ 对应 w_i），证明使用接收方 j 的环 Pedersen 参数
+type cggmpMtAPayload struct {
+	D        *big.Int           `json:"d"`
+	F        *big.Int           `json:"f"`
+	DHat     *big.Int           `json:"d_hat"`
+	FHat     *big.Int           `json:"f_hat"`
+	AffG     *cggmpAffGProof    `json:"aff_g"`
+	AffGHat  *cggmpAffGProof    `json:"aff_g_hat"`
+	LogStarG *cggmpLogStarProof `json:"log_star_g"`
+}
+
+// cggmpPresign2Payload 预签名第二轮广播：Γ_i 和发给每个签名者的 MtA 数据
+// 整体广播，使所有签名者拥有相同的 MtA 密文（可识别中止时需要）
+type cggmpPresign2Payload struct {
+	Gamma []byte                      `json:"gamma"`
+	MtA   map[string]*cggmpMtAPayload `json:"mta"`
+}
+
+// cggmpPresign3Payload 预签名第三轮广播：Δ_i = k_i·Γ、δ_i 和针对每个验证方的 Π^log*
+type cggmpPresign3Payload struct {
+	Delta      []byte                        `json:"delta"`
+	DeltaShare []byte                        `json:"delta_share"`
+	LogStar    map[string]*cggmpLogStarProof `json:"log_star"`
+}
+
+// cggmpDecBlamePayload 可识别中止时广播的证明：H = K^x·ρ^N（Π^mul*）以及由 H 构造的密文解密结果与公开值一致（Π^dec）
+type cggmpDecBlamePayload struct {
+	H       *big.Int                      `json:"h"`
+	MulStar map[string]*cggmpMulStarProof `json:"mul_star"`
+	Dec     map[string]*cggmpDecProof     `json:"dec"`
+}
+
+// cggmpSignerSet 签名者集合及其公开参数
+type cggmpSignerSet struct {
+	key     *cggmpKeyMaterial
+	signers []string
+	others  []string
+	// lambdas 各签名者的 Lagrange 系数，W_j = λ_j·X_j
+	lambdas map[string]*big.Int
+	bigW    map[string]*crypto.ECPoint
+}
+
+// newCGGMPSignerSet 校验签名者并计算 Lagrange 系数
+func (p *CGGMPProtocol) newCGGMPSignerSet(key *cggmpKeyMaterial, nodeIDs []string) (*cggmpSignerSet, error) {
+	cs := key.cs
+	signers, err := selectSigners(key.frostKeyMaterial, p.thisNodeID, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	identifiers := make([]*big.Int, 0, len(signers))
+	for _, nodeID := range signers {
+		identifier, _ := key.identifierOf(nodeID)
+		identifiers = append(identifiers, identifier)
+	}
+	set := &cggmpSignerSet{
+		key:     key,
+		signers: signers,
+		others:  p.otherNodeIDs(signers),
+		lambdas: make(map[string]*big.Int, len(signers)),
+		bigW:    make(map[string]*crypto.ECPoint, len(signers)),
+	}
+	for i, nodeID := range signers {
+		lambda, err := cs.lagrangeCoefficient(identifiers[i], identifiers)
+		if err != nil {
+			return nil, err
+		}
+		set.lambdas[nodeID] = lambda
+		set.bigW[nodeID] = cs.scalarMult(key.verifyingShares[nodeID], lambda)
+	}
+	return set, nil
+}
+
+// paillierN 节点的 Paillier 模数
+func (s *cggmpSignerSet) paillierN(nodeID string) *big.Int {
+	return s.key.auxInfo[nodeID].PaillierN
+}
+
+// pedersen 节点作为验证方时的环 Pedersen 参数
+func (s *cggmpSignerSet) pedersen(nodeID string) *cggmpPedersen {
+	return s.key.auxInfo[nodeID].pedersen()
+}
+
+// cggmpProofContext 证明上下文，绑定会话、轮次、证明方和验证方
+func cggmpProofContext(sessionID, round, prover, verifier string) []byte {
+	return []byte("cggmp21/" + sessionID + "/" + round + "/" + prover + "/" + verifier)
+}
+
+// cggmpPaillierDecrypt 使用本节点私钥解密并恢复随机数（可识别中止时证明解密结果）
+func cggmpPaillierDecrypt(key *cggmpKeyMaterial, c *big.Int) (*big.Int, *big.Int, error) {
+	sk := key.paillierSK
+	m, err := sk.Decrypt(c)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "paillier decryption failed")
+	}
+	rho, err := paillierRandomness(sk.PhiN, sk.N, c, m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paillierCentered(m, sk.N), rho, nil
+}
+
+// cggmpMulCiphertexts 计算 Π num · Π den⁻¹ mod n²（同态相加/相减明文）
+func cggmpMulCiphertexts(n *big.Int, num []*big.Int, den []*big.Int) (*big.Int, error) {
+	n2 := new(big.Int).Mul(n, n)
+	modN2 := common.ModInt(n2)
+	acc := big.NewInt(1)
+	for _, c := range num {
+		acc = modN2.Mul(acc, c)
+	}
+	for _, c := range den {
+		inv := new(big.Int).ModInverse(c, n2)
+		if inv == nil {
+			return nil, errors.New("ciphertext is not invertible")
+		}
+		acc = modN2.Mul(acc, inv)
+	}
+	return acc, nil
+}
+
+// presign 执行预签名（本节点认领并释放会话收件箱）
+func (p *CGGMPProtocol) presign(ctx context.Context, sessionID string, keyID string, nodeIDs []string, key *cggmpKeyMaterial) (*cggmpPresignature, error) {
+	set, err := p.newCGGMPSignerSet(key, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	inbox := p.hub.claim(sessionID)
+	defer p.hub.release(sessionID)
+	return p.runPresign(ctx, inbox, sessionID, keyID, set)
+}
+
+// runPresign 执行 CGGMP21 三轮预签名
+//
+//	第一轮：广播 K_i、G_i 及 Π^enc
+//	第二轮：广播 Γ_i，与每个签名者执行两组 MtA（k·γ 和 k·w），附 Π^aff-g 和 Π^log*
+//	第三轮：广播 Δ_i = k_i·Γ、δ_i 及 Π^log*；δ·G ≠ ΣΔ_j 时所有签名者公开证明 δ_i，定位作恶节点
+func (p *CGGMPProtocol) runPresign(ctx context.Context, inbox *frostInbox, sessionID string, keyID string, set *cggmpSignerSet) (*cggmpPresignature, error) {
+	cs := frostSecp256k1Suite
+	key := set.key
+	me := p.thisNodeID
+	myN := set.paillierN(me)
+	myPed := set.pedersen(me)
+	modQ := common.ModInt(cs.order())
+	generator := cs.scalarBaseMult(cggmpOne)
+
+	// 第一轮
+	k, err := cs.randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := cs.randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	rhoK := cggmpRandomUnit(myN)
+	nuG := cggmpRandomUnit(myN)
+	myK := paillierEncryptWith(myN, k, rhoK)
+	myG := paillierEncryptWith(myN, gamma, nuG)
+	round1 := &cggmpPresign1Payload{K: myK, G: myG, EncProofs: make(map[string]*cggmpEncProof, len(set.others))}
+	for _, nodeID := range set.others {
+		round1.EncProofs[nodeID] = cggmpProveEnc(cggmpProofContext(sessionID, cggmpRoundPresign1, me, nodeID), set.pedersen(nodeID), myN, myK, k, rhoK)
+	}
+	if err := p.sendCGGMPMessage(sessionID, cggmpRoundPresign1, set.others, round1, true); err != nil {
+		return nil, err
+	}
+	received, err := inbox.collect(ctx, cggmpRoundPresign1, set.others)
+	if err != nil {
+		return nil, err
+	}
+	bigK := map[string]*big.Int{me: myK}
+	bigG := map[string]*big.Int{me: myG}
+	for _, nodeID := range set.others {
+		var payload cggmpPresign1Payload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed presign message", nodeID)
+		}
+		n := set.paillierN(nodeID)
+		n2 := new(big.Int).Mul(n, n)
+		if !cggmpInUnitGroup(payload.K, n2) || !cggmpInUnitGroup(payload.G, n2) {
+			return nil, newAbortError("CGGMP21 presign", sessionID, fmt.Sprintf("node %s sent invalid ciphertexts", nodeID), []string{nodeID}, nil)
+		}
+		if err := payload.EncProofs[me].verify(cggmpProofContext(sessionID, cggmpRoundPresign1, nodeID, me), myPed, n, payload.K); err != nil {
+			return nil, newAbortError("CGGMP21 presign", sessionID, fmt.Sprintf("node %s failed the encryption range proof", nodeID), []string{nodeID}, err)
+		}
+		bigK[nodeID] = payload.K
+		bigG[nodeID] = payload.G
+	}
+
+	// 第二轮
+	w := modQ.Mul(set.lambdas[me], key.secretShare)
+	myGamma := cs.scalarBaseMult(gamma)
+	round2 := &cggmpPresign2Payload{Gamma: cs.serializeElement(myGamma), MtA: make(map[string]*cggmpMtAPayload, len(set.others))}
+	betaSum := big.NewInt(0)
+	betaHatSum := big.NewInt(0)
+	for _, nodeID := range set.others {
+		ctxBytes := cggmpProofContext(sessionID, cggmpRoundPresign2, me, nodeID)
+		ped := set.pedersen(nodeID)
+		n := set.paillierN(nodeID)
+		mta := &cggmpMtAPayload{}
+		mta.D, mta.F, mta.AffG = cggmpMtA(ctxBytes, ped, n, myN, bigK[nodeID], gamma, myGamma, &betaSum)
+		mta.DHat, mta.FHat, mta.AffGHat = cggmpMtA(ctxBytes, ped, n, myN, bigK[nodeID], w, set.bigW[me], &betaHatSum)
+		mta.LogStarG = cggmpProveLogStar(ctxBytes, ped, myN, myG, gamma, nuG, generator, myGamma)
+		round2.MtA[nodeID] = mta
+	}
+	if err := p.sendCGGMPMessage(sessionID, cggmpRoundPresign2, set.others, round2, true); err != nil {
+		return nil, err
+	}
+	received, err = inbox.collect(ctx, cggmpRoundPresign2, set.others)
+	if err != nil {
+		return nil, err
+	}
+	// mta[接收方][发送方]
+	mtas := make(map[string]map[string]*cggmpMtAPayload, len(set.signers))
+	for _, nodeID := range set.signers {
+		mtas[nodeID] = make(map[string]*cggmpMtAPayload, len(set.signers))
+	}
+	for recipient, mta := range round2.MtA {
+		mtas[recipient][me] = mta
+	}
+	gammas := map[string]*crypto.ECPoint{me: myGamma}
+	bigGamma := myGamma
+	alphaSum := big.NewInt(0)
+	alphaHatSum := big.NewInt(0)
+	for _, nodeID := range set.others {
+		var payload cggmpPresign2Payload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed presign message", nodeID)
+		}
+		culprit := func(reason string, cause error) error {
+			return newAbortError("CGGMP21 presign", sessionID, fmt.Sprintf("node %s %s", nodeID, reason), []string{nodeID}, cause)
+		}
+		gammaJ, err := cs.deserializeElement(payload.Gamma)
+		if err != nil {
+			return nil, culprit("sent an invalid Γ", err)
+		}
+		n := set.paillierN(nodeID)
+		for _, recipient := range set.signers {
+			if recipient == nodeID {
+				continue
+			}
+			mta := payload.MtA[recipient]
+			rn := set.paillierN(recipient)
+			if mta == nil || !cggmpInUnitGroup(mta.D, new(big.Int).Mul(rn, rn)) || !cggmpInUnitGroup(mta.DHat, new(big.Int).Mul(rn, rn)) ||
+				!cggmpInUnitGroup(mta.F, new(big.Int).Mul(n, n)) || !cggmpInUnitGroup(mta.FHat, new(big.Int).Mul(n, n)) {
+				return nil, culprit(fmt.Sprintf("sent invalid MtA ciphertexts for node %s", recipient), nil)
+			}
+			mtas[recipient][nodeID] = mta
+		}
+		mta := payload.MtA[me]
+		ctxBytes := cggmpProofContext(sessionID, cggmpRoundPresign2, nodeID, me)
+		if err := mta.AffG.verify(ctxBytes, myPed, &cggmpAffGStatement{N0: myN, N1: n, C: myK, D: mta.D, Y: mta.F, X: gammaJ}); err != nil {
+			return nil, culprit("failed the MtA proof for k·γ", err)
+		}
+		if err := mta.AffGHat.verify(ctxBytes, myPed, &cggmpAffGStatement{N0: myN, N1: n, C: myK, D: mta.DHat, Y: mta.FHat, X: set.bigW[nodeID]}); err != nil {
+			return nil, culprit("failed the MtA proof for k·w", err)
+		}
+		if err := mta.LogStarG.verify(ctxBytes, myPed, n, bigG[nodeID], generator, gammaJ); err != nil {
+			return nil, culprit("failed the proof for Γ", err)
+		}
+		alpha, _, err := cggmpPaillierDecrypt(key, mta.D)
+		if err != nil {
+			return nil, err
+		}
+		alphaHat, _, err := cggmpPaillierDecrypt(key, mta.DHat)
+		if err != nil {
+			return nil, err
+		}
+		alphaSum.Add(alphaSum, alpha)
+		alphaHatSum.Add(alphaHatSum, alphaHat)
+		gammas[nodeID] = gammaJ
+		if bigGamma, err = cs.addPoints(bigGamma, gammaJ); err != nil {
+			return nil, errors.Wrap(err, "invalid combined Γ")
+		}
+	}
+
+	// 第三轮
+	delta := modQ.Add(modQ.Mul(k, gamma), new(big.Int).Add(alphaSum, betaSum))
+	chi := modQ.Add(modQ.Mul(k, w), new(big.Int).Add(alphaHatSum, betaHatSum))
+	myDelta := cs.scalarMult(bigGamma, k)
+	round3 := &cggmpPresign3Payload{
+		Delta:      cs.serializeElement(myDelta),
+		DeltaShare: cs.serializeScalar(delta),
+		LogStar:    make(map[string]*cggmpLogStarProof, len(set.others)),
+	}
+	for _, nodeID := range set.others {
+		round3.LogStar[nodeID] = cggmpProveLogStar(cggmpProofContext(sessionID, cggmpRoundPresign3, me, nodeID), set.pedersen(nodeID), myN, myK, k, rhoK, bigGamma, myDelta)
+	}
+	if err := p.sendCGGMPMessage(sessionID, cggmpRoundPresign3, set.others, round3, true); err != nil {
+		return nil, err
+	}
+	received, err = inbox.collect(ctx, cggmpRoundPresign3, set.others)
+	if err != nil {
+		return nil, err
+	}
+	deltaShares := map[string]*big.Int{me: delta}
+	deltaSum := delta
+	bigDeltaSum := myDelta
+	for _, nodeID := range set.others {
+		var payload cggmpPresign3Payload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed presign message", nodeID)
+		}
+		deltaJ, err := cs.deserializeElement(payload.Delta)
+		if err == nil {
+			err = payload.LogStar[me].verify(cggmpProofContext(sessionID, cggmpRoundPresign3, nodeID, me), myPed, set.paillierN(nodeID), bigK[nodeID], bigGamma, deltaJ)
+		}
+		if err != nil {
+			return nil, newAbortError("CGGMP21 presign", sessionID, fmt.Sprintf("node %s failed the proof for Δ", nodeID), []string{nodeID}, err)
+		}
+		share, err := cs.deserializeScalar(payload.DeltaShare)
+		if err != nil {
+			return nil, newAbortError("CGGMP21 presign", sessionID, fmt.Sprintf("node %s sent an invalid δ share", nodeID), []string{nodeID}, err)
+		}
+		deltaShares[nodeID] = share
+		deltaSum = modQ.Add(deltaSum, share)
+		if bigDeltaSum, err = cs.addPoints(bigDeltaSum, deltaJ); err != nil {
+			return nil, errors.Wrap(err, "invalid combined Δ")
+		}
+	}
+
+	// δ·G = Σ Δ_j = k·γ·G，否则有签名者的 MtA 结果错误
+	if deltaSum.Sign() == 0 || !cs.scalarBaseMult(deltaSum).Equals(bigDeltaSum) {
+		culprits, err := p.presignBlame(ctx, inbox, sessionID, set, mtas, bigK, gammas, deltaShares, gamma)
+		if err != nil {
+			return nil, err
+		}
+		return nil, newAbortError("CGGMP21 presign", sessionID, "δ share verification failed", culprits, nil)
+	}
+
+	bigR := cs.scalarMult(bigGamma, modQ.ModInverse(deltaSum))
+	presig := &cggmpPresignature{
+		ID:         sessionID,
+		KeyID:      keyID,
+		Epoch:      key.data.Epoch,
+		NodeIDs:    set.signers,
+		R:          cs.serializeElement(bigR),
+		K:          cs.serializeScalar(k),
+		Chi:        cs.serializeScalar(chi),
+		Transcript: make(map[string]*cggmpPresignTranscript, len(set.signers)),
+	}
+	for _, nodeID := range set.signers {
+		gHat, err := cggmpMtASum(set, mtas, nodeID, true)
+		if err != nil {
+			return nil, err
+		}
+		presig.Transcript[nodeID] = &cggmpPresignTranscript{K: bigK[nodeID], GHat: gHat}
+	}
+
+	log.Info().
+		Str("key_id", keyID).
+		Str("session_id", sessionID).
+		Str("node_id", me).
+		Strs("signers", set.signers).
+		Msg("CGGMP21 presign completed")
+
+	return presig, nil
+}
+
+// cggmpMtA 发送方执行一次 MtA：D = C^x · enc_N0(y)、F = enc_N1(y)，本方份额 β = -y 累加到 betaSum
+func cggmpMtA(context []byte, ped *cggmpPedersen, n0, n1, c, x *big.Int, bigX *crypto.ECPoint, betaSum **big.Int) (*big.Int, *big.Int, *cggmpAffGProof) {
+	n02 := new(big.Int).Mul(n0, n0)
+	y := cggmpRandomSigned(cggmpEllPrime, nil)
+	rho := cggmpRandomUnit(n0)
+	rhoY := cggmpRandomUnit(n1)
+	d := common.ModInt(n02).Mul(new(big.Int).Exp(c, x, n02), paillierEncryptWith(n0, y, rho))
+	f := paillierEncryptWith(n1, y, rhoY)
+	*betaSum = new(big.Int).Sub(*betaSum, y)
+	pf := cggmpProveAffG(context, ped, &cggmpAffGStatement{N0: n0, N1: n1, C: c, D: d, Y: f, X: bigX}, x, y, rho, rhoY)
+	return d, f, pf
+}
+
+// cggmpMtASum 计算签名者 j 的 MtA 份额之和的密文 Π_l D_{j,l}·F_{l,j}⁻¹（hat 为 true 时使用 D̂、F̂）
+func cggmpMtASum(set *cggmpSignerSet, mtas map[string]map[string]*cggmpMtAPayload, nodeID string, hat bool) (*big.Int, error) {
+	var num, den []*big.Int
+	for _, other := range set.signers {
+		if other == nodeID {
+			continue
+		}
+		received, sent := mtas[nodeID][other], mtas[other][nodeID]
+		if hat {
+			num, den = append(num, received.DHat), append(den, sent.FHat)
+		} else {
+			num, den = append(num, received.D), append(den, sent.F)
+		}
+	}
+	return cggmpMulCiphertexts(set.paillierN(nodeID), num, den)
+}
+
+// presignBlame 预签名 δ 校验失败时的可识别中止：
+// 每个签名者公开 H_i = K_i^γ_i·ρ^N（Π^mul*），并证明 H_i·Π_l D_{i,l}·F_{l,i}⁻¹ 的明文 ≡ δ_i (mod q)（Π^dec）
+func (p *CGGMPProtocol) presignBlame(
+	ctx context.Context,
+	inbox *frostInbox,
+	sessionID string,
+	set *cggmpSignerSet,
+	mtas map[string]map[string]*cggmpMtAPayload,
+	bigK map[string]*big.Int,
+	gammas map[string]*crypto.ECPoint,
+	deltaShares map[string]*big.Int,
+	gamma *big.Int,
+) ([]string, error) {
+	log.Warn().
+		Str("session_id", sessionID).
+		Str("node_id", p.thisNodeID).
+		Msg("CGGMP21 presign δ check failed, starting identifiable abort")
+
+	statement := func(nodeID string) *cggmpBlameStatement {
+		return &cggmpBlameStatement{
+			K:     bigK[nodeID],
+			BigX:  gammas[nodeID],
+			Value: deltaShares[nodeID],
+			ciphertext: func(h *big.Int) (*big.Int, error) {
+				mtaSum, err := cggmpMtASum(set, mtas, nodeID, false)
+				if err != nil {
+					return nil, err
+				}
+				return cggmpMulCiphertexts(set.paillierN(nodeID), []*big.Int{h, mtaSum}, nil)
+			},
+		}
+	}
+	return p.runBlame(ctx, inbox, sessionID, cggmpRoundPresignBlame, set, statement, gamma)
+}
+
+// cggmpBlameStatement 可识别中止中证明方 i 的公开陈述
+// H = K^x·ρ^N 且 X = x·G（Π^mul*），ciphertext(H) 的明文 ≡ Value (mod q)（Π^dec）
+type cggmpBlameStatement struct {
+	K     *big.Int
+	BigX  *crypto.ECPoint
+	Value *big.Int
+
+	ciphertext func(h *big.Int) (*big.Int, error)
+}
+
+// runBlame 执行一轮可识别中止：广播本节点的证明并校验其他签名者的证明，返回证明失败的节点
+func (p *CGGMPProtocol) runBlame(
+	ctx context.Context,
+	inbox *frostInbox,
+	sessionID string,
+	round string,
+	set *cggmpSignerSet,
+	statement func(nodeID string) *cggmpBlameStatement,
+	x *big.Int,
+) ([]string, error) {
+	me := p.thisNodeID
+	myN := set.paillierN(me)
+	n2 := new(big.Int).Mul(myN, myN)
+	mine := statement(me)
+
+	rho := cggmpRandomUnit(myN)
+	h := common.ModInt(n2).Mul(new(big.Int).Exp(mine.K, x, n2), new(big.Int).Exp(rho, myN, n2))
+	c, err := mine.ciphertext(h)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, decRho, err := cggmpPaillierDecrypt(set.key, c)
+	if err != nil {
+		return nil, err
+	}
+	payload := &cggmpDecBlamePayload{
+		H:       h,
+		MulStar: make(map[string]*cggmpMulStarProof, len(set.others)),
+		Dec:     make(map[string]*cggmpDecProof, len(set.others)),
+	}
+	for _, nodeID := range set.others {
+		ctxBytes := cggmpProofContext(sessionID, round, me, nodeID)
+		ped := set.pedersen(nodeID)
+		payload.MulStar[nodeID] = cggmpProveMulStar(ctxBytes, ped, myN, mine.K, h, mine.BigX, x, rho)
+		payload.Dec[nodeID] = cggmpProveDec(ctxBytes, ped, myN, c, mine.Value, plaintext, decRho)
+	}
+	if err := p.sendCGGMPMessage(sessionID, round, set.others, payload, true); err != nil {
+		return nil, err
+	}
+	received, err := inbox.collect(ctx, round, set.others)
+	if err != nil {
+		return nil, err
+	}
+
+	var culprits []string
+	for _, nodeID := range set.others {
+		if err := cggmpVerifyBlame(sessionID, round, set, nodeID, me, received[nodeID], statement(nodeID)); err != nil {
+			log.Warn().
+				Err(err).
+				Str("session_id", sessionID).
+				Str("culprit", nodeID).
+				Msg("CGGMP21 blame proof failed")
+			culprits = append(culprits, nodeID)
+		}
+	}
+	if len(culprits) == 0 {
+		return nil, errors.Errorf("%s: verification failed but all blame proofs are valid", round)
+	}
+	sort.Strings(culprits)
+	return culprits, nil
+}
+
+// cggmpVerifyBlame 校验证明方在可识别中止轮次中发送的证明
+func cggmpVerifyBlame(sessionID, round string, set *cggmpSignerSet, prover, verifier string, msg *frostWireMessage, st *cggmpBlameStatement) error {
+	var payload cggmpDecBlamePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return errors.Wrap(err, "malformed blame message")
+	}
+	n := set.paillierN(prover)
+	if !cggmpInUnitGroup(payload.H, new(big.Int).Mul(n, n)) {
+		return errors.Wrap(errCGGMPProof, "invalid H ciphertext")
+	}
+	c, err := st.ciphertext(payload.H)
+	if err != nil {
+		return err
+	}
+	ctxBytes := cggmpProofContext(sessionID, round, prover, verifier)
+	ped := set.pedersen(verifier)
+	if err := payload.MulStar[verifier].verify(ctxBytes, ped, n, st.K, payload.H, st.BigX); err != nil {
+		return err
+	}
+	return payload.Dec[verifier].verify(ctxBytes, ped, n, c, st.Value)
+}
@@ -0,0 +1,220 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// cggmpSignSharePayload 在线签名广播的签名分片 σ_i
+type cggmpSignSharePayload struct {
+	Sigma []byte `json:"sigma"`
+}
+
+// sign 执行在线签名：σ_i = k_i·(m + r·δ) + r·χ_i，δ 为 BIP-32 派生调整值（未派生时为 0）
+// 签名校验失败时每个签名者公开证明 σ_i，定位作恶节点
+func (p *CGGMPProtocol) sign(ctx context.Context, sessionID string, req *SignRequest, key *cggmpKeyMaterial, message []byte) (*Signature, *PublicKey, error) {
+	cs := frostSecp256k1Suite
+	modQ := common.ModInt(cs.order())
+
+	set, err := p.newCGGMPSignerSet(key, req.NodeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pubKey := key.groupPublicKey
+	publicKey := key.publicKey()
+	tweak := big.NewInt(0)
+	derived, err := deriveForSignRequest("secp256k1", publicKey.Bytes, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if derived != nil {
+		pubKey, publicKey, tweak = derived.point, derived.publicKey(), derived.tweak
+	}
+
+	inbox := p.hub.claim(sessionID)
+	defer p.hub.release(sessionID)
+
+	var presig *cggmpPresignature
+	if req.PresignatureID != "" {
+		presig, err = p.consumePresignature(ctx, req.KeyID, req.PresignatureID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if presig.Epoch != key.data.Epoch {
+			return nil, nil, errors.Errorf("presignature %s was generated for a previous key share", req.PresignatureID)
+		}
+		if !sameNodeSet(presig.NodeIDs, set.signers) {
+			return nil, nil, errors.Errorf("presignature %s was generated by nodes %v, but signing requested nodes %v", req.PresignatureID, presig.NodeIDs, set.signers)
+		}
+	} else {
+		// 未指定预签名：在同一会话中执行预签名，预签名结果不落盘
+		presig, err = p.runPresign(ctx, inbox, sessionID, req.KeyID, set)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	bigR, err := cs.deserializeElement(presig.R)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid presignature R")
+	}
+	k, err := cs.deserializeScalar(presig.K)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid presignature nonce")
+	}
+	chi, err := cs.deserializeScalar(presig.Chi)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid presignature share")
+	}
+
+	hash := ECDSADigest(message, req.Prehashed)
+	r := new(big.Int).Mod(bigR.X(), cs.order())
+	// m' = m + r·δ：派生子密钥 x + δ 的签名分片之和为 k·(m + r·(x + δ))
+	mPrime := modQ.Add(new(big.Int).SetBytes(hash), modQ.Mul(r, tweak))
+	sigma := modQ.Add(modQ.Mul(k, mPrime), modQ.Mul(r, chi))
+
+	if err := p.sendCGGMPMessage(sessionID, cggmpRoundSign, set.others, &cggmpSignSharePayload{Sigma: cs.serializeScalar(sigma)}, true); err != nil {
+		return nil, nil, err
+	}
+	received, err := inbox.collect(ctx, cggmpRoundSign, set.others)
+	if err != nil {
+		return nil, nil, err
+	}
+	sigmas := map[string]*big.Int{p.thisNodeID: sigma}
+	s := sigma
+	for _, nodeID := range set.others {
+		var payload cggmpSignSharePayload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, nil, errors.Wrapf(err, "node %s sent a malformed signature share", nodeID)
+		}
+		share, err := cs.deserializeScalar(payload.Sigma)
+		if err != nil {
+			return nil, nil, newAbortError("CGGMP21", sessionID, fmt.Sprintf("node %s sent an invalid signature share", nodeID), []string{nodeID}, err)
+		}
+		sigmas[nodeID] = share
+		s = modQ.Add(s, share)
+	}
+
+	sigData, err := finalizePresignedSignature(tss.S256(), pubKey, hash, bigR.X(), bigR.Y(), s)
+	if err != nil {
+		culprits, blameErr := p.signBlame(ctx, inbox, sessionID, set, presig, r, mPrime, sigmas)
+		if blameErr != nil {
+			return nil, nil, errors.Wrapf(blameErr, "signature verification failed (%v)", err)
+		}
+		return nil, nil, newAbortError("CGGMP21", sessionID, "signature share verification failed", culprits, err)
+	}
+	signature, err := convertTSSSignature(sigData)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "convert signature")
+	}
+
+	log.Info().
+		Str("key_id", req.KeyID).
+		Str("session_id", sessionID).
+		Str("node_id", p.thisNodeID).
+		Bool("presigned", req.PresignatureID != "").
+		Bool("derived", derived != nil).
+		Msg("CGGMP21 signing completed")
+
+	return signature, publicKey, nil
+}
+
+// signBlame 签名校验失败时的可识别中止：
+// 每个签名者公开 Ĥ_i = K_i^w_i·ρ^N（Π^mul*，X = W_i），并证明 K_i^m'·(Ĥ_i·Ĝ_i)^r 的明文 ≡ σ_i (mod q)（Π^dec）
+func (p *CGGMPProtocol) signBlame(
+	ctx context.Context,
+	inbox *frostInbox,
+	sessionID string,
+	set *cggmpSignerSet,
+	presig *cggmpPresignature,
+	r, mPrime *big.Int,
+	sigmas map[string]*big.Int,
+) ([]string, error) {
+	log.Warn().
+		Str("session_id", sessionID).
+		Str("node_id", p.thisNodeID).
+		Msg("CGGMP21 signature check failed, starting identifiable abort")
+
+	for _, nodeID := range set.signers {
+		if transcript := presig.Transcript[nodeID]; transcript == nil || !cggmpNonNil(transcript.K, transcript.GHat) {
+			return nil, errors.Errorf("presignature %s has no transcript for node %s", presig.ID, nodeID)
+		}
+	}
+	statement := func(nodeID string) *cggmpBlameStatement {
+		transcript := presig.Transcript[nodeID]
+		n := set.paillierN(nodeID)
+		return &cggmpBlameStatement{
+			K:     transcript.K,
+			BigX:  set.bigW[nodeID],
+			Value: sigmas[nodeID],
+			ciphertext: func(h *big.Int) (*big.Int, error) {
+				n2 := new(big.Int).Mul(n, n)
+				chiCipher, err := cggmpMulCiphertexts(n, []*big.Int{h, transcript.GHat}, nil)
+				if err != nil {
+					return nil, err
+				}
+				return common.ModInt(n2).Mul(new(big.Int).Exp(transcript.K, mPrime, n2), new(big.Int).Exp(chiCipher, r, n2)), nil
+			},
+		}
+	}
+	w := common.ModInt(frostSecp256k1Suite.order()).Mul(set.lambdas[p.thisNodeID], set.key.secretShare)
+	return p.runBlame(ctx, inbox, sessionID, cggmpRoundSignBlame, set, statement, w)
+}
+
+// storePresignature 保存本节点的预签名
+func (p *CGGMPProtocol) storePresignature(ctx context.Context, presig *cggmpPresignature) error {
+	data, err := json.Marshal(presig)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal presignature")
+	}
+	if err := p.keyShareStorage.StoreKeyData(ctx, presignStorageKeyID(presig.KeyID, presig.ID), p.thisNodeID, data); err != nil {
+		return errors.Wrap(err, "failed to store presignature")
+	}
+	return nil
+}
+
+// consumePresignature 读取并删除本节点的预签名（先删除再使用，保证同一 k_i 只用于一次签名）
+func (p *CGGMPProtocol) consumePresignature(ctx context.Context, keyID string, presignID string) (*cggmpPresignature, error) {
+	if !presignIDPattern.MatchString(presignID) {
+		return nil, errors.Errorf("invalid presignature ID: %q", presignID)
+	}
+	if p.keyShareStorage == nil {
+		return nil, errors.New("key share storage is required for presignatures")
+	}
+
+	p.presignMu.Lock()
+	defer p.presignMu.Unlock()
+
+	storageKeyID := presignStorageKeyID(keyID, presignID)
+	data, err := p.keyShareStorage.GetKeyData(ctx, storageKeyID, p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "presignature %s not found or already used", presignID)
+	}
+	if err := p.keyShareStorage.DeleteKeyData(ctx, storageKeyID, p.thisNodeID); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete presignature %s before use", presignID)
+	}
+
+	var presig cggmpPresignature
+	if err := json.Unmarshal(data, &presig); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal presignature")
+	}
+	if presig.ID != presignID || presig.KeyID != keyID {
+		return nil, errors.Errorf("presignature %s does not belong to key %s", presignID, keyID)
+	}
+
+	log.Info().
+		Str("key_id", keyID).
+		Str("presignature_id", presignID).
+		Str("node_id", p.thisNodeID).
+		Msg("CGGMP21 presignature consumed")
+
+	return &presig, nil
+}
@@ -0,0 +1,443 @@
+package protocol
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadCGGMPPreParams 读取测试用的预参数（安全素数在线生成需要数分钟）
+func loadCGGMPPreParams(t *testing.T) []*keygen.LocalPreParams {
+	t.Helper()
+	data, err := os.ReadFile("testdata/cggmp_preparams.json")
+	require.NoError(t, err)
+	var preParams []*keygen.LocalPreParams
+	require.NoError(t, json.Unmarshal(data, &preParams))
+	require.GreaterOrEqual(t, len(preParams), 5)
+	return preParams
+}
+
+// cggmpTestCluster 进程内的多节点 CGGMP21 集群
+// tamper 非空时可以在投递前修改消息（模拟作恶节点）
+type cggmpTestCluster struct {
+	storage   *memoryKeyDataStorage
+	nodes     map[string]*CGGMPProtocol
+	pools     map[string]*PreParamsPool
+	preParams []*keygen.LocalPreParams
+	tamper    func(sessionID string, toNodeID string, msg *frostWireMessage)
+}
+
+func newCGGMPTestCluster(t *testing.T, nodeIDs ...string) *cggmpTestCluster {
+	c := &cggmpTestCluster{
+		storage:   newMemoryKeyDataStorage(),
+		nodes:     make(map[string]*CGGMPProtocol),
+		pools:     make(map[string]*PreParamsPool),
+		preParams: loadCGGMPPreParams(t),
+	}
+	for _, nodeID := range nodeIDs {
+		c.addNode(nodeID)
+	}
+	return c
+}
+
+func (c *cggmpTestCluster) addNode(nodeID string) *CGGMPProtocol {
+	from := nodeID
+	router := func(sessionID string, toNodeID string, msg tss.Message, isBroadcast bool) error {
+		if c.tamper != nil {
+			wire := *msg.(*frostWireMessage)
+			c.tamper(sessionID, toNodeID, &wire)
+			msg = &wire
+		}
+		data, _, err := msg.WireBytes()
+		if err != nil {
+			return err
+		}
+		target, ok := c.nodes[toNodeID]
+		if !ok {
+			return fmt.Errorf("unknown node %s", toNodeID)
+		}
+		ctx := context.Background()
+		switch {
+		case strings.HasPrefix(sessionID, "reshare-"):
+			return target.ProcessIncomingResharingMessage(ctx, sessionID, from, data, isBroadcast)
+		case strings.HasPrefix(sessionID, "key-"):
+			return target.ProcessIncomingKeygenMessage(ctx, sessionID, from, data, isBroadcast)
+		default:
+			return target.ProcessIncomingSigningMessage(ctx, sessionID, from, data, isBroadcast)
+		}
+	}
+	node := NewCGGMPProtocol("secp256k1", nodeID, router, c.storage)
+	pool := NewPreParamsPool(nodeID, nil, 0, 0)
+	node.SetPreParamsPool(pool)
+	c.nodes[nodeID] = node
+	c.pools[nodeID] = pool
+	return node
+}
+
+// givePreParams 向节点的预参数池放入测试预参数
+func (c *cggmpTestCluster) givePreParams(nodeID string, index int) {
+	pool := c.pools[nodeID]
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.entries = append(pool.entries, c.preParams[index])
+}
+
+// cggmpRunAll 在 nodeIDs 上并发执行 fn，返回各节点的结果和错误
+func cggmpRunAll[T any](nodeIDs []string, fn func(ctx context.Context, nodeID string) (T, error)) (map[string]T, map[string]error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]T, len(nodeIDs))
+	errs := make(map[string]error)
+	for _, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+			result, err := fn(ctx, nodeID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[nodeID] = err
+				return
+			}
+			results[nodeID] = result
+		}(nodeID)
+	}
+	wg.Wait()
+	return results, errs
+}
+
+func (c *cggmpTestCluster) keygen(t *testing.T, keyID string, threshold int, nodeIDs []string) *PublicKey {
+	t.Helper()
+	results, errs := cggmpRunAll(nodeIDs, func(ctx context.Context, nodeID string) (*KeyGenResponse, error) {
+		return c.nodes[nodeID].GenerateKeyShare(ctx, &KeyGenRequest{
+			KeyID:      keyID,
+			Algorithm:  "ECDSA",
+			Curve:      "secp256k1",
+			Threshold:  threshold,
+			TotalNodes: len(nodeIDs),
+			NodeIDs:    nodeIDs,
+		})
+	})
+	require.Empty(t, errs)
+	var publicKey *PublicKey
+	for _, resp := range results {
+		if publicKey == nil {
+			publicKey = resp.PublicKey
+		}
+		require.Equal(t, publicKey.Hex, resp.PublicKey.Hex, "all nodes must derive the same public key")
+		require.Len(t, resp.KeyShares, len(nodeIDs))
+	}
+	return publicKey
+}
+
+func (c *cggmpTestCluster) sign(t *testing.T, sessionID string, req SignRequest) *SignResponse {
+	t.Helper()
+	results, errs := cggmpRunAll(req.NodeIDs, func(ctx context.Context, nodeID string) (*SignResponse, error) {
+		r := req
+		return c.nodes[nodeID].ThresholdSign(ctx, sessionID, &r)
+	})
+	require.Empty(t, errs)
+	var resp *SignResponse
+	for _, r := range results {
+		if resp == nil {
+			resp = r
+		}
+		require.Equal(t, resp.Signature.Hex, r.Signature.Hex, "all signers must produce the same signature")
+	}
+	return resp
+}
+
+func (c *cggmpTestCluster) presign(t *testing.T, presignID string, keyID string, signers []string) {
+	t.Helper()
+	_, errs := cggmpRunAll(signers, func(ctx context.Context, nodeID string) (*PresignResponse, error) {
+		return c.nodes[nodeID].Presign(ctx, presignID, &PresignRequest{KeyID: keyID, NodeIDs: signers})
+	})
+	require.Empty(t, errs)
+}
+
+// TestCGGMPProtocol_EndToEnd keygen、签名（含预签名和 BIP-32 派生）和密钥刷新的端到端测试
+func TestCGGMPProtocol_EndToEnd(t *testing.T) {
+	c := newCGGMPTestCluster(t, "node-1", "node-2", "node-3", "node-4")
+	for i, nodeID := range []string{"node-1", "node-2", "node-3"} {
+		c.givePreParams(nodeID, i)
+	}
+	keyID := "key-cggmp"
+	publicKey := c.keygen(t, keyID, 2, []string{"node-1", "node-2", "node-3"})
+	require.Len(t, publicKey.Bytes, 33)
+
+	message := []byte("cggmp21 end to end")
+
+	// 未指定预签名：同一会话内完成预签名和签名
+	resp := c.sign(t, "sign-1", SignRequest{KeyID: keyID, Message: message, NodeIDs: []string{"node-1", "node-3"}})
+	valid, err := c.nodes["node-1"].VerifySignature(context.Background(), resp.Signature, message, publicKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// 预签名 + 单轮在线签名
+	signers := []string{"node-1", "node-2", "node-3"}
+	c.presign(t, "presign-1", keyID, signers)
+	resp = c.sign(t, "sign-2", SignRequest{KeyID: keyID, Message: message, NodeIDs: signers, PresignatureID: "presign-1"})
+	valid, err = verifyECDSASignature(resp.Signature, message, publicKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// 预签名只能使用一次
+	_, err = c.nodes["node-2"].ThresholdSign(context.Background(), "sign-3", &SignRequest{KeyID: keyID, Message: message, NodeIDs: signers, PresignatureID: "presign-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already used")
+
+	// BIP-32 派生子密钥签名
+	chainCode := sha256.Sum256([]byte("chain code"))
+	derived, err := DeriveChildPublicKey("secp256k1", publicKey.Bytes, chainCode[:], "m/0/7")
+	require.NoError(t, err)
+	resp = c.sign(t, "sign-4", SignRequest{KeyID: keyID, Message: message, NodeIDs: []string{"node-2", "node-3"}, DerivationPath: "m/0/7", ChainCode: chainCode[:]})
+	assert.Equal(t, derived.PublicKey, resp.PublicKey.Bytes)
+	valid, err = verifyECDSASignature(resp.Signature, message, resp.PublicKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// 预哈希签名：直接签名 32 字节摘要（EIP-191/EIP-712），不是 32 字节摘要时拒绝
+	digest := sha256.Sum256([]byte("prehashed digest"))
+	resp = c.sign(t, "sign-prehashed", SignRequest{KeyID: keyID, Message: digest[:], NodeIDs: []string{"node-1", "node-2"}, Prehashed: true})
+	valid, err = VerifyECDSADigest(resp.Signature, digest[:], publicKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	_, err = c.nodes["node-1"].ThresholdSign(context.Background(), "sign-prehashed-invalid", &SignRequest{KeyID: keyID, Message: message, NodeIDs: []string{"node-1", "node-2"}, Prehashed: true})
+	assert.Error(t, err)
+
+	// 刷新：node-1 退出，node-4 加入，所有新成员更换 aux info，公钥不变
+	c.givePreParams("node-2", 3)
+	c.givePreParams("node-3", 4)
+	c.givePreParams("node-4", 0)
+	c.presign(t, "presign-2", keyID, []string{"node-2", "node-3"})
+	refreshReq := ReshareRequest{
+		SessionID:    "reshare-cggmp",
+		KeyID:        keyID,
+		OldNodeIDs:   []string{"node-1", "node-2"},
+		OldThreshold: 2,
+		NewNodeIDs:   []string{"node-2", "node-3", "node-4"},
+		NewThreshold: 2,
+		OldEpoch:     0,
+		NewEpoch:     1,
+	}
+	oldN := c.nodes["node-2"].keyRecords[keyID].paillierSK.N
+	refreshed, errs := cggmpRunAll([]string{"node-1", "node-2", "node-3", "node-4"}, func(ctx context.Context, nodeID string) (*ReshareResponse, error) {
+		r := refreshReq
+		return c.nodes[nodeID].RotateKey(ctx, &r)
+	})
+	require.Empty(t, errs)
+	for nodeID, r := range refreshed {
+		assert.Equal(t, publicKey.Hex, r.PublicKey.Hex, "node %s", nodeID)
+	}
+	assert.Nil(t, refreshed["node-1"].KeyShare)
+	_, err = c.storage.GetKeyData(context.Background(), keyID, "node-1")
+	assert.Error(t, err, "old share must be discarded")
+	assert.NotEqual(t, 0, oldN.Cmp(c.nodes["node-2"].keyRecords[keyID].paillierSK.N), "refresh must replace the paillier key")
+
+	// 刷新前生成的预签名不能再使用
+	_, err = c.nodes["node-2"].ThresholdSign(context.Background(), "sign-5", &SignRequest{KeyID: keyID, Message: message, NodeIDs: []string{"node-2", "node-3"}, PresignatureID: "presign-2"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "previous key share")
+
+	resp = c.sign(t, "sign-6", SignRequest{KeyID: keyID, Message: message, NodeIDs: []string{"node-3", "node-4"}})
+	valid, err = verifyECDSASignature(resp.Signature, message, publicKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+// TestCGGMPProtocol_IdentifiesCheatingSigner 签名者篡改预签名分片后，其他签名者通过可识别中止定位该节点
+func TestCGGMPProtocol_IdentifiesCheatingSigner(t *testing.T) {
+	c := newCGGMPTestCluster(t, "node-1", "node-2", "node-3")
+	nodeIDs := []string{"node-1", "node-2", "node-3"}
+	for i, nodeID := range nodeIDs {
+		c.givePreParams(nodeID, i)
+	}
+	keyID := "key-cggmp-blame"
+	c.keygen(t, keyID, 2, nodeIDs)
+	c.presign(t, "presign-blame", keyID, nodeIDs)
+
+	// node-2 使用错误的 χ_i 计算签名分片
+	storageKeyID := presignStorageKeyID(keyID, "presign-blame")
+	data, err := c.storage.GetKeyData(context.Background(), storageKeyID, "node-2")
+	require.NoError(t, err)
+	var presig cggmpPresignature
+	require.NoError(t, json.Unmarshal(data, &presig))
+	chi := new(big.Int).SetBytes(presig.Chi)
+	presig.Chi = frostSecp256k1Suite.serializeScalar(common.ModInt(frostSecp256k1Suite.order()).Add(chi, big.NewInt(1)))
+	data, err = json.Marshal(&presig)
+	require.NoError(t, err)
+	require.NoError(t, c.storage.StoreKeyData(context.Background(), storageKeyID, "node-2", data))
+
+	_, errs := cggmpRunAll(nodeIDs, func(ctx context.Context, nodeID string) (*SignResponse, error) {
+		return c.nodes[nodeID].ThresholdSign(ctx, "sign-blame", &SignRequest{KeyID: keyID, Message: []byte("blame"), NodeIDs: nodeIDs, PresignatureID: "presign-blame"})
+	})
+	require.Len(t, errs, 3)
+	assert.Equal(t, []string{"node-2"}, Culprits(errs["node-1"]))
+	assert.Equal(t, []string{"node-2"}, Culprits(errs["node-3"]))
+}
+
+// TestCGGMPProtocol_KeygenIdentifiesBadShare keygen 中分片与承诺不一致时中止并指出发送方
+func TestCGGMPProtocol_KeygenIdentifiesBadShare(t *testing.T) {
+	c := newCGGMPTestCluster(t, "node-1", "node-2", "node-3")
+	nodeIDs := []string{"node-1", "node-2", "node-3"}
+	for i, nodeID := range nodeIDs {
+		c.givePreParams(nodeID, i)
+	}
+	c.tamper = func(sessionID string, toNodeID string, msg *frostWireMessage) {
+		if msg.From != "node-3" || toNodeID != "node-1" || msg.Round != cggmpRoundKeygenShare {
+			return
+		}
+		var payload cggmpKeygenSharePayload
+		require.NoError(t, json.Unmarshal(msg.Payload, &payload))
+		share := new(big.Int).Add(new(big.Int).SetBytes(payload.Share), big.NewInt(1))
+		payload.Share = frostSecp256k1Suite.serializeScalar(share)
+		data, err := json.Marshal(&payload)
+		require.NoError(t, err)
+		msg.Payload = data
+	}
+
+	_, errs := cggmpRunAll(nodeIDs, func(ctx context.Context, nodeID string) (*KeyGenResponse, error) {
+		return c.nodes[nodeID].GenerateKeyShare(ctx, &KeyGenRequest{
+			KeyID:      "key-cggmp-bad-share",
+			Threshold:  2,
+			TotalNodes: len(nodeIDs),
+			NodeIDs:    nodeIDs,
+		})
+	})
+	require.Contains(t, errs, "node-1")
+	assert.Equal(t, []string{"node-3"}, Culprits(errs["node-1"]))
+}
+
+// TestCGGMPAuxInfo_RejectsInvalidProofs aux info 的 Π^mod、Π^prm 和 Π^fac 校验
+func TestCGGMPAuxInfo_RejectsInvalidProofs(t *testing.T) {
+	preParams := loadCGGMPPreParams(t)
+	aux, err := newCGGMPAuxPayload("key-1", "node-1", preParams[0])
+	require.NoError(t, err)
+	require.NoError(t, aux.verify("key-1", "node-1"))
+
+	// 证明绑定会话和证明方
+	assert.Error(t, aux.verify("key-2", "node-1"))
+	assert.Error(t, aux.verify("key-1", "node-2"))
+
+	// 替换为其他节点的 Paillier 模数
+	other, err := newCGGMPAuxPayload("key-1", "node-1", preParams[1])
+	require.NoError(t, err)
+	forged := *aux
+	forgedInfo := *aux.AuxInfo
+	forgedInfo.PaillierN = other.AuxInfo.PaillierN
+	forged.AuxInfo = &forgedInfo
+	assert.Error(t, forged.verify("key-1", "node-1"))
+
+	// 缺失 dlnproof 字段
+	forged = *aux
+	forged.DLNProof1 = nil
+	assert.Error(t, forged.verify("key-1", "node-1"))
+
+	verifier := other.AuxInfo
+	facProof, err := newCGGMPFacProof("key-1", "node-1", "node-2", preParams[0].PaillierSK, verifier)
+	require.NoError(t, err)
+	assert.True(t, verifyCGGMPFacProof(facProof, "key-1", "node-1", "node-2", aux.AuxInfo, verifier))
+	assert.False(t, verifyCGGMPFacProof(facProof, "key-1", "node-3", "node-2", aux.AuxInfo, verifier))
+}
+
+// TestCGGMPProofs 零知识证明的正确性和可靠性（篡改陈述后校验失败）
+func TestCGGMPProofs(t *testing.T) {
+	cs := frostSecp256k1Suite
+	preParams := loadCGGMPPreParams(t)
+	prover, verifier := preParams[0], preParams[1]
+	n0 := prover.PaillierSK.N
+	n1 := verifier.PaillierSK.N
+	ped := &cggmpPedersen{N: verifier.NTildei, S: verifier.H1i, T: verifier.H2i}
+	proofContext := []byte("test")
+
+	x, err := cs.randomScalar()
+	require.NoError(t, err)
+	rho := cggmpRandomUnit(n0)
+	c := paillierEncryptWith(n0, x, rho)
+	bigX := cs.scalarBaseMult(x)
+	other := cs.scalarBaseMult(big.NewInt(7))
+
+	t.Run("enc", func(t *testing.T) {
+		pf := cggmpProveEnc(proofContext, ped, n0, c, x, rho)
+		require.NoError(t, pf.verify(proofContext, ped, n0, c))
+		assert.ErrorIs(t, pf.verify([]byte("other"), ped, n0, c), errCGGMPProof)
+		assert.Error(t, pf.verify(proofContext, ped, n0, paillierEncryptWith(n0, x, cggmpRandomUnit(n0))))
+
+		// 超出范围的明文无法通过范围证明
+		large := new(big.Int).Lsh(cggmpOne, cggmpEll+cggmpEpsilon+8)
+		largeC := paillierEncryptWith(n0, large, rho)
+		assert.Error(t, cggmpProveEnc(proofContext, ped, n0, largeC, large, rho).verify(proofContext, ped, n0, largeC))
+	})
+
+	t.Run("log*", func(t *testing.T) {
+		base := cs.scalarBaseMult(big.NewInt(5))
+		pf := cggmpProveLogStar(proofContext, ped, n0, c, x, rho, base, cs.scalarMult(base, x))
+		require.NoError(t, pf.verify(proofContext, ped, n0, c, base, cs.scalarMult(base, x)))
+		assert.Error(t, pf.verify(proofContext, ped, n0, c, base, other))
+	})
+
+	t.Run("aff-g", func(t *testing.T) {
+		// 接收方（验证方）的密文 C = enc_N1(k)，证明方计算 D = C^x·enc_N1(y)、Y = enc_N0(y)
+		k, err := cs.randomScalar()
+		require.NoError(t, err)
+		kCipher := paillierEncryptWith(n1, k, cggmpRandomUnit(n1))
+		var beta *big.Int = big.NewInt(0)
+		d, y, pf := cggmpMtA(proofContext, ped, n1, n0, kCipher, x, bigX, &beta)
+		st := &cggmpAffGStatement{N0: n1, N1: n0, C: kCipher, D: d, Y: y, X: bigX}
+		require.NoError(t, pf.verify(proofContext, ped, st))
+
+		// 接收方解密得到 α = k·x + y，与发送方的 β = -y 相加得到 k·x
+		alpha, err := verifier.PaillierSK.Decrypt(d)
+		require.NoError(t, err)
+		modQ := common.ModInt(cs.order())
+		assert.Equal(t, modQ.Mul(k, x), modQ.Add(paillierCentered(alpha, n1), beta))
+
+		forged := *st
+		forged.X = other
+		assert.Error(t, pf.verify(proofContext, ped, &forged))
+		forged = *st
+		forged.D = common.ModInt(new(big.Int).Mul(n1, n1)).Mul(d, kCipher)
+		assert.Error(t, pf.verify(proofContext, ped, &forged))
+	})
+
+	t.Run("mul*", func(t *testing.T) {
+		n02 := new(big.Int).Mul(n0, n0)
+		r := cggmpRandomUnit(n0)
+		d := common.ModInt(n02).Mul(new(big.Int).Exp(c, x, n02), new(big.Int).Exp(r, n0, n02))
+		pf := cggmpProveMulStar(proofContext, ped, n0, c, d, bigX, x, r)
+		require.NoError(t, pf.verify(proofContext, ped, n0, c, d, bigX))
+		assert.Error(t, pf.verify(proofContext, ped, n0, c, d, other))
+	})
+
+	t.Run("dec", func(t *testing.T) {
+		// 明文为负数且大于 q，证明其模 q 与 x 相同
+		y := new(big.Int).Sub(x, new(big.Int).Lsh(cs.order(), 600))
+		r := cggmpRandomUnit(n0)
+		cipher := paillierEncryptWith(n0, y, r)
+		key := &cggmpKeyMaterial{paillierSK: prover.PaillierSK}
+		plaintext, recovered, err := cggmpPaillierDecrypt(key, cipher)
+		require.NoError(t, err)
+		assert.Equal(t, y, plaintext)
+		assert.Equal(t, r, recovered)
+
+		pf := cggmpProveDec(proofContext, ped, n0, cipher, new(big.Int).Mod(y, cs.order()), plaintext, recovered)
+		require.NoError(t, pf.verify(proofContext, ped, n0, cipher, new(big.Int).Mod(y, cs.order())))
+		assert.Error(t, pf.verify(proofContext, ped, n0, cipher, new(big.Int).Add(new(big.Int).Mod(y, cs.order()), cggmpOne)))
+	})
+}
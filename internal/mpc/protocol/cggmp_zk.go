@@ -0,0 +1,500 @@
+package protocol
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/pkg/errors"
+)
+
+// CGGMP21 零知识证明参数（论文 §2 / 附录 C，ℓ = |q|、ℓ' = 5ℓ、ε = 2ℓ）
+// Paillier 模数至少 2048 位，保证 k·γ + β 等 MtA 中间值不会模 N 回绕
+const (
+	cggmpEll      = 256
+	cggmpEllPrime = 1280
+	cggmpEpsilon  = 512
+)
+
+var (
+	cggmpOne = big.NewInt(1)
+
+	// errCGGMPProof 证明校验失败（调用方负责把证明方标记为责任节点）
+	errCGGMPProof = errors.New("zero-knowledge proof verification failed")
+)
+
+// cggmpPedersen 验证方的环 Pedersen 参数 (N̂, s, t)，取自其 aux info 中的 (NTilde, h1, h2)
+type cggmpPedersen struct {
+	N *big.Int
+	S *big.Int
+	T *big.Int
+}
+
+// commit 计算 s^a · t^b mod N̂（指数可以为负）
+func (ped *cggmpPedersen) commit(a, b *big.Int) *big.Int {
+	return common.ModInt(ped.N).Mul(cggmpExp(ped.S, a, ped.N), cggmpExp(ped.T, b, ped.N))
+}
+
+// valid 承诺值必须在 Z*_N̂ 中
+func (ped *cggmpPedersen) valid(values ...*big.Int) bool {
+	for _, v := range values {
+		if !cggmpInUnitGroup(v, ped.N) {
+			return false
+		}
+	}
+	return true
+}
+
+// cggmpExp 计算 base^exp mod m，exp 为负时使用模逆
+// base 与 m 不互素时返回 0（在后续的相等性校验中必然失败）
+func cggmpExp(base, exp, m *big.Int) *big.Int {
+	if exp.Sign() >= 0 {
+		return new(big.Int).Exp(base, exp, m)
+	}
+	inv := new(big.Int).ModInverse(base, m)
+	if inv == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Exp(inv, new(big.Int).Neg(exp), m)
+}
+
+// cggmpInUnitGroup 判断 0 < v < m 且 gcd(v, m) = 1
+func cggmpInUnitGroup(v, m *big.Int) bool {
+	if v == nil || v.Sign() <= 0 || v.Cmp(m) >= 0 {
+		return false
+	}
+	return new(big.Int).GCD(nil, nil, v, m).Cmp(cggmpOne) == 0
+}
+
+// cggmpNonNil 判断所有值都不为 nil（反序列化后的证明字段可能缺失）
+func cggmpNonNil(values ...*big.Int) bool {
+	for _, v := range values {
+		if v == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// cggmpRandomSigned 从 ±2^bits·scale 中均匀采样（scale 为 nil 时视为 1）
+func cggmpRandomSigned(bits int, scale *big.Int) *big.Int {
+	bound := new(big.Int).Lsh(cggmpOne, uint(bits))
+	if scale != nil {
+		bound.Mul(bound, scale)
+	}
+	v := common.GetRandomPositiveInt(rand.Reader, new(big.Int).Lsh(bound, 1))
+	return v.Sub(v, bound)
+}
+
+// cggmpInRange 判断 |v| ≤ 2^bits
+func cggmpInRange(v *big.Int, bits int) bool {
+	return v != nil && v.CmpAbs(new(big.Int).Lsh(cggmpOne, uint(bits))) <= 0
+}
+
+// cggmpRandomUnit 从 Z*_n 中采样
+func cggmpRandomUnit(n *big.Int) *big.Int {
+	return common.GetRandomPositiveRelativelyPrimeInt(rand.Reader, n)
+}
+
+// paillierEncryptWith 使用给定随机数加密：(1+N)^m · ρ^N mod N²，m 可以为负（按模 N 处理）
+func paillierEncryptWith(n, m, rho *big.Int) *big.Int {
+	n2 := new(big.Int).Mul(n, n)
+	// (1+N)^m = 1 + m·N mod N²
+	gm := new(big.Int).Mod(m, n)
+	gm.Mul(gm, n).Add(gm, cggmpOne).Mod(gm, n2)
+	return common.ModInt(n2).Mul(gm, cggmpExp(rho, n, n2))
+}
+
+// paillierCentered 把 [0, N) 中的明文映射到 (-N/2, N/2]
+func paillierCentered(m, n *big.Int) *big.Int {
+	if m.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		return new(big.Int).Sub(m, n)
+	}
+	return m
+}
+
+// paillierRandomness 用私钥恢复密文的随机数 ρ：c·(1+N)^{-m} = ρ^N mod N²，ρ = (ρ^N mod N)^{N⁻¹ mod φ(N)} mod N
+func paillierRandomness(phi, n, c, m *big.Int) (*big.Int, error) {
+	n2 := new(big.Int).Mul(n, n)
+	rhoN := common.ModInt(n2).Mul(c, paillierEncryptWith(n, new(big.Int).Neg(m), cggmpOne))
+	nInv := new(big.Int).ModInverse(n, phi)
+	if nInv == nil {
+		return nil, errors.New("paillier modulus is not coprime to phi(N)")
+	}
+	return new(big.Int).Exp(new(big.Int).Mod(rhoN, n), nInv, n), nil
+}
+
+// cggmpChallenge Fiat-Shamir 挑战值 e ∈ [0, q)
+// 每个值编码为 符号 || 长度 || 绝对值，context 绑定会话、证明方和验证方，防止跨会话重放
+func cggmpChallenge(tag string, context []byte, values ...*big.Int) *big.Int {
+	h := sha512.New()
+	writePart := func(sign byte, data []byte) {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(data)))
+		h.Write([]byte{sign})
+		h.Write(length[:])
+		h.Write(data)
+	}
+	writePart(0, []byte("cggmp21/"+tag))
+	writePart(0, context)
+	for _, v := range values {
+		if v == nil {
+			writePart(2, nil)
+			continue
+		}
+		sign := byte(0)
+		if v.Sign() < 0 {
+			sign = 1
+		}
+		writePart(sign, v.Bytes())
+	}
+	return new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), frostSecp256k1Suite.order())
+}
+
+// cggmpPointInts 点坐标（用于挑战值哈希）
+func cggmpPointInts(points ...*crypto.ECPoint) []*big.Int {
+	ints := make([]*big.Int, 0, 2*len(points))
+	for _, p := range points {
+		ints = append(ints, p.X(), p.Y())
+	}
+	return ints
+}
+
+// cggmpEncProof Π^enc：证明 K = enc_N0(k; ρ) 且 k ∈ ±2^ℓ
+type cggmpEncProof struct {
+	S  *big.Int `json:"s"`
+	A  *big.Int `json:"a"`
+	C  *big.Int `json:"c"`
+	Z1 *big.Int `json:"z1"`
+	Z2 *big.Int `json:"z2"`
+	Z3 *big.Int `json:"z3"`
+}
+
+func cggmpProveEnc(context []byte, ped *cggmpPedersen, n0, kCipher, k, rho *big.Int) *cggmpEncProof {
+	alpha := cggmpRandomSigned(cggmpEll+cggmpEpsilon, nil)
+	mu := cggmpRandomSigned(cggmpEll, ped.N)
+	r := cggmpRandomUnit(n0)
+	gamma := cggmpRandomSigned(cggmpEll+cggmpEpsilon, ped.N)
+
+	pf := &cggmpEncProof{
+		S: ped.commit(k, mu),
+		A: paillierEncryptWith(n0, alpha, r),
+		C: ped.commit(alpha, gamma),
+	}
+	e := cggmpChallenge("enc", context, n0, kCipher, pf.S, pf.A, pf.C)
+	pf.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, k))
+	pf.Z2 = common.ModInt(n0).Mul(r, new(big.Int).Exp(rho, e, n0))
+	pf.Z3 = new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+	return pf
+}
+
+func (pf *cggmpEncProof) verify(context []byte, ped *cggmpPedersen, n0, kCipher *big.Int) error {
+	if pf == nil || !cggmpNonNil(pf.S, pf.A, pf.C, pf.Z1, pf.Z2, pf.Z3) {
+		return errors.Wrap(errCGGMPProof, "enc proof is incomplete")
+	}
+	n2 := new(big.Int).Mul(n0, n0)
+	if !ped.valid(pf.S, pf.C) || !cggmpInUnitGroup(pf.A, n2) || !cggmpInUnitGroup(pf.Z2, n0) {
+		return errors.Wrap(errCGGMPProof, "enc proof has out-of-range elements")
+	}
+	if !cggmpInRange(pf.Z1, cggmpEll+cggmpEpsilon) {
+		return errors.Wrap(errCGGMPProof, "enc proof range check failed")
+	}
+	e := cggmpChallenge("enc", context, n0, kCipher, pf.S, pf.A, pf.C)
+	modN2 := common.ModInt(n2)
+	if paillierEncryptWith(n0, pf.Z1, pf.Z2).Cmp(modN2.Mul(pf.A, new(big.Int).Exp(kCipher, e, n2))) != 0 {
+		return errors.Wrap(errCGGMPProof, "enc proof ciphertext check failed")
+	}
+	modNCap := common.ModInt(ped.N)
+	if ped.commit(pf.Z1, pf.Z3).Cmp(modNCap.Mul(pf.C, new(big.Int).Exp(pf.S, e, ped.N))) != 0 {
+		return errors.Wrap(errCGGMPProof, "enc proof commitment check failed")
+	}
+	return nil
+}
+
+// cggmpLogStarProof Π^log*：证明 C = enc_N0(x; ρ)、X = x·g 且 x ∈ ±2^ℓ
+type cggmpLogStarProof struct {
+	S  *big.Int `json:"s"`
+	A  *big.Int `json:"a"`
+	Y  []byte   `json:"y"`
+	D  *big.Int `json:"d"`
+	Z1 *big.Int `json:"z1"`
+	Z2 *big.Int `json:"z2"`
+	Z3 *big.Int `json:"z3"`
+}
+
+func cggmpProveLogStar(context []byte, ped *cggmpPedersen, n0, c *big.Int, x *big.Int, rho *big.Int, g, bigX *crypto.ECPoint) *cggmpLogStarProof {
+	cs := frostSecp256k1Suite
+	alpha := cggmpRandomSigned(cggmpEll+cggmpEpsilon, nil)
+	mu := cggmpRandomSigned(cggmpEll, ped.N)
+	r := cggmpRandomUnit(n0)
+	gamma := cggmpRandomSigned(cggmpEll+cggmpEpsilon, ped.N)
+
+	y := cs.scalarMult(g, alpha)
+	pf := &cggmpLogStarProof{
+		S: ped.commit(x, mu),
+		A: paillierEncryptWith(n0, alpha, r),
+		Y: cs.serializeElement(y),
+		D: ped.commit(alpha, gamma),
+	}
+	e := cggmpChallenge("log*", context, append([]*big.Int{n0, c, pf.S, pf.A, pf.D}, cggmpPointInts(g, bigX, y)...)...)
+	pf.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	pf.Z2 = common.ModInt(n0).Mul(r, new(big.Int).Exp(rho, e, n0))
+	pf.Z3 = new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+	return pf
+}
+
+func (pf *cggmpLogStarProof) verify(context []byte, ped *cggmpPedersen, n0, c *big.Int, g, bigX *crypto.ECPoint) error {
+	cs := frostSecp256k1Suite
+	if pf == nil || !cggmpNonNil(pf.S, pf.A, pf.D, pf.Z1, pf.Z2, pf.Z3) {
+		return errors.Wrap(errCGGMPProof, "log* proof is incomplete")
+	}
+	y, err := cs.deserializeElement(pf.Y)
+	if err != nil {
+		return errors.Wrap(errCGGMPProof, "log* proof has an invalid point")
+	}
+	n2 := new(big.Int).Mul(n0, n0)
+	if !ped.valid(pf.S, pf.D) || !cggmpInUnitGroup(pf.A, n2) || !cggmpInUnitGroup(pf.Z2, n0) {
+		return errors.Wrap(errCGGMPProof, "log* proof has out-of-range elements")
+	}
+	if !cggmpInRange(pf.Z1, cggmpEll+cggmpEpsilon) {
+		return errors.Wrap(errCGGMPProof, "log* proof range check failed")
+	}
+	e := cggmpChallenge("log*", context, append([]*big.Int{n0, c, pf.S, pf.A, pf.D}, cggmpPointInts(g, bigX, y)...)...)
+	if paillierEncryptWith(n0, pf.Z1, pf.Z2).Cmp(common.ModInt(n2).Mul(pf.A, new(big.Int).Exp(c, e, n2))) != 0 {
+		return errors.Wrap(errCGGMPProof, "log* proof ciphertext check failed")
+	}
+	expected, err := cs.addPoints(y, cs.scalarMult(bigX, e))
+	if err != nil || !cs.scalarMult(g, pf.Z1).Equals(expected) {
+		return errors.Wrap(errCGGMPProof, "log* proof group check failed")
+	}
+	if ped.commit(pf.Z1, pf.Z3).Cmp(common.ModInt(ped.N).Mul(pf.D, new(big.Int).Exp(pf.S, e, ped.N))) != 0 {
+		return errors.Wrap(errCGGMPProof, "log* proof commitment check failed")
+	}
+	return nil
+}
+
+// cggmpAffGProof Π^aff-g：证明 D = C^x · enc_N0(y; ρ)、Y = enc_N1(y; ρy)、X = x·G，
+// 且 x ∈ ±2^ℓ、y ∈ ±2^ℓ'（MtA 中由乘数方生成，C 为接收方的密文）
+type cggmpAffGProof struct {
+	A  *big.Int `json:"a"`
+	Bx []byte   `json:"bx"`
+	By *big.Int `json:"by"`
+	E  *big.Int `json:"e"`
+	S  *big.Int `json:"s"`
+	F  *big.Int `json:"f"`
+	T  *big.Int `json:"t"`
+	Z1 *big.Int `json:"z1"`
+	Z2 *big.Int `json:"z2"`
+	Z3 *big.Int `json:"z3"`
+	Z4 *big.Int `json:"z4"`
+	W  *big.Int `json:"w"`
+	Wy *big.Int `json:"wy"`
+}
+
+// cggmpAffGStatement Π^aff-g 的公开陈述
+type cggmpAffGStatement struct {
+	N0 *big.Int // 接收方 Paillier 模数（C、D 在其下加密）
+	N1 *big.Int // 证明方 Paillier 模数（Y 在其下加密）
+	C  *big.Int
+	D  *big.Int
+	Y  *big.Int
+	X  *crypto.ECPoint
+}
+
+func (st *cggmpAffGStatement) ints() []*big.Int {
+	return append([]*big.Int{st.N0, st.N1, st.C, st.D, st.Y}, cggmpPointInts(st.X)...)
+}
+
+func cggmpProveAffG(context []byte, ped *cggmpPedersen, st *cggmpAffGStatement, x, y, rho, rhoY *big.Int) *cggmpAffGProof {
+	cs := frostSecp256k1Suite
+	alpha := cggmpRandomSigned(cggmpEll+cggmpEpsilon, nil)
+	beta := cggmpRandomSigned(cggmpEllPrime+cggmpEpsilon, nil)
+	r := cggmpRandomUnit(st.N0)
+	ry := cggmpRandomUnit(st.N1)
+	gamma := cggmpRandomSigned(cggmpEll+cggmpEpsilon, ped.N)
+	m := cggmpRandomSigned(cggmpEll, ped.N)
+	delta := cggmpRandomSigned(cggmpEll+cggmpEpsilon, ped.N)
+	mu := cggmpRandomSigned(cggmpEll, ped.N)
+
+	n02 := new(big.Int).Mul(st.N0, st.N0)
+	pf := &cggmpAffGProof{
+		A:  common.ModInt(n02).Mul(cggmpExp(st.C, alpha, n02), paillierEncryptWith(st.N0, beta, r)),
+		Bx: cs.serializeElement(cs.scalarBaseMult(alpha)),
+		By: paillierEncryptWith(st.N1, beta, ry),
+		E:  ped.commit(alpha, gamma),
+		S:  ped.commit(x, m),
+		F:  ped.commit(beta, delta),
+		T:  ped.commit(y, mu),
+	}
+	e := cggmpChallenge("aff-g", context, append(st.ints(), pf.A, new(big.Int).SetBytes(pf.Bx), pf.By, pf.E, pf.S, pf.F, pf.T)...)
+	pf.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	pf.Z2 = new(big.Int).Add(beta, new(big.Int).Mul(e, y))
+	pf.Z3 = new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	pf.Z4 = new(big.Int).Add(delta, new(big.Int).Mul(e, mu))
+	pf.W = common.ModInt(st.N0).Mul(r, new(big.Int).Exp(rho, e, st.N0))
+	pf.Wy = common.ModInt(st.N1).Mul(ry, new(big.Int).Exp(rhoY, e, st.N1))
+	return pf
+}
+
+func (pf *cggmpAffGProof) verify(context []byte, ped *cggmpPedersen, st *cggmpAffGStatement) error {
+	cs := frostSecp256k1Suite
+	if pf == nil || !cggmpNonNil(pf.A, pf.By, pf.E, pf.S, pf.F, pf.T, pf.Z1, pf.Z2, pf.Z3, pf.Z4, pf.W, pf.Wy) {
+		return errors.Wrap(errCGGMPProof, "aff-g proof is incomplete")
+	}
+	bx, err := cs.deserializeElement(pf.Bx)
+	if err != nil {
+		return errors.Wrap(errCGGMPProof, "aff-g proof has an invalid point")
+	}
+	n02 := new(big.Int).Mul(st.N0, st.N0)
+	n12 := new(big.Int).Mul(st.N1, st.N1)
+	if !ped.valid(pf.E, pf.S, pf.F, pf.T) ||
+		!cggmpInUnitGroup(pf.A, n02) || !cggmpInUnitGroup(pf.By, n12) ||
+		!cggmpInUnitGroup(pf.W, st.N0) || !cggmpInUnitGroup(pf.Wy, st.N1) {
+		return errors.Wrap(errCGGMPProof, "aff-g proof has out-of-range elements")
+	}
+	if !cggmpInRange(pf.Z1, cggmpEll+cggmpEpsilon) || !cggmpInRange(pf.Z2, cggmpEllPrime+cggmpEpsilon) {
+		return errors.Wrap(errCGGMPProof, "aff-g proof range check failed")
+	}
+	e := cggmpChallenge("aff-g", context, append(st.ints(), pf.A, new(big.Int).SetBytes(pf.Bx), pf.By, pf.E, pf.S, pf.F, pf.T)...)
+
+	modN02 := common.ModInt(n02)
+	lhs := modN02.Mul(cggmpExp(st.C, pf.Z1, n02), paillierEncryptWith(st.N0, pf.Z2, pf.W))
+	if lhs.Cmp(modN02.Mul(pf.A, new(big.Int).Exp(st.D, e, n02))) != 0 {
+		return errors.Wrap(errCGGMPProof, "aff-g proof affine ciphertext check failed")
+	}
+	expected, err := cs.addPoints(bx, cs.scalarMult(st.X, e))
+	if err != nil || !cs.scalarBaseMult(pf.Z1).Equals(expected) {
+		return errors.Wrap(errCGGMPProof, "aff-g proof group check failed")
+	}
+	if paillierEncryptWith(st.N1, pf.Z2, pf.Wy).Cmp(common.ModInt(n12).Mul(pf.By, new(big.Int).Exp(st.Y, e, n12))) != 0 {
+		return errors.Wrap(errCGGMPProof, "aff-g proof ciphertext check failed")
+	}
+	modNCap := common.ModInt(ped.N)
+	if ped.commit(pf.Z1, pf.Z3).Cmp(modNCap.Mul(pf.E, new(big.Int).Exp(pf.S, e, ped.N))) != 0 ||
+		ped.commit(pf.Z2, pf.Z4).Cmp(modNCap.Mul(pf.F, new(big.Int).Exp(pf.T, e, ped.N))) != 0 {
+		return errors.Wrap(errCGGMPProof, "aff-g proof commitment check failed")
+	}
+	return nil
+}
+
+// cggmpMulStarProof Π^mul*：证明 D = C^x · ρ^N0 mod N0²、X = x·G 且 x ∈ ±2^ℓ（用于可识别中止）
+type cggmpMulStarProof struct {
+	A  *big.Int `json:"a"`
+	Bx []byte   `json:"bx"`
+	E  *big.Int `json:"e"`
+	S  *big.Int `json:"s"`
+	Z1 *big.Int `json:"z1"`
+	Z2 *big.Int `json:"z2"`
+	W  *big.Int `json:"w"`
+}
+
+func cggmpProveMulStar(context []byte, ped *cggmpPedersen, n0, c, d *big.Int, bigX *crypto.ECPoint, x, rho *big.Int) *cggmpMulStarProof {
+	cs := frostSecp256k1Suite
+	alpha := cggmpRandomSigned(cggmpEll+cggmpEpsilon, nil)
+	r := cggmpRandomUnit(n0)
+	gamma := cggmpRandomSigned(cggmpEll+cggmpEpsilon, ped.N)
+	m := cggmpRandomSigned(cggmpEll, ped.N)
+
+	n02 := new(big.Int).Mul(n0, n0)
+	pf := &cggmpMulStarProof{
+		A:  common.ModInt(n02).Mul(cggmpExp(c, alpha, n02), new(big.Int).Exp(r, n0, n02)),
+		Bx: cs.serializeElement(cs.scalarBaseMult(alpha)),
+		E:  ped.commit(alpha, gamma),
+		S:  ped.commit(x, m),
+	}
+	e := cggmpChallenge("mul*", context, append([]*big.Int{n0, c, d, pf.A, new(big.Int).SetBytes(pf.Bx), pf.E, pf.S}, cggmpPointInts(bigX)...)...)
+	pf.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	pf.Z2 = new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	pf.W = common.ModInt(n0).Mul(r, new(big.Int).Exp(rho, e, n0))
+	return pf
+}
+
+func (pf *cggmpMulStarProof) verify(context []byte, ped *cggmpPedersen, n0, c, d *big.Int, bigX *crypto.ECPoint) error {
+	cs := frostSecp256k1Suite
+	if pf == nil || !cggmpNonNil(pf.A, pf.E, pf.S, pf.Z1, pf.Z2, pf.W) {
+		return errors.Wrap(errCGGMPProof, "mul* proof is incomplete")
+	}
+	bx, err := cs.deserializeElement(pf.Bx)
+	if err != nil {
+		return errors.Wrap(errCGGMPProof, "mul* proof has an invalid point")
+	}
+	n02 := new(big.Int).Mul(n0, n0)
+	if !ped.valid(pf.E, pf.S) || !cggmpInUnitGroup(pf.A, n02) || !cggmpInUnitGroup(pf.W, n0) {
+		return errors.Wrap(errCGGMPProof, "mul* proof has out-of-range elements")
+	}
+	if !cggmpInRange(pf.Z1, cggmpEll+cggmpEpsilon) {
+		return errors.Wrap(errCGGMPProof, "mul* proof range check failed")
+	}
+	e := cggmpChallenge("mul*", context, append([]*big.Int{n0, c, d, pf.A, new(big.Int).SetBytes(pf.Bx), pf.E, pf.S}, cggmpPointInts(bigX)...)...)
+	modN02 := common.ModInt(n02)
+	lhs := modN02.Mul(cggmpExp(c, pf.Z1, n02), new(big.Int).Exp(pf.W, n0, n02))
+	if lhs.Cmp(modN02.Mul(pf.A, new(big.Int).Exp(d, e, n02))) != 0 {
+		return errors.Wrap(errCGGMPProof, "mul* proof ciphertext check failed")
+	}
+	expected, err := cs.addPoints(bx, cs.scalarMult(bigX, e))
+	if err != nil || !cs.scalarBaseMult(pf.Z1).Equals(expected) {
+		return errors.Wrap(errCGGMPProof, "mul* proof group check failed")
+	}
+	if ped.commit(pf.Z1, pf.Z2).Cmp(common.ModInt(ped.N).Mul(pf.E, new(big.Int).Exp(pf.S, e, ped.N))) != 0 {
+		return errors.Wrap(errCGGMPProof, "mul* proof commitment check failed")
+	}
+	return nil
+}
+
+// cggmpDecProof Π^dec：证明密文 C 在 N0 下的（居中）明文 y 满足 y ≡ x (mod q)（用于可识别中止）
+// y 为 MtA 份额之和，不限制在 ±2^ℓ 内，因此掩码范围按 N0 放大
+type cggmpDecProof struct {
+	S     *big.Int `json:"s"`
+	T     *big.Int `json:"t"`
+	A     *big.Int `json:"a"`
+	Gamma *big.Int `json:"gamma"`
+	Z1    *big.Int `json:"z1"`
+	Z2    *big.Int `json:"z2"`
+	W     *big.Int `json:"w"`
+}
+
+func cggmpProveDec(context []byte, ped *cggmpPedersen, n0, c, x, y, rho *big.Int) *cggmpDecProof {
+	q := frostSecp256k1Suite.order()
+	alpha := cggmpRandomSigned(cggmpEll+cggmpEpsilon, n0)
+	mu := cggmpRandomSigned(cggmpEpsilon, ped.N)
+	nu := cggmpRandomSigned(cggmpEll+cggmpEpsilon, new(big.Int).Mul(ped.N, n0))
+	r := cggmpRandomUnit(n0)
+
+	pf := &cggmpDecProof{
+		S:     ped.commit(y, mu),
+		T:     ped.commit(alpha, nu),
+		A:     paillierEncryptWith(n0, alpha, r),
+		Gamma: new(big.Int).Mod(alpha, q),
+	}
+	e := cggmpChallenge("dec", context, n0, c, x, pf.S, pf.T, pf.A, pf.Gamma)
+	pf.Z1 = new(big.Int).Add(alpha, new(big.Int).Mul(e, y))
+	pf.Z2 = new(big.Int).Add(nu, new(big.Int).Mul(e, mu))
+	pf.W = common.ModInt(n0).Mul(r, new(big.Int).Exp(rho, e, n0))
+	return pf
+}
+
+func (pf *cggmpDecProof) verify(context []byte, ped *cggmpPedersen, n0, c, x *big.Int) error {
+	q := frostSecp256k1Suite.order()
+	if pf == nil || !cggmpNonNil(pf.S, pf.T, pf.A, pf.Gamma, pf.Z1, pf.Z2, pf.W) {
+		return errors.Wrap(errCGGMPProof, "dec proof is incomplete")
+	}
+	n02 := new(big.Int).Mul(n0, n0)
+	if !ped.valid(pf.S, pf.T) || !cggmpInUnitGroup(pf.A, n02) || !cggmpInUnitGroup(pf.W, n0) {
+		return errors.Wrap(errCGGMPProof, "dec proof has out-of-range elements")
+	}
+	e := cggmpChallenge("dec", context, n0, c, x, pf.S, pf.T, pf.A, pf.Gamma)
+	if paillierEncryptWith(n0, pf.Z1, pf.W).Cmp(common.ModInt(n02).Mul(pf.A, new(big.Int).Exp(c, e, n02))) != 0 {
+		return errors.Wrap(errCGGMPProof, "dec proof ciphertext check failed")
+	}
+	modQ := common.ModInt(q)
+	if new(big.Int).Mod(pf.Z1, q).Cmp(modQ.Add(pf.Gamma, modQ.Mul(e, x))) != 0 {
+		return errors.Wrap(errCGGMPProof, "dec proof plaintext check failed")
+	}
+	if ped.commit(pf.Z1, pf.Z2).Cmp(common.ModInt(ped.N).Mul(pf.T, new(big.Int).Exp(pf.S, e, ped.N))) != 0 {
+		return errors.Wrap(errCGGMPProof, "dec proof commitment check failed")
+	}
+	return nil
+}
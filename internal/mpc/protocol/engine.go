@@ -16,20 +16,31 @@ type Engine interface {
 	// 签名验证
 	VerifySignature(ctx context.Context, sig *Signature, msg []byte, pubKey *PublicKey) (bool, error)
 
-	// 密钥轮换
-	RotateKey(ctx context.Context, keyID string) error
+	// 密钥重分享（resharing）：更换参与节点和/或阈值，公钥保持不变
+	RotateKey(ctx context.Context, req *ReshareRequest) (*ReshareResponse, error)
+
+	// 预签名（离线阶段）：预先完成与消息无关的签名轮次，sessionID 即预签名ID
+	Presign(ctx context.Context, sessionID string, req *PresignRequest) (*PresignResponse, error)
 
 	// 处理接收到的DKG消息
 	ProcessIncomingKeygenMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte, isBroadcast bool) error
 
 	// 处理接收到的签名消息
-	ProcessIncomingSigningMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte) error
+	ProcessIncomingSigningMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte, isBroadcast bool) error
+
+	// 处理接收到的 resharing 消息
+	ProcessIncomingResharingMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte, isBroadcast bool) error
 
 	// 支持的协议
 	SupportedProtocols() []string
 	DefaultProtocol() string
 }
 
+// KeyForgetter 在内存中缓存密钥数据的协议引擎实现该接口，销毁分片时丢弃缓存
+type KeyForgetter interface {
+	ForgetKey(keyID string)
+}
+
 // ProtocolRegistry 协议注册表
 type ProtocolRegistry struct {
 	protocols       map[string]Engine
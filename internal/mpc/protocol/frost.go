@@ -9,7 +9,6 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/kashguard/tss-lib/common"
 	eddsaKeygen "github.com/kashguard/tss-lib/eddsa/keygen"
 	eddsaSigning "github.com/kashguard/tss-lib/eddsa/signing"
@@ -18,21 +17,27 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// FROSTProtocol FROST协议实现（基于 Schnorr 签名的阈值签名）
+// FROSTProtocol FROST协议实现（RFC 9591，基于 Schnorr 签名的阈值签名）
 // FROST 的主要特点：
-// 1. 2 轮通信（相比 GG18 的 4-9 轮，GG20 的优化轮次）
-// 2. 基于 Schnorr 签名（更适合 Bitcoin BIP-340）
-// 3. 更高的性能和效率
-// 4. IETF 标准协议
+//  1. 2 轮签名（第一轮 nonce 承诺与消息无关，可预处理，在线签名只需 1 轮）
+//  2. 基于 Schnorr 签名：FROST(Ed25519, SHA-512) 输出标准 Ed25519 签名，
+//     FROST(secp256k1, SHA-256) 输出 BIP-340 签名（支持 Taproot key-path 调整）
+//  3. 签名分片逐个校验，失败时可定位作恶节点
+//  4. IETF 标准协议
 //
-// 注意：DKG 只支持 Ed25519 曲线（tss-lib 的 EdDSA keygen 限制）
-// 签名验证支持 Ed25519 和 secp256k1 两种曲线
+// 新密钥使用 RFC 9591 DKG 生成；此前由 tss-lib EdDSA keygen 生成的密钥（旧格式）继续使用 tss-lib 签名和重分享
 type FROSTProtocol struct {
 	curve string
 
 	mu         sync.RWMutex
 	keyRecords map[string]*frostKeyRecord
 
+	// hub RFC 9591 协议消息收件箱
+	hub *frostMessageHub
+
+	// presignMu 串行化预处理 nonce 的读取与删除，保证同一 nonce 在本节点只被使用一次
+	presignMu sync.Mutex
+
 	// roundMu 和 roundStates 保留用于未来扩展（协议进度跟踪）
 	// roundMu     sync.Mutex
 	// roundStates map[string]*signingRoundState
@@ -53,7 +58,9 @@ type FROSTProtocol struct {
 
 // frostKeyRecord 保存 FROST 密钥生成后的内部状态
 type frostKeyRecord struct {
-	// 使用 EdDSA keygen 的数据结构（Schnorr 兼容）
+	// Material RFC 9591 格式的密钥（新密钥）
+	Material *frostKeyMaterial
+	// KeyData 旧格式密钥（tss-lib EdDSA keygen 的数据结构）
 	KeyData    *eddsaKeygen.LocalPartySaveData
 	PublicKey  *PublicKey
 	Curve      string // 曲线类型（ed25519 或 secp256k1）
@@ -68,6 +75,7 @@ func NewFROSTProtocol(curve string, thisNodeID string, messageRouter func(sessio
 	return &FROSTProtocol{
 		curve:           curve,
 		keyRecords:      make(map[string]*frostKeyRecord),
+		hub:             newFROSTMessageHub(),
 		partyManager:    partyManager,
 		thisNodeID:      thisNodeID,
 		messageRouter:   messageRouter,
@@ -89,8 +97,8 @@ func (p *FROSTProtocol) saveKeyRecord(keyID string, record *frostKeyRecord) {
 	p.keyRecords[keyID] = record
 }
 
-// GenerateKeyShare 分布式密钥生成（使用 EdDSA DKG，Schnorr 兼容）
-// 注意：DKG 只支持 Ed25519 曲线，不支持 secp256k1（tss-lib 的 EdDSA keygen 限制）
+// GenerateKeyShare 分布式密钥生成（RFC 9591 附录 C 的 DKG，支持 Ed25519 和 secp256k1）
+// req.Threshold 为最少签名者数量
 func (p *FROSTProtocol) GenerateKeyShare(ctx context.Context, req *KeyGenRequest) (*KeyGenResponse, error) {
 	if err := p.ValidateKeyGenRequest(req); err != nil {
 		return nil, errors.Wrap(err, "invalid key generation request")
@@ -106,97 +114,70 @@ func (p *FROSTProtocol) GenerateKeyShare(ctx context.Context, req *KeyGenRequest
 		return nil, errors.Wrap(err, "invalid node IDs")
 	}
 
-	// 使用 tss-lib 执行 EdDSA DKG（通过 tssPartyManager）
-	keyData, err := p.partyManager.executeEdDSAKeygen(ctx, keyID, nodeIDs, req.Threshold, p.thisNodeID)
-	if err != nil {
-		return nil, errors.Wrap(err, "execute FROST keygen")
+	curve := strings.ToLower(req.Curve)
+	if curve == "" {
+		curve = strings.ToLower(p.curve)
 	}
-
-	// 转换密钥数据
-	keyShares, publicKey, err := convertFROSTKeyData(keyID, keyData, nodeIDs)
+	cs, err := getFROSTCiphersuite(curve)
 	if err != nil {
-		return nil, errors.Wrap(err, "convert FROST key data")
+		return nil, err
 	}
 
-	// 确定曲线类型（FROST DKG 只支持 Ed25519）
-	curve := req.Curve
-	if curve == "" {
-		curve = p.curve
+	keyData, err := p.executeDKG(ctx, cs, keyID, nodeIDs, req.Threshold)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute FROST keygen")
 	}
-	// 标准化曲线名称（统一为小写）
-	curve = strings.ToLower(curve)
-	// FROST DKG 只支持 Ed25519，强制设置为 ed25519
-	if curve != "ed25519" {
-		log.Warn().
-			Str("requested_curve", req.Curve).
-			Str("default_curve", p.curve).
-			Msg("FROST DKG only supports Ed25519, forcing curve to ed25519")
-		curve = "ed25519"
+	material, err := keyData.decode(p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode FROST key data")
 	}
 
-	// 保存密钥记录
-	record := &frostKeyRecord{
-		KeyData:    keyData,
-		PublicKey:  publicKey,
+	if err := p.storeFROSTKeyData(ctx, keyID, keyData); err != nil {
+		return nil, err
+	}
+	p.saveKeyRecord(keyID, &frostKeyRecord{
+		Material:   material,
+		PublicKey:  material.publicKey(),
 		Curve:      curve,
 		Threshold:  req.Threshold,
-		TotalNodes: req.TotalNodes,
-		NodeIDs:    nodeIDs,
-	}
-	p.saveKeyRecord(keyID, record)
-
-	// 持久化 LocalPartySaveData 到 keyShareStorage（用于签名时加载）
-	// 注意：keyShareStorage 是必需的，如果为 nil，DKG 应该失败
-	if p.keyShareStorage == nil {
-		log.Error().
-			Str("key_id", keyID).
-			Str("node_id", p.thisNodeID).
-			Msg("keyShareStorage is nil, cannot store LocalPartySaveData - DKG will fail")
-		return nil, errors.New("keyShareStorage is nil, cannot store LocalPartySaveData")
-	}
-
-	keyDataBytes, err := serializeEdDSALocalPartySaveData(keyData)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to serialize LocalPartySaveData")
-	}
-	log.Info().
-		Str("key_id", keyID).
-		Str("node_id", p.thisNodeID).
-		Int("key_data_bytes", len(keyDataBytes)).
-		Msg("Storing LocalPartySaveData to keyShareStorage")
-	if err := p.keyShareStorage.StoreKeyData(ctx, keyID, p.thisNodeID, keyDataBytes); err != nil {
-		log.Error().
-			Err(err).
-			Str("key_id", keyID).
-			Str("node_id", p.thisNodeID).
-			Msg("Failed to store LocalPartySaveData")
-		return nil, errors.Wrap(err, "failed to store LocalPartySaveData")
-	}
-	log.Info().
-		Str("key_id", keyID).
-		Str("node_id", p.thisNodeID).
-		Msg("LocalPartySaveData stored successfully")
+		TotalNodes: len(keyData.NodeIDs),
+		NodeIDs:    keyData.NodeIDs,
+	})
+	logFROSTKeyData("FROST DKG completed", p.thisNodeID, keyData)
 
 	return &KeyGenResponse{
-		KeyShares: keyShares,
-		PublicKey: publicKey,
+		KeyShares: frostKeyShares(keyID, keyData),
+		PublicKey: material.publicKey(),
 	}, nil
 }
 
-// ThresholdSign 阈值签名（FROST 2 轮签名协议）
-func (p *FROSTProtocol) ThresholdSign(ctx context.Context, sessionID string, req *SignRequest) (*SignResponse, error) {
-	if err := p.ValidateSignRequest(req); err != nil {
-		return nil, errors.Wrap(err, "invalid sign request")
-	}
-
-	// 复用密钥加载逻辑（从内存或 keyShareStorage 加载）
-	record, ok := p.getKeyRecord(req.KeyID)
+// loadKeyRecord 获取密钥记录（优先从内存，如果不存在则从 keyShareStorage 加载）
+func (p *FROSTProtocol) loadKeyRecord(ctx context.Context, keyID string) (*frostKeyRecord, error) {
+	record, ok := p.getKeyRecord(keyID)
 	if !ok {
 		// 内存中没有，尝试从 keyShareStorage 加载
 		if p.keyShareStorage != nil {
-			keyDataBytes, err := p.keyShareStorage.GetKeyData(ctx, req.KeyID, p.thisNodeID)
+			keyDataBytes, err := p.keyShareStorage.GetKeyData(ctx, keyID, p.thisNodeID)
 			if err != nil {
-				return nil, errors.Wrapf(err, "key %s not found in memory or storage", req.KeyID)
+				return nil, errors.Wrapf(err, "key %s not found in memory or storage", keyID)
+			}
+
+			// RFC 9591 格式
+			if frostData, ok := parseFROSTKeyData(keyDataBytes); ok {
+				material, err := frostData.decode(p.thisNodeID)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to decode FROST key data")
+				}
+				record = &frostKeyRecord{
+					Material:   material,
+					PublicKey:  material.publicKey(),
+					Curve:      frostData.Ciphersuite,
+					Threshold:  frostData.Threshold,
+					TotalNodes: len(frostData.NodeIDs),
+					NodeIDs:    frostData.NodeIDs,
+				}
+				p.saveKeyRecord(keyID, record)
+				return record, nil
 			}
 
 			// 反序列化 EdDSA LocalPartySaveData
@@ -240,14 +221,75 @@ func (p *FROSTProtocol) ThresholdSign(ctx context.Context, sessionID string, req
 				TotalNodes: 0,
 				NodeIDs:    nil,
 			}
-			p.saveKeyRecord(req.KeyID, record)
+			p.saveKeyRecord(keyID, record)
 		} else {
-			return nil, errors.Errorf("key %s not found in memory and keyShareStorage is nil", req.KeyID)
+			return nil, errors.Errorf("key %s not found in memory and keyShareStorage is nil", keyID)
 		}
 	}
 
-	if record == nil || record.KeyData == nil {
-		return nil, errors.New("key data not found in record")
+	return record, nil
+}
+
+// storeFROSTKeyData 持久化 RFC 9591 格式的密钥数据到 keyShareStorage
+func (p *FROSTProtocol) storeFROSTKeyData(ctx context.Context, keyID string, keyData *frostKeyData) error {
+	if p.keyShareStorage == nil {
+		return errors.New("keyShareStorage is nil, cannot store FROST key data")
+	}
+	data, err := json.Marshal(keyData)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal FROST key data")
+	}
+	if err := p.keyShareStorage.StoreKeyData(ctx, keyID, p.thisNodeID, data); err != nil {
+		return errors.Wrap(err, "failed to store FROST key data")
+	}
+	return nil
+}
+
+// storeKeyData 持久化 EdDSA LocalPartySaveData 到 keyShareStorage（用于签名时加载）
+// 注意：keyShareStorage 是必需的，如果为 nil 则返回错误
+func (p *FROSTProtocol) storeKeyData(ctx context.Context, keyID string, keyData *eddsaKeygen.LocalPartySaveData) error {
+	if p.keyShareStorage == nil {
+		log.Error().
+			Str("key_id", keyID).
+			Str("node_id", p.thisNodeID).
+			Msg("keyShareStorage is nil, cannot store LocalPartySaveData")
+		return errors.New("keyShareStorage is nil, cannot store LocalPartySaveData")
+	}
+
+	keyDataBytes, err := serializeEdDSALocalPartySaveData(keyData)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize LocalPartySaveData")
+	}
+	log.Info().
+		Str("key_id", keyID).
+		Str("node_id", p.thisNodeID).
+		Int("key_data_bytes", len(keyDataBytes)).
+		Msg("Storing LocalPartySaveData to keyShareStorage")
+	if err := p.keyShareStorage.StoreKeyData(ctx, keyID, p.thisNodeID, keyDataBytes); err != nil {
+		log.Error().
+			Err(err).
+			Str("key_id", keyID).
+			Str("node_id", p.thisNodeID).
+			Msg("Failed to store LocalPartySaveData")
+		return errors.Wrap(err, "failed to store LocalPartySaveData")
+	}
+	log.Info().
+		Str("key_id", keyID).
+		Str("node_id", p.thisNodeID).
+		Msg("LocalPartySaveData stored successfully")
+	return nil
+}
+
+// ThresholdSign 阈值签名（FROST 2 轮签名协议）
+func (p *FROSTProtocol) ThresholdSign(ctx context.Context, sessionID string, req *SignRequest) (*SignResponse, error) {
+	if err := p.ValidateSignRequest(req); err != nil {
+		return nil, errors.Wrap(err, "invalid sign request")
+	}
+
+	// 复用密钥加载逻辑（从内存或 keyShareStorage 加载）
+	record, err := p.loadKeyRecord(ctx, req.KeyID)
+	if err != nil {
+		return nil, err
 	}
 
 	// 解析消息
@@ -256,7 +298,18 @@ func (p *FROSTProtocol) ThresholdSign(ctx context.Context, sessionID string, req
 		return nil, errors.Wrap(err, "resolve message payload")
 	}
 
-	// 使用 tss-lib 执行 FROST 签名协议（通过 tssPartyManager，使用 EdDSA signing）
+	if record != nil && record.Material != nil {
+		return p.thresholdSignRFC9591(ctx, sessionID, req, record.Material, message)
+	}
+
+	if record == nil || record.KeyData == nil {
+		return nil, errors.New("key data not found in record")
+	}
+	if req.PresignatureID != "" || req.TaprootKeySpend || req.DerivationPath != "" {
+		return nil, errors.New("presignatures, taproot tweaks and key derivation require a key generated by the RFC 9591 DKG")
+	}
+
+	// 旧格式密钥：使用 tss-lib 执行 EdDSA 签名协议（通过 tssPartyManager，使用 EdDSA signing）
 	sigData, err := p.partyManager.executeEdDSASigning(
 		ctx,
 		sessionID,
@@ -383,6 +436,9 @@ func (p *FROSTProtocol) VerifySignature(ctx context.Context, sig *Signature, msg
 	// 根据公钥长度自动判断曲线类型
 	// Ed25519 公钥：32 字节
 	// secp256k1 公钥：33 字节（压缩）或 65 字节（未压缩）
+	if pubKey == nil {
+		return false, errors.New("public key is empty")
+	}
 	var curve string
 	if len(pubKey.Bytes) == 32 {
 		curve = "ed25519"
@@ -419,17 +475,12 @@ func (p *FROSTProtocol) ValidateKeyGenRequest(req *KeyGenRequest) error {
 		return errors.New("key generation request is nil")
 	}
 
-	// FROST DKG 只支持 Ed25519 曲线（tss-lib 的 EdDSA keygen 限制）
-	// 注意：签名验证支持 Ed25519 和 secp256k1，但 DKG 只支持 Ed25519
 	curveLower := strings.ToLower(req.Curve)
-	if req.Curve != "" && curveLower != "ed25519" {
-		if curveLower == "secp256k1" {
-			return errors.Errorf("FROST DKG does not support secp256k1 curve (only Ed25519 is supported for DKG). Use Ed25519 for DKG, or use GG18/GG20 protocol for secp256k1")
-		}
-		return errors.Errorf("unsupported curve for FROST DKG: %s (only Ed25519 is supported for DKG)", req.Curve)
+	if req.Curve != "" && curveLower != frostCiphersuiteEd25519 && curveLower != frostCiphersuiteSecp256k1 {
+		return errors.Errorf("unsupported curve for FROST DKG: %s (supported: ed25519, secp256k1)", req.Curve)
 	}
 
-	if req.Algorithm != "" && req.Algorithm != "Schnorr" && req.Algorithm != "EdDSA" {
+	if req.Algorithm != "" && !strings.EqualFold(req.Algorithm, "Schnorr") && !strings.EqualFold(req.Algorithm, "EdDSA") {
 		return errors.Errorf("unsupported algorithm for FROST: %s (supported: Schnorr, EdDSA)", req.Algorithm)
 	}
 
@@ -449,9 +500,195 @@ func (p *FROSTProtocol) ValidateSignRequest(req *SignRequest) error {
 	return validateSignRequest(req)
 }
 
-// RotateKey 密钥轮换
-func (p *FROSTProtocol) RotateKey(ctx context.Context, keyID string) error {
-	return errors.New("FROST key rotation not yet implemented")
+// RotateKey 密钥重分享（公钥保持不变）
+// RFC 9591 格式的密钥由旧委员会成员对 λ_i·s_i 做 Feldman 秘密分享；旧格式密钥使用 tss-lib EdDSA resharing
+// 仅属于旧委员会的节点在完成后作废本地分片
+func (p *FROSTProtocol) RotateKey(ctx context.Context, req *ReshareRequest) (*ReshareResponse, error) {
+	if err := validateReshareRequest(req); err != nil {
+		return nil, errors.Wrap(err, "invalid reshare request")
+	}
+
+	var oldRecord *frostKeyRecord
+	if containsNodeID(req.OldNodeIDs, p.thisNodeID) {
+		record, err := p.loadKeyRecord(ctx, req.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		if record == nil || (record.KeyData == nil && record.Material == nil) {
+			return nil, errors.New("key data not found in record")
+		}
+		oldRecord = record
+	}
+
+	// 只在新委员会的节点没有本地密钥，根据旧委员会发来的消息判断密钥格式
+	useRFC9591 := oldRecord != nil && oldRecord.Material != nil
+	if oldRecord == nil {
+		useRFC9591 = p.hub.awaitAny(ctx, req.SessionID, frostRoundReshare, frostReshareDetectTimeout)
+	}
+	if useRFC9591 {
+		return p.rotateKeyRFC9591(ctx, req, oldRecord)
+	}
+
+	var oldKeyData *eddsaKeygen.LocalPartySaveData
+	if oldRecord != nil {
+		oldKeyData = oldRecord.KeyData
+	}
+
+	newKeyData, err := p.partyManager.executeEdDSAResharing(ctx, req, p.thisNodeID, oldKeyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute FROST resharing")
+	}
+
+	// 不在新委员会：旧分片已失效，删除本地数据
+	if newKeyData == nil {
+		if err := p.discardKeyData(ctx, req.KeyID); err != nil {
+			return nil, err
+		}
+		return &ReshareResponse{PublicKey: oldRecord.PublicKey}, nil
+	}
+
+	keyShares, publicKey, err := convertFROSTKeyData(req.KeyID, newKeyData, req.NewNodeIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert FROST key data")
+	}
+	if oldRecord != nil && oldRecord.PublicKey != nil && oldRecord.PublicKey.Hex != publicKey.Hex {
+		return nil, errors.Errorf("public key changed after resharing: %s -> %s", oldRecord.PublicKey.Hex, publicKey.Hex)
+	}
+
+	if err := p.storeKeyData(ctx, req.KeyID, newKeyData); err != nil {
+		return nil, err
+	}
+	p.saveKeyRecord(req.KeyID, &frostKeyRecord{
+		KeyData:    newKeyData,
+		PublicKey:  publicKey,
+		Curve:      "ed25519",
+		Threshold:  req.NewThreshold,
+		TotalNodes: len(req.NewNodeIDs),
+		NodeIDs:    req.NewNodeIDs,
+	})
+
+	return &ReshareResponse{
+		PublicKey: publicKey,
+		KeyShare:  keyShares[p.thisNodeID],
+	}, nil
+}
+
+// rotateKeyRFC9591 RFC 9591 格式密钥的重分享
+func (p *FROSTProtocol) rotateKeyRFC9591(ctx context.Context, req *ReshareRequest, oldRecord *frostKeyRecord) (*ReshareResponse, error) {
+	var oldKey *frostKeyMaterial
+	if oldRecord != nil {
+		oldKey = oldRecord.Material
+		if req.NewEpoch <= oldKey.data.Epoch {
+			return nil, errors.Errorf("new epoch %d must be greater than current epoch %d", req.NewEpoch, oldKey.data.Epoch)
+		}
+	}
+
+	keyData, err := p.executeResharing(ctx, req, oldKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute FROST resharing")
+	}
+
+	// 不在新委员会：旧分片已失效，删除本地数据
+	if keyData == nil {
+		if err := p.discardKeyData(ctx, req.KeyID); err != nil {
+			return nil, err
+		}
+		return &ReshareResponse{PublicKey: oldRecord.PublicKey}, nil
+	}
+
+	material, err := keyData.decode(p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode FROST key data")
+	}
+	publicKey := material.publicKey()
+	if oldRecord != nil && oldRecord.PublicKey != nil && oldRecord.PublicKey.Hex != publicKey.Hex {
+		return nil, errors.Errorf("public key changed after resharing: %s -> %s", oldRecord.PublicKey.Hex, publicKey.Hex)
+	}
+
+	if err := p.storeFROSTKeyData(ctx, req.KeyID, keyData); err != nil {
+		return nil, err
+	}
+	p.saveKeyRecord(req.KeyID, &frostKeyRecord{
+		Material:   material,
+		PublicKey:  publicKey,
+		Curve:      keyData.Ciphersuite,
+		Threshold:  keyData.Threshold,
+		TotalNodes: len(keyData.NodeIDs),
+		NodeIDs:    keyData.NodeIDs,
+	})
+	logFROSTKeyData("FROST resharing completed", p.thisNodeID, keyData)
+
+	return &ReshareResponse{
+		PublicKey: publicKey,
+		KeyShare:  frostKeyShares(req.KeyID, keyData)[p.thisNodeID],
+	}, nil
+}
+
+// ForgetKey 丢弃内存中缓存的密钥数据（keyShareStorage 中的数据由调用方删除）
+func (p *FROSTProtocol) ForgetKey(keyID string) {
+	p.mu.Lock()
+	delete(p.keyRecords, keyID)
+	p.mu.Unlock()
+}
+
+// discardKeyData 删除本节点的密钥数据（内存和 keyShareStorage）
+func (p *FROSTProtocol) discardKeyData(ctx context.Context, keyID string) error {
+	p.mu.Lock()
+	delete(p.keyRecords, keyID)
+	p.mu.Unlock()
+
+	if p.keyShareStorage == nil {
+		return nil
+	}
+	if err := p.keyShareStorage.DeleteKeyData(ctx, keyID, p.thisNodeID); err != nil {
+		return errors.Wrap(err, "failed to delete LocalPartySaveData")
+	}
+	log.Info().
+		Str("key_id", keyID).
+		Str("node_id", p.thisNodeID).
+		Msg("Old key share discarded after resharing")
+	return nil
+}
+
+// Presign 预处理 nonce（FROST 第一轮与消息无关），结果保存在本节点的 keyShareStorage 中
+// sessionID 即预签名ID，在线签名时由协调者通过 SignRequest.PresignatureID 指定
+func (p *FROSTProtocol) Presign(ctx context.Context, sessionID string, req *PresignRequest) (*PresignResponse, error) {
+	if req == nil || req.KeyID == "" {
+		return nil, errors.New("key ID is required")
+	}
+	if !presignIDPattern.MatchString(sessionID) {
+		return nil, errors.Errorf("invalid presignature ID: %q", sessionID)
+	}
+	if p.keyShareStorage == nil {
+		return nil, errors.New("key share storage is required for presignatures")
+	}
+
+	record, err := p.loadKeyRecord(ctx, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.Material == nil {
+		return nil, errors.New("presignatures require a key generated by the RFC 9591 DKG")
+	}
+
+	if err := p.presignRFC9591(ctx, sessionID, req, record.Material); err != nil {
+		return nil, errors.Wrap(err, "execute FROST presign")
+	}
+	return &PresignResponse{PresignatureID: sessionID}, nil
+}
+
+// ProcessIncomingResharingMessage 处理接收到的 resharing 消息
+func (p *FROSTProtocol) ProcessIncomingResharingMessage(
+	ctx context.Context,
+	sessionID string,
+	fromNodeID string,
+	msgBytes []byte,
+	isBroadcast bool,
+) error {
+	if msg, ok := parseFROSTWireMessage(msgBytes); ok {
+		return p.hub.deliver(sessionID, fromNodeID, msg)
+	}
+	return p.partyManager.ProcessIncomingResharingMessage(ctx, sessionID, fromNodeID, msgBytes, isBroadcast)
 }
 
 // ProcessIncomingKeygenMessage 处理接收到的DKG消息
@@ -462,6 +699,9 @@ func (p *FROSTProtocol) ProcessIncomingKeygenMessage(
 	msgBytes []byte,
 	isBroadcast bool,
 ) error {
+	if msg, ok := parseFROSTWireMessage(msgBytes); ok {
+		return p.hub.deliver(sessionID, fromNodeID, msg)
+	}
 	return p.partyManager.ProcessIncomingKeygenMessage(ctx, sessionID, fromNodeID, msgBytes, isBroadcast)
 }
 
@@ -473,6 +713,9 @@ func (p *FROSTProtocol) ProcessIncomingSigningMessage(
 	msgBytes []byte,
 	isBroadcast bool,
 ) error {
+	if msg, ok := parseFROSTWireMessage(msgBytes); ok {
+		return p.hub.deliver(sessionID, fromNodeID, msg)
+	}
 	return p.partyManager.ProcessIncomingSigningMessage(ctx, sessionID, fromNodeID, msgBytes, isBroadcast)
 }
 
@@ -552,30 +795,28 @@ func verifyEd25519Signature(sig *Signature, msg []byte, pubKey *PublicKey) (bool
 }
 
 // verifySecp256k1SchnorrSignature 验证 secp256k1 Schnorr 签名（BIP-340）
-// 注意：这里使用简化的验证方法，实际应该实现完整的 BIP-340 Schnorr 验证
+// 公钥可以是 33/65 字节 SEC1 编码或 32 字节 x-only 编码，验证时只使用其 x 坐标
 func verifySecp256k1SchnorrSignature(sig *Signature, msg []byte, pubKey *PublicKey) (bool, error) {
-	// secp256k1 Schnorr 签名格式：R (32 bytes) || S (32 bytes) = 64 bytes
+	// BIP-340 签名格式：x(R) (32 bytes) || s (32 bytes) = 64 bytes
 	if len(sig.Bytes) != 64 {
 		return false, errors.Errorf("invalid secp256k1 Schnorr signature length: expected 64 bytes, got %d", len(sig.Bytes))
 	}
 
-	// 验证公钥格式
-	if _, err := secp256k1.ParsePubKey(pubKey.Bytes); err != nil {
+	pk, err := frostSecp256k1Suite.deserializeElement(pubKey.Bytes)
+	if err != nil {
 		return false, errors.Wrap(err, "failed to parse secp256k1 public key")
 	}
 
-	// 注意：这里使用简化的验证方法
-	// 完整的 BIP-340 Schnorr 验证需要实现：
-	// 1. 验证 R 是有效的曲线点
-	// 2. 计算挑战 c = H(R || P || m)
-	// 3. 验证 s*G = R + c*P
-	//
-	// 由于 tss-lib 的 EdDSA keygen 可能不支持 secp256k1，这里暂时使用 ECDSA 验证作为回退
-	// 如果 tss-lib 支持 secp256k1 的 Schnorr，应该使用专门的 Schnorr 验证库
-
-	// 临时实现：使用 ECDSA 验证作为回退方案
-	// TODO: 实现完整的 BIP-340 Schnorr 验证
-	return verifyECDSASignature(sig, msg, pubKey)
+	if err := frostSecp256k1Suite.verifySignature(pk, msg, sig.Bytes); err != nil {
+		log.Warn().
+			Err(err).
+			Int("message_length", len(msg)).
+			Str("signature_hex", hex.EncodeToString(sig.Bytes)).
+			Str("public_key_hex", hex.EncodeToString(pubKey.Bytes)).
+			Msg("BIP-340 signature verification failed")
+		return false, nil
+	}
+	return true, nil
 }
 
 // validateSignRequest 验证签名请求（通用验证逻辑）
@@ -592,6 +833,9 @@ func validateSignRequest(req *SignRequest) error {
 	if len(req.NodeIDs) == 0 {
 		return errors.New("node IDs are required")
 	}
+	if req.DerivationPath != "" && req.PresignatureID != "" {
+		return errors.New("presignatures cannot be used with a derivation path")
+	}
 	return nil
 }
 
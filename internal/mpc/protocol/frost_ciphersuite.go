@@ -0,0 +1,295 @@
+package protocol
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+)
+
+// FROST 密码套件名称（与 KeyGenRequest.Curve 一致）
+const (
+	frostCiphersuiteEd25519   = "ed25519"
+	frostCiphersuiteSecp256k1 = "secp256k1"
+)
+
+// frostCiphersuite RFC 9591 密码套件
+// Ed25519 使用 FROST(Ed25519, SHA-512)，签名可用标准 Ed25519 验证；
+// secp256k1 使用 BIP-340 兼容的 FROST(secp256k1, SHA-256-TR)：挑战值使用 BIP0340/challenge 标签哈希，
+// 群公钥和 R 都取偶数 Y（签名时按奇偶性翻转分片/nonce），签名为 x(R) || s，可用于 Taproot key-path 花费
+type frostCiphersuite struct {
+	name          string
+	contextString string
+	curve         elliptic.Curve
+	// xOnly 为 true 时使用 BIP-340 的 x-only 公钥和偶数 Y 约定
+	xOnly bool
+}
+
+var (
+	frostEd25519Suite = &frostCiphersuite{
+		name:          frostCiphersuiteEd25519,
+		contextString: "FROST-ED25519-SHA512-v1",
+		curve:         tss.Edwards(),
+	}
+	frostSecp256k1Suite = &frostCiphersuite{
+		name:          frostCiphersuiteSecp256k1,
+		contextString: "FROST-secp256k1-SHA256-TR-v1",
+		curve:         tss.S256(),
+		xOnly:         true,
+	}
+)
+
+// getFROSTCiphersuite 根据曲线名称获取密码套件
+func getFROSTCiphersuite(curve string) (*frostCiphersuite, error) {
+	switch strings.ToLower(curve) {
+	case "", frostCiphersuiteEd25519:
+		return frostEd25519Suite, nil
+	case frostCiphersuiteSecp256k1:
+		return frostSecp256k1Suite, nil
+	default:
+		return nil, errors.Errorf("unsupported FROST ciphersuite: %s (supported: ed25519, secp256k1)", curve)
+	}
+}
+
+// order 群的阶
+func (cs *frostCiphersuite) order() *big.Int {
+	return cs.curve.Params().N
+}
+
+// scalarBaseMult 计算 k·G
+func (cs *frostCiphersuite) scalarBaseMult(k *big.Int) *crypto.ECPoint {
+	x, y := cs.curve.ScalarBaseMult(new(big.Int).Mod(k, cs.order()).Bytes())
+	return crypto.NewECPointNoCurveCheck(cs.curve, x, y)
+}
+
+// scalarMult 计算 k·P
+func (cs *frostCiphersuite) scalarMult(p *crypto.ECPoint, k *big.Int) *crypto.ECPoint {
+	x, y := cs.curve.ScalarMult(p.X(), p.Y(), new(big.Int).Mod(k, cs.order()).Bytes())
+	return crypto.NewECPointNoCurveCheck(cs.curve, x, y)
+}
+
+// isIdentity 判断点是否为单位元（secp256k1 用 (0,0) 表示，Ed25519 为 (0,1)）
+func (cs *frostCiphersuite) isIdentity(p *crypto.ECPoint) bool {
+	if p == nil {
+		return true
+	}
+	if cs.name == frostCiphersuiteEd25519 {
+		return p.X().Sign() == 0 && p.Y().Cmp(big.NewInt(1)) == 0
+	}
+	return p.X().Sign() == 0 && p.Y().Sign() == 0
+}
+
+// addPoints 点加法（结果为单位元时返回错误）
+func (cs *frostCiphersuite) addPoints(a, b *crypto.ECPoint) (*crypto.ECPoint, error) {
+	x, y := cs.curve.Add(a.X(), a.Y(), b.X(), b.Y())
+	p := crypto.NewECPointNoCurveCheck(cs.curve, x, y)
+	if cs.isIdentity(p) {
+		return nil, errors.New("point addition resulted in the identity element")
+	}
+	return p, nil
+}
+
+// negatePoint 点取反
+func (cs *frostCiphersuite) negatePoint(p *crypto.ECPoint) *crypto.ECPoint {
+	if cs.name == frostCiphersuiteEd25519 {
+		// 扭曲爱德华曲线：-(x, y) = (-x, y)
+		return crypto.NewECPointNoCurveCheck(cs.curve, new(big.Int).Sub(cs.curve.Params().P, p.X()), new(big.Int).Set(p.Y()))
+	}
+	return crypto.NewECPointNoCurveCheck(cs.curve, new(big.Int).Set(p.X()), new(big.Int).Sub(cs.curve.Params().P, p.Y()))
+}
+
+// hasOddY 点的 Y 坐标是否为奇数（BIP-340）
+func hasOddY(p *crypto.ECPoint) bool {
+	return p.Y().Bit(0) == 1
+}
+
+// serializeElement 序列化群元素：Ed25519 为 32 字节压缩编码，secp256k1 为 33 字节 SEC1 压缩编码
+func (cs *frostCiphersuite) serializeElement(p *crypto.ECPoint) []byte {
+	if cs.name == frostCiphersuiteEd25519 {
+		return edwards.NewPublicKey(p.X(), p.Y()).Serialize()
+	}
+	var fx, fy secp256k1.FieldVal
+	fx.SetByteSlice(p.X().Bytes())
+	fy.SetByteSlice(p.Y().Bytes())
+	return secp256k1.NewPublicKey(&fx, &fy).SerializeCompressed()
+}
+
+// deserializeElement 反序列化群元素并校验其在曲线上且不是单位元
+// secp256k1 额外接受 65 字节未压缩编码和 32 字节 x-only 编码（取偶数 Y）
+func (cs *frostCiphersuite) deserializeElement(data []byte) (*crypto.ECPoint, error) {
+	var x, y *big.Int
+	if cs.name == frostCiphersuiteEd25519 {
+		pub, err := edwards.ParsePubKey(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid Ed25519 element")
+		}
+		x, y = pub.X, pub.Y
+	} else {
+		if len(data) == 32 {
+			data = append([]byte{secp256k1.PubKeyFormatCompressedEven}, data...)
+		}
+		pub, err := secp256k1.ParsePubKey(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid secp256k1 element")
+		}
+		x, y = pub.X(), pub.Y()
+	}
+	p, err := crypto.NewECPoint(cs.curve, x, y)
+	if err != nil {
+		return nil, errors.Wrap(err, "element is not on the curve")
+	}
+	if cs.isIdentity(p) {
+		return nil, errors.New("element is the identity")
+	}
+	return p, nil
+}
+
+// serializePublicKey 序列化对外公钥：Ed25519 为 32 字节，secp256k1 为 33 字节压缩公钥
+// （BIP-340 验证时取其 x 坐标）
+func (cs *frostCiphersuite) serializePublicKey(p *crypto.ECPoint) []byte {
+	return cs.serializeElement(p)
+}
+
+// serializeScalar 序列化标量：Ed25519 为 32 字节小端，secp256k1 为 32 字节大端
+func (cs *frostCiphersuite) serializeScalar(k *big.Int) []byte {
+	out := make([]byte, 32)
+	new(big.Int).Mod(k, cs.order()).FillBytes(out)
+	if cs.name == frostCiphersuiteEd25519 {
+		reverseBytes(out)
+	}
+	return out
+}
+
+// deserializeScalar 反序列化标量并校验其小于群的阶
+func (cs *frostCiphersuite) deserializeScalar(data []byte) (*big.Int, error) {
+	if len(data) != 32 {
+		return nil, errors.Errorf("invalid scalar length: %d", len(data))
+	}
+	buf := append([]byte(nil), data...)
+	if cs.name == frostCiphersuiteEd25519 {
+		reverseBytes(buf)
+	}
+	k := new(big.Int).SetBytes(buf)
+	if k.Cmp(cs.order()) >= 0 {
+		return nil, errors.New("scalar is not reduced modulo the group order")
+	}
+	return k, nil
+}
+
+// hashToScalar 带域分隔标签的哈希到标量（RFC 9591 H1/H3 以及 DKG 使用的 HDKG）
+func (cs *frostCiphersuite) hashToScalar(tag string, parts ...[]byte) *big.Int {
+	if cs.name == frostCiphersuiteEd25519 {
+		h := sha512.New()
+		h.Write([]byte(cs.contextString + tag))
+		for _, part := range parts {
+			h.Write(part)
+		}
+		return cs.scalarFromLittleEndian(h.Sum(nil))
+	}
+	var msg []byte
+	for _, part := range parts {
+		msg = append(msg, part...)
+	}
+	uniform := expandMessageXMD(msg, []byte(cs.contextString+tag), 48)
+	return new(big.Int).Mod(new(big.Int).SetBytes(uniform), cs.order())
+}
+
+// hash 带域分隔标签的哈希（RFC 9591 H4/H5）
+func (cs *frostCiphersuite) hash(tag string, parts ...[]byte) []byte {
+	if cs.name == frostCiphersuiteEd25519 {
+		h := sha512.New()
+		h.Write([]byte(cs.contextString + tag))
+		for _, part := range parts {
+			h.Write(part)
+		}
+		return h.Sum(nil)
+	}
+	h := sha256.New()
+	h.Write([]byte(cs.contextString + tag))
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// challenge 计算 Schnorr 挑战值（RFC 9591 H2）
+// Ed25519：SHA-512(R || PK || msg)，与 RFC 8032 一致；secp256k1：BIP-340 tagged_hash("BIP0340/challenge", x(R) || x(PK) || msg)
+func (cs *frostCiphersuite) challenge(groupCommitment, groupPublicKey *crypto.ECPoint, message []byte) *big.Int {
+	if cs.name == frostCiphersuiteEd25519 {
+		h := sha512.New()
+		h.Write(cs.serializeElement(groupCommitment))
+		h.Write(cs.serializeElement(groupPublicKey))
+		h.Write(message)
+		return cs.scalarFromLittleEndian(h.Sum(nil))
+	}
+	digest := chainhash.TaggedHash(chainhash.TagBIP0340Challenge,
+		xOnlyBytes(groupCommitment), xOnlyBytes(groupPublicKey), message)
+	return new(big.Int).Mod(new(big.Int).SetBytes(digest[:]), cs.order())
+}
+
+func (cs *frostCiphersuite) scalarFromLittleEndian(data []byte) *big.Int {
+	buf := append([]byte(nil), data...)
+	reverseBytes(buf)
+	return new(big.Int).Mod(new(big.Int).SetBytes(buf), cs.order())
+}
+
+// xOnlyBytes 返回点的 32 字节 x 坐标（BIP-340）
+func xOnlyBytes(p *crypto.ECPoint) []byte {
+	out := make([]byte, 32)
+	p.X().FillBytes(out)
+	return out
+}
+
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// expandMessageXMD RFC 9380 expand_message_xmd（SHA-256）
+func expandMessageXMD(msg, dst []byte, lenInBytes int) []byte {
+	const bInBytes, sInBytes = sha256.Size, sha256.BlockSize
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	dstPrime := append(append([]byte(nil), dst...), byte(len(dst)))
+
+	var lib [2]byte
+	binary.BigEndian.PutUint16(lib[:], uint16(lenInBytes))
+
+	h := sha256.New()
+	h.Write(make([]byte, sInBytes))
+	h.Write(msg)
+	h.Write(lib[:])
+	h.Write([]byte{0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	uniform := append([]byte(nil), bi...)
+	for i := 2; i <= ell; i++ {
+		xored := make([]byte, bInBytes)
+		for j := range xored {
+			xored[j] = b0[j] ^ bi[j]
+		}
+		h.Reset()
+		h.Write(xored)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		uniform = append(uniform, bi...)
+	}
+	return uniform[:lenInBytes]
+}
@@ -0,0 +1,457 @@
+package protocol
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// frostKeyDataVersion RFC 9591 密钥数据格式版本（旧版 tss-lib EdDSA 数据没有该字段）
+const frostKeyDataVersion = 1
+
+// frostKeyData 本节点的 FROST 密钥数据（持久化到 keyShareStorage）
+// Threshold 为最少签名者数量（多项式次数为 Threshold-1）
+type frostKeyData struct {
+	Version         int               `json:"frost_version"`
+	Ciphersuite     string            `json:"ciphersuite"`
+	KeyID           string            `json:"key_id"`
+	Threshold       int               `json:"threshold"`
+	Epoch           int               `json:"epoch"`
+	NodeIDs         []string          `json:"node_ids"`
+	Identifiers     map[string]int    `json:"identifiers"`
+	SecretShare     []byte            `json:"secret_share"`
+	VerifyingShares map[string][]byte `json:"verifying_shares"`
+	GroupPublicKey  []byte            `json:"group_public_key"`
+}
+
+// frostKeyMaterial 解码后的 FROST 密钥数据
+type frostKeyMaterial struct {
+	data            *frostKeyData
+	cs              *frostCiphersuite
+	identifier      *big.Int
+	secretShare     *big.Int
+	verifyingShares map[string]*crypto.ECPoint
+	groupPublicKey  *crypto.ECPoint
+}
+
+// parseFROSTKeyData 解析 RFC 9591 格式的密钥数据，旧版格式返回 false
+func parseFROSTKeyData(data []byte) (*frostKeyData, bool) {
+	var keyData frostKeyData
+	if err := json.Unmarshal(data, &keyData); err != nil || keyData.Version == 0 {
+		return nil, false
+	}
+	return &keyData, true
+}
+
+// decode 校验并解码密钥数据
+func (d *frostKeyData) decode(thisNodeID string) (*frostKeyMaterial, error) {
+	cs, err := getFROSTCiphersuite(d.Ciphersuite)
+	if err != nil {
+		return nil, err
+	}
+	index, ok := d.Identifiers[thisNodeID]
+	if !ok {
+		return nil, errors.Errorf("node %s has no identifier in key %s", thisNodeID, d.KeyID)
+	}
+	secretShare, err := cs.deserializeScalar(d.SecretShare)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid secret share")
+	}
+	groupPublicKey, err := cs.deserializeElement(d.GroupPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid group public key")
+	}
+	verifyingShares := make(map[string]*crypto.ECPoint, len(d.VerifyingShares))
+	for nodeID, encoded := range d.VerifyingShares {
+		point, err := cs.deserializeElement(encoded)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid verifying share for node %s", nodeID)
+		}
+		verifyingShares[nodeID] = point
+	}
+	if own, ok := verifyingShares[thisNodeID]; !ok || !cs.scalarBaseMult(secretShare).Equals(own) {
+		return nil, errors.New("secret share does not match its verifying share")
+	}
+	return &frostKeyMaterial{
+		data:            d,
+		cs:              cs,
+		identifier:      frostIdentifier(index),
+		secretShare:     secretShare,
+		verifyingShares: verifyingShares,
+		groupPublicKey:  groupPublicKey,
+	}, nil
+}
+
+// publicKey 群公钥（Ed25519 为 32 字节，secp256k1 为 33 字节压缩编码）
+func (m *frostKeyMaterial) publicKey() *PublicKey {
+	pubKeyBytes := m.cs.serializePublicKey(m.groupPublicKey)
+	return &PublicKey{Bytes: pubKeyBytes, Hex: hex.EncodeToString(pubKeyBytes)}
+}
+
+// identifierOf 返回委员会中节点的 identifier
+func (m *frostKeyMaterial) identifierOf(nodeID string) (*big.Int, error) {
+	index, ok := m.data.Identifiers[nodeID]
+	if !ok {
+		return nil, errors.Errorf("node %s is not a member of key %s", nodeID, m.data.KeyID)
+	}
+	return frostIdentifier(index), nil
+}
+
+// assignFROSTIdentifiers 按节点ID排序后依次分配 identifier（从 1 开始）
+func assignFROSTIdentifiers(nodeIDs []string) ([]string, map[string]int) {
+	sorted := append([]string(nil), nodeIDs...)
+	sort.Strings(sorted)
+	identifiers := make(map[string]int, len(sorted))
+	for i, nodeID := range sorted {
+		identifiers[nodeID] = i + 1
+	}
+	return sorted, identifiers
+}
+
+// frostDKGCommitPayload DKG 第一轮广播内容
+type frostDKGCommitPayload struct {
+	Ciphersuite string   `json:"ciphersuite"`
+	Commitments [][]byte `json:"commitments"`
+	ProofR      []byte   `json:"proof_r"`
+	ProofMu     []byte   `json:"proof_mu"`
+}
+
+// frostDKGSharePayload DKG 第二轮点对点内容（依赖节点间 gRPC 的 TLS 保证机密性）
+type frostDKGSharePayload struct {
+	Share []byte `json:"share"`
+}
+
+// executeDKG 执行 FROST DKG（Pedersen DKG + 知识证明，RFC 9591 附录 C）
+// 第一轮广播 Feldman 承诺和常数项知识证明，第二轮点对点发送分片；任一校验失败都会中止并指出作恶节点
+func (p *FROSTProtocol) executeDKG(ctx context.Context, cs *frostCiphersuite, keyID string, nodeIDs []string, threshold int) (*frostKeyData, error) {
+	sortedNodeIDs, identifiers := assignFROSTIdentifiers(nodeIDs)
+	index, ok := identifiers[p.thisNodeID]
+	if !ok {
+		return nil, errors.Errorf("this node %s is not a DKG participant", p.thisNodeID)
+	}
+	myIdentifier := frostIdentifier(index)
+	others := p.otherNodeIDs(sortedNodeIDs)
+	proofContext := []byte(keyID)
+
+	inbox := p.hub.claim(keyID)
+	defer p.hub.release(keyID)
+
+	// 第一轮：生成 t-1 次多项式，广播承诺和知识证明
+	secret, err := cs.randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	coefficients, err := cs.newPolynomial(secret, threshold-1)
+	if err != nil {
+		return nil, err
+	}
+	myCommitments := cs.commitPolynomial(coefficients)
+	proofR, proofMu, err := cs.proveKnowledge(proofContext, myIdentifier, secret, myCommitments[0])
+	if err != nil {
+		return nil, err
+	}
+	commitPayload := &frostDKGCommitPayload{
+		Ciphersuite: cs.name,
+		ProofR:      cs.serializeElement(proofR),
+		ProofMu:     cs.serializeScalar(proofMu),
+	}
+	for _, c := range myCommitments {
+		commitPayload.Commitments = append(commitPayload.Commitments, cs.serializeElement(c))
+	}
+	if err := p.sendFROSTMessage(keyID, frostRoundDKGCommit, others, commitPayload, true); err != nil {
+		return nil, err
+	}
+
+	received, err := inbox.collect(ctx, frostRoundDKGCommit, others)
+	if err != nil {
+		return nil, err
+	}
+	commitments := map[string][]*crypto.ECPoint{p.thisNodeID: myCommitments}
+	for nodeID, msg := range received {
+		var payload frostDKGCommitPayload
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed DKG commitment", nodeID)
+		}
+		if payload.Ciphersuite != cs.name {
+			return nil, errors.Errorf("node %s uses ciphersuite %s, expected %s", nodeID, payload.Ciphersuite, cs.name)
+		}
+		if len(payload.Commitments) != threshold {
+			return nil, errors.Errorf("node %s sent %d commitments, expected %d", nodeID, len(payload.Commitments), threshold)
+		}
+		points := make([]*crypto.ECPoint, 0, threshold)
+		for _, encoded := range payload.Commitments {
+			point, err := cs.deserializeElement(encoded)
+			if err != nil {
+				return nil, errors.Wrapf(err, "node %s sent an invalid commitment", nodeID)
+			}
+			points = append(points, point)
+		}
+		r, err := cs.deserializeElement(payload.ProofR)
+		if err != nil {
+			return nil, errors.Wrapf(err, "node %s sent an invalid proof of knowledge", nodeID)
+		}
+		mu, err := cs.deserializeScalar(payload.ProofMu)
+		if err != nil {
+			return nil, errors.Wrapf(err, "node %s sent an invalid proof of knowledge", nodeID)
+		}
+		if err := cs.verifyKnowledge(proofContext, frostIdentifier(identifiers[nodeID]), points[0], r, mu); err != nil {
+			return nil, newAbortError("FROST DKG", keyID, fmt.Sprintf("node %s failed the DKG proof of knowledge", nodeID), []string{nodeID}, err)
+		}
+		commitments[nodeID] = points
+	}
+
+	// 第二轮：向每个节点发送 f_i(j)
+	for _, nodeID := range others {
+		share := cs.evalPolynomial(coefficients, frostIdentifier(identifiers[nodeID]))
+		if err := p.sendFROSTDirect(keyID, frostRoundDKGShare, nodeID, &frostDKGSharePayload{Share: cs.serializeScalar(share)}); err != nil {
+			return nil, err
+		}
+	}
+
+	received, err = inbox.collect(ctx, frostRoundDKGShare, others)
+	if err != nil {
+		return nil, err
+	}
+	modN := common.ModInt(cs.order())
+	secretShare := cs.evalPolynomial(coefficients, myIdentifier)
+	for nodeID, msg := range received {
+		var payload frostDKGSharePayload
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed DKG share", nodeID)
+		}
+		share, err := cs.deserializeScalar(payload.Share)
+		if err != nil {
+			return nil, errors.Wrapf(err, "node %s sent an invalid DKG share", nodeID)
+		}
+		if err := cs.verifyVSSShare(share, myIdentifier, commitments[nodeID]); err != nil {
+			return nil, newAbortError("FROST DKG", keyID, fmt.Sprintf("node %s sent a DKG share that does not match its commitment", nodeID), []string{nodeID}, err)
+		}
+		secretShare = modN.Add(secretShare, share)
+	}
+
+	return buildFROSTKeyData(cs, keyID, threshold, 0, sortedNodeIDs, identifiers, secretShare, commitments)
+}
+
+// buildFROSTKeyData 由各节点的多项式承诺计算群公钥和所有节点的验证分片
+func buildFROSTKeyData(
+	cs *frostCiphersuite,
+	keyID string,
+	threshold int,
+	epoch int,
+	sortedNodeIDs []string,
+	identifiers map[string]int,
+	secretShare *big.Int,
+	commitments map[string][]*crypto.ECPoint,
+) (*frostKeyData, error) {
+	dealers := make([]string, 0, len(commitments))
+	for nodeID := range commitments {
+		dealers = append(dealers, nodeID)
+	}
+	sort.Strings(dealers)
+
+	// 合并承诺：C_k = Σ_i C_{i,k}
+	combined := make([]*crypto.ECPoint, threshold)
+	for _, dealer := range dealers {
+		for k, point := range commitments[dealer] {
+			if combined[k] == nil {
+				combined[k] = point
+				continue
+			}
+			sum, err := cs.addPoints(combined[k], point)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid combined commitment")
+			}
+			combined[k] = sum
+		}
+	}
+
+	keyData := &frostKeyData{
+		Version:         frostKeyDataVersion,
+		Ciphersuite:     cs.name,
+		KeyID:           keyID,
+		Threshold:       threshold,
+		Epoch:           epoch,
+		NodeIDs:         sortedNodeIDs,
+		Identifiers:     identifiers,
+		SecretShare:     cs.serializeScalar(secretShare),
+		VerifyingShares: make(map[string][]byte, len(sortedNodeIDs)),
+		GroupPublicKey:  cs.serializeElement(combined[0]),
+	}
+	for _, nodeID := range sortedNodeIDs {
+		verifyingShare, err := cs.evalCommitment(combined, frostIdentifier(identifiers[nodeID]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid verifying share for node %s", nodeID)
+		}
+		keyData.VerifyingShares[nodeID] = cs.serializeElement(verifyingShare)
+	}
+	return keyData, nil
+}
+
+// frostResharePayload 旧委员会成员发给新委员会成员的子分片
+// 每个旧成员对 λ_i·s_i 做 Feldman 秘密分享，新成员将收到的子分片相加得到新分片，群公钥不变
+type frostResharePayload struct {
+	Ciphersuite    string   `json:"ciphersuite"`
+	GroupPublicKey []byte   `json:"group_public_key"`
+	Commitments    [][]byte `json:"commitments"`
+	Share          []byte   `json:"share"`
+}
+
+// executeResharing 执行 FROST 重分享
+// oldKey 为本节点在旧委员会中的密钥（不在旧委员会时为 nil）；本节点不在新委员会时返回 nil
+func (p *FROSTProtocol) executeResharing(ctx context.Context, req *ReshareRequest, oldKey *frostKeyMaterial) (*frostKeyData, error) {
+	sessionID := req.SessionID
+	inbox := p.hub.claim(sessionID)
+	defer p.hub.release(sessionID)
+
+	sortedNewNodeIDs, newIdentifiers := assignFROSTIdentifiers(req.NewNodeIDs)
+	oldNodeIDs := append([]string(nil), req.OldNodeIDs...)
+	sort.Strings(oldNodeIDs)
+
+	// 旧委员会成员：分享 λ_i·s_i
+	if oldKey != nil {
+		cs := oldKey.cs
+		oldIdentifiers := make([]*big.Int, 0, len(oldNodeIDs))
+		for _, nodeID := range oldNodeIDs {
+			identifier, err := oldKey.identifierOf(nodeID)
+			if err != nil {
+				return nil, err
+			}
+			oldIdentifiers = append(oldIdentifiers, identifier)
+		}
+		lambda, err := cs.lagrangeCoefficient(oldKey.identifier, oldIdentifiers)
+		if err != nil {
+			return nil, err
+		}
+		modN := common.ModInt(cs.order())
+		coefficients, err := cs.newPolynomial(modN.Mul(lambda, oldKey.secretShare), req.NewThreshold-1)
+		if err != nil {
+			return nil, err
+		}
+		var encodedCommitments [][]byte
+		for _, c := range cs.commitPolynomial(coefficients) {
+			encodedCommitments = append(encodedCommitments, cs.serializeElement(c))
+		}
+		for _, nodeID := range sortedNewNodeIDs {
+			payload := &frostResharePayload{
+				Ciphersuite:    cs.name,
+				GroupPublicKey: oldKey.data.GroupPublicKey,
+				Commitments:    encodedCommitments,
+				Share:          cs.serializeScalar(cs.evalPolynomial(coefficients, frostIdentifier(newIdentifiers[nodeID]))),
+			}
+			if nodeID == p.thisNodeID {
+				data, err := json.Marshal(payload)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to marshal reshare payload")
+				}
+				if err := inbox.put(&frostWireMessage{Protocol: frostWireProtocol, Round: frostRoundReshare, From: p.thisNodeID, Payload: data}); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := p.sendFROSTDirect(sessionID, frostRoundReshare, nodeID, payload); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if _, ok := newIdentifiers[p.thisNodeID]; !ok {
+		return nil, nil
+	}
+
+	// 新委员会成员：收集所有旧成员的子分片
+	received, err := inbox.collect(ctx, frostRoundReshare, oldNodeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var cs *frostCiphersuite
+	var groupPublicKey []byte
+	myIdentifier := frostIdentifier(newIdentifiers[p.thisNodeID])
+	commitments := make(map[string][]*crypto.ECPoint, len(received))
+	var secretShare *big.Int
+	for _, nodeID := range oldNodeIDs {
+		var payload frostResharePayload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed reshare message", nodeID)
+		}
+		if cs == nil {
+			if cs, err = getFROSTCiphersuite(payload.Ciphersuite); err != nil {
+				return nil, errors.Wrapf(err, "node %s sent an unknown ciphersuite", nodeID)
+			}
+			groupPublicKey = payload.GroupPublicKey
+		}
+		if payload.Ciphersuite != cs.name || hex.EncodeToString(payload.GroupPublicKey) != hex.EncodeToString(groupPublicKey) {
+			return nil, errors.Errorf("node %s disagrees on the key being reshared", nodeID)
+		}
+		if len(payload.Commitments) != req.NewThreshold {
+			return nil, errors.Errorf("node %s sent %d commitments, expected %d", nodeID, len(payload.Commitments), req.NewThreshold)
+		}
+		points := make([]*crypto.ECPoint, 0, len(payload.Commitments))
+		for _, encoded := range payload.Commitments {
+			point, err := cs.deserializeElement(encoded)
+			if err != nil {
+				return nil, errors.Wrapf(err, "node %s sent an invalid commitment", nodeID)
+			}
+			points = append(points, point)
+		}
+		share, err := cs.deserializeScalar(payload.Share)
+		if err != nil {
+			return nil, errors.Wrapf(err, "node %s sent an invalid reshare share", nodeID)
+		}
+		if err := cs.verifyVSSShare(share, myIdentifier, points); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a reshare share that does not match its commitment", nodeID)
+		}
+		commitments[nodeID] = points
+		if secretShare == nil {
+			secretShare = share
+		} else {
+			secretShare = common.ModInt(cs.order()).Add(secretShare, share)
+		}
+	}
+
+	keyData, err := buildFROSTKeyData(cs, req.KeyID, req.NewThreshold, req.NewEpoch, sortedNewNodeIDs, newIdentifiers, secretShare, commitments)
+	if err != nil {
+		return nil, err
+	}
+	// Σ λ_i·s_i = s：子分片常数项之和必须等于原群公钥
+	if hex.EncodeToString(keyData.GroupPublicKey) != hex.EncodeToString(groupPublicKey) {
+		return nil, errors.New("reshared commitments do not reconstruct the group public key")
+	}
+	return keyData, nil
+}
+
+// frostKeyShares 为所有节点生成 KeyShare 描述（分片本身只保存在各节点的 keyShareStorage 中）
+func frostKeyShares(keyID string, keyData *frostKeyData) map[string]*KeyShare {
+	keyShares := make(map[string]*KeyShare, len(keyData.NodeIDs))
+	for _, nodeID := range keyData.NodeIDs {
+		index := keyData.Identifiers[nodeID]
+		keyShares[nodeID] = &KeyShare{
+			ShareID: fmt.Sprintf("%s-%02d", keyID, index),
+			NodeID:  nodeID,
+			Share:   nil,
+			Index:   index,
+		}
+	}
+	return keyShares
+}
+
+// logFROSTKeyData 记录密钥生成/重分享结果
+func logFROSTKeyData(msg string, thisNodeID string, keyData *frostKeyData) {
+	log.Info().
+		Str("key_id", keyData.KeyID).
+		Str("node_id", thisNodeID).
+		Str("ciphersuite", keyData.Ciphersuite).
+		Int("threshold", keyData.Threshold).
+		Int("epoch", keyData.Epoch).
+		Strs("node_ids", keyData.NodeIDs).
+		Str("group_public_key", hex.EncodeToString(keyData.GroupPublicKey)).
+		Msg(msg)
+}
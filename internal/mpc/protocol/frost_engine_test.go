@@ -0,0 +1,242 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frostTestCluster 进程内的多节点 FROST 集群，消息路由直接投递到目标节点的引擎
+type frostTestCluster struct {
+	storage *memoryKeyDataStorage
+	nodes   map[string]*FROSTProtocol
+}
+
+func newFROSTTestCluster(nodeIDs ...string) *frostTestCluster {
+	c := &frostTestCluster{
+		storage: newMemoryKeyDataStorage(),
+		nodes:   make(map[string]*FROSTProtocol),
+	}
+	for _, nodeID := range nodeIDs {
+		c.addNode(nodeID)
+	}
+	return c
+}
+
+func (c *frostTestCluster) addNode(nodeID string) *FROSTProtocol {
+	from := nodeID
+	router := func(sessionID string, toNodeID string, msg tss.Message, isBroadcast bool) error {
+		data, _, err := msg.WireBytes()
+		if err != nil {
+			return err
+		}
+		target, ok := c.nodes[toNodeID]
+		if !ok {
+			return fmt.Errorf("unknown node %s", toNodeID)
+		}
+		ctx := context.Background()
+		switch {
+		case strings.HasPrefix(sessionID, "reshare-"):
+			return target.ProcessIncomingResharingMessage(ctx, sessionID, from, data, isBroadcast)
+		case strings.HasPrefix(sessionID, "key-"):
+			return target.ProcessIncomingKeygenMessage(ctx, sessionID, from, data, isBroadcast)
+		default:
+			return target.ProcessIncomingSigningMessage(ctx, sessionID, from, data, isBroadcast)
+		}
+	}
+	node := NewFROSTProtocol("ed25519", nodeID, router, c.storage)
+	c.nodes[nodeID] = node
+	return node
+}
+
+// run 在 nodeIDs 上并发执行 fn，返回各节点的结果
+func frostRunAll[T any](t *testing.T, nodeIDs []string, fn func(ctx context.Context, nodeID string) (T, error)) map[string]T {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]T, len(nodeIDs))
+	errs := make(map[string]error)
+	for _, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+			result, err := fn(ctx, nodeID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[nodeID] = err
+				return
+			}
+			results[nodeID] = result
+		}(nodeID)
+	}
+	wg.Wait()
+	for nodeID, err := range errs {
+		require.NoError(t, err, "node %s", nodeID)
+	}
+	return results
+}
+
+func (c *frostTestCluster) keygen(t *testing.T, keyID string, curve string, threshold int, nodeIDs []string) *PublicKey {
+	t.Helper()
+	results := frostRunAll(t, nodeIDs, func(ctx context.Context, nodeID string) (*KeyGenResponse, error) {
+		return c.nodes[nodeID].GenerateKeyShare(ctx, &KeyGenRequest{
+			KeyID:      keyID,
+			Algorithm:  "Schnorr",
+			Curve:      curve,
+			Threshold:  threshold,
+			TotalNodes: len(nodeIDs),
+			NodeIDs:    nodeIDs,
+		})
+	})
+	var publicKey *PublicKey
+	for _, resp := range results {
+		if publicKey == nil {
+			publicKey = resp.PublicKey
+		}
+		require.Equal(t, publicKey.Hex, resp.PublicKey.Hex, "all nodes must derive the same group public key")
+		require.Len(t, resp.KeyShares, len(nodeIDs))
+	}
+	return publicKey
+}
+
+func (c *frostTestCluster) sign(t *testing.T, sessionID string, req SignRequest) *SignResponse {
+	t.Helper()
+	results := frostRunAll(t, req.NodeIDs, func(ctx context.Context, nodeID string) (*SignResponse, error) {
+		r := req
+		return c.nodes[nodeID].ThresholdSign(ctx, sessionID, &r)
+	})
+	var resp *SignResponse
+	for _, r := range results {
+		if resp == nil {
+			resp = r
+		}
+		require.Equal(t, resp.Signature.Hex, r.Signature.Hex, "all signers must aggregate the same signature")
+	}
+	return resp
+}
+
+// TestFROSTProtocol_Secp256k1EndToEnd DKG、两轮签名、nonce 预处理、Taproot 和重分享的端到端测试
+func TestFROSTProtocol_Secp256k1EndToEnd(t *testing.T) {
+	c := newFROSTTestCluster("node-1", "node-2", "node-3", "node-4")
+	keyID := "key-frost-secp"
+	publicKey := c.keygen(t, keyID, "secp256k1", 2, []string{"node-1", "node-2", "node-3"})
+	require.Len(t, publicKey.Bytes, 33)
+
+	hash := sha256.Sum256([]byte("frost secp256k1 end to end"))
+
+	// 两轮签名
+	resp := c.sign(t, "sign-1", SignRequest{KeyID: keyID, Message: hash[:], NodeIDs: []string{"node-1", "node-3"}})
+	valid, err := c.nodes["node-1"].VerifySignature(context.Background(), resp.Signature, hash[:], publicKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// nonce 预处理 + 单轮在线签名
+	signers := []string{"node-2", "node-3"}
+	frostRunAll(t, signers, func(ctx context.Context, nodeID string) (*PresignResponse, error) {
+		return c.nodes[nodeID].Presign(ctx, "presign-1", &PresignRequest{KeyID: keyID, NodeIDs: signers})
+	})
+	resp = c.sign(t, "sign-2", SignRequest{KeyID: keyID, Message: hash[:], NodeIDs: signers, PresignatureID: "presign-1"})
+	valid, err = verifySecp256k1SchnorrSignature(resp.Signature, hash[:], publicKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// 预处理的 nonce 只能使用一次
+	_, err = c.nodes["node-2"].ThresholdSign(context.Background(), "sign-3", &SignRequest{KeyID: keyID, Message: hash[:], NodeIDs: signers, PresignatureID: "presign-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already used")
+
+	// Taproot key-path（BIP-86）
+	resp = c.sign(t, "sign-4", SignRequest{KeyID: keyID, Message: hash[:], NodeIDs: []string{"node-1", "node-2"}, TaprootKeySpend: true})
+	outputKey, err := TaprootOutputKey(publicKey.Bytes, nil)
+	require.NoError(t, err)
+	assert.Equal(t, outputKey, resp.PublicKey.Bytes)
+	valid, err = verifySecp256k1SchnorrSignature(resp.Signature, hash[:], &PublicKey{Bytes: outputKey})
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// 重分享：node-1 退出，node-4 加入，公钥不变
+	reshareReq := ReshareRequest{
+		SessionID:    "reshare-frost-secp",
+		KeyID:        keyID,
+		OldNodeIDs:   []string{"node-1", "node-2"},
+		OldThreshold: 2,
+		NewNodeIDs:   []string{"node-2", "node-3", "node-4"},
+		NewThreshold: 2,
+		OldEpoch:     0,
+		NewEpoch:     1,
+	}
+	reshared := frostRunAll(t, []string{"node-1", "node-2", "node-3", "node-4"}, func(ctx context.Context, nodeID string) (*ReshareResponse, error) {
+		r := reshareReq
+		return c.nodes[nodeID].RotateKey(ctx, &r)
+	})
+	for nodeID, r := range reshared {
+		assert.Equal(t, publicKey.Hex, r.PublicKey.Hex, "node %s", nodeID)
+	}
+	assert.Nil(t, reshared["node-1"].KeyShare)
+	_, err = c.storage.GetKeyData(context.Background(), keyID, "node-1")
+	assert.Error(t, err, "old share must be discarded")
+
+	// 旧委员会的分片不能再参与签名
+	_, err = c.nodes["node-1"].ThresholdSign(context.Background(), "sign-old", &SignRequest{KeyID: keyID, Message: hash[:], NodeIDs: []string{"node-1", "node-2"}})
+	require.Error(t, err)
+
+	resp = c.sign(t, "sign-5", SignRequest{KeyID: keyID, Message: hash[:], NodeIDs: []string{"node-3", "node-4"}})
+	valid, err = verifySecp256k1SchnorrSignature(resp.Signature, hash[:], publicKey)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+// TestFROSTProtocol_Ed25519EndToEnd Ed25519 密钥生成并签名，结果可通过标准 Ed25519 验证
+func TestFROSTProtocol_Ed25519EndToEnd(t *testing.T) {
+	c := newFROSTTestCluster("node-1", "node-2", "node-3")
+	keyID := "key-frost-ed25519"
+	publicKey := c.keygen(t, keyID, "ed25519", 2, []string{"node-1", "node-2", "node-3"})
+	require.Len(t, publicKey.Bytes, 32)
+
+	message := []byte("frost ed25519 end to end")
+	resp := c.sign(t, "sign-1", SignRequest{KeyID: keyID, Message: message, NodeIDs: []string{"node-2", "node-3"}})
+	assert.True(t, ed25519.Verify(publicKey.Bytes, message, resp.Signature.Bytes))
+
+	// 重新加载（模拟重启）后仍可签名
+	c.nodes["node-1"] = nil
+	node1 := c.addNode("node-1")
+	resp = c.sign(t, "sign-2", SignRequest{KeyID: keyID, Message: message, NodeIDs: []string{"node-1", "node-2"}})
+	assert.True(t, ed25519.Verify(publicKey.Bytes, message, resp.Signature.Bytes))
+
+	_, err := node1.ThresholdSign(context.Background(), "sign-3", &SignRequest{KeyID: keyID, Message: message, NodeIDs: []string{"node-1", "node-2"}, TaprootKeySpend: true})
+	require.Error(t, err)
+}
+
+// TestFROSTProtocol_RejectsEquivocation 同一节点在同一轮次发送不同内容会被拒绝
+func TestFROSTProtocol_RejectsEquivocation(t *testing.T) {
+	p := NewFROSTProtocol("secp256k1", "node-1", mockMessageRouter, nil)
+	msg := &frostWireMessage{Protocol: frostWireProtocol, Round: frostRoundSignCommit, From: "node-2", Payload: []byte(`{"a":1}`)}
+	data, _, err := msg.WireBytes()
+	require.NoError(t, err)
+	require.NoError(t, p.ProcessIncomingSigningMessage(context.Background(), "sign-1", "node-2", data, true))
+	require.NoError(t, p.ProcessIncomingSigningMessage(context.Background(), "sign-1", "node-2", data, true))
+
+	msg.Payload = []byte(`{"a":2}`)
+	data, _, err = msg.WireBytes()
+	require.NoError(t, err)
+	err = p.ProcessIncomingSigningMessage(context.Background(), "sign-1", "node-2", data, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicting")
+
+	// 发送方与传输层不一致
+	err = p.ProcessIncomingSigningMessage(context.Background(), "sign-1", "node-3", data, true)
+	require.Error(t, err)
+}
@@ -0,0 +1,469 @@
+package protocol
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"math/big"
+	"sort"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/pkg/errors"
+)
+
+// frostNonce 签名者的一对一次性 nonce（hiding, binding），使用后必须销毁
+type frostNonce struct {
+	Hiding  *big.Int
+	Binding *big.Int
+}
+
+// frostCommitment 签名者对 nonce 的承诺 (D_i, E_i)
+type frostCommitment struct {
+	Identifier *big.Int
+	Hiding     *crypto.ECPoint
+	Binding    *crypto.ECPoint
+}
+
+// frostTaprootTweak BIP-341 key-path 调整（MerkleRoot 为空即 BIP-86）
+type frostTaprootTweak struct {
+	MerkleRoot []byte
+}
+
+// randomScalar 生成非零随机标量
+func (cs *frostCiphersuite) randomScalar() (*big.Int, error) {
+	for {
+		k, err := rand.Int(rand.Reader, cs.order())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate random scalar")
+		}
+		if k.Sign() != 0 {
+			return k, nil
+		}
+	}
+}
+
+// generateNonce RFC 9591 nonce_generate：H3(random_bytes || SerializeScalar(secret))
+func (cs *frostCiphersuite) generateNonce(secret *big.Int) (*big.Int, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to read random bytes")
+	}
+	return cs.hashToScalar("nonce", randomBytes, cs.serializeScalar(secret)), nil
+}
+
+// commit RFC 9591 commit：生成 nonce 对及其承诺（第一轮，可预处理）
+func (cs *frostCiphersuite) commit(identifier *big.Int, secret *big.Int) (*frostNonce, *frostCommitment, error) {
+	hiding, err := cs.generateNonce(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	binding, err := cs.generateNonce(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &frostNonce{Hiding: hiding, Binding: binding}, &frostCommitment{
+		Identifier: identifier,
+		Hiding:     cs.scalarBaseMult(hiding),
+		Binding:    cs.scalarBaseMult(binding),
+	}, nil
+}
+
+// encodeCommitmentList RFC 9591 encode_group_commitment_list（按 identifier 升序）
+func (cs *frostCiphersuite) encodeCommitmentList(commitments []*frostCommitment) []byte {
+	var out []byte
+	for _, c := range commitments {
+		out = append(out, cs.serializeScalar(c.Identifier)...)
+		out = append(out, cs.serializeElement(c.Hiding)...)
+		out = append(out, cs.serializeElement(c.Binding)...)
+	}
+	return out
+}
+
+// lagrangeCoefficient 计算 identifier 在 identifiers 集合中于 0 处的拉格朗日系数
+func (cs *frostCiphersuite) lagrangeCoefficient(identifier *big.Int, identifiers []*big.Int) (*big.Int, error) {
+	modN := common.ModInt(cs.order())
+	num, den := big.NewInt(1), big.NewInt(1)
+	found := false
+	for _, x := range identifiers {
+		if x.Cmp(identifier) == 0 {
+			found = true
+			continue
+		}
+		num = modN.Mul(num, x)
+		den = modN.Mul(den, modN.Sub(x, identifier))
+	}
+	if !found {
+		return nil, errors.Errorf("identifier %s is not in the signer set", identifier)
+	}
+	if den.Sign() == 0 {
+		return nil, errors.New("duplicate identifiers in the signer set")
+	}
+	return modN.Mul(num, modN.ModInverse(den)), nil
+}
+
+// taprootTweakScalar 计算 BIP-341 调整值 t = int(hash_TapTweak(x(P) || merkle_root))
+func taprootTweakScalar(cs *frostCiphersuite, internalKey *crypto.ECPoint, merkleRoot []byte) (*big.Int, error) {
+	if !cs.xOnly {
+		return nil, errors.New("taproot tweak requires the secp256k1 ciphersuite")
+	}
+	digest := chainhash.TaggedHash(chainhash.TagTapTweak, xOnlyBytes(internalKey), merkleRoot)
+	t := new(big.Int).SetBytes(digest[:])
+	if t.Cmp(cs.order()) >= 0 {
+		return nil, errors.New("taproot tweak is not a valid scalar")
+	}
+	return t, nil
+}
+
+// TaprootOutputKey 计算 BIP-341 key-path 输出公钥 Q = P + t·G（merkleRoot 为空即 BIP-86）
+// internalKey 可以是 33 字节压缩公钥或 32 字节 x-only 公钥，返回 33 字节压缩编码（Y 为偶数，0x02 前缀）
+func TaprootOutputKey(internalKey []byte, merkleRoot []byte) ([]byte, error) {
+	cs := frostSecp256k1Suite
+	p, err := cs.deserializeElement(internalKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid taproot internal key")
+	}
+	if hasOddY(p) {
+		p = cs.negatePoint(p)
+	}
+	t, err := taprootTweakScalar(cs, p, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	q, err := cs.addPoints(p, cs.scalarBaseMult(t))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid taproot output key")
+	}
+	if hasOddY(q) {
+		q = cs.negatePoint(q)
+	}
+	return cs.serializeElement(q), nil
+}
+
+// frostSigningPackage 一次签名中所有签名者计算结果相同的公共数据
+type frostSigningPackage struct {
+	cs          *frostCiphersuite
+	message     []byte
+	commitments []*frostCommitment
+	identifiers []*big.Int
+
+	// verifyingKey 签名实际对应的公钥（BIP-340 下为偶数 Y、可能经过 Taproot 调整的输出公钥）
+	verifyingKey *crypto.ECPoint
+	// keyFactor 分片系数（±1），tweakTerm 聚合时附加的 c·tweakTerm
+	keyFactor *big.Int
+	tweakTerm *big.Int
+
+	bindingFactors  map[string]*big.Int
+	groupCommitment *crypto.ECPoint
+	// nonceFactor nonce 系数（±1），BIP-340 下 R 为奇数 Y 时所有签名者翻转 nonce
+	nonceFactor *big.Int
+	challenge   *big.Int
+}
+
+// newFROSTSigningPackage 根据群公钥、消息和全部签名者的承诺计算绑定因子、群承诺和挑战值
+func newFROSTSigningPackage(cs *frostCiphersuite, groupPublicKey *crypto.ECPoint, tweak *frostTaprootTweak, message []byte, commitments []*frostCommitment) (*frostSigningPackage, error) {
+	if len(commitments) < 2 {
+		return nil, errors.New("at least two signers are required")
+	}
+	sorted := append([]*frostCommitment(nil), commitments...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Identifier.Cmp(sorted[j].Identifier) < 0 })
+
+	modN := common.ModInt(cs.order())
+	one := big.NewInt(1)
+	minusOne := new(big.Int).Sub(cs.order(), one)
+
+	pkg := &frostSigningPackage{
+		cs:             cs,
+		message:        message,
+		commitments:    sorted,
+		verifyingKey:   groupPublicKey,
+		keyFactor:      one,
+		tweakTerm:      big.NewInt(0),
+		bindingFactors: make(map[string]*big.Int, len(sorted)),
+		nonceFactor:    one,
+	}
+	for i, c := range sorted {
+		if i > 0 && c.Identifier.Cmp(sorted[i-1].Identifier) == 0 {
+			return nil, errors.Errorf("duplicate commitment for identifier %s", c.Identifier)
+		}
+		pkg.identifiers = append(pkg.identifiers, c.Identifier)
+	}
+
+	// BIP-340：群公钥取偶数 Y；Taproot 调整后的输出公钥同样取偶数 Y
+	if cs.xOnly {
+		if hasOddY(groupPublicKey) {
+			pkg.keyFactor = minusOne
+			pkg.verifyingKey = cs.negatePoint(groupPublicKey)
+		}
+		if tweak != nil {
+			t, err := taprootTweakScalar(cs, pkg.verifyingKey, tweak.MerkleRoot)
+			if err != nil {
+				return nil, err
+			}
+			outputKey, err := cs.addPoints(pkg.verifyingKey, cs.scalarBaseMult(t))
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid taproot output key")
+			}
+			pkg.tweakTerm = t
+			if hasOddY(outputKey) {
+				outputKey = cs.negatePoint(outputKey)
+				pkg.keyFactor = modN.Mul(pkg.keyFactor, minusOne)
+				pkg.tweakTerm = modN.Mul(t, minusOne)
+			}
+			pkg.verifyingKey = outputKey
+		}
+	} else if tweak != nil {
+		return nil, errors.New("taproot tweak is only supported for secp256k1 keys")
+	}
+
+	// RFC 9591 compute_binding_factors
+	msgHash := cs.hash("msg", message)
+	encodedCommitHash := cs.hash("com", cs.encodeCommitmentList(sorted))
+	prefix := append(append(cs.serializeElement(pkg.verifyingKey), msgHash...), encodedCommitHash...)
+	for _, c := range sorted {
+		pkg.bindingFactors[c.Identifier.String()] = cs.hashToScalar("rho", prefix, cs.serializeScalar(c.Identifier))
+	}
+
+	// RFC 9591 compute_group_commitment
+	var groupCommitment *crypto.ECPoint
+	for _, c := range sorted {
+		term, err := cs.addPoints(c.Hiding, cs.scalarMult(c.Binding, pkg.bindingFactors[c.Identifier.String()]))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid commitment")
+		}
+		if groupCommitment == nil {
+			groupCommitment = term
+			continue
+		}
+		if groupCommitment, err = cs.addPoints(groupCommitment, term); err != nil {
+			return nil, errors.Wrap(err, "invalid group commitment")
+		}
+	}
+	if cs.xOnly && hasOddY(groupCommitment) {
+		groupCommitment = cs.negatePoint(groupCommitment)
+		pkg.nonceFactor = minusOne
+	}
+	pkg.groupCommitment = groupCommitment
+	pkg.challenge = cs.challenge(groupCommitment, pkg.verifyingKey, message)
+	return pkg, nil
+}
+
+// signShare RFC 9591 sign：z_i = ±(d_i + e_i·ρ_i) + λ_i·s_i·c（BIP-340 下分片按 keyFactor 翻转）
+func (pkg *frostSigningPackage) signShare(identifier *big.Int, secretShare *big.Int, nonce *frostNonce) (*big.Int, error) {
+	modN := common.ModInt(pkg.cs.order())
+	rho, ok := pkg.bindingFactors[identifier.String()]
+	if !ok {
+		return nil, errors.Errorf("no commitment for identifier %s", identifier)
+	}
+	lambda, err := pkg.cs.lagrangeCoefficient(identifier, pkg.identifiers)
+	if err != nil {
+		return nil, err
+	}
+	noncePart := modN.Mul(pkg.nonceFactor, modN.Add(nonce.Hiding, modN.Mul(nonce.Binding, rho)))
+	keyPart := modN.Mul(modN.Mul(lambda, modN.Mul(pkg.keyFactor, secretShare)), pkg.challenge)
+	return modN.Add(noncePart, keyPart), nil
+}
+
+// verifyShare RFC 9591 verify_signature_share：z_i·G == ±(D_i + ρ_i·E_i) + c·λ_i·Y_i
+func (pkg *frostSigningPackage) verifyShare(identifier *big.Int, verifyingShare *crypto.ECPoint, z *big.Int) error {
+	cs := pkg.cs
+	modN := common.ModInt(cs.order())
+	var commitment *frostCommitment
+	for _, c := range pkg.commitments {
+		if c.Identifier.Cmp(identifier) == 0 {
+			commitment = c
+			break
+		}
+	}
+	if commitment == nil {
+		return errors.Errorf("no commitment for identifier %s", identifier)
+	}
+	lambda, err := cs.lagrangeCoefficient(identifier, pkg.identifiers)
+	if err != nil {
+		return err
+	}
+
+	commShare, err := cs.addPoints(commitment.Hiding, cs.scalarMult(commitment.Binding, pkg.bindingFactors[identifier.String()]))
+	if err != nil {
+		return err
+	}
+	if pkg.nonceFactor.Cmp(big.NewInt(1)) != 0 {
+		commShare = cs.negatePoint(commShare)
+	}
+	keyTerm := cs.scalarMult(verifyingShare, modN.Mul(modN.Mul(pkg.challenge, lambda), pkg.keyFactor))
+	expected, err := cs.addPoints(commShare, keyTerm)
+	if err != nil {
+		return err
+	}
+	if !cs.scalarBaseMult(z).Equals(expected) {
+		return errors.Errorf("invalid signature share from identifier %s", identifier)
+	}
+	return nil
+}
+
+// aggregate RFC 9591 aggregate：z = Σz_i（Taproot 调整时加上 c·t），返回 64 字节签名
+func (pkg *frostSigningPackage) aggregate(shares []*big.Int) ([]byte, error) {
+	cs := pkg.cs
+	modN := common.ModInt(cs.order())
+	if len(shares) != len(pkg.commitments) {
+		return nil, errors.Errorf("expected %d signature shares, got %d", len(pkg.commitments), len(shares))
+	}
+	z := modN.Mul(pkg.challenge, pkg.tweakTerm)
+	for _, share := range shares {
+		z = modN.Add(z, share)
+	}
+
+	var sig []byte
+	if cs.xOnly {
+		sig = append(xOnlyBytes(pkg.groupCommitment), make([]byte, 32)...)
+		z.FillBytes(sig[32:])
+	} else {
+		sig = append(cs.serializeElement(pkg.groupCommitment), cs.serializeScalar(z)...)
+	}
+
+	if err := cs.verifySignature(pkg.verifyingKey, pkg.message, sig); err != nil {
+		return nil, errors.Wrap(err, "aggregated signature is invalid")
+	}
+	return sig, nil
+}
+
+// verifySignature 验证 64 字节 Schnorr 签名：Ed25519 为 RFC 8032，secp256k1 为 BIP-340
+func (cs *frostCiphersuite) verifySignature(publicKey *crypto.ECPoint, message []byte, sig []byte) error {
+	if len(sig) != 64 {
+		return errors.Errorf("invalid signature length: expected 64 bytes, got %d", len(sig))
+	}
+	if !cs.xOnly {
+		if !ed25519.Verify(cs.serializeElement(publicKey), message, sig) {
+			return errors.New("Ed25519 signature verification failed")
+		}
+		return nil
+	}
+
+	// BIP-340 Verify
+	pk, err := cs.deserializeElement(xOnlyBytes(publicKey))
+	if err != nil {
+		return err
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if r.Cmp(cs.curve.Params().P) >= 0 || s.Cmp(cs.order()) >= 0 {
+		return errors.New("signature component out of range")
+	}
+	rPoint := crypto.NewECPointNoCurveCheck(cs.curve, r, big.NewInt(0))
+	e := cs.challenge(rPoint, pk, message)
+	computed := cs.scalarBaseMult(s)
+	if e.Sign() != 0 {
+		var err error
+		computed, err = cs.addPoints(computed, cs.negatePoint(cs.scalarMult(pk, e)))
+		if err != nil {
+			return errors.New("BIP-340 signature verification failed")
+		}
+	}
+	if cs.isIdentity(computed) || hasOddY(computed) || computed.X().Cmp(r) != 0 {
+		return errors.New("BIP-340 signature verification failed")
+	}
+	return nil
+}
+
+// newPolynomial 生成常数项为 constant、次数为 degree 的随机多项式
+func (cs *frostCiphersuite) newPolynomial(constant *big.Int, degree int) ([]*big.Int, error) {
+	coefficients := []*big.Int{new(big.Int).Mod(constant, cs.order())}
+	for i := 0; i < degree; i++ {
+		c, err := cs.randomScalar()
+		if err != nil {
+			return nil, err
+		}
+		coefficients = append(coefficients, c)
+	}
+	return coefficients, nil
+}
+
+// evalPolynomial 计算 f(x)
+func (cs *frostCiphersuite) evalPolynomial(coefficients []*big.Int, x *big.Int) *big.Int {
+	modN := common.ModInt(cs.order())
+	result := big.NewInt(0)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result = modN.Add(modN.Mul(result, x), coefficients[i])
+	}
+	return result
+}
+
+// commitPolynomial Feldman 承诺 C_k = a_k·G
+func (cs *frostCiphersuite) commitPolynomial(coefficients []*big.Int) []*crypto.ECPoint {
+	commitments := make([]*crypto.ECPoint, len(coefficients))
+	for i, c := range coefficients {
+		commitments[i] = cs.scalarBaseMult(c)
+	}
+	return commitments
+}
+
+// evalCommitment 计算 Σ C_k·x^k，即 f(x)·G
+func (cs *frostCiphersuite) evalCommitment(commitments []*crypto.ECPoint, x *big.Int) (*crypto.ECPoint, error) {
+	modN := common.ModInt(cs.order())
+	power := big.NewInt(1)
+	var result *crypto.ECPoint
+	for _, c := range commitments {
+		term := cs.scalarMult(c, power)
+		if result == nil {
+			result = term
+		} else {
+			var err error
+			if result, err = cs.addPoints(result, term); err != nil {
+				return nil, err
+			}
+		}
+		power = modN.Mul(power, x)
+	}
+	if result == nil {
+		return nil, errors.New("empty commitment")
+	}
+	return result, nil
+}
+
+// verifyVSSShare 校验秘密分片与 Feldman 承诺一致：f(x)·G == Σ C_k·x^k
+func (cs *frostCiphersuite) verifyVSSShare(share *big.Int, x *big.Int, commitments []*crypto.ECPoint) error {
+	expected, err := cs.evalCommitment(commitments, x)
+	if err != nil {
+		return err
+	}
+	if !cs.scalarBaseMult(share).Equals(expected) {
+		return errors.New("secret share does not match the commitment")
+	}
+	return nil
+}
+
+// dkgChallenge DKG 知识证明的挑战值 HDKG(context || identifier || C_0 || R)
+// context 绑定本次 DKG（如 keyID），防止证明被重放到其他会话
+func (cs *frostCiphersuite) dkgChallenge(context []byte, identifier *big.Int, commitment0, r *crypto.ECPoint) *big.Int {
+	return cs.hashToScalar("dkg", context, cs.serializeScalar(identifier), cs.serializeElement(commitment0), cs.serializeElement(r))
+}
+
+// proveKnowledge 对多项式常数项的 Schnorr 知识证明（防止 rogue-key 攻击）
+func (cs *frostCiphersuite) proveKnowledge(context []byte, identifier *big.Int, secret *big.Int, commitment0 *crypto.ECPoint) (*crypto.ECPoint, *big.Int, error) {
+	k, err := cs.randomScalar()
+	if err != nil {
+		return nil, nil, err
+	}
+	r := cs.scalarBaseMult(k)
+	c := cs.dkgChallenge(context, identifier, commitment0, r)
+	modN := common.ModInt(cs.order())
+	return r, modN.Add(k, modN.Mul(secret, c)), nil
+}
+
+// verifyKnowledge 校验知识证明：μ·G == R + c·C_0
+func (cs *frostCiphersuite) verifyKnowledge(context []byte, identifier *big.Int, commitment0, r *crypto.ECPoint, mu *big.Int) error {
+	c := cs.dkgChallenge(context, identifier, commitment0, r)
+	expected, err := cs.addPoints(r, cs.scalarMult(commitment0, c))
+	if err != nil {
+		return err
+	}
+	if !cs.scalarBaseMult(mu).Equals(expected) {
+		return errors.New("invalid proof of knowledge")
+	}
+	return nil
+}
+
+// frostIdentifier 委员会中第 index 个节点（从 1 开始）的 identifier
+func frostIdentifier(index int) *big.Int {
+	return big.NewInt(int64(index))
+}
@@ -0,0 +1,251 @@
+package protocol
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frostDealerShares 使用可信分发者生成 t-of-n 分片（仅测试使用）
+func frostDealerShares(t *testing.T, cs *frostCiphersuite, minSigners, n int) (*crypto.ECPoint, map[int]*big.Int, map[int]*crypto.ECPoint) {
+	t.Helper()
+	secret, err := cs.randomScalar()
+	require.NoError(t, err)
+	coefficients, err := cs.newPolynomial(secret, minSigners-1)
+	require.NoError(t, err)
+
+	shares := make(map[int]*big.Int, n)
+	verifyingShares := make(map[int]*crypto.ECPoint, n)
+	for i := 1; i <= n; i++ {
+		shares[i] = cs.evalPolynomial(coefficients, frostIdentifier(i))
+		verifyingShares[i] = cs.scalarBaseMult(shares[i])
+	}
+	return cs.scalarBaseMult(secret), shares, verifyingShares
+}
+
+// frostSignWithDealerShares 使用 signers 中的分片执行两轮 FROST 签名
+func frostSignWithDealerShares(t *testing.T, cs *frostCiphersuite, groupKey *crypto.ECPoint, tweak *frostTaprootTweak, shares map[int]*big.Int, verifyingShares map[int]*crypto.ECPoint, signers []int, message []byte) ([]byte, *frostSigningPackage) {
+	t.Helper()
+	nonces := make(map[int]*frostNonce, len(signers))
+	var commitments []*frostCommitment
+	for _, i := range signers {
+		nonce, commitment, err := cs.commit(frostIdentifier(i), shares[i])
+		require.NoError(t, err)
+		nonces[i] = nonce
+		commitments = append(commitments, commitment)
+	}
+
+	pkg, err := newFROSTSigningPackage(cs, groupKey, tweak, message, commitments)
+	require.NoError(t, err)
+
+	var zs []*big.Int
+	for _, i := range signers {
+		z, err := pkg.signShare(frostIdentifier(i), shares[i], nonces[i])
+		require.NoError(t, err)
+		require.NoError(t, pkg.verifyShare(frostIdentifier(i), verifyingShares[i], z))
+		zs = append(zs, z)
+	}
+	sig, err := pkg.aggregate(zs)
+	require.NoError(t, err)
+	return sig, pkg
+}
+
+// TestFROST_Ed25519Signature 验证 FROST(Ed25519, SHA-512) 签名可通过标准 Ed25519 验证
+func TestFROST_Ed25519Signature(t *testing.T) {
+	cs := frostEd25519Suite
+	groupKey, shares, verifyingShares := frostDealerShares(t, cs, 2, 3)
+	message := []byte("frost ed25519 message")
+
+	for _, signers := range [][]int{{1, 2}, {1, 3}, {2, 3}, {1, 2, 3}} {
+		sig, _ := frostSignWithDealerShares(t, cs, groupKey, nil, shares, verifyingShares, signers, message)
+		pubKey := cs.serializePublicKey(groupKey)
+		assert.True(t, ed25519.Verify(pubKey, message, sig), "signers %v", signers)
+
+		valid, err := verifySchnorrSignature(&Signature{Bytes: sig}, message, &PublicKey{Bytes: pubKey}, "ed25519")
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+}
+
+// TestVerifySecp256k1SchnorrSignature_BIP340Vectors 使用 BIP-340 官方测试向量校验验证实现
+func TestVerifySecp256k1SchnorrSignature_BIP340Vectors(t *testing.T) {
+	vectors := []struct {
+		pubKey  string
+		message string
+		sig     string
+		valid   bool
+	}{
+		{
+			pubKey:  "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			message: "0000000000000000000000000000000000000000000000000000000000000000",
+			sig:     "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+			valid:   true,
+		},
+		{
+			pubKey:  "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message: "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			sig:     "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+			valid:   true,
+		},
+		{
+			// 篡改消息
+			pubKey:  "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			message: "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C8A",
+			sig:     "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+			valid:   false,
+		},
+	}
+
+	for i, v := range vectors {
+		pubKey, err := hex.DecodeString(v.pubKey)
+		require.NoError(t, err)
+		message, err := hex.DecodeString(v.message)
+		require.NoError(t, err)
+		sig, err := hex.DecodeString(v.sig)
+		require.NoError(t, err)
+
+		valid, err := verifySecp256k1SchnorrSignature(&Signature{Bytes: sig}, message, &PublicKey{Bytes: pubKey})
+		require.NoError(t, err)
+		assert.Equal(t, v.valid, valid, "vector %d", i)
+	}
+}
+
+// TestFROST_Secp256k1BIP340Signature 验证 secp256k1 签名符合 BIP-340（群公钥奇偶两种情况）
+func TestFROST_Secp256k1BIP340Signature(t *testing.T) {
+	cs := frostSecp256k1Suite
+	hash := sha256.Sum256([]byte("frost bip340 message"))
+
+	seenOdd, seenEven := false, false
+	for i := 0; i < 8 || !(seenOdd && seenEven); i++ {
+		require.Less(t, i, 64)
+		groupKey, shares, verifyingShares := frostDealerShares(t, cs, 2, 3)
+		if hasOddY(groupKey) {
+			seenOdd = true
+		} else {
+			seenEven = true
+		}
+
+		sig, _ := frostSignWithDealerShares(t, cs, groupKey, nil, shares, verifyingShares, []int{1, 3}, hash[:])
+		pubKey := cs.serializePublicKey(groupKey)
+		require.Len(t, pubKey, 33)
+
+		valid, err := verifySecp256k1SchnorrSignature(&Signature{Bytes: sig}, hash[:], &PublicKey{Bytes: pubKey})
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = verifySecp256k1SchnorrSignature(&Signature{Bytes: sig}, hash[:], &PublicKey{Bytes: pubKey[1:]})
+		require.NoError(t, err)
+		assert.True(t, valid, "x-only public key")
+
+		tampered := append([]byte(nil), sig...)
+		tampered[63] ^= 0x01
+		valid, err = verifySecp256k1SchnorrSignature(&Signature{Bytes: tampered}, hash[:], &PublicKey{Bytes: pubKey})
+		require.NoError(t, err)
+		assert.False(t, valid)
+	}
+}
+
+// TestTaprootOutputKey_BIP86Vector 使用 BIP-86 测试向量校验 Taproot 调整
+func TestTaprootOutputKey_BIP86Vector(t *testing.T) {
+	internalKey, err := hex.DecodeString("cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	require.NoError(t, err)
+
+	outputKey, err := TaprootOutputKey(internalKey, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", hex.EncodeToString(outputKey[1:]))
+}
+
+// TestFROST_TaprootKeyPathSignature 验证 BIP-86 调整后的签名对 Taproot 输出公钥有效
+func TestFROST_TaprootKeyPathSignature(t *testing.T) {
+	cs := frostSecp256k1Suite
+	hash := sha256.Sum256([]byte("taproot sighash"))
+
+	for i := 0; i < 8; i++ {
+		groupKey, shares, verifyingShares := frostDealerShares(t, cs, 3, 5)
+		sig, pkg := frostSignWithDealerShares(t, cs, groupKey, &frostTaprootTweak{}, shares, verifyingShares, []int{2, 4, 5}, hash[:])
+
+		outputKey, err := TaprootOutputKey(cs.serializePublicKey(groupKey), nil)
+		require.NoError(t, err)
+		assert.Equal(t, outputKey[1:], xOnlyBytes(pkg.verifyingKey))
+
+		valid, err := verifySecp256k1SchnorrSignature(&Signature{Bytes: sig}, hash[:], &PublicKey{Bytes: outputKey})
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		// 未调整的内部公钥不能验证 key-path 签名
+		valid, err = verifySecp256k1SchnorrSignature(&Signature{Bytes: sig}, hash[:], &PublicKey{Bytes: cs.serializePublicKey(groupKey)})
+		require.NoError(t, err)
+		assert.False(t, valid)
+	}
+}
+
+// TestFROST_InvalidShareIsIdentified 测试篡改的签名分片可定位到签名者
+func TestFROST_InvalidShareIsIdentified(t *testing.T) {
+	cs := frostEd25519Suite
+	_, shares, verifyingShares := frostDealerShares(t, cs, 2, 3)
+	groupKey := verifyingShares[1] // 故意使用错误的群公钥不影响分片校验
+	message := []byte("message")
+
+	nonce1, c1, err := cs.commit(frostIdentifier(1), shares[1])
+	require.NoError(t, err)
+	_, c2, err := cs.commit(frostIdentifier(2), shares[2])
+	require.NoError(t, err)
+	pkg, err := newFROSTSigningPackage(cs, groupKey, nil, message, []*frostCommitment{c1, c2})
+	require.NoError(t, err)
+
+	z1, err := pkg.signShare(frostIdentifier(1), shares[1], nonce1)
+	require.NoError(t, err)
+	require.NoError(t, pkg.verifyShare(frostIdentifier(1), verifyingShares[1], z1))
+
+	err = pkg.verifyShare(frostIdentifier(2), verifyingShares[2], z1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "identifier 2")
+}
+
+// TestFROST_VSSAndKnowledgeProof 测试 Feldman 承诺校验与 DKG 知识证明
+func TestFROST_VSSAndKnowledgeProof(t *testing.T) {
+	for _, cs := range []*frostCiphersuite{frostEd25519Suite, frostSecp256k1Suite} {
+		secret, err := cs.randomScalar()
+		require.NoError(t, err)
+		coefficients, err := cs.newPolynomial(secret, 2)
+		require.NoError(t, err)
+		commitments := cs.commitPolynomial(coefficients)
+
+		share := cs.evalPolynomial(coefficients, frostIdentifier(4))
+		require.NoError(t, cs.verifyVSSShare(share, frostIdentifier(4), commitments))
+		assert.Error(t, cs.verifyVSSShare(share, frostIdentifier(5), commitments))
+
+		r, mu, err := cs.proveKnowledge([]byte("key-1"), frostIdentifier(1), secret, commitments[0])
+		require.NoError(t, err)
+		require.NoError(t, cs.verifyKnowledge([]byte("key-1"), frostIdentifier(1), commitments[0], r, mu))
+		assert.Error(t, cs.verifyKnowledge([]byte("key-1"), frostIdentifier(2), commitments[0], r, mu))
+		assert.Error(t, cs.verifyKnowledge([]byte("key-2"), frostIdentifier(1), commitments[0], r, mu))
+	}
+}
+
+// TestFROST_ElementAndScalarEncoding 测试元素与标量的编码往返
+func TestFROST_ElementAndScalarEncoding(t *testing.T) {
+	for _, cs := range []*frostCiphersuite{frostEd25519Suite, frostSecp256k1Suite} {
+		k, err := cs.randomScalar()
+		require.NoError(t, err)
+		p := cs.scalarBaseMult(k)
+
+		decoded, err := cs.deserializeElement(cs.serializeElement(p))
+		require.NoError(t, err)
+		assert.True(t, decoded.Equals(p))
+
+		scalar, err := cs.deserializeScalar(cs.serializeScalar(k))
+		require.NoError(t, err)
+		assert.Equal(t, 0, scalar.Cmp(k))
+	}
+
+	// Ed25519 标量为小端编码
+	assert.Equal(t, byte(1), frostEd25519Suite.serializeScalar(big.NewInt(1))[0])
+	assert.Equal(t, byte(1), frostSecp256k1Suite.serializeScalar(big.NewInt(1))[31])
+}
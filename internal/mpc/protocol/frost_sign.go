@@ -0,0 +1,382 @@
+package protocol
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// frostCommitmentPayload 签名者的 nonce 承诺 (D_i, E_i)
+type frostCommitmentPayload struct {
+	Hiding  []byte `json:"hiding"`
+	Binding []byte `json:"binding"`
+}
+
+// frostSignSharePayload 签名第二轮广播的签名分片 z_i
+type frostSignSharePayload struct {
+	Z []byte `json:"z"`
+}
+
+// frostPresignature 预处理的 nonce（本节点的 nonce 及全部签名者的承诺），只能使用一次
+type frostPresignature struct {
+	ID          string                             `json:"id"`
+	KeyID       string                             `json:"key_id"`
+	Epoch       int                                `json:"epoch"`
+	NodeIDs     []string                           `json:"node_ids"`
+	Hiding      []byte                             `json:"hiding"`
+	Binding     []byte                             `json:"binding"`
+	Commitments map[string]*frostCommitmentPayload `json:"commitments"`
+}
+
+// encodeCommitment 编码承诺
+func (cs *frostCiphersuite) encodeCommitment(c *frostCommitment) *frostCommitmentPayload {
+	return &frostCommitmentPayload{
+		Hiding:  cs.serializeElement(c.Hiding),
+		Binding: cs.serializeElement(c.Binding),
+	}
+}
+
+// decodeCommitment 解码承诺
+func (cs *frostCiphersuite) decodeCommitment(identifier *big.Int, payload *frostCommitmentPayload) (*frostCommitment, error) {
+	if payload == nil {
+		return nil, errors.New("commitment is missing")
+	}
+	hiding, err := cs.deserializeElement(payload.Hiding)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hiding commitment")
+	}
+	binding, err := cs.deserializeElement(payload.Binding)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid binding commitment")
+	}
+	return &frostCommitment{Identifier: identifier, Hiding: hiding, Binding: binding}, nil
+}
+
+// frostSigners 校验签名者集合并按节点ID排序
+func (p *FROSTProtocol) frostSigners(key *frostKeyMaterial, nodeIDs []string) ([]string, error) {
+	return selectSigners(key, p.thisNodeID, nodeIDs)
+}
+
+// selectSigners 校验签名者集合（均为密钥成员、不少于阈值且包含本节点）并按节点ID排序
+func selectSigners(key *frostKeyMaterial, thisNodeID string, nodeIDs []string) ([]string, error) {
+	signers := append([]string(nil), nodeIDs...)
+	sort.Strings(signers)
+	for i, nodeID := range signers {
+		if i > 0 && signers[i-1] == nodeID {
+			return nil, errors.Errorf("duplicate signer %s", nodeID)
+		}
+		if _, err := key.identifierOf(nodeID); err != nil {
+			return nil, err
+		}
+	}
+	if len(signers) < key.data.Threshold {
+		return nil, errors.Errorf("insufficient signers: need %d, have %d", key.data.Threshold, len(signers))
+	}
+	if !containsNodeID(signers, thisNodeID) {
+		return nil, errors.Errorf("this node %s is not a signer", thisNodeID)
+	}
+	return signers, nil
+}
+
+// frostTweakFromRequest 根据签名请求构造 Taproot 调整
+func frostTweakFromRequest(req *SignRequest) *frostTaprootTweak {
+	if !req.TaprootKeySpend {
+		return nil
+	}
+	return &frostTaprootTweak{MerkleRoot: req.TaprootMerkleRoot}
+}
+
+// thresholdSignRFC9591 执行 FROST 签名
+// 未指定预签名时执行两轮（承诺 + 签名分片）；指定预签名时直接使用预处理的 nonce，只执行签名分片一轮
+func (p *FROSTProtocol) thresholdSignRFC9591(ctx context.Context, sessionID string, req *SignRequest, key *frostKeyMaterial, message []byte) (*SignResponse, error) {
+	cs := key.cs
+	if req.TaprootKeySpend && cs.name != frostCiphersuiteSecp256k1 {
+		return nil, errors.New("taproot tweak is only supported for secp256k1 keys")
+	}
+	if req.DerivationPath != "" {
+		// BIP-32 派生在 Taproot 调整之前应用：先得到子密钥，再对子密钥做 key-path 调整
+		derivedKey, err := deriveFROSTKeyMaterial(key, req)
+		if err != nil {
+			return nil, err
+		}
+		key = derivedKey
+	}
+	signers, err := p.frostSigners(key, req.NodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	others := p.otherNodeIDs(signers)
+
+	inbox := p.hub.claim(sessionID)
+	defer p.hub.release(sessionID)
+
+	var nonce *frostNonce
+	commitments := make([]*frostCommitment, 0, len(signers))
+	if req.PresignatureID != "" {
+		presig, err := p.consumePresignature(ctx, req.KeyID, req.PresignatureID)
+		if err != nil {
+			return nil, err
+		}
+		if presig.Epoch != key.data.Epoch {
+			return nil, errors.Errorf("presignature %s was generated for a previous key share", req.PresignatureID)
+		}
+		if !sameNodeSet(presig.NodeIDs, signers) {
+			return nil, errors.Errorf("presignature %s was generated by nodes %v, but signing requested nodes %v", req.PresignatureID, presig.NodeIDs, signers)
+		}
+		hiding, err := cs.deserializeScalar(presig.Hiding)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid presignature nonce")
+		}
+		binding, err := cs.deserializeScalar(presig.Binding)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid presignature nonce")
+		}
+		nonce = &frostNonce{Hiding: hiding, Binding: binding}
+		for _, nodeID := range signers {
+			identifier, _ := key.identifierOf(nodeID)
+			commitment, err := cs.decodeCommitment(identifier, presig.Commitments[nodeID])
+			if err != nil {
+				return nil, errors.Wrapf(err, "presignature %s has an invalid commitment for node %s", req.PresignatureID, nodeID)
+			}
+			commitments = append(commitments, commitment)
+		}
+	} else {
+		// 第一轮：广播 nonce 承诺
+		var myCommitment *frostCommitment
+		nonce, myCommitment, err = cs.commit(key.identifier, key.secretShare)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.sendFROSTMessage(sessionID, frostRoundSignCommit, others, cs.encodeCommitment(myCommitment), true); err != nil {
+			return nil, err
+		}
+		received, err := inbox.collect(ctx, frostRoundSignCommit, others)
+		if err != nil {
+			return nil, err
+		}
+		commitments = append(commitments, myCommitment)
+		for _, nodeID := range others {
+			commitment, err := p.decodeReceivedCommitment(key, nodeID, received[nodeID])
+			if err != nil {
+				return nil, err
+			}
+			commitments = append(commitments, commitment)
+		}
+	}
+
+	pkg, err := newFROSTSigningPackage(cs, key.groupPublicKey, frostTweakFromRequest(req), message, commitments)
+	if err != nil {
+		return nil, err
+	}
+	z, err := pkg.signShare(key.identifier, key.secretShare, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// 第二轮：广播签名分片，每个签名者独立校验并聚合
+	if err := p.sendFROSTMessage(sessionID, frostRoundSignShare, others, &frostSignSharePayload{Z: cs.serializeScalar(z)}, true); err != nil {
+		return nil, err
+	}
+	received, err := inbox.collect(ctx, frostRoundSignShare, others)
+	if err != nil {
+		return nil, err
+	}
+	shares := []*big.Int{z}
+	for _, nodeID := range others {
+		var payload frostSignSharePayload
+		if err := json.Unmarshal(received[nodeID].Payload, &payload); err != nil {
+			return nil, errors.Wrapf(err, "node %s sent a malformed signature share", nodeID)
+		}
+		share, err := cs.deserializeScalar(payload.Z)
+		if err != nil {
+			return nil, errors.Wrapf(err, "node %s sent an invalid signature share", nodeID)
+		}
+		identifier, _ := key.identifierOf(nodeID)
+		if err := pkg.verifyShare(identifier, key.verifyingShares[nodeID], share); err != nil {
+			return nil, newAbortError("FROST", sessionID, fmt.Sprintf("node %s sent an invalid signature share", nodeID), []string{nodeID}, err)
+		}
+		shares = append(shares, share)
+	}
+
+	sig, err := pkg.aggregate(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := key.publicKey()
+	if req.TaprootKeySpend {
+		// Taproot key-path：签名对应调整后的输出公钥
+		outputKey := append([]byte{0x02}, xOnlyBytes(pkg.verifyingKey)...)
+		publicKey = &PublicKey{Bytes: outputKey, Hex: hex.EncodeToString(outputKey)}
+	}
+
+	log.Info().
+		Str("key_id", req.KeyID).
+		Str("session_id", sessionID).
+		Str("node_id", p.thisNodeID).
+		Str("ciphersuite", cs.name).
+		Bool("presigned", req.PresignatureID != "").
+		Bool("taproot", req.TaprootKeySpend).
+		Msg("FROST signing completed")
+
+	return &SignResponse{
+		Signature: &Signature{
+			R:     sig[:32],
+			S:     sig[32:],
+			Bytes: sig,
+			Hex:   hex.EncodeToString(sig),
+		},
+		PublicKey: publicKey,
+	}, nil
+}
+
+// deriveFROSTKeyMaterial 返回按 BIP-32 子密钥调整后的密钥材料副本
+// 每个分片加上 δ、每个验证分片和群公钥加上 δG；由于 Lagrange 系数之和为 1，聚合签名对应子公钥
+func deriveFROSTKeyMaterial(key *frostKeyMaterial, req *SignRequest) (*frostKeyMaterial, error) {
+	cs := key.cs
+	if cs.name != frostCiphersuiteSecp256k1 {
+		return nil, errors.Errorf("BIP-32 derivation is only supported for secp256k1 keys, got %s", cs.name)
+	}
+	derived, err := deriveForSignRequest(cs.name, cs.serializePublicKey(key.groupPublicKey), req)
+	if err != nil {
+		return nil, err
+	}
+
+	deltaPoint := cs.scalarBaseMult(derived.tweak)
+	groupPublicKey, err := cs.addPoints(key.groupPublicKey, deltaPoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive group public key")
+	}
+	if !groupPublicKey.Equals(derived.point) {
+		return nil, errors.New("derived group public key does not match BIP-32 child key")
+	}
+	verifyingShares := make(map[string]*crypto.ECPoint, len(key.verifyingShares))
+	for nodeID, share := range key.verifyingShares {
+		verifyingShares[nodeID], err = cs.addPoints(share, deltaPoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive verifying share of node %s", nodeID)
+		}
+	}
+
+	return &frostKeyMaterial{
+		data:            key.data,
+		cs:              cs,
+		identifier:      key.identifier,
+		secretShare:     new(big.Int).Mod(new(big.Int).Add(key.secretShare, derived.tweak), cs.order()),
+		verifyingShares: verifyingShares,
+		groupPublicKey:  groupPublicKey,
+	}, nil
+}
+
+// decodeReceivedCommitment 解析其他签名者发送的承诺
+func (p *FROSTProtocol) decodeReceivedCommitment(key *frostKeyMaterial, nodeID string, msg *frostWireMessage) (*frostCommitment, error) {
+	var payload frostCommitmentPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return nil, errors.Wrapf(err, "node %s sent a malformed commitment", nodeID)
+	}
+	identifier, err := key.identifierOf(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	commitment, err := key.cs.decodeCommitment(identifier, &payload)
+	if err != nil {
+		return nil, errors.Wrapf(err, "node %s sent an invalid commitment", nodeID)
+	}
+	return commitment, nil
+}
+
+// presignRFC9591 预处理 nonce：所有签名者交换承诺后各自保存，在线签名只需一轮
+func (p *FROSTProtocol) presignRFC9591(ctx context.Context, sessionID string, req *PresignRequest, key *frostKeyMaterial) error {
+	cs := key.cs
+	signers, err := p.frostSigners(key, req.NodeIDs)
+	if err != nil {
+		return err
+	}
+	others := p.otherNodeIDs(signers)
+
+	inbox := p.hub.claim(sessionID)
+	defer p.hub.release(sessionID)
+
+	nonce, myCommitment, err := cs.commit(key.identifier, key.secretShare)
+	if err != nil {
+		return err
+	}
+	if err := p.sendFROSTMessage(sessionID, frostRoundPresign, others, cs.encodeCommitment(myCommitment), true); err != nil {
+		return err
+	}
+	received, err := inbox.collect(ctx, frostRoundPresign, others)
+	if err != nil {
+		return err
+	}
+
+	presig := &frostPresignature{
+		ID:          sessionID,
+		KeyID:       req.KeyID,
+		Epoch:       key.data.Epoch,
+		NodeIDs:     signers,
+		Hiding:      cs.serializeScalar(nonce.Hiding),
+		Binding:     cs.serializeScalar(nonce.Binding),
+		Commitments: map[string]*frostCommitmentPayload{p.thisNodeID: cs.encodeCommitment(myCommitment)},
+	}
+	for _, nodeID := range others {
+		commitment, err := p.decodeReceivedCommitment(key, nodeID, received[nodeID])
+		if err != nil {
+			return err
+		}
+		presig.Commitments[nodeID] = cs.encodeCommitment(commitment)
+	}
+
+	data, err := json.Marshal(presig)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal presignature")
+	}
+	if err := p.keyShareStorage.StoreKeyData(ctx, presignStorageKeyID(req.KeyID, sessionID), p.thisNodeID, data); err != nil {
+		return errors.Wrap(err, "failed to store presignature")
+	}
+	return nil
+}
+
+// consumePresignature 读取并删除本节点预处理的 nonce（先删除再使用，保证 nonce 只使用一次）
+func (p *FROSTProtocol) consumePresignature(ctx context.Context, keyID string, presignID string) (*frostPresignature, error) {
+	if !presignIDPattern.MatchString(presignID) {
+		return nil, errors.Errorf("invalid presignature ID: %q", presignID)
+	}
+	if p.keyShareStorage == nil {
+		return nil, errors.New("key share storage is required for presignatures")
+	}
+
+	p.presignMu.Lock()
+	defer p.presignMu.Unlock()
+
+	storageKeyID := presignStorageKeyID(keyID, presignID)
+	data, err := p.keyShareStorage.GetKeyData(ctx, storageKeyID, p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "presignature %s not found or already used", presignID)
+	}
+	if err := p.keyShareStorage.DeleteKeyData(ctx, storageKeyID, p.thisNodeID); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete presignature %s before use", presignID)
+	}
+
+	var presig frostPresignature
+	if err := json.Unmarshal(data, &presig); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal presignature")
+	}
+	if presig.ID != presignID || presig.KeyID != keyID {
+		return nil, errors.Errorf("presignature %s does not belong to key %s", presignID, keyID)
+	}
+
+	log.Info().
+		Str("key_id", keyID).
+		Str("presignature_id", presignID).
+		Str("node_id", p.thisNodeID).
+		Msg("FROST presignature consumed")
+
+	return &presig, nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
 	eddsaKeygen "github.com/kashguard/tss-lib/eddsa/keygen"
 	"github.com/kashguard/tss-lib/tss"
 	"github.com/stretchr/testify/assert"
@@ -14,7 +16,7 @@ import (
 )
 
 func TestNewFROSTProtocol(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 
 	assert.NotNil(t, protocol)
 	assert.Equal(t, "ed25519", protocol.GetCurve())
@@ -23,7 +25,7 @@ func TestNewFROSTProtocol(t *testing.T) {
 }
 
 func TestFROSTProtocol_ValidateKeyGenRequest(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 
 	tests := []struct {
 		name    string
@@ -54,7 +56,7 @@ func TestFROSTProtocol_ValidateKeyGenRequest(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "valid request with secp256k1",
+			name: "secp256k1 supported for DKG",
 			req: &KeyGenRequest{
 				Algorithm:  "Schnorr",
 				Curve:      "secp256k1",
@@ -150,7 +152,7 @@ func TestFROSTProtocol_ValidateKeyGenRequest(t *testing.T) {
 }
 
 func TestFROSTProtocol_ValidateSignRequest(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 
 	tests := []struct {
 		name    string
@@ -227,32 +229,32 @@ func TestFROSTProtocol_ValidateSignRequest(t *testing.T) {
 }
 
 func TestFROSTProtocol_GetCurve(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 	assert.Equal(t, "ed25519", protocol.GetCurve())
 
-	protocol2 := NewFROSTProtocol("secp256k1", "node-1", mockMessageRouter)
+	protocol2 := NewFROSTProtocol("secp256k1", "node-1", mockMessageRouter, nil)
 	assert.Equal(t, "secp256k1", protocol2.GetCurve())
 }
 
 func TestFROSTProtocol_SupportedProtocols(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 	assert.Equal(t, []string{"frost"}, protocol.SupportedProtocols())
 }
 
 func TestFROSTProtocol_DefaultProtocol(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 	assert.Equal(t, "frost", protocol.DefaultProtocol())
 }
 
 func TestFROSTProtocol_RotateKey(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
-	err := protocol.RotateKey(context.Background(), "some-key-id")
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
+	_, err := protocol.RotateKey(context.Background(), &ReshareRequest{KeyID: "some-key-id"})
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "not yet implemented")
+	assert.Contains(t, err.Error(), "invalid reshare request")
 }
 
 func TestFROSTProtocol_GetKeyRecord(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 	keyID := "test-key-record"
 	record := &frostKeyRecord{
 		PublicKey: &PublicKey{Hex: "pubkeyhex"},
@@ -285,10 +287,10 @@ func TestConvertFROSTKeyData(t *testing.T) {
 			errMsg:    "saveData is nil", // 修正：expect "saveData is nil" instead of "EDDSAPub is nil"
 		},
 		{
-			name:      "empty node IDs",
-			keyID:     "test-key",
-			saveData:  &eddsaKeygen.LocalPartySaveData{
-				EDDSAPub: &eddsaKeygen.EDDSAPubKey{}, // Provide dummy EDDSAPub to avoid nil check error
+			name:  "empty node IDs",
+			keyID: "test-key",
+			saveData: &eddsaKeygen.LocalPartySaveData{
+				EDDSAPub: crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(1)), // Provide dummy EDDSAPub to avoid nil check error
 			},
 			nodeIDs:   []string{},
 			wantError: false, // 应该成功，只是没有 keyShares
@@ -332,26 +334,18 @@ func TestConvertFROSTSignature(t *testing.T) {
 		{
 			name: "valid signature data",
 			sigData: &common.SignatureData{
-				R: []byte{0x01, 0x02, 0x03},
-				S: []byte{0x04, 0x05, 0x06},
+				Signature: make([]byte, 64),
 			},
 			wantError: false,
 		},
 		{
-			name: "signature with empty R",
+			name: "signature too short",
 			sigData: &common.SignatureData{
-				R: []byte{},
-				S: []byte{0x04, 0x05, 0x06},
+				R:         []byte{0x01, 0x02, 0x03},
+				S:         []byte{0x04, 0x05, 0x06},
+				Signature: []byte{0x01, 0x02, 0x03},
 			},
-			wantError: false, // padScalarBytes 会填充
-		},
-		{
-			name: "signature with empty S",
-			sigData: &common.SignatureData{
-				R: []byte{0x01, 0x02, 0x03},
-				S: []byte{},
-			},
-			wantError: false, // padScalarBytes 会填充
+			wantError: true,
 		},
 	}
 
@@ -432,7 +426,7 @@ func TestVerifySchnorrSignature(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := verifySchnorrSignature(tt.sig, tt.msg, tt.pubKey)
+			result, err := verifySchnorrSignature(tt.sig, tt.msg, tt.pubKey, "ed25519")
 			if tt.wantError {
 				require.Error(t, err)
 				if tt.errMsg != "" {
@@ -515,7 +509,7 @@ func TestFROSTValidateSignRequest(t *testing.T) {
 }
 
 func TestFROSTProtocol_VerifySignature(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 
 	tests := []struct {
 		name      string
@@ -581,7 +575,7 @@ func TestConvertFROSTKeyData_WithValidData(t *testing.T) {
 	// 测试 nil saveData
 	keyShares, pubKey, err := convertFROSTKeyData(keyID, nil, nodeIDs)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "EDDSAPub is nil")
+	assert.Contains(t, err.Error(), "saveData is nil")
 	assert.Nil(t, keyShares)
 	assert.Nil(t, pubKey)
 }
@@ -590,13 +584,11 @@ func TestConvertFROSTKeyData_WithValidData(t *testing.T) {
 func TestConvertFROSTSignature_WithValidData(t *testing.T) {
 	// 创建有效的签名数据
 	sigData := &common.SignatureData{
-		R: make([]byte, 32),
-		S: make([]byte, 32),
+		Signature: make([]byte, 64),
 	}
 	// 填充一些测试数据
-	for i := range sigData.R {
-		sigData.R[i] = byte(i)
-		sigData.S[i] = byte(i + 32)
+	for i := range sigData.Signature {
+		sigData.Signature[i] = byte(i)
 	}
 
 	sig, err := convertFROSTSignature(sigData)
@@ -610,7 +602,7 @@ func TestConvertFROSTSignature_WithValidData(t *testing.T) {
 
 // TestFROSTProtocol_GenerateKeyID 测试密钥ID生成
 func TestFROSTProtocol_GenerateKeyID(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 
 	req := &KeyGenRequest{
 		Algorithm:  "Schnorr",
@@ -629,7 +621,7 @@ func TestFROSTProtocol_GenerateKeyID(t *testing.T) {
 
 // TestFROSTProtocol_ConcurrentAccess 测试并发访问
 func TestFROSTProtocol_ConcurrentAccess(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 	keyID := "test-key"
 
 	// 并发保存和读取
@@ -663,17 +655,18 @@ func TestFROSTProtocol_ConcurrentAccess(t *testing.T) {
 func TestFROSTSignatureFormat(t *testing.T) {
 	// 测试 Schnorr 签名格式：R || S（64 字节）
 	sigData := &common.SignatureData{
-		R: []byte{0x01, 0x02, 0x03},
-		S: []byte{0x04, 0x05, 0x06},
+		Signature: make([]byte, 64),
 	}
+	sigData.Signature[0] = 0x01
+	sigData.Signature[63] = 0x06
 
 	sig, err := convertFROSTSignature(sigData)
 	require.NoError(t, err)
 
 	// 验证签名格式
 	assert.Equal(t, 64, len(sig.Bytes), "Schnorr signature should be 64 bytes (R || S)")
-	assert.Equal(t, 32, len(sig.R), "R should be padded to 32 bytes")
-	assert.Equal(t, 32, len(sig.S), "S should be padded to 32 bytes")
+	assert.Equal(t, 32, len(sig.R), "R should be 32 bytes")
+	assert.Equal(t, 32, len(sig.S), "S should be 32 bytes")
 	assert.Equal(t, sig.Bytes, append(sig.R, sig.S...), "Bytes should be R || S")
 }
 
@@ -721,11 +714,11 @@ func TestFROSTKeyDataConversion_EdgeCases(t *testing.T) {
 
 // TestFROSTProtocol_MessageHandling 测试消息处理
 func TestFROSTProtocol_MessageHandling(t *testing.T) {
-	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter)
+	protocol := NewFROSTProtocol("ed25519", "node-1", mockMessageRouter, nil)
 
 	// 测试消息路由函数
 	messageCount := 0
-	protocol.messageRouter = func(sessionID string, nodeID string, msg tss.Message) error {
+	protocol.messageRouter = func(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error {
 		messageCount++
 		return nil
 	}
@@ -749,7 +742,7 @@ func TestFROSTProtocol_CurveSupport(t *testing.T) {
 		{
 			name:      "secp256k1",
 			curve:     "secp256k1",
-			wantValid: true,
+			wantValid: true, // BIP-340 ciphersuite
 		},
 		{
 			name:      "empty curve",
@@ -760,7 +753,7 @@ func TestFROSTProtocol_CurveSupport(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			protocol := NewFROSTProtocol(tt.curve, "node-1", mockMessageRouter)
+			protocol := NewFROSTProtocol(tt.curve, "node-1", mockMessageRouter, nil)
 			req := &KeyGenRequest{
 				Algorithm:  "Schnorr",
 				Curve:      tt.curve,
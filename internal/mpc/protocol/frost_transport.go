@@ -0,0 +1,331 @@
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// frostWireProtocol FROST（RFC 9591）消息的协议标记，用于与旧版 tss-lib EdDSA 消息区分
+const frostWireProtocol = "rfc9591"
+
+// frostInboxTTL 未被本地协议认领的收件箱的保留时间（消息先于本地协议启动到达时需要暂存）
+const frostInboxTTL = 10 * time.Minute
+
+// frostReshareDetectTimeout 只属于新委员会的节点等待 RFC 9591 重分享消息的时间，超时按旧格式密钥处理
+// 必须小于旧版 tss-lib 消息等待队列创建的时间（10 秒），否则旧格式的 resharing 消息会被丢弃
+const frostReshareDetectTimeout = 5 * time.Second
+
+// FROST 协议轮次
+const (
+	frostRoundDKGCommit  = "dkg-commit"  // DKG 第一轮：广播多项式承诺和知识证明
+	frostRoundDKGShare   = "dkg-share"   // DKG 第二轮：点对点发送秘密分片
+	frostRoundSignCommit = "sign-commit" // 签名第一轮：广播 nonce 承诺
+	frostRoundSignShare  = "sign-share"  // 签名第二轮：广播签名分片
+	frostRoundPresign    = "presign"     // nonce 预处理：广播 nonce 承诺
+	frostRoundReshare    = "reshare"     // 重分享：旧委员会向新委员会发送子分片
+)
+
+// frostWireMessage FROST 节点间消息（JSON 编码，通过 messageRouter 传输）
+// 实现 tss.Message 以复用现有的 gRPC 消息路由；To 为空表示广播
+type frostWireMessage struct {
+	Protocol string          `json:"frost"`
+	Round    string          `json:"round"`
+	From     string          `json:"from"`
+	To       string          `json:"to,omitempty"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+var _ tss.Message = (*frostWireMessage)(nil)
+
+// parseFROSTWireMessage 解析 FROST 消息，不是 FROST 消息时返回 false（按旧版 tss-lib 消息处理）
+func parseFROSTWireMessage(msgBytes []byte) (*frostWireMessage, bool) {
+	return parseWireMessage(msgBytes, frostWireProtocol)
+}
+
+// parseWireMessage 解析协议标记为 protocol 的 JSON 消息（FROST 与 CGGMP21 共用同一消息格式和收件箱）
+func parseWireMessage(msgBytes []byte, protocol string) (*frostWireMessage, bool) {
+	trimmed := bytes.TrimSpace(msgBytes)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, false
+	}
+	var msg frostWireMessage
+	if err := json.Unmarshal(trimmed, &msg); err != nil || msg.Protocol != protocol {
+		return nil, false
+	}
+	return &msg, true
+}
+
+// protocolName 消息所属协议的名称（用于日志和消息类型）
+func (m *frostWireMessage) protocolName() string {
+	if m.Protocol == cggmpWireProtocol {
+		return "cggmp21"
+	}
+	return "frost"
+}
+
+func (m *frostWireMessage) Type() string {
+	return m.protocolName() + "." + m.Round
+}
+
+func (m *frostWireMessage) GetTo() []*tss.PartyID {
+	if m.To == "" {
+		return nil
+	}
+	return []*tss.PartyID{tss.NewPartyID(m.To, m.To, partyKeyForEpoch(m.To, 0))}
+}
+
+func (m *frostWireMessage) GetFrom() *tss.PartyID {
+	return tss.NewPartyID(m.From, m.From, partyKeyForEpoch(m.From, 0))
+}
+
+func (m *frostWireMessage) IsBroadcast() bool {
+	return m.To == ""
+}
+
+func (m *frostWireMessage) IsToOldCommittee() bool {
+	return false
+}
+
+func (m *frostWireMessage) IsToOldAndNewCommittees() bool {
+	return false
+}
+
+func (m *frostWireMessage) WireBytes() ([]byte, *tss.MessageRouting, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal FROST message")
+	}
+	return data, &tss.MessageRouting{
+		From:        m.GetFrom(),
+		To:          m.GetTo(),
+		IsBroadcast: m.IsBroadcast(),
+	}, nil
+}
+
+func (m *frostWireMessage) WireMsg() *tss.MessageWrapper {
+	return nil
+}
+
+func (m *frostWireMessage) String() string {
+	return m.protocolName() + " message " + m.Round + " from " + m.From
+}
+
+// frostInbox 单个会话的收件箱，按轮次和发送方保存消息
+type frostInbox struct {
+	mu       sync.Mutex
+	messages map[string]map[string]*frostWireMessage
+	updated  chan struct{}
+	claimed  bool
+	created  time.Time
+}
+
+// put 保存消息；同一发送方在同一轮次发送内容不同的消息视为作恶
+func (in *frostInbox) put(msg *frostWireMessage) error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	byFrom, ok := in.messages[msg.Round]
+	if !ok {
+		byFrom = make(map[string]*frostWireMessage)
+		in.messages[msg.Round] = byFrom
+	}
+	if existing, ok := byFrom[msg.From]; ok {
+		if !bytes.Equal(existing.Payload, msg.Payload) {
+			return errors.Errorf("node %s sent conflicting %s messages", msg.From, msg.Round)
+		}
+		return nil
+	}
+	byFrom[msg.From] = msg
+
+	select {
+	case in.updated <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// collect 等待 round 轮次中 fromNodeIDs 的消息全部到达
+func (in *frostInbox) collect(ctx context.Context, round string, fromNodeIDs []string) (map[string]*frostWireMessage, error) {
+	for {
+		in.mu.Lock()
+		byFrom := in.messages[round]
+		var missing []string
+		for _, nodeID := range fromNodeIDs {
+			if _, ok := byFrom[nodeID]; !ok {
+				missing = append(missing, nodeID)
+			}
+		}
+		if len(missing) == 0 {
+			result := make(map[string]*frostWireMessage, len(fromNodeIDs))
+			for _, nodeID := range fromNodeIDs {
+				result[nodeID] = byFrom[nodeID]
+			}
+			in.mu.Unlock()
+			return result, nil
+		}
+		in.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "timed out waiting for %s messages from %v", round, missing)
+		case <-in.updated:
+		}
+	}
+}
+
+// frostMessageHub 管理所有 FROST 会话的收件箱
+// 收件箱由本地协议或先到达的消息惰性创建，本地协议结束时释放
+type frostMessageHub struct {
+	mu      sync.Mutex
+	inboxes map[string]*frostInbox
+}
+
+func newFROSTMessageHub() *frostMessageHub {
+	return &frostMessageHub{inboxes: make(map[string]*frostInbox)}
+}
+
+// inbox 获取或创建会话收件箱，同时清理过期未认领的收件箱
+func (h *frostMessageHub) inbox(sessionID string) *frostInbox {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	for id, in := range h.inboxes {
+		in.mu.Lock()
+		expired := !in.claimed && now.Sub(in.created) > frostInboxTTL
+		in.mu.Unlock()
+		if expired {
+			delete(h.inboxes, id)
+			log.Warn().Str("session_id", id).Msg("Dropping unclaimed FROST inbox")
+		}
+	}
+
+	in, ok := h.inboxes[sessionID]
+	if !ok {
+		in = &frostInbox{
+			messages: make(map[string]map[string]*frostWireMessage),
+			updated:  make(chan struct{}, 1),
+			created:  now,
+		}
+		h.inboxes[sessionID] = in
+	}
+	return in
+}
+
+// claim 本地协议认领会话收件箱（认领后不会被过期清理）
+func (h *frostMessageHub) claim(sessionID string) *frostInbox {
+	in := h.inbox(sessionID)
+	in.mu.Lock()
+	in.claimed = true
+	in.mu.Unlock()
+	return in
+}
+
+// release 本地协议结束后释放会话收件箱
+func (h *frostMessageHub) release(sessionID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.inboxes, sessionID)
+}
+
+// awaitAny 等待会话在 round 轮次收到任意消息，超时返回 false
+func (h *frostMessageHub) awaitAny(ctx context.Context, sessionID string, round string, timeout time.Duration) bool {
+	in := h.inbox(sessionID)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		in.mu.Lock()
+		received := len(in.messages[round]) > 0
+		in.mu.Unlock()
+		if received {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			return false
+		case <-in.updated:
+			// 重新放回通知，避免随后的 collect 错过
+			select {
+			case in.updated <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// deliver 将收到的消息放入会话收件箱
+func (h *frostMessageHub) deliver(sessionID string, fromNodeID string, msg *frostWireMessage) error {
+	if msg.From != fromNodeID {
+		return errors.Errorf("FROST message sender mismatch: transport says %s, message says %s", fromNodeID, msg.From)
+	}
+	return h.inbox(sessionID).put(msg)
+}
+
+// sendFROSTMessage 向 toNodeIDs 发送同一轮次消息；broadcast 为 true 时所有接收方收到相同内容
+func (p *FROSTProtocol) sendFROSTMessage(sessionID string, round string, toNodeIDs []string, payload interface{}, broadcast bool) error {
+	return sendWireMessage(p.messageRouter, frostWireProtocol, p.thisNodeID, sessionID, round, toNodeIDs, payload, broadcast)
+}
+
+// sendWireMessage 通过 messageRouter 向 toNodeIDs 发送 JSON 消息（跳过本节点）
+func sendWireMessage(
+	messageRouter func(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error,
+	protocol string,
+	thisNodeID string,
+	sessionID string,
+	round string,
+	toNodeIDs []string,
+	payload interface{},
+	broadcast bool,
+) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s payload", round)
+	}
+	if messageRouter == nil {
+		return errors.New("message router is not configured")
+	}
+
+	for _, nodeID := range toNodeIDs {
+		if nodeID == thisNodeID {
+			continue
+		}
+		msg := &frostWireMessage{
+			Protocol: protocol,
+			Round:    round,
+			From:     thisNodeID,
+			Payload:  data,
+		}
+		if !broadcast {
+			msg.To = nodeID
+		}
+		if err := messageRouter(sessionID, nodeID, msg, broadcast); err != nil {
+			return errors.Wrapf(err, "failed to send %s message to node %s", round, nodeID)
+		}
+	}
+	return nil
+}
+
+// sendFROSTDirect 向单个节点发送点对点消息
+func (p *FROSTProtocol) sendFROSTDirect(sessionID string, round string, toNodeID string, payload interface{}) error {
+	return p.sendFROSTMessage(sessionID, round, []string{toNodeID}, payload, false)
+}
+
+// otherNodeIDs 返回 nodeIDs 中除本节点外的节点
+func (p *FROSTProtocol) otherNodeIDs(nodeIDs []string) []string {
+	others := make([]string, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if nodeID != p.thisNodeID {
+			others = append(others, nodeID)
+		}
+	}
+	return others
+}
@@ -3,7 +3,6 @@ package protocol
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"encoding/gob"
 	"encoding/hex"
 	"fmt"
@@ -33,6 +32,7 @@ type gg18KeyRecord struct {
 type KeyShareStorage interface {
 	StoreKeyData(ctx context.Context, keyID string, nodeID string, keyData []byte) error
 	GetKeyData(ctx context.Context, keyID string, nodeID string) ([]byte, error)
+	DeleteKeyData(ctx context.Context, keyID string, nodeID string) error
 }
 
 // GG18Protocol GG18协议实现（基于 tss-lib 的生产级实现）
@@ -69,6 +69,13 @@ func NewGG18Protocol(curve string, thisNodeID string, messageRouter func(session
 	}
 }
 
+// SetPreParamsPool 设置 keygen 预参数池（GG20 通过嵌入复用）
+func (p *GG18Protocol) SetPreParamsPool(pool *PreParamsPool) {
+	p.partyManager.mu.Lock()
+	defer p.partyManager.mu.Unlock()
+	p.partyManager.preParamsPool = pool
+}
+
 // getKeyRecord 获取密钥记录（测试或签名阶段使用）
 func (p *GG18Protocol) getKeyRecord(keyID string) (*gg18KeyRecord, bool) {
 	p.mu.RLock()
@@ -123,33 +130,8 @@ func (p *GG18Protocol) GenerateKeyShare(ctx context.Context, req *KeyGenRequest)
 	p.saveKeyRecord(keyID, record)
 
 	// 持久化 LocalPartySaveData 到 keyShareStorage（用于签名时加载）
-	if p.keyShareStorage != nil {
-		keyDataBytes, err := serializeLocalPartySaveData(keyData)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to serialize LocalPartySaveData")
-		}
-		log.Info().
-			Str("key_id", keyID).
-			Str("node_id", p.thisNodeID).
-			Int("key_data_bytes", len(keyDataBytes)).
-			Msg("Storing LocalPartySaveData to keyShareStorage")
-		if err := p.keyShareStorage.StoreKeyData(ctx, keyID, p.thisNodeID, keyDataBytes); err != nil {
-			log.Error().
-				Err(err).
-				Str("key_id", keyID).
-				Str("node_id", p.thisNodeID).
-				Msg("Failed to store LocalPartySaveData")
-			return nil, errors.Wrap(err, "failed to store LocalPartySaveData")
-		}
-		log.Info().
-			Str("key_id", keyID).
-			Str("node_id", p.thisNodeID).
-			Msg("LocalPartySaveData stored successfully")
-	} else {
-		log.Warn().
-			Str("key_id", keyID).
-			Str("node_id", p.thisNodeID).
-			Msg("keyShareStorage is nil, cannot store LocalPartySaveData")
+	if err := p.storeKeyData(ctx, keyID, keyData); err != nil {
+		return nil, err
 	}
 
 	// 返回当前节点的KeyShare（在map中）
@@ -162,20 +144,15 @@ func (p *GG18Protocol) GenerateKeyShare(ctx context.Context, req *KeyGenRequest)
 	}, nil
 }
 
-// ThresholdSign 阈值签名（使用 tss-lib 的真实签名协议）
-func (p *GG18Protocol) ThresholdSign(ctx context.Context, sessionID string, req *SignRequest) (*SignResponse, error) {
-	if err := p.ValidateSignRequest(req); err != nil {
-		return nil, errors.Wrap(err, "invalid sign request")
-	}
-
-	// 获取密钥记录（优先从内存，如果不存在则从 keyShareStorage 加载）
-	record, ok := p.getKeyRecord(req.KeyID)
+// loadKeyRecord 获取密钥记录（优先从内存，如果不存在则从 keyShareStorage 加载）
+func (p *GG18Protocol) loadKeyRecord(ctx context.Context, keyID string) (*gg18KeyRecord, error) {
+	record, ok := p.getKeyRecord(keyID)
 	if !ok {
 		// 内存中没有，尝试从 keyShareStorage 加载
 		if p.keyShareStorage != nil {
-			keyDataBytes, err := p.keyShareStorage.GetKeyData(ctx, req.KeyID, p.thisNodeID)
+			keyDataBytes, err := p.keyShareStorage.GetKeyData(ctx, keyID, p.thisNodeID)
 			if err != nil {
-				return nil, errors.Wrapf(err, "key %s not found in memory or storage", req.KeyID)
+				return nil, errors.Wrapf(err, "key %s not found in memory or storage", keyID)
 			}
 
 			// 反序列化 LocalPartySaveData
@@ -221,12 +198,61 @@ func (p *GG18Protocol) ThresholdSign(ctx context.Context, sessionID string, req
 				TotalNodes: 0,
 				NodeIDs:    nil,
 			}
-			p.saveKeyRecord(req.KeyID, record)
+			p.saveKeyRecord(keyID, record)
 		} else {
-			return nil, errors.Errorf("key %s not found", req.KeyID)
+			return nil, errors.Errorf("key %s not found", keyID)
 		}
 	}
 
+	return record, nil
+}
+
+// storeKeyData 持久化 LocalPartySaveData 到 keyShareStorage（用于签名时加载）
+func (p *GG18Protocol) storeKeyData(ctx context.Context, keyID string, keyData *keygen.LocalPartySaveData) error {
+	if p.keyShareStorage == nil {
+		log.Warn().
+			Str("key_id", keyID).
+			Str("node_id", p.thisNodeID).
+			Msg("keyShareStorage is nil, cannot store LocalPartySaveData")
+		return nil
+	}
+
+	keyDataBytes, err := serializeLocalPartySaveData(keyData)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize LocalPartySaveData")
+	}
+	log.Info().
+		Str("key_id", keyID).
+		Str("node_id", p.thisNodeID).
+		Int("key_data_bytes", len(keyDataBytes)).
+		Msg("Storing LocalPartySaveData to keyShareStorage")
+	if err := p.keyShareStorage.StoreKeyData(ctx, keyID, p.thisNodeID, keyDataBytes); err != nil {
+		log.Error().
+			Err(err).
+			Str("key_id", keyID).
+			Str("node_id", p.thisNodeID).
+			Msg("Failed to store LocalPartySaveData")
+		return errors.Wrap(err, "failed to store LocalPartySaveData")
+	}
+	log.Info().
+		Str("key_id", keyID).
+		Str("node_id", p.thisNodeID).
+		Msg("LocalPartySaveData stored successfully")
+	return nil
+}
+
+// ThresholdSign 阈值签名（使用 tss-lib 的真实签名协议）
+func (p *GG18Protocol) ThresholdSign(ctx context.Context, sessionID string, req *SignRequest) (*SignResponse, error) {
+	if err := p.ValidateSignRequest(req); err != nil {
+		return nil, errors.Wrap(err, "invalid sign request")
+	}
+
+	// 获取密钥记录（优先从内存，如果不存在则从 keyShareStorage 加载）
+	record, err := p.loadKeyRecord(ctx, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
 	if record.KeyData == nil {
 		return nil, errors.New("key data not found in record")
 	}
@@ -238,6 +264,14 @@ func (p *GG18Protocol) ThresholdSign(ctx context.Context, sessionID string, req
 	}
 
 	// 使用 tss-lib 执行真正的阈值签名（GG18 默认选项）
+	opts := DefaultSigningOptions()
+	opts.Prehashed = req.Prehashed
+	derived, err := deriveForSignRequest("secp256k1", compressECPoint(record.KeyData.ECDSAPub), req)
+	if err != nil {
+		return nil, err
+	}
+	opts.KeyDerivation = derived
+
 	sigData, err := p.partyManager.executeSigning(
 		ctx,
 		sessionID,
@@ -246,7 +280,7 @@ func (p *GG18Protocol) ThresholdSign(ctx context.Context, sessionID string, req
 		req.NodeIDs,
 		p.thisNodeID,
 		record.KeyData,
-		DefaultSigningOptions(),
+		opts,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "execute tss-lib signing")
@@ -258,9 +292,13 @@ func (p *GG18Protocol) ThresholdSign(ctx context.Context, sessionID string, req
 		return nil, errors.Wrap(err, "convert tss signature")
 	}
 
+	publicKey := record.PublicKey
+	if derived != nil {
+		publicKey = derived.publicKey()
+	}
 	return &SignResponse{
 		Signature: signature,
-		PublicKey: record.PublicKey,
+		PublicKey: publicKey,
 	}, nil
 }
 
@@ -290,19 +328,24 @@ func normalizeNodeIDs(ids []string, total int) ([]string, error) {
 }
 
 func resolveMessagePayload(req *SignRequest) ([]byte, error) {
+	var msg []byte
 	switch {
 	case len(req.Message) > 0:
-		return req.Message, nil
+		msg = req.Message
 	case req.MessageHex != "":
 		payload := strings.TrimPrefix(req.MessageHex, "0x")
-		msg, err := hex.DecodeString(payload)
+		decoded, err := hex.DecodeString(payload)
 		if err != nil {
 			return nil, errors.Wrap(err, "invalid message hex")
 		}
-		return msg, nil
+		msg = decoded
 	default:
 		return nil, errors.New("message payload is empty")
 	}
+	if req.Prehashed && len(msg) != 32 {
+		return nil, errors.Errorf("prehashed message must be a 32-byte digest, got %d bytes", len(msg))
+	}
+	return msg, nil
 }
 
 func verifyECDSASignature(sig *Signature, msg []byte, pubKey *PublicKey) (bool, error) {
@@ -316,7 +359,21 @@ func verifyECDSASignature(sig *Signature, msg []byte, pubKey *PublicKey) (bool,
 		return false, errors.New("public key is empty")
 	}
 
-	hash := sha256.Sum256(msg)
+	return VerifyECDSADigest(sig, ECDSADigest(msg, false), pubKey)
+}
+
+// VerifyECDSADigest 验证对 32 字节摘要的 DER 签名（预哈希签名由调用方计算摘要）
+func VerifyECDSADigest(sig *Signature, digest []byte, pubKey *PublicKey) (bool, error) {
+	if sig == nil || len(sig.Bytes) == 0 {
+		return false, errors.New("signature bytes missing")
+	}
+	if len(digest) != 32 {
+		return false, errors.Errorf("invalid digest length: %d", len(digest))
+	}
+	if pubKey == nil || len(pubKey.Bytes) == 0 {
+		return false, errors.New("public key is empty")
+	}
+
 	parsedSig, err := ecdsa.ParseDERSignature(sig.Bytes)
 	if err != nil {
 		return false, errors.Wrap(err, "parse signature")
@@ -326,18 +383,105 @@ func verifyECDSASignature(sig *Signature, msg []byte, pubKey *PublicKey) (bool,
 		return false, errors.Wrap(err, "parse pub key")
 	}
 
-	return parsedSig.Verify(hash[:], parsedPub), nil
+	return parsedSig.Verify(digest, parsedPub), nil
+}
+
+// RotateKey 密钥重分享（resharing）：旧委员会把分片重新分发给新委员会，公钥保持不变
+// 仅属于旧委员会的节点在完成后作废本地分片
+func (p *GG18Protocol) RotateKey(ctx context.Context, req *ReshareRequest) (*ReshareResponse, error) {
+	if err := validateReshareRequest(req); err != nil {
+		return nil, errors.Wrap(err, "invalid reshare request")
+	}
+
+	var oldRecord *gg18KeyRecord
+	if containsNodeID(req.OldNodeIDs, p.thisNodeID) {
+		record, err := p.loadKeyRecord(ctx, req.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		if record.KeyData == nil {
+			return nil, errors.New("key data not found in record")
+		}
+		oldRecord = record
+	}
+
+	var oldKeyData *keygen.LocalPartySaveData
+	if oldRecord != nil {
+		oldKeyData = oldRecord.KeyData
+	}
+
+	newKeyData, err := p.partyManager.executeResharing(ctx, req, p.thisNodeID, oldKeyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute tss-lib resharing")
+	}
+
+	// 不在新委员会：旧分片已失效，删除本地数据
+	if newKeyData == nil {
+		if err := p.discardKeyData(ctx, req.KeyID); err != nil {
+			return nil, err
+		}
+		return &ReshareResponse{PublicKey: oldRecord.PublicKey}, nil
+	}
+
+	keyShare, publicKey, err := convertTSSKeyData(req.KeyID, newKeyData, p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert tss key data")
+	}
+	if oldRecord != nil && oldRecord.PublicKey != nil && oldRecord.PublicKey.Hex != publicKey.Hex {
+		return nil, errors.Errorf("public key changed after resharing: %s -> %s", oldRecord.PublicKey.Hex, publicKey.Hex)
+	}
+
+	if err := p.storeKeyData(ctx, req.KeyID, newKeyData); err != nil {
+		return nil, err
+	}
+	p.saveKeyRecord(req.KeyID, &gg18KeyRecord{
+		KeyData:    newKeyData,
+		PublicKey:  publicKey,
+		Threshold:  req.NewThreshold,
+		TotalNodes: len(req.NewNodeIDs),
+		NodeIDs:    req.NewNodeIDs,
+	})
+
+	return &ReshareResponse{
+		PublicKey: publicKey,
+		KeyShare:  keyShare,
+	}, nil
 }
 
-// RotateKey 密钥轮换
-func (p *GG18Protocol) RotateKey(ctx context.Context, keyID string) error {
-	// TODO: 实现密钥轮换协议
-	// 1. 执行密钥轮换DKG
-	// 2. 生成新的密钥分片
-	// 3. 更新密钥元数据
+// ForgetKey 丢弃内存中缓存的密钥数据（keyShareStorage 中的数据由调用方删除）
+func (p *GG18Protocol) ForgetKey(keyID string) {
+	p.mu.Lock()
+	delete(p.keyRecords, keyID)
+	p.mu.Unlock()
+}
+
+// discardKeyData 删除本节点的密钥数据（内存和 keyShareStorage）
+func (p *GG18Protocol) discardKeyData(ctx context.Context, keyID string) error {
+	p.mu.Lock()
+	delete(p.keyRecords, keyID)
+	p.mu.Unlock()
+
+	if p.keyShareStorage == nil {
+		return nil
+	}
+	if err := p.keyShareStorage.DeleteKeyData(ctx, keyID, p.thisNodeID); err != nil {
+		return errors.Wrap(err, "failed to delete LocalPartySaveData")
+	}
+	log.Info().
+		Str("key_id", keyID).
+		Str("node_id", p.thisNodeID).
+		Msg("Old key share discarded after resharing")
+	return nil
+}
+
+// Presign GG18 不支持预签名（离线/在线拆分仅在 GG20 中提供）
+func (p *GG18Protocol) Presign(ctx context.Context, sessionID string, req *PresignRequest) (*PresignResponse, error) {
+	return nil, errors.New("presignatures are only supported by GG20")
+}
 
-	// 临时实现：返回错误，提示需要实现
-	return errors.New("GG18 key rotation not yet implemented")
+// ProcessIncomingResharingMessage 处理接收到的 resharing 消息
+func (p *GG18Protocol) ProcessIncomingResharingMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte, isBroadcast bool) error {
+	return p.partyManager.ProcessIncomingResharingMessage(ctx, sessionID, fromNodeID, msgBytes, isBroadcast)
 }
 
 // ProcessIncomingKeygenMessage 处理接收到的DKG消息
@@ -346,8 +490,8 @@ func (p *GG18Protocol) ProcessIncomingKeygenMessage(ctx context.Context, session
 }
 
 // ProcessIncomingSigningMessage 处理接收到的签名消息
-func (p *GG18Protocol) ProcessIncomingSigningMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte) error {
-	return p.partyManager.ProcessIncomingSigningMessage(ctx, sessionID, fromNodeID, msgBytes)
+func (p *GG18Protocol) ProcessIncomingSigningMessage(ctx context.Context, sessionID string, fromNodeID string, msgBytes []byte, isBroadcast bool) error {
+	return p.partyManager.ProcessIncomingSigningMessage(ctx, sessionID, fromNodeID, msgBytes, isBroadcast)
 }
 
 // SupportedProtocols 支持的协议
@@ -404,6 +548,10 @@ func (p *GG18Protocol) ValidateSignRequest(req *SignRequest) error {
 		return fmt.Errorf("node IDs are required")
 	}
 
+	if req.DerivationPath != "" && req.PresignatureID != "" {
+		return fmt.Errorf("presignatures cannot be used with a derivation path")
+	}
+
 	return nil
 }
 
@@ -14,13 +14,13 @@ import (
 )
 
 // mockMessageRouter 模拟消息路由函数（用于测试）
-func mockMessageRouter(sessionID string, nodeID string, msg tss.Message) error {
+func mockMessageRouter(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error {
 	// 在单元测试中，消息路由只是记录，不实际发送
 	return nil
 }
 
 func TestNewGG18Protocol(t *testing.T) {
-	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	assert.NotNil(t, protocol)
 	assert.Equal(t, "secp256k1", protocol.GetCurve())
@@ -29,7 +29,7 @@ func TestNewGG18Protocol(t *testing.T) {
 }
 
 func TestValidateKeyGenRequest(t *testing.T) {
-	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	tests := []struct {
 		name    string
@@ -122,7 +122,7 @@ func TestValidateKeyGenRequest(t *testing.T) {
 }
 
 func TestValidateSignRequest(t *testing.T) {
-	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	tests := []struct {
 		name    string
@@ -443,18 +443,31 @@ func TestGenerateKeyID(t *testing.T) {
 }
 
 func TestRotateKey(t *testing.T) {
-	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	ctx := context.Background()
-	err := protocol.RotateKey(ctx, "key-1")
 
-	// RotateKey 目前未实现，应该返回错误
+	// 缺少会话ID和委员会信息，应该返回错误
+	_, err := protocol.RotateKey(ctx, &ReshareRequest{KeyID: "key-1"})
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "not yet implemented")
+	assert.Contains(t, err.Error(), "invalid reshare request")
+
+	// 当前节点属于旧委员会但没有密钥分片，应该返回错误
+	_, err = protocol.RotateKey(ctx, &ReshareRequest{
+		SessionID:    "reshare-1",
+		KeyID:        "key-1",
+		OldNodeIDs:   []string{"node-1", "node-2"},
+		OldThreshold: 1,
+		NewNodeIDs:   []string{"node-2", "node-3"},
+		NewThreshold: 1,
+		NewEpoch:     1,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
 }
 
 func TestGetKeyRecord(t *testing.T) {
-	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	// 测试获取不存在的密钥
 	_, ok := protocol.getKeyRecord("non-existent")
@@ -462,20 +475,20 @@ func TestGetKeyRecord(t *testing.T) {
 }
 
 func TestGG18Protocol_GetCurve(t *testing.T) {
-	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	assert.Equal(t, "secp256k1", protocol.GetCurve())
 }
 
 func TestGG18Protocol_SupportedProtocols(t *testing.T) {
-	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	protocols := protocol.SupportedProtocols()
 	assert.Equal(t, []string{"gg18"}, protocols)
 }
 
 func TestGG18Protocol_DefaultProtocol(t *testing.T) {
-	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	assert.Equal(t, "gg18", protocol.DefaultProtocol())
 }
@@ -2,9 +2,13 @@ package protocol
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"sync"
 
 	"github.com/kashguard/tss-lib/tss"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 )
 
 // GG20Protocol GG20协议实现（改进版GG18，支持单轮签名和可识别的中止）
@@ -14,6 +18,9 @@ import (
 // 3. 更好的性能（减少网络通信轮次）
 type GG20Protocol struct {
 	*GG18Protocol
+
+	// presignMu 串行化预签名的读取与删除，保证同一预签名在本节点只被使用一次
+	presignMu sync.Mutex
 }
 
 // NewGG20Protocol 创建GG20协议实例
@@ -39,10 +46,10 @@ func (p *GG20Protocol) ThresholdSign(ctx context.Context, sessionID string, req
 		return nil, errors.Wrap(err, "invalid sign request")
 	}
 
-	// 获取密钥记录
-	record, ok := p.getKeyRecord(req.KeyID)
-	if !ok {
-		return nil, errors.Errorf("key %s not found", req.KeyID)
+	// 获取密钥记录（优先从内存，如果不存在则从 keyShareStorage 加载）
+	record, err := p.loadKeyRecord(ctx, req.KeyID)
+	if err != nil {
+		return nil, err
 	}
 
 	if record.KeyData == nil {
@@ -55,7 +62,20 @@ func (p *GG20Protocol) ThresholdSign(ctx context.Context, sessionID string, req
 		return nil, errors.Wrap(err, "resolve message payload")
 	}
 
+	// 指定了预签名：只执行单轮在线签名
+	if req.PresignatureID != "" {
+		return p.thresholdSignWithPresignature(ctx, sessionID, req, record, message)
+	}
+
 	// 使用 tss-lib 执行 GG20 签名协议（复用通用签名执行函数）
+	opts := GG20SigningOptions()
+	opts.Prehashed = req.Prehashed
+	derived, err := deriveForSignRequest("secp256k1", compressECPoint(record.KeyData.ECDSAPub), req)
+	if err != nil {
+		return nil, err
+	}
+	opts.KeyDerivation = derived
+
 	sigData, err := p.partyManager.executeSigning(
 		ctx,
 		sessionID,
@@ -64,7 +84,7 @@ func (p *GG20Protocol) ThresholdSign(ctx context.Context, sessionID string, req
 		req.NodeIDs,
 		p.thisNodeID,
 		record.KeyData,
-		GG20SigningOptions(),
+		opts,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "execute GG20 signing")
@@ -76,12 +96,147 @@ func (p *GG20Protocol) ThresholdSign(ctx context.Context, sessionID string, req
 		return nil, errors.Wrap(err, "convert tss signature")
 	}
 
+	publicKey := record.PublicKey
+	if derived != nil {
+		publicKey = derived.publicKey()
+	}
+	return &SignResponse{
+		Signature: signature,
+		PublicKey: publicKey,
+	}, nil
+}
+
+// thresholdSignWithPresignature 消耗一个预签名并执行单轮在线签名
+func (p *GG20Protocol) thresholdSignWithPresignature(ctx context.Context, sessionID string, req *SignRequest, record *gg18KeyRecord, message []byte) (*SignResponse, error) {
+	presig, err := p.consumePresignature(ctx, req.KeyID, req.PresignatureID)
+	if err != nil {
+		return nil, err
+	}
+	if presig.ShareID == nil || presig.ShareID.Cmp(record.KeyData.ShareID) != 0 {
+		return nil, errors.Errorf("presignature %s was generated for a previous key share", req.PresignatureID)
+	}
+	if !sameNodeSet(presig.NodeIDs, req.NodeIDs) {
+		return nil, errors.Errorf("presignature %s was generated by nodes %v, but signing requested nodes %v", req.PresignatureID, presig.NodeIDs, req.NodeIDs)
+	}
+
+	sigData, err := p.partyManager.executePresignedSigning(ctx, sessionID, message, req.Prehashed, presig, p.thisNodeID, record.KeyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute GG20 presigned signing")
+	}
+
+	signature, err := convertTSSSignature(sigData)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert tss signature")
+	}
+
 	return &SignResponse{
 		Signature: signature,
 		PublicKey: record.PublicKey,
 	}, nil
 }
 
+// Presign 生成预签名（GG20 离线阶段），结果加密保存在本节点的 keyShareStorage 中
+// sessionID 即预签名ID，在线签名时由协调者通过 SignRequest.PresignatureID 指定
+func (p *GG20Protocol) Presign(ctx context.Context, sessionID string, req *PresignRequest) (*PresignResponse, error) {
+	if req == nil || req.KeyID == "" {
+		return nil, errors.New("key ID is required")
+	}
+	if !presignIDPattern.MatchString(sessionID) {
+		return nil, errors.Errorf("invalid presignature ID: %q", sessionID)
+	}
+	if len(req.NodeIDs) < 2 {
+		return nil, errors.New("at least two nodes are required for presigning")
+	}
+	if !containsNodeID(req.NodeIDs, p.thisNodeID) {
+		return nil, errors.Errorf("this node %s is not a presign participant", p.thisNodeID)
+	}
+	if p.keyShareStorage == nil {
+		return nil, errors.New("key share storage is required for presignatures")
+	}
+
+	record, err := p.loadKeyRecord(ctx, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if record.KeyData == nil {
+		return nil, errors.New("key data not found in record")
+	}
+
+	presig, err := p.partyManager.executePresign(ctx, sessionID, req.KeyID, req.NodeIDs, p.thisNodeID, record.KeyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute GG20 presign")
+	}
+
+	data, err := json.Marshal(presig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal presignature")
+	}
+	if err := p.keyShareStorage.StoreKeyData(ctx, presignStorageKeyID(req.KeyID, sessionID), p.thisNodeID, data); err != nil {
+		return nil, errors.Wrap(err, "failed to store presignature")
+	}
+
+	return &PresignResponse{PresignatureID: sessionID}, nil
+}
+
+// consumePresignature 读取并删除本节点的预签名
+// 先删除再使用：即使随后的在线签名失败，同一预签名也不会被再次使用
+func (p *GG20Protocol) consumePresignature(ctx context.Context, keyID string, presignID string) (*presignature, error) {
+	if !presignIDPattern.MatchString(presignID) {
+		return nil, errors.Errorf("invalid presignature ID: %q", presignID)
+	}
+	if p.keyShareStorage == nil {
+		return nil, errors.New("key share storage is required for presignatures")
+	}
+
+	p.presignMu.Lock()
+	defer p.presignMu.Unlock()
+
+	storageKeyID := presignStorageKeyID(keyID, presignID)
+	data, err := p.keyShareStorage.GetKeyData(ctx, storageKeyID, p.thisNodeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "presignature %s not found or already used", presignID)
+	}
+	if err := p.keyShareStorage.DeleteKeyData(ctx, storageKeyID, p.thisNodeID); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete presignature %s before use", presignID)
+	}
+
+	var presig presignature
+	if err := json.Unmarshal(data, &presig); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal presignature")
+	}
+	if presig.ID != presignID || presig.KeyID != keyID {
+		return nil, errors.Errorf("presignature %s does not belong to key %s", presignID, keyID)
+	}
+	if presig.K == nil || presig.Sigma == nil || presig.Rx == nil || presig.Ry == nil {
+		return nil, errors.Errorf("presignature %s is incomplete", presignID)
+	}
+
+	log.Info().
+		Str("key_id", keyID).
+		Str("presignature_id", presignID).
+		Str("node_id", p.thisNodeID).
+		Msg("Presignature consumed")
+
+	return &presig, nil
+}
+
+// sameNodeSet 判断两个节点列表是否包含相同的节点（忽略顺序）
+func sameNodeSet(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // SupportedProtocols 支持的协议
 func (p *GG20Protocol) SupportedProtocols() []string {
 	return []string{"gg20"}
@@ -112,7 +267,7 @@ func (p *GG20Protocol) VerifySignature(ctx context.Context, sig *Signature, msg
 	return p.GG18Protocol.VerifySignature(ctx, sig, msg, pubKey)
 }
 
-// RotateKey 密钥轮换（复用GG18的实现）
-func (p *GG20Protocol) RotateKey(ctx context.Context, keyID string) error {
-	return p.GG18Protocol.RotateKey(ctx, keyID)
+// RotateKey 密钥重分享（复用GG18的实现，GG20的分片格式与GG18相同）
+func (p *GG20Protocol) RotateKey(ctx context.Context, req *ReshareRequest) (*ReshareResponse, error) {
+	return p.GG18Protocol.RotateKey(ctx, req)
 }
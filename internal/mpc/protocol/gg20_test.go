@@ -11,7 +11,7 @@ import (
 )
 
 func TestNewGG20Protocol(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	assert.NotNil(t, protocol)
 	assert.NotNil(t, protocol.GG18Protocol)
@@ -37,24 +37,24 @@ func TestGG20Protocol_GetCurve(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			protocol := NewGG20Protocol(tt.curve, "node-1", mockMessageRouter)
+			protocol := NewGG20Protocol(tt.curve, "node-1", mockMessageRouter, nil)
 			assert.Equal(t, tt.curve, protocol.GetCurve())
 		})
 	}
 }
 
 func TestGG20Protocol_SupportedProtocols(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 	assert.Equal(t, []string{"gg20"}, protocol.SupportedProtocols())
 }
 
 func TestGG20Protocol_DefaultProtocol(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 	assert.Equal(t, "gg20", protocol.DefaultProtocol())
 }
 
 func TestGG20Protocol_ValidateKeyGenRequest(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	tests := []struct {
 		name    string
@@ -135,7 +135,7 @@ func TestGG20Protocol_ValidateKeyGenRequest(t *testing.T) {
 }
 
 func TestGG20Protocol_ValidateSignRequest(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	tests := []struct {
 		name    string
@@ -206,7 +206,7 @@ func TestGG20Protocol_ValidateSignRequest(t *testing.T) {
 }
 
 func TestGG20Protocol_ThresholdSign_InvalidRequest(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	ctx := context.Background()
 	sessionID := "test-session"
@@ -263,7 +263,7 @@ func TestGG20Protocol_ThresholdSign_InvalidRequest(t *testing.T) {
 }
 
 func TestGG20Protocol_GenerateKeyShare_Delegation(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	req := &KeyGenRequest{
 		Algorithm:  "ECDSA",
@@ -287,7 +287,7 @@ func TestGG20Protocol_GenerateKeyShare_Delegation(t *testing.T) {
 }
 
 func TestGG20Protocol_VerifySignature_Delegation(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	ctx := context.Background()
 	sig := &Signature{
@@ -310,8 +310,8 @@ func TestGG20Protocol_VerifySignature_Delegation(t *testing.T) {
 }
 
 func TestGG20Protocol_VerifySignature_DelegationComparison(t *testing.T) {
-	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
-	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
+	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	ctx := context.Background()
 	sig := &Signature{
@@ -340,40 +340,50 @@ func TestGG20Protocol_VerifySignature_DelegationComparison(t *testing.T) {
 	}
 }
 
+func newTestReshareRequest(keyID string) *ReshareRequest {
+	return &ReshareRequest{
+		SessionID:    "reshare-test",
+		KeyID:        keyID,
+		OldNodeIDs:   []string{"node-1", "node-2"},
+		OldThreshold: 1,
+		NewNodeIDs:   []string{"node-1", "node-2", "node-3"},
+		NewThreshold: 1,
+		NewEpoch:     1,
+	}
+}
+
 func TestGG20Protocol_RotateKey_Delegation(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	ctx := context.Background()
 	keyID := "test-key"
 
-	// 验证委托给 GG18Protocol
-	// 注意：密钥轮换功能尚未实现
-	err := protocol.RotateKey(ctx, keyID)
+	// 验证委托给 GG18Protocol（密钥不存在时返回错误）
+	_, err := protocol.RotateKey(ctx, newTestReshareRequest(keyID))
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "not yet implemented")
+	assert.Contains(t, err.Error(), "not found")
 }
 
 func TestGG20Protocol_RotateKey_DelegationComparison(t *testing.T) {
-	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
-	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
+	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	ctx := context.Background()
 	keyID := "test-key"
 
 	// 验证 RotateKey 委托给 GG18Protocol
-	err18 := gg18Protocol.RotateKey(ctx, keyID)
-	err20 := gg20Protocol.RotateKey(ctx, keyID)
+	_, err18 := gg18Protocol.RotateKey(ctx, newTestReshareRequest(keyID))
+	_, err20 := gg20Protocol.RotateKey(ctx, newTestReshareRequest(keyID))
 
-	// 验证错误相同（都因为功能未实现而失败）
+	// 验证错误相同（都因为密钥不存在而失败）
 	assert.Equal(t, err18 == nil, err20 == nil)
 	if err18 != nil && err20 != nil {
-		assert.Contains(t, err18.Error(), "not yet implemented")
-		assert.Contains(t, err20.Error(), "not yet implemented")
+		assert.Equal(t, err18.Error(), err20.Error())
 	}
 }
 
 func TestGG20Protocol_GG18ProtocolEmbedding(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	// 验证 GG20Protocol 正确嵌入了 GG18Protocol
 	assert.NotNil(t, protocol.GG18Protocol)
@@ -383,8 +393,8 @@ func TestGG20Protocol_GG18ProtocolEmbedding(t *testing.T) {
 }
 
 func TestGG20Protocol_ProtocolIdentity(t *testing.T) {
-	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
-	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
+	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	// 验证协议标识符不同
 	assert.Equal(t, []string{"gg18"}, gg18Protocol.SupportedProtocols())
@@ -397,7 +407,7 @@ func TestGG20Protocol_ProtocolIdentity(t *testing.T) {
 }
 
 func TestGG20Protocol_ThresholdSign_UsesGG20Options(t *testing.T) {
-	_ = NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	_ = NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	// 验证 ThresholdSign 使用 GG20SigningOptions
 	// 这通过检查 GG20SigningOptions 的配置来验证
@@ -411,7 +421,7 @@ func TestGG20Protocol_ThresholdSign_UsesGG20Options(t *testing.T) {
 }
 
 func TestGG20Protocol_ConcurrentAccess(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	// 并发访问测试
 	const numGoroutines = 10
@@ -441,8 +451,8 @@ func TestGG20Protocol_ConcurrentAccess(t *testing.T) {
 }
 
 func TestGG20Protocol_ValidateKeyGenRequest_Delegation(t *testing.T) {
-	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
-	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
+	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	req := &KeyGenRequest{
 		Algorithm:  "ECDSA",
@@ -463,8 +473,8 @@ func TestGG20Protocol_ValidateKeyGenRequest_Delegation(t *testing.T) {
 }
 
 func TestGG20Protocol_ValidateSignRequest_Delegation(t *testing.T) {
-	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
-	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
+	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	req := &SignRequest{
 		KeyID:   "test-key",
@@ -483,8 +493,8 @@ func TestGG20Protocol_ValidateSignRequest_Delegation(t *testing.T) {
 }
 
 func TestGG20Protocol_GetCurve_Delegation(t *testing.T) {
-	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter)
-	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	gg18Protocol := NewGG18Protocol("secp256k1", "node-1", mockMessageRouter, nil)
+	gg20Protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	// 验证 GetCurve 委托给 GG18Protocol
 	assert.Equal(t, gg18Protocol.GetCurve(), gg20Protocol.GetCurve())
@@ -492,12 +502,12 @@ func TestGG20Protocol_GetCurve_Delegation(t *testing.T) {
 
 func TestGG20Protocol_MessageRouter(t *testing.T) {
 	messageCount := 0
-	messageRouter := func(sessionID string, nodeID string, msg tss.Message) error {
+	messageRouter := func(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error {
 		messageCount++
 		return nil
 	}
 
-	protocol := NewGG20Protocol("secp256k1", "node-1", messageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", messageRouter, nil)
 
 	// 验证消息路由函数已设置
 	assert.NotNil(t, protocol.GG18Protocol)
@@ -506,7 +516,7 @@ func TestGG20Protocol_MessageRouter(t *testing.T) {
 
 func TestGG20Protocol_ThisNodeID(t *testing.T) {
 	thisNodeID := "test-node-123"
-	protocol := NewGG20Protocol("secp256k1", thisNodeID, mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", thisNodeID, mockMessageRouter, nil)
 
 	// 验证 thisNodeID 已设置
 	assert.NotNil(t, protocol.GG18Protocol)
@@ -514,7 +524,7 @@ func TestGG20Protocol_ThisNodeID(t *testing.T) {
 }
 
 func TestGG20Protocol_GenerateKeyShare_ReusesGG18(t *testing.T) {
-	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter)
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, nil)
 
 	req := &KeyGenRequest{
 		Algorithm:  "ECDSA",
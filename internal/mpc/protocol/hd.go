@@ -0,0 +1,181 @@
+package protocol
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/ecdsa/signing"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+)
+
+// ChainCodeSize BIP-32 链码长度
+const ChainCodeSize = 32
+
+// maxDerivationDepth BIP-32 扩展公钥深度上限
+const maxDerivationDepth = 255
+
+// NewChainCode 生成随机 BIP-32 链码（随 DKG 生成并保存在密钥元数据中）
+func NewChainCode() ([]byte, error) {
+	chainCode := make([]byte, ChainCodeSize)
+	if _, err := rand.Read(chainCode); err != nil {
+		return nil, errors.Wrap(err, "failed to generate chain code")
+	}
+	return chainCode, nil
+}
+
+// ParseDerivationPath 解析 BIP-32 派生路径（如 m/0/5）
+// MPC 根密钥没有完整私钥，无法执行强化派生，因此路径只能包含非强化索引；
+// 根密钥本身相当于 BIP-44 账户层级（m/44'/60'/0'）的节点，调用方传入其后的非强化部分
+func ParseDerivationPath(path string) ([]uint32, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("derivation path is empty")
+	}
+
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" && parts[0] != "M" {
+		return nil, errors.Errorf("derivation path must start with m/: %q", path)
+	}
+	if len(parts)-1 > maxDerivationDepth {
+		return nil, errors.Errorf("derivation path is too deep: %d levels (max %d)", len(parts)-1, maxDerivationDepth)
+	}
+
+	indices := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H") {
+			return nil, errors.Errorf("hardened derivation is not supported for MPC keys: %q in %q", part, path)
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("invalid derivation index %q in %q", part, path)
+		}
+		if index >= ckd.HardenedKeyStart {
+			return nil, errors.Errorf("hardened derivation is not supported for MPC keys: %d in %q", index, path)
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
+
+// DerivedPublicKey 非强化派生得到的子公钥
+type DerivedPublicKey struct {
+	Path              string
+	PublicKey         []byte // 33 字节压缩公钥
+	ChainCode         []byte
+	ExtendedPublicKey string // BIP-32 扩展公钥（xpub）
+
+	// tweak 派生路径上各级 IL 之和，子私钥 = 根私钥 + tweak（签名时加到每个分片上）
+	tweak *big.Int
+	point *crypto.ECPoint
+}
+
+// DeriveChildPublicKey 从 MPC 根公钥和链码按路径派生子公钥（BIP-32 CKDpub，仅支持 secp256k1）
+func DeriveChildPublicKey(curve string, rootPublicKey []byte, chainCode []byte, path string) (*DerivedPublicKey, error) {
+	if curve != "" && !strings.EqualFold(curve, "secp256k1") {
+		return nil, errors.Errorf("BIP-32 derivation is only supported for secp256k1 keys, got %s", curve)
+	}
+	if len(chainCode) != ChainCodeSize {
+		return nil, errors.Errorf("invalid chain code length: %d", len(chainCode))
+	}
+	indices, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := secp256k1.ParsePubKey(rootPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid root public key")
+	}
+	ec := tss.S256()
+	root := &ckd.ExtendedKey{
+		PublicKey:  ecdsa.PublicKey{Curve: ec, X: pub.X(), Y: pub.Y()},
+		Depth:      0,
+		ChildIndex: 0,
+		ChainCode:  chainCode,
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+		Version:    chaincfg.MainNetParams.HDPublicKeyID[:],
+	}
+
+	tweak := big.NewInt(0)
+	child := root
+	if len(indices) > 0 {
+		tweak, child, err = ckd.DeriveChildKeyFromHierarchy(indices, root, ec.Params().N, ec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive %s", path)
+		}
+	}
+
+	childPub, err := crypto.NewECPoint(ec, child.X, child.Y)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid derived public key")
+	}
+
+	return &DerivedPublicKey{
+		Path:              path,
+		PublicKey:         compressECPoint(childPub),
+		ChainCode:         child.ChainCode,
+		ExtendedPublicKey: child.String(),
+		tweak:             tweak,
+		point:             childPub,
+	}, nil
+}
+
+// deriveForSignRequest 根据签名请求中的派生路径计算子公钥和分片调整值，未指定路径时返回 nil
+func deriveForSignRequest(curve string, rootPublicKey []byte, req *SignRequest) (*DerivedPublicKey, error) {
+	if req.DerivationPath == "" {
+		return nil, nil
+	}
+	if req.PresignatureID != "" {
+		return nil, errors.New("presignatures cannot be used with a derivation path")
+	}
+	derived, err := DeriveChildPublicKey(curve, rootPublicKey, req.ChainCode, req.DerivationPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "derive signing key")
+	}
+	return derived, nil
+}
+
+// applyKeyDerivation 返回按子密钥调整后的 ECDSA 分片数据副本和派生调整值，不修改原始数据
+// Shamir 分片 x_i 对应的子密钥分片为 x_i + δ，因此所有 BigXj 加上 δG，公钥替换为子公钥
+func applyKeyDerivation(keyData *keygen.LocalPartySaveData, derived *DerivedPublicKey) (keygen.LocalPartySaveData, *big.Int, error) {
+	if derived == nil {
+		return *keyData, nil, nil
+	}
+	signingKey := *keyData
+	signingKey.BigXj = append([]*crypto.ECPoint(nil), keyData.BigXj...)
+	keys := []keygen.LocalPartySaveData{signingKey}
+	childPk := derived.point.ToECDSAPubKey()
+	if err := signing.UpdatePublicKeyAndAdjustBigXj(derived.tweak, keys, childPk, tss.S256()); err != nil {
+		return keygen.LocalPartySaveData{}, nil, errors.Wrap(err, "failed to adjust key share for derivation")
+	}
+	return keys[0], derived.tweak, nil
+}
+
+// publicKey 以 PublicKey 形式返回子公钥
+func (d *DerivedPublicKey) publicKey() *PublicKey {
+	return &PublicKey{
+		Bytes: d.PublicKey,
+		Hex:   hex.EncodeToString(d.PublicKey),
+	}
+}
+
+// compressECPoint 将 secp256k1 点编码为 33 字节压缩格式
+func compressECPoint(p *crypto.ECPoint) []byte {
+	out := make([]byte, 33)
+	out[0] = 0x02
+	if p.Y().Bit(0) == 1 {
+		out[0] = 0x03
+	}
+	p.X().FillBytes(out[1:])
+	return out
+}
@@ -0,0 +1,171 @@
+package protocol
+
+import (
+	"context"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDerivationPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []uint32
+		wantErr string
+	}{
+		{name: "root", path: "m", want: []uint32{}},
+		{name: "non-hardened", path: "m/0/5", want: []uint32{0, 5}},
+		{name: "max non-hardened index", path: "m/2147483647", want: []uint32{2147483647}},
+		{name: "empty", path: "", wantErr: "empty"},
+		{name: "missing m", path: "0/1", wantErr: "must start with m/"},
+		{name: "hardened apostrophe", path: "m/44'/60'/0'/0/1", wantErr: "hardened derivation is not supported"},
+		{name: "hardened h", path: "m/0h", wantErr: "hardened derivation is not supported"},
+		{name: "hardened index value", path: "m/2147483648", wantErr: "hardened derivation is not supported"},
+		{name: "not a number", path: "m/abc", wantErr: "invalid derivation index"},
+		{name: "empty component", path: "m//1", wantErr: "invalid derivation index"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDerivationPath(tt.path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestDeriveChildPublicKey_BIP32Vector BIP-32 测试向量 2 中的非强化派生 m/0
+func TestDeriveChildPublicKey_BIP32Vector(t *testing.T) {
+	const masterXpub = "xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB"
+	const childXpub = "xpub69H7F5d8KSRgmmdJg2KhpAK8SR3DjMwAdkxj3ZuxV27CprR9LgpeyGmXUbC6wb7ERfvrnKZjXoUmmDznezpbZb7ap6r1D3tgFxHmwMkQTPH"
+
+	master, err := ckd.NewExtendedKeyFromString(masterXpub, tss.S256())
+	require.NoError(t, err)
+	masterPub, err := crypto.NewECPoint(tss.S256(), master.X, master.Y)
+	require.NoError(t, err)
+
+	root, err := DeriveChildPublicKey("secp256k1", compressECPoint(masterPub), master.ChainCode, "m")
+	require.NoError(t, err)
+	assert.Equal(t, masterXpub, root.ExtendedPublicKey)
+
+	child, err := DeriveChildPublicKey("secp256k1", compressECPoint(masterPub), master.ChainCode, "m/0")
+	require.NoError(t, err)
+	assert.Equal(t, childXpub, child.ExtendedPublicKey)
+	assert.Len(t, child.PublicKey, 33)
+}
+
+// TestDeriveChildPublicKey_TweakMatchesPrivateDerivation 子公钥等于 (根私钥 + tweak)·G
+func TestDeriveChildPublicKey_TweakMatchesPrivateDerivation(t *testing.T) {
+	ec := tss.S256()
+	secret := randomScalar(t)
+	rootPub := crypto.ScalarBaseMult(ec, secret)
+	chainCode, err := NewChainCode()
+	require.NoError(t, err)
+
+	derived, err := DeriveChildPublicKey("secp256k1", compressECPoint(rootPub), chainCode, "m/0/42")
+	require.NoError(t, err)
+
+	childSecret := new(big.Int).Mod(new(big.Int).Add(secret, derived.tweak), ec.Params().N)
+	assert.Equal(t, compressECPoint(crypto.ScalarBaseMult(ec, childSecret)), derived.PublicKey)
+
+	other, err := DeriveChildPublicKey("secp256k1", compressECPoint(rootPub), chainCode, "m/0/43")
+	require.NoError(t, err)
+	assert.NotEqual(t, derived.PublicKey, other.PublicKey)
+}
+
+func TestDeriveChildPublicKey_Rejects(t *testing.T) {
+	rootPub := compressECPoint(crypto.ScalarBaseMult(tss.S256(), big.NewInt(7)))
+	chainCode := make([]byte, ChainCodeSize)
+
+	_, err := DeriveChildPublicKey("ed25519", rootPub, chainCode, "m/0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only supported for secp256k1")
+
+	_, err = DeriveChildPublicKey("secp256k1", rootPub, chainCode[:16], "m/0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid chain code length")
+
+	_, err = DeriveChildPublicKey("secp256k1", rootPub, chainCode, "m/0'")
+	require.Error(t, err)
+}
+
+// TestApplyKeyDerivation ECDSA 分片数据按子密钥调整，原始数据不变
+func TestApplyKeyDerivation(t *testing.T) {
+	ec := tss.S256()
+	secret := randomScalar(t)
+	x1, x2 := randomScalar(t), randomScalar(t)
+	bigX1, bigX2 := crypto.ScalarBaseMult(ec, x1), crypto.ScalarBaseMult(ec, x2)
+	keyData := &keygen.LocalPartySaveData{}
+	keyData.ECDSAPub = crypto.ScalarBaseMult(ec, secret)
+	keyData.BigXj = []*crypto.ECPoint{bigX1, bigX2}
+
+	chainCode, err := NewChainCode()
+	require.NoError(t, err)
+	derived, err := DeriveChildPublicKey("secp256k1", compressECPoint(keyData.ECDSAPub), chainCode, "m/1/2")
+	require.NoError(t, err)
+
+	signingKey, delta, err := applyKeyDerivation(keyData, derived)
+	require.NoError(t, err)
+	assert.Equal(t, derived.tweak, delta)
+	assert.Equal(t, derived.PublicKey, compressECPoint(signingKey.ECDSAPub))
+	n := ec.Params().N
+	assert.True(t, signingKey.BigXj[0].Equals(crypto.ScalarBaseMult(ec, new(big.Int).Mod(new(big.Int).Add(x1, delta), n))))
+	assert.True(t, signingKey.BigXj[1].Equals(crypto.ScalarBaseMult(ec, new(big.Int).Mod(new(big.Int).Add(x2, delta), n))))
+
+	// 原始分片数据不能被修改
+	assert.True(t, keyData.BigXj[0].Equals(bigX1))
+	assert.True(t, keyData.ECDSAPub.Equals(crypto.ScalarBaseMult(ec, secret)))
+
+	unchanged, delta, err := applyKeyDerivation(keyData, nil)
+	require.NoError(t, err)
+	assert.Nil(t, delta)
+	assert.True(t, unchanged.ECDSAPub.Equals(keyData.ECDSAPub))
+}
+
+// TestFROSTProtocol_DerivedKeySigning FROST secp256k1 使用派生子密钥签名（含 Taproot）
+func TestFROSTProtocol_DerivedKeySigning(t *testing.T) {
+	c := newFROSTTestCluster("node-1", "node-2", "node-3")
+	keyID := "key-frost-hd"
+	publicKey := c.keygen(t, keyID, "secp256k1", 2, []string{"node-1", "node-2", "node-3"})
+	chainCode, err := NewChainCode()
+	require.NoError(t, err)
+
+	derived, err := DeriveChildPublicKey("secp256k1", publicKey.Bytes, chainCode, "m/0/7")
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte("frost derived key"))
+
+	resp := c.sign(t, "sign-hd-1", SignRequest{KeyID: keyID, Message: hash[:], NodeIDs: []string{"node-1", "node-3"}, DerivationPath: "m/0/7", ChainCode: chainCode})
+	assert.Equal(t, derived.PublicKey, resp.PublicKey.Bytes)
+	valid, err := verifySecp256k1SchnorrSignature(resp.Signature, hash[:], &PublicKey{Bytes: derived.PublicKey})
+	require.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = verifySecp256k1SchnorrSignature(resp.Signature, hash[:], publicKey)
+	require.NoError(t, err)
+	assert.False(t, valid, "signature must not verify against the root key")
+
+	// 派生在 Taproot 调整之前应用
+	resp = c.sign(t, "sign-hd-2", SignRequest{KeyID: keyID, Message: hash[:], NodeIDs: []string{"node-2", "node-3"}, DerivationPath: "m/0/7", ChainCode: chainCode, TaprootKeySpend: true})
+	outputKey, err := TaprootOutputKey(derived.PublicKey, nil)
+	require.NoError(t, err)
+	assert.Equal(t, outputKey, resp.PublicKey.Bytes)
+	valid, err = verifySecp256k1SchnorrSignature(resp.Signature, hash[:], &PublicKey{Bytes: outputKey})
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = c.nodes["node-1"].ThresholdSign(context.Background(), "sign-hd-3", &SignRequest{KeyID: keyID, Message: hash[:], NodeIDs: []string{"node-1", "node-2"}, DerivationPath: "m/0/7", ChainCode: chainCode, PresignatureID: "presign-x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "derivation path")
+}
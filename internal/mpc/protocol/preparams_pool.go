@@ -0,0 +1,269 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+)
+
+// preParamsStorageKeyID 预参数池在 KeyShareStorage 中使用的保留 keyID
+const preParamsStorageKeyID = "_preparams_pool"
+
+var (
+	preParamsMetricsOnce   sync.Once
+	preParamsPoolSizeGauge prometheus.Gauge
+	preParamsGenerateHist  prometheus.Histogram
+)
+
+func ensurePreParamsMetrics() {
+	preParamsMetricsOnce.Do(func() {
+		preParamsPoolSizeGauge = promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: "mpc",
+			Subsystem: "preparams",
+			Name:      "pool_size",
+			Help:      "Number of precomputed Paillier/safe-prime pre-parameters available for ECDSA keygen",
+		})
+		preParamsGenerateHist = promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "mpc",
+			Subsystem: "preparams",
+			Name:      "generation_duration_seconds",
+			Help:      "Time spent generating a single pre-parameters entry",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		})
+	})
+}
+
+// PreParamsPool ECDSA keygen 预参数池
+// 在后台预先生成 Paillier 密钥和安全素数（LocalPreParams），keygen 时直接取用，
+// 避免每次 DKG 都在线生成安全素数（通常需要数分钟）。
+// 池内容通过 KeyShareStorage 加密持久化，节点重启后可继续使用。
+type PreParamsPool struct {
+	nodeID          string
+	storage         KeyShareStorage
+	targetSize      int
+	generateTimeout time.Duration
+
+	// generate 生成单个预参数（测试中可替换）
+	generate func(ctx context.Context) (*keygen.LocalPreParams, error)
+
+	mu      sync.Mutex
+	entries []*keygen.LocalPreParams
+
+	refillCh chan struct{}
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewPreParamsPool 创建预参数池
+// targetSize 为 0 时池不会在后台生成任何预参数，keygen 回退为在线生成
+func NewPreParamsPool(nodeID string, storage KeyShareStorage, targetSize int, generateTimeout time.Duration) *PreParamsPool {
+	ensurePreParamsMetrics()
+
+	if generateTimeout <= 0 {
+		generateTimeout = 10 * time.Minute
+	}
+
+	return &PreParamsPool{
+		nodeID:          nodeID,
+		storage:         storage,
+		targetSize:      targetSize,
+		generateTimeout: generateTimeout,
+		generate: func(ctx context.Context) (*keygen.LocalPreParams, error) {
+			return keygen.GeneratePreParamsWithContext(ctx)
+		},
+		refillCh: make(chan struct{}, 1),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Run 加载已持久化的预参数并在后台补充到目标数量，直到 ctx 取消或调用 Stop
+func (p *PreParamsPool) Run(ctx context.Context) {
+	if p.targetSize <= 0 {
+		return
+	}
+
+	if err := p.load(ctx); err != nil {
+		log.Warn().
+			Err(err).
+			Str("node_id", p.nodeID).
+			Msg("Failed to load persisted pre-params, starting with empty pool")
+	}
+
+	p.triggerRefill()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		case <-p.refillCh:
+			p.refill(ctx)
+		}
+	}
+}
+
+// Stop 停止后台生成
+func (p *PreParamsPool) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+}
+
+// Take 从池中取出一个预参数，池为空时返回 false（调用方应回退为在线生成）
+func (p *PreParamsPool) Take(ctx context.Context) (*keygen.LocalPreParams, bool) {
+	p.mu.Lock()
+	if len(p.entries) == 0 {
+		p.mu.Unlock()
+		p.triggerRefill()
+		return nil, false
+	}
+	preParams := p.entries[0]
+	p.entries = p.entries[1:]
+	p.updateSizeMetricLocked()
+	err := p.persistLocked(ctx)
+	p.mu.Unlock()
+
+	if err != nil {
+		// 持久化失败不影响本次使用，但重启后该预参数可能被重复使用，记录错误便于排查
+		log.Error().
+			Err(err).
+			Str("node_id", p.nodeID).
+			Msg("Failed to persist pre-params pool after take")
+	}
+
+	p.triggerRefill()
+	return preParams, true
+}
+
+// Size 返回当前池中可用的预参数数量
+func (p *PreParamsPool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.entries)
+}
+
+func (p *PreParamsPool) triggerRefill() {
+	if p.targetSize <= 0 {
+		return
+	}
+	select {
+	case p.refillCh <- struct{}{}:
+	default:
+	}
+}
+
+// refill 逐个生成预参数直到达到目标数量
+func (p *PreParamsPool) refill(ctx context.Context) {
+	for p.Size() < p.targetSize {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		default:
+		}
+
+		genCtx, cancel := context.WithTimeout(ctx, p.generateTimeout)
+		start := time.Now()
+		preParams, err := p.generate(genCtx)
+		cancel()
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("node_id", p.nodeID).
+				Dur("elapsed", time.Since(start)).
+				Msg("Failed to generate pre-params")
+			return
+		}
+		elapsed := time.Since(start)
+		preParamsGenerateHist.Observe(elapsed.Seconds())
+
+		p.mu.Lock()
+		p.entries = append(p.entries, preParams)
+		p.updateSizeMetricLocked()
+		size := len(p.entries)
+		err = p.persistLocked(ctx)
+		p.mu.Unlock()
+
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("node_id", p.nodeID).
+				Msg("Failed to persist pre-params pool")
+		}
+
+		log.Info().
+			Str("node_id", p.nodeID).
+			Dur("elapsed", elapsed).
+			Int("pool_size", size).
+			Int("target_size", p.targetSize).
+			Msg("Generated pre-params entry")
+	}
+}
+
+// load 从 KeyShareStorage 恢复已持久化的预参数
+func (p *PreParamsPool) load(ctx context.Context) error {
+	if p.storage == nil {
+		return nil
+	}
+
+	data, err := p.storage.GetKeyData(ctx, preParamsStorageKeyID, p.nodeID)
+	if err != nil {
+		// 首次启动时没有持久化数据
+		return nil
+	}
+
+	var entries []*keygen.LocalPreParams
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return errors.Wrap(err, "failed to unmarshal persisted pre-params")
+	}
+
+	valid := make([]*keygen.LocalPreParams, 0, len(entries))
+	for _, entry := range entries {
+		if entry != nil && entry.ValidateWithProof() {
+			valid = append(valid, entry)
+		}
+	}
+
+	p.mu.Lock()
+	p.entries = append(p.entries, valid...)
+	p.updateSizeMetricLocked()
+	p.mu.Unlock()
+
+	log.Info().
+		Str("node_id", p.nodeID).
+		Int("loaded", len(valid)).
+		Int("discarded", len(entries)-len(valid)).
+		Msg("Loaded persisted pre-params")
+
+	return nil
+}
+
+// persistLocked 将当前池内容加密持久化（调用方需持有 p.mu）
+func (p *PreParamsPool) persistLocked(ctx context.Context) error {
+	if p.storage == nil {
+		return nil
+	}
+
+	if len(p.entries) == 0 {
+		return p.storage.DeleteKeyData(ctx, preParamsStorageKeyID, p.nodeID)
+	}
+
+	data, err := json.Marshal(p.entries)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal pre-params")
+	}
+	return p.storage.StoreKeyData(ctx, preParamsStorageKeyID, p.nodeID, data)
+}
+
+func (p *PreParamsPool) updateSizeMetricLocked() {
+	preParamsPoolSizeGauge.Set(float64(len(p.entries)))
+}
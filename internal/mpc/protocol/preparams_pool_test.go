@@ -0,0 +1,115 @@
+package protocol
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryKeyDataStorage 内存实现的 KeyShareStorage（仅测试使用）
+type memoryKeyDataStorage struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMemoryKeyDataStorage() *memoryKeyDataStorage {
+	return &memoryKeyDataStorage{data: make(map[string][]byte)}
+}
+
+func (s *memoryKeyDataStorage) StoreKeyData(ctx context.Context, keyID string, nodeID string, keyData []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[keyID+"/"+nodeID] = append([]byte(nil), keyData...)
+	return nil
+}
+
+func (s *memoryKeyDataStorage) GetKeyData(ctx context.Context, keyID string, nodeID string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.data[keyID+"/"+nodeID]
+	if !ok {
+		return nil, errors.New("key data not found")
+	}
+	return data, nil
+}
+
+func (s *memoryKeyDataStorage) DeleteKeyData(ctx context.Context, keyID string, nodeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, keyID+"/"+nodeID)
+	return nil
+}
+
+func fakePreParams(n int64) *keygen.LocalPreParams {
+	return &keygen.LocalPreParams{NTildei: big.NewInt(n)}
+}
+
+// TestPreParamsPool_RefillAndTake 测试后台补充与取用
+func TestPreParamsPool_RefillAndTake(t *testing.T) {
+	storage := newMemoryKeyDataStorage()
+	pool := NewPreParamsPool("node-1", storage, 2, time.Minute)
+
+	var counter int64
+	pool.generate = func(ctx context.Context) (*keygen.LocalPreParams, error) {
+		counter++
+		return fakePreParams(counter), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go pool.Run(ctx)
+
+	require.Eventually(t, func() bool { return pool.Size() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	preParams, ok := pool.Take(ctx)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), preParams.NTildei.Int64())
+
+	// 取用后应自动补充回目标数量
+	require.Eventually(t, func() bool { return pool.Size() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// 池内容应已持久化
+	_, err := storage.GetKeyData(ctx, preParamsStorageKeyID, "node-1")
+	require.NoError(t, err)
+
+	pool.Stop()
+}
+
+// TestPreParamsPool_Disabled 测试池大小为0时不生成预参数
+func TestPreParamsPool_Disabled(t *testing.T) {
+	pool := NewPreParamsPool("node-1", nil, 0, time.Minute)
+	pool.generate = func(ctx context.Context) (*keygen.LocalPreParams, error) {
+		t.Fatal("generate should not be called when pool is disabled")
+		return nil, nil
+	}
+
+	pool.Run(context.Background())
+
+	_, ok := pool.Take(context.Background())
+	assert.False(t, ok)
+	assert.Equal(t, 0, pool.Size())
+}
+
+// TestTSSPartyManager_TakePreParams 测试 tssPartyManager 从池中取预参数
+func TestTSSPartyManager_TakePreParams(t *testing.T) {
+	manager := newTSSPartyManager(mockMessageRouter)
+
+	_, ok := manager.takePreParams(context.Background())
+	assert.False(t, ok, "no pool configured")
+
+	pool := NewPreParamsPool("node-1", nil, 1, time.Minute)
+	pool.entries = append(pool.entries, fakePreParams(7))
+	manager.preParamsPool = pool
+
+	preParams, ok := manager.takePreParams(context.Background())
+	require.True(t, ok)
+	assert.Equal(t, int64(7), preParams.NTildei.Int64())
+	assert.Equal(t, 0, pool.Size())
+}
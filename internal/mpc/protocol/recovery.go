@@ -0,0 +1,111 @@
+package protocol
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/pkg/errors"
+)
+
+// RecoveredKey 由门限数量的分片重建出的完整私钥（仅用于离线灾难恢复）
+type RecoveredKey struct {
+	KeyID      string
+	ShareEpoch int
+	Curve      string // secp256k1 或 ed25519
+	// PrivateKey 私钥标量（secp256k1 为 32 字节 big-endian；ed25519 为 32 字节 little-endian 标量，不是 RFC 8032 种子）
+	PrivateKey []byte
+	PublicKey  []byte // 私钥对应的公钥，与 DKG 生成的群公钥编码一致
+	NodeIDs    []string
+}
+
+// RecoverPrivateKey 使用解密后的分片备份重建私钥
+// 分片优先从备份中的原始密钥数据（tss-lib LocalPartySaveData 的 Xi/ShareID、FROST 或 CGGMP21 分片）解析，
+// 以分片标识符计算 Lagrange 系数在 0 处插值；结果必须与 expectedPublicKey 一致，否则说明分片不足或被篡改
+func RecoverPrivateKey(plaintexts []*ShareBackupPlaintext, expectedPublicKey []byte) (*RecoveredKey, error) {
+	if len(plaintexts) == 0 {
+		return nil, errors.New("no share backups")
+	}
+	if len(expectedPublicKey) == 0 {
+		return nil, errors.New("expected public key is required")
+	}
+
+	first := plaintexts[0]
+	cs, err := getFROSTCiphersuite(first.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers := make([]*big.Int, 0, len(plaintexts))
+	secrets := make([]*big.Int, 0, len(plaintexts))
+	nodeIDs := make([]string, 0, len(plaintexts))
+	seen := make(map[string]string, len(plaintexts))
+	for _, p := range plaintexts {
+		if p.KeyID != first.KeyID || p.ShareEpoch != first.ShareEpoch || p.Curve != first.Curve {
+			return nil, errors.Errorf("share of node %s belongs to a different key or share epoch", p.NodeID)
+		}
+		identifier, secret, err := plaintextShare(cs, p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid share of node %s", p.NodeID)
+		}
+		if other, ok := seen[identifier.String()]; ok {
+			return nil, errors.Errorf("nodes %s and %s have the same share identifier", other, p.NodeID)
+		}
+		seen[identifier.String()] = p.NodeID
+		identifiers = append(identifiers, identifier)
+		secrets = append(secrets, secret)
+		nodeIDs = append(nodeIDs, p.NodeID)
+	}
+
+	modN := common.ModInt(cs.order())
+	secret := big.NewInt(0)
+	for i, identifier := range identifiers {
+		lambda, err := cs.lagrangeCoefficient(identifier, identifiers)
+		if err != nil {
+			return nil, err
+		}
+		secret = modN.Add(secret, modN.Mul(lambda, secrets[i]))
+	}
+	if secret.Sign() == 0 {
+		return nil, errors.New("reconstructed private key is zero")
+	}
+
+	publicKey := cs.serializeElement(cs.scalarBaseMult(secret))
+	if string(publicKey) != string(expectedPublicKey) {
+		return nil, errors.New("reconstructed private key does not match the public key (not enough shares, or shares from different keys)")
+	}
+
+	sort.Strings(nodeIDs)
+	return &RecoveredKey{
+		KeyID:      first.KeyID,
+		ShareEpoch: first.ShareEpoch,
+		Curve:      cs.name,
+		PrivateKey: cs.serializeScalar(secret),
+		PublicKey:  publicKey,
+		NodeIDs:    nodeIDs,
+	}, nil
+}
+
+// plaintextShare 取出备份中的分片标识符和分片值；备份包含原始密钥数据时以其为准，并要求与 SecretShare 一致
+func plaintextShare(cs *frostCiphersuite, p *ShareBackupPlaintext) (*big.Int, *big.Int, error) {
+	identifier, err := cs.deserializeScalar(p.Identifier)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid identifier")
+	}
+	secret, err := cs.deserializeScalar(p.SecretShare)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid secret share")
+	}
+	if len(p.KeyData) == 0 {
+		return identifier, secret, nil
+	}
+
+	share, err := parseKeyShareSecret(p.KeyData, p.NodeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if share.cs != cs || share.identifier.Cmp(identifier) != 0 || share.secret.Cmp(secret) != 0 {
+		return nil, nil, errors.New("key data does not match the backed up share")
+	}
+	return share.identifier, share.secret, nil
+}
@@ -0,0 +1,135 @@
+package protocol
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecoverPrivateKey_FROST 门限数量的解密备份可以重建私钥，不足门限、公钥不符或混用密钥时失败
+func TestRecoverPrivateKey_FROST(t *testing.T) {
+	for _, curve := range []string{"ed25519", "secp256k1"} {
+		t.Run(curve, func(t *testing.T) {
+			nodeIDs := []string{"node-1", "node-2", "node-3"}
+			c := newFROSTTestCluster(nodeIDs...)
+			publicKey := c.keygen(t, "key-recover", curve, 2, nodeIDs)
+			otherPublicKey := c.keygen(t, "key-other", curve, 2, nodeIDs)
+			recovery := newRecoveryKey(t)
+
+			plaintext := func(keyID, nodeID string) *ShareBackupPlaintext {
+				keyData, err := c.storage.GetKeyData(context.Background(), keyID, nodeID)
+				require.NoError(t, err)
+				backup, err := NewShareBackup(keyID, nodeID, 0, keyData, recovery.PublicKey().Bytes())
+				require.NoError(t, err)
+				p, err := backup.Decrypt(recovery.Bytes())
+				require.NoError(t, err)
+				return p
+			}
+
+			recovered, err := RecoverPrivateKey([]*ShareBackupPlaintext{
+				plaintext("key-recover", "node-3"),
+				plaintext("key-recover", "node-1"),
+			}, publicKey.Bytes)
+			require.NoError(t, err)
+			assert.Equal(t, "key-recover", recovered.KeyID)
+			assert.Equal(t, curve, recovered.Curve)
+			assert.Equal(t, publicKey.Bytes, recovered.PublicKey)
+			assert.Equal(t, []string{"node-1", "node-3"}, recovered.NodeIDs)
+
+			cs, err := getFROSTCiphersuite(curve)
+			require.NoError(t, err)
+			secret, err := cs.deserializeScalar(recovered.PrivateKey)
+			require.NoError(t, err)
+			assert.Equal(t, publicKey.Bytes, cs.serializeElement(cs.scalarBaseMult(secret)))
+
+			// 所有分片一起参与插值结果相同
+			all, err := RecoverPrivateKey([]*ShareBackupPlaintext{
+				plaintext("key-recover", "node-1"),
+				plaintext("key-recover", "node-2"),
+				plaintext("key-recover", "node-3"),
+			}, publicKey.Bytes)
+			require.NoError(t, err)
+			assert.Equal(t, recovered.PrivateKey, all.PrivateKey)
+
+			// 不足门限
+			_, err = RecoverPrivateKey([]*ShareBackupPlaintext{plaintext("key-recover", "node-1")}, publicKey.Bytes)
+			assert.Error(t, err)
+
+			// 公钥不符
+			_, err = RecoverPrivateKey([]*ShareBackupPlaintext{
+				plaintext("key-recover", "node-1"),
+				plaintext("key-recover", "node-2"),
+			}, otherPublicKey.Bytes)
+			assert.Error(t, err)
+
+			// 混用不同密钥的分片
+			_, err = RecoverPrivateKey([]*ShareBackupPlaintext{
+				plaintext("key-recover", "node-1"),
+				plaintext("key-other", "node-2"),
+			}, publicKey.Bytes)
+			assert.Error(t, err)
+
+			// 重复分片
+			_, err = RecoverPrivateKey([]*ShareBackupPlaintext{
+				plaintext("key-recover", "node-1"),
+				plaintext("key-recover", "node-1"),
+			}, publicKey.Bytes)
+			assert.Error(t, err)
+
+			// 明文中的 SecretShare 与原始密钥数据不一致
+			tampered := plaintext("key-recover", "node-2")
+			tampered.SecretShare = cs.serializeScalar(big.NewInt(7))
+			_, err = RecoverPrivateKey([]*ShareBackupPlaintext{plaintext("key-recover", "node-1"), tampered}, publicKey.Bytes)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestRecoverPrivateKey_TSSKeyData 以 tss-lib LocalPartySaveData 的 ShareID/Xi 计算 Lagrange 系数重建私钥
+func TestRecoverPrivateKey_TSSKeyData(t *testing.T) {
+	cs := frostEd25519Suite
+	modN := common.ModInt(cs.order())
+	a0, err := cs.randomScalar()
+	require.NoError(t, err)
+	a1, err := cs.randomScalar()
+	require.NoError(t, err)
+	groupPublicKey := cs.scalarBaseMult(a0)
+
+	shareIDs := []*big.Int{big.NewInt(21), big.NewInt(22), big.NewInt(23)}
+	bigXj := make([]*crypto.ECPoint, len(shareIDs))
+	shares := make([]*big.Int, len(shareIDs))
+	for i, shareID := range shareIDs {
+		shares[i] = modN.Add(a0, modN.Mul(a1, shareID))
+		bigXj[i] = cs.scalarBaseMult(shares[i])
+	}
+
+	plaintexts := make([]*ShareBackupPlaintext, 0, len(shareIDs))
+	for i, nodeID := range []string{"node-1", "node-2", "node-3"} {
+		saveData := keygen.NewLocalPartySaveData(3)
+		saveData.ShareID = shareIDs[i]
+		saveData.Xi = shares[i]
+		saveData.Ks = shareIDs
+		saveData.BigXj = bigXj
+		saveData.EDDSAPub = groupPublicKey
+		keyData, err := serializeEdDSALocalPartySaveData(&saveData)
+		require.NoError(t, err)
+
+		p, err := ShareFromKeyData("key-tss", nodeID, 2, keyData)
+		require.NoError(t, err)
+		plaintexts = append(plaintexts, p)
+	}
+
+	recovered, err := RecoverPrivateKey(plaintexts[:2], cs.serializeElement(groupPublicKey))
+	require.NoError(t, err)
+	assert.Equal(t, 2, recovered.ShareEpoch)
+	assert.Equal(t, cs.serializeScalar(a0), recovered.PrivateKey)
+
+	_, err = RecoverPrivateKey(plaintexts[2:], cs.serializeElement(groupPublicKey))
+	assert.Error(t, err)
+}
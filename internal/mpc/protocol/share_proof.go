@@ -0,0 +1,264 @@
+package protocol
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"sort"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/pkg/errors"
+)
+
+// shareProofInfo 分片持有证明挑战值的域分隔标签
+const shareProofInfo = "mpc-share-proof/v1"
+
+// ShareProofChallengeSize 协调者随机挑战值的长度
+const ShareProofChallengeSize = 32
+
+// ShareProof 节点对所持分片的持有证明
+// ProofCommitment/ProofResponse 是对分片 secret 的 Schnorr 知识证明，挑战值绑定协调者的随机挑战 Challenge，
+// 防止重放旧证明；PublicShares 是节点本地保存的委员会公开分片向量（tss-lib 的 Ks/BigXj，FROST 的 verifying shares）
+type ShareProof struct {
+	KeyID           string              `json:"key_id"`
+	NodeID          string              `json:"node_id"`
+	ShareEpoch      int                 `json:"share_epoch"`
+	Curve           string              `json:"curve"`
+	Identifier      []byte              `json:"identifier"`
+	PublicShare     []byte              `json:"public_share"`
+	GroupPublicKey  []byte              `json:"group_public_key"`
+	PublicShares    []*PublicShareEntry `json:"public_shares"`
+	Challenge       []byte              `json:"challenge"`
+	ProofCommitment []byte              `json:"proof_commitment"`
+	ProofResponse   []byte              `json:"proof_response"`
+}
+
+// PublicShareEntry 公开分片向量中的一项
+type PublicShareEntry struct {
+	Identifier  []byte `json:"identifier"`
+	PublicShare []byte `json:"public_share"`
+}
+
+// NewShareProof 使用节点存储的密钥数据生成对协调者挑战的分片持有证明
+func NewShareProof(keyID, nodeID string, shareEpoch int, keyData []byte, challenge []byte) (*ShareProof, error) {
+	if len(challenge) != ShareProofChallengeSize {
+		return nil, errors.Errorf("invalid challenge length: %d", len(challenge))
+	}
+	share, err := parseKeyShareSecret(keyData, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := share.cs
+	proof := &ShareProof{
+		KeyID:          keyID,
+		NodeID:         nodeID,
+		ShareEpoch:     shareEpoch,
+		Curve:          cs.name,
+		Identifier:     cs.serializeScalar(share.identifier),
+		PublicShare:    cs.serializeElement(share.publicShare),
+		GroupPublicKey: cs.serializeElement(share.groupPublicKey),
+		PublicShares:   make([]*PublicShareEntry, 0, len(share.publicShares)),
+		Challenge:      challenge,
+	}
+	for _, entry := range share.publicShares {
+		proof.PublicShares = append(proof.PublicShares, &PublicShareEntry{
+			Identifier:  cs.serializeScalar(entry.identifier),
+			PublicShare: cs.serializeElement(entry.point),
+		})
+	}
+
+	// Schnorr 证明：R = k·G，s = k + c·secret
+	k, err := cs.randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	proof.ProofCommitment = cs.serializeElement(cs.scalarBaseMult(k))
+	c := proof.challenge(cs)
+	modN := common.ModInt(cs.order())
+	proof.ProofResponse = cs.serializeScalar(modN.Add(k, modN.Mul(c, share.secret)))
+	return proof, nil
+}
+
+// Verify 校验持有证明是对 challenge 的响应，且证明与节点的公开分片 PublicShare = secret·G 一致
+func (p *ShareProof) Verify(challenge []byte) error {
+	if p.KeyID == "" || p.NodeID == "" {
+		return errors.New("share proof has no key or node ID")
+	}
+	if string(p.Challenge) != string(challenge) {
+		return errors.New("share proof answers a different challenge")
+	}
+	cs, err := getFROSTCiphersuite(p.Curve)
+	if err != nil {
+		return err
+	}
+	if _, err := cs.deserializeScalar(p.Identifier); err != nil {
+		return errors.Wrap(err, "invalid identifier")
+	}
+	publicShare, err := cs.deserializeElement(p.PublicShare)
+	if err != nil {
+		return errors.Wrap(err, "invalid public share")
+	}
+	if _, err := cs.deserializeElement(p.GroupPublicKey); err != nil {
+		return errors.Wrap(err, "invalid group public key")
+	}
+	commitment, err := cs.deserializeElement(p.ProofCommitment)
+	if err != nil {
+		return errors.Wrap(err, "invalid proof commitment")
+	}
+	s, err := cs.deserializeScalar(p.ProofResponse)
+	if err != nil {
+		return errors.Wrap(err, "invalid proof response")
+	}
+	// s·G == R + c·PublicShare
+	expected, err := cs.addPoints(commitment, cs.scalarMult(publicShare, p.challenge(cs)))
+	if err != nil {
+		return errors.Wrap(err, "invalid proof")
+	}
+	if !cs.scalarBaseMult(s).Equals(expected) {
+		return errors.New("share proof does not match its public share")
+	}
+	return nil
+}
+
+// PublicShareVectorDigest 公开分片向量的摘要，用于比较各节点保存的向量是否一致
+func (p *ShareProof) PublicShareVectorDigest() []byte {
+	h := sha256.New()
+	for _, entry := range p.PublicShares {
+		writeLengthPrefixed(h, entry.Identifier)
+		writeLengthPrefixed(h, entry.PublicShare)
+	}
+	return h.Sum(nil)
+}
+
+// publicShareOf 公开分片向量中 identifier 对应的公开分片
+func (p *ShareProof) publicShareOf(identifier []byte) ([]byte, bool) {
+	for _, entry := range p.PublicShares {
+		if string(entry.Identifier) == string(identifier) {
+			return entry.PublicShare, true
+		}
+	}
+	return nil, false
+}
+
+// challenge 证明的挑战值 c = H(header || Challenge || H(PublicShares) || PublicShare || R)
+func (p *ShareProof) challenge(cs *frostCiphersuite) *big.Int {
+	h := sha256.New()
+	var epoch [8]byte
+	binary.BigEndian.PutUint64(epoch[:], uint64(p.ShareEpoch))
+	writeLengthPrefixed(h, []byte(shareProofInfo))
+	writeLengthPrefixed(h, []byte(p.KeyID))
+	writeLengthPrefixed(h, []byte(p.NodeID))
+	writeLengthPrefixed(h, epoch[:])
+	writeLengthPrefixed(h, []byte(p.Curve))
+	writeLengthPrefixed(h, p.Identifier)
+	writeLengthPrefixed(h, p.GroupPublicKey)
+	return cs.hashToScalar("share-proof", h.Sum(nil), p.Challenge, p.PublicShareVectorDigest(), p.PublicShare, p.ProofCommitment)
+}
+
+// ShareProofCheck 一组持有证明的一致性检查结果
+type ShareProofCheck struct {
+	// ConsistentNodeIDs 证明有效、公开分片向量与多数一致、自身公开分片与向量一致的节点
+	ConsistentNodeIDs []string
+	// InconsistentNodeIDs 证明有效但公开分片或向量与其他节点不一致的节点（分片可能已损坏）
+	InconsistentNodeIDs []string
+	// VectorInterpolates 一致节点共同保存的公开分片向量在 0 处插值得到群公钥
+	VectorInterpolates bool
+	// Recoverable 一致节点的公开分片插值得到群公钥，即这些节点的分片足以签名和重建私钥
+	Recoverable bool
+}
+
+// CheckShareProofs 检查同一密钥同一轮次的持有证明（调用前应已通过 Verify）：
+// 取多数节点保存的公开分片向量为参照，向量必须插值得到 groupPublicKey；
+// 节点自身的公开分片必须与参照向量中对应项一致，一致节点的公开分片插值必须得到群公钥
+func CheckShareProofs(proofs []*ShareProof, groupPublicKey []byte) (*ShareProofCheck, error) {
+	check := &ShareProofCheck{}
+	if len(proofs) == 0 {
+		return check, nil
+	}
+	cs, err := getFROSTCiphersuite(proofs[0].Curve)
+	if err != nil {
+		return nil, err
+	}
+	groupPoint, err := cs.deserializeElement(groupPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid group public key")
+	}
+
+	// 按向量摘要分组，取节点数最多的向量为参照（相同数量时取摘要较小者，保证结果确定）
+	groups := make(map[string][]*ShareProof)
+	for _, proof := range proofs {
+		digest := string(proof.PublicShareVectorDigest())
+		groups[digest] = append(groups[digest], proof)
+	}
+	digests := make([]string, 0, len(groups))
+	for digest := range groups {
+		digests = append(digests, digest)
+	}
+	sort.Slice(digests, func(i, j int) bool {
+		if len(groups[digests[i]]) != len(groups[digests[j]]) {
+			return len(groups[digests[i]]) > len(groups[digests[j]])
+		}
+		return digests[i] < digests[j]
+	})
+	reference := groups[digests[0]][0]
+	check.VectorInterpolates = vectorInterpolates(cs, reference.PublicShares, groupPoint)
+
+	identifiers := make([]*big.Int, 0, len(proofs))
+	publicShares := make([]*crypto.ECPoint, 0, len(proofs))
+	seen := make(map[string]bool, len(proofs))
+	for _, proof := range proofs {
+		expected, ok := reference.publicShareOf(proof.Identifier)
+		consistent := ok && check.VectorInterpolates &&
+			proof.Curve == reference.Curve &&
+			string(proof.GroupPublicKey) == string(groupPublicKey) &&
+			string(proof.PublicShareVectorDigest()) == digests[0] &&
+			string(proof.PublicShare) == string(expected) &&
+			!seen[string(proof.Identifier)]
+		if !consistent {
+			check.InconsistentNodeIDs = append(check.InconsistentNodeIDs, proof.NodeID)
+			continue
+		}
+		seen[string(proof.Identifier)] = true
+		identifier, err := cs.deserializeScalar(proof.Identifier)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid identifier of node %s", proof.NodeID)
+		}
+		publicShare, err := cs.deserializeElement(proof.PublicShare)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public share of node %s", proof.NodeID)
+		}
+		check.ConsistentNodeIDs = append(check.ConsistentNodeIDs, proof.NodeID)
+		identifiers = append(identifiers, identifier)
+		publicShares = append(publicShares, publicShare)
+	}
+	sort.Strings(check.ConsistentNodeIDs)
+	sort.Strings(check.InconsistentNodeIDs)
+
+	if len(identifiers) > 0 {
+		interpolated, err := interpolatePublicShares(cs, identifiers, publicShares)
+		check.Recoverable = err == nil && interpolated.Equals(groupPoint)
+	}
+	return check, nil
+}
+
+// vectorInterpolates 公开分片向量在 0 处插值是否得到群公钥
+func vectorInterpolates(cs *frostCiphersuite, entries []*PublicShareEntry, groupPublicKey *crypto.ECPoint) bool {
+	identifiers := make([]*big.Int, 0, len(entries))
+	points := make([]*crypto.ECPoint, 0, len(entries))
+	for _, entry := range entries {
+		identifier, err := cs.deserializeScalar(entry.Identifier)
+		if err != nil {
+			return false
+		}
+		point, err := cs.deserializeElement(entry.PublicShare)
+		if err != nil {
+			return false
+		}
+		identifiers = append(identifiers, identifier)
+		points = append(points, point)
+	}
+	interpolated, err := interpolatePublicShares(cs, identifiers, points)
+	return err == nil && interpolated.Equals(groupPublicKey)
+}
@@ -0,0 +1,136 @@
+package protocol
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newShareProofChallenge(t *testing.T) []byte {
+	t.Helper()
+	challenge := make([]byte, ShareProofChallengeSize)
+	_, err := rand.Read(challenge)
+	require.NoError(t, err)
+	return challenge
+}
+
+// TestShareProof_FROST 所有节点分片一致时全部通过；某节点保存的公开分片向量被篡改时只有该节点不一致
+func TestShareProof_FROST(t *testing.T) {
+	for _, curve := range []string{"ed25519", "secp256k1"} {
+		t.Run(curve, func(t *testing.T) {
+			nodeIDs := []string{"node-1", "node-2", "node-3"}
+			c := newFROSTTestCluster(nodeIDs...)
+			keyID := "key-proof-" + curve
+			publicKey := c.keygen(t, keyID, curve, 2, nodeIDs)
+			challenge := newShareProofChallenge(t)
+
+			proofs := make([]*ShareProof, 0, len(nodeIDs))
+			for _, nodeID := range nodeIDs {
+				keyData, err := c.storage.GetKeyData(context.Background(), keyID, nodeID)
+				require.NoError(t, err)
+				proof, err := NewShareProof(keyID, nodeID, 0, keyData, challenge)
+				require.NoError(t, err)
+				require.NoError(t, proof.Verify(challenge))
+				assert.Len(t, proof.PublicShares, len(nodeIDs))
+				proofs = append(proofs, proof)
+			}
+
+			// 证明不能用于其他挑战
+			assert.Error(t, proofs[0].Verify(newShareProofChallenge(t)))
+
+			check, err := CheckShareProofs(proofs, publicKey.Bytes)
+			require.NoError(t, err)
+			assert.Equal(t, nodeIDs, check.ConsistentNodeIDs)
+			assert.Empty(t, check.InconsistentNodeIDs)
+			assert.True(t, check.VectorInterpolates)
+			assert.True(t, check.Recoverable)
+
+			// node-3 保存的 node-1 公开分片被篡改
+			keyData, err := c.storage.GetKeyData(context.Background(), keyID, "node-3")
+			require.NoError(t, err)
+			var data frostKeyData
+			require.NoError(t, json.Unmarshal(keyData, &data))
+			cs, err := getFROSTCiphersuite(curve)
+			require.NoError(t, err)
+			data.VerifyingShares["node-1"] = cs.serializeElement(cs.scalarBaseMult(big.NewInt(5)))
+			corrupted, err := json.Marshal(&data)
+			require.NoError(t, err)
+			proof, err := NewShareProof(keyID, "node-3", 0, corrupted, challenge)
+			require.NoError(t, err)
+			require.NoError(t, proof.Verify(challenge), "the proof of possession itself is still valid")
+
+			check, err = CheckShareProofs([]*ShareProof{proofs[0], proofs[1], proof}, publicKey.Bytes)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"node-1", "node-2"}, check.ConsistentNodeIDs)
+			assert.Equal(t, []string{"node-3"}, check.InconsistentNodeIDs)
+			assert.True(t, check.Recoverable, "two consistent shares still reach the threshold")
+
+			// 只剩一个一致节点时不足门限
+			check, err = CheckShareProofs([]*ShareProof{proofs[0], proof}, publicKey.Bytes)
+			require.NoError(t, err)
+			assert.False(t, check.Recoverable)
+
+			// 群公钥不符
+			check, err = CheckShareProofs(proofs, cs.serializeElement(cs.scalarBaseMult(big.NewInt(7))))
+			require.NoError(t, err)
+			assert.False(t, check.VectorInterpolates)
+			assert.Empty(t, check.ConsistentNodeIDs)
+			assert.False(t, check.Recoverable)
+		})
+	}
+}
+
+// TestShareProof_TSSKeyData tss-lib LocalPartySaveData 的 Xi 损坏时，节点公开分片与其他节点保存的 BigXj 不一致
+func TestShareProof_TSSKeyData(t *testing.T) {
+	cs := frostEd25519Suite
+	modN := common.ModInt(cs.order())
+	a0, err := cs.randomScalar()
+	require.NoError(t, err)
+	a1, err := cs.randomScalar()
+	require.NoError(t, err)
+	groupPublicKey := cs.scalarBaseMult(a0)
+	challenge := newShareProofChallenge(t)
+
+	shareIDs := []*big.Int{big.NewInt(31), big.NewInt(32), big.NewInt(33)}
+	shares := make([]*big.Int, len(shareIDs))
+	bigXj := make([]*crypto.ECPoint, len(shareIDs))
+	for i, shareID := range shareIDs {
+		shares[i] = modN.Add(a0, modN.Mul(a1, shareID))
+		bigXj[i] = cs.scalarBaseMult(shares[i])
+	}
+
+	proofs := make([]*ShareProof, 0, len(shareIDs))
+	for i, nodeID := range []string{"node-1", "node-2", "node-3"} {
+		saveData := keygen.NewLocalPartySaveData(3)
+		saveData.ShareID = shareIDs[i]
+		saveData.Xi = shares[i]
+		if nodeID == "node-3" {
+			saveData.Xi = modN.Add(shares[i], big.NewInt(1))
+		}
+		saveData.Ks = shareIDs
+		saveData.BigXj = bigXj
+		saveData.EDDSAPub = groupPublicKey
+		keyData, err := serializeEdDSALocalPartySaveData(&saveData)
+		require.NoError(t, err)
+
+		proof, err := NewShareProof("key-tss", nodeID, 1, keyData, challenge)
+		require.NoError(t, err)
+		require.NoError(t, proof.Verify(challenge))
+		proofs = append(proofs, proof)
+	}
+
+	check, err := CheckShareProofs(proofs, cs.serializeElement(groupPublicKey))
+	require.NoError(t, err)
+	assert.True(t, check.VectorInterpolates)
+	assert.Equal(t, []string{"node-1", "node-2"}, check.ConsistentNodeIDs)
+	assert.Equal(t, []string{"node-3"}, check.InconsistentNodeIDs)
+	assert.True(t, check.Recoverable)
+}
@@ -0,0 +1,230 @@
+package protocol
+
+import (
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/pkg/errors"
+)
+
+// 签名输出格式
+const (
+	// SignatureFormatDER ECDSA DER 编码（比特币 legacy/SegWit 见证中的签名去掉 sighash 字节）
+	SignatureFormatDER = "der"
+	// SignatureFormatDERSighash DER || sighash 字节，可直接放入比特币 scriptSig/witness
+	SignatureFormatDERSighash = "der_sighash"
+	// SignatureFormatCompact 64 字节 r || s
+	SignatureFormatCompact = "compact"
+	// SignatureFormatRSV 65 字节 r || s || v，v = 27 + recovery id（以太坊 personal_sign/eth_sign 约定）
+	SignatureFormatRSV = "rsv"
+	// SignatureFormatRaw EdDSA/Schnorr 签名原样输出（Ed25519 为 RFC 8032 的 R || S，BIP-340 为 64 字节）
+	SignatureFormatRaw = "raw"
+)
+
+// SighashAll 比特币 SIGHASH_ALL，der_sighash 格式未指定 sighash 时使用
+const SighashAll byte = 0x01
+
+// ecdsaRecoveryIDOffset 以太坊 v 值相对 recovery id 的偏移
+const ecdsaRecoveryIDOffset = 27
+
+// EncodedSignature 按指定格式编码后的签名
+type EncodedSignature struct {
+	Format string
+	Bytes  []byte
+	// R/S 签名分量（32 字节 big-endian；EdDSA/Schnorr 为签名的前后 32 字节）
+	R []byte
+	S []byte
+	// RecoveryID ECDSA 公钥恢复标识（0-3），EdDSA/Schnorr 为 -1
+	RecoveryID int
+	// V 以太坊 v 值（27 + RecoveryID），EdDSA/Schnorr 为 0
+	V int
+}
+
+// ECDSADigest ECDSA 引擎实际签名的摘要：所有引擎都对消息做 SHA-256 后签名，
+// 预哈希请求（Prehashed，消息已是 32 字节摘要）直接签名消息本身
+func ECDSADigest(message []byte, prehashed bool) []byte {
+	if prehashed {
+		return append([]byte(nil), message...)
+	}
+	digest := sha256.Sum256(message)
+	return digest[:]
+}
+
+// ValidateSignatureFormat 检查签名格式是否适用于签名算法
+func ValidateSignatureFormat(format, algorithm string) error {
+	switch format {
+	case SignatureFormatDER, SignatureFormatDERSighash, SignatureFormatCompact, SignatureFormatRSV:
+		if !strings.EqualFold(algorithm, "ecdsa") {
+			return errors.Errorf("signature format %s requires an ECDSA key, got %s", format, algorithm)
+		}
+		return nil
+	case SignatureFormatRaw:
+		if strings.EqualFold(algorithm, "ecdsa") {
+			return errors.Errorf("signature format %s is not available for ECDSA keys", format)
+		}
+		return nil
+	default:
+		return errors.Errorf("unsupported signature format %q", format)
+	}
+}
+
+// EncodeECDSASignature 把协议返回的签名（DER、r||s 或 r||s||v）编码为指定格式，digest 为实际签名的摘要（见 ECDSADigest）
+// S 规范化为 low-S，recovery id 通过公钥恢复确定，因此输出与签名引擎无关
+func EncodeECDSASignature(format string, signature, digest, publicKey []byte, sighashType byte) (*EncodedSignature, error) {
+	r, s, err := ParseECDSASignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	s = normalizeLowS(s)
+
+	recoveryID, err := ECDSARecoveryID(digest, r, s, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rBytes := padScalarBytes(r.Bytes())
+	sBytes := padScalarBytes(s.Bytes())
+	encoded := &EncodedSignature{
+		Format:     format,
+		R:          rBytes,
+		S:          sBytes,
+		RecoveryID: recoveryID,
+		V:          ecdsaRecoveryIDOffset + recoveryID,
+	}
+
+	switch format {
+	case SignatureFormatDER:
+		encoded.Bytes = buildDERSignature(rBytes, sBytes)
+	case SignatureFormatDERSighash:
+		if sighashType == 0 {
+			sighashType = SighashAll
+		}
+		encoded.Bytes = append(buildDERSignature(rBytes, sBytes), sighashType)
+	case SignatureFormatCompact:
+		encoded.Bytes = append(append([]byte(nil), rBytes...), sBytes...)
+	case SignatureFormatRSV:
+		encoded.Bytes = append(append(append([]byte(nil), rBytes...), sBytes...), byte(encoded.V))
+	default:
+		return nil, errors.Errorf("unsupported ECDSA signature format %q", format)
+	}
+	return encoded, nil
+}
+
+// EncodeRawSignature EdDSA/Schnorr 签名只有原始格式，R/S 为签名的前后 32 字节
+func EncodeRawSignature(signature []byte) (*EncodedSignature, error) {
+	if len(signature) != 64 {
+		return nil, errors.Errorf("invalid signature length: expected 64 bytes, got %d", len(signature))
+	}
+	return &EncodedSignature{
+		Format:     SignatureFormatRaw,
+		Bytes:      append([]byte(nil), signature...),
+		R:          append([]byte(nil), signature[:32]...),
+		S:          append([]byte(nil), signature[32:]...),
+		RecoveryID: -1,
+	}, nil
+}
+
+// ParseECDSASignature 解析 DER、DER || sighash、64 字节 r || s 或 65 字节 r || s || v 格式的 ECDSA 签名
+func ParseECDSASignature(signature []byte) (*big.Int, *big.Int, error) {
+	if der, ok := derPayload(signature); ok {
+		// 先按 secp256k1 的严格 DER 规则校验，再取出 r/s
+		if _, err := ecdsa.ParseDERSignature(der); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid DER signature")
+		}
+		var parsed struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(der, &parsed); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid DER signature")
+		}
+		return parsed.R, parsed.S, nil
+	}
+	if len(signature) != 64 && len(signature) != 65 {
+		return nil, nil, errors.Errorf("unrecognized ECDSA signature encoding (%d bytes)", len(signature))
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	if err := checkECDSAScalars(r, s); err != nil {
+		return nil, nil, err
+	}
+	return r, s, nil
+}
+
+// ECDSASignatureFromEncoding 把任意支持的 ECDSA 签名编码转换为引擎验证使用的 DER 签名
+func ECDSASignatureFromEncoding(signature []byte) (*Signature, error) {
+	r, s, err := ParseECDSASignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	rBytes := padScalarBytes(r.Bytes())
+	sBytes := padScalarBytes(s.Bytes())
+	der := buildDERSignature(rBytes, sBytes)
+	return &Signature{
+		R:     rBytes,
+		S:     sBytes,
+		Bytes: der,
+		Hex:   hex.EncodeToString(der),
+	}, nil
+}
+
+// derPayload 按 SEQUENCE 头判断签名是否为 DER：长度与头部一致时为 DER，多 1 字节时末尾是 sighash 字节
+func derPayload(signature []byte) ([]byte, bool) {
+	if len(signature) < 2 || signature[0] != 0x30 {
+		return nil, false
+	}
+	switch len(signature) - int(signature[1]) {
+	case 2:
+		return signature, true
+	case 3:
+		return signature[:len(signature)-1], true
+	default:
+		return nil, false
+	}
+}
+
+// ECDSARecoveryID 确定签名的公钥恢复标识：依次尝试 0-3，恢复出的公钥与 publicKey 相同即为结果
+func ECDSARecoveryID(digest []byte, r, s *big.Int, publicKey []byte) (int, error) {
+	expected, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid secp256k1 public key")
+	}
+
+	compact := make([]byte, 65)
+	r.FillBytes(compact[1:33])
+	s.FillBytes(compact[33:65])
+	for recoveryID := 0; recoveryID < 4; recoveryID++ {
+		// decred 的 compact 头字节：27 + recovery id + 4（压缩公钥）
+		compact[0] = byte(ecdsaRecoveryIDOffset + 4 + recoveryID)
+		recovered, _, err := ecdsa.RecoverCompact(compact, digest)
+		if err != nil {
+			continue
+		}
+		if recovered.IsEqual(expected) {
+			return recoveryID, nil
+		}
+	}
+	return 0, errors.New("signature does not recover to the public key")
+}
+
+// normalizeLowS 返回 low-S 形式：S > n/2 时取 n - S
+func normalizeLowS(s *big.Int) *big.Int {
+	n := secp256k1.S256().Params().N
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		return new(big.Int).Sub(n, s)
+	}
+	return s
+}
+
+func checkECDSAScalars(r, s *big.Int) error {
+	n := secp256k1.S256().Params().N
+	if r.Sign() == 0 || r.Cmp(n) >= 0 {
+		return errors.New("invalid signature: r out of range")
+	}
+	if s.Sign() == 0 || s.Cmp(n) >= 0 {
+		return errors.New("invalid signature: s out of range")
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package protocol
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/kashguard/tss-lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// highSDER 把签名改写为 high-S 的 DER 编码（同样是有效签名，但不符合比特币/以太坊的规范）
+func highSDER(t *testing.T, der []byte) []byte {
+	t.Helper()
+	r, s, err := ParseECDSASignature(der)
+	require.NoError(t, err)
+	n := secp256k1.S256().Params().N
+	if s.Cmp(new(big.Int).Rsh(n, 1)) <= 0 {
+		s = new(big.Int).Sub(n, s)
+	}
+	return buildDERSignature(padScalarBytes(r.Bytes()), padScalarBytes(s.Bytes()))
+}
+
+// TestEncodeECDSASignature 各格式的编码结果：low-S、recovery id 与 secp256k1 SignCompact 一致、rsv 可由以太坊恢复公钥
+func TestEncodeECDSASignature(t *testing.T) {
+	message := []byte("chain-aware signature encoding")
+	halfN := new(big.Int).Rsh(secp256k1.S256().Params().N, 1)
+
+	for i := 0; i < 8; i++ {
+		priv, err := secp256k1.GeneratePrivateKey()
+		require.NoError(t, err)
+		publicKey := priv.PubKey().SerializeCompressed()
+		digest := ECDSADigest(message, false)
+
+		compact := ecdsa.SignCompact(priv, digest, true)
+		expectedRecoveryID := int(compact[0]) - 27 - 4
+		der := ecdsa.Sign(priv, digest).Serialize()
+
+		for _, sig := range [][]byte{der, highSDER(t, der)} {
+			encoded, err := EncodeECDSASignature(SignatureFormatRSV, sig, digest, publicKey, 0)
+			require.NoError(t, err)
+			assert.Equal(t, expectedRecoveryID, encoded.RecoveryID)
+			assert.Equal(t, 27+expectedRecoveryID, encoded.V)
+			assert.Equal(t, compact[1:], encoded.Bytes[:64])
+			assert.True(t, new(big.Int).SetBytes(encoded.S).Cmp(halfN) <= 0, "S must be normalized to low-S")
+
+			ethSig := append(append([]byte(nil), encoded.Bytes[:64]...), byte(encoded.RecoveryID))
+			recovered, err := ethcrypto.SigToPub(digest, ethSig)
+			require.NoError(t, err)
+			assert.Equal(t, publicKey, ethcrypto.CompressPubkey(recovered))
+
+			encoded, err = EncodeECDSASignature(SignatureFormatDER, sig, digest, publicKey, 0)
+			require.NoError(t, err)
+			assert.Equal(t, der, encoded.Bytes)
+
+			encoded, err = EncodeECDSASignature(SignatureFormatDERSighash, sig, digest, publicKey, 0)
+			require.NoError(t, err)
+			assert.Equal(t, append(append([]byte(nil), der...), SighashAll), encoded.Bytes)
+
+			encoded, err = EncodeECDSASignature(SignatureFormatCompact, sig, digest, publicKey, 0)
+			require.NoError(t, err)
+			assert.Equal(t, compact[1:], encoded.Bytes)
+		}
+	}
+}
+
+// TestParseECDSASignature 各种输入编码解析出相同的 r/s，其他公钥无法得到 recovery id
+func TestParseECDSASignature(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	digest := ECDSADigest([]byte("parse"), false)
+	der := ecdsa.Sign(priv, digest).Serialize()
+	r, s, err := ParseECDSASignature(der)
+	require.NoError(t, err)
+
+	rs := append(padScalarBytes(r.Bytes()), padScalarBytes(s.Bytes())...)
+	for _, encoding := range [][]byte{
+		append(append([]byte(nil), der...), 0x83),
+		rs,
+		append(append([]byte(nil), rs...), 28),
+	} {
+		parsedR, parsedS, err := ParseECDSASignature(encoding)
+		require.NoError(t, err)
+		assert.Equal(t, r, parsedR)
+		assert.Equal(t, s, parsedS)
+	}
+
+	encoded, err := EncodeECDSASignature(SignatureFormatDERSighash, der, digest, priv.PubKey().SerializeCompressed(), 0x83)
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x83), encoded.Bytes[len(encoded.Bytes)-1])
+
+	_, _, err = ParseECDSASignature(der[:len(der)-4])
+	assert.Error(t, err)
+	_, _, err = ParseECDSASignature(make([]byte, 64))
+	assert.Error(t, err, "zero r/s")
+
+	other, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	_, err = ECDSARecoveryID(digest, r, s, other.PubKey().SerializeCompressed())
+	assert.Error(t, err)
+}
+
+// TestConvertTSSSignature_LowS tss-lib 返回 high-S 签名时转换结果为 low-S 的 DER
+func TestConvertTSSSignature_LowS(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	message := []byte("low-s")
+	der := ecdsa.Sign(priv, ECDSADigest(message, false)).Serialize()
+	r, s, err := ParseECDSASignature(der)
+	require.NoError(t, err)
+	highS := new(big.Int).Sub(secp256k1.S256().Params().N, s)
+
+	signature, err := convertTSSSignature(&common.SignatureData{R: r.Bytes(), S: highS.Bytes()})
+	require.NoError(t, err)
+	assert.Equal(t, der, signature.Bytes)
+	assert.Equal(t, padScalarBytes(s.Bytes()), signature.S)
+
+	valid, err := verifyECDSASignature(signature, message, &PublicKey{Bytes: priv.PubKey().SerializeCompressed()})
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+// TestValidateSignatureFormat ECDSA 格式只适用于 ECDSA 密钥，raw 只适用于 EdDSA/Schnorr
+func TestValidateSignatureFormat(t *testing.T) {
+	assert.NoError(t, ValidateSignatureFormat(SignatureFormatRSV, "ECDSA"))
+	assert.NoError(t, ValidateSignatureFormat(SignatureFormatRaw, "EdDSA"))
+	assert.NoError(t, ValidateSignatureFormat(SignatureFormatRaw, "Schnorr"))
+	assert.Error(t, ValidateSignatureFormat(SignatureFormatRaw, "ECDSA"))
+	assert.Error(t, ValidateSignatureFormat(SignatureFormatDER, "EdDSA"))
+	assert.Error(t, ValidateSignatureFormat("base64", "ECDSA"))
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sig := ed25519.Sign(priv, []byte("raw"))
+	encoded, err := EncodeRawSignature(sig)
+	require.NoError(t, err)
+	assert.Equal(t, sig, encoded.Bytes)
+	assert.Equal(t, sig[:32], encoded.R)
+	assert.Equal(t, -1, encoded.RecoveryID)
+	_, err = EncodeRawSignature(sig[:63])
+	assert.Error(t, err)
+}
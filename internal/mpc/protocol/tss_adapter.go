@@ -37,6 +37,9 @@ type tssPartyManager struct {
 	activeEdDSAKeygen  map[string]*eddsaKeygen.LocalParty
 	activeEdDSASigning map[string]*eddsaSigning.LocalParty
 
+	// 当前活跃的 resharing 会话（ECDSA 与 EdDSA 共用）
+	activeResharing map[string]*resharingSession
+
 	// 消息路由：从 tss-lib 消息到节点通信
 	// 参数：sessionID（用于DKG或签名会话），nodeID（目标节点），msg（tss-lib消息）
 	messageRouter func(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error
@@ -45,9 +48,14 @@ type tssPartyManager struct {
 	// 消息包含字节数据和发送方节点ID
 	incomingKeygenMessages  map[string]chan *incomingMessage
 	incomingSigningMessages map[string]chan *incomingMessage
+	// resharing 消息队列
+	incomingResharingMessages map[string]chan *incomingMessage
 
 	// 会话ID映射：keyID/sessionID -> sessionID（用于消息路由时获取会话ID）
 	sessionIDMap map[string]string
+
+	// ECDSA keygen 预参数池（可选，为 nil 时在线生成）
+	preParamsPool *PreParamsPool
 }
 
 // incomingMessage 接收到的消息（包含消息字节和发送方信息）
@@ -59,19 +67,32 @@ type incomingMessage struct {
 
 func newTSSPartyManager(messageRouter func(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error) *tssPartyManager {
 	return &tssPartyManager{
-		nodeIDToPartyID:         make(map[string]*tss.PartyID),
-		partyIDToNodeID:         make(map[string]string),
-		activeKeygen:            make(map[string]*keygen.LocalParty),
-		activeSigning:           make(map[string]*signing.LocalParty),
-		activeEdDSAKeygen:       make(map[string]*eddsaKeygen.LocalParty),
-		activeEdDSASigning:      make(map[string]*eddsaSigning.LocalParty),
-		messageRouter:           messageRouter,
-		incomingKeygenMessages:  make(map[string]chan *incomingMessage),
-		incomingSigningMessages: make(map[string]chan *incomingMessage),
-		sessionIDMap:            make(map[string]string),
+		nodeIDToPartyID:           make(map[string]*tss.PartyID),
+		partyIDToNodeID:           make(map[string]string),
+		activeKeygen:              make(map[string]*keygen.LocalParty),
+		activeSigning:             make(map[string]*signing.LocalParty),
+		activeEdDSAKeygen:         make(map[string]*eddsaKeygen.LocalParty),
+		activeEdDSASigning:        make(map[string]*eddsaSigning.LocalParty),
+		activeResharing:           make(map[string]*resharingSession),
+		messageRouter:             messageRouter,
+		incomingKeygenMessages:    make(map[string]chan *incomingMessage),
+		incomingSigningMessages:   make(map[string]chan *incomingMessage),
+		incomingResharingMessages: make(map[string]chan *incomingMessage),
+		sessionIDMap:              make(map[string]string),
 	}
 }
 
+// takePreParams 从预参数池取出一个预参数
+func (m *tssPartyManager) takePreParams(ctx context.Context) (*keygen.LocalPreParams, bool) {
+	m.mu.RLock()
+	pool := m.preParamsPool
+	m.mu.RUnlock()
+	if pool == nil {
+		return nil, false
+	}
+	return pool.Take(ctx)
+}
+
 // setupPartyIDs 为节点创建 PartyID
 func (m *tssPartyManager) setupPartyIDs(nodeIDs []string) error {
 	m.mu.Lock()
@@ -82,11 +103,8 @@ func (m *tssPartyManager) setupPartyIDs(nodeIDs []string) error {
 			continue
 		}
 
-		// 使用节点ID的哈希作为唯一密钥
-		hash := sha256.Sum256([]byte(nodeID))
-		uniqueKey := new(big.Int).SetBytes(hash[:])
-
-		partyID := tss.NewPartyID(nodeID, nodeID, uniqueKey)
+		// 使用节点ID的哈希作为唯一密钥（DKG 生成的分片属于轮次 0）
+		partyID := tss.NewPartyID(nodeID, nodeID, partyKeyForEpoch(nodeID, 0))
 		m.nodeIDToPartyID[nodeID] = partyID
 		m.partyIDToNodeID[partyID.Id] = nodeID
 	}
@@ -137,6 +155,75 @@ func (m *tssPartyManager) getNodeID(partyID string) (string, bool) {
 	return nodeID, ok
 }
 
+// partyKeyForEpoch 计算节点在指定分片轮次下的 PartyID 密钥
+// 轮次 0 与 DKG 使用的 sha256(nodeID) 保持一致；resharing 产生的新分片使用新的轮次，
+// 这样同时属于新旧委员会的节点可以在同一次 resharing 中以两个不同的 PartyID 参与
+func partyKeyForEpoch(nodeID string, epoch int) *big.Int {
+	input := nodeID
+	if epoch > 0 {
+		input = fmt.Sprintf("%s#%d", nodeID, epoch)
+	}
+	hash := sha256.Sum256([]byte(input))
+	return new(big.Int).SetBytes(hash[:])
+}
+
+// resolveShareEpoch 根据本节点分片的 ShareID 反推分片所属的轮次
+// 无法识别的 ShareID 按轮次 0 处理，保持与历史数据兼容
+func resolveShareEpoch(nodeID string, shareID *big.Int) int {
+	if shareID == nil {
+		return 0
+	}
+	for epoch := 0; epoch <= maxShareEpoch; epoch++ {
+		if partyKeyForEpoch(nodeID, epoch).Cmp(shareID) == 0 {
+			return epoch
+		}
+	}
+	log.Warn().
+		Str("node_id", nodeID).
+		Msg("Share ID does not match any known epoch, falling back to epoch 0")
+	return 0
+}
+
+// buildEpochPartyIDs 为指定轮次构建会话内独立的 PartyID 列表（不写入全局映射）
+func buildEpochPartyIDs(nodeIDs []string, epoch int) (tss.SortedPartyIDs, map[string]*tss.PartyID) {
+	byNode := make(map[string]*tss.PartyID, len(nodeIDs))
+	unsorted := make([]*tss.PartyID, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if _, exists := byNode[nodeID]; exists {
+			continue
+		}
+		partyID := tss.NewPartyID(nodeID, nodeID, partyKeyForEpoch(nodeID, epoch))
+		byNode[nodeID] = partyID
+		unsorted = append(unsorted, partyID)
+	}
+	return tss.SortPartyIDs(unsorted), byNode
+}
+
+// getSigningPartyIDs 获取签名会话使用的 PartyID 列表及节点映射
+// 分片所属轮次由本节点的 ShareID 决定：轮次 0 沿用全局映射，resharing 后的分片使用对应轮次的 PartyID
+func (m *tssPartyManager) getSigningPartyIDs(nodeIDs []string, thisNodeID string, shareID *big.Int) (tss.SortedPartyIDs, map[string]*tss.PartyID, error) {
+	// 全局映射仍用于 PartyID.Id -> nodeID 的反查（Id 与轮次无关）
+	if err := m.setupPartyIDs(nodeIDs); err != nil {
+		return nil, nil, errors.Wrap(err, "setup party IDs")
+	}
+
+	epoch := resolveShareEpoch(thisNodeID, shareID)
+	if epoch > 0 {
+		parties, byNode := buildEpochPartyIDs(nodeIDs, epoch)
+		return parties, byNode, nil
+	}
+
+	parties, err := m.getPartyIDs(nodeIDs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get party IDs")
+	}
+	byNode := make(map[string]*tss.PartyID, len(parties))
+	for _, partyID := range parties {
+		byNode[partyID.Id] = partyID
+	}
+	return parties, byNode, nil
+}
+
 // executeKeygen 执行真正的 DKG 协议
 func (m *tssPartyManager) executeKeygen(
 	ctx context.Context,
@@ -199,8 +286,21 @@ func (m *tssPartyManager) executeKeygen(
 	endCh := make(chan *keygen.LocalPartySaveData, 1)
 	errCh := make(chan *tss.Error, 1)
 
-	// 创建 LocalParty
-	party := keygen.NewLocalParty(params, outCh, endCh)
+	// 创建 LocalParty（优先使用预参数池，避免在线生成安全素数）
+	var party tss.Party
+	if preParams, ok := m.takePreParams(ctx); ok {
+		log.Info().
+			Str("key_id", keyID).
+			Str("this_node_id", thisNodeID).
+			Msg("Using pooled pre-params for DKG")
+		party = keygen.NewLocalParty(params, outCh, endCh, *preParams)
+	} else {
+		log.Warn().
+			Str("key_id", keyID).
+			Str("this_node_id", thisNodeID).
+			Msg("Pre-params pool empty or disabled, generating pre-params inline")
+		party = keygen.NewLocalParty(params, outCh, endCh)
+	}
 
 	m.mu.Lock()
 	// 类型断言为 *keygen.LocalParty
@@ -491,7 +591,7 @@ func (m *tssPartyManager) executeKeygen(
 				delete(m.incomingKeygenMessages, keyID)
 			}
 			m.mu.Unlock()
-			return nil, errors.Wrap(err, "keygen error")
+			return nil, m.wrapTSSError(err, "keygen", keyID, "keygen error")
 		}
 	}
 }
@@ -504,6 +604,10 @@ type SigningOptions struct {
 	EnableIdentifiableAbort bool
 	// ProtocolName 协议名称（用于错误消息）
 	ProtocolName string
+	// KeyDerivation 非空时使用 BIP-32 派生的子密钥签名（每个分片加上派生调整值）
+	KeyDerivation *DerivedPublicKey
+	// Prehashed 为 true 时 message 已是 32 字节摘要，直接签名
+	Prehashed bool
 }
 
 // DefaultSigningOptions 返回默认的签名选项（GG18）
@@ -535,16 +639,12 @@ func (m *tssPartyManager) executeSigning(
 	keyData *keygen.LocalPartySaveData,
 	opts SigningOptions,
 ) (*common.SignatureData, error) {
-	if err := m.setupPartyIDs(nodeIDs); err != nil {
-		return nil, errors.Wrap(err, "setup party IDs")
-	}
-
-	parties, err := m.getPartyIDs(nodeIDs)
+	parties, sessionPartyIDs, err := m.getSigningPartyIDs(nodeIDs, thisNodeID, keyData.ShareID)
 	if err != nil {
-		return nil, errors.Wrap(err, "get party IDs")
+		return nil, err
 	}
 
-	thisPartyID, ok := m.getPartyID(thisNodeID)
+	thisPartyID, ok := sessionPartyIDs[thisNodeID]
 	if !ok {
 		return nil, errors.Errorf("this node ID not found: %s", thisNodeID)
 	}
@@ -568,17 +668,20 @@ func (m *tssPartyManager) executeSigning(
 		}()).
 		Msg("🔍 [DIAGNOSTIC] Created TSS parameters for signing")
 
-	// 计算消息哈希
-	hash := sha256.Sum256(message)
-	msgBigInt := new(big.Int).SetBytes(hash[:])
+	// 计算消息哈希（预哈希请求直接使用摘要）
+	msgBigInt := new(big.Int).SetBytes(ECDSADigest(message, opts.Prehashed))
 
 	// 创建消息通道
 	outCh := make(chan tss.Message, len(parties))
 	endCh := make(chan *common.SignatureData, 1)
 	errCh := make(chan *tss.Error, 1)
 
-	// 创建 LocalParty
-	party := signing.NewLocalParty(msgBigInt, params, *keyData, outCh, endCh)
+	// 创建 LocalParty（指定派生路径时，将公钥和 BigXj 调整为子密钥，round 1 中为 xi 加上调整值）
+	signingKey, keyDerivationDelta, err := applyKeyDerivation(keyData, opts.KeyDerivation)
+	if err != nil {
+		return nil, err
+	}
+	party := signing.NewLocalPartyWithKDD(msgBigInt, params, signingKey, keyDerivationDelta, outCh, endCh)
 
 	m.mu.Lock()
 	// 类型断言为 *signing.LocalParty
@@ -747,7 +850,7 @@ func (m *tssPartyManager) executeSigning(
 				}
 
 				// 获取发送方的PartyID
-				fromPartyID, ok := m.nodeIDToPartyID[incomingMsg.fromNodeID]
+				fromPartyID, ok := sessionPartyIDs[incomingMsg.fromNodeID]
 				if !ok {
 					// 发送方节点ID未找到，忽略消息
 					log.Warn().
@@ -884,15 +987,13 @@ func (m *tssPartyManager) executeSigning(
 				m.mu.RUnlock()
 
 				if isBroadcast {
-					// 广播到所有节点（SendSigningMessage 会自行跳过发送给自身）
-					m.mu.RLock()
-					allTargetNodeIDs := make([]string, 0, len(m.partyIDToNodeID))
-					for _, targetNodeID := range m.partyIDToNodeID {
-						if targetNodeID != thisNodeID {
-							allTargetNodeIDs = append(allTargetNodeIDs, targetNodeID)
+					// 广播到本次签名的其他参与节点（全局映射中还有其他密钥或旧委员会的节点）
+					allTargetNodeIDs := make([]string, 0, len(parties))
+					for _, partyID := range parties {
+						if partyID.Id != thisNodeID {
+							allTargetNodeIDs = append(allTargetNodeIDs, partyID.Id)
 						}
 					}
-					m.mu.RUnlock()
 
 					log.Info().
 						Str("session_id", sessionID).
@@ -1011,13 +1112,14 @@ func (m *tssPartyManager) executeSigning(
 			}
 			m.mu.Unlock()
 			// 如果支持可识别的中止，可以识别恶意节点
-			if opts.EnableIdentifiableAbort && err.Culprits() != nil {
+			if opts.EnableIdentifiableAbort && len(err.Culprits()) > 0 {
+				abortErr := m.wrapTSSError(err, opts.ProtocolName, sessionID, fmt.Sprintf("%s signing error", opts.ProtocolName))
 				log.Error().
 					Str("session_id", sessionID).
 					Str("this_node_id", thisNodeID).
-					Interface("culprits", err.Culprits()).
+					Strs("culprits", Culprits(abortErr)).
 					Msg("🔍 [DIAGNOSTIC] Identifiable abort detected")
-				return nil, errors.Wrapf(err, "%s signing error (identifiable abort: %v)", opts.ProtocolName, err.Culprits())
+				return nil, abortErr
 			}
 			return nil, errors.Wrapf(err, "%s signing error", opts.ProtocolName)
 		}
@@ -1353,6 +1455,9 @@ func convertTSSSignature(sigData *common.SignatureData) (*Signature, error) {
 	rBytes := sigData.R
 	sBytes := sigData.S
 
+	// 规范化为 low-S（BIP-62/BIP-146 要求 S <= n/2，以太坊 EIP-2 同样拒绝 high-S）
+	sBytes = normalizeLowS(new(big.Int).SetBytes(sBytes)).Bytes()
+
 	// 填充到 32 字节
 	rPadded := padScalarBytes(rBytes)
 	sPadded := padScalarBytes(sBytes)
@@ -1369,20 +1474,34 @@ func convertTSSSignature(sigData *common.SignatureData) (*Signature, error) {
 }
 
 func buildDERSignature(r, s []byte) []byte {
-	// 简化的 DER 编码实现
-	// 实际应该使用标准的 DER 编码库
-	der := make([]byte, 0, 70)
+	rInt := derInteger(r)
+	sInt := derInteger(s)
+	der := make([]byte, 0, 6+len(rInt)+len(sInt))
 	der = append(der, 0x30) // SEQUENCE
-	der = append(der, byte(len(r)+len(s)+4))
+	der = append(der, byte(len(rInt)+len(sInt)+4))
 	der = append(der, 0x02) // INTEGER
-	der = append(der, byte(len(r)))
-	der = append(der, r...)
+	der = append(der, byte(len(rInt)))
+	der = append(der, rInt...)
 	der = append(der, 0x02) // INTEGER
-	der = append(der, byte(len(s)))
-	der = append(der, s...)
+	der = append(der, byte(len(sInt)))
+	der = append(der, sInt...)
 	return der
 }
 
+// derInteger 将大端无符号整数编码为 DER INTEGER 内容：去掉多余的前导零，最高位为 1 时补 0x00 以保持正数
+func derInteger(src []byte) []byte {
+	for len(src) > 1 && src[0] == 0 && src[1]&0x80 == 0 {
+		src = src[1:]
+	}
+	if len(src) == 0 {
+		return []byte{0}
+	}
+	if src[0]&0x80 != 0 {
+		return append([]byte{0}, src...)
+	}
+	return append([]byte(nil), src...)
+}
+
 func padScalarBytes(src []byte) []byte {
 	const size = 32
 	if len(src) >= size {
@@ -1643,7 +1762,7 @@ func (m *tssPartyManager) executeEdDSAKeygen(
 				delete(m.incomingKeygenMessages, keyID)
 			}
 			m.mu.Unlock()
-			return nil, errors.Wrap(err, "EdDSA keygen error")
+			return nil, m.wrapTSSError(err, "EdDSA keygen", keyID, "EdDSA keygen error")
 		}
 	}
 }
@@ -1659,16 +1778,12 @@ func (m *tssPartyManager) executeEdDSASigning(
 	keyData *eddsaKeygen.LocalPartySaveData,
 	opts SigningOptions,
 ) (*common.SignatureData, error) {
-	if err := m.setupPartyIDs(nodeIDs); err != nil {
-		return nil, errors.Wrap(err, "setup party IDs")
-	}
-
-	parties, err := m.getPartyIDs(nodeIDs)
+	parties, sessionPartyIDs, err := m.getSigningPartyIDs(nodeIDs, thisNodeID, keyData.ShareID)
 	if err != nil {
-		return nil, errors.Wrap(err, "get party IDs")
+		return nil, err
 	}
 
-	thisPartyID, ok := m.nodeIDToPartyID[thisNodeID]
+	thisPartyID, ok := sessionPartyIDs[thisNodeID]
 	if !ok {
 		return nil, errors.Errorf("this node ID not found: %s", thisNodeID)
 	}
@@ -1747,8 +1862,8 @@ func (m *tssPartyManager) executeEdDSASigning(
 			m.mu.Lock()
 			delete(m.activeEdDSASigning, sessionID)
 			m.mu.Unlock()
-			if opts.EnableIdentifiableAbort && err.Culprits() != nil {
-				return nil, errors.Wrapf(err, "%s signing error (identifiable abort: %v)", opts.ProtocolName, err.Culprits())
+			if opts.EnableIdentifiableAbort && len(err.Culprits()) > 0 {
+				return nil, m.wrapTSSError(err, opts.ProtocolName, sessionID, fmt.Sprintf("%s signing error", opts.ProtocolName))
 			}
 			return nil, errors.Wrapf(err, "%s signing error", opts.ProtocolName)
 		}
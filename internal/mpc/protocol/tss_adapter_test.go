@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"context"
+	"crypto/sha256"
 	"math/big"
 	"testing"
 	"time"
@@ -184,15 +185,18 @@ func TestExecuteEdDSASigning_ContextCancellation(t *testing.T) {
 	// 我们需要确保 KeyData 中的 ShareID 与 setupPartyIDs 生成的 ID 匹配
 	// setupPartyIDs 使用 sha256(nodeID) 作为 uniqueKey
 	keyData := eddsaKeygen.NewLocalPartySaveData(2)
-	
+
 	// 计算 node-1 的 hash (uniqueKey)
 	hash := sha256.Sum256([]byte("node-1"))
 	uniqueKey := new(big.Int).SetBytes(hash[:])
-	
+
+	otherHash := sha256.Sum256([]byte("node-2"))
+
 	keyData.ShareID = uniqueKey // 设置当前节点的 ShareID
+	keyData.Xi = big.NewInt(1)
 	keyData.Ks = []*big.Int{
 		uniqueKey,
-		big.NewInt(2), // 另一个假 ID
+		new(big.Int).SetBytes(otherHash[:]), // node-2 的 uniqueKey
 	}
 
 	// 设置 PartyID
@@ -222,9 +226,11 @@ func TestExecuteEdDSASigning_Timeout(t *testing.T) {
 
 	// 创建一个最小的 keyData（需要有效的 Ks）
 	keyData := eddsaKeygen.NewLocalPartySaveData(2)
+	keyData.ShareID = testPartyKey("node-1")
+	keyData.Xi = big.NewInt(1)
 	keyData.Ks = []*big.Int{
-		big.NewInt(1),
-		big.NewInt(2),
+		testPartyKey("node-1"),
+		testPartyKey("node-2"),
 	}
 
 	// 设置 PartyID
@@ -496,7 +502,9 @@ func TestExecuteEdDSAKeygen_ThresholdValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
+			// 单节点环境下协议无法完成，使用短超时避免等待默认的 10 分钟
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
 			// 注意：实际的阈值验证应该在协议层完成
 			// 这里只测试参数传递
 			_, err := manager.executeEdDSAKeygen(ctx, keyID, nodeIDs, tt.threshold, thisNodeID)
@@ -518,9 +526,11 @@ func TestExecuteEdDSASigning_MessageHandling(t *testing.T) {
 
 	// 创建一个最小的 keyData（需要有效的 Ks）
 	keyData := eddsaKeygen.NewLocalPartySaveData(2)
+	keyData.ShareID = testPartyKey("node-1")
+	keyData.Xi = big.NewInt(1)
 	keyData.Ks = []*big.Int{
-		big.NewInt(1),
-		big.NewInt(2),
+		testPartyKey("node-1"),
+		testPartyKey("node-2"),
 	}
 
 	// 设置 PartyID
@@ -579,7 +589,7 @@ func TestSigningOptions_StringRepresentation(t *testing.T) {
 // TestExecuteEdDSAKeygen_MessageRouter 测试消息路由
 func TestExecuteEdDSAKeygen_MessageRouter(t *testing.T) {
 	messageCount := 0
-	messageRouter := func(sessionID string, nodeID string, msg tss.Message) error {
+	messageRouter := func(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error {
 		messageCount++
 		return nil
 	}
@@ -596,7 +606,7 @@ func TestExecuteEdDSAKeygen_MessageRouter(t *testing.T) {
 // TestExecuteEdDSASigning_MessageRouter 测试消息路由
 func TestExecuteEdDSASigning_MessageRouter(t *testing.T) {
 	messageCount := 0
-	messageRouter := func(sessionID string, nodeID string, msg tss.Message) error {
+	messageRouter := func(sessionID string, nodeID string, msg tss.Message, isBroadcast bool) error {
 		messageCount++
 		return nil
 	}
@@ -642,3 +652,9 @@ func TestGG20SigningOptions_Consistency(t *testing.T) {
 	assert.Equal(t, opts1.EnableIdentifiableAbort, opts2.EnableIdentifiableAbort)
 	assert.Equal(t, opts1.ProtocolName, opts2.ProtocolName)
 }
+
+// testPartyKey 返回 setupPartyIDs 为节点生成的 uniqueKey（sha256(nodeID)）
+func testPartyKey(nodeID string) *big.Int {
+	hash := sha256.Sum256([]byte(nodeID))
+	return new(big.Int).SetBytes(hash[:])
+}
@@ -0,0 +1,569 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+	"reflect"
+	"regexp"
+	"time"
+	"unsafe"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/ecdsa/signing"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// presignOfflineTimeout 离线阶段（预签名生成）超时时间
+	presignOfflineTimeout = 2 * time.Minute
+	// presignOnlineTimeout 在线阶段（单轮签名）超时时间
+	presignOnlineTimeout = 10 * time.Second
+)
+
+// presignIDPattern 预签名ID格式（预签名ID同时作为离线阶段的会话ID，并参与存储路径拼接）
+var presignIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// presignature 本节点持有的 GG20 预签名（离线阶段结果）
+// K、Sigma 为本节点的秘密份额（k_i 与 σ_i = k·x 的加法份额），
+// 同一预签名用于两条不同的消息会直接泄露私钥，因此只能使用一次
+type presignature struct {
+	ID        string    `json:"id"`
+	KeyID     string    `json:"key_id"`
+	NodeIDs   []string  `json:"node_ids"`
+	ShareID   *big.Int  `json:"share_id"` // 生成时本节点分片的 ShareID，resharing 后预签名失效
+	K         *big.Int  `json:"k"`
+	Sigma     *big.Int  `json:"sigma"`
+	Rx        *big.Int  `json:"rx"`
+	Ry        *big.Int  `json:"ry"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// presignStorageKeyID 预签名在 KeyShareStorage 中使用的 keyID
+func presignStorageKeyID(keyID string, presignID string) string {
+	return keyID + "/presign/" + presignID
+}
+
+// presignPartyIDs 构建预签名会话使用的 PartyID 列表
+// 始终为会话单独创建 PartyID：预签名与普通签名可能并发执行且参与节点不同，
+// 共享全局映射中的 PartyID 会导致排序后的 Index 被其他会话覆盖
+func presignPartyIDs(nodeIDs []string, thisNodeID string, shareID *big.Int) (tss.SortedPartyIDs, map[string]*tss.PartyID) {
+	return buildEpochPartyIDs(nodeIDs, resolveShareEpoch(thisNodeID, shareID))
+}
+
+// signingMessageIsBroadcast 根据消息类型判断 tss-lib 签名消息是否为广播消息
+// SendSigningMessage 不携带广播标记，接收方需要自行推断
+func signingMessageIsBroadcast(content tss.MessageContent) bool {
+	switch content.(type) {
+	case *signing.SignRound1Message1, *signing.SignRound2Message:
+		return false
+	default:
+		return true
+	}
+}
+
+// parseSigningWireMessage 解析签名消息，广播标记由消息类型决定
+func parseSigningWireMessage(msgBytes []byte, from *tss.PartyID) (tss.ParsedMessage, error) {
+	parsed, err := tss.ParseWireMessage(msgBytes, from, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse signing wire message")
+	}
+	if !signingMessageIsBroadcast(parsed.Content()) {
+		if parsed, err = tss.ParseWireMessage(msgBytes, from, false); err != nil {
+			return nil, errors.Wrap(err, "parse signing wire message")
+		}
+	}
+	return parsed, nil
+}
+
+// presignSecrets 从 tss-lib 签名 Party 中取出的离线阶段中间值
+type presignSecrets struct {
+	k            *big.Int
+	sigma        *big.Int
+	thetaInverse *big.Int
+	ssid         []byte
+	pointGamma   *crypto.ECPoint
+}
+
+// extractPresignSecrets 读取 signing.LocalParty 第4轮结束时的内部状态
+// tss-lib 没有提供离线/在线拆分的接口：k、σ 与 θ^-1 在第3、4轮计算完成并保存在未导出的 temp 字段中，
+// 第5轮才与消息结合。因此在本节点发出 SignRound4Message 后读取这些值（此后 Party 不再修改它们）。
+func extractPresignSecrets(party *signing.LocalParty) (*presignSecrets, error) {
+	temp := reflect.ValueOf(party).Elem().FieldByName("temp")
+	if !temp.IsValid() {
+		return nil, errors.New("tss-lib signing party has no temp data")
+	}
+
+	field := func(name string) (reflect.Value, error) {
+		f := temp.FieldByName(name)
+		if !f.IsValid() {
+			return reflect.Value{}, errors.Errorf("tss-lib signing temp data has no field %s", name)
+		}
+		return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem(), nil
+	}
+	bigInt := func(name string) (*big.Int, error) {
+		f, err := field(name)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := f.Interface().(*big.Int)
+		if !ok || v == nil || v.Sign() == 0 {
+			return nil, errors.Errorf("tss-lib signing temp field %s is not available", name)
+		}
+		return new(big.Int).Set(v), nil
+	}
+
+	secrets := &presignSecrets{}
+	var err error
+	if secrets.k, err = bigInt("k"); err != nil {
+		return nil, err
+	}
+	if secrets.sigma, err = bigInt("sigma"); err != nil {
+		return nil, err
+	}
+	if secrets.thetaInverse, err = bigInt("thetaInverse"); err != nil {
+		return nil, err
+	}
+
+	ssid, err := field("ssid")
+	if err != nil {
+		return nil, err
+	}
+	ssidBytes, ok := ssid.Interface().([]byte)
+	if !ok || len(ssidBytes) == 0 {
+		return nil, errors.New("tss-lib signing temp field ssid is not available")
+	}
+	secrets.ssid = append([]byte(nil), ssidBytes...)
+
+	gamma, err := field("pointGamma")
+	if err != nil {
+		return nil, err
+	}
+	pointGamma, ok := gamma.Interface().(*crypto.ECPoint)
+	if !ok || pointGamma == nil {
+		return nil, errors.New("tss-lib signing temp field pointGamma is not available")
+	}
+	secrets.pointGamma = pointGamma
+
+	return secrets, nil
+}
+
+// computePresignR 按 tss-lib 第5轮的方式计算 R = (Σ Γ_j)^{θ^-1}
+// 其他参与方的 Γ_j 通过第1轮承诺与第4轮打开值校验，并验证 Γ_j 的 Schnorr 证明
+func computePresignR(
+	ec elliptic.Curve,
+	parties tss.SortedPartyIDs,
+	selfIndex int,
+	secrets *presignSecrets,
+	commitmentsByIndex map[int]commitments.HashCommitment,
+	round4ByIndex map[int]*signing.SignRound4Message,
+) (*crypto.ECPoint, error) {
+	R := secrets.pointGamma
+	for j, partyID := range parties {
+		if j == selfIndex {
+			continue
+		}
+		commitment, ok := commitmentsByIndex[j]
+		if !ok {
+			return nil, errors.Errorf("missing round 1 commitment from party %s", partyID.Id)
+		}
+		r4msg, ok := round4ByIndex[j]
+		if !ok {
+			return nil, errors.Errorf("missing round 4 message from party %s", partyID.Id)
+		}
+
+		cmtDeCmt := commitments.HashCommitDecommit{C: commitment, D: r4msg.UnmarshalDeCommitment()}
+		ok, bigGammaJ := cmtDeCmt.DeCommit()
+		if !ok || len(bigGammaJ) != 2 {
+			return nil, errors.Errorf("commitment verify failed for party %s", partyID.Id)
+		}
+		bigGammaJPoint, err := crypto.NewECPoint(ec, bigGammaJ[0], bigGammaJ[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid Gamma point from party %s", partyID.Id)
+		}
+		proof, err := r4msg.UnmarshalZKProof(ec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unmarshal Gamma proof from party %s", partyID.Id)
+		}
+		contextJ := common.AppendBigIntToBytesSlice(secrets.ssid, big.NewInt(int64(j)))
+		if !proof.Verify(contextJ, bigGammaJPoint) {
+			return nil, errors.Errorf("Gamma proof verify failed for party %s", partyID.Id)
+		}
+		if R, err = R.Add(bigGammaJPoint); err != nil {
+			return nil, errors.Wrapf(err, "add Gamma point from party %s", partyID.Id)
+		}
+	}
+	return R.ScalarMult(secrets.thetaInverse), nil
+}
+
+// sumPresignCheckPoints 校验各参与方广播的 k_i·R 与 σ_i·R 之和
+// Σ k_i·R = G 且 Σ σ_i·R = Y 说明所有参与方持有一致的 R 且份额与公钥匹配
+func sumPresignCheckPoints(ec elliptic.Curve, pubKey *crypto.ECPoint, checks map[int][]*big.Int) error {
+	var sumKR, sumSigmaR *crypto.ECPoint
+	for index, values := range checks {
+		if len(values) != 4 {
+			return errors.Errorf("invalid presign check message from party index %d", index)
+		}
+		kR, err := crypto.NewECPoint(ec, values[0], values[1])
+		if err != nil {
+			return errors.Wrapf(err, "invalid k·R point from party index %d", index)
+		}
+		sigmaR, err := crypto.NewECPoint(ec, values[2], values[3])
+		if err != nil {
+			return errors.Wrapf(err, "invalid sigma·R point from party index %d", index)
+		}
+		if sumKR == nil {
+			sumKR, sumSigmaR = kR, sigmaR
+			continue
+		}
+		if sumKR, err = sumKR.Add(kR); err != nil {
+			return errors.Wrap(err, "sum k·R points")
+		}
+		if sumSigmaR, err = sumSigmaR.Add(sigmaR); err != nil {
+			return errors.Wrap(err, "sum sigma·R points")
+		}
+	}
+	if sumKR == nil {
+		return errors.New("no presign check messages")
+	}
+
+	generator := crypto.NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+	if !sumKR.Equals(generator) {
+		return errors.New("presign consistency check failed: sum of k·R does not equal G")
+	}
+	if !sumSigmaR.Equals(pubKey) {
+		return errors.New("presign consistency check failed: sum of sigma·R does not equal public key")
+	}
+	return nil
+}
+
+// executePresign 执行 GG20 离线阶段，生成本节点的预签名份额
+// 运行 tss-lib 签名协议的第1-4轮（与消息无关），扣留其他参与方的 SignRound4Message 使 Party 停留在第4轮，
+// 自行完成第5轮中 R 的计算，然后广播 k_i·R 与 σ_i·R 做一致性校验。
+func (m *tssPartyManager) executePresign(
+	ctx context.Context,
+	sessionID string,
+	keyID string,
+	nodeIDs []string,
+	thisNodeID string,
+	keyData *keygen.LocalPartySaveData,
+) (*presignature, error) {
+	parties, sessionPartyIDs := presignPartyIDs(nodeIDs, thisNodeID, keyData.ShareID)
+	thisPartyID, ok := sessionPartyIDs[thisNodeID]
+	if !ok {
+		return nil, errors.Errorf("this node ID not found: %s", thisNodeID)
+	}
+
+	ec := tss.S256()
+	params := tss.NewParameters(ec, tss.NewPeerContext(parties), thisPartyID, len(parties), len(parties)-1)
+
+	outCh := make(chan tss.Message, 4*len(parties))
+	endCh := make(chan *common.SignatureData, 1)
+	errCh := make(chan *tss.Error, len(parties)+1)
+
+	// 离线阶段与消息无关，使用占位消息；Party 不会进入使用消息的第5轮
+	party, ok := signing.NewLocalParty(big.NewInt(1), params, *keyData, outCh, endCh).(*signing.LocalParty)
+	if !ok {
+		return nil, errors.New("unexpected tss-lib signing party type")
+	}
+
+	msgCh := m.registerSigningQueue(sessionID)
+	defer m.unregisterSigningQueue(sessionID, msgCh)
+
+	go func() {
+		if tssErr := party.Start(); tssErr != nil {
+			errCh <- tssErr
+		}
+	}()
+
+	deliver := func(parsed tss.ParsedMessage) {
+		// 异步注入，避免 Party 在处理消息时向已满的 outCh 写入而阻塞主循环
+		go func() {
+			if _, tssErr := party.Update(parsed); tssErr != nil {
+				errCh <- tssErr
+			}
+		}()
+	}
+
+	commitmentsByIndex := make(map[int]commitments.HashCommitment, len(parties))
+	round4ByIndex := make(map[int]*signing.SignRound4Message, len(parties))
+	checks := make(map[int][]*big.Int, len(parties))
+	var secrets *presignSecrets
+	var err error
+	var R *crypto.ECPoint
+
+	timeout := time.NewTimer(presignOfflineTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			return nil, errors.New("GG20 presign timeout")
+		case tssErr := <-errCh:
+			return nil, m.wrapTSSError(tssErr, "GG20 presign", sessionID, "GG20 presign error")
+		case <-endCh:
+			return nil, errors.New("GG20 presign party finished unexpectedly")
+		case msg := <-outCh:
+			if err := m.routeSessionMessage(sessionID, thisNodeID, nodeIDs, msg); err != nil {
+				return nil, err
+			}
+			parsed, ok := msg.(tss.ParsedMessage)
+			if !ok {
+				continue
+			}
+			if _, isRound4 := parsed.Content().(*signing.SignRound4Message); isRound4 {
+				if secrets, err = extractPresignSecrets(party); err != nil {
+					return nil, errors.Wrap(err, "extract presign secrets")
+				}
+			}
+		case in := <-msgCh:
+			from, ok := sessionPartyIDs[in.fromNodeID]
+			if !ok {
+				log.Warn().
+					Str("session_id", sessionID).
+					Str("from_node_id", in.fromNodeID).
+					Msg("Ignoring presign message from node outside the session")
+				continue
+			}
+			parsed, err := parseSigningWireMessage(in.msgBytes, from)
+			if err != nil {
+				return nil, errors.Wrapf(err, "message from node %s", in.fromNodeID)
+			}
+			switch content := parsed.Content().(type) {
+			case *signing.SignRound1Message2:
+				commitmentsByIndex[from.Index] = content.UnmarshalCommitment()
+				deliver(parsed)
+			case *signing.SignRound4Message:
+				// 扣留第4轮消息：Party 收齐后会进入第5轮并清除 k
+				round4ByIndex[from.Index] = content
+			case *signing.SignRound8Message:
+				checks[from.Index] = content.UnmarshalDeCommitment()
+			default:
+				deliver(parsed)
+			}
+		}
+
+		if R == nil && secrets != nil && len(round4ByIndex) == len(parties)-1 && len(commitmentsByIndex) == len(parties)-1 {
+			if R, err = computePresignR(ec, parties, thisPartyID.Index, secrets, commitmentsByIndex, round4ByIndex); err != nil {
+				return nil, err
+			}
+			kR := R.ScalarMult(secrets.k)
+			sigmaR := R.ScalarMult(secrets.sigma)
+			check := []*big.Int{kR.X(), kR.Y(), sigmaR.X(), sigmaR.Y()}
+			checks[thisPartyID.Index] = check
+
+			// 复用 SignRound8Message 作为一致性校验消息的载体（第8轮在预签名流程中不会出现）
+			checkMsg := signing.NewSignRound8Message(thisPartyID, check)
+			if err := m.routeSessionMessage(sessionID, thisNodeID, nodeIDs, checkMsg); err != nil {
+				return nil, err
+			}
+		}
+
+		if R != nil && len(checks) == len(parties) {
+			if err := sumPresignCheckPoints(ec, keyData.ECDSAPub, checks); err != nil {
+				return nil, err
+			}
+
+			log.Info().
+				Str("session_id", sessionID).
+				Str("key_id", keyID).
+				Str("this_node_id", thisNodeID).
+				Int("party_count", len(parties)).
+				Msg("GG20 presignature generated")
+
+			return &presignature{
+				ID:        sessionID,
+				KeyID:     keyID,
+				NodeIDs:   append([]string(nil), nodeIDs...),
+				ShareID:   new(big.Int).Set(keyData.ShareID),
+				K:         secrets.k,
+				Sigma:     secrets.sigma,
+				Rx:        R.X(),
+				Ry:        R.Y(),
+				CreatedAt: time.Now(),
+			}, nil
+		}
+	}
+}
+
+// executePresignedSigning 使用预签名执行 GG20 在线阶段（单轮）
+// 每个参与方计算 s_i = m·k_i + r·σ_i 并广播，收齐后求和得到签名
+func (m *tssPartyManager) executePresignedSigning(
+	ctx context.Context,
+	sessionID string,
+	message []byte,
+	prehashed bool,
+	presig *presignature,
+	thisNodeID string,
+	keyData *keygen.LocalPartySaveData,
+) (*common.SignatureData, error) {
+	parties, sessionPartyIDs := presignPartyIDs(presig.NodeIDs, thisNodeID, keyData.ShareID)
+	thisPartyID, ok := sessionPartyIDs[thisNodeID]
+	if !ok {
+		return nil, errors.Errorf("this node ID not found: %s", thisNodeID)
+	}
+
+	ec := tss.S256()
+	modN := common.ModInt(ec.Params().N)
+	hash := ECDSADigest(message, prehashed)
+	msgBigInt := new(big.Int).SetBytes(hash)
+	si := modN.Add(modN.Mul(msgBigInt, presig.K), modN.Mul(presig.Rx, presig.Sigma))
+
+	msgCh := m.registerSigningQueue(sessionID)
+	defer m.unregisterSigningQueue(sessionID, msgCh)
+
+	shares := map[int]*big.Int{thisPartyID.Index: si}
+	if err := m.routeSessionMessage(sessionID, thisNodeID, presig.NodeIDs, signing.NewSignRound9Message(thisPartyID, si)); err != nil {
+		return nil, err
+	}
+
+	timeout := time.NewTimer(presignOnlineTimeout)
+	defer timeout.Stop()
+
+	for len(shares) < len(parties) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			return nil, errors.Errorf("GG20 presigned signing timeout (received %d/%d shares)", len(shares), len(parties))
+		case in := <-msgCh:
+			from, ok := sessionPartyIDs[in.fromNodeID]
+			if !ok {
+				log.Warn().
+					Str("session_id", sessionID).
+					Str("from_node_id", in.fromNodeID).
+					Msg("Ignoring signature share from node outside the session")
+				continue
+			}
+			parsed, err := parseSigningWireMessage(in.msgBytes, from)
+			if err != nil {
+				return nil, errors.Wrapf(err, "message from node %s", in.fromNodeID)
+			}
+			r9msg, ok := parsed.Content().(*signing.SignRound9Message)
+			if !ok {
+				return nil, errors.Errorf("unexpected message type %T from node %s in presigned signing", parsed.Content(), in.fromNodeID)
+			}
+			shares[from.Index] = r9msg.UnmarshalS()
+		}
+	}
+
+	sumS := big.NewInt(0)
+	for _, share := range shares {
+		sumS = modN.Add(sumS, share)
+	}
+
+	sigData, err := finalizePresignedSignature(ec, keyData.ECDSAPub, hash, presig.Rx, presig.Ry, sumS)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("session_id", sessionID).
+		Str("presignature_id", presig.ID).
+		Str("this_node_id", thisNodeID).
+		Msg("GG20 presigned signing completed")
+
+	return sigData, nil
+}
+
+// finalizePresignedSignature 与 tss-lib finalize 相同：计算恢复ID、规范化为 low-s 并验证签名
+func finalizePresignedSignature(ec elliptic.Curve, pubKey *crypto.ECPoint, hash []byte, rx, ry, s *big.Int) (*common.SignatureData, error) {
+	N := ec.Params().N
+	r := new(big.Int).Mod(rx, N)
+	s = new(big.Int).Mod(s, N)
+	if r.Sign() == 0 || s.Sign() == 0 {
+		return nil, errors.New("invalid presigned signature: zero r or s")
+	}
+
+	recid := 0
+	if rx.Cmp(N) > 0 {
+		recid = 2
+	}
+	if ry.Bit(0) != 0 {
+		recid |= 1
+	}
+	halfN := new(big.Int).Rsh(N, 1)
+	if s.Cmp(halfN) > 0 {
+		s.Sub(N, s)
+		recid ^= 1
+	}
+
+	pk := ecdsa.PublicKey{Curve: ec, X: pubKey.X(), Y: pubKey.Y()}
+	if !ecdsa.Verify(&pk, hash, r, s) {
+		return nil, errors.New("presigned signature verification failed")
+	}
+
+	rBytes := padScalarBytes(r.Bytes())
+	sBytes := padScalarBytes(s.Bytes())
+	return &common.SignatureData{
+		Signature:         append(append([]byte(nil), rBytes...), sBytes...),
+		SignatureRecovery: []byte{byte(recid)},
+		R:                 rBytes,
+		S:                 sBytes,
+		M:                 hash,
+	}, nil
+}
+
+// registerSigningQueue 为会话创建签名消息队列（ProcessIncomingSigningMessage 会等待队列创建）
+func (m *tssPartyManager) registerSigningQueue(sessionID string) chan *incomingMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	msgCh, exists := m.incomingSigningMessages[sessionID]
+	if !exists {
+		msgCh = make(chan *incomingMessage, 100)
+		m.incomingSigningMessages[sessionID] = msgCh
+	}
+	m.sessionIDMap[sessionID] = sessionID
+	return msgCh
+}
+
+// unregisterSigningQueue 移除会话的签名消息队列
+// 不关闭通道：ProcessIncomingSigningMessage 可能仍持有引用，关闭后写入会 panic
+func (m *tssPartyManager) unregisterSigningQueue(sessionID string, msgCh chan *incomingMessage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.incomingSigningMessages[sessionID]; ok && current == msgCh {
+		delete(m.incomingSigningMessages, sessionID)
+	}
+	delete(m.sessionIDMap, sessionID)
+}
+
+// routeSessionMessage 将消息路由到会话参与节点（广播消息发给除自身外的所有参与节点）
+// 与 executeSigning 不同，目标节点只限于本会话的参与节点，而不是全局映射中的所有节点
+func (m *tssPartyManager) routeSessionMessage(sessionID string, thisNodeID string, nodeIDs []string, msg tss.Message) error {
+	if m.messageRouter == nil {
+		return errors.New("messageRouter is nil, cannot route signing message")
+	}
+
+	targets := make([]string, 0, len(nodeIDs))
+	if msg.IsBroadcast() || len(msg.GetTo()) == 0 {
+		for _, nodeID := range nodeIDs {
+			if nodeID != thisNodeID {
+				targets = append(targets, nodeID)
+			}
+		}
+	} else {
+		for _, to := range msg.GetTo() {
+			// 签名会话的 PartyID.Id 即节点ID（与分片轮次无关）
+			if !containsNodeID(nodeIDs, to.Id) {
+				return errors.Errorf("party ID to node ID mapping not found: %s", to.Id)
+			}
+			targets = append(targets, to.Id)
+		}
+	}
+
+	for _, targetNodeID := range targets {
+		if err := m.messageRouter(sessionID, targetNodeID, msg, msg.IsBroadcast()); err != nil {
+			return errors.Wrapf(err, "route message to node %s", targetNodeID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/ecdsa/signing"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomScalar(t *testing.T) *big.Int {
+	t.Helper()
+	k, err := rand.Int(rand.Reader, tss.S256().Params().N)
+	require.NoError(t, err)
+	return k
+}
+
+// TestFinalizePresignedSignature 按 GG20 离线阶段的关系构造两方份额，验证在线阶段合成的签名
+func TestFinalizePresignedSignature(t *testing.T) {
+	ec := tss.S256()
+	modN := common.ModInt(ec.Params().N)
+
+	x := randomScalar(t)
+	pubKey := crypto.ScalarBaseMult(ec, x)
+
+	// k = k1 + k2，σ = k·x = σ1 + σ2，R = g^{k^-1}
+	k1, k2 := randomScalar(t), randomScalar(t)
+	k := modN.Add(k1, k2)
+	sigma := modN.Mul(k, x)
+	sigma1 := randomScalar(t)
+	sigma2 := modN.Sub(sigma, sigma1)
+	R := crypto.ScalarBaseMult(ec, modN.ModInverse(k))
+
+	hash := sha256.Sum256([]byte("hello presign"))
+	m := new(big.Int).SetBytes(hash[:])
+	s1 := modN.Add(modN.Mul(m, k1), modN.Mul(R.X(), sigma1))
+	s2 := modN.Add(modN.Mul(m, k2), modN.Mul(R.X(), sigma2))
+
+	sigData, err := finalizePresignedSignature(ec, pubKey, hash[:], R.X(), R.Y(), modN.Add(s1, s2))
+	require.NoError(t, err)
+	require.Len(t, sigData.R, 32)
+	require.Len(t, sigData.S, 32)
+	require.Len(t, sigData.SignatureRecovery, 1)
+
+	s := new(big.Int).SetBytes(sigData.S)
+	assert.True(t, s.Cmp(new(big.Int).Rsh(ec.Params().N, 1)) <= 0, "signature must be low-s")
+
+	pk := ecdsa.PublicKey{Curve: ec, X: pubKey.X(), Y: pubKey.Y()}
+	assert.True(t, ecdsa.Verify(&pk, hash[:], new(big.Int).SetBytes(sigData.R), s))
+
+	// 缺少一个份额时签名无效
+	_, err = finalizePresignedSignature(ec, pubKey, hash[:], R.X(), R.Y(), s1)
+	assert.Error(t, err)
+}
+
+// TestSumPresignCheckPoints 测试离线阶段的一致性校验
+func TestSumPresignCheckPoints(t *testing.T) {
+	ec := tss.S256()
+	modN := common.ModInt(ec.Params().N)
+
+	x := randomScalar(t)
+	pubKey := crypto.ScalarBaseMult(ec, x)
+	k1, k2 := randomScalar(t), randomScalar(t)
+	k := modN.Add(k1, k2)
+	sigma1 := randomScalar(t)
+	sigma2 := modN.Sub(modN.Mul(k, x), sigma1)
+	R := crypto.ScalarBaseMult(ec, modN.ModInverse(k))
+
+	check := func(ki, sigmai *big.Int) []*big.Int {
+		kR, sigmaR := R.ScalarMult(ki), R.ScalarMult(sigmai)
+		return []*big.Int{kR.X(), kR.Y(), sigmaR.X(), sigmaR.Y()}
+	}
+
+	valid := map[int][]*big.Int{0: check(k1, sigma1), 1: check(k2, sigma2)}
+	require.NoError(t, sumPresignCheckPoints(ec, pubKey, valid))
+
+	tampered := map[int][]*big.Int{0: check(k1, sigma1), 1: check(k2, sigma1)}
+	assert.Error(t, sumPresignCheckPoints(ec, pubKey, tampered))
+
+	assert.Error(t, sumPresignCheckPoints(ec, pubKey, map[int][]*big.Int{}))
+}
+
+// TestSigningMessageIsBroadcast 测试签名消息的广播类型推断
+func TestSigningMessageIsBroadcast(t *testing.T) {
+	assert.False(t, signingMessageIsBroadcast(&signing.SignRound1Message1{}))
+	assert.False(t, signingMessageIsBroadcast(&signing.SignRound2Message{}))
+	assert.True(t, signingMessageIsBroadcast(&signing.SignRound1Message2{}))
+	assert.True(t, signingMessageIsBroadcast(&signing.SignRound4Message{}))
+	assert.True(t, signingMessageIsBroadcast(&signing.SignRound9Message{}))
+}
+
+// TestGG20Protocol_ConsumePresignature 测试预签名只能使用一次
+func TestGG20Protocol_ConsumePresignature(t *testing.T) {
+	ctx := context.Background()
+	storage := newMemoryKeyDataStorage()
+	protocol := NewGG20Protocol("secp256k1", "node-1", mockMessageRouter, storage)
+
+	presig := &presignature{
+		ID:      "presign-1",
+		KeyID:   "key-1",
+		NodeIDs: []string{"node-1", "node-2"},
+		ShareID: big.NewInt(1),
+		K:       big.NewInt(2),
+		Sigma:   big.NewInt(3),
+		Rx:      big.NewInt(4),
+		Ry:      big.NewInt(5),
+	}
+	data, err := json.Marshal(presig)
+	require.NoError(t, err)
+	require.NoError(t, storage.StoreKeyData(ctx, presignStorageKeyID("key-1", "presign-1"), "node-1", data))
+
+	_, err = protocol.consumePresignature(ctx, "key-2", "presign-1")
+	assert.Error(t, err, "presignature of another key")
+
+	require.NoError(t, storage.StoreKeyData(ctx, presignStorageKeyID("key-1", "presign-1"), "node-1", data))
+	consumed, err := protocol.consumePresignature(ctx, "key-1", "presign-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), consumed.K.Int64())
+
+	_, err = protocol.consumePresignature(ctx, "key-1", "presign-1")
+	assert.Error(t, err, "presignature must not be reusable")
+
+	_, err = protocol.consumePresignature(ctx, "key-1", "../key-1")
+	assert.Error(t, err, "invalid presignature ID")
+}
+
+func TestSameNodeSet(t *testing.T) {
+	assert.True(t, sameNodeSet([]string{"node-1", "node-2"}, []string{"node-2", "node-1"}))
+	assert.False(t, sameNodeSet([]string{"node-1", "node-2"}, []string{"node-1", "node-3"}))
+	assert.False(t, sameNodeSet([]string{"node-1"}, []string{"node-1", "node-2"}))
+}
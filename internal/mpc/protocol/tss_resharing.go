@@ -0,0 +1,554 @@
+package protocol
+
+import (
+	"context"
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/ecdsa/resharing"
+	eddsaKeygen "github.com/kashguard/tss-lib/eddsa/keygen"
+	eddsaResharing "github.com/kashguard/tss-lib/eddsa/resharing"
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// maxShareEpoch 分片轮次上限，用于从 ShareID 反推分片所属的轮次
+const maxShareEpoch = 4096
+
+// resharingRoute 描述一类 resharing 消息的发送方和接收方委员会
+type resharingRoute struct {
+	fromNew bool // 发送方属于新委员会
+	toOld   bool // 旧委员会需要接收
+	toNew   bool // 新委员会需要接收
+}
+
+// resharingRoutes 按 protobuf 消息全名索引的路由表
+// ECDSA 与 EdDSA 的消息位于不同的 protobuf 包，可以共用一张表
+var resharingRoutes = map[protoreflect.FullName]resharingRoute{
+	// ECDSA
+	proto.MessageName(&resharing.DGRound1Message{}):  {fromNew: false, toNew: true},
+	proto.MessageName(&resharing.DGRound2Message1{}): {fromNew: true, toNew: true},
+	proto.MessageName(&resharing.DGRound2Message2{}): {fromNew: true, toOld: true},
+	proto.MessageName(&resharing.DGRound3Message1{}): {fromNew: false, toNew: true},
+	proto.MessageName(&resharing.DGRound3Message2{}): {fromNew: false, toNew: true},
+	proto.MessageName(&resharing.DGRound4Message1{}): {fromNew: true, toNew: true},
+	proto.MessageName(&resharing.DGRound4Message2{}): {fromNew: true, toOld: true, toNew: true},
+	// EdDSA
+	proto.MessageName(&eddsaResharing.DGRound1Message{}):  {fromNew: false, toNew: true},
+	proto.MessageName(&eddsaResharing.DGRound2Message{}):  {fromNew: true, toOld: true},
+	proto.MessageName(&eddsaResharing.DGRound3Message1{}): {fromNew: false, toNew: true},
+	proto.MessageName(&eddsaResharing.DGRound3Message2{}): {fromNew: false, toNew: true},
+	proto.MessageName(&eddsaResharing.DGRound4Message{}):  {fromNew: true, toOld: true, toNew: true},
+}
+
+// classifyResharingMessage 根据消息内容类型确定其路由
+// tss-lib 的 wire 字节不携带委员会信息，接收方只能通过消息类型判断发送方角色
+func classifyResharingMessage(msgBytes []byte) (resharingRoute, error) {
+	wire := &anypb.Any{}
+	if err := proto.Unmarshal(msgBytes, wire); err != nil {
+		return resharingRoute{}, errors.Wrap(err, "failed to decode resharing message")
+	}
+	route, ok := resharingRoutes[wire.MessageName()]
+	if !ok {
+		return resharingRoute{}, errors.Errorf("unexpected resharing message type: %s", wire.MessageName())
+	}
+	return route, nil
+}
+
+// resharingSession 单个节点在一次 resharing 中的状态
+// 同一节点可能同时属于新旧委员会，此时本地运行两个 Party（旧分片持有者和新分片接收者）
+type resharingSession struct {
+	sessionID  string
+	thisNodeID string
+
+	oldParties tss.SortedPartyIDs
+	newParties tss.SortedPartyIDs
+	oldByNode  map[string]*tss.PartyID
+	newByNode  map[string]*tss.PartyID
+	// PartyID 密钥（hex）-> 节点ID
+	nodeByKey map[string]string
+
+	oldParty tss.Party
+	newParty tss.Party
+
+	// 协议错误（Start 失败或注入消息时出错）
+	errCh chan *tss.Error
+}
+
+// newResharingSession 根据请求构建新旧委员会的 PartyID
+func newResharingSession(req *ReshareRequest, thisNodeID string) (*resharingSession, error) {
+	if req.NewEpoch <= req.OldEpoch {
+		return nil, errors.Errorf("new epoch %d must be greater than old epoch %d", req.NewEpoch, req.OldEpoch)
+	}
+	if req.OldThreshold < 1 || len(req.OldNodeIDs) < req.OldThreshold+1 {
+		return nil, errors.Errorf("old committee needs at least %d nodes, got %d", req.OldThreshold+1, len(req.OldNodeIDs))
+	}
+	if req.NewThreshold < 1 || len(req.NewNodeIDs) < req.NewThreshold+1 {
+		return nil, errors.Errorf("new committee needs at least %d nodes, got %d", req.NewThreshold+1, len(req.NewNodeIDs))
+	}
+
+	rs := &resharingSession{
+		sessionID:  req.SessionID,
+		thisNodeID: thisNodeID,
+		nodeByKey:  make(map[string]string),
+		errCh:      make(chan *tss.Error, 4),
+	}
+	rs.oldParties, rs.oldByNode = buildEpochPartyIDs(req.OldNodeIDs, req.OldEpoch)
+	rs.newParties, rs.newByNode = buildEpochPartyIDs(req.NewNodeIDs, req.NewEpoch)
+	for nodeID, partyID := range rs.oldByNode {
+		rs.nodeByKey[hex.EncodeToString(partyID.Key)] = nodeID
+	}
+	for nodeID, partyID := range rs.newByNode {
+		rs.nodeByKey[hex.EncodeToString(partyID.Key)] = nodeID
+	}
+
+	_, inOld := rs.oldByNode[thisNodeID]
+	_, inNew := rs.newByNode[thisNodeID]
+	if !inOld && !inNew {
+		return nil, errors.Errorf("node %s is not a member of the old or new committee", thisNodeID)
+	}
+
+	return rs, nil
+}
+
+// reSharingParameters 构建本节点在指定委员会中的 resharing 参数
+func (rs *resharingSession) reSharingParameters(curve elliptic.Curve, partyID *tss.PartyID, oldThreshold, newThreshold int) *tss.ReSharingParameters {
+	return tss.NewReSharingParameters(
+		curve,
+		tss.NewPeerContext(rs.oldParties),
+		tss.NewPeerContext(rs.newParties),
+		partyID,
+		len(rs.oldParties),
+		oldThreshold,
+		len(rs.newParties),
+		newThreshold,
+	)
+}
+
+// reportError 上报协议错误（非阻塞，只保留最先出现的几个错误）
+func (rs *resharingSession) reportError(err *tss.Error) {
+	select {
+	case rs.errCh <- err:
+	default:
+	}
+}
+
+// localTargets 返回需要接收该消息的本地 Party（排除发送方自身）
+func (rs *resharingSession) localTargets(route resharingRoute, from *tss.PartyID) []tss.Party {
+	targets := make([]tss.Party, 0, 2)
+	if route.toOld && rs.oldParty != nil && rs.oldParty.PartyID() != from {
+		targets = append(targets, rs.oldParty)
+	}
+	if route.toNew && rs.newParty != nil && rs.newParty.PartyID() != from {
+		targets = append(targets, rs.newParty)
+	}
+	return targets
+}
+
+// senderPartyID 根据消息路由确定发送方在其委员会中的 PartyID
+func (rs *resharingSession) senderPartyID(route resharingRoute, fromNodeID string) (*tss.PartyID, bool) {
+	if route.fromNew {
+		partyID, ok := rs.newByNode[fromNodeID]
+		return partyID, ok
+	}
+	partyID, ok := rs.oldByNode[fromNodeID]
+	return partyID, ok
+}
+
+// validateOldCommitteeShares 校验旧委员会的 PartyID 都在本地分片的 Ks 中
+// tss-lib 的 BuildLocalSaveDataSubset 遇到未知的 PartyID 会直接 panic
+func validateOldCommitteeShares(ks []*big.Int, oldParties tss.SortedPartyIDs) error {
+	known := make(map[string]struct{}, len(ks))
+	for _, k := range ks {
+		if k != nil {
+			known[k.String()] = struct{}{}
+		}
+	}
+	for _, partyID := range oldParties {
+		if _, ok := known[partyID.KeyInt().String()]; !ok {
+			return errors.Errorf("old committee member %s does not hold a share of this key at the given epoch", partyID.Id)
+		}
+	}
+	return nil
+}
+
+// executeResharing 执行 ECDSA 密钥重分享（GG18/GG20 共用）
+// keyData 为本节点当前的分片（仅旧委员会成员需要），返回本节点在新委员会中的分片（不在新委员会则为 nil）
+func (m *tssPartyManager) executeResharing(
+	ctx context.Context,
+	req *ReshareRequest,
+	thisNodeID string,
+	keyData *keygen.LocalPartySaveData,
+) (*keygen.LocalPartySaveData, error) {
+	rs, err := newResharingSession(req, thisNodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	bufSize := 2 * (len(rs.oldParties) + len(rs.newParties))
+	outCh := make(chan tss.Message, bufSize)
+	var oldEndCh, newEndCh chan *keygen.LocalPartySaveData
+
+	if oldPartyID, ok := rs.oldByNode[thisNodeID]; ok {
+		if keyData == nil || keyData.Xi == nil {
+			return nil, errors.New("node is in the old committee but has no key share")
+		}
+		if err := validateOldCommitteeShares(keyData.Ks, rs.oldParties); err != nil {
+			return nil, err
+		}
+		// 复制 Xi：tss-lib 在结束时会把旧分片的 Xi 原地清零
+		input := *keyData
+		input.Xi = new(big.Int).Set(keyData.Xi)
+		oldEndCh = make(chan *keygen.LocalPartySaveData, 1)
+		params := rs.reSharingParameters(tss.S256(), oldPartyID, req.OldThreshold, req.NewThreshold)
+		rs.oldParty = resharing.NewLocalParty(params, input, outCh, oldEndCh)
+	}
+
+	if newPartyID, ok := rs.newByNode[thisNodeID]; ok {
+		// 复用已有的 Paillier 预参数，避免在 round 2 重新生成
+		input := keygen.NewLocalPartySaveData(len(rs.newParties))
+		if keyData != nil {
+			input.LocalPreParams = keyData.LocalPreParams
+		} else if preParams, ok := m.takePreParams(ctx); ok {
+			// 新加入的节点没有旧分片，从预参数池获取
+			input.LocalPreParams = *preParams
+		}
+		newEndCh = make(chan *keygen.LocalPartySaveData, 1)
+		params := rs.reSharingParameters(tss.S256(), newPartyID, req.OldThreshold, req.NewThreshold)
+		rs.newParty = resharing.NewLocalParty(params, input, outCh, newEndCh)
+	}
+
+	newSave, err := runResharing(ctx, m, rs, outCh, oldEndCh, newEndCh)
+	if err != nil {
+		return nil, errors.Wrap(err, "ECDSA resharing")
+	}
+	// tss-lib 的新分片是各子分片之和，没有对曲线阶取模
+	if newSave != nil && newSave.Xi != nil {
+		newSave.Xi = new(big.Int).Mod(newSave.Xi, tss.S256().Params().N)
+	}
+	return newSave, nil
+}
+
+// executeEdDSAResharing 执行 EdDSA 密钥重分享（FROST 使用）
+func (m *tssPartyManager) executeEdDSAResharing(
+	ctx context.Context,
+	req *ReshareRequest,
+	thisNodeID string,
+	keyData *eddsaKeygen.LocalPartySaveData,
+) (*eddsaKeygen.LocalPartySaveData, error) {
+	rs, err := newResharingSession(req, thisNodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	bufSize := 2 * (len(rs.oldParties) + len(rs.newParties))
+	outCh := make(chan tss.Message, bufSize)
+	var oldEndCh, newEndCh chan *eddsaKeygen.LocalPartySaveData
+
+	if oldPartyID, ok := rs.oldByNode[thisNodeID]; ok {
+		if keyData == nil || keyData.Xi == nil {
+			return nil, errors.New("node is in the old committee but has no key share")
+		}
+		if err := validateOldCommitteeShares(keyData.Ks, rs.oldParties); err != nil {
+			return nil, err
+		}
+		input := *keyData
+		input.Xi = new(big.Int).Set(keyData.Xi)
+		oldEndCh = make(chan *eddsaKeygen.LocalPartySaveData, 1)
+		params := rs.reSharingParameters(tss.Edwards(), oldPartyID, req.OldThreshold, req.NewThreshold)
+		rs.oldParty = eddsaResharing.NewLocalParty(params, input, outCh, oldEndCh)
+	}
+
+	if newPartyID, ok := rs.newByNode[thisNodeID]; ok {
+		input := eddsaKeygen.NewLocalPartySaveData(len(rs.newParties))
+		newEndCh = make(chan *eddsaKeygen.LocalPartySaveData, 1)
+		params := rs.reSharingParameters(tss.Edwards(), newPartyID, req.OldThreshold, req.NewThreshold)
+		rs.newParty = eddsaResharing.NewLocalParty(params, input, outCh, newEndCh)
+	}
+
+	newSave, err := runResharing(ctx, m, rs, outCh, oldEndCh, newEndCh)
+	if err != nil {
+		return nil, errors.Wrap(err, "EdDSA resharing")
+	}
+	return newSave, nil
+}
+
+// runResharing 驱动本地 Party 执行 resharing：路由输出消息、注入接收到的消息并收集结果
+// 新委员会的结果作为返回值；旧委员会的结果只用于确认本地旧分片已完成交接
+func runResharing[T any](
+	ctx context.Context,
+	m *tssPartyManager,
+	rs *resharingSession,
+	outCh chan tss.Message,
+	oldEndCh, newEndCh chan *T,
+) (*T, error) {
+	sessionID := rs.sessionID
+
+	m.mu.Lock()
+	if _, exists := m.activeResharing[sessionID]; exists {
+		m.mu.Unlock()
+		return nil, errors.Errorf("resharing session %s is already running", sessionID)
+	}
+	m.activeResharing[sessionID] = rs
+	msgCh, exists := m.incomingResharingMessages[sessionID]
+	if !exists {
+		msgCh = make(chan *incomingMessage, 100)
+		m.incomingResharingMessages[sessionID] = msgCh
+	}
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.activeResharing, sessionID)
+		delete(m.incomingResharingMessages, sessionID)
+		m.mu.Unlock()
+	}()
+
+	log.Info().
+		Str("session_id", sessionID).
+		Str("this_node_id", rs.thisNodeID).
+		Int("old_party_count", len(rs.oldParties)).
+		Int("new_party_count", len(rs.newParties)).
+		Bool("in_old_committee", rs.oldParty != nil).
+		Bool("in_new_committee", rs.newParty != nil).
+		Msg("Starting TSS resharing")
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, party := range []tss.Party{rs.oldParty, rs.newParty} {
+		if party == nil {
+			continue
+		}
+		go func(party tss.Party) {
+			if err := party.Start(); err != nil {
+				rs.reportError(err)
+			}
+		}(party)
+	}
+
+	// 消息处理循环：把其他节点发来的消息注入对应角色的本地 Party
+	go func() {
+		for {
+			select {
+			case <-loopCtx.Done():
+				return
+			case incomingMsg := <-msgCh:
+				if err := deliverResharingMessage(rs, incomingMsg); err != nil {
+					log.Warn().
+						Err(err).
+						Str("session_id", sessionID).
+						Str("this_node_id", rs.thisNodeID).
+						Str("from_node_id", incomingMsg.fromNodeID).
+						Bool("is_broadcast", incomingMsg.isBroadcast).
+						Msg("Failed to deliver resharing message")
+				}
+			}
+		}
+	}()
+
+	// 使用调用方上下文的截止时间作为超时，否则默认 10 分钟（新节点可能需要生成 Paillier 预参数）
+	timeoutDur := 10 * time.Minute
+	if deadline, ok := ctx.Deadline(); ok {
+		timeoutDur = time.Until(deadline)
+	}
+	timeout := time.NewTimer(timeoutDur)
+	defer timeout.Stop()
+
+	oldDone := oldEndCh == nil
+	newDone := newEndCh == nil
+	var newSave *T
+
+	for !oldDone || !newDone {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			return nil, errors.Errorf("resharing timeout (session %s)", sessionID)
+		case err := <-rs.errCh:
+			return nil, errors.Wrap(err, "resharing protocol error")
+		case msg := <-outCh:
+			if err := m.routeResharingMessage(rs, msg); err != nil {
+				return nil, err
+			}
+		case <-oldEndCh:
+			oldDone = true
+			log.Info().
+				Str("session_id", sessionID).
+				Str("this_node_id", rs.thisNodeID).
+				Msg("Old committee share handed over")
+		case save := <-newEndCh:
+			if save == nil {
+				return nil, errors.New("resharing returned nil key data")
+			}
+			newSave = save
+			newDone = true
+			log.Info().
+				Str("session_id", sessionID).
+				Str("this_node_id", rs.thisNodeID).
+				Msg("New committee share received")
+		}
+	}
+
+	return newSave, nil
+}
+
+// routeResharingMessage 把本地 Party 输出的消息发往目标节点
+// 目标在本节点的消息直接注入本地另一个角色的 Party；同一节点上的多个目标只发送一次
+func (m *tssPartyManager) routeResharingMessage(rs *resharingSession, msg tss.Message) error {
+	msgBytes, _, err := msg.WireBytes()
+	if err != nil {
+		return errors.Wrap(err, "serialize resharing message")
+	}
+
+	sent := make(map[string]struct{}, len(msg.GetTo()))
+	for _, to := range msg.GetTo() {
+		targetNodeID, ok := rs.nodeByKey[hex.EncodeToString(to.Key)]
+		if !ok {
+			return errors.Errorf("party ID to node ID mapping not found: %s", to.Id)
+		}
+		if _, done := sent[targetNodeID]; done {
+			continue
+		}
+		sent[targetNodeID] = struct{}{}
+
+		if targetNodeID == rs.thisNodeID {
+			route, err := classifyResharingMessage(msgBytes)
+			if err != nil {
+				return err
+			}
+			for _, party := range rs.localTargets(route, msg.GetFrom()) {
+				// 异步注入，避免 Party 在处理消息时向已满的 outCh 写入而阻塞主循环
+				go func(party tss.Party) {
+					if ok, tssErr := party.UpdateFromBytes(msgBytes, msg.GetFrom(), msg.IsBroadcast()); !ok || tssErr != nil {
+						if tssErr != nil {
+							rs.reportError(tssErr)
+						}
+						log.Warn().
+							Err(tssErr).
+							Str("session_id", rs.sessionID).
+							Str("this_node_id", rs.thisNodeID).
+							Msg("Failed to deliver local resharing message")
+					}
+				}(party)
+			}
+			continue
+		}
+
+		if m.messageRouter == nil {
+			return errors.New("messageRouter is nil, cannot route resharing message")
+		}
+		if err := m.messageRouter(rs.sessionID, targetNodeID, msg, msg.IsBroadcast()); err != nil {
+			return errors.Wrapf(err, "route resharing message to node %s", targetNodeID)
+		}
+	}
+
+	return nil
+}
+
+// deliverResharingMessage 将其他节点发来的消息注入本地 Party
+func deliverResharingMessage(rs *resharingSession, incomingMsg *incomingMessage) error {
+	route, err := classifyResharingMessage(incomingMsg.msgBytes)
+	if err != nil {
+		return err
+	}
+
+	fromPartyID, ok := rs.senderPartyID(route, incomingMsg.fromNodeID)
+	if !ok {
+		return errors.Errorf("sender %s is not a member of the expected committee", incomingMsg.fromNodeID)
+	}
+
+	for _, party := range rs.localTargets(route, fromPartyID) {
+		ok, tssErr := party.UpdateFromBytes(incomingMsg.msgBytes, fromPartyID, incomingMsg.isBroadcast)
+		if tssErr != nil {
+			rs.reportError(tssErr)
+			return errors.Wrap(tssErr, "update party from bytes")
+		}
+		if !ok {
+			return errors.New("party rejected resharing message")
+		}
+	}
+
+	return nil
+}
+
+// ProcessIncomingResharingMessage 处理接收到的 resharing 消息
+// 参与节点可能在本地会话创建前就收到消息，因此最多等待 10 秒
+func (m *tssPartyManager) ProcessIncomingResharingMessage(
+	ctx context.Context,
+	sessionID string,
+	fromNodeID string,
+	msgBytes []byte,
+	isBroadcast bool,
+) error {
+	waitTimeout := time.NewTimer(10 * time.Second)
+	defer waitTimeout.Stop()
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	var msgCh chan *incomingMessage
+	for {
+		m.mu.RLock()
+		ch, exists := m.incomingResharingMessages[sessionID]
+		m.mu.RUnlock()
+		if exists {
+			msgCh = ch
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-waitTimeout.C:
+			return errors.Errorf("timeout waiting for resharing message queue (session %s)", sessionID)
+		case <-ticker.C:
+		}
+	}
+
+	select {
+	case msgCh <- &incomingMessage{msgBytes: msgBytes, fromNodeID: fromNodeID, isBroadcast: isBroadcast}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		log.Warn().
+			Str("session_id", sessionID).
+			Str("from_node_id", fromNodeID).
+			Msg("Resharing message queue full, message dropped")
+		return errors.Errorf("resharing message queue full for session %s", sessionID)
+	}
+}
+
+// validateReshareRequest 验证密钥重分享请求
+func validateReshareRequest(req *ReshareRequest) error {
+	if req == nil {
+		return errors.New("reshare request is nil")
+	}
+	if req.KeyID == "" {
+		return errors.New("key ID is required")
+	}
+	if req.SessionID == "" {
+		return errors.New("session ID is required")
+	}
+	if len(req.OldNodeIDs) == 0 || len(req.NewNodeIDs) == 0 {
+		return errors.New("old and new node IDs are required")
+	}
+	return nil
+}
+
+// containsNodeID 判断节点是否在列表中
+func containsNodeID(nodeIDs []string, nodeID string) bool {
+	for _, id := range nodeIDs {
+		if id == nodeID {
+			return true
+		}
+	}
+	return false
+}
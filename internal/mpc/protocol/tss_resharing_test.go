@@ -0,0 +1,135 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kashguard/tss-lib/tss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tssTestEngine GG18/GG20 共用的测试接口
+type tssTestEngine interface {
+	Engine
+	SetPreParamsPool(pool *PreParamsPool)
+}
+
+// newTSSTestCluster 进程内的多节点 GG18/GG20 集群，复用 CGGMP 测试的预参数
+func newTSSTestCluster(t *testing.T, newEngine func(nodeID string, router func(string, string, tss.Message, bool) error, storage KeyShareStorage) tssTestEngine, nodeIDs ...string) (map[string]tssTestEngine, *memoryKeyDataStorage) {
+	storage := newMemoryKeyDataStorage()
+	nodes := make(map[string]tssTestEngine, len(nodeIDs))
+	preParams := loadCGGMPPreParams(t)
+	for i, nodeID := range nodeIDs {
+		from := nodeID
+		router := func(sessionID string, toNodeID string, msg tss.Message, isBroadcast bool) error {
+			data, _, err := msg.WireBytes()
+			if err != nil {
+				return err
+			}
+			target, ok := nodes[toNodeID]
+			if !ok {
+				return fmt.Errorf("unknown node %s", toNodeID)
+			}
+			ctx := context.Background()
+			switch {
+			case strings.HasPrefix(sessionID, "reshare-"):
+				return target.ProcessIncomingResharingMessage(ctx, sessionID, from, data, isBroadcast)
+			case strings.HasPrefix(sessionID, "key-"):
+				return target.ProcessIncomingKeygenMessage(ctx, sessionID, from, data, isBroadcast)
+			default:
+				return target.ProcessIncomingSigningMessage(ctx, sessionID, from, data, isBroadcast)
+			}
+		}
+		node := newEngine(nodeID, router, storage)
+		pool := NewPreParamsPool(nodeID, nil, 0, 0)
+		pool.entries = append(pool.entries, preParams[i])
+		node.SetPreParamsPool(pool)
+		nodes[nodeID] = node
+	}
+	return nodes, storage
+}
+
+// TestTSSResharing_EndToEnd GG18/GG20 keygen、重分享后用新委员会签名，公钥不变且旧分片不能再签名
+func TestTSSResharing_EndToEnd(t *testing.T) {
+	engines := map[string]func(nodeID string, router func(string, string, tss.Message, bool) error, storage KeyShareStorage) tssTestEngine{
+		"gg18": func(nodeID string, router func(string, string, tss.Message, bool) error, storage KeyShareStorage) tssTestEngine {
+			return NewGG18Protocol("secp256k1", nodeID, router, storage)
+		},
+		"gg20": func(nodeID string, router func(string, string, tss.Message, bool) error, storage KeyShareStorage) tssTestEngine {
+			return NewGG20Protocol("secp256k1", nodeID, router, storage)
+		},
+	}
+	for name, newEngine := range engines {
+		t.Run(name, func(t *testing.T) {
+			nodes, storage := newTSSTestCluster(t, newEngine, "node-1", "node-2", "node-3", "node-4")
+			keyID := "key-" + name
+			message := []byte(name + " resharing end to end")
+
+			// tss-lib 的 Threshold 为 t，需要 t+1 个节点参与签名
+			committee := []string{"node-1", "node-2", "node-3"}
+			keygen, errs := cggmpRunAll(committee, func(ctx context.Context, nodeID string) (*KeyGenResponse, error) {
+				return nodes[nodeID].GenerateKeyShare(ctx, &KeyGenRequest{
+					KeyID:      keyID,
+					Algorithm:  "ECDSA",
+					Curve:      "secp256k1",
+					Threshold:  2,
+					TotalNodes: len(committee),
+					NodeIDs:    committee,
+				})
+			})
+			require.Empty(t, errs)
+			publicKey := keygen["node-1"].PublicKey
+
+			sign := func(sessionID string, signers []string) *SignResponse {
+				t.Helper()
+				results, errs := cggmpRunAll(signers, func(ctx context.Context, nodeID string) (*SignResponse, error) {
+					return nodes[nodeID].ThresholdSign(ctx, sessionID, &SignRequest{KeyID: keyID, Message: message, NodeIDs: signers})
+				})
+				require.Empty(t, errs)
+				resp := results[signers[0]]
+				valid, err := verifyECDSASignature(resp.Signature, message, publicKey)
+				require.NoError(t, err)
+				require.True(t, valid)
+				return resp
+			}
+			sign("sign-1", committee)
+
+			// 重分享：node-1 退出，node-4 加入
+			oldShare, err := storage.GetKeyData(context.Background(), keyID, "node-2")
+			require.NoError(t, err)
+			reshareReq := ReshareRequest{
+				SessionID:    "reshare-" + name,
+				KeyID:        keyID,
+				OldNodeIDs:   committee,
+				OldThreshold: 2,
+				NewNodeIDs:   []string{"node-2", "node-3", "node-4"},
+				NewThreshold: 2,
+				OldEpoch:     0,
+				NewEpoch:     1,
+			}
+			reshared, errs := cggmpRunAll([]string{"node-1", "node-2", "node-3", "node-4"}, func(ctx context.Context, nodeID string) (*ReshareResponse, error) {
+				r := reshareReq
+				return nodes[nodeID].RotateKey(ctx, &r)
+			})
+			require.Empty(t, errs)
+			for nodeID, r := range reshared {
+				assert.Equal(t, publicKey.Hex, r.PublicKey.Hex, "node %s", nodeID)
+			}
+			assert.Nil(t, reshared["node-1"].KeyShare)
+
+			// 旧分片失效：退出的节点已删除分片，留任节点的分片已替换
+			_, err = storage.GetKeyData(context.Background(), keyID, "node-1")
+			assert.Error(t, err, "old share must be discarded")
+			newShare, err := storage.GetKeyData(context.Background(), keyID, "node-2")
+			require.NoError(t, err)
+			assert.NotEqual(t, oldShare, newShare)
+			_, err = nodes["node-1"].ThresholdSign(context.Background(), "sign-old", &SignRequest{KeyID: keyID, Message: message, NodeIDs: committee})
+			require.Error(t, err)
+
+			sign("sign-2", reshareReq.NewNodeIDs)
+		})
+	}
+}
@@ -83,6 +83,18 @@ type SignRequest struct {
 	Message    []byte
 	MessageHex string
 	NodeIDs    []string
+	// PresignatureID 非空时使用该预签名执行单轮在线签名（GG20 和 FROST 支持）
+	PresignatureID string
+	// TaprootKeySpend 为 true 时按 BIP-341 key-path 调整群公钥签名（仅 FROST secp256k1）
+	TaprootKeySpend bool
+	// TaprootMerkleRoot 脚本树根哈希，为空时按 BIP-86 调整（仅 TaprootKeySpend 时有效）
+	TaprootMerkleRoot []byte
+	// DerivationPath 非强化 BIP-32 派生路径（如 m/0/5），非空时使用派生出的子密钥签名（仅 secp256k1）
+	DerivationPath string
+	// ChainCode 根密钥链码（DerivationPath 非空时必填）
+	ChainCode []byte
+	// Prehashed 为 true 时 Message 是调用方计算好的 32 字节摘要（如 EIP-191/EIP-712），ECDSA 引擎直接签名，不再做 SHA-256
+	Prehashed bool
 }
 
 // SignResponse 签名响应
@@ -101,3 +113,33 @@ type ProtocolMessage struct {
 	Data      []byte
 	Timestamp int64
 }
+
+// ReshareRequest 密钥重分享请求（更换参与节点和/或阈值，公钥保持不变）
+type ReshareRequest struct {
+	SessionID    string
+	KeyID        string
+	OldNodeIDs   []string
+	OldThreshold int
+	NewNodeIDs   []string
+	NewThreshold int
+	OldEpoch     int // 旧委员会分片所属的轮次
+	NewEpoch     int // 新委员会分片所属的轮次（必须大于 OldEpoch）
+}
+
+// PresignRequest 预签名（离线阶段）请求
+type PresignRequest struct {
+	KeyID   string
+	NodeIDs []string // 预签名的参与节点，在线签名时必须由相同的节点完成
+}
+
+// PresignResponse 预签名响应
+type PresignResponse struct {
+	PresignatureID string
+}
+
+// ReshareResponse 密钥重分享响应
+type ReshareResponse struct {
+	PublicKey *PublicKey
+	// KeyShare 当前节点在新委员会中的分片（仅属于旧委员会的节点为 nil）
+	KeyShare *KeyShare
+}
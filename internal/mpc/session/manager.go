@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/webhook"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
@@ -16,15 +18,19 @@ type Manager struct {
 	sessionStore  storage.SessionStore
 	timeout       time.Duration
 	stateStore    *StateStore
+	auditLogger   *audit.Logger
+	webhooks      *webhook.Service // DKG 完成和会话失败的 webhook 事件（未启用时不发布）
 }
 
 // NewManager 创建会话管理器
-func NewManager(metadataStore storage.MetadataStore, sessionStore storage.SessionStore, timeout time.Duration) *Manager {
+func NewManager(metadataStore storage.MetadataStore, sessionStore storage.SessionStore, timeout time.Duration, auditLogger *audit.Logger, webhooks *webhook.Service) *Manager {
 	return &Manager{
 		metadataStore: metadataStore,
 		sessionStore:  sessionStore,
 		timeout:       timeout,
 		stateStore:    NewStateStore(metadataStore, sessionStore),
+		auditLogger:   auditLogger,
+		webhooks:      webhooks,
 	}
 }
 
@@ -77,6 +83,109 @@ func (m *Manager) CreateSession(ctx context.Context, keyID string, protocol stri
 	return session, nil
 }
 
+// CreateResharingSession 创建 resharing 会话
+// 参与节点为新旧委员会的并集，Threshold/TotalNodes 记录新委员会的参数
+func (m *Manager) CreateResharingSession(ctx context.Context, keyID string, protocol string, newThreshold int, newTotalNodes int, nodeIDs []string) (*Session, error) {
+	sessionID := ResharingSessionPrefix + uuid.New().String()
+	now := time.Now()
+	expiresAt := now.Add(m.timeout)
+
+	session := &Session{
+		SessionID:          sessionID,
+		KeyID:              keyID,
+		Protocol:           protocol,
+		Status:             string(SessionStatusActive),
+		Threshold:          newThreshold,
+		TotalNodes:         newTotalNodes,
+		ParticipatingNodes: nodeIDs,
+		CurrentRound:       0,
+		TotalRounds:        5, // tss-lib resharing 需要5轮
+		CreatedAt:          now,
+		ExpiresAt:          expiresAt,
+	}
+
+	storageSession := &storage.SigningSession{
+		SessionID:          session.SessionID,
+		KeyID:              session.KeyID,
+		Protocol:           session.Protocol,
+		Status:             session.Status,
+		Threshold:          session.Threshold,
+		TotalNodes:         session.TotalNodes,
+		ParticipatingNodes: session.ParticipatingNodes,
+		CurrentRound:       session.CurrentRound,
+		TotalRounds:        session.TotalRounds,
+		Signature:          session.Signature,
+		CreatedAt:          session.CreatedAt,
+		CompletedAt:        session.CompletedAt,
+		DurationMs:         session.DurationMs,
+	}
+
+	if err := m.metadataStore.SaveSigningSession(ctx, storageSession); err != nil {
+		return nil, errors.Wrap(err, "failed to save resharing session to database")
+	}
+
+	// 保存到Redis缓存
+	if err := m.sessionStore.SaveSession(ctx, storageSession, m.timeout); err != nil {
+		log.Warn().
+			Err(err).
+			Str("session_id", session.SessionID).
+			Str("key_id", session.KeyID).
+			Msg("Failed to save resharing session to cache (non-critical)")
+	}
+
+	return session, nil
+}
+
+// CreatePresignSession 创建预签名会话（GG20 离线阶段）
+// 会话ID同时作为预签名ID，参与节点即之后使用该预签名的签名节点
+func (m *Manager) CreatePresignSession(ctx context.Context, keyID string, protocol string, nodeIDs []string) (*Session, error) {
+	sessionID := PresignSessionPrefix + uuid.New().String()
+	now := time.Now()
+	expiresAt := now.Add(m.timeout)
+
+	session := &Session{
+		SessionID:          sessionID,
+		KeyID:              keyID,
+		Protocol:           protocol,
+		Status:             string(SessionStatusActive),
+		Threshold:          len(nodeIDs),
+		TotalNodes:         len(nodeIDs),
+		ParticipatingNodes: nodeIDs,
+		CurrentRound:       0,
+		TotalRounds:        5, // 签名协议第1-4轮 + 一致性校验
+		CreatedAt:          now,
+		ExpiresAt:          expiresAt,
+	}
+
+	storageSession := &storage.SigningSession{
+		SessionID:          session.SessionID,
+		KeyID:              session.KeyID,
+		Protocol:           session.Protocol,
+		Status:             session.Status,
+		Threshold:          session.Threshold,
+		TotalNodes:         session.TotalNodes,
+		ParticipatingNodes: session.ParticipatingNodes,
+		CurrentRound:       session.CurrentRound,
+		TotalRounds:        session.TotalRounds,
+		CreatedAt:          session.CreatedAt,
+	}
+
+	if err := m.metadataStore.SaveSigningSession(ctx, storageSession); err != nil {
+		return nil, errors.Wrap(err, "failed to save presign session to database")
+	}
+
+	// 保存到Redis缓存
+	if err := m.sessionStore.SaveSession(ctx, storageSession, m.timeout); err != nil {
+		log.Warn().
+			Err(err).
+			Str("session_id", session.SessionID).
+			Str("key_id", session.KeyID).
+			Msg("Failed to save presign session to cache (non-critical)")
+	}
+
+	return session, nil
+}
+
 // CreateKeyGenSession 创建DKG会话（密钥生成会话）
 // 对于DKG，使用keyID作为sessionID，因为每个密钥的DKG是唯一的
 func (m *Manager) CreateKeyGenSession(ctx context.Context, keyID string, protocol string, threshold int, totalNodes int, nodeIDs []string) (*Session, error) {
@@ -245,6 +354,8 @@ func (m *Manager) UpdateSession(ctx context.Context, session *Session) error {
 		CreatedAt:          session.CreatedAt,
 		CompletedAt:        session.CompletedAt,
 		DurationMs:         session.DurationMs,
+		FailureReason:      session.FailureReason,
+		Culprits:           session.Culprits,
 	}
 
 	// 更新PostgreSQL
@@ -283,12 +394,16 @@ func (m *Manager) JoinSession(ctx context.Context, sessionID string, nodeID stri
 	}
 
 	// 添加节点
+	fromStatus := session.Status
 	session.ParticipatingNodes = append(session.ParticipatingNodes, nodeID)
 	session.Status = string(SessionStatusActive)
 
 	if err := m.UpdateSession(ctx, session); err != nil {
 		return errors.Wrap(err, "failed to update session")
 	}
+	if fromStatus != session.Status {
+		m.recordStateChange(ctx, session, fromStatus, map[string]interface{}{"node_id": nodeID})
+	}
 
 	return nil
 }
@@ -301,6 +416,7 @@ func (m *Manager) CompleteSession(ctx context.Context, sessionID string, signatu
 	}
 
 	now := time.Now()
+	fromStatus := session.Status
 	session.Status = string(SessionStatusCompleted)
 	session.Signature = signature
 	session.CompletedAt = &now
@@ -309,6 +425,7 @@ func (m *Manager) CompleteSession(ctx context.Context, sessionID string, signatu
 	if err := m.UpdateSession(ctx, session); err != nil {
 		return errors.Wrap(err, "failed to update session")
 	}
+	m.recordStateChange(ctx, session, fromStatus, map[string]interface{}{"duration_ms": session.DurationMs})
 
 	return nil
 }
@@ -339,6 +456,7 @@ func (m *Manager) CompleteKeygenSession(ctx context.Context, keyID string, publi
 	}
 
 	now := time.Now()
+	fromStatus := session.Status
 	session.Status = string(SessionStatusCompleted)
 	session.Signature = publicKey // 对于 DKG，将公钥写入 Signature 字段
 	session.CompletedAt = &now
@@ -355,6 +473,7 @@ func (m *Manager) CompleteKeygenSession(ctx context.Context, keyID string, publi
 	log.Info().
 		Str("key_id", keyID).
 		Msg("Keygen session updated successfully")
+	m.recordStateChange(ctx, session, fromStatus, map[string]interface{}{"public_key": publicKey, "duration_ms": session.DurationMs})
 
 	// 更新密钥元数据：公钥 + 状态 Active
 	keyMeta, err := m.metadataStore.GetKeyMetadata(ctx, keyID)
@@ -368,7 +487,8 @@ func (m *Manager) CompleteKeygenSession(ctx context.Context, keyID string, publi
 
 	oldStatus := keyMeta.Status
 	keyMeta.PublicKey = publicKey
-	keyMeta.Status = "Active"
+	keyMeta.Status = storage.KeyStatusEnabled
+	keyMeta.NodeIDs = session.ParticipatingNodes // DKG 参与节点即初始委员会
 	keyMeta.UpdatedAt = now
 
 	if err := m.metadataStore.UpdateKeyMetadata(ctx, keyMeta); err != nil {
@@ -376,7 +496,7 @@ func (m *Manager) CompleteKeygenSession(ctx context.Context, keyID string, publi
 			Err(err).
 			Str("key_id", keyID).
 			Str("old_status", oldStatus).
-			Str("new_status", "Active").
+			Str("new_status", storage.KeyStatusEnabled).
 			Msg("Failed to update key metadata in CompleteKeygenSession")
 		return errors.Wrap(err, "failed to update key metadata")
 	}
@@ -384,13 +504,34 @@ func (m *Manager) CompleteKeygenSession(ctx context.Context, keyID string, publi
 	log.Info().
 		Str("key_id", keyID).
 		Str("old_status", oldStatus).
-		Str("new_status", "Active").
+		Str("new_status", storage.KeyStatusEnabled).
 		Str("public_key", publicKey).
 		Msg("Key metadata updated successfully - DKG completed")
 
+	m.webhooks.Publish(ctx, webhook.EventDKGCompleted, keyID, map[string]interface{}{
+		"key_id":      keyID,
+		"session_id":  session.SessionID,
+		"protocol":    session.Protocol,
+		"public_key":  publicKey,
+		"algorithm":   keyMeta.Algorithm,
+		"curve":       keyMeta.Curve,
+		"threshold":   keyMeta.Threshold,
+		"total_nodes": keyMeta.TotalNodes,
+		"node_ids":    keyMeta.NodeIDs,
+	})
+
 	return nil
 }
 
+// GetKeyMetadata 获取会话所属密钥的元数据（参与节点据此确定 DKG 的算法和曲线）
+func (m *Manager) GetKeyMetadata(ctx context.Context, keyID string) (*storage.KeyMetadata, error) {
+	keyMeta, err := m.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get key metadata")
+	}
+	return keyMeta, nil
+}
+
 // CancelSession 取消会话
 func (m *Manager) CancelSession(ctx context.Context, sessionID string) error {
 	session, err := m.GetSession(ctx, sessionID)
@@ -398,11 +539,41 @@ func (m *Manager) CancelSession(ctx context.Context, sessionID string) error {
 		return errors.Wrap(err, "failed to get session")
 	}
 
+	fromStatus := session.Status
 	session.Status = string(SessionStatusCancelled)
 
 	if err := m.UpdateSession(ctx, session); err != nil {
 		return errors.Wrap(err, "failed to update session")
 	}
+	m.recordStateChange(ctx, session, fromStatus, nil)
+
+	return nil
+}
+
+// FailSession 将会话标记为失败并记录失败原因和责任节点
+// 多个参与节点可能分别上报同一会话的失败，责任节点取并集，失败原因保留最先写入的一条
+// 合并在数据库的一条 UPDATE 中完成，并发上报不会互相覆盖
+func (m *Manager) FailSession(ctx context.Context, sessionID string, reason string, culprits []string) error {
+	fromStatus, storageSession, err := m.metadataStore.FailSigningSession(ctx, sessionID, reason, culprits, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to mark session as failed")
+	}
+
+	// 缓存中的会话可能是其他节点写入的旧状态，删除后由 GetSession 从数据库读取
+	if err := m.sessionStore.DeleteSession(ctx, sessionID); err != nil {
+		return errors.Wrap(err, "failed to invalidate session cache")
+	}
+
+	session := convertStorageSession(storageSession)
+	log.Warn().
+		Str("session_id", sessionID).
+		Str("reason", reason).
+		Strs("culprits", session.Culprits).
+		Msg("Session failed")
+	if fromStatus != session.Status {
+		m.recordStateChange(ctx, session, fromStatus, map[string]interface{}{"reason": reason, "culprits": session.Culprits})
+		m.publishSessionFailed(ctx, session)
+	}
 
 	return nil
 }
@@ -415,10 +586,13 @@ func (m *Manager) CheckTimeout(ctx context.Context, sessionID string) (bool, err
 	}
 
 	if time.Now().After(session.ExpiresAt) {
+		fromStatus := session.Status
 		session.Status = string(SessionStatusTimeout)
 		if err := m.UpdateSession(ctx, session); err != nil {
 			return true, errors.Wrap(err, "failed to update session")
 		}
+		m.recordStateChange(ctx, session, fromStatus, nil)
+		m.publishSessionFailed(ctx, session)
 		return true, nil
 	}
 
@@ -450,6 +624,36 @@ func (m *Manager) ObserveRoundMetric(protocol string, round int, duration time.D
 	m.stateStore.ObserveRoundMetric(protocol, round, duration)
 }
 
+// recordStateChange 记录会话状态变化的审计日志
+func (m *Manager) recordStateChange(ctx context.Context, session *Session, fromStatus string, details map[string]interface{}) {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["protocol"] = session.Protocol
+	details["from_status"] = fromStatus
+	details["to_status"] = session.Status
+	m.auditLogger.Record(ctx, &audit.Event{
+		EventType: audit.EventTypeSession,
+		Operation: audit.OperationSessionState,
+		Result:    audit.ResultSuccess,
+		KeyID:     session.KeyID,
+		SessionID: session.SessionID,
+		Details:   details,
+	})
+}
+
+// publishSessionFailed 发布会话失败（包括超时）的 webhook 事件
+func (m *Manager) publishSessionFailed(ctx context.Context, session *Session) {
+	m.webhooks.Publish(ctx, webhook.EventSessionFailed, session.KeyID, map[string]interface{}{
+		"session_id": session.SessionID,
+		"key_id":     session.KeyID,
+		"protocol":   session.Protocol,
+		"status":     session.Status,
+		"reason":     session.FailureReason,
+		"culprits":   session.Culprits,
+	})
+}
+
 // convertStorageSession 转换存储会话为会话
 func convertStorageSession(storageSession *storage.SigningSession) *Session {
 	return &Session{
@@ -467,5 +671,7 @@ func convertStorageSession(storageSession *storage.SigningSession) *Session {
 		CompletedAt:        storageSession.CompletedAt,
 		DurationMs:         storageSession.DurationMs,
 		ExpiresAt:          storageSession.CreatedAt.Add(5 * time.Minute), // 默认5分钟超时
+		FailureReason:      storageSession.FailureReason,
+		Culprits:           storageSession.Culprits,
 	}
 }
@@ -18,8 +18,16 @@ type Session struct {
 	CompletedAt        *time.Time
 	DurationMs         int
 	ExpiresAt          time.Time
+	FailureReason      string   // 会话失败原因
+	Culprits           []string // 可识别中止的责任节点 ID
 }
 
+// ResharingSessionPrefix resharing 会话ID前缀（用于消息路由时区分 DKG/签名/resharing）
+const ResharingSessionPrefix = "reshare-"
+
+// PresignSessionPrefix 预签名会话ID前缀（预签名会话ID同时作为预签名ID）
+const PresignSessionPrefix = "presign-"
+
 // SessionStatus 会话状态
 type SessionStatus string
 
@@ -0,0 +1,148 @@
+package signing
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/session"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// asyncSignTimeout 异步签名的最长执行时间（覆盖预签名尝试和 5 分钟的完整签名协议），
+// 超时仍未结束的请求（如 coordinator 在签名过程中重启）在查询时标记为失败
+const asyncSignTimeout = 10 * time.Minute
+
+// StartThresholdSign 异步阈值签名：同步完成密钥、派生路径和交易策略检查并创建签名会话，
+// 签名在后台执行，立即返回以会话 ID 为句柄的签名请求，通过 GetSigningRequest 查询结果。
+// 检查失败（包括策略拒绝和需要审批）时直接返回错误并记录审计日志
+func (s *Service) StartThresholdSign(ctx context.Context, req *SignRequest) (*SigningRequest, error) {
+	plan, signingSession, stored, err := s.startThresholdSign(ctx, req)
+	if err != nil {
+		s.recordSign(ctx, req, "", nil, err)
+		return nil, err
+	}
+
+	// 签名在 HTTP 请求结束后继续执行，保留上下文中的用户和请求方地址用于审计日志
+	runCtx := context.WithoutCancel(ctx)
+	s.execute(func() { s.runAsync(runCtx, plan, signingSession, stored) })
+
+	return fromStorage(stored)
+}
+
+func (s *Service) startThresholdSign(ctx context.Context, req *SignRequest) (*signPlan, *session.Session, *storage.SigningRequest, error) {
+	plan, err := s.prepareSign(ctx, req)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	signingSession, err := s.createSigningSession(ctx, plan)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	now := s.now().UTC()
+	stored := &storage.SigningRequest{
+		SessionID: signingSession.SessionID,
+		KeyID:     req.KeyID,
+		Status:    storage.SigningRequestStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.metadataStore.SaveSigningRequest(ctx, stored); err != nil {
+		s.sessionManager.FailSession(ctx, signingSession.SessionID, "failed to save signing request", nil)
+		return nil, nil, nil, errors.Wrap(err, "failed to save signing request")
+	}
+
+	return plan, signingSession, stored, nil
+}
+
+// runAsync 执行异步签名并记录结果
+func (s *Service) runAsync(ctx context.Context, plan *signPlan, signingSession *session.Session, stored *storage.SigningRequest) {
+	logger := log.With().Str("session_id", stored.SessionID).Str("key_id", stored.KeyID).Logger()
+
+	resp, err := s.executeSign(ctx, plan, signingSession)
+	s.recordSign(ctx, plan.req, stored.SessionID, resp, err)
+
+	result := *stored
+	result.Status = storage.SigningRequestStatusFailed
+	if err != nil {
+		result.Error = err.Error()
+	} else if result.Result, err = json.Marshal(resp); err != nil {
+		result.Error = errors.Wrap(err, "failed to marshal sign response").Error()
+	} else {
+		result.Status = storage.SigningRequestStatusCompleted
+	}
+
+	result.UpdatedAt = s.now().UTC()
+	updated, err := s.metadataStore.TransitionSigningRequest(ctx, &result, storage.SigningRequestStatusPending)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to record signing request result")
+		return
+	}
+	if !updated {
+		logger.Warn().Str("status", result.Status).Msg("Signing request already timed out, result discarded")
+		return
+	}
+	if result.Status == storage.SigningRequestStatusFailed {
+		logger.Error().Str("error", result.Error).Msg("Asynchronous signing failed")
+		return
+	}
+	logger.Info().Msg("Asynchronous signing completed")
+}
+
+// GetSigningRequest 按会话 ID 查询异步签名请求，超过 asyncSignTimeout 仍未结束的请求标记为失败
+func (s *Service) GetSigningRequest(ctx context.Context, sessionID string) (*SigningRequest, error) {
+	stored, err := s.metadataStore.GetSigningRequest(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, ErrSigningRequestNotFound
+	}
+
+	now := s.now().UTC()
+	if stored.Status == storage.SigningRequestStatusPending && now.After(stored.CreatedAt.Add(asyncSignTimeout)) {
+		timedOut := *stored
+		timedOut.Status = storage.SigningRequestStatusFailed
+		timedOut.Error = "signing timeout"
+		timedOut.UpdatedAt = now
+		updated, err := s.metadataStore.TransitionSigningRequest(ctx, &timedOut, storage.SigningRequestStatusPending)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to expire signing request")
+		}
+		if updated {
+			s.sessionManager.FailSession(ctx, sessionID, "signing timeout", nil)
+			stored = &timedOut
+		} else {
+			// 签名恰好在此期间结束，重新读取结果
+			if stored, err = s.metadataStore.GetSigningRequest(ctx, sessionID); err != nil {
+				return nil, err
+			}
+			if stored == nil {
+				return nil, ErrSigningRequestNotFound
+			}
+		}
+	}
+
+	return fromStorage(stored)
+}
+
+func fromStorage(stored *storage.SigningRequest) (*SigningRequest, error) {
+	request := &SigningRequest{
+		SessionID: stored.SessionID,
+		KeyID:     stored.KeyID,
+		Status:    stored.Status,
+		Error:     stored.Error,
+		CreatedAt: stored.CreatedAt,
+		UpdatedAt: stored.UpdatedAt,
+	}
+	if len(stored.Result) > 0 {
+		request.Result = &SignResponse{}
+		if err := json.Unmarshal(stored.Result, request.Result); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal sign response of signing request %s", stored.SessionID)
+		}
+	}
+	return request, nil
+}
@@ -0,0 +1,315 @@
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/node"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/session"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	pb "github.com/kashguard/go-mpc-wallet/internal/pb/mpc/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signingStore 只实现签名相关方法的内存存储
+type signingStore struct {
+	storage.MetadataStore
+	mu       sync.Mutex
+	keys     map[string]*storage.KeyMetadata
+	nodes    []*storage.NodeInfo
+	sessions map[string]*storage.SigningSession
+	requests map[string]*storage.SigningRequest
+}
+
+func (m *signingStore) GetKeyMetadata(_ context.Context, keyID string) (*storage.KeyMetadata, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.keys[keyID]
+	if !ok {
+		return nil, storage.ErrKeyNotFound
+	}
+	k := *stored
+	return &k, nil
+}
+
+func (m *signingStore) ListNodes(_ context.Context, filter *storage.NodeFilter) ([]*storage.NodeInfo, error) {
+	nodes := m.nodes
+	if len(nodes) > filter.Limit {
+		nodes = nodes[:filter.Limit]
+	}
+	return nodes, nil
+}
+
+func (m *signingStore) SaveSigningSession(_ context.Context, s *storage.SigningSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *s
+	m.sessions[s.SessionID] = &stored
+	return nil
+}
+
+func (m *signingStore) GetSigningSession(_ context.Context, sessionID string) (*storage.SigningSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, errors.New("session not found")
+	}
+	s := *stored
+	return &s, nil
+}
+
+func (m *signingStore) UpdateSigningSession(ctx context.Context, s *storage.SigningSession) error {
+	return m.SaveSigningSession(ctx, s)
+}
+
+func (m *signingStore) FailSigningSession(_ context.Context, sessionID string, reason string, culprits []string, failedAt time.Time) (string, *storage.SigningSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.sessions[sessionID]
+	if !ok {
+		return "", nil, errors.New("session not found")
+	}
+	fromStatus := stored.Status
+	stored.Status = storage.SigningSessionStatusFailed
+	if stored.FailureReason == "" {
+		stored.FailureReason = reason
+	}
+	for _, culprit := range culprits {
+		if !slices.Contains(stored.Culprits, culprit) {
+			stored.Culprits = append(stored.Culprits, culprit)
+		}
+	}
+	if stored.CompletedAt == nil {
+		stored.CompletedAt = &failedAt
+	}
+	s := *stored
+	return fromStatus, &s, nil
+}
+
+func (m *signingStore) SaveSigningRequest(_ context.Context, r *storage.SigningRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *r
+	m.requests[r.SessionID] = &stored
+	return nil
+}
+
+func (m *signingStore) GetSigningRequest(_ context.Context, sessionID string) (*storage.SigningRequest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.requests[sessionID]
+	if !ok {
+		return nil, nil
+	}
+	r := *stored
+	return &r, nil
+}
+
+func (m *signingStore) TransitionSigningRequest(_ context.Context, r *storage.SigningRequest, fromStatus string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.requests[r.SessionID]
+	if !ok || stored.Status != fromStatus {
+		return false, nil
+	}
+	updated := *r
+	m.requests[r.SessionID] = &updated
+	return true, nil
+}
+
+// uncachedSessionStore 不缓存会话，会话总是从 MetadataStore 读取
+type uncachedSessionStore struct {
+	storage.SessionStore
+}
+
+func (uncachedSessionStore) SaveSession(context.Context, *storage.SigningSession, time.Duration) error {
+	return nil
+}
+
+func (uncachedSessionStore) GetSession(context.Context, string) (*storage.SigningSession, error) {
+	return nil, errors.New("session not cached")
+}
+
+func (uncachedSessionStore) UpdateSession(context.Context, *storage.SigningSession, time.Duration) error {
+	return nil
+}
+
+func (uncachedSessionStore) DeleteSession(context.Context, string) error {
+	return nil
+}
+
+// signingClient 模拟参与节点：StartSign 时用私钥签名并完成会话
+type signingClient struct {
+	privateKey     *secp256k1.PrivateKey
+	sessionManager *session.Manager
+	failing        bool
+
+	mu       sync.Mutex
+	sessions []string
+}
+
+func (c *signingClient) SendStartSign(ctx context.Context, _ string, req *pb.StartSignRequest) (*pb.StartSignResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failing {
+		return nil, errors.New("node unavailable")
+	}
+	c.sessions = append(c.sessions, req.SessionId)
+	signature := hex.EncodeToString(ecdsa.Sign(c.privateKey, req.Message).Serialize())
+	if err := c.sessionManager.CompleteSession(ctx, req.SessionId, signature); err != nil {
+		return nil, err
+	}
+	return &pb.StartSignResponse{Started: true}, nil
+}
+
+var testDigest = []byte("0123456789abcdef0123456789abcdef")
+
+func newAsyncSigningService(t *testing.T) (*Service, *signingStore, *signingClient, *time.Time) {
+	t.Helper()
+
+	privateKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	store := &signingStore{
+		keys: map[string]*storage.KeyMetadata{
+			"key-1": {
+				KeyID:      "key-1",
+				PublicKey:  hex.EncodeToString(privateKey.PubKey().SerializeCompressed()),
+				Algorithm:  "ECDSA",
+				Curve:      "secp256k1",
+				Threshold:  2,
+				TotalNodes: 3,
+				Status:     storage.KeyStatusEnabled,
+				Protocol:   "gg20",
+			},
+			"key-2": {KeyID: "key-2", Algorithm: "ECDSA", Curve: "secp256k1", Status: storage.KeyStatusDisabled},
+		},
+		nodes: []*storage.NodeInfo{
+			{NodeID: "node-1", NodeType: string(node.NodeTypeParticipant), Status: string(node.NodeStatusActive)},
+			{NodeID: "node-2", NodeType: string(node.NodeTypeParticipant), Status: string(node.NodeStatusActive)},
+		},
+		sessions: map[string]*storage.SigningSession{},
+		requests: map[string]*storage.SigningRequest{},
+	}
+	sessionManager := session.NewManager(store, uncachedSessionStore{}, time.Minute, nil, nil)
+	client := &signingClient{privateKey: privateKey, sessionManager: sessionManager}
+	nodeDiscovery := node.NewDiscovery(node.NewManager(store, time.Minute, 0, nil), nil)
+	keyService := key.NewService(store, nil, nil, nil, nil, nil)
+
+	service := NewService(keyService, nil, nil, sessionManager, nodeDiscovery, "gg20", client, nil, nil, nil, store, nil)
+	now := time.Date(2026, 1, 25, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	// 后台签名同步执行，测试在 StartThresholdSign 返回时即可查询结果
+	service.execute = func(f func()) { f() }
+	return service, store, client, &now
+}
+
+func sighashRequest(keyID string) *SignRequest {
+	return &SignRequest{
+		KeyID:       keyID,
+		MessageHex:  hex.EncodeToString(testDigest),
+		MessageType: MessageTypeSighash,
+		ChainType:   "bitcoin",
+	}
+}
+
+func TestStartThresholdSign(t *testing.T) {
+	ctx := context.Background()
+	service, _, client, _ := newAsyncSigningService(t)
+
+	started, err := service.StartThresholdSign(ctx, sighashRequest("key-1"))
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningRequestStatusPending, started.Status)
+	assert.Equal(t, "key-1", started.KeyID)
+	assert.Nil(t, started.Result)
+
+	// 会话句柄即为节点执行签名的会话
+	require.Len(t, client.sessions, 2)
+	assert.Equal(t, started.SessionID, client.sessions[0])
+
+	request, err := service.GetSigningRequest(ctx, started.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningRequestStatusCompleted, request.Status)
+	assert.Empty(t, request.Error)
+	require.NotNil(t, request.Result)
+	assert.Equal(t, started.SessionID, request.Result.SessionID)
+	assert.Equal(t, hex.EncodeToString(testDigest), request.Result.Digest)
+	assert.ElementsMatch(t, []string{"node-1", "node-2"}, request.Result.ParticipatingNodes)
+}
+
+func TestStartThresholdSignFailure(t *testing.T) {
+	ctx := context.Background()
+	service, store, client, _ := newAsyncSigningService(t)
+
+	// 检查失败时同步返回错误，不创建签名请求
+	_, err := service.StartThresholdSign(ctx, sighashRequest("key-2"))
+	assert.True(t, errors.Is(err, key.ErrKeyNotEnabled))
+	_, err = service.StartThresholdSign(ctx, &SignRequest{KeyID: "key-1", MessageHex: "abcd", MessageType: MessageTypeSighash})
+	require.Error(t, err)
+	assert.Empty(t, store.requests)
+
+	// 签名失败记录在签名请求中
+	client.failing = true
+	started, err := service.StartThresholdSign(ctx, sighashRequest("key-1"))
+	require.NoError(t, err)
+
+	request, err := service.GetSigningRequest(ctx, started.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningRequestStatusFailed, request.Status)
+	assert.Contains(t, request.Error, "node unavailable")
+	assert.Nil(t, request.Result)
+
+	_, err = service.GetSigningRequest(ctx, "session-missing")
+	assert.True(t, errors.Is(err, ErrSigningRequestNotFound))
+}
+
+func TestGetSigningRequestTimeout(t *testing.T) {
+	ctx := context.Background()
+	service, store, _, now := newAsyncSigningService(t)
+
+	// coordinator 在签名过程中重启，请求停留在 pending
+	service.execute = func(func()) {}
+	started, err := service.StartThresholdSign(ctx, sighashRequest("key-1"))
+	require.NoError(t, err)
+
+	request, err := service.GetSigningRequest(ctx, started.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningRequestStatusPending, request.Status)
+
+	*now = now.Add(asyncSignTimeout + time.Second)
+	request, err = service.GetSigningRequest(ctx, started.SessionID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.SigningRequestStatusFailed, request.Status)
+	assert.Equal(t, "signing timeout", request.Error)
+	assert.Equal(t, string(session.SessionStatusFailed), store.sessions[started.SessionID].Status)
+}
+
+func TestThresholdSignUsesKeyCommittee(t *testing.T) {
+	ctx := context.Background()
+	service, store, _, _ := newAsyncSigningService(t)
+
+	// resharing 后密钥由 node-2、node-3 持有，node-1 仍然活跃但没有当前轮次的分片
+	store.nodes = append(store.nodes, &storage.NodeInfo{NodeID: "node-3", NodeType: string(node.NodeTypeParticipant), Status: string(node.NodeStatusActive)})
+	store.keys["key-1"].NodeIDs = []string{"node-3", "node-2"}
+	store.keys["key-1"].ShareEpoch = 1
+
+	resp, err := service.ThresholdSign(ctx, sighashRequest("key-1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node-3", "node-2"}, resp.ParticipatingNodes)
+
+	// 委员会中活跃节点不足阈值时不选择委员会以外的节点
+	store.keys["key-1"].NodeIDs = []string{"node-3", "node-4"}
+	_, err = service.ThresholdSign(ctx, sighashRequest("key-1"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient active nodes in key committee")
+}
@@ -0,0 +1,49 @@
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/pkg/errors"
+)
+
+// BitcoinInputSigner 返回使用指定密钥对 Bitcoin 交易输入进行门限签名的 chain.InputSigner：
+// P2WPKH 输入以预哈希方式对 BIP-143 摘要进行 ECDSA 签名，P2TR 输入按 BIP-341 key-path 调整后进行 FROST 签名。
+// derivationPath 非空时使用派生子密钥签名（仅 P2WPKH）。unsigned 为 BitcoinAdapter.BuildTransaction 生成的交易，
+// 其 PSBT 随每个输入的签名请求提交给策略检查
+func (s *Service) BitcoinInputSigner(keyID string, derivationPath string, unsigned *chain.Transaction) chain.InputSigner {
+	return func(ctx context.Context, input *chain.InputSigHash) ([]byte, error) {
+		req := &SignRequest{
+			KeyID:          keyID,
+			MessageHex:     hex.EncodeToString(input.SigHash),
+			MessageType:    MessageTypeSighash,
+			ChainType:      "bitcoin",
+			DerivationPath: derivationPath,
+		}
+		if unsigned != nil {
+			req.UnsignedTx = unsigned.PSBT
+		}
+		switch input.ScriptType {
+		case chain.BitcoinScriptTypeP2WPKH:
+			req.SignatureFormat = protocol.SignatureFormatDER
+		case chain.BitcoinScriptTypeP2TR:
+			req.SignatureFormat = protocol.SignatureFormatRaw
+			req.TaprootKeySpend = true
+			req.TaprootMerkleRoot = input.TaprootMerkleRoot
+		default:
+			return nil, errors.Errorf("unsupported bitcoin script type %s", input.ScriptType)
+		}
+
+		resp, err := s.ThresholdSign(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := hex.DecodeString(resp.Signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode signature")
+		}
+		return signature, nil
+	}
+}
@@ -0,0 +1,8 @@
+package signing
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrSigningRequestNotFound 异步签名请求不存在
+var ErrSigningRequestNotFound = errors.New("signing request not found")
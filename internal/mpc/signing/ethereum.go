@@ -0,0 +1,45 @@
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/pkg/errors"
+)
+
+// SignEthereumTransaction 对 EthereumAdapter.BuildTransaction 生成的未签名交易的签名哈希进行门限签名，
+// 并将签名插入交易，返回可广播的原始交易。derivationPath 非空时使用派生子密钥签名
+func (s *Service) SignEthereumTransaction(ctx context.Context, keyID string, derivationPath string, adapter *chain.EthereumAdapter, unsigned *chain.Transaction) (*chain.Transaction, error) {
+	if adapter == nil {
+		return nil, errors.New("ethereum adapter is required")
+	}
+	if unsigned == nil || unsigned.SigningHash == "" {
+		return nil, errors.New("unsigned transaction has no signing hash")
+	}
+
+	resp, err := s.ThresholdSign(ctx, &SignRequest{
+		KeyID:           keyID,
+		MessageHex:      strings.TrimPrefix(unsigned.SigningHash, "0x"),
+		MessageType:     MessageTypeSighash,
+		ChainType:       "ethereum",
+		DerivationPath:  derivationPath,
+		SignatureFormat: protocol.SignatureFormatRSV,
+		UnsignedTx:      unsigned.Raw,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := hex.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode signature")
+	}
+	publicKey, err := hex.DecodeString(resp.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode public key")
+	}
+	return adapter.AssembleTransaction(unsigned, signature, publicKey)
+}
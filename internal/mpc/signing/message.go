@@ -0,0 +1,95 @@
+package signing
+
+import (
+	"strings"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/pkg/errors"
+)
+
+// 签名请求的消息类型
+const (
+	MessageTypeTransaction = "transaction"
+	// MessageTypeMessage ECDSA 密钥按 EIP-191 personal_sign 加前缀后签名，EdDSA/Schnorr 密钥直接签名消息
+	MessageTypeMessage = "message"
+	MessageTypeRaw     = "raw"
+	// MessageTypeTypedData EIP-712 结构化数据，签名其摘要（仅 ECDSA）
+	MessageTypeTypedData = "typed_data"
+	// MessageTypeSighash 32 字节交易签名摘要（如 BIP-143/BIP-341 sighash），ECDSA 以预哈希方式签名，Schnorr 直接签名
+	MessageTypeSighash = "sighash"
+)
+
+// signPayload 根据消息类型确定的签名载荷
+type signPayload struct {
+	// message 请求中的原始消息（typed_data 为 EIP-712 JSON）
+	message []byte
+	// signed 发送给签名引擎的数据，prehashed 时为 32 字节摘要
+	signed    []byte
+	prehashed bool
+	// digest ECDSA 实际签名的摘要，EdDSA/Schnorr 为空
+	digest []byte
+}
+
+// resolveSignPayload 按消息类型计算签名载荷：message 类型应用 EIP-191 前缀，typed_data 计算 EIP-712 摘要，
+// 两者与 sighash 类型都以预哈希方式签名；transaction/raw 原样交给引擎（ECDSA 引擎签名其 SHA-256）
+func resolveSignPayload(req *SignRequest, algorithm string) (*signPayload, error) {
+	ecdsaKey := strings.EqualFold(algorithm, "ecdsa")
+
+	messageType := strings.ToLower(req.MessageType)
+	if messageType == MessageTypeTypedData {
+		if !ecdsaKey {
+			return nil, errors.Errorf("message type %s requires an ECDSA key, got %s", messageType, algorithm)
+		}
+		data := req.TypedData
+		if len(data) == 0 {
+			decoded, err := resolveSignMessage(req)
+			if err != nil {
+				return nil, err
+			}
+			data = decoded
+		}
+		typedData, err := chain.ParseTypedData(data)
+		if err != nil {
+			return nil, err
+		}
+		digest, err := chain.TypedDataHash(typedData)
+		if err != nil {
+			return nil, err
+		}
+		return &signPayload{message: data, signed: digest, prehashed: true, digest: digest}, nil
+	}
+
+	message, err := resolveSignMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(message) == 0 {
+		return nil, errors.New("message is empty")
+	}
+
+	switch messageType {
+	case "", MessageTypeTransaction, MessageTypeRaw:
+		payload := &signPayload{message: message, signed: message}
+		if ecdsaKey {
+			payload.digest = protocol.ECDSADigest(message, false)
+		}
+		return payload, nil
+	case MessageTypeSighash:
+		if len(message) != 32 {
+			return nil, errors.Errorf("sighash must be 32 bytes, got %d", len(message))
+		}
+		if !ecdsaKey {
+			return &signPayload{message: message, signed: message}, nil
+		}
+		return &signPayload{message: message, signed: message, prehashed: true, digest: message}, nil
+	case MessageTypeMessage:
+		if !ecdsaKey {
+			return &signPayload{message: message, signed: message}, nil
+		}
+		digest := chain.EIP191Hash(message)
+		return &signPayload{message: message, signed: digest, prehashed: true, digest: digest}, nil
+	default:
+		return nil, errors.Errorf("unsupported message type %q", req.MessageType)
+	}
+}
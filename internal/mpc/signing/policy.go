@@ -0,0 +1,102 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
+	"github.com/pkg/errors"
+)
+
+// checkPolicy 签名前的交易策略检查：请求携带未签名交易时由链适配器解析，并确认被签名的数据属于该交易；
+// 类型化数据按其授权内容检查（见 chain.DecodeTypedData）。EdDSA/Schnorr 密钥的 message 直接签名原始数据，
+// 可以是任意交易，按交易检查；只有 ECDSA 密钥的 EIP-191 消息签名只检查时间段规则。决策不是 allow 时返回 *policy.DecisionError
+func (s *Service) checkPolicy(ctx context.Context, req *SignRequest, keyMetadata *key.KeyMetadata, payload *signPayload) error {
+	if !s.policyEngine.Enabled() {
+		return nil
+	}
+
+	chainType := req.ChainType
+	if chainType == "" {
+		chainType = keyMetadata.ChainType
+	}
+	if spec, err := s.keyService.Chains().Get(chainType); err == nil {
+		chainType = spec.ChainType
+		// EVM 交易按密钥所属的 EVM 链解析（链 ID 不同），例如 SignEthereumTransaction 签名 BSC 密钥的交易
+		if keySpec, err := s.keyService.Chains().Get(keyMetadata.ChainType); err == nil && spec.ChainID != nil && keySpec.ChainID != nil {
+			chainType = keySpec.ChainType
+		}
+	}
+	messageType := strings.ToLower(req.MessageType)
+	if messageType == "" {
+		messageType = MessageTypeTransaction
+	}
+
+	policyReq := &policy.Request{
+		KeyID:         req.KeyID,
+		ChainType:     chainType,
+		MessageType:   messageType,
+		Transactional: messageType != MessageTypeMessage || !strings.EqualFold(keyMetadata.Algorithm, "ecdsa"),
+		ApprovalID:    req.ApprovalID,
+	}
+	if messageType == MessageTypeTypedData {
+		typedData, err := chain.ParseTypedData(payload.message)
+		if err != nil {
+			return err
+		}
+		// 无法识别的类型化数据（如交易所订单）不设置 Transaction，交易规则一律命中
+		policyReq.Transaction = chain.DecodeTypedData(typedData, payload.digest)
+		policyReq.TxHash = hex.EncodeToString(payload.digest)
+	} else if policyReq.Transactional && req.UnsignedTx != "" {
+		tx, err := s.decodeTransaction(keyMetadata, chainType, req.UnsignedTx)
+		if err != nil {
+			return err
+		}
+		if !containsPayload(tx.SigningPayloads, payload.message) {
+			return errors.New("message does not match the signing payload of the unsigned transaction")
+		}
+		txHash := sha256.Sum256([]byte(req.UnsignedTx))
+		policyReq.Transaction = tx
+		policyReq.TxHash = hex.EncodeToString(txHash[:])
+	}
+
+	decision, err := s.policyEngine.Evaluate(ctx, policyReq)
+	if err != nil {
+		return errors.Wrap(err, "failed to evaluate signing policy")
+	}
+	if !decision.Allowed() {
+		return &policy.DecisionError{Decision: decision}
+	}
+	return nil
+}
+
+// decodeTransaction 使用链适配器解析未签名交易
+func (s *Service) decodeTransaction(keyMetadata *key.KeyMetadata, chainType string, unsignedTx string) (*chain.DecodedTransaction, error) {
+	adapter, _, err := s.keyService.Chains().NewAdapter(chainType, keyMetadata.Algorithm, keyMetadata.Curve, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create chain adapter")
+	}
+	decoder, ok := adapter.(chain.TransactionDecoder)
+	if !ok {
+		return nil, errors.Errorf("chain %s does not support transaction decoding", chainType)
+	}
+	tx, err := decoder.DecodeTransaction(unsignedTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode unsigned transaction")
+	}
+	return tx, nil
+}
+
+func containsPayload(payloads [][]byte, message []byte) bool {
+	for _, payload := range payloads {
+		if bytes.Equal(payload, message) {
+			return true
+		}
+	}
+	return false
+}
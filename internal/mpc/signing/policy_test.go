@@ -0,0 +1,118 @@
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// policyStore 在 signingStore 之上实现策略规则和决策记录
+type policyStore struct {
+	*signingStore
+	rules     []*storage.PolicyRule
+	decisions []*storage.PolicyDecision
+}
+
+func (m *policyStore) SavePolicyRule(_ context.Context, rule *storage.PolicyRule) error {
+	m.rules = append(m.rules, rule)
+	return nil
+}
+
+func (m *policyStore) ListPolicyRules(_ context.Context, keyID string) ([]*storage.PolicyRule, error) {
+	var rules []*storage.PolicyRule
+	for _, rule := range m.rules {
+		if rule.KeyID == "" || rule.KeyID == keyID {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (m *policyStore) SavePolicyDecision(_ context.Context, decision *storage.PolicyDecision) error {
+	m.decisions = append(m.decisions, decision)
+	return nil
+}
+
+// permitTypedData 向 spender 授权 1000 USDC 的 EIP-2612 permit
+const permitTypedData = `{
+  "types": {"Permit": [
+    {"name": "owner", "type": "address"},
+    {"name": "spender", "type": "address"},
+    {"name": "value", "type": "uint256"},
+    {"name": "nonce", "type": "uint256"},
+    {"name": "deadline", "type": "uint256"}
+  ]},
+  "primaryType": "Permit",
+  "domain": {"name": "USD Coin", "version": "2", "chainId": 1, "verifyingContract": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"},
+  "message": {
+    "owner": "0x1111111111111111111111111111111111111111",
+    "spender": "0x2222222222222222222222222222222222222222",
+    "value": "1000000000",
+    "nonce": 0,
+    "deadline": 1700000000
+  }
+}`
+
+func TestCheckPolicyCoversMessagesAndTypedData(t *testing.T) {
+	ctx := context.Background()
+	service, base, _, _ := newAsyncSigningService(t)
+	base.keys["key-ed"] = &storage.KeyMetadata{
+		KeyID:      "key-ed",
+		Algorithm:  "EdDSA",
+		Curve:      "ed25519",
+		Threshold:  2,
+		TotalNodes: 3,
+		Status:     storage.KeyStatusEnabled,
+		Protocol:   "frost",
+		ChainType:  "solana",
+	}
+	store := &policyStore{signingStore: base}
+	service.policyEngine = policy.NewEngine(store, true, nil)
+
+	_, err := service.policyEngine.CreateRule(ctx, &policy.Rule{
+		RuleType: policy.RuleTypeDenylist,
+		Action:   policy.DecisionDeny,
+		Params:   &policy.RuleParams{Addresses: []string{"0x2222222222222222222222222222222222222222"}},
+		Enabled:  true,
+	})
+	require.NoError(t, err)
+
+	denied := func(req *SignRequest) *policy.Decision {
+		t.Helper()
+		_, err := service.ThresholdSign(ctx, req)
+		var decisionErr *policy.DecisionError
+		require.True(t, errors.As(err, &decisionErr), "expected policy decision error, got %v", err)
+		assert.Equal(t, policy.DecisionDeny, decisionErr.Decision.Decision)
+		return decisionErr.Decision
+	}
+
+	// EdDSA 密钥的 message 直接签名原始数据（可以是序列化的交易），未附带可解析的交易时交易规则一律命中
+	decision := denied(&SignRequest{KeyID: "key-ed", MessageHex: hex.EncodeToString([]byte("arbitrary transaction bytes")), MessageType: MessageTypeMessage})
+	assert.Contains(t, decision.Reason, "could not be decoded")
+
+	// permit 授权的 spender 在黑名单中
+	decision = denied(&SignRequest{KeyID: "key-1", TypedData: []byte(permitTypedData), MessageType: MessageTypeTypedData})
+	assert.Contains(t, decision.Reason, "0x2222222222222222222222222222222222222222")
+
+	// 无法识别的类型化数据（如订单）按未能解析的交易处理
+	order := []byte(`{
+	  "types": {"Order": [{"name": "maker", "type": "address"}, {"name": "amount", "type": "uint256"}]},
+	  "primaryType": "Order",
+	  "domain": {"name": "Exchange", "chainId": 1},
+	  "message": {"maker": "0x1111111111111111111111111111111111111111", "amount": "1"}
+	}`)
+	decision = denied(&SignRequest{KeyID: "key-1", TypedData: order, MessageType: MessageTypeTypedData})
+	assert.Contains(t, decision.Reason, "could not be decoded")
+
+	// ECDSA 密钥的 EIP-191 消息不能授权转移，只检查时间段规则
+	_, err = service.ThresholdSign(ctx, &SignRequest{KeyID: "key-1", Message: []byte("hello"), MessageType: MessageTypeMessage})
+	require.NoError(t, err)
+	assert.Len(t, store.decisions, 4)
+	assert.Equal(t, policy.DecisionAllow, store.decisions[3].Decision)
+}
@@ -0,0 +1,389 @@
+package signing
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/node"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/session"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	pb "github.com/kashguard/go-mpc-wallet/internal/pb/mpc/v1"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+)
+
+// presignGenerateTimeout 单个预签名（离线阶段）的生成超时时间
+const presignGenerateTimeout = 3 * time.Minute
+
+var (
+	presignMetricsOnce  sync.Once
+	presignClaimCounter *prometheus.CounterVec
+	presignGenerateHist prometheus.Histogram
+)
+
+func ensurePresignMetrics() {
+	presignMetricsOnce.Do(func() {
+		presignClaimCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "mpc",
+			Subsystem: "presign",
+			Name:      "claims_total",
+			Help:      "Number of signing requests that tried to claim a presignature, by result (hit/miss)",
+		}, []string{"result"})
+		presignGenerateHist = promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "mpc",
+			Subsystem: "presign",
+			Name:      "generation_duration_seconds",
+			Help:      "Time spent generating a single presignature (GG20 offline phase or FROST nonce commitments)",
+			Buckets:   prometheus.ExponentialBuckets(0.25, 2, 10),
+		})
+	})
+}
+
+// PresignGRPCClient 预签名池使用的 gRPC 客户端接口
+type PresignGRPCClient interface {
+	SendStartPresign(ctx context.Context, nodeID string, req *pb.StartPresignRequest) (*pb.StartPresignResponse, error)
+}
+
+// PresignPool 预签名池（协调者），ECDSA 密钥使用 GG20 离线阶段，Schnorr/EdDSA 密钥使用 FROST 预处理的 nonce 承诺
+// 预签名份额只保存在参与节点上，协调者在 presignatures 表中记录预签名ID、参与节点和分片轮次。
+// 签名时原子地取出一个预签名（一次性使用），被使用过的密钥会被跟踪，
+// 可用数量低于低水位时在后台补充到目标数量。
+type PresignPool struct {
+	metadataStore  storage.MetadataStore
+	sessionManager *session.Manager
+	nodeDiscovery  *node.Discovery
+	grpcClient     PresignGRPCClient
+
+	target       int
+	lowWatermark int
+	interval     time.Duration
+
+	mu      sync.Mutex
+	tracked map[string]struct{}
+
+	refillCh chan string
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewPresignPool 创建预签名池
+// target 为 0 时池关闭，签名总是执行完整协议；lowWatermark 不在 (0, target] 范围内时按 target 处理
+func NewPresignPool(
+	metadataStore storage.MetadataStore,
+	sessionManager *session.Manager,
+	nodeDiscovery *node.Discovery,
+	grpcClient PresignGRPCClient,
+	target int,
+	lowWatermark int,
+	interval time.Duration,
+) *PresignPool {
+	ensurePresignMetrics()
+
+	if lowWatermark <= 0 || lowWatermark > target {
+		lowWatermark = target
+	}
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	return &PresignPool{
+		metadataStore:  metadataStore,
+		sessionManager: sessionManager,
+		nodeDiscovery:  nodeDiscovery,
+		grpcClient:     grpcClient,
+		target:         target,
+		lowWatermark:   lowWatermark,
+		interval:       interval,
+		tracked:        make(map[string]struct{}),
+		refillCh:       make(chan string, 64),
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// Enabled 预签名池是否开启
+func (p *PresignPool) Enabled() bool {
+	return p != nil && p.target > 0
+}
+
+// Claim 取出密钥在当前分片轮次下的一个可用预签名，没有可用预签名时返回 nil
+// 取出后预签名在数据库中即标记为已使用，无论随后的签名是否成功都不会再次分配
+func (p *PresignPool) Claim(ctx context.Context, keyID string, shareEpoch int) (*storage.Presignature, error) {
+	if !p.Enabled() {
+		return nil, nil
+	}
+
+	p.Track(keyID)
+
+	presig, err := p.metadataStore.ClaimPresignature(ctx, keyID, shareEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to claim presignature")
+	}
+	if presig == nil {
+		presignClaimCounter.WithLabelValues("miss").Inc()
+		return nil, nil
+	}
+
+	presignClaimCounter.WithLabelValues("hit").Inc()
+	return presig, nil
+}
+
+// Track 将密钥加入预签名池并触发一次补充检查
+func (p *PresignPool) Track(keyID string) {
+	if !p.Enabled() {
+		return
+	}
+
+	p.mu.Lock()
+	p.tracked[keyID] = struct{}{}
+	p.mu.Unlock()
+
+	select {
+	case p.refillCh <- keyID:
+	default:
+	}
+}
+
+// Run 在后台按补充策略维护预签名，直到 ctx 取消或调用 Stop
+func (p *PresignPool) Run(ctx context.Context) {
+	if !p.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		case keyID := <-p.refillCh:
+			p.refill(ctx, keyID)
+		case <-ticker.C:
+			for _, keyID := range p.trackedKeys() {
+				p.refill(ctx, keyID)
+			}
+		}
+	}
+}
+
+// Stop 停止后台补充
+func (p *PresignPool) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+}
+
+func (p *PresignPool) trackedKeys() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	keyIDs := make([]string, 0, len(p.tracked))
+	for keyID := range p.tracked {
+		keyIDs = append(keyIDs, keyID)
+	}
+	return keyIDs
+}
+
+func (p *PresignPool) untrack(keyID string) {
+	p.mu.Lock()
+	delete(p.tracked, keyID)
+	p.mu.Unlock()
+}
+
+// refill 可用数量低于低水位时逐个生成预签名，直到达到目标数量
+func (p *PresignPool) refill(ctx context.Context, keyID string) {
+	keyMeta, err := p.metadataStore.GetKeyMetadata(ctx, keyID)
+	if err != nil {
+		log.Warn().Err(err).Str("key_id", keyID).Msg("Presign pool: failed to get key metadata, untracking key")
+		p.untrack(keyID)
+		return
+	}
+	if keyMeta.Status != storage.KeyStatusEnabled || presignProtocol(keyMeta) == "" {
+		p.untrack(keyID)
+		return
+	}
+
+	available, err := p.metadataStore.CountAvailablePresignatures(ctx, keyID, keyMeta.ShareEpoch)
+	if err != nil {
+		log.Warn().Err(err).Str("key_id", keyID).Msg("Presign pool: failed to count presignatures")
+		return
+	}
+	if available >= p.lowWatermark {
+		return
+	}
+
+	for available < p.target {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		default:
+		}
+
+		if err := p.generate(ctx, keyMeta); err != nil {
+			log.Error().
+				Err(err).
+				Str("key_id", keyID).
+				Int("available", available).
+				Int("target", p.target).
+				Msg("Presign pool: failed to generate presignature")
+			return
+		}
+		available++
+	}
+
+	log.Info().
+		Str("key_id", keyID).
+		Int("available", available).
+		Int("target", p.target).
+		Msg("Presign pool refilled")
+}
+
+// generate 选择参与节点并执行一次离线阶段，所有节点成功后才登记预签名
+func (p *PresignPool) generate(ctx context.Context, keyMeta *storage.KeyMetadata) error {
+	nodeIDs, err := p.selectNodes(ctx, keyMeta)
+	if err != nil {
+		return err
+	}
+
+	protocolName := presignProtocol(keyMeta)
+	presignSession, err := p.sessionManager.CreatePresignSession(ctx, keyMeta.KeyID, protocolName, nodeIDs)
+	if err != nil {
+		return errors.Wrap(err, "failed to create presign session")
+	}
+
+	req := &pb.StartPresignRequest{
+		SessionId: presignSession.SessionID,
+		KeyId:     keyMeta.KeyID,
+		Protocol:  protocolName,
+		NodeIds:   nodeIDs,
+	}
+
+	start := time.Now()
+	presignCtx, cancel := context.WithTimeout(ctx, presignGenerateTimeout)
+	defer cancel()
+
+	errCh := make(chan error, len(nodeIDs))
+	var wg sync.WaitGroup
+	for _, nodeID := range nodeIDs {
+		wg.Add(1)
+		go func(nodeID string) {
+			defer wg.Done()
+			resp, err := p.grpcClient.SendStartPresign(presignCtx, nodeID, req)
+			if err != nil {
+				errCh <- errors.Wrapf(err, "StartPresign on node %s", nodeID)
+				return
+			}
+			if !resp.Success {
+				errCh <- errors.Errorf("StartPresign on node %s failed: %s", nodeID, resp.Message)
+			}
+		}(nodeID)
+	}
+	wg.Wait()
+	close(errCh)
+
+	if err := <-errCh; err != nil {
+		if cancelErr := p.sessionManager.CancelSession(ctx, presignSession.SessionID); cancelErr != nil {
+			log.Warn().Err(cancelErr).Str("session_id", presignSession.SessionID).Msg("Presign pool: failed to cancel presign session")
+		}
+		return err
+	}
+	presignGenerateHist.Observe(time.Since(start).Seconds())
+
+	if err := p.metadataStore.SavePresignature(ctx, &storage.Presignature{
+		PresignatureID: presignSession.SessionID,
+		KeyID:          keyMeta.KeyID,
+		NodeIDs:        nodeIDs,
+		ShareEpoch:     keyMeta.ShareEpoch,
+		Status:         storage.PresignatureStatusAvailable,
+		CreatedAt:      time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if err := p.sessionManager.CompleteSession(ctx, presignSession.SessionID, ""); err != nil {
+		log.Warn().Err(err).Str("session_id", presignSession.SessionID).Msg("Presign pool: failed to complete presign session")
+	}
+
+	return nil
+}
+
+// presignProtocol 返回密钥对应的预签名协议，不支持预签名时返回空字符串
+// CGGMP21 密钥使用自身的预签名，其他 ECDSA 密钥（gg18/gg20 分片格式相同）使用 GG20
+func presignProtocol(keyMeta *storage.KeyMetadata) string {
+	if strings.EqualFold(keyMeta.Protocol, "cggmp21") {
+		return "cggmp21"
+	}
+	switch strings.ToLower(keyMeta.Algorithm) {
+	case "ecdsa":
+		return "gg20"
+	case "schnorr", "eddsa":
+		return "frost"
+	default:
+		return ""
+	}
+}
+
+// selectNodes 选择预签名的参与节点：密钥当前委员会中的活跃节点，数量等于阈值
+func (p *PresignPool) selectNodes(ctx context.Context, keyMeta *storage.KeyMetadata) ([]string, error) {
+	if keyMeta.Threshold < 2 {
+		return nil, errors.Errorf("presign requires a threshold of at least 2, key %s has %d", keyMeta.KeyID, keyMeta.Threshold)
+	}
+	return selectCommitteeNodes(ctx, p.nodeDiscovery, keyMeta.NodeIDs, keyMeta.Threshold)
+}
+
+// maxCommitteeDiscovery 选择委员会节点时最多发现的活跃节点数量
+// 委员会可能只是活跃节点的一部分（resharing 后旧委员会节点仍然活跃），需要取回全部活跃节点再筛选
+const maxCommitteeDiscovery = 1000
+
+// selectCommitteeNodes 从密钥当前委员会 committee 的活跃参与者中选择 count 个节点
+// 不在委员会中的节点没有当前轮次的分片，不能参与签名；未记录委员会的旧密钥使用任意活跃节点
+func selectCommitteeNodes(ctx context.Context, nodeDiscovery *node.Discovery, committee []string, count int) ([]string, error) {
+	limit := count
+	if len(committee) > 0 {
+		limit = maxCommitteeDiscovery
+	}
+	participants, err := nodeDiscovery.DiscoverNodes(ctx, node.NodeTypeParticipant, node.NodeStatusActive, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to discover participants")
+	}
+
+	members := make(map[string]struct{}, len(committee))
+	for _, nodeID := range committee {
+		members[nodeID] = struct{}{}
+	}
+
+	// 按委员会顺序选择，同一委员会的签名节点保持稳定
+	active := make(map[string]struct{}, len(participants))
+	var nodeIDs []string
+	for _, participant := range participants {
+		if len(members) == 0 {
+			if len(nodeIDs) < count {
+				nodeIDs = append(nodeIDs, participant.NodeID)
+			}
+			continue
+		}
+		if _, ok := members[participant.NodeID]; ok {
+			active[participant.NodeID] = struct{}{}
+		}
+	}
+	for _, nodeID := range committee {
+		if len(nodeIDs) >= count {
+			break
+		}
+		if _, ok := active[nodeID]; ok {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+
+	if len(nodeIDs) < count {
+		return nil, errors.Errorf("insufficient active nodes in key committee: need %d, have %d", count, len(nodeIDs))
+	}
+	return nodeIDs, nil
+}
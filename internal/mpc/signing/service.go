@@ -7,10 +7,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/audit"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/key"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/node"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/policy"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
 	"github.com/kashguard/go-mpc-wallet/internal/mpc/session"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/webhook"
 	pb "github.com/kashguard/go-mpc-wallet/internal/pb/mpc/v1"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -23,35 +27,76 @@ type GRPCClient interface {
 
 // Service 签名服务
 type Service struct {
-	keyService      *key.Service
-	protocolEngine  protocol.Engine
-	sessionManager  *session.Manager
-	nodeDiscovery   *node.Discovery
-	defaultProtocol string     // 默认协议（从配置中获取）
-	grpcClient      GRPCClient // gRPC客户端，用于调用participant节点
+	keyService       *key.Service
+	protocolEngine   protocol.Engine
+	protocolRegistry *protocol.ProtocolRegistry // 协议注册表（可选，用于按密钥协议验证签名）
+	sessionManager   *session.Manager
+	nodeDiscovery    *node.Discovery
+	defaultProtocol  string           // 默认协议（从配置中获取）
+	grpcClient       GRPCClient       // gRPC客户端，用于调用participant节点
+	presignPool      *PresignPool     // GG20/FROST 预签名池（未开启时签名总是执行完整协议）
+	policyEngine     *policy.Engine   // 交易策略引擎（未启用时不做策略检查）
+	auditLogger      *audit.Logger    // 审计日志（未启用时不记录）
+	webhooks         *webhook.Service // 签名完成和失败的 webhook 事件（未启用时不发布）
+	metadataStore    storage.MetadataStore
+	now              func() time.Time
+	// execute 执行异步签名（默认在后台 goroutine 中，签名可能持续数分钟）
+	execute func(func())
 }
 
 // NewService 创建签名服务
 func NewService(
 	keyService *key.Service,
 	protocolEngine protocol.Engine,
+	protocolRegistry *protocol.ProtocolRegistry,
 	sessionManager *session.Manager,
 	nodeDiscovery *node.Discovery,
 	defaultProtocol string,
 	grpcClient GRPCClient,
+	presignPool *PresignPool,
+	policyEngine *policy.Engine,
+	auditLogger *audit.Logger,
+	metadataStore storage.MetadataStore,
+	webhooks *webhook.Service,
 ) *Service {
 	return &Service{
-		keyService:      keyService,
-		protocolEngine:  protocolEngine,
-		sessionManager:  sessionManager,
-		nodeDiscovery:   nodeDiscovery,
-		defaultProtocol: defaultProtocol,
-		grpcClient:      grpcClient,
+		keyService:       keyService,
+		protocolEngine:   protocolEngine,
+		protocolRegistry: protocolRegistry,
+		sessionManager:   sessionManager,
+		nodeDiscovery:    nodeDiscovery,
+		defaultProtocol:  defaultProtocol,
+		grpcClient:       grpcClient,
+		presignPool:      presignPool,
+		policyEngine:     policyEngine,
+		auditLogger:      auditLogger,
+		metadataStore:    metadataStore,
+		webhooks:         webhooks,
+		now:              time.Now,
+		execute:          func(f func()) { go f() },
 	}
 }
 
+// engineFor 返回协议对应的引擎，注册表中没有时使用默认引擎
+func (s *Service) engineFor(protocolName string) protocol.Engine {
+	if s.protocolRegistry != nil && protocolName != "" {
+		if engine, err := s.protocolRegistry.Get(protocolName); err == nil {
+			return engine
+		}
+	}
+	return s.protocolEngine
+}
+
+// keyProtocol 返回密钥使用的协议：优先使用密钥元数据中记录的 DKG 协议，未记录时按算法推断
+func keyProtocol(keyMetadata *key.KeyMetadata, defaultProtocol string) string {
+	if keyMetadata.Protocol != "" {
+		return strings.ToLower(keyMetadata.Protocol)
+	}
+	return inferProtocol(keyMetadata.Algorithm, keyMetadata.Curve, defaultProtocol)
+}
+
 // inferProtocol 根据密钥的 Algorithm 和 Curve 推断协议类型
-// 返回协议名称（gg18, gg20, frost）
+// 返回协议名称（gg18, gg20, cggmp21, frost）
 func inferProtocol(algorithm, curve, defaultProtocol string) string {
 	algorithmLower := strings.ToLower(algorithm)
 	curveLower := strings.ToLower(curve)
@@ -63,10 +108,10 @@ func inferProtocol(algorithm, curve, defaultProtocol string) string {
 		}
 	}
 
-	// ECDSA + secp256k1：使用默认协议（gg18 或 gg20）
+	// ECDSA + secp256k1：使用默认协议（gg18、gg20 或 cggmp21）
 	if algorithmLower == "ecdsa" && curveLower == "secp256k1" {
-		// 如果默认协议是 gg18 或 gg20，使用默认协议
-		if defaultProtocol == "gg18" || defaultProtocol == "gg20" {
+		// 如果默认协议是 ECDSA 协议，使用默认协议
+		if defaultProtocol == "gg18" || defaultProtocol == "gg20" || defaultProtocol == "cggmp21" {
 			return defaultProtocol
 		}
 		// 否则默认使用 gg20
@@ -82,37 +127,205 @@ func inferProtocol(algorithm, curve, defaultProtocol string) string {
 	return "gg20"
 }
 
-// ThresholdSign 阈值签名
+// ThresholdSign 阈值签名，每次签名（包括被策略拒绝的请求）都记录审计日志
 func (s *Service) ThresholdSign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	resp, err := s.thresholdSign(ctx, req)
+	s.recordSign(ctx, req, "", resp, err)
+	return resp, err
+}
+
+// recordSign 记录签名的审计日志并发布 webhook 事件，策略拒绝或需要审批时结果为 denied（不发布事件）。
+// sessionID 为异步签名请求的会话 ID，同步签名为空
+func (s *Service) recordSign(ctx context.Context, req *SignRequest, sessionID string, resp *SignResponse, err error) {
+	event := &audit.Event{
+		EventType: audit.EventTypeSigning,
+		Operation: audit.OperationSign,
+		Result:    audit.ResultOf(err),
+		KeyID:     req.KeyID,
+		Details: map[string]interface{}{
+			"message_type": req.MessageType,
+			"chain_type":   req.ChainType,
+		},
+	}
+	if req.ApprovalID != "" {
+		event.Details["approval_id"] = req.ApprovalID
+	}
+	if req.DerivationPath != "" {
+		event.Details["derivation_path"] = req.DerivationPath
+	}
+	var decisionErr *policy.DecisionError
+	if errors.As(err, &decisionErr) {
+		event.Result = audit.ResultDenied
+		event.Details["decision"] = decisionErr.Decision.Decision
+		event.Details["rule_id"] = decisionErr.Decision.RuleID
+	} else if err != nil {
+		event.Details["error"] = err.Error()
+	}
+	event.SessionID = sessionID
+	if resp != nil {
+		event.SessionID = resp.SessionID
+		event.Details["participating_nodes"] = resp.ParticipatingNodes
+	}
+	s.auditLogger.Record(ctx, event)
+
+	if event.Result != audit.ResultDenied {
+		s.publishSign(ctx, req, sessionID, resp, err)
+	}
+}
+
+// publishSign 发布签名完成或失败的 webhook 事件，异步签名的 session_id 为请求的会话 ID（查询句柄）
+func (s *Service) publishSign(ctx context.Context, req *SignRequest, sessionID string, resp *SignResponse, err error) {
+	data := map[string]interface{}{
+		"key_id":       req.KeyID,
+		"chain_type":   req.ChainType,
+		"message_type": req.MessageType,
+	}
+	if req.ApprovalID != "" {
+		data["approval_id"] = req.ApprovalID
+	}
+	if sessionID != "" {
+		data["session_id"] = sessionID
+	}
+	if err != nil {
+		data["error"] = err.Error()
+		s.webhooks.Publish(ctx, webhook.EventSigningFailed, req.KeyID, data)
+		return
+	}
+	if sessionID == "" {
+		data["session_id"] = resp.SessionID
+	}
+	data["signature"] = resp.Signature
+	data["signature_format"] = resp.SignatureFormat
+	data["public_key"] = resp.PublicKey
+	data["message"] = resp.Message
+	data["signed_at"] = resp.SignedAt
+	data["participating_nodes"] = resp.ParticipatingNodes
+	s.webhooks.Publish(ctx, webhook.EventSigningCompleted, req.KeyID, data)
+}
+
+// signPlan 通知节点前在 coordinator 上完成检查的签名请求
+type signPlan struct {
+	req              *SignRequest
+	keyMetadata      *key.KeyMetadata
+	protocolName     string
+	chainCode        []byte
+	signingPublicKey string
+	payload          *signPayload
+}
+
+func (s *Service) thresholdSign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	plan, err := s.prepareSign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.executeSign(ctx, plan, nil)
+}
+
+// prepareSign 获取密钥并检查请求（派生路径、签名格式、交易策略），不通知任何节点
+func (s *Service) prepareSign(ctx context.Context, req *SignRequest) (*signPlan, error) {
 	// 1. 获取密钥信息
 	keyMetadata, err := s.keyService.GetKey(ctx, req.KeyID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get key")
 	}
+	// 禁用、等待删除和已删除的密钥不能签名
+	if !keyMetadata.Enabled() {
+		return nil, errors.Wrapf(key.ErrKeyNotEnabled, "key %s is %s", req.KeyID, keyMetadata.Status)
+	}
 
-	// 2. 推断协议类型
-	protocolName := inferProtocol(keyMetadata.Algorithm, keyMetadata.Curve, s.defaultProtocol)
+	// 2. 确定协议类型（历史 GG20 密钥继续使用 GG20，CGGMP21 密钥使用 CGGMP21）
+	protocolName := keyProtocol(keyMetadata, s.defaultProtocol)
 
-	// 3. 创建签名会话
-	signingSession, err := s.sessionManager.CreateSession(ctx, req.KeyID, protocolName, keyMetadata.Threshold, keyMetadata.TotalNodes)
+	if req.TaprootKeySpend && (protocolName != "frost" || strings.ToLower(keyMetadata.Curve) != "secp256k1") {
+		return nil, errors.Errorf("taproot key-path signing requires a FROST secp256k1 key, key %s uses %s/%s", req.KeyID, keyMetadata.Algorithm, keyMetadata.Curve)
+	}
+
+	var chainCode []byte
+	if req.DerivationPath != "" {
+		if keyMetadata.ChainCode == "" {
+			return nil, errors.Errorf("key %s has no chain code and does not support derivation", req.KeyID)
+		}
+		chainCode, err = hex.DecodeString(keyMetadata.ChainCode)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode chain code")
+		}
+	}
+
+	// 先在 coordinator 上计算签名公钥，无效的派生路径在通知节点前即被拒绝
+	signingPublicKey, err := s.signingPublicKey(keyMetadata, req)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create signing session")
+		return nil, err
+	}
+	if _, err := resolveSignatureFormat(req, keyMetadata.Algorithm); err != nil {
+		return nil, err
 	}
+	payload, err := resolveSignPayload(req, keyMetadata.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	// 通知节点前检查交易策略，拒绝或需要审批时返回 *policy.DecisionError
+	if err := s.checkPolicy(ctx, req, keyMetadata, payload); err != nil {
+		return nil, err
+	}
+
+	return &signPlan{
+		req:              req,
+		keyMetadata:      keyMetadata,
+		protocolName:     protocolName,
+		chainCode:        chainCode,
+		signingPublicKey: signingPublicKey,
+		payload:          payload,
+	}, nil
+}
 
-	// 4. 选择参与节点（达到阈值即可）
-	participants, err := s.nodeDiscovery.DiscoverNodes(ctx, node.NodeTypeParticipant, node.NodeStatusActive, keyMetadata.Threshold)
+// createSigningSession 为签名请求创建签名会话
+func (s *Service) createSigningSession(ctx context.Context, plan *signPlan) (*session.Session, error) {
+	signingSession, err := s.sessionManager.CreateSession(ctx, plan.req.KeyID, plan.protocolName, plan.keyMetadata.Threshold, plan.keyMetadata.TotalNodes)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to discover participants")
+		return nil, errors.Wrap(err, "failed to create signing session")
+	}
+	return signingSession, nil
+}
+
+// executeSign 在签名会话中执行签名，signingSession 为空时创建新会话
+func (s *Service) executeSign(ctx context.Context, plan *signPlan, signingSession *session.Session) (*SignResponse, error) {
+	req, keyMetadata, protocolName := plan.req, plan.keyMetadata, plan.protocolName
+
+	// 3. 创建签名会话
+	if signingSession == nil {
+		var err error
+		if signingSession, err = s.createSigningSession(ctx, plan); err != nil {
+			return nil, err
+		}
 	}
 
-	if len(participants) < keyMetadata.Threshold {
-		return nil, errors.Errorf("insufficient active nodes: need %d, have %d", keyMetadata.Threshold, len(participants))
+	// GG20/CGGMP21/FROST：优先使用预签名，只执行单轮在线签名（预签名绑定根密钥，派生子密钥签名时不可用）
+	if (protocolName == "gg20" || protocolName == "cggmp21" || protocolName == "frost") && req.DerivationPath == "" && s.presignPool.Enabled() {
+		presig, err := s.presignPool.Claim(ctx, req.KeyID, keyMetadata.ShareEpoch)
+		if err != nil {
+			log.Warn().Err(err).Str("key_id", req.KeyID).Msg("Failed to claim presignature, using full signing protocol")
+		} else if presig != nil {
+			resp, err := s.thresholdSignWithPresignature(ctx, plan, presig, signingSession)
+			if err == nil {
+				return resp, nil
+			}
+			// 预签名已标记为已使用，不会再次分配；退回完整签名协议
+			log.Warn().
+				Err(err).
+				Str("key_id", req.KeyID).
+				Str("presignature_id", presig.PresignatureID).
+				Msg("Presigned signing failed, falling back to full signing protocol")
+			// 节点按会话去重 StartSign，完整签名协议使用新会话
+			if signingSession, err = s.createSigningSession(ctx, plan); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	// 选择前 threshold 个节点
-	participatingNodes := make([]string, 0, keyMetadata.Threshold)
-	for i := 0; i < keyMetadata.Threshold && i < len(participants); i++ {
-		participatingNodes = append(participatingNodes, participants[i].NodeID)
+	// 4. 从密钥当前委员会中选择参与节点（达到阈值即可）
+	participatingNodes, err := selectCommitteeNodes(ctx, s.nodeDiscovery, keyMetadata.NodeIDs, keyMetadata.Threshold)
+	if err != nil {
+		return nil, err
 	}
 
 	// 更新会话的参与节点
@@ -121,74 +334,68 @@ func (s *Service) ThresholdSign(ctx context.Context, req *SignRequest) (*SignRes
 		return nil, errors.Wrap(err, "failed to update session with participating nodes")
 	}
 
-	// 5. 准备消息
-	var message []byte
-	if req.MessageHex != "" {
-		var err error
-		message, err = hex.DecodeString(req.MessageHex)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to decode message hex")
-		}
-	} else {
-		message = req.Message
-	}
-
-	// 6. 通过 gRPC 调用 participant 节点执行签名
-	// Coordinator 不直接执行签名，而是通知 participant 节点执行
-	// 选择第一个 participant 节点作为 leader（类似 DKG 流程）
+	// 5. 通过 gRPC 调用 participant 节点执行签名
+	// Coordinator 不直接执行签名，而是通知所有参与节点启动签名协议（StartSign 在节点上按会话去重）
 	if len(participatingNodes) == 0 {
 		return nil, errors.New("no participating nodes available")
 	}
 
-	leaderNodeID := participatingNodes[0]
-
 	// 准备 StartSign 请求
 	startSignReq := &pb.StartSignRequest{
-		SessionId:  signingSession.SessionID,
-		KeyId:      req.KeyID,
-		Message:    message,
-		MessageHex: hex.EncodeToString(message),
-		Protocol:   protocolName,
-		Threshold:  int32(keyMetadata.Threshold),
-		TotalNodes: int32(keyMetadata.TotalNodes),
-		NodeIds:    participatingNodes,
+		SessionId:         signingSession.SessionID,
+		KeyId:             req.KeyID,
+		Message:           plan.payload.signed,
+		MessageHex:        hex.EncodeToString(plan.payload.signed),
+		Protocol:          protocolName,
+		Threshold:         int32(keyMetadata.Threshold),
+		TotalNodes:        int32(keyMetadata.TotalNodes),
+		NodeIds:           participatingNodes,
+		TaprootKeySpend:   req.TaprootKeySpend,
+		TaprootMerkleRoot: req.TaprootMerkleRoot,
+		DerivationPath:    req.DerivationPath,
+		ChainCode:         plan.chainCode,
+		Prehashed:         plan.payload.prehashed,
 	}
 
 	log.Info().
 		Str("key_id", req.KeyID).
 		Str("session_id", signingSession.SessionID).
-		Str("leader_node_id", leaderNodeID).
 		Str("protocol", protocolName).
-		Int("participating_nodes_count", len(participatingNodes)).
-		Msg("Calling StartSign RPC on leader participant node")
+		Strs("participating_nodes", participatingNodes).
+		Msg("Calling StartSign RPC on all participating nodes")
 
-	// 调用 leader participant 节点的 StartSign RPC
-	// 注意：签名协议会在 participant 节点间执行，coordinator 只负责协调
+	// 签名协议在 participant 节点间执行，coordinator 只负责协调
 	startSignCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	startResp, err := s.grpcClient.SendStartSign(startSignCtx, leaderNodeID, startSignReq)
-	if err != nil {
-		// 标记会话为失败
-		signingSession.Status = "failed"
-		s.sessionManager.UpdateSession(ctx, signingSession)
-		return nil, errors.Wrap(err, "failed to call StartSign on leader participant")
+	startErrs := make(chan error, len(participatingNodes))
+	for _, nodeID := range participatingNodes {
+		go func(nodeID string) {
+			startResp, err := s.grpcClient.SendStartSign(startSignCtx, nodeID, startSignReq)
+			switch {
+			case err != nil:
+				startErrs <- errors.Wrapf(err, "failed to call StartSign on participant %s", nodeID)
+			case !startResp.Started:
+				startErrs <- errors.Errorf("StartSign on participant %s failed: %s", nodeID, startResp.Message)
+			default:
+				startErrs <- nil
+			}
+		}(nodeID)
 	}
-
-	if !startResp.Started {
-		// 标记会话为失败
-		signingSession.Status = "failed"
-		s.sessionManager.UpdateSession(ctx, signingSession)
-		return nil, errors.Errorf("StartSign failed: %s", startResp.Message)
+	for range participatingNodes {
+		if err := <-startErrs; err != nil {
+			// 标记会话为失败
+			s.sessionManager.FailSession(ctx, signingSession.SessionID, err.Error(), nil)
+			return nil, err
+		}
 	}
 
 	log.Info().
 		Str("key_id", req.KeyID).
 		Str("session_id", signingSession.SessionID).
-		Str("leader_node_id", leaderNodeID).
 		Msg("StartSign RPC succeeded, waiting for signature completion")
 
-	// 7. 等待签名完成（轮询会话状态）
+	// 6. 等待签名完成（轮询会话状态）
 	// 签名完成后，会话的 Signature 字段会被更新
 	maxWaitTime := 5 * time.Minute
 	pollInterval := 2 * time.Second
@@ -215,7 +422,7 @@ func (s *Service) ThresholdSign(ctx context.Context, req *SignRequest) (*SignRes
 
 		// 检查是否失败
 		if updatedSession.Status == "failed" {
-			return nil, errors.New("signing session failed")
+			return nil, sessionFailureError(updatedSession)
 		}
 
 		// 等待一段时间后再次检查
@@ -224,25 +431,177 @@ func (s *Service) ThresholdSign(ctx context.Context, req *SignRequest) (*SignRes
 
 	if signatureHex == "" {
 		// 超时
-		signingSession.Status = "failed"
-		s.sessionManager.UpdateSession(ctx, signingSession)
+		s.sessionManager.FailSession(ctx, signingSession.SessionID, "signing timeout", nil)
 		return nil, errors.New("signing timeout")
 	}
 
-	// 8. 验证签名（可选，但建议验证）
-	pubKeyBytes, err := hex.DecodeString(keyMetadata.PublicKey)
+	// 7. 验证签名（可选，但建议验证）
+	if err := s.verifySignature(ctx, s.engineFor(protocolName), plan.signingPublicKey, signatureHex, plan.payload); err != nil {
+		return nil, err
+	}
+
+	// 8. 构建响应（按请求的格式编码签名）
+	return newSignResponse(req, keyMetadata.Algorithm, signatureHex, plan.payload, plan.signingPublicKey, signingSession.SessionID, participatingNodes)
+}
+
+// thresholdSignWithPresignature 使用预签名执行单轮在线签名
+// 通知预签名的所有参与节点同步完成在线轮次，节点直接在 StartSign 响应中返回签名，无需轮询会话
+func (s *Service) thresholdSignWithPresignature(ctx context.Context, plan *signPlan, presig *storage.Presignature, signingSession *session.Session) (*SignResponse, error) {
+	req, keyMetadata, protocolName, payload := plan.req, plan.keyMetadata, plan.protocolName, plan.payload
+
+	signingSession.ParticipatingNodes = presig.NodeIDs
+	if err := s.sessionManager.UpdateSession(ctx, signingSession); err != nil {
+		return nil, errors.Wrap(err, "failed to update session with participating nodes")
+	}
+
+	startSignReq := &pb.StartSignRequest{
+		SessionId:         signingSession.SessionID,
+		KeyId:             req.KeyID,
+		Message:           payload.signed,
+		MessageHex:        hex.EncodeToString(payload.signed),
+		Protocol:          protocolName,
+		Threshold:         int32(len(presig.NodeIDs)),
+		TotalNodes:        int32(keyMetadata.TotalNodes),
+		NodeIds:           presig.NodeIDs,
+		PresignatureId:    presig.PresignatureID,
+		TaprootKeySpend:   req.TaprootKeySpend,
+		TaprootMerkleRoot: req.TaprootMerkleRoot,
+		Prehashed:         payload.prehashed,
+	}
+
+	log.Info().
+		Str("key_id", req.KeyID).
+		Str("session_id", signingSession.SessionID).
+		Str("presignature_id", presig.PresignatureID).
+		Strs("node_ids", presig.NodeIDs).
+		Msg("Calling StartSign RPC with presignature on all participants")
+
+	signCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	type startSignResult struct {
+		nodeID string
+		resp   *pb.StartSignResponse
+		err    error
+	}
+	results := make(chan startSignResult, len(presig.NodeIDs))
+	for _, nodeID := range presig.NodeIDs {
+		go func(nodeID string) {
+			resp, err := s.grpcClient.SendStartSign(signCtx, nodeID, startSignReq)
+			results <- startSignResult{nodeID: nodeID, resp: resp, err: err}
+		}(nodeID)
+	}
+
+	var signatureHex string
+	var firstErr error
+	for range presig.NodeIDs {
+		result := <-results
+		switch {
+		case result.err != nil:
+			firstErr = errors.Wrapf(result.err, "StartSign on node %s", result.nodeID)
+		case !result.resp.Started || result.resp.Signature == "":
+			firstErr = errors.Errorf("StartSign on node %s failed: %s", result.nodeID, result.resp.Message)
+		case signatureHex == "":
+			signatureHex = result.resp.Signature
+		case signatureHex != result.resp.Signature:
+			firstErr = errors.Errorf("node %s returned a different signature", result.nodeID)
+		}
+		if firstErr != nil {
+			break
+		}
+	}
+
+	if firstErr != nil || signatureHex == "" {
+		if firstErr == nil {
+			firstErr = errors.New("presigned signing returned no signature")
+		}
+		s.sessionManager.FailSession(ctx, signingSession.SessionID, firstErr.Error(), nil)
+		// 参与节点会把可识别中止的责任节点写入会话
+		if failedSession, err := s.sessionManager.GetSession(ctx, signingSession.SessionID); err == nil && len(failedSession.Culprits) > 0 {
+			return nil, sessionFailureError(failedSession)
+		}
+		return nil, firstErr
+	}
+
+	if err := s.verifySignature(ctx, s.engineFor(protocolName), plan.signingPublicKey, signatureHex, payload); err != nil {
+		return nil, err
+	}
+
+	return newSignResponse(req, keyMetadata.Algorithm, signatureHex, payload, plan.signingPublicKey, signingSession.SessionID, presig.NodeIDs)
+}
+
+// sessionFailureError 根据会话记录的失败原因和责任节点构建错误
+func sessionFailureError(sess *session.Session) error {
+	reason := sess.FailureReason
+	if reason == "" {
+		reason = "unknown reason"
+	}
+	if len(sess.Culprits) > 0 {
+		return errors.Errorf("signing session %s failed: %s (culprits: %s)", sess.SessionID, reason, strings.Join(sess.Culprits, ","))
+	}
+	return errors.Errorf("signing session %s failed: %s", sess.SessionID, reason)
+}
+
+// resolveSignMessage 解析签名请求中的消息（优先使用 MessageHex）
+func resolveSignMessage(req *SignRequest) ([]byte, error) {
+	if req.MessageHex != "" {
+		message, err := hex.DecodeString(req.MessageHex)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode message hex")
+		}
+		return message, nil
+	}
+	return req.Message, nil
+}
+
+// signingPublicKey 返回签名对应的公钥：指定派生路径时为 BIP-32 子公钥；
+// Taproot key-path 签名对应（派生后）调整的输出公钥（02||x），否则为密钥公钥
+func (s *Service) signingPublicKey(keyMetadata *key.KeyMetadata, req *SignRequest) (string, error) {
+	publicKeyHex := keyMetadata.PublicKey
+	if req.DerivationPath != "" {
+		rootPublicKey, err := hex.DecodeString(publicKeyHex)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to decode public key hex")
+		}
+		chainCode, err := hex.DecodeString(keyMetadata.ChainCode)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to decode chain code")
+		}
+		derived, err := protocol.DeriveChildPublicKey(keyMetadata.Curve, rootPublicKey, chainCode, req.DerivationPath)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to derive signing key")
+		}
+		publicKeyHex = hex.EncodeToString(derived.PublicKey)
+	}
+	if !req.TaprootKeySpend {
+		return publicKeyHex, nil
+	}
+	internalKey, err := hex.DecodeString(publicKeyHex)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode public key hex")
+		return "", errors.Wrap(err, "failed to decode public key hex")
+	}
+	outputKey, err := protocol.TaprootOutputKey(internalKey, req.TaprootMerkleRoot)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compute taproot output key")
+	}
+	return hex.EncodeToString(outputKey), nil
+}
+
+// verifySignature 使用密钥公钥验证协议返回的签名（由签名所用协议的引擎验证，预哈希签名直接验证摘要）
+func (s *Service) verifySignature(ctx context.Context, engine protocol.Engine, publicKeyHex string, signatureHex string, payload *signPayload) error {
+	pubKeyBytes, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode public key hex")
 	}
 
 	pubKey := &protocol.PublicKey{
-		Hex:   keyMetadata.PublicKey,
+		Hex:   publicKeyHex,
 		Bytes: pubKeyBytes,
 	}
 
 	sigBytes, err := hex.DecodeString(signatureHex)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode signature hex")
+		return errors.Wrap(err, "failed to decode signature hex")
 	}
 
 	signature := &protocol.Signature{
@@ -255,27 +614,19 @@ func (s *Service) ThresholdSign(ctx context.Context, req *SignRequest) (*SignRes
 		signature.S = sigBytes[32:64]
 	}
 
-	valid, err := s.protocolEngine.VerifySignature(ctx, signature, message, pubKey)
+	var valid bool
+	if payload.prehashed {
+		valid, err = protocol.VerifyECDSADigest(signature, payload.digest, pubKey)
+	} else {
+		valid, err = engine.VerifySignature(ctx, signature, payload.signed, pubKey)
+	}
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to verify signature")
+		return errors.Wrap(err, "failed to verify signature")
 	}
 	if !valid {
-		return nil, errors.New("signature verification failed")
-	}
-
-	// 9. 构建响应
-	response := &SignResponse{
-		Signature:          signatureHex,
-		KeyID:              req.KeyID,
-		PublicKey:          keyMetadata.PublicKey,
-		Message:            hex.EncodeToString(message),
-		ChainType:          req.ChainType,
-		SessionID:          signingSession.SessionID,
-		SignedAt:           time.Now().Format(time.RFC3339),
-		ParticipatingNodes: participatingNodes,
+		return errors.New("signature verification failed")
 	}
-
-	return response, nil
+	return nil
 }
 
 // BatchSign 批量签名
@@ -337,40 +688,59 @@ func (s *Service) BatchSign(ctx context.Context, req *BatchSignRequest) (*BatchS
 }
 
 // Verify 验证签名
+// ECDSA 签名接受 DER、DER+sighash、r||s 和 r||s||v 编码；EdDSA/Schnorr 签名为 64 字节原始签名。
+// 未指定算法时按公钥长度推断：32 字节为 EdDSA，否则为 ECDSA
 func (s *Service) Verify(ctx context.Context, req *VerifyRequest) (*VerifyResponse, error) {
-	// 1. 解析签名
-	sigBytes, err := hex.DecodeString(req.Signature)
+	// 1. 解析公钥
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(req.PublicKey, "0x"))
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode signature hex")
+		return nil, errors.Wrap(err, "failed to decode public key hex")
 	}
 
-	// 构建签名对象（假设签名格式为 R||S）
-	if len(sigBytes) < 64 {
-		return nil, errors.New("invalid signature length")
+	pubKey := &protocol.PublicKey{
+		Bytes: pubKeyBytes,
+		Hex:   req.PublicKey,
 	}
 
-	signature := &protocol.Signature{
-		Bytes: sigBytes,
-		Hex:   req.Signature,
-		R:     sigBytes[:32],
-		S:     sigBytes[32:64],
+	// 2. 解析签名
+	sigBytes, err := hex.DecodeString(strings.TrimPrefix(req.Signature, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode signature hex")
 	}
 
-	// 2. 解析公钥
-	pubKeyBytes, err := hex.DecodeString(req.PublicKey)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode public key hex")
+	algorithm := req.Algorithm
+	if algorithm == "" {
+		algorithm = "ECDSA"
+		if len(pubKeyBytes) == 32 {
+			algorithm = "EdDSA"
+		}
 	}
 
-	pubKey := &protocol.PublicKey{
-		Bytes: pubKeyBytes,
-		Hex:   req.PublicKey,
+	var signature *protocol.Signature
+	var engine protocol.Engine
+	if strings.EqualFold(algorithm, "ecdsa") {
+		signature, err = protocol.ECDSASignatureFromEncoding(sigBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse signature")
+		}
+		engine = s.engineFor(inferProtocol("ECDSA", "secp256k1", s.defaultProtocol))
+	} else {
+		// EdDSA/Schnorr：R || S
+		if len(sigBytes) != 64 {
+			return nil, errors.Errorf("invalid signature length: expected 64 bytes, got %d", len(sigBytes))
+		}
+		signature = &protocol.Signature{
+			Bytes: sigBytes,
+			Hex:   hex.EncodeToString(sigBytes),
+			R:     sigBytes[:32],
+			S:     sigBytes[32:],
+		}
+		engine = s.engineFor("frost")
 	}
 
 	// 3. 准备消息
 	var message []byte
 	if req.MessageHex != "" {
-		var err error
 		message, err = hex.DecodeString(req.MessageHex)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to decode message hex")
@@ -380,7 +750,7 @@ func (s *Service) Verify(ctx context.Context, req *VerifyRequest) (*VerifyRespon
 	}
 
 	// 4. 验证签名
-	valid, err := s.protocolEngine.VerifySignature(ctx, signature, message, pubKey)
+	valid, err := engine.VerifySignature(ctx, signature, message, pubKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to verify signature")
 	}
@@ -0,0 +1,81 @@
+package signing
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/pkg/errors"
+)
+
+// DefaultSignatureFormat 未指定签名格式时按链类型和算法选择：
+// 以太坊为 r||s||v，比特币及其他 ECDSA 为 DER，EdDSA/Schnorr 为原始签名
+func DefaultSignatureFormat(chainType, algorithm string) string {
+	if !strings.EqualFold(algorithm, "ecdsa") {
+		return protocol.SignatureFormatRaw
+	}
+	switch strings.ToLower(chainType) {
+	case "ethereum", "eth", "evm":
+		return protocol.SignatureFormatRSV
+	default:
+		return protocol.SignatureFormatDER
+	}
+}
+
+// resolveSignatureFormat 返回请求的签名格式（未指定时使用链默认格式），并检查格式与密钥算法匹配
+func resolveSignatureFormat(req *SignRequest, algorithm string) (string, error) {
+	format := strings.ToLower(req.SignatureFormat)
+	if format == "" {
+		format = DefaultSignatureFormat(req.ChainType, algorithm)
+	}
+	if err := protocol.ValidateSignatureFormat(format, algorithm); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
+// encodeSignature 把引擎返回并已验证的签名编码为请求的格式，digest 为 ECDSA 实际签名的摘要
+func encodeSignature(algorithm, format string, sighashType byte, signatureHex string, digest []byte, publicKeyHex string) (*protocol.EncodedSignature, error) {
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode signature hex")
+	}
+	if !strings.EqualFold(algorithm, "ecdsa") {
+		return protocol.EncodeRawSignature(signature)
+	}
+	publicKey, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode public key hex")
+	}
+	return protocol.EncodeECDSASignature(format, signature, digest, publicKey, sighashType)
+}
+
+// newSignResponse 构建签名响应：签名按请求格式编码，同时返回 r/s 分量，ECDSA 签名附带 recovery id 和实际签名的摘要
+func newSignResponse(req *SignRequest, algorithm, signatureHex string, payload *signPayload, publicKeyHex, sessionID string, participatingNodes []string) (*SignResponse, error) {
+	format, err := resolveSignatureFormat(req, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := encodeSignature(algorithm, format, req.SighashType, signatureHex, payload.digest, publicKeyHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode signature")
+	}
+
+	return &SignResponse{
+		Signature:          hex.EncodeToString(encoded.Bytes),
+		SignatureFormat:    encoded.Format,
+		R:                  hex.EncodeToString(encoded.R),
+		S:                  hex.EncodeToString(encoded.S),
+		V:                  encoded.V,
+		RecoveryID:         encoded.RecoveryID,
+		KeyID:              req.KeyID,
+		PublicKey:          publicKeyHex,
+		Message:            hex.EncodeToString(payload.message),
+		Digest:             hex.EncodeToString(payload.digest),
+		ChainType:          req.ChainType,
+		SessionID:          sessionID,
+		SignedAt:           time.Now().Format(time.RFC3339),
+		ParticipatingNodes: participatingNodes,
+	}, nil
+}
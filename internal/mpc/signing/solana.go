@@ -0,0 +1,39 @@
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/chain"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/protocol"
+	"github.com/pkg/errors"
+)
+
+// SignSolanaTransaction 使用 EdDSA（FROST Ed25519）密钥对 SolanaAdapter.BuildTransaction 生成的交易消息进行门限签名，
+// 并将签名插入交易，返回可广播的交易。Ed25519 密钥不支持派生，签名公钥须为交易的付费账户
+func (s *Service) SignSolanaTransaction(ctx context.Context, keyID string, adapter *chain.SolanaAdapter, unsigned *chain.Transaction) (*chain.Transaction, error) {
+	if adapter == nil {
+		return nil, errors.New("solana adapter is required")
+	}
+	if unsigned == nil || unsigned.SigningMessage == "" {
+		return nil, errors.New("unsigned transaction has no signing message")
+	}
+
+	resp, err := s.ThresholdSign(ctx, &SignRequest{
+		KeyID:           keyID,
+		MessageHex:      unsigned.SigningMessage,
+		MessageType:     MessageTypeTransaction,
+		ChainType:       "solana",
+		SignatureFormat: protocol.SignatureFormatRaw,
+		UnsignedTx:      unsigned.Raw,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := hex.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode signature")
+	}
+	return adapter.AssembleTransaction(unsigned, signature)
+}
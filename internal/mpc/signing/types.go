@@ -1,26 +1,64 @@
 package signing
 
+import "time"
+
 // SignRequest 签名请求
 type SignRequest struct {
 	KeyID       string
 	Message     []byte
 	MessageHex  string
-	MessageType string // transaction, message, raw
+	MessageType string // transaction, message（EIP-191）, raw, typed_data（EIP-712）, sighash
 	ChainType   string
+	// TypedData EIP-712 结构化数据 JSON（eth_signTypedData_v4 格式），MessageType 为 typed_data 时使用，为空时使用 Message
+	TypedData []byte
+
+	// TaprootKeySpend 为 true 时按 BIP-341 key-path 签名（仅 FROST secp256k1 密钥），签名对应调整后的输出公钥
+	TaprootKeySpend bool
+	// TaprootMerkleRoot Taproot 脚本树根（可选，为空表示 BIP-86 无脚本路径）
+	TaprootMerkleRoot []byte
+	// DerivationPath 非强化 BIP-32 派生路径（如 m/0/5），非空时使用从根密钥派生的子密钥签名（仅 secp256k1）
+	DerivationPath string
+	// SignatureFormat 签名输出格式（der、der_sighash、compact、rsv、raw），为空时按链类型选择
+	SignatureFormat string
+	// SighashType der_sighash 格式追加的 sighash 字节，为 0 时使用 SIGHASH_ALL
+	SighashType byte
+	// UnsignedTx 被签名的未签名交易（EVM 为 Raw hex，Bitcoin 为 PSBT base64，Solana 为 Raw base64），
+	// 策略检查据此解析资产转移；交易签名未提供时，交易相关的策略规则一律视为命中
+	UnsignedTx string
+	// ApprovalID 已通过人工审批的签名请求 ID，由审批服务在达到法定人数后设置，策略的 require_approval 规则视为已满足
+	ApprovalID string
 }
 
 // SignResponse 签名响应
 type SignResponse struct {
-	Signature          string
+	Signature          string // 按 SignatureFormat 编码的签名（hex）
+	SignatureFormat    string
+	R                  string
+	S                  string
+	V                  int // 以太坊 v 值（27 + RecoveryID），仅 ECDSA
+	RecoveryID         int // ECDSA 公钥恢复标识，EdDSA/Schnorr 为 -1
 	KeyID              string
 	PublicKey          string
 	Message            string
+	Digest             string // ECDSA 实际签名的摘要（hex），EdDSA/Schnorr 为空
 	ChainType          string
 	SessionID          string
 	SignedAt           string
 	ParticipatingNodes []string
 }
 
+// SigningRequest 异步签名请求：SessionID 为签名会话 ID，作为查询句柄。
+// 预签名失败退回完整签名协议时使用新会话，Result.SessionID 为实际完成签名的会话
+type SigningRequest struct {
+	SessionID string
+	KeyID     string
+	Status    string        // pending、completed、failed
+	Result    *SignResponse // 签名成功后的结果
+	Error     string        // 签名失败的原因
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 // BatchSignRequest 批量签名请求
 type BatchSignRequest struct {
 	KeyID     string
@@ -3,6 +3,27 @@ package storage
 import (
 	"context"
 	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrKeyNotFound 密钥不存在
+var ErrKeyNotFound = errors.New("key not found")
+
+// ErrDuplicateVote 审批人已经对该签名审批投过票（违反审批 ID 和审批人的唯一约束）
+var ErrDuplicateVote = errors.New("approver has already voted")
+
+// 密钥状态
+// Pending（DKG 未完成的占位符）-> Enabled；Enabled <-> Disabled；Enabled/Disabled -> PendingDeletion；
+// PendingDeletion -> Disabled（取消删除）或 Destroying（等待期结束，开始销毁分片，不能再取消）；
+// Destroying -> Deleted（所有节点确认销毁）
+const (
+	KeyStatusPending         = "Pending"
+	KeyStatusEnabled         = "Enabled"
+	KeyStatusDisabled        = "Disabled"
+	KeyStatusPendingDeletion = "PendingDeletion"
+	KeyStatusDestroying      = "Destroying"
+	KeyStatusDeleted         = "Deleted"
 )
 
 // KeyMetadata 密钥元数据
@@ -18,9 +39,15 @@ type KeyMetadata struct {
 	Status       string
 	Description  string
 	Tags         map[string]string
+	ShareEpoch   int      // 分片轮次，每次 resharing 后递增
+	NodeIDs      []string // 当前持有分片的节点（委员会）
+	ChainCode    string   // BIP-32 链码（hex），用于非强化派生子密钥
+	Protocol     string   // DKG 使用的协议（gg18 / gg20 / frost / cggmp21）
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletionDate *time.Time
+	// DeletionAttempts 分片销毁连续失败的次数（删除任务据此退避）
+	DeletionAttempts int
 }
 
 // NodeInfo 节点信息
@@ -34,6 +61,7 @@ type NodeInfo struct {
 	Metadata      map[string]interface{}
 	RegisteredAt  time.Time
 	LastHeartbeat *time.Time
+	FaultScore    int // 被判定为协议中止责任方的累计次数
 }
 
 // SigningSession 签名会话
@@ -51,6 +79,255 @@ type SigningSession struct {
 	CreatedAt          time.Time
 	CompletedAt        *time.Time
 	DurationMs         int
+	FailureReason      string   // 会话失败原因
+	Culprits           []string // 可识别中止的责任节点 ID
+}
+
+// SigningSessionStatusFailed 签名会话失败状态
+const SigningSessionStatusFailed = "failed"
+
+// 预签名状态
+const (
+	PresignatureStatusAvailable   = "available"
+	PresignatureStatusUsed        = "used"
+	PresignatureStatusInvalidated = "invalidated"
+)
+
+// Presignature 协调者记录的 GG20 预签名（预签名份额只保存在参与节点上）
+type Presignature struct {
+	PresignatureID string
+	KeyID          string
+	NodeIDs        []string // 生成预签名的节点，在线签名必须由相同的节点完成
+	ShareEpoch     int      // 生成时密钥的分片轮次，resharing 后失效
+	Status         string
+	CreatedAt      time.Time
+	UsedAt         *time.Time
+}
+
+// KeyShareBackup 参与节点导出的加密分片备份（Backup 为 protocol.ShareBackup 的 JSON）
+type KeyShareBackup struct {
+	KeyID             string
+	NodeID            string
+	ShareEpoch        int
+	PublicShare       string // hex 编码的公开分片
+	RecoveryPublicKey string // hex 编码的 X25519 恢复公钥
+	Backup            []byte
+	CreatedAt         time.Time
+}
+
+// 密钥分片刷新结果
+const (
+	KeyRefreshStatusCompleted = "completed"
+	KeyRefreshStatusFailed    = "failed"
+)
+
+// KeyRefresh 密钥分片刷新记录（同一委员会和阈值重新分享，公钥不变，share epoch 加一）
+type KeyRefresh struct {
+	RefreshID   string
+	KeyID       string
+	Status      string
+	OldEpoch    int
+	NewEpoch    int // 失败时与 OldEpoch 相同
+	Error       string
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+// KeyRefreshDueFilter 到期刷新密钥的查询条件：最近一次成功刷新（从未刷新时为创建时间）不晚于 RefreshedBefore，
+// 且不在失败退避中（最近一次成功之后连续失败 n 次时，最后一次失败后等待 RetryBackoff*2^(n-1)，最长 MaxRetryBackoff）
+type KeyRefreshDueFilter struct {
+	RefreshedBefore time.Time
+	Now             time.Time
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	Limit           int
+}
+
+// KeyShareValidation 密钥分片一致性校验的最近一次结果（Nodes 为各节点校验结果的 JSON）
+type KeyShareValidation struct {
+	KeyID       string
+	ShareEpoch  int
+	Status      string
+	Nodes       []byte
+	ValidatedAt time.Time
+}
+
+// PolicyRule 交易策略规则（Params 为规则参数的 JSON），KeyID 为空表示对所有密钥生效
+type PolicyRule struct {
+	RuleID      string
+	KeyID       string
+	ChainType   string // 为空表示对所有链生效
+	RuleType    string
+	Action      string // 规则命中时的决策：deny 或 require_approval
+	Params      []byte
+	Enabled     bool
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// PolicyDecision 签名请求的策略决策记录（Transfers 为交易中资产转移的 JSON 数组）
+type PolicyDecision struct {
+	DecisionID  string
+	KeyID       string
+	ChainType   string
+	MessageType string
+	Decision    string
+	RuleID      string // 决定结果的规则，allow 时为空
+	Reason      string
+	Transfers   []byte
+	TxHash      string // 未签名交易的标识，同一交易的多次决策（如 Bitcoin 各输入）在限额统计中只计一次
+	CreatedAt   time.Time
+}
+
+// 签名审批状态
+const (
+	SigningApprovalStatusPending  = "pending"
+	SigningApprovalStatusApproved = "approved" // 达到法定人数，正在执行签名
+	SigningApprovalStatusRejected = "rejected"
+	SigningApprovalStatusExpired  = "expired"
+	SigningApprovalStatusExecuted = "executed"
+	SigningApprovalStatusFailed   = "failed"
+)
+
+// SigningApproval 等待人工审批的签名请求（Request 为签名请求的 JSON，Result 为签名结果的 JSON）
+type SigningApproval struct {
+	ApprovalID        string
+	KeyID             string
+	DecisionID        string // 要求审批的策略决策
+	RuleID            string
+	Reason            string
+	RequestedBy       string   // 发起签名的用户 ID
+	Approvers         []string // 可以审批的用户 ID
+	RequiredApprovals int
+	Request           []byte
+	Status            string
+	Result            []byte
+	Error             string
+	ExpiresAt         time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// 异步签名请求状态
+const (
+	SigningRequestStatusPending   = "pending"
+	SigningRequestStatusCompleted = "completed"
+	SigningRequestStatusFailed    = "failed"
+)
+
+// SigningRequest 异步签名请求，以签名会话 ID 作为查询句柄（Result 为签名结果的 JSON）
+type SigningRequest struct {
+	SessionID string
+	KeyID     string
+	Status    string
+	Result    []byte
+	Error     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SigningApprovalVote 审批人对签名请求的批准或拒绝（审批的审计记录）
+type SigningApprovalVote struct {
+	ApprovalID string
+	ApproverID string
+	Vote       string // approve 或 reject
+	Comment    string
+	CreatedAt  time.Time
+}
+
+// KeyApprovalQuorum 密钥的审批法定人数（M-of-N），策略规则未指定审批人时使用
+type KeyApprovalQuorum struct {
+	KeyID             string
+	Approvers         []string
+	RequiredApprovals int
+	UpdatedAt         time.Time
+}
+
+// SigningApprovalFilter 签名审批过滤条件
+type SigningApprovalFilter struct {
+	KeyID  string
+	Status string
+	Limit  int
+	Offset int
+}
+
+// WebhookEndpoint 用户注册的 webhook 接收地址，只接收 EventTypes 中的事件（KeyID 不为空时只接收该密钥的事件）
+type WebhookEndpoint struct {
+	WebhookID   string
+	OwnerID     string // 注册 webhook 的用户 ID
+	URL         string
+	Secret      string // HMAC-SHA256 签名密钥
+	EventTypes  []string
+	KeyID       string
+	Description string
+	Enabled     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// webhook 投递状态
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed" // 达到最大重试次数
+)
+
+// WebhookDelivery webhook 发件箱中的一次事件投递（Payload 为发送的 JSON 请求体）
+type WebhookDelivery struct {
+	DeliveryID     string
+	WebhookID      string
+	EventID        string // 同一事件的重新投递使用相同的 EventID
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus int // 最近一次投递的 HTTP 状态码，请求失败时为 0
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookDeliveryFilter webhook 投递过滤条件
+type WebhookDeliveryFilter struct {
+	WebhookID string
+	Status    string
+	Limit     int
+	Offset    int
+}
+
+// AuditLog 审计日志记录：每条记录的 Hash 覆盖记录内容和上一条记录的 Hash（PrevHash），形成哈希链
+type AuditLog struct {
+	ID        int64
+	Timestamp time.Time
+	EventType string
+	UserID    string
+	KeyID     string
+	NodeID    string
+	SessionID string
+	Operation string
+	Result    string
+	Details   []byte
+	IPAddress string
+	PrevHash  string
+	Hash      string
+}
+
+// AuditLogFilter 审计日志过滤条件，按 ID 升序返回 AfterID 之后的记录，空字段不过滤
+type AuditLogFilter struct {
+	AfterID   int64
+	KeyID     string
+	NodeID    string
+	SessionID string
+	UserID    string
+	EventType string
+	Result    string
+	// From、To 时间范围 [From, To)，为零值时不限制
+	From  time.Time
+	To    time.Time
+	Limit int
 }
 
 // MetadataStore 密钥元数据存储接口
@@ -61,6 +338,13 @@ type MetadataStore interface {
 	UpdateKeyMetadata(ctx context.Context, key *KeyMetadata) error
 	DeleteKeyMetadata(ctx context.Context, keyID string) error
 	ListKeys(ctx context.Context, filter *KeyFilter) ([]*KeyMetadata, error)
+	// TransitionKeyStatus 仅当密钥仍处于 fromStatus 时更新状态和删除日期，返回是否更新成功
+	TransitionKeyStatus(ctx context.Context, keyID string, fromStatus string, toStatus string, deletionDate *time.Time, updatedAt time.Time) (bool, error)
+	// ListKeysDueForDeletion 列出删除日期不晚于 before 的 PendingDeletion 密钥和销毁未完成的 Destroying 密钥（按删除日期升序），
+	// 跳过下一次重试时间晚于 before 的密钥
+	ListKeysDueForDeletion(ctx context.Context, before time.Time, limit int) ([]*KeyMetadata, error)
+	// RecordKeyDeletionFailure 记录一次分片销毁失败：失败次数加一，nextAttemptAt 之前不再列为到期
+	RecordKeyDeletionFailure(ctx context.Context, keyID string, nextAttemptAt time.Time) error
 
 	// 节点操作
 	SaveNode(ctx context.Context, node *NodeInfo) error
@@ -68,11 +352,104 @@ type MetadataStore interface {
 	UpdateNode(ctx context.Context, node *NodeInfo) error
 	ListNodes(ctx context.Context, filter *NodeFilter) ([]*NodeInfo, error)
 	UpdateNodeHeartbeat(ctx context.Context, nodeID string) error
+	// RecordNodeFault 记录节点在某个会话中的故障并返回最新故障评分，同一会话重复上报只计一次
+	RecordNodeFault(ctx context.Context, nodeID string, sessionID string, reason string) (int, error)
+	ResetNodeFaultScore(ctx context.Context, nodeID string) error
 
 	// 会话操作
 	SaveSigningSession(ctx context.Context, session *SigningSession) error
 	GetSigningSession(ctx context.Context, sessionID string) (*SigningSession, error)
 	UpdateSigningSession(ctx context.Context, session *SigningSession) error
+	// FailSigningSession 原子地把会话标记为失败：责任节点与已有记录取并集，失败原因和完成时间只在首次失败时写入
+	// 返回更新前的状态和更新后的会话
+	FailSigningSession(ctx context.Context, sessionID string, reason string, culprits []string, failedAt time.Time) (string, *SigningSession, error)
+
+	// 预签名操作
+	SavePresignature(ctx context.Context, presig *Presignature) error
+	// ClaimPresignature 原子地取出一个可用预签名并标记为已使用，没有可用预签名时返回 nil
+	ClaimPresignature(ctx context.Context, keyID string, shareEpoch int) (*Presignature, error)
+	CountAvailablePresignatures(ctx context.Context, keyID string, shareEpoch int) (int, error)
+	// InvalidatePresignatures 作废密钥的所有可用预签名，返回作废数量
+	InvalidatePresignatures(ctx context.Context, keyID string) (int, error)
+
+	// 分片备份操作
+	// SaveKeyShareBackup 保存节点的分片备份，同一节点同一轮次的备份会被覆盖
+	SaveKeyShareBackup(ctx context.Context, backup *KeyShareBackup) error
+	ListKeyShareBackups(ctx context.Context, keyID string, shareEpoch int) ([]*KeyShareBackup, error)
+
+	// 分片刷新操作
+	SaveKeyRefresh(ctx context.Context, refresh *KeyRefresh) error
+	// ListKeyRefreshes 列出密钥最近的分片刷新记录（按开始时间降序）
+	ListKeyRefreshes(ctx context.Context, keyID string, limit int) ([]*KeyRefresh, error)
+	// ListKeysDueForRefresh 列出到期刷新且不在失败退避中的 Enabled 密钥（最久未刷新的在前），
+	// 退避在查询中过滤，持续失败的密钥不会占满每批的处理数量
+	ListKeysDueForRefresh(ctx context.Context, filter *KeyRefreshDueFilter) ([]*KeyMetadata, error)
+
+	// 分片一致性校验操作
+	// SaveKeyShareValidation 记录密钥最近一次分片一致性校验结果（覆盖旧结果）
+	SaveKeyShareValidation(ctx context.Context, validation *KeyShareValidation) error
+	// GetKeyShareValidation 获取密钥最近一次分片一致性校验结果，从未校验过时返回 nil
+	GetKeyShareValidation(ctx context.Context, keyID string) (*KeyShareValidation, error)
+
+	// 交易策略操作
+	SavePolicyRule(ctx context.Context, rule *PolicyRule) error
+	// GetPolicyRule 获取策略规则，不存在时返回 nil
+	GetPolicyRule(ctx context.Context, ruleID string) (*PolicyRule, error)
+	// ListPolicyRules 列出对密钥生效的规则（包括全局规则），keyID 为空时列出所有规则
+	ListPolicyRules(ctx context.Context, keyID string) ([]*PolicyRule, error)
+	DeletePolicyRule(ctx context.Context, ruleID string) error
+	SavePolicyDecision(ctx context.Context, decision *PolicyDecision) error
+	// SumAllowedTransfers 统计密钥自 since 以来被允许的某资产转移总量（最小单位的十进制字符串），asset 为空表示原生资产，
+	// 同一交易只计一次，excludeTxHash 对应的交易不计入
+	SumAllowedTransfers(ctx context.Context, keyID string, chainType string, asset string, since time.Time, excludeTxHash string) (string, error)
+
+	// 异步签名操作
+	SaveSigningRequest(ctx context.Context, request *SigningRequest) error
+	// GetSigningRequest 获取异步签名请求，不存在时返回 nil
+	GetSigningRequest(ctx context.Context, sessionID string) (*SigningRequest, error)
+	// TransitionSigningRequest 仅当请求处于 fromStatus 时更新状态、结果和错误，返回是否更新
+	TransitionSigningRequest(ctx context.Context, request *SigningRequest, fromStatus string) (bool, error)
+
+	// 签名审批操作
+	SaveSigningApproval(ctx context.Context, approval *SigningApproval) error
+	// GetSigningApproval 获取签名审批，不存在时返回 nil
+	GetSigningApproval(ctx context.Context, approvalID string) (*SigningApproval, error)
+	ListSigningApprovals(ctx context.Context, filter *SigningApprovalFilter) ([]*SigningApproval, error)
+	// TransitionSigningApproval 仅当审批处于 fromStatus 时更新状态和结果，返回是否更新（并发审批只有一个能触发执行）
+	TransitionSigningApproval(ctx context.Context, approval *SigningApproval, fromStatus string) (bool, error)
+	// SaveSigningApprovalVote 记录审批人的投票，同一审批人重复投票时返回 ErrDuplicateVote
+	SaveSigningApprovalVote(ctx context.Context, vote *SigningApprovalVote) error
+	ListSigningApprovalVotes(ctx context.Context, approvalID string) ([]*SigningApprovalVote, error)
+	// SaveKeyApprovalQuorum 设置密钥的审批法定人数（覆盖旧设置）
+	SaveKeyApprovalQuorum(ctx context.Context, quorum *KeyApprovalQuorum) error
+	// GetKeyApprovalQuorum 获取密钥的审批法定人数，未设置时返回 nil
+	GetKeyApprovalQuorum(ctx context.Context, keyID string) (*KeyApprovalQuorum, error)
+
+	// webhook 操作
+	SaveWebhookEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	// GetWebhookEndpoint 获取 webhook，不存在时返回 nil
+	GetWebhookEndpoint(ctx context.Context, webhookID string) (*WebhookEndpoint, error)
+	// ListWebhookEndpoints 按创建时间倒序列出用户注册的 webhook
+	ListWebhookEndpoints(ctx context.Context, ownerID string) ([]*WebhookEndpoint, error)
+	// ListWebhookEndpointsForEvent 列出订阅了该事件的已启用 webhook（未限定密钥或限定为 keyID）
+	ListWebhookEndpointsForEvent(ctx context.Context, eventType string, keyID string) ([]*WebhookEndpoint, error)
+	// DeleteWebhookEndpoint 删除 webhook 及其投递记录
+	DeleteWebhookEndpoint(ctx context.Context, webhookID string) error
+	SaveWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	// GetWebhookDelivery 获取 webhook 投递，不存在时返回 nil
+	GetWebhookDelivery(ctx context.Context, deliveryID string) (*WebhookDelivery, error)
+	// ListWebhookDeliveries 按创建时间倒序列出 webhook 的投递记录
+	ListWebhookDeliveries(ctx context.Context, filter *WebhookDeliveryFilter) ([]*WebhookDelivery, error)
+	// ClaimWebhookDeliveries 原子地取出最多 limit 个到期（NextAttemptAt 不晚于 now）的待投递记录，
+	// 并把它们的 NextAttemptAt 推迟到 leaseUntil，投递期间其他协调者不会重复取出
+	ClaimWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*WebhookDelivery, error)
+	// UpdateWebhookDelivery 更新投递状态、尝试次数和最近一次投递结果
+	UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error
+
+	// 审计日志操作
+	// AppendAuditLog 串行追加审计日志：读取最后一条记录的 Hash 作为 PrevHash，由 seal 计算本条记录的 Hash
+	AppendAuditLog(ctx context.Context, entry *AuditLog, seal func(entry *AuditLog) (string, error)) error
+	ListAuditLogs(ctx context.Context, filter *AuditLogFilter) ([]*AuditLog, error)
 }
 
 // KeyFilter 密钥过滤条件
@@ -113,6 +490,12 @@ type KeyShareStorage interface {
 
 	// 获取密钥数据（解密并返回序列化的 LocalPartySaveData）
 	GetKeyData(ctx context.Context, keyID string, nodeID string) ([]byte, error)
+
+	// 删除密钥数据（resharing 后不在新委员会的节点作废旧分片）
+	DeleteKeyData(ctx context.Context, keyID string, nodeID string) error
+
+	// 销毁节点在密钥下保存的所有数据（分片、密钥数据和预签名），密钥删除时调用
+	DestroyKeyMaterial(ctx context.Context, keyID string, nodeID string) error
 }
 
 // SessionStore 签名会话存储接口（Redis）
@@ -129,11 +512,14 @@ type SessionStore interface {
 	// 删除会话
 	DeleteSession(ctx context.Context, sessionID string) error
 
-	// 获取分布式锁
-	AcquireLock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// 获取分布式锁，token 标识持有者，锁在 ttl 后自动过期
+	AcquireLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
+
+	// 延长分布式锁的有效期，锁已过期或由其他持有者获取时返回 false
+	RenewLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
 
-	// 释放分布式锁
-	ReleaseLock(ctx context.Context, key string) error
+	// 释放分布式锁，只删除 token 对应持有者的锁
+	ReleaseLock(ctx context.Context, key string, token string) error
 
 	// 发布消息（用于节点间通信）
 	PublishMessage(ctx context.Context, channel string, message interface{}) error
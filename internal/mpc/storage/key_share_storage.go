@@ -257,6 +257,55 @@ func (s *FileSystemKeyShareStorage) GetKeyData(ctx context.Context, keyID string
 	return keyData, nil
 }
 
+// DeleteKeyData 删除密钥数据
+func (s *FileSystemKeyShareStorage) DeleteKeyData(ctx context.Context, keyID string, nodeID string) error {
+	filePath := filepath.Join(s.basePath, keyID, nodeID+".keydata.enc")
+
+	if err := os.Remove(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return nil // 文件不存在，认为已删除
+		}
+		return errors.Wrap(err, "failed to delete key data")
+	}
+
+	return nil
+}
+
+// DestroyKeyMaterial 删除密钥目录下属于该节点的所有文件（分片、密钥数据和预签名），并清理空目录
+func (s *FileSystemKeyShareStorage) DestroyKeyMaterial(ctx context.Context, keyID string, nodeID string) error {
+	keyDir := filepath.Join(s.basePath, keyID)
+	var dirs []string
+	err := filepath.Walk(keyDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			dirs = append(dirs, path)
+			return nil
+		}
+		name := filepath.Base(path)
+		if name == nodeID+".enc" || name == nodeID+".keydata.enc" {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to destroy key material")
+	}
+
+	// 由深到浅删除空目录（其他节点的数据仍在时目录保留）
+	for i := len(dirs) - 1; i >= 0; i-- {
+		_ = os.Remove(dirs[i])
+	}
+
+	return nil
+}
+
 // ValidateKeyShare 验证密钥分片格式（辅助函数）
 func ValidateKeyShare(share []byte) error {
 	// 基本验证：检查长度和格式
@@ -5,10 +5,15 @@ import (
 	"database/sql"
 	"encoding/json"
 	"strings"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
+// pgUniqueViolation PostgreSQL 唯一约束冲突的错误码
+const pgUniqueViolation = "23505"
+
 // PostgreSQLStore PostgreSQL存储实现
 type PostgreSQLStore struct {
 	db *sql.DB
@@ -26,11 +31,16 @@ func (s *PostgreSQLStore) SaveKeyMetadata(ctx context.Context, key *KeyMetadata)
 		return errors.Wrap(err, "failed to marshal tags")
 	}
 
+	nodeIDsJSON, err := json.Marshal(key.NodeIDs)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal node ids")
+	}
+
 	query := `
 		INSERT INTO keys (
 			key_id, public_key, algorithm, curve, threshold, total_nodes,
-			chain_type, address, status, description, tags, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			chain_type, address, status, description, tags, share_epoch, node_ids, chain_code, protocol, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		ON CONFLICT (key_id) DO UPDATE SET
 			public_key = EXCLUDED.public_key,
 			algorithm = EXCLUDED.algorithm,
@@ -42,13 +52,17 @@ func (s *PostgreSQLStore) SaveKeyMetadata(ctx context.Context, key *KeyMetadata)
 			status = EXCLUDED.status,
 			description = EXCLUDED.description,
 			tags = EXCLUDED.tags,
+			share_epoch = EXCLUDED.share_epoch,
+			node_ids = EXCLUDED.node_ids,
+			chain_code = EXCLUDED.chain_code,
+			protocol = EXCLUDED.protocol,
 			updated_at = EXCLUDED.updated_at
 	`
 
 	result, err := s.db.ExecContext(ctx, query,
 		key.KeyID, key.PublicKey, key.Algorithm, key.Curve, key.Threshold, key.TotalNodes,
-		key.ChainType, key.Address, key.Status, key.Description, tagsJSON,
-		key.CreatedAt, key.UpdatedAt,
+		key.ChainType, key.Address, key.Status, key.Description, tagsJSON, key.ShareEpoch, nodeIDsJSON,
+		key.ChainCode, key.Protocol, key.CreatedAt, key.UpdatedAt,
 	)
 	if err != nil {
 		return errors.Wrapf(err, "failed to save key metadata for key_id: %s", key.KeyID)
@@ -70,23 +84,24 @@ func (s *PostgreSQLStore) SaveKeyMetadata(ctx context.Context, key *KeyMetadata)
 func (s *PostgreSQLStore) GetKeyMetadata(ctx context.Context, keyID string) (*KeyMetadata, error) {
 	query := `
 		SELECT key_id, public_key, algorithm, curve, threshold, total_nodes,
-			chain_type, address, status, description, tags, created_at, updated_at, deletion_date
+			chain_type, address, status, description, tags, share_epoch, node_ids, chain_code, protocol, created_at, updated_at, deletion_date, deletion_attempts
 		FROM keys
 		WHERE key_id = $1
 	`
 
 	var key KeyMetadata
 	var tagsJSON []byte
+	var nodeIDsJSON []byte
 	var deletionDate sql.NullTime
 
 	err := s.db.QueryRowContext(ctx, query, keyID).Scan(
 		&key.KeyID, &key.PublicKey, &key.Algorithm, &key.Curve, &key.Threshold, &key.TotalNodes,
-		&key.ChainType, &key.Address, &key.Status, &key.Description, &tagsJSON,
-		&key.CreatedAt, &key.UpdatedAt, &deletionDate,
+		&key.ChainType, &key.Address, &key.Status, &key.Description, &tagsJSON, &key.ShareEpoch, &nodeIDsJSON,
+		&key.ChainCode, &key.Protocol, &key.CreatedAt, &key.UpdatedAt, &deletionDate, &key.DeletionAttempts,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("key not found")
+			return nil, ErrKeyNotFound
 		}
 		return nil, errors.Wrap(err, "failed to get key metadata")
 	}
@@ -100,6 +115,12 @@ func (s *PostgreSQLStore) GetKeyMetadata(ctx context.Context, keyID string) (*Ke
 		key.Tags = make(map[string]string)
 	}
 
+	if len(nodeIDsJSON) > 0 {
+		if err := json.Unmarshal(nodeIDsJSON, &key.NodeIDs); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal node ids")
+		}
+	}
+
 	if deletionDate.Valid {
 		key.DeletionDate = &deletionDate.Time
 	}
@@ -114,6 +135,11 @@ func (s *PostgreSQLStore) UpdateKeyMetadata(ctx context.Context, key *KeyMetadat
 		return errors.Wrap(err, "failed to marshal tags")
 	}
 
+	nodeIDsJSON, err := json.Marshal(key.NodeIDs)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal node ids")
+	}
+
 	query := `
 		UPDATE keys SET
 			public_key = $2,
@@ -127,7 +153,11 @@ func (s *PostgreSQLStore) UpdateKeyMetadata(ctx context.Context, key *KeyMetadat
 			description = $10,
 			tags = $11,
 			updated_at = $12,
-			deletion_date = $13
+			deletion_date = $13,
+			share_epoch = $14,
+			node_ids = $15,
+			chain_code = $16,
+			protocol = $17
 		WHERE key_id = $1
 	`
 
@@ -139,7 +169,7 @@ func (s *PostgreSQLStore) UpdateKeyMetadata(ctx context.Context, key *KeyMetadat
 	_, err = s.db.ExecContext(ctx, query,
 		key.KeyID, key.PublicKey, key.Algorithm, key.Curve, key.Threshold, key.TotalNodes,
 		key.ChainType, key.Address, key.Status, key.Description, tagsJSON,
-		key.UpdatedAt, deletionDate,
+		key.UpdatedAt, deletionDate, key.ShareEpoch, nodeIDsJSON, key.ChainCode, key.Protocol,
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to update key metadata")
@@ -168,7 +198,7 @@ func (s *PostgreSQLStore) ListKeys(ctx context.Context, filter *KeyFilter) ([]*K
 	}
 
 	query := `SELECT key_id, public_key, algorithm, curve, threshold, total_nodes,
-		chain_type, address, status, description, tags, created_at, updated_at, deletion_date
+		chain_type, address, status, description, tags, share_epoch, node_ids, chain_code, protocol, created_at, updated_at, deletion_date, deletion_attempts
 		FROM keys WHERE 1=1`
 	args := []interface{}{}
 	argIndex := 1
@@ -194,16 +224,79 @@ func (s *PostgreSQLStore) ListKeys(ctx context.Context, filter *KeyFilter) ([]*K
 	}
 	defer rows.Close()
 
+	return scanKeys(rows)
+}
+
+// TransitionKeyStatus 仅当密钥仍处于 fromStatus 时更新状态和删除日期（并发的状态变更只有一个成功）
+func (s *PostgreSQLStore) TransitionKeyStatus(ctx context.Context, keyID string, fromStatus string, toStatus string, deletionDate *time.Time, updatedAt time.Time) (bool, error) {
+	query := `
+		UPDATE keys
+		SET status = $3, deletion_date = $4, updated_at = $5
+		WHERE key_id = $1 AND status = $2
+	`
+
+	res, err := s.db.ExecContext(ctx, query, keyID, fromStatus, toStatus, nullableTime(deletionDate), updatedAt)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to update key status")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get affected rows")
+	}
+
+	return affected > 0, nil
+}
+
+// ListKeysDueForDeletion 列出删除日期已到且不在退避中的 PendingDeletion 和 Destroying 密钥
+func (s *PostgreSQLStore) ListKeysDueForDeletion(ctx context.Context, before time.Time, limit int) ([]*KeyMetadata, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `SELECT key_id, public_key, algorithm, curve, threshold, total_nodes,
+		chain_type, address, status, description, tags, share_epoch, node_ids, chain_code, protocol, created_at, updated_at, deletion_date, deletion_attempts
+		FROM keys
+		WHERE status IN ($1, $2) AND deletion_date <= $3
+			AND (next_deletion_attempt_at IS NULL OR next_deletion_attempt_at <= $3)
+		ORDER BY deletion_date ASC LIMIT $4`
+
+	rows, err := s.db.QueryContext(ctx, query, KeyStatusPendingDeletion, KeyStatusDestroying, before, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list keys due for deletion")
+	}
+	defer rows.Close()
+
+	return scanKeys(rows)
+}
+
+// RecordKeyDeletionFailure 增加分片销毁失败次数并设置下一次重试时间
+func (s *PostgreSQLStore) RecordKeyDeletionFailure(ctx context.Context, keyID string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE keys
+		SET deletion_attempts = deletion_attempts + 1, next_deletion_attempt_at = $2
+		WHERE key_id = $1
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, keyID, nextAttemptAt); err != nil {
+		return errors.Wrap(err, "failed to record key deletion failure")
+	}
+
+	return nil
+}
+
+// scanKeys 扫描 keys 表的查询结果（列顺序与 GetKeyMetadata 相同）
+func scanKeys(rows *sql.Rows) ([]*KeyMetadata, error) {
 	var keys []*KeyMetadata
 	for rows.Next() {
 		var key KeyMetadata
 		var tagsJSON []byte
+		var nodeIDsJSON []byte
 		var deletionDate sql.NullTime
 
 		err := rows.Scan(
 			&key.KeyID, &key.PublicKey, &key.Algorithm, &key.Curve, &key.Threshold, &key.TotalNodes,
-			&key.ChainType, &key.Address, &key.Status, &key.Description, &tagsJSON,
-			&key.CreatedAt, &key.UpdatedAt, &deletionDate,
+			&key.ChainType, &key.Address, &key.Status, &key.Description, &tagsJSON, &key.ShareEpoch, &nodeIDsJSON,
+			&key.ChainCode, &key.Protocol, &key.CreatedAt, &key.UpdatedAt, &deletionDate, &key.DeletionAttempts,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to scan key")
@@ -218,6 +311,12 @@ func (s *PostgreSQLStore) ListKeys(ctx context.Context, filter *KeyFilter) ([]*K
 			key.Tags = make(map[string]string)
 		}
 
+		if len(nodeIDsJSON) > 0 {
+			if err := json.Unmarshal(nodeIDsJSON, &key.NodeIDs); err != nil {
+				return nil, errors.Wrap(err, "failed to unmarshal node ids")
+			}
+		}
+
 		if deletionDate.Valid {
 			key.DeletionDate = &deletionDate.Time
 		}
@@ -249,7 +348,8 @@ func (s *PostgreSQLStore) SaveNode(ctx context.Context, node *NodeInfo) error {
 			node_type = EXCLUDED.node_type,
 			endpoint = EXCLUDED.endpoint,
 			public_key = EXCLUDED.public_key,
-			status = EXCLUDED.status,
+			-- faulty 节点重新注册时保持 faulty，只能通过显式更新状态恢复
+			status = CASE WHEN nodes.status = 'faulty' THEN nodes.status ELSE EXCLUDED.status END,
 			capabilities = EXCLUDED.capabilities,
 			metadata = EXCLUDED.metadata,
 			last_heartbeat = EXCLUDED.last_heartbeat
@@ -270,7 +370,7 @@ func (s *PostgreSQLStore) SaveNode(ctx context.Context, node *NodeInfo) error {
 func (s *PostgreSQLStore) GetNode(ctx context.Context, nodeID string) (*NodeInfo, error) {
 	query := `
 		SELECT node_id, node_type, endpoint, public_key, status, capabilities, metadata,
-			registered_at, last_heartbeat
+			registered_at, last_heartbeat, fault_score
 		FROM nodes
 		WHERE node_id = $1
 	`
@@ -282,7 +382,7 @@ func (s *PostgreSQLStore) GetNode(ctx context.Context, nodeID string) (*NodeInfo
 
 	err := s.db.QueryRowContext(ctx, query, nodeID).Scan(
 		&node.NodeID, &node.NodeType, &node.Endpoint, &node.PublicKey, &node.Status,
-		&capabilitiesJSON, &metadataJSON, &node.RegisteredAt, &lastHeartbeat,
+		&capabilitiesJSON, &metadataJSON, &node.RegisteredAt, &lastHeartbeat, &node.FaultScore,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -361,7 +461,7 @@ func (s *PostgreSQLStore) ListNodes(ctx context.Context, filter *NodeFilter) ([]
 	}
 
 	query := `SELECT node_id, node_type, endpoint, public_key, status, capabilities, metadata,
-		registered_at, last_heartbeat
+		registered_at, last_heartbeat, fault_score
 		FROM nodes WHERE 1=1`
 	args := []interface{}{}
 	argIndex := 1
@@ -396,7 +496,7 @@ func (s *PostgreSQLStore) ListNodes(ctx context.Context, filter *NodeFilter) ([]
 
 		err := rows.Scan(
 			&node.NodeID, &node.NodeType, &node.Endpoint, &node.PublicKey, &node.Status,
-			&capabilitiesJSON, &metadataJSON, &node.RegisteredAt, &lastHeartbeat,
+			&capabilitiesJSON, &metadataJSON, &node.RegisteredAt, &lastHeartbeat, &node.FaultScore,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to scan node")
@@ -440,6 +540,43 @@ func (s *PostgreSQLStore) UpdateNodeHeartbeat(ctx context.Context, nodeID string
 	return nil
 }
 
+// RecordNodeFault 记录节点故障并返回最新故障评分
+// node_faults 以 (node_id, session_id) 为主键，同一会话的重复上报不会重复计分
+func (s *PostgreSQLStore) RecordNodeFault(ctx context.Context, nodeID string, sessionID string, reason string) (int, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO node_faults (node_id, session_id, reason)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (node_id, session_id) DO NOTHING
+			RETURNING node_id
+		)
+		UPDATE nodes SET fault_score = fault_score + (SELECT COUNT(*) FROM inserted)
+		WHERE node_id = $1
+		RETURNING fault_score
+	`
+
+	var score int
+	err := s.db.QueryRowContext(ctx, query, nodeID, sessionID, reason).Scan(&score)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("node not found")
+		}
+		return 0, errors.Wrap(err, "failed to record node fault")
+	}
+
+	return score, nil
+}
+
+// ResetNodeFaultScore 清零节点故障评分（节点修复后重新启用）
+func (s *PostgreSQLStore) ResetNodeFaultScore(ctx context.Context, nodeID string) error {
+	query := `UPDATE nodes SET fault_score = 0 WHERE node_id = $1`
+	_, err := s.db.ExecContext(ctx, query, nodeID)
+	if err != nil {
+		return errors.Wrap(err, "failed to reset node fault score")
+	}
+	return nil
+}
+
 // SaveSigningSession 保存签名会话
 func (s *PostgreSQLStore) SaveSigningSession(ctx context.Context, session *SigningSession) error {
 	// 记录保存的节点列表（用于调试）
@@ -451,12 +588,17 @@ func (s *PostgreSQLStore) SaveSigningSession(ctx context.Context, session *Signi
 		return errors.Wrap(err, "failed to marshal participating nodes")
 	}
 
+	culpritsJSON, err := json.Marshal(session.Culprits)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal culprits")
+	}
+
 	query := `
 		INSERT INTO signing_sessions (
 			session_id, key_id, protocol, status, threshold, total_nodes,
 			participating_nodes, current_round, total_rounds, signature,
-			created_at, completed_at, duration_ms
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			created_at, completed_at, duration_ms, failure_reason, culprits
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		ON CONFLICT (session_id) DO UPDATE SET
 			key_id = EXCLUDED.key_id,
 			protocol = EXCLUDED.protocol,
@@ -468,7 +610,9 @@ func (s *PostgreSQLStore) SaveSigningSession(ctx context.Context, session *Signi
 			total_rounds = EXCLUDED.total_rounds,
 			signature = EXCLUDED.signature,
 			completed_at = EXCLUDED.completed_at,
-			duration_ms = EXCLUDED.duration_ms
+			duration_ms = EXCLUDED.duration_ms,
+			failure_reason = EXCLUDED.failure_reason,
+			culprits = EXCLUDED.culprits
 	`
 
 	var completedAt interface{}
@@ -493,6 +637,7 @@ func (s *PostgreSQLStore) SaveSigningSession(ctx context.Context, session *Signi
 		session.Threshold, session.TotalNodes, participatingNodesJSON,
 		session.CurrentRound, session.TotalRounds, session.Signature,
 		session.CreatedAt, completedAt, session.DurationMs,
+		session.FailureReason, culpritsJSON,
 	)
 	if err != nil {
 		// 检查是否是外键约束错误
@@ -516,13 +661,14 @@ func (s *PostgreSQLStore) GetSigningSession(ctx context.Context, sessionID strin
 	query := `
 		SELECT session_id, key_id, protocol, status, threshold, total_nodes,
 			participating_nodes, current_round, total_rounds, signature,
-			created_at, completed_at, duration_ms
+			created_at, completed_at, duration_ms, failure_reason, culprits
 		FROM signing_sessions
 		WHERE session_id = $1
 	`
 
 	var session SigningSession
 	var participatingNodesJSON []byte
+	var culpritsJSON []byte
 	var completedAt sql.NullTime
 
 	err := s.db.QueryRowContext(ctx, query, sessionID).Scan(
@@ -530,6 +676,7 @@ func (s *PostgreSQLStore) GetSigningSession(ctx context.Context, sessionID strin
 		&session.Threshold, &session.TotalNodes, &participatingNodesJSON,
 		&session.CurrentRound, &session.TotalRounds, &session.Signature,
 		&session.CreatedAt, &completedAt, &session.DurationMs,
+		&session.FailureReason, &culpritsJSON,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -547,6 +694,12 @@ func (s *PostgreSQLStore) GetSigningSession(ctx context.Context, sessionID strin
 		session.ParticipatingNodes = []string{}
 	}
 
+	if len(culpritsJSON) > 0 {
+		if err := json.Unmarshal(culpritsJSON, &session.Culprits); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal culprits")
+		}
+	}
+
 	if completedAt.Valid {
 		session.CompletedAt = &completedAt.Time
 	}
@@ -561,6 +714,11 @@ func (s *PostgreSQLStore) UpdateSigningSession(ctx context.Context, session *Sig
 		return errors.Wrap(err, "failed to marshal participating nodes")
 	}
 
+	culpritsJSON, err := json.Marshal(session.Culprits)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal culprits")
+	}
+
 	query := `
 		UPDATE signing_sessions SET
 			key_id = $2,
@@ -573,7 +731,9 @@ func (s *PostgreSQLStore) UpdateSigningSession(ctx context.Context, session *Sig
 			total_rounds = $9,
 			signature = $10,
 			completed_at = $11,
-			duration_ms = $12
+			duration_ms = $12,
+			failure_reason = $13,
+			culprits = $14
 		WHERE session_id = $1
 	`
 
@@ -586,7 +746,7 @@ func (s *PostgreSQLStore) UpdateSigningSession(ctx context.Context, session *Sig
 		session.SessionID, session.KeyID, session.Protocol, session.Status,
 		session.Threshold, session.TotalNodes, participatingNodesJSON,
 		session.CurrentRound, session.TotalRounds, session.Signature,
-		completedAt, session.DurationMs,
+		completedAt, session.DurationMs, session.FailureReason, culpritsJSON,
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to update signing session")
@@ -594,3 +754,1211 @@ func (s *PostgreSQLStore) UpdateSigningSession(ctx context.Context, session *Sig
 
 	return nil
 }
+
+// FailSigningSession 原子地把签名会话标记为失败
+// 多个参与节点可能同时上报失败，责任节点在同一条 UPDATE 中与已有记录合并（保持上报顺序），并发上报不会互相覆盖
+func (s *PostgreSQLStore) FailSigningSession(ctx context.Context, sessionID string, reason string, culprits []string, failedAt time.Time) (string, *SigningSession, error) {
+	seen := make(map[string]bool, len(culprits))
+	newCulprits := make([]string, 0, len(culprits))
+	for _, culprit := range culprits {
+		if !seen[culprit] {
+			seen[culprit] = true
+			newCulprits = append(newCulprits, culprit)
+		}
+	}
+	culpritsJSON, err := json.Marshal(newCulprits)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to marshal culprits")
+	}
+
+	query := `
+		WITH previous AS (
+			SELECT session_id, status FROM signing_sessions
+			WHERE session_id = $1
+			FOR UPDATE
+		)
+		UPDATE signing_sessions s SET
+			status = $2,
+			failure_reason = CASE WHEN s.failure_reason = '' THEN $3 ELSE s.failure_reason END,
+			culprits = COALESCE(s.culprits, '[]'::jsonb) || COALESCE((
+				SELECT jsonb_agg(c.value ORDER BY c.position)
+				FROM jsonb_array_elements_text($4::jsonb) WITH ORDINALITY AS c(value, position)
+				WHERE NOT COALESCE(s.culprits, '[]'::jsonb) ? c.value
+			), '[]'::jsonb),
+			completed_at = COALESCE(s.completed_at, $5::timestamptz),
+			duration_ms = CASE
+				WHEN s.completed_at IS NULL THEN (EXTRACT(EPOCH FROM ($5::timestamptz - s.created_at)) * 1000)::integer
+				ELSE s.duration_ms
+			END
+		FROM previous
+		WHERE s.session_id = previous.session_id
+		RETURNING previous.status, s.session_id, s.key_id, s.protocol, s.status, s.threshold, s.total_nodes,
+			s.participating_nodes, s.current_round, s.total_rounds, s.signature,
+			s.created_at, s.completed_at, s.duration_ms, s.failure_reason, s.culprits
+	`
+
+	var fromStatus string
+	var session SigningSession
+	var participatingNodesJSON []byte
+	var storedCulpritsJSON []byte
+	var completedAt sql.NullTime
+
+	err = s.db.QueryRowContext(ctx, query, sessionID, SigningSessionStatusFailed, reason, culpritsJSON, failedAt).Scan(
+		&fromStatus, &session.SessionID, &session.KeyID, &session.Protocol, &session.Status,
+		&session.Threshold, &session.TotalNodes, &participatingNodesJSON,
+		&session.CurrentRound, &session.TotalRounds, &session.Signature,
+		&session.CreatedAt, &completedAt, &session.DurationMs,
+		&session.FailureReason, &storedCulpritsJSON,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil, errors.New("session not found")
+		}
+		return "", nil, errors.Wrap(err, "failed to fail signing session")
+	}
+
+	if len(participatingNodesJSON) > 0 {
+		if err := json.Unmarshal(participatingNodesJSON, &session.ParticipatingNodes); err != nil {
+			return "", nil, errors.Wrap(err, "failed to unmarshal participating nodes")
+		}
+	}
+	if len(storedCulpritsJSON) > 0 {
+		if err := json.Unmarshal(storedCulpritsJSON, &session.Culprits); err != nil {
+			return "", nil, errors.Wrap(err, "failed to unmarshal culprits")
+		}
+	}
+	if completedAt.Valid {
+		session.CompletedAt = &completedAt.Time
+	}
+
+	return fromStatus, &session, nil
+}
+
+// SavePresignature 保存预签名记录
+func (s *PostgreSQLStore) SavePresignature(ctx context.Context, presig *Presignature) error {
+	nodeIDsJSON, err := json.Marshal(presig.NodeIDs)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal node ids")
+	}
+
+	status := presig.Status
+	if status == "" {
+		status = PresignatureStatusAvailable
+	}
+
+	query := `
+		INSERT INTO presignatures (
+			presignature_id, key_id, node_ids, share_epoch, status, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = s.db.ExecContext(ctx, query,
+		presig.PresignatureID, presig.KeyID, nodeIDsJSON, presig.ShareEpoch, status, presig.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save presignature")
+	}
+
+	return nil
+}
+
+// ClaimPresignature 原子地取出最早生成的可用预签名并标记为已使用
+// FOR UPDATE SKIP LOCKED 保证并发签名请求不会取到同一个预签名
+func (s *PostgreSQLStore) ClaimPresignature(ctx context.Context, keyID string, shareEpoch int) (*Presignature, error) {
+	query := `
+		UPDATE presignatures SET
+			status = $3,
+			used_at = NOW()
+		WHERE presignature_id = (
+			SELECT presignature_id FROM presignatures
+			WHERE key_id = $1 AND share_epoch = $2 AND status = $4
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		) AND status = $4
+		RETURNING presignature_id, key_id, node_ids, share_epoch, status, created_at, used_at
+	`
+
+	var presig Presignature
+	var nodeIDsJSON []byte
+	var usedAt sql.NullTime
+
+	err := s.db.QueryRowContext(ctx, query, keyID, shareEpoch, PresignatureStatusUsed, PresignatureStatusAvailable).Scan(
+		&presig.PresignatureID, &presig.KeyID, &nodeIDsJSON, &presig.ShareEpoch, &presig.Status, &presig.CreatedAt, &usedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to claim presignature")
+	}
+
+	if len(nodeIDsJSON) > 0 {
+		if err := json.Unmarshal(nodeIDsJSON, &presig.NodeIDs); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal node ids")
+		}
+	}
+	if usedAt.Valid {
+		presig.UsedAt = &usedAt.Time
+	}
+
+	return &presig, nil
+}
+
+// CountAvailablePresignatures 统计密钥在指定分片轮次下的可用预签名数量
+func (s *PostgreSQLStore) CountAvailablePresignatures(ctx context.Context, keyID string, shareEpoch int) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM presignatures
+		WHERE key_id = $1 AND share_epoch = $2 AND status = $3
+	`
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, query, keyID, shareEpoch, PresignatureStatusAvailable).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to count presignatures")
+	}
+
+	return count, nil
+}
+
+// InvalidatePresignatures 作废密钥的所有可用预签名
+func (s *PostgreSQLStore) InvalidatePresignatures(ctx context.Context, keyID string) (int, error) {
+	query := `
+		UPDATE presignatures SET status = $2
+		WHERE key_id = $1 AND status = $3
+	`
+
+	result, err := s.db.ExecContext(ctx, query, keyID, PresignatureStatusInvalidated, PresignatureStatusAvailable)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to invalidate presignatures")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get affected rows")
+	}
+
+	return int(affected), nil
+}
+
+// SaveKeyShareBackup 保存节点的分片备份（同一节点同一轮次覆盖旧备份）
+func (s *PostgreSQLStore) SaveKeyShareBackup(ctx context.Context, backup *KeyShareBackup) error {
+	query := `
+		INSERT INTO key_share_backups (
+			key_id, node_id, share_epoch, public_share, recovery_public_key, backup, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (key_id, node_id, share_epoch) DO UPDATE SET
+			public_share = EXCLUDED.public_share,
+			recovery_public_key = EXCLUDED.recovery_public_key,
+			backup = EXCLUDED.backup,
+			created_at = EXCLUDED.created_at
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		backup.KeyID, backup.NodeID, backup.ShareEpoch, backup.PublicShare, backup.RecoveryPublicKey, backup.Backup, backup.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save key share backup")
+	}
+
+	return nil
+}
+
+// ListKeyShareBackups 列出密钥在指定分片轮次下的所有节点备份
+func (s *PostgreSQLStore) ListKeyShareBackups(ctx context.Context, keyID string, shareEpoch int) ([]*KeyShareBackup, error) {
+	query := `
+		SELECT key_id, node_id, share_epoch, public_share, recovery_public_key, backup, created_at
+		FROM key_share_backups
+		WHERE key_id = $1 AND share_epoch = $2
+		ORDER BY node_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, keyID, shareEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list key share backups")
+	}
+	defer rows.Close()
+
+	var backups []*KeyShareBackup
+	for rows.Next() {
+		var backup KeyShareBackup
+		if err := rows.Scan(
+			&backup.KeyID, &backup.NodeID, &backup.ShareEpoch, &backup.PublicShare,
+			&backup.RecoveryPublicKey, &backup.Backup, &backup.CreatedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan key share backup")
+		}
+		backups = append(backups, &backup)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate key share backups")
+	}
+
+	return backups, nil
+}
+
+// SaveKeyRefresh 保存密钥分片刷新记录
+func (s *PostgreSQLStore) SaveKeyRefresh(ctx context.Context, refresh *KeyRefresh) error {
+	query := `
+		INSERT INTO key_refreshes (
+			refresh_id, key_id, status, old_epoch, new_epoch, error, started_at, completed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		refresh.RefreshID, refresh.KeyID, refresh.Status, refresh.OldEpoch, refresh.NewEpoch,
+		refresh.Error, refresh.StartedAt, refresh.CompletedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save key refresh")
+	}
+
+	return nil
+}
+
+// ListKeyRefreshes 列出密钥最近的分片刷新记录
+func (s *PostgreSQLStore) ListKeyRefreshes(ctx context.Context, keyID string, limit int) ([]*KeyRefresh, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `
+		SELECT refresh_id, key_id, status, old_epoch, new_epoch, error, started_at, completed_at
+		FROM key_refreshes
+		WHERE key_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, keyID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list key refreshes")
+	}
+	defer rows.Close()
+
+	var refreshes []*KeyRefresh
+	for rows.Next() {
+		var refresh KeyRefresh
+		if err := rows.Scan(
+			&refresh.RefreshID, &refresh.KeyID, &refresh.Status, &refresh.OldEpoch, &refresh.NewEpoch,
+			&refresh.Error, &refresh.StartedAt, &refresh.CompletedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan key refresh")
+		}
+		refreshes = append(refreshes, &refresh)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate key refreshes")
+	}
+
+	return refreshes, nil
+}
+
+// ListKeysDueForRefresh 列出需要刷新分片且不在失败退避中的 Enabled 密钥
+// 连续失败为最近一次成功刷新之后开始的失败记录，退避从最后一次失败的结束时间起算
+func (s *PostgreSQLStore) ListKeysDueForRefresh(ctx context.Context, filter *KeyRefreshDueFilter) ([]*KeyMetadata, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `SELECT key_id, public_key, algorithm, curve, threshold, total_nodes,
+		chain_type, address, status, description, tags, share_epoch, node_ids, chain_code, protocol, created_at, updated_at, deletion_date, deletion_attempts
+		FROM (
+			SELECT k.*, COALESCE(c.last_completed_at, k.created_at) AS last_refreshed_at, f.failures, f.last_failed_at
+			FROM keys k
+			LEFT JOIN LATERAL (
+				SELECT MAX(r.completed_at) AS last_completed_at, MAX(r.started_at) AS last_started_at
+				FROM key_refreshes r WHERE r.key_id = k.key_id AND r.status = $2
+			) c ON true
+			LEFT JOIN LATERAL (
+				SELECT COUNT(*) AS failures, MAX(r.completed_at) AS last_failed_at
+				FROM key_refreshes r
+				WHERE r.key_id = k.key_id AND r.status = $3
+					AND (c.last_started_at IS NULL OR r.started_at > c.last_started_at)
+			) f ON true
+			WHERE k.status = $1
+		) due
+		WHERE last_refreshed_at <= $4
+			AND (failures = 0 OR last_failed_at + LEAST(
+				make_interval(secs => $6::double precision * power(2, LEAST(failures - 1, 30))),
+				make_interval(secs => $7::double precision)
+			) <= $5)
+		ORDER BY last_refreshed_at ASC LIMIT $8`
+
+	rows, err := s.db.QueryContext(ctx, query, KeyStatusEnabled, KeyRefreshStatusCompleted, KeyRefreshStatusFailed,
+		filter.RefreshedBefore, filter.Now, filter.RetryBackoff.Seconds(), filter.MaxRetryBackoff.Seconds(), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list keys due for refresh")
+	}
+	defer rows.Close()
+
+	return scanKeys(rows)
+}
+
+// SaveKeyShareValidation 记录密钥最近一次分片一致性校验结果
+func (s *PostgreSQLStore) SaveKeyShareValidation(ctx context.Context, validation *KeyShareValidation) error {
+	query := `
+		INSERT INTO key_share_validations (
+			key_id, share_epoch, status, nodes, validated_at
+		) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key_id) DO UPDATE SET
+			share_epoch = EXCLUDED.share_epoch,
+			status = EXCLUDED.status,
+			nodes = EXCLUDED.nodes,
+			validated_at = EXCLUDED.validated_at
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		validation.KeyID, validation.ShareEpoch, validation.Status, validation.Nodes, validation.ValidatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save key share validation")
+	}
+
+	return nil
+}
+
+// GetKeyShareValidation 获取密钥最近一次分片一致性校验结果，从未校验过时返回 nil
+func (s *PostgreSQLStore) GetKeyShareValidation(ctx context.Context, keyID string) (*KeyShareValidation, error) {
+	query := `
+		SELECT key_id, share_epoch, status, nodes, validated_at
+		FROM key_share_validations
+		WHERE key_id = $1
+	`
+
+	var validation KeyShareValidation
+	err := s.db.QueryRowContext(ctx, query, keyID).Scan(
+		&validation.KeyID, &validation.ShareEpoch, &validation.Status, &validation.Nodes, &validation.ValidatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get key share validation")
+	}
+
+	return &validation, nil
+}
+
+// SavePolicyRule 保存策略规则（同一规则 ID 覆盖旧规则）
+func (s *PostgreSQLStore) SavePolicyRule(ctx context.Context, rule *PolicyRule) error {
+	query := `
+		INSERT INTO policy_rules (
+			rule_id, key_id, chain_type, rule_type, action, params, enabled, description, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (rule_id) DO UPDATE SET
+			key_id = EXCLUDED.key_id,
+			chain_type = EXCLUDED.chain_type,
+			rule_type = EXCLUDED.rule_type,
+			action = EXCLUDED.action,
+			params = EXCLUDED.params,
+			enabled = EXCLUDED.enabled,
+			description = EXCLUDED.description,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		rule.RuleID, sql.NullString{String: rule.KeyID, Valid: rule.KeyID != ""}, rule.ChainType, rule.RuleType,
+		rule.Action, rule.Params, rule.Enabled, rule.Description, rule.CreatedAt, rule.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save policy rule")
+	}
+
+	return nil
+}
+
+// GetPolicyRule 获取策略规则，不存在时返回 nil
+func (s *PostgreSQLStore) GetPolicyRule(ctx context.Context, ruleID string) (*PolicyRule, error) {
+	query := `
+		SELECT rule_id, key_id, chain_type, rule_type, action, params, enabled, description, created_at, updated_at
+		FROM policy_rules
+		WHERE rule_id = $1
+	`
+
+	rule, err := scanPolicyRule(s.db.QueryRowContext(ctx, query, ruleID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get policy rule")
+	}
+
+	return rule, nil
+}
+
+// ListPolicyRules 列出对密钥生效的规则（密钥规则和全局规则），keyID 为空时列出所有规则
+func (s *PostgreSQLStore) ListPolicyRules(ctx context.Context, keyID string) ([]*PolicyRule, error) {
+	query := `
+		SELECT rule_id, key_id, chain_type, rule_type, action, params, enabled, description, created_at, updated_at
+		FROM policy_rules
+		WHERE $1 = '' OR key_id IS NULL OR key_id = $1
+		ORDER BY created_at, rule_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list policy rules")
+	}
+	defer rows.Close()
+
+	var rules []*PolicyRule
+	for rows.Next() {
+		rule, err := scanPolicyRule(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan policy rule")
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate policy rules")
+	}
+
+	return rules, nil
+}
+
+// DeletePolicyRule 删除策略规则（已记录的决策保留规则 ID）
+func (s *PostgreSQLStore) DeletePolicyRule(ctx context.Context, ruleID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM policy_rules WHERE rule_id = $1`, ruleID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete policy rule")
+	}
+	return nil
+}
+
+// SavePolicyDecision 记录策略决策
+func (s *PostgreSQLStore) SavePolicyDecision(ctx context.Context, decision *PolicyDecision) error {
+	transfers := decision.Transfers
+	if transfers == nil {
+		transfers = []byte("[]")
+	}
+
+	query := `
+		INSERT INTO policy_decisions (
+			decision_id, key_id, chain_type, message_type, decision, rule_id, reason, transfers, tx_hash, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		decision.DecisionID, decision.KeyID, decision.ChainType, decision.MessageType, decision.Decision,
+		sql.NullString{String: decision.RuleID, Valid: decision.RuleID != ""}, decision.Reason, transfers,
+		sql.NullString{String: decision.TxHash, Valid: decision.TxHash != ""}, decision.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save policy decision")
+	}
+
+	return nil
+}
+
+// SumAllowedTransfers 统计密钥自 since 以来被允许的某资产转移总量，同一 tx_hash 的决策只取一条
+// 资产地址按小写比较（EVM 地址大小写不敏感，Solana 铸币地址不会因此冲突）
+func (s *PostgreSQLStore) SumAllowedTransfers(ctx context.Context, keyID string, chainType string, asset string, since time.Time, excludeTxHash string) (string, error) {
+	query := `
+		SELECT COALESCE(SUM((t->>'amount')::numeric), 0)::text
+		FROM (
+			SELECT DISTINCT ON (COALESCE(tx_hash, decision_id)) transfers
+			FROM policy_decisions
+			WHERE key_id = $1 AND chain_type = $2 AND decision = 'allow' AND created_at >= $4
+				AND (tx_hash IS NULL OR tx_hash <> $5)
+		) d, jsonb_array_elements(d.transfers) t
+		WHERE lower(COALESCE(t->>'asset', '')) = lower($3)
+	`
+
+	var total string
+	if err := s.db.QueryRowContext(ctx, query, keyID, chainType, asset, since, excludeTxHash).Scan(&total); err != nil {
+		return "", errors.Wrap(err, "failed to sum allowed transfers")
+	}
+
+	return total, nil
+}
+
+// SaveSigningRequest 保存异步签名请求
+func (s *PostgreSQLStore) SaveSigningRequest(ctx context.Context, request *SigningRequest) error {
+	query := `
+		INSERT INTO signing_requests (session_id, key_id, status, result, error, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		request.SessionID, request.KeyID, request.Status, nullableJSON(request.Result), request.Error,
+		request.CreatedAt, request.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save signing request")
+	}
+
+	return nil
+}
+
+// GetSigningRequest 获取异步签名请求，不存在时返回 nil
+func (s *PostgreSQLStore) GetSigningRequest(ctx context.Context, sessionID string) (*SigningRequest, error) {
+	query := `
+		SELECT session_id, key_id, status, result, error, created_at, updated_at
+		FROM signing_requests
+		WHERE session_id = $1
+	`
+
+	var request SigningRequest
+	err := s.db.QueryRowContext(ctx, query, sessionID).Scan(
+		&request.SessionID, &request.KeyID, &request.Status, &request.Result, &request.Error,
+		&request.CreatedAt, &request.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get signing request")
+	}
+
+	return &request, nil
+}
+
+// TransitionSigningRequest 仅当请求处于 fromStatus 时更新状态、结果和错误
+func (s *PostgreSQLStore) TransitionSigningRequest(ctx context.Context, request *SigningRequest, fromStatus string) (bool, error) {
+	query := `
+		UPDATE signing_requests
+		SET status = $2, result = $3, error = $4, updated_at = $5
+		WHERE session_id = $1 AND status = $6
+	`
+
+	res, err := s.db.ExecContext(ctx, query,
+		request.SessionID, request.Status, nullableJSON(request.Result), request.Error, request.UpdatedAt, fromStatus,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to update signing request")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get affected rows")
+	}
+
+	return affected > 0, nil
+}
+
+// SaveSigningApproval 保存等待审批的签名请求
+func (s *PostgreSQLStore) SaveSigningApproval(ctx context.Context, approval *SigningApproval) error {
+	approversJSON, err := json.Marshal(approval.Approvers)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal approvers")
+	}
+
+	query := `
+		INSERT INTO signing_approvals (
+			approval_id, key_id, decision_id, rule_id, reason, requested_by, approvers, required_approvals,
+			request, status, result, error, expires_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err = s.db.ExecContext(ctx, query,
+		approval.ApprovalID, approval.KeyID, approval.DecisionID,
+		sql.NullString{String: approval.RuleID, Valid: approval.RuleID != ""}, approval.Reason, approval.RequestedBy,
+		approversJSON, approval.RequiredApprovals, approval.Request, approval.Status, nullableJSON(approval.Result), approval.Error,
+		approval.ExpiresAt, approval.CreatedAt, approval.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save signing approval")
+	}
+
+	return nil
+}
+
+// GetSigningApproval 获取签名审批，不存在时返回 nil
+func (s *PostgreSQLStore) GetSigningApproval(ctx context.Context, approvalID string) (*SigningApproval, error) {
+	query := `
+		SELECT approval_id, key_id, decision_id, rule_id, reason, requested_by, approvers, required_approvals,
+			request, status, result, error, expires_at, created_at, updated_at
+		FROM signing_approvals
+		WHERE approval_id = $1
+	`
+
+	approval, err := scanSigningApproval(s.db.QueryRowContext(ctx, query, approvalID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get signing approval")
+	}
+
+	return approval, nil
+}
+
+// ListSigningApprovals 按创建时间倒序列出签名审批
+func (s *PostgreSQLStore) ListSigningApprovals(ctx context.Context, filter *SigningApprovalFilter) ([]*SigningApproval, error) {
+	if filter == nil {
+		filter = &SigningApprovalFilter{Limit: 50}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+
+	query := `
+		SELECT approval_id, key_id, decision_id, rule_id, reason, requested_by, approvers, required_approvals,
+			request, status, result, error, expires_at, created_at, updated_at
+		FROM signing_approvals
+		WHERE ($1 = '' OR key_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, approval_id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, filter.KeyID, filter.Status, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list signing approvals")
+	}
+	defer rows.Close()
+
+	var approvals []*SigningApproval
+	for rows.Next() {
+		approval, err := scanSigningApproval(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan signing approval")
+		}
+		approvals = append(approvals, approval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate signing approvals")
+	}
+
+	return approvals, nil
+}
+
+// TransitionSigningApproval 仅当审批处于 fromStatus 时更新状态、结果和错误
+func (s *PostgreSQLStore) TransitionSigningApproval(ctx context.Context, approval *SigningApproval, fromStatus string) (bool, error) {
+	query := `
+		UPDATE signing_approvals
+		SET status = $2, result = $3, error = $4, updated_at = $5
+		WHERE approval_id = $1 AND status = $6
+	`
+
+	res, err := s.db.ExecContext(ctx, query,
+		approval.ApprovalID, approval.Status, nullableJSON(approval.Result), approval.Error, approval.UpdatedAt, fromStatus,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to update signing approval")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get affected rows")
+	}
+
+	return affected > 0, nil
+}
+
+// SaveSigningApprovalVote 记录审批人的投票（主键为审批 ID 和审批人，重复投票违反约束）
+func (s *PostgreSQLStore) SaveSigningApprovalVote(ctx context.Context, vote *SigningApprovalVote) error {
+	query := `
+		INSERT INTO signing_approval_votes (approval_id, approver_id, vote, comment, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := s.db.ExecContext(ctx, query, vote.ApprovalID, vote.ApproverID, vote.Vote, vote.Comment, vote.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation {
+			return ErrDuplicateVote
+		}
+		return errors.Wrap(err, "failed to save signing approval vote")
+	}
+
+	return nil
+}
+
+// ListSigningApprovalVotes 按时间顺序列出审批的投票
+func (s *PostgreSQLStore) ListSigningApprovalVotes(ctx context.Context, approvalID string) ([]*SigningApprovalVote, error) {
+	query := `
+		SELECT approval_id, approver_id, vote, comment, created_at
+		FROM signing_approval_votes
+		WHERE approval_id = $1
+		ORDER BY created_at, approver_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, approvalID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list signing approval votes")
+	}
+	defer rows.Close()
+
+	var votes []*SigningApprovalVote
+	for rows.Next() {
+		var vote SigningApprovalVote
+		if err := rows.Scan(&vote.ApprovalID, &vote.ApproverID, &vote.Vote, &vote.Comment, &vote.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan signing approval vote")
+		}
+		votes = append(votes, &vote)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate signing approval votes")
+	}
+
+	return votes, nil
+}
+
+// SaveKeyApprovalQuorum 设置密钥的审批法定人数（覆盖旧设置）
+func (s *PostgreSQLStore) SaveKeyApprovalQuorum(ctx context.Context, quorum *KeyApprovalQuorum) error {
+	approversJSON, err := json.Marshal(quorum.Approvers)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal approvers")
+	}
+
+	query := `
+		INSERT INTO key_approval_quorums (key_id, approvers, required_approvals, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key_id) DO UPDATE SET
+			approvers = EXCLUDED.approvers,
+			required_approvals = EXCLUDED.required_approvals,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, quorum.KeyID, approversJSON, quorum.RequiredApprovals, quorum.UpdatedAt); err != nil {
+		return errors.Wrap(err, "failed to save key approval quorum")
+	}
+
+	return nil
+}
+
+// GetKeyApprovalQuorum 获取密钥的审批法定人数，未设置时返回 nil
+func (s *PostgreSQLStore) GetKeyApprovalQuorum(ctx context.Context, keyID string) (*KeyApprovalQuorum, error) {
+	query := `
+		SELECT key_id, approvers, required_approvals, updated_at
+		FROM key_approval_quorums
+		WHERE key_id = $1
+	`
+
+	var quorum KeyApprovalQuorum
+	var approversJSON []byte
+	err := s.db.QueryRowContext(ctx, query, keyID).Scan(&quorum.KeyID, &approversJSON, &quorum.RequiredApprovals, &quorum.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get key approval quorum")
+	}
+	if err := json.Unmarshal(approversJSON, &quorum.Approvers); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal approvers")
+	}
+
+	return &quorum, nil
+}
+
+// SaveWebhookEndpoint 保存 webhook
+func (s *PostgreSQLStore) SaveWebhookEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error {
+	eventTypesJSON, err := json.Marshal(endpoint.EventTypes)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event types")
+	}
+
+	query := `
+		INSERT INTO webhook_endpoints (
+			webhook_id, owner_id, url, secret, event_types, key_id, description, enabled, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err = s.db.ExecContext(ctx, query,
+		endpoint.WebhookID, endpoint.OwnerID, endpoint.URL, endpoint.Secret, eventTypesJSON,
+		sql.NullString{String: endpoint.KeyID, Valid: endpoint.KeyID != ""}, endpoint.Description, endpoint.Enabled,
+		endpoint.CreatedAt, endpoint.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save webhook endpoint")
+	}
+
+	return nil
+}
+
+// GetWebhookEndpoint 获取 webhook，不存在时返回 nil
+func (s *PostgreSQLStore) GetWebhookEndpoint(ctx context.Context, webhookID string) (*WebhookEndpoint, error) {
+	query := `
+		SELECT webhook_id, owner_id, url, secret, event_types, key_id, description, enabled, created_at, updated_at
+		FROM webhook_endpoints
+		WHERE webhook_id = $1
+	`
+
+	endpoint, err := scanWebhookEndpoint(s.db.QueryRowContext(ctx, query, webhookID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get webhook endpoint")
+	}
+
+	return endpoint, nil
+}
+
+// ListWebhookEndpoints 按创建时间倒序列出用户注册的 webhook
+func (s *PostgreSQLStore) ListWebhookEndpoints(ctx context.Context, ownerID string) ([]*WebhookEndpoint, error) {
+	query := `
+		SELECT webhook_id, owner_id, url, secret, event_types, key_id, description, enabled, created_at, updated_at
+		FROM webhook_endpoints
+		WHERE owner_id = $1
+		ORDER BY created_at DESC, webhook_id
+	`
+
+	return s.queryWebhookEndpoints(ctx, query, ownerID)
+}
+
+// ListWebhookEndpointsForEvent 列出订阅了该事件的已启用 webhook
+func (s *PostgreSQLStore) ListWebhookEndpointsForEvent(ctx context.Context, eventType string, keyID string) ([]*WebhookEndpoint, error) {
+	query := `
+		SELECT webhook_id, owner_id, url, secret, event_types, key_id, description, enabled, created_at, updated_at
+		FROM webhook_endpoints
+		WHERE enabled AND event_types @> jsonb_build_array($1::text) AND (key_id IS NULL OR key_id = $2)
+		ORDER BY created_at, webhook_id
+	`
+
+	return s.queryWebhookEndpoints(ctx, query, eventType, keyID)
+}
+
+func (s *PostgreSQLStore) queryWebhookEndpoints(ctx context.Context, query string, args ...interface{}) ([]*WebhookEndpoint, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhook endpoints")
+	}
+	defer rows.Close()
+
+	var endpoints []*WebhookEndpoint
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan webhook endpoint")
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate webhook endpoints")
+	}
+
+	return endpoints, nil
+}
+
+// DeleteWebhookEndpoint 删除 webhook，投递记录通过外键级联删除
+func (s *PostgreSQLStore) DeleteWebhookEndpoint(ctx context.Context, webhookID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE webhook_id = $1`, webhookID); err != nil {
+		return errors.Wrap(err, "failed to delete webhook endpoint")
+	}
+	return nil
+}
+
+// SaveWebhookDelivery 向发件箱写入一次投递
+func (s *PostgreSQLStore) SaveWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (
+			delivery_id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+			last_attempt_at, response_status, last_error, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		delivery.DeliveryID, delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.Payload, delivery.Status,
+		delivery.Attempts, delivery.NextAttemptAt, nullableTime(delivery.LastAttemptAt), delivery.ResponseStatus, delivery.LastError,
+		delivery.CreatedAt, delivery.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to save webhook delivery")
+	}
+
+	return nil
+}
+
+// GetWebhookDelivery 获取 webhook 投递，不存在时返回 nil
+func (s *PostgreSQLStore) GetWebhookDelivery(ctx context.Context, deliveryID string) (*WebhookDelivery, error) {
+	query := `
+		SELECT delivery_id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+			last_attempt_at, response_status, last_error, created_at, updated_at
+		FROM webhook_deliveries
+		WHERE delivery_id = $1
+	`
+
+	delivery, err := scanWebhookDelivery(s.db.QueryRowContext(ctx, query, deliveryID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get webhook delivery")
+	}
+
+	return delivery, nil
+}
+
+// ListWebhookDeliveries 按创建时间倒序列出 webhook 的投递记录
+func (s *PostgreSQLStore) ListWebhookDeliveries(ctx context.Context, filter *WebhookDeliveryFilter) ([]*WebhookDelivery, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+
+	query := `
+		SELECT delivery_id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+			last_attempt_at, response_status, last_error, created_at, updated_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, delivery_id
+		LIMIT $3 OFFSET $4
+	`
+
+	return s.queryWebhookDeliveries(ctx, query, filter.WebhookID, filter.Status, filter.Limit, filter.Offset)
+}
+
+// ClaimWebhookDeliveries 取出到期的待投递记录并推迟到 leaseUntil（SKIP LOCKED 使多个协调者取出不同的记录）
+func (s *PostgreSQLStore) ClaimWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries SET
+			next_attempt_at = $2
+		WHERE delivery_id IN (
+			SELECT delivery_id FROM webhook_deliveries
+			WHERE status = $3 AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING delivery_id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+			last_attempt_at, response_status, last_error, created_at, updated_at
+	`
+
+	return s.queryWebhookDeliveries(ctx, query, now, leaseUntil, WebhookDeliveryStatusPending, limit)
+}
+
+// UpdateWebhookDelivery 更新投递状态、尝试次数和最近一次投递结果
+func (s *PostgreSQLStore) UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries SET
+			status = $2,
+			attempts = $3,
+			next_attempt_at = $4,
+			last_attempt_at = $5,
+			response_status = $6,
+			last_error = $7,
+			updated_at = $8
+		WHERE delivery_id = $1
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		delivery.DeliveryID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, nullableTime(delivery.LastAttemptAt),
+		delivery.ResponseStatus, delivery.LastError, delivery.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to update webhook delivery")
+	}
+
+	return nil
+}
+
+func (s *PostgreSQLStore) queryWebhookDeliveries(ctx context.Context, query string, args ...interface{}) ([]*WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhook deliveries")
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan webhook delivery")
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+// auditLogLockID 追加审计日志时使用的事务级 advisory lock，保证哈希链按写入顺序串行延伸
+const auditLogLockID = 0x6d7063617564 // "mpcaud"
+
+// AppendAuditLog 追加审计日志
+func (s *PostgreSQLStore) AppendAuditLog(ctx context.Context, entry *AuditLog, seal func(entry *AuditLog) (string, error)) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin audit log transaction")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, auditLogLockID); err != nil {
+		return errors.Wrap(err, "failed to lock audit log")
+	}
+
+	var prevHash string
+	err = tx.QueryRowContext(ctx, `SELECT hash FROM audit_logs ORDER BY id DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		return errors.Wrap(err, "failed to get previous audit log hash")
+	}
+	entry.PrevHash = prevHash
+
+	hash, err := seal(entry)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	query := `
+		INSERT INTO audit_logs (
+			timestamp, event_type, user_id, key_id, node_id, session_id,
+			operation, result, details, ip_address, prev_hash, hash
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`
+	if err := tx.QueryRowContext(ctx, query,
+		entry.Timestamp, entry.EventType,
+		sql.NullString{String: entry.UserID, Valid: entry.UserID != ""},
+		sql.NullString{String: entry.KeyID, Valid: entry.KeyID != ""},
+		sql.NullString{String: entry.NodeID, Valid: entry.NodeID != ""},
+		sql.NullString{String: entry.SessionID, Valid: entry.SessionID != ""},
+		entry.Operation, entry.Result, nullableJSON(entry.Details),
+		sql.NullString{String: entry.IPAddress, Valid: entry.IPAddress != ""},
+		entry.PrevHash, entry.Hash,
+	).Scan(&entry.ID); err != nil {
+		return errors.Wrap(err, "failed to insert audit log")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit audit log")
+	}
+	return nil
+}
+
+// ListAuditLogs 列出审计日志
+func (s *PostgreSQLStore) ListAuditLogs(ctx context.Context, filter *AuditLogFilter) ([]*AuditLog, error) {
+	query := `
+		SELECT id, timestamp, event_type, user_id, key_id, node_id, session_id,
+			operation, result, details, ip_address, prev_hash, hash
+		FROM audit_logs
+		WHERE id > $1
+			AND ($2 = '' OR key_id = $2)
+			AND ($3 = '' OR node_id = $3)
+			AND ($4 = '' OR session_id = $4)
+			AND ($5 = '' OR user_id = $5)
+			AND ($6 = '' OR event_type = $6)
+			AND ($7 = '' OR result = $7)
+			AND ($8::timestamptz IS NULL OR timestamp >= $8)
+			AND ($9::timestamptz IS NULL OR timestamp < $9)
+		ORDER BY id ASC
+	`
+	args := []interface{}{
+		filter.AfterID, filter.KeyID, filter.NodeID, filter.SessionID, filter.UserID, filter.EventType, filter.Result,
+		sql.NullTime{Time: filter.From, Valid: !filter.From.IsZero()},
+		sql.NullTime{Time: filter.To, Valid: !filter.To.IsZero()},
+	}
+	if filter.Limit > 0 {
+		query += " LIMIT $10"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list audit logs")
+	}
+	defer rows.Close()
+
+	var entries []*AuditLog
+	for rows.Next() {
+		var entry AuditLog
+		var userID, keyID, nodeID, sessionID, ipAddress sql.NullString
+		if err := rows.Scan(
+			&entry.ID, &entry.Timestamp, &entry.EventType, &userID, &keyID, &nodeID, &sessionID,
+			&entry.Operation, &entry.Result, &entry.Details, &ipAddress, &entry.PrevHash, &entry.Hash,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan audit log")
+		}
+		entry.UserID = userID.String
+		entry.KeyID = keyID.String
+		entry.NodeID = nodeID.String
+		entry.SessionID = sessionID.String
+		entry.IPAddress = ipAddress.String
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate audit logs")
+	}
+
+	return entries, nil
+}
+
+// rowScanner sql.Row 和 sql.Rows 共有的扫描方法
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPolicyRule 扫描 policy_rules 的一行
+func scanPolicyRule(row rowScanner) (*PolicyRule, error) {
+	var rule PolicyRule
+	var keyID sql.NullString
+	if err := row.Scan(
+		&rule.RuleID, &keyID, &rule.ChainType, &rule.RuleType, &rule.Action, &rule.Params,
+		&rule.Enabled, &rule.Description, &rule.CreatedAt, &rule.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	rule.KeyID = keyID.String
+	return &rule, nil
+}
+
+// nullableJSON 空的 JSON 写入为 NULL
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
+
+// nullableTime 空时间写入 NULL
+func nullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+// scanSigningApproval 扫描 signing_approvals 的一行
+func scanSigningApproval(row rowScanner) (*SigningApproval, error) {
+	var approval SigningApproval
+	var ruleID sql.NullString
+	var approversJSON []byte
+	if err := row.Scan(
+		&approval.ApprovalID, &approval.KeyID, &approval.DecisionID, &ruleID, &approval.Reason, &approval.RequestedBy,
+		&approversJSON, &approval.RequiredApprovals, &approval.Request, &approval.Status, &approval.Result, &approval.Error,
+		&approval.ExpiresAt, &approval.CreatedAt, &approval.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	approval.RuleID = ruleID.String
+	if err := json.Unmarshal(approversJSON, &approval.Approvers); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal approvers")
+	}
+	return &approval, nil
+}
+
+// scanWebhookEndpoint 扫描 webhook_endpoints 的一行
+func scanWebhookEndpoint(row rowScanner) (*WebhookEndpoint, error) {
+	var endpoint WebhookEndpoint
+	var keyID sql.NullString
+	var eventTypesJSON []byte
+	if err := row.Scan(
+		&endpoint.WebhookID, &endpoint.OwnerID, &endpoint.URL, &endpoint.Secret, &eventTypesJSON, &keyID,
+		&endpoint.Description, &endpoint.Enabled, &endpoint.CreatedAt, &endpoint.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	endpoint.KeyID = keyID.String
+	if err := json.Unmarshal(eventTypesJSON, &endpoint.EventTypes); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal event types")
+	}
+	return &endpoint, nil
+}
+
+// scanWebhookDelivery 扫描 webhook_deliveries 的一行
+func scanWebhookDelivery(row rowScanner) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	var lastAttemptAt sql.NullTime
+	if err := row.Scan(
+		&delivery.DeliveryID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &delivery.Payload, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &lastAttemptAt, &delivery.ResponseStatus, &delivery.LastError,
+		&delivery.CreatedAt, &delivery.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if lastAttemptAt.Valid {
+		delivery.LastAttemptAt = &lastAttemptAt.Time
+	}
+	return &delivery, nil
+}
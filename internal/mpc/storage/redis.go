@@ -67,20 +67,46 @@ func (s *RedisStore) DeleteSession(ctx context.Context, sessionID string) error
 	return nil
 }
 
+// renewLockScript 持有者一致时延长锁的有效期
+var renewLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseLockScript 持有者一致时删除锁
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
 // AcquireLock 获取分布式锁
-func (s *RedisStore) AcquireLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+func (s *RedisStore) AcquireLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
 	lockKey := "mpc:lock:" + key
-	result, err := s.client.SetNX(ctx, lockKey, "1", ttl).Result()
+	result, err := s.client.SetNX(ctx, lockKey, token, ttl).Result()
 	if err != nil {
 		return false, errors.Wrap(err, "failed to acquire lock")
 	}
 	return result, nil
 }
 
+// RenewLock 延长分布式锁的有效期
+func (s *RedisStore) RenewLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	lockKey := "mpc:lock:" + key
+	result, err := renewLockScript.Run(ctx, s.client, []string{lockKey}, token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to renew lock")
+	}
+	return result == 1, nil
+}
+
 // ReleaseLock 释放分布式锁
-func (s *RedisStore) ReleaseLock(ctx context.Context, key string) error {
+func (s *RedisStore) ReleaseLock(ctx context.Context, key string, token string) error {
 	lockKey := "mpc:lock:" + key
-	if err := s.client.Del(ctx, lockKey).Err(); err != nil {
+	if err := releaseLockScript.Run(ctx, s.client, []string{lockKey}, token).Err(); err != nil {
 		return errors.Wrap(err, "failed to release lock")
 	}
 	return nil
@@ -0,0 +1,253 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// dispatchBatchSize 每次检查最多投递的记录数量
+	dispatchBatchSize = 50
+	// 投递失败后的重试退避：首次 30 秒，每次失败翻倍，最长 6 小时
+	retryBackoff    = 30 * time.Second
+	maxRetryBackoff = 6 * time.Hour
+	// dialTimeout 建立连接的超时
+	dialTimeout = 10 * time.Second
+)
+
+// Dispatcher 定期从发件箱取出到期的投递，以签名的 POST 请求发送给 webhook，失败后按指数退避重试（协调者）
+type Dispatcher struct {
+	metadataStore storage.MetadataStore
+	client        *http.Client
+	interval      time.Duration
+	timeout       time.Duration
+	maxAttempts   int
+	now           func() time.Time
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// NewDispatcher 创建 webhook 投递任务，interval 为检查间隔（为 0 时关闭），timeout 为单次请求超时，
+// maxAttempts 为每次投递的最大尝试次数
+func NewDispatcher(metadataStore storage.MetadataStore, interval time.Duration, timeout time.Duration, maxAttempts int) *Dispatcher {
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	return &Dispatcher{
+		metadataStore: metadataStore,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: newTransport(publicAddressOnly),
+			// 不跟随重定向，3xx 视为投递失败
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		interval:    interval,
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		now:         time.Now,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// newTransport 创建投递使用的 Transport：不使用代理，control 在连接前检查 DNS 解析后的目标地址
+func newTransport(control func(network, address string, conn syscall.RawConn) error) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: control,
+	}
+	return &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: dialTimeout,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// publicAddressOnly 拒绝连接回环、内网、链路本地、组播和未指定地址，防止 webhook 被用于访问内部服务（SSRF）。
+// 在解析后的地址上检查，DNS 重绑定无法绕过
+func publicAddressOnly(_ string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return errors.Wrapf(ErrForbiddenAddress, "invalid address %s", address)
+	}
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return errors.Wrapf(ErrForbiddenAddress, "%s", addr)
+	}
+	return nil
+}
+
+// sharedAddressSpace 运营商级 NAT 地址（RFC 6598），通常用于云厂商内部网络
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Enabled webhook 投递任务是否开启
+func (d *Dispatcher) Enabled() bool {
+	return d != nil && d.interval > 0
+}
+
+// Run 在后台定期投递到期的事件，直到 ctx 取消或调用 Stop
+func (d *Dispatcher) Run(ctx context.Context) {
+	if !d.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.DispatchDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop 停止后台任务
+func (d *Dispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopCh)
+	})
+}
+
+// DispatchDue 投递一批到期的事件，返回投递成功的数量
+func (d *Dispatcher) DispatchDue(ctx context.Context) int {
+	now := d.now().UTC()
+	// 租约覆盖整批投递，投递期间协调者退出时记录在租约到期后重新投递
+	lease := now.Add(d.timeout*dispatchBatchSize + time.Minute)
+	deliveries, err := d.metadataStore.ClaimWebhookDeliveries(ctx, now, lease, dispatchBatchSize)
+	if err != nil {
+		log.Error().Err(err).Msg("Dispatcher: failed to claim webhook deliveries")
+		return 0
+	}
+
+	succeeded := 0
+	for _, delivery := range deliveries {
+		if d.deliver(ctx, delivery) {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
+// deliver 投递一次并记录结果，返回是否成功
+func (d *Dispatcher) deliver(ctx context.Context, delivery *storage.WebhookDelivery) bool {
+	logger := log.With().
+		Str("webhook_id", delivery.WebhookID).
+		Str("delivery_id", delivery.DeliveryID).
+		Str("event_type", delivery.EventType).
+		Logger()
+
+	endpoint, err := d.metadataStore.GetWebhookEndpoint(ctx, delivery.WebhookID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Dispatcher: failed to get webhook endpoint, will retry")
+		d.record(ctx, delivery, 0, err, true)
+		return false
+	}
+	if endpoint == nil || !endpoint.Enabled {
+		d.record(ctx, delivery, 0, errors.New("webhook endpoint is disabled"), false)
+		return false
+	}
+
+	statusCode, err := d.send(ctx, endpoint, delivery)
+	d.record(ctx, delivery, statusCode, err, true)
+	if err != nil {
+		logger.Warn().
+			Err(err).
+			Int("attempts", delivery.Attempts).
+			Str("status", delivery.Status).
+			Time("next_attempt_at", delivery.NextAttemptAt).
+			Msg("Dispatcher: webhook delivery failed")
+		return false
+	}
+
+	logger.Debug().Int("attempts", delivery.Attempts).Msg("Dispatcher: webhook delivered")
+	return true
+}
+
+// send 发送签名的 POST 请求，2xx 以外的响应视为失败
+func (d *Dispatcher) send(ctx context.Context, endpoint *storage.WebhookEndpoint, delivery *storage.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create webhook request")
+	}
+
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-mpc-wallet-webhook")
+	req.Header.Set(HeaderWebhookID, endpoint.WebhookID)
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderDeliveryID, delivery.DeliveryID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to send webhook request")
+	}
+	defer resp.Body.Close()
+
+	// 不读取响应体：错误信息通过 API 返回给 webhook 所有者，记录响应内容会泄露接收方的数据
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// record 记录一次投递尝试：成功时为 succeeded，不可重试或达到最大尝试次数时为 failed，否则按退避时间安排下一次尝试
+func (d *Dispatcher) record(ctx context.Context, delivery *storage.WebhookDelivery, statusCode int, sendErr error, retry bool) {
+	now := d.now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = statusCode
+	delivery.UpdatedAt = now
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = storage.WebhookDeliveryStatusSucceeded
+		delivery.LastError = ""
+	case !retry || delivery.Attempts >= d.maxAttempts:
+		delivery.Status = storage.WebhookDeliveryStatusFailed
+		delivery.LastError = sendErr.Error()
+	default:
+		delivery.Status = storage.WebhookDeliveryStatusPending
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = now.Add(deliveryBackoff(delivery.Attempts))
+	}
+
+	if err := d.metadataStore.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		log.Error().Err(err).Str("delivery_id", delivery.DeliveryID).Msg("Dispatcher: failed to record webhook delivery attempt")
+	}
+}
+
+// deliveryBackoff 失败 attempts 次后的重试等待时间
+func deliveryBackoff(attempts int) time.Duration {
+	backoff := retryBackoff
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
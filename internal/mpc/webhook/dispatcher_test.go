@@ -0,0 +1,239 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receivedRequest 接收方收到的一次投递
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+	err    error // 签名校验结果
+}
+
+// receiver 本地 webhook 接收方：校验签名，按 statuses 依次返回状态码（用完后返回 200）
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	secret   string
+	statuses []int
+	requests []receivedRequest
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	t.Helper()
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		verifyErr := Verify(r.secret, req.Header.Get(HeaderTimestamp), req.Header.Get(HeaderSignature), body, 0, time.Time{})
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body, err: verifyErr})
+
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status = r.statuses[0]
+			r.statuses = r.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+// setup 注册指向接收方的 webhook 并发布一个事件，返回使用固定时钟的投递任务
+func setup(t *testing.T, r *receiver, maxAttempts int, now *time.Time) (*webhookStore, *Endpoint, *Dispatcher) {
+	t.Helper()
+	store := newWebhookStore()
+	svc := NewService(store, true)
+	svc.now = func() time.Time { return *now }
+
+	endpoint := createEndpoint(t, svc, "user-1", r.URL, "", EventSigningCompleted)
+	r.mu.Lock()
+	r.secret = endpoint.Secret
+	r.mu.Unlock()
+
+	svc.Publish(context.Background(), EventSigningCompleted, "key-1", map[string]interface{}{"session_id": "session-1"})
+
+	dispatcher := NewDispatcher(store, time.Second, 5*time.Second, maxAttempts)
+	dispatcher.now = func() time.Time { return *now }
+	// 测试接收方监听在回环地址
+	dispatcher.client.Transport = newTransport(nil)
+	return store, endpoint, dispatcher
+}
+
+func TestDispatcherDeliversSignedEvent(t *testing.T) {
+	now := time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC)
+	r := newReceiver(t)
+	store, endpoint, dispatcher := setup(t, r, 3, &now)
+
+	assert.Equal(t, 1, dispatcher.DispatchDue(context.Background()))
+
+	requests := r.received()
+	require.Len(t, requests, 1)
+	delivery := store.deliveriesFor(t, endpoint.WebhookID)[0]
+
+	req := requests[0]
+	require.NoError(t, req.err)
+	assert.Equal(t, delivery.Payload, req.body)
+	assert.Equal(t, endpoint.WebhookID, req.header.Get(HeaderWebhookID))
+	assert.Equal(t, delivery.EventID, req.header.Get(HeaderEventID))
+	assert.Equal(t, EventSigningCompleted, req.header.Get(HeaderEventType))
+	assert.Equal(t, delivery.DeliveryID, req.header.Get(HeaderDeliveryID))
+	assert.Equal(t, "1769763600", req.header.Get(HeaderTimestamp))
+
+	assert.Equal(t, storage.WebhookDeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, delivery.ResponseStatus)
+	assert.Empty(t, delivery.LastError)
+
+	// 已成功的投递不会再次发送
+	assert.Equal(t, 0, dispatcher.DispatchDue(context.Background()))
+	assert.Len(t, r.received(), 1)
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	now := time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC)
+	r := newReceiver(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	store, endpoint, dispatcher := setup(t, r, 5, &now)
+	ctx := context.Background()
+
+	assert.Equal(t, 0, dispatcher.DispatchDue(ctx))
+	delivery := store.deliveriesFor(t, endpoint.WebhookID)[0]
+	assert.Equal(t, storage.WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+	assert.Contains(t, delivery.LastError, "500")
+	assert.NotContains(t, delivery.LastError, "internal response body")
+	assert.Equal(t, now.Add(30*time.Second), delivery.NextAttemptAt)
+
+	// 退避时间未到时不重试
+	now = now.Add(29 * time.Second)
+	assert.Equal(t, 0, dispatcher.DispatchDue(ctx))
+	assert.Len(t, r.received(), 1)
+
+	now = now.Add(time.Second)
+	assert.Equal(t, 0, dispatcher.DispatchDue(ctx))
+	delivery = store.deliveriesFor(t, endpoint.WebhookID)[0]
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Equal(t, now.Add(time.Minute), delivery.NextAttemptAt)
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, 1, dispatcher.DispatchDue(ctx))
+	delivery = store.deliveriesFor(t, endpoint.WebhookID)[0]
+	assert.Equal(t, storage.WebhookDeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Empty(t, delivery.LastError)
+
+	// 每次重试使用相同的事件 ID，签名随时间戳更新
+	requests := r.received()
+	require.Len(t, requests, 3)
+	for _, req := range requests {
+		require.NoError(t, req.err)
+		assert.Equal(t, delivery.EventID, req.header.Get(HeaderEventID))
+	}
+	assert.NotEqual(t, requests[0].header.Get(HeaderSignature), requests[2].header.Get(HeaderSignature))
+}
+
+func TestDispatcherFailsAfterMaxAttempts(t *testing.T) {
+	now := time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC)
+	r := newReceiver(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	store, endpoint, dispatcher := setup(t, r, 2, &now)
+	ctx := context.Background()
+
+	dispatcher.DispatchDue(ctx)
+	now = now.Add(time.Hour)
+	dispatcher.DispatchDue(ctx)
+
+	delivery := store.deliveriesFor(t, endpoint.WebhookID)[0]
+	assert.Equal(t, storage.WebhookDeliveryStatusFailed, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+
+	now = now.Add(24 * time.Hour)
+	dispatcher.DispatchDue(ctx)
+	assert.Len(t, r.received(), 2)
+}
+
+func TestDispatcherSkipsDisabledEndpoint(t *testing.T) {
+	now := time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC)
+	r := newReceiver(t)
+	store, endpoint, dispatcher := setup(t, r, 3, &now)
+	ctx := context.Background()
+
+	stored, err := store.GetWebhookEndpoint(ctx, endpoint.WebhookID)
+	require.NoError(t, err)
+	stored.Enabled = false
+	require.NoError(t, store.SaveWebhookEndpoint(ctx, stored))
+
+	assert.Equal(t, 0, dispatcher.DispatchDue(ctx))
+	assert.Len(t, r.received(), 0)
+
+	delivery := store.deliveriesFor(t, endpoint.WebhookID)[0]
+	assert.Equal(t, storage.WebhookDeliveryStatusFailed, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+}
+
+func TestDispatcherRejectsInternalAddresses(t *testing.T) {
+	now := time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC)
+	r := newReceiver(t)
+	store, endpoint, dispatcher := setup(t, r, 3, &now)
+	dispatcher.client.Transport = newTransport(publicAddressOnly)
+
+	assert.Equal(t, 0, dispatcher.DispatchDue(context.Background()))
+	assert.Empty(t, r.received())
+	delivery := store.deliveriesFor(t, endpoint.WebhookID)[0]
+	assert.Equal(t, storage.WebhookDeliveryStatusPending, delivery.Status)
+	assert.Contains(t, delivery.LastError, ErrForbiddenAddress.Error())
+}
+
+func TestPublicAddressOnly(t *testing.T) {
+	for _, address := range []string{
+		"127.0.0.1:80", "[::1]:443", "10.0.0.5:8080", "172.16.1.1:80", "192.168.1.1:80",
+		"169.254.169.254:80", "[fe80::1]:80", "[fd00::1]:80", "0.0.0.0:80", "100.64.0.1:80",
+		"[::ffff:127.0.0.1]:80", "224.0.0.1:80",
+	} {
+		assert.ErrorIs(t, publicAddressOnly("tcp", address, nil), ErrForbiddenAddress, address)
+	}
+	for _, address := range []string{"93.184.216.34:443", "[2606:2800:220:1:248:1893:25c8:1946]:443"} {
+		assert.NoError(t, publicAddressOnly("tcp", address, nil), address)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":"event-1"}`)
+	signature := Sign("whsec_test", now.Unix(), body)
+
+	assert.NoError(t, Verify("whsec_test", "1769763600", signature, body, 5*time.Minute, now.Add(time.Minute)))
+	assert.ErrorIs(t, Verify("whsec_other", "1769763600", signature, body, 5*time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("whsec_test", "1769763600", signature, []byte(`{"id":"event-2"}`), 5*time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("whsec_test", "1769763601", signature, body, 5*time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("whsec_test", "1769763600", signature, body, 5*time.Minute, now.Add(10*time.Minute)), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("whsec_test", "invalid", signature, body, 0, now), ErrInvalidSignature)
+}
+
+func TestDeliveryBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, deliveryBackoff(1))
+	assert.Equal(t, time.Minute, deliveryBackoff(2))
+	assert.Equal(t, 2*time.Minute, deliveryBackoff(3))
+	assert.Equal(t, 6*time.Hour, deliveryBackoff(20))
+	assert.Equal(t, 6*time.Hour, deliveryBackoff(1000))
+}
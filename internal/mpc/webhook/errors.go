@@ -0,0 +1,20 @@
+package webhook
+
+import (
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotFound webhook 不存在或不属于当前用户
+	ErrNotFound = errors.New("webhook not found")
+	// ErrDeliveryNotFound webhook 投递不存在
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrInvalidURL webhook 地址不是有效的 http(s) URL
+	ErrInvalidURL = errors.New("webhook url must be an absolute http or https url")
+	// ErrForbiddenAddress webhook 地址解析到回环、内网或链路本地地址，投递时拒绝连接
+	ErrForbiddenAddress = errors.New("webhook url resolves to a loopback, private or link-local address")
+	// ErrInvalidEventType 订阅了不支持的事件类型
+	ErrInvalidEventType = errors.New("unsupported webhook event type")
+	// ErrInvalidSignature webhook 请求的签名无效或时间戳超出容忍范围
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
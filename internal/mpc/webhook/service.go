@@ -0,0 +1,269 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/kashguard/go-mpc-wallet/internal/util"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// secretBytes webhook 签名密钥的随机字节数
+const secretBytes = 32
+
+// Service webhook 服务：管理用户注册的 webhook，把 MPC 事件写入发件箱（webhook_deliveries），由 Dispatcher 投递
+type Service struct {
+	metadataStore storage.MetadataStore
+	enabled       bool
+	now           func() time.Time
+}
+
+// NewService 创建 webhook 服务，enabled 为 false 时不发布事件（已注册的 webhook 仍可管理）
+func NewService(metadataStore storage.MetadataStore, enabled bool) *Service {
+	return &Service{
+		metadataStore: metadataStore,
+		enabled:       enabled,
+		now:           time.Now,
+	}
+}
+
+// Enabled 是否发布事件（服务为空时视为未启用）
+func (s *Service) Enabled() bool {
+	return s != nil && s.enabled
+}
+
+// CreateEndpoint 为用户注册 webhook 并生成签名密钥
+func (s *Service) CreateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error) {
+	if err := validateEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+
+	secret, err := util.GenerateRandomHexString(secretBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate webhook secret")
+	}
+
+	now := s.now().UTC()
+	stored := &storage.WebhookEndpoint{
+		WebhookID:   "webhook-" + uuid.New().String(),
+		OwnerID:     endpoint.OwnerID,
+		URL:         endpoint.URL,
+		Secret:      "whsec_" + secret,
+		EventTypes:  endpoint.EventTypes,
+		KeyID:       endpoint.KeyID,
+		Description: endpoint.Description,
+		Enabled:     true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.metadataStore.SaveWebhookEndpoint(ctx, stored); err != nil {
+		return nil, errors.Wrap(err, "failed to save webhook endpoint")
+	}
+
+	log.Info().
+		Str("webhook_id", stored.WebhookID).
+		Str("owner_id", stored.OwnerID).
+		Strs("event_types", stored.EventTypes).
+		Str("key_id", stored.KeyID).
+		Msg("Webhook endpoint registered")
+
+	return endpointFromStorage(stored), nil
+}
+
+// GetEndpoint 获取用户的 webhook，不存在或属于其他用户时返回 ErrNotFound
+func (s *Service) GetEndpoint(ctx context.Context, webhookID string, ownerID string) (*Endpoint, error) {
+	stored, err := s.metadataStore.GetWebhookEndpoint(ctx, webhookID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook endpoint")
+	}
+	if stored == nil || stored.OwnerID != ownerID {
+		return nil, ErrNotFound
+	}
+	return endpointFromStorage(stored), nil
+}
+
+// ListEndpoints 列出用户注册的 webhook
+func (s *Service) ListEndpoints(ctx context.Context, ownerID string) ([]*Endpoint, error) {
+	stored, err := s.metadataStore.ListWebhookEndpoints(ctx, ownerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhook endpoints")
+	}
+	endpoints := make([]*Endpoint, len(stored))
+	for i, st := range stored {
+		endpoints[i] = endpointFromStorage(st)
+	}
+	return endpoints, nil
+}
+
+// DeleteEndpoint 删除用户的 webhook 及其投递记录
+func (s *Service) DeleteEndpoint(ctx context.Context, webhookID string, ownerID string) error {
+	if _, err := s.GetEndpoint(ctx, webhookID, ownerID); err != nil {
+		return err
+	}
+	if err := s.metadataStore.DeleteWebhookEndpoint(ctx, webhookID); err != nil {
+		return errors.Wrap(err, "failed to delete webhook endpoint")
+	}
+	log.Info().Str("webhook_id", webhookID).Str("owner_id", ownerID).Msg("Webhook endpoint deleted")
+	return nil
+}
+
+// ListDeliveries 列出用户 webhook 的投递记录
+func (s *Service) ListDeliveries(ctx context.Context, ownerID string, filter *storage.WebhookDeliveryFilter) ([]*Delivery, error) {
+	if _, err := s.GetEndpoint(ctx, filter.WebhookID, ownerID); err != nil {
+		return nil, err
+	}
+	stored, err := s.metadataStore.ListWebhookDeliveries(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhook deliveries")
+	}
+	deliveries := make([]*Delivery, len(stored))
+	for i, st := range stored {
+		deliveries[i] = deliveryFromStorage(st)
+	}
+	return deliveries, nil
+}
+
+// Redeliver 手动重新投递一次事件：写入使用相同事件 ID 和请求体的新投递记录，原记录保持不变
+func (s *Service) Redeliver(ctx context.Context, webhookID string, deliveryID string, ownerID string) (*Delivery, error) {
+	if _, err := s.GetEndpoint(ctx, webhookID, ownerID); err != nil {
+		return nil, err
+	}
+	original, err := s.metadataStore.GetWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get webhook delivery")
+	}
+	if original == nil || original.WebhookID != webhookID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	delivery := s.newDelivery(webhookID, original.EventID, original.EventType, original.Payload)
+	if err := s.metadataStore.SaveWebhookDelivery(ctx, delivery); err != nil {
+		return nil, errors.Wrap(err, "failed to save webhook delivery")
+	}
+
+	log.Info().
+		Str("webhook_id", webhookID).
+		Str("event_id", delivery.EventID).
+		Str("delivery_id", delivery.DeliveryID).
+		Str("original_delivery_id", deliveryID).
+		Msg("Webhook delivery scheduled for redelivery")
+
+	return deliveryFromStorage(delivery), nil
+}
+
+// Publish 把事件写入订阅了该事件（及密钥）的每个 webhook 的发件箱。
+// 写入失败只记录错误日志，不影响触发事件的操作
+func (s *Service) Publish(ctx context.Context, eventType string, keyID string, data map[string]interface{}) {
+	if !s.Enabled() {
+		return
+	}
+
+	logger := log.With().Str("event_type", eventType).Str("key_id", keyID).Logger()
+
+	endpoints, err := s.metadataStore.ListWebhookEndpointsForEvent(ctx, eventType, keyID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list webhook endpoints for event")
+		return
+	}
+	if len(endpoints) == 0 {
+		return
+	}
+
+	event := &Event{
+		EventID:   "event-" + uuid.New().String(),
+		EventType: eventType,
+		CreatedAt: s.now().UTC(),
+		Data:      data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to marshal webhook event")
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		delivery := s.newDelivery(endpoint.WebhookID, event.EventID, eventType, payload)
+		if err := s.metadataStore.SaveWebhookDelivery(ctx, delivery); err != nil {
+			logger.Error().Err(err).Str("webhook_id", endpoint.WebhookID).Msg("Failed to save webhook delivery")
+		}
+	}
+}
+
+func (s *Service) newDelivery(webhookID string, eventID string, eventType string, payload []byte) *storage.WebhookDelivery {
+	now := s.now().UTC()
+	return &storage.WebhookDelivery{
+		DeliveryID:    "delivery-" + uuid.New().String(),
+		WebhookID:     webhookID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        storage.WebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// validateEndpoint 检查 webhook 地址和订阅的事件类型
+func validateEndpoint(endpoint *Endpoint) error {
+	u, err := url.Parse(endpoint.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if len(endpoint.EventTypes) == 0 {
+		return errors.Wrap(ErrInvalidEventType, "at least one event type is required")
+	}
+	for _, eventType := range endpoint.EventTypes {
+		if !isEventType(eventType) {
+			return errors.Wrapf(ErrInvalidEventType, "%s", eventType)
+		}
+	}
+	return nil
+}
+
+func isEventType(eventType string) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func endpointFromStorage(stored *storage.WebhookEndpoint) *Endpoint {
+	return &Endpoint{
+		WebhookID:   stored.WebhookID,
+		OwnerID:     stored.OwnerID,
+		URL:         stored.URL,
+		Secret:      stored.Secret,
+		EventTypes:  stored.EventTypes,
+		KeyID:       stored.KeyID,
+		Description: stored.Description,
+		Enabled:     stored.Enabled,
+		CreatedAt:   stored.CreatedAt,
+		UpdatedAt:   stored.UpdatedAt,
+	}
+}
+
+func deliveryFromStorage(stored *storage.WebhookDelivery) *Delivery {
+	return &Delivery{
+		DeliveryID:     stored.DeliveryID,
+		WebhookID:      stored.WebhookID,
+		EventID:        stored.EventID,
+		EventType:      stored.EventType,
+		Payload:        stored.Payload,
+		Status:         stored.Status,
+		Attempts:       stored.Attempts,
+		NextAttemptAt:  stored.NextAttemptAt,
+		LastAttemptAt:  stored.LastAttemptAt,
+		ResponseStatus: stored.ResponseStatus,
+		LastError:      stored.LastError,
+		CreatedAt:      stored.CreatedAt,
+		UpdatedAt:      stored.UpdatedAt,
+	}
+}
@@ -0,0 +1,284 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kashguard/go-mpc-wallet/internal/mpc/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookStore 只实现 webhook 相关方法的内存存储
+type webhookStore struct {
+	storage.MetadataStore
+	mu         sync.Mutex
+	endpoints  map[string]*storage.WebhookEndpoint
+	deliveries map[string]*storage.WebhookDelivery
+}
+
+func newWebhookStore() *webhookStore {
+	return &webhookStore{
+		endpoints:  map[string]*storage.WebhookEndpoint{},
+		deliveries: map[string]*storage.WebhookDelivery{},
+	}
+}
+
+func (m *webhookStore) SaveWebhookEndpoint(_ context.Context, endpoint *storage.WebhookEndpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *endpoint
+	m.endpoints[endpoint.WebhookID] = &stored
+	return nil
+}
+
+func (m *webhookStore) GetWebhookEndpoint(_ context.Context, webhookID string) (*storage.WebhookEndpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.endpoints[webhookID]
+	if !ok {
+		return nil, nil
+	}
+	endpoint := *stored
+	return &endpoint, nil
+}
+
+func (m *webhookStore) ListWebhookEndpoints(_ context.Context, ownerID string) ([]*storage.WebhookEndpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var endpoints []*storage.WebhookEndpoint
+	for _, stored := range m.endpoints {
+		if stored.OwnerID == ownerID {
+			endpoint := *stored
+			endpoints = append(endpoints, &endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+func (m *webhookStore) ListWebhookEndpointsForEvent(_ context.Context, eventType string, keyID string) ([]*storage.WebhookEndpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var endpoints []*storage.WebhookEndpoint
+	for _, stored := range m.endpoints {
+		if !stored.Enabled || (stored.KeyID != "" && stored.KeyID != keyID) {
+			continue
+		}
+		for _, t := range stored.EventTypes {
+			if t == eventType {
+				endpoint := *stored
+				endpoints = append(endpoints, &endpoint)
+				break
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+func (m *webhookStore) DeleteWebhookEndpoint(_ context.Context, webhookID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.endpoints, webhookID)
+	for id, delivery := range m.deliveries {
+		if delivery.WebhookID == webhookID {
+			delete(m.deliveries, id)
+		}
+	}
+	return nil
+}
+
+func (m *webhookStore) SaveWebhookDelivery(_ context.Context, delivery *storage.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *delivery
+	m.deliveries[delivery.DeliveryID] = &stored
+	return nil
+}
+
+func (m *webhookStore) GetWebhookDelivery(_ context.Context, deliveryID string) (*storage.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.deliveries[deliveryID]
+	if !ok {
+		return nil, nil
+	}
+	delivery := *stored
+	return &delivery, nil
+}
+
+func (m *webhookStore) ListWebhookDeliveries(_ context.Context, filter *storage.WebhookDeliveryFilter) ([]*storage.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []*storage.WebhookDelivery
+	for _, stored := range m.deliveries {
+		if stored.WebhookID != filter.WebhookID || (filter.Status != "" && stored.Status != filter.Status) {
+			continue
+		}
+		delivery := *stored
+		deliveries = append(deliveries, &delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	return deliveries, nil
+}
+
+func (m *webhookStore) ClaimWebhookDeliveries(_ context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*storage.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []*storage.WebhookDelivery
+	for _, stored := range m.deliveries {
+		if len(deliveries) >= limit {
+			break
+		}
+		if stored.Status != storage.WebhookDeliveryStatusPending || stored.NextAttemptAt.After(now) {
+			continue
+		}
+		stored.NextAttemptAt = leaseUntil
+		delivery := *stored
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+func (m *webhookStore) UpdateWebhookDelivery(ctx context.Context, delivery *storage.WebhookDelivery) error {
+	return m.SaveWebhookDelivery(ctx, delivery)
+}
+
+// deliveriesFor 返回 webhook 的全部投递记录
+func (m *webhookStore) deliveriesFor(t *testing.T, webhookID string) []*storage.WebhookDelivery {
+	t.Helper()
+	deliveries, err := m.ListWebhookDeliveries(context.Background(), &storage.WebhookDeliveryFilter{WebhookID: webhookID})
+	require.NoError(t, err)
+	return deliveries
+}
+
+func createEndpoint(t *testing.T, svc *Service, ownerID string, url string, keyID string, eventTypes ...string) *Endpoint {
+	t.Helper()
+	endpoint, err := svc.CreateEndpoint(context.Background(), &Endpoint{
+		OwnerID:    ownerID,
+		URL:        url,
+		EventTypes: eventTypes,
+		KeyID:      keyID,
+	})
+	require.NoError(t, err)
+	return endpoint
+}
+
+func TestCreateEndpoint(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(newWebhookStore(), true)
+
+	endpoint := createEndpoint(t, svc, "user-1", "https://backoffice.example.com/hooks/mpc", "", EventSigningCompleted)
+	assert.True(t, strings.HasPrefix(endpoint.WebhookID, "webhook-"))
+	assert.True(t, strings.HasPrefix(endpoint.Secret, "whsec_"))
+	assert.True(t, endpoint.Enabled)
+
+	_, err := svc.GetEndpoint(ctx, endpoint.WebhookID, "user-2")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = svc.CreateEndpoint(ctx, &Endpoint{OwnerID: "user-1", URL: "ftp://example.com", EventTypes: []string{EventSigningCompleted}})
+	assert.ErrorIs(t, err, ErrInvalidURL)
+
+	_, err = svc.CreateEndpoint(ctx, &Endpoint{OwnerID: "user-1", URL: "https://example.com", EventTypes: []string{"key.created"}})
+	assert.ErrorIs(t, err, ErrInvalidEventType)
+
+	_, err = svc.CreateEndpoint(ctx, &Endpoint{OwnerID: "user-1", URL: "https://example.com"})
+	assert.ErrorIs(t, err, ErrInvalidEventType)
+
+	require.ErrorIs(t, svc.DeleteEndpoint(ctx, endpoint.WebhookID, "user-2"), ErrNotFound)
+	require.NoError(t, svc.DeleteEndpoint(ctx, endpoint.WebhookID, "user-1"))
+	_, err = svc.GetEndpoint(ctx, endpoint.WebhookID, "user-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPublishFiltersEndpoints(t *testing.T) {
+	ctx := context.Background()
+	store := newWebhookStore()
+	svc := NewService(store, true)
+
+	all := createEndpoint(t, svc, "user-1", "https://a.example.com", "", EventSigningCompleted, EventSigningFailed)
+	otherKey := createEndpoint(t, svc, "user-1", "https://b.example.com", "key-2", EventSigningCompleted)
+	sameKey := createEndpoint(t, svc, "user-2", "https://c.example.com", "key-1", EventSigningCompleted)
+	dkg := createEndpoint(t, svc, "user-1", "https://d.example.com", "", EventDKGCompleted)
+
+	svc.Publish(ctx, EventSigningCompleted, "key-1", map[string]interface{}{"session_id": "session-1"})
+
+	assert.Len(t, store.deliveriesFor(t, otherKey.WebhookID), 0)
+	assert.Len(t, store.deliveriesFor(t, dkg.WebhookID), 0)
+
+	allDeliveries := store.deliveriesFor(t, all.WebhookID)
+	sameKeyDeliveries := store.deliveriesFor(t, sameKey.WebhookID)
+	require.Len(t, allDeliveries, 1)
+	require.Len(t, sameKeyDeliveries, 1)
+
+	// 同一事件投递给不同 webhook 时使用相同的事件 ID 和请求体
+	delivery := allDeliveries[0]
+	assert.Equal(t, storage.WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, EventSigningCompleted, delivery.EventType)
+	assert.Equal(t, delivery.EventID, sameKeyDeliveries[0].EventID)
+	assert.Equal(t, delivery.Payload, sameKeyDeliveries[0].Payload)
+
+	var event Event
+	require.NoError(t, json.Unmarshal(delivery.Payload, &event))
+	assert.Equal(t, delivery.EventID, event.EventID)
+	assert.Equal(t, EventSigningCompleted, event.EventType)
+	assert.Equal(t, "session-1", event.Data["session_id"])
+}
+
+func TestPublishDisabled(t *testing.T) {
+	ctx := context.Background()
+	store := newWebhookStore()
+	svc := NewService(store, false)
+
+	endpoint := createEndpoint(t, svc, "user-1", "https://a.example.com", "", EventDKGCompleted)
+	svc.Publish(ctx, EventDKGCompleted, "key-1", map[string]interface{}{})
+	assert.Len(t, store.deliveriesFor(t, endpoint.WebhookID), 0)
+
+	var nilService *Service
+	assert.NotPanics(t, func() {
+		nilService.Publish(ctx, EventDKGCompleted, "key-1", map[string]interface{}{})
+	})
+}
+
+func TestRedeliver(t *testing.T) {
+	ctx := context.Background()
+	store := newWebhookStore()
+	svc := NewService(store, true)
+
+	endpoint := createEndpoint(t, svc, "user-1", "https://a.example.com", "", EventSessionFailed)
+	other := createEndpoint(t, svc, "user-1", "https://b.example.com", "", EventSigningFailed)
+	svc.Publish(ctx, EventSessionFailed, "key-1", map[string]interface{}{"session_id": "session-1"})
+
+	original := store.deliveriesFor(t, endpoint.WebhookID)[0]
+	original.Status = storage.WebhookDeliveryStatusFailed
+	original.Attempts = 8
+	require.NoError(t, store.UpdateWebhookDelivery(ctx, original))
+
+	_, err := svc.Redeliver(ctx, endpoint.WebhookID, original.DeliveryID, "user-2")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = svc.Redeliver(ctx, other.WebhookID, original.DeliveryID, "user-1")
+	assert.ErrorIs(t, err, ErrDeliveryNotFound)
+
+	redelivery, err := svc.Redeliver(ctx, endpoint.WebhookID, original.DeliveryID, "user-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, original.DeliveryID, redelivery.DeliveryID)
+	assert.Equal(t, original.EventID, redelivery.EventID)
+	assert.Equal(t, original.Payload, redelivery.Payload)
+	assert.Equal(t, storage.WebhookDeliveryStatusPending, redelivery.Status)
+	assert.Equal(t, 0, redelivery.Attempts)
+
+	deliveries, err := svc.ListDeliveries(ctx, "user-1", &storage.WebhookDeliveryFilter{WebhookID: endpoint.WebhookID})
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 2)
+
+	failed, err := svc.ListDeliveries(ctx, "user-1", &storage.WebhookDeliveryFilter{WebhookID: endpoint.WebhookID, Status: storage.WebhookDeliveryStatusFailed})
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, original.DeliveryID, failed[0].DeliveryID)
+}
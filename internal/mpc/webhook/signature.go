@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// webhook 请求头
+const (
+	HeaderWebhookID  = "X-MPC-Webhook-Id"
+	HeaderEventID    = "X-MPC-Event-Id"
+	HeaderEventType  = "X-MPC-Event-Type"
+	HeaderDeliveryID = "X-MPC-Delivery-Id"
+	// HeaderTimestamp 发送时间（Unix 秒），包含在签名中，接收方据此拒绝重放的请求
+	HeaderTimestamp = "X-MPC-Timestamp"
+	// HeaderSignature "sha256=" 加 HMAC-SHA256(secret, timestamp + "." + body) 的 hex
+	HeaderSignature = "X-MPC-Signature"
+)
+
+const signaturePrefix = "sha256="
+
+// Sign 计算请求体的签名（HeaderSignature 的值）
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 接收方校验请求的签名，时间戳与 now 相差超过 tolerance 时视为重放（tolerance 为 0 时不检查时间戳）
+func Verify(secret string, timestamp string, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrap(ErrInvalidSignature, "invalid timestamp")
+	}
+	if tolerance > 0 {
+		sentAt := time.Unix(ts, 0)
+		if now.Sub(sentAt) > tolerance || sentAt.Sub(now) > tolerance {
+			return errors.Wrap(ErrInvalidSignature, "timestamp outside tolerance")
+		}
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return errors.Wrap(ErrInvalidSignature, "unsupported signature scheme")
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
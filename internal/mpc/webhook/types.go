@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"time"
+)
+
+// 事件类型
+const (
+	EventSigningCompleted = "signing.completed"
+	EventSigningFailed    = "signing.failed"
+	EventDKGCompleted     = "dkg.completed"
+	EventSessionFailed    = "session.failed"
+)
+
+// EventTypes 可以订阅的全部事件类型
+var EventTypes = []string{EventSigningCompleted, EventSigningFailed, EventDKGCompleted, EventSessionFailed}
+
+// Endpoint 用户注册的 webhook：只接收 EventTypes 中的事件，KeyID 不为空时只接收该密钥的事件
+type Endpoint struct {
+	WebhookID   string
+	OwnerID     string
+	URL         string
+	Secret      string // HMAC-SHA256 签名密钥，只在注册时返回给用户
+	EventTypes  []string
+	KeyID       string
+	Description string
+	Enabled     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Event 投递给 webhook 的事件（请求体）
+type Event struct {
+	EventID   string                 `json:"id"`
+	EventType string                 `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// Delivery 事件对某个 webhook 的一次投递，失败后按指数退避重试，达到最大次数后为 failed
+type Delivery struct {
+	DeliveryID     string
+	WebhookID      string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Status         string // pending、succeeded、failed
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -489,6 +489,7 @@ type StartDKGRequest struct {
 	Threshold     int32                  `protobuf:"varint,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
 	TotalNodes    int32                  `protobuf:"varint,6,opt,name=total_nodes,json=totalNodes,proto3" json:"total_nodes,omitempty"`
 	NodeIds       []string               `protobuf:"bytes,7,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"` // 参与节点列表
+	Protocol      string                 `protobuf:"bytes,8,opt,name=protocol,proto3" json:"protocol,omitempty"`              // DKG 协议（gg18 / gg20 / frost / cggmp21），为空时按算法推断
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -572,29 +573,593 @@ func (x *StartDKGRequest) GetNodeIds() []string {
 	return nil
 }
 
+func (x *StartDKGRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
 type StartDKGResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Started       bool                   `protobuf:"varint,1,opt,name=started,proto3" json:"started,omitempty"`
+	Started       bool                   `protobuf:"varint,1,opt,name=started,proto3" json:"started,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartDKGResponse) Reset() {
+	*x = StartDKGResponse{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDKGResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDKGResponse) ProtoMessage() {}
+
+func (x *StartDKGResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDKGResponse.ProtoReflect.Descriptor instead.
+func (*StartDKGResponse) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StartDKGResponse) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *StartDKGResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 启动签名 请求/响应
+type StartSignRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // 签名会话ID
+	KeyId             string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Message           []byte                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                         // 要签名的消息
+	MessageHex        string                 `protobuf:"bytes,4,opt,name=message_hex,json=messageHex,proto3" json:"message_hex,omitempty"` // 消息的hex编码（可选）
+	Protocol          string                 `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`                       // "gg18", "gg20", "frost"
+	Threshold         int32                  `protobuf:"varint,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	TotalNodes        int32                  `protobuf:"varint,7,opt,name=total_nodes,json=totalNodes,proto3" json:"total_nodes,omitempty"`
+	NodeIds           []string               `protobuf:"bytes,8,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`                                  // 参与节点列表
+	PresignatureId    string                 `protobuf:"bytes,9,opt,name=presignature_id,json=presignatureId,proto3" json:"presignature_id,omitempty"`             // 预签名ID（可选，GG20/FROST；设置时同步执行单轮在线签名）
+	TaprootKeySpend   bool                   `protobuf:"varint,10,opt,name=taproot_key_spend,json=taprootKeySpend,proto3" json:"taproot_key_spend,omitempty"`      // FROST secp256k1：按 BIP-341 key-path 签名（对调整后的输出公钥签名）
+	TaprootMerkleRoot []byte                 `protobuf:"bytes,11,opt,name=taproot_merkle_root,json=taprootMerkleRoot,proto3" json:"taproot_merkle_root,omitempty"` // Taproot 脚本树根（可选，为空表示 BIP-86 无脚本路径）
+	DerivationPath    string                 `protobuf:"bytes,12,opt,name=derivation_path,json=derivationPath,proto3" json:"derivation_path,omitempty"`            // 非强化 BIP-32 派生路径（可选，如 m/0/5；设置时使用派生的子密钥签名）
+	ChainCode         []byte                 `protobuf:"bytes,13,opt,name=chain_code,json=chainCode,proto3" json:"chain_code,omitempty"`                           // 根密钥链码（derivation_path 非空时必填）
+	Prehashed         bool                   `protobuf:"varint,14,opt,name=prehashed,proto3" json:"prehashed,omitempty"`                                           // message 为 32 字节摘要（EIP-191/EIP-712），ECDSA 引擎直接签名，不再做 SHA-256
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StartSignRequest) Reset() {
+	*x = StartSignRequest{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSignRequest) ProtoMessage() {}
+
+func (x *StartSignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSignRequest.ProtoReflect.Descriptor instead.
+func (*StartSignRequest) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StartSignRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StartSignRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *StartSignRequest) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *StartSignRequest) GetMessageHex() string {
+	if x != nil {
+		return x.MessageHex
+	}
+	return ""
+}
+
+func (x *StartSignRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *StartSignRequest) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *StartSignRequest) GetTotalNodes() int32 {
+	if x != nil {
+		return x.TotalNodes
+	}
+	return 0
+}
+
+func (x *StartSignRequest) GetNodeIds() []string {
+	if x != nil {
+		return x.NodeIds
+	}
+	return nil
+}
+
+func (x *StartSignRequest) GetPresignatureId() string {
+	if x != nil {
+		return x.PresignatureId
+	}
+	return ""
+}
+
+func (x *StartSignRequest) GetTaprootKeySpend() bool {
+	if x != nil {
+		return x.TaprootKeySpend
+	}
+	return false
+}
+
+func (x *StartSignRequest) GetTaprootMerkleRoot() []byte {
+	if x != nil {
+		return x.TaprootMerkleRoot
+	}
+	return nil
+}
+
+func (x *StartSignRequest) GetDerivationPath() string {
+	if x != nil {
+		return x.DerivationPath
+	}
+	return ""
+}
+
+func (x *StartSignRequest) GetChainCode() []byte {
+	if x != nil {
+		return x.ChainCode
+	}
+	return nil
+}
+
+func (x *StartSignRequest) GetPrehashed() bool {
+	if x != nil {
+		return x.Prehashed
+	}
+	return false
+}
+
+type StartSignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Started       bool                   `protobuf:"varint,1,opt,name=started,proto3" json:"started,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Signature     string                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"` // hex encoded，仅使用预签名时返回
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSignResponse) Reset() {
+	*x = StartSignResponse{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSignResponse) ProtoMessage() {}
+
+func (x *StartSignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSignResponse.ProtoReflect.Descriptor instead.
+func (*StartSignResponse) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StartSignResponse) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *StartSignResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StartSignResponse) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+// 预签名 请求/响应
+type StartPresignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // 预签名会话ID（同时作为预签名ID）
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Protocol      string                 `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`              // "gg20" 或 "frost"
+	NodeIds       []string               `protobuf:"bytes,4,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"` // 预签名参与节点列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartPresignRequest) Reset() {
+	*x = StartPresignRequest{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartPresignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPresignRequest) ProtoMessage() {}
+
+func (x *StartPresignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPresignRequest.ProtoReflect.Descriptor instead.
+func (*StartPresignRequest) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StartPresignRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StartPresignRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *StartPresignRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *StartPresignRequest) GetNodeIds() []string {
+	if x != nil {
+		return x.NodeIds
+	}
+	return nil
+}
+
+type StartPresignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartPresignResponse) Reset() {
+	*x = StartPresignResponse{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartPresignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPresignResponse) ProtoMessage() {}
+
+func (x *StartPresignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPresignResponse.ProtoReflect.Descriptor instead.
+func (*StartPresignResponse) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StartPresignResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartPresignResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 分片备份 请求/响应
+type ExportShareBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	ShareEpoch    int32                  `protobuf:"varint,2,opt,name=share_epoch,json=shareEpoch,proto3" json:"share_epoch,omitempty"` // 协调者记录的当前分片轮次，写入备份头部
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportShareBackupRequest) Reset() {
+	*x = ExportShareBackupRequest{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportShareBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportShareBackupRequest) ProtoMessage() {}
+
+func (x *ExportShareBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportShareBackupRequest.ProtoReflect.Descriptor instead.
+func (*ExportShareBackupRequest) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExportShareBackupRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *ExportShareBackupRequest) GetShareEpoch() int32 {
+	if x != nil {
+		return x.ShareEpoch
+	}
+	return 0
+}
+
+type ExportShareBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Backup        []byte                 `protobuf:"bytes,3,opt,name=backup,proto3" json:"backup,omitempty"` // JSON 编码的分片备份（密文 + 公开分片 + 知识证明）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportShareBackupResponse) Reset() {
+	*x = ExportShareBackupResponse{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportShareBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportShareBackupResponse) ProtoMessage() {}
+
+func (x *ExportShareBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportShareBackupResponse.ProtoReflect.Descriptor instead.
+func (*ExportShareBackupResponse) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExportShareBackupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExportShareBackupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExportShareBackupResponse) GetBackup() []byte {
+	if x != nil {
+		return x.Backup
+	}
+	return nil
+}
+
+// 分片持有证明 请求/响应
+type ProveKeyShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	ShareEpoch    int32                  `protobuf:"varint,2,opt,name=share_epoch,json=shareEpoch,proto3" json:"share_epoch,omitempty"` // 协调者记录的当前分片轮次
+	Challenge     []byte                 `protobuf:"bytes,3,opt,name=challenge,proto3" json:"challenge,omitempty"`                      // 协调者生成的 32 字节随机挑战，防止重放旧证明
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProveKeyShareRequest) Reset() {
+	*x = ProveKeyShareRequest{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProveKeyShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProveKeyShareRequest) ProtoMessage() {}
+
+func (x *ProveKeyShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProveKeyShareRequest.ProtoReflect.Descriptor instead.
+func (*ProveKeyShareRequest) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ProveKeyShareRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *ProveKeyShareRequest) GetShareEpoch() int32 {
+	if x != nil {
+		return x.ShareEpoch
+	}
+	return 0
+}
+
+func (x *ProveKeyShareRequest) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+type ProveKeyShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Proof         []byte                 `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"` // JSON 编码的分片持有证明
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartDKGResponse) Reset() {
-	*x = StartDKGResponse{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[7]
+func (x *ProveKeyShareResponse) Reset() {
+	*x = ProveKeyShareResponse{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartDKGResponse) String() string {
+func (x *ProveKeyShareResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartDKGResponse) ProtoMessage() {}
+func (*ProveKeyShareResponse) ProtoMessage() {}
 
-func (x *StartDKGResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[7]
+func (x *ProveKeyShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,55 +1170,160 @@ func (x *StartDKGResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartDKGResponse.ProtoReflect.Descriptor instead.
-func (*StartDKGResponse) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ProveKeyShareResponse.ProtoReflect.Descriptor instead.
+func (*ProveKeyShareResponse) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *StartDKGResponse) GetStarted() bool {
+func (x *ProveKeyShareResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Started
+		return x.Success
 	}
 	return false
 }
 
-func (x *StartDKGResponse) GetMessage() string {
+func (x *ProveKeyShareResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-// 启动签名 请求/响应
-type StartSignRequest struct {
+func (x *ProveKeyShareResponse) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// 分片销毁 请求/响应
+type DestroyKeyShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestroyKeyShareRequest) Reset() {
+	*x = DestroyKeyShareRequest{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestroyKeyShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestroyKeyShareRequest) ProtoMessage() {}
+
+func (x *DestroyKeyShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestroyKeyShareRequest.ProtoReflect.Descriptor instead.
+func (*DestroyKeyShareRequest) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DestroyKeyShareRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type DestroyKeyShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestroyKeyShareResponse) Reset() {
+	*x = DestroyKeyShareResponse{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestroyKeyShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestroyKeyShareResponse) ProtoMessage() {}
+
+func (x *DestroyKeyShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestroyKeyShareResponse.ProtoReflect.Descriptor instead.
+func (*DestroyKeyShareResponse) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DestroyKeyShareResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DestroyKeyShareResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 密钥重分享 请求/响应
+type StartResharingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // 签名会话ID
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // resharing 会话ID
 	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
-	Message       []byte                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                         // 要签名的消息
-	MessageHex    string                 `protobuf:"bytes,4,opt,name=message_hex,json=messageHex,proto3" json:"message_hex,omitempty"` // 消息的hex编码（可选）
-	Protocol      string                 `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`                       // "gg18", "gg20", "frost"
-	Threshold     int32                  `protobuf:"varint,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
-	TotalNodes    int32                  `protobuf:"varint,7,opt,name=total_nodes,json=totalNodes,proto3" json:"total_nodes,omitempty"`
-	NodeIds       []string               `protobuf:"bytes,8,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"` // 参与节点列表
+	Protocol      string                 `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`                         // "gg18", "gg20", "frost"
+	OldNodeIds    []string               `protobuf:"bytes,4,rep,name=old_node_ids,json=oldNodeIds,proto3" json:"old_node_ids,omitempty"` // 旧委员会节点列表
+	OldThreshold  int32                  `protobuf:"varint,5,opt,name=old_threshold,json=oldThreshold,proto3" json:"old_threshold,omitempty"`
+	NewNodeIds    []string               `protobuf:"bytes,6,rep,name=new_node_ids,json=newNodeIds,proto3" json:"new_node_ids,omitempty"` // 新委员会节点列表
+	NewThreshold  int32                  `protobuf:"varint,7,opt,name=new_threshold,json=newThreshold,proto3" json:"new_threshold,omitempty"`
+	OldEpoch      int32                  `protobuf:"varint,8,opt,name=old_epoch,json=oldEpoch,proto3" json:"old_epoch,omitempty"` // 旧分片轮次
+	NewEpoch      int32                  `protobuf:"varint,9,opt,name=new_epoch,json=newEpoch,proto3" json:"new_epoch,omitempty"` // 新分片轮次
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartSignRequest) Reset() {
-	*x = StartSignRequest{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[8]
+func (x *StartResharingRequest) Reset() {
+	*x = StartResharingRequest{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartSignRequest) String() string {
+func (x *StartResharingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartSignRequest) ProtoMessage() {}
+func (*StartResharingRequest) ProtoMessage() {}
 
-func (x *StartSignRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[8]
+func (x *StartResharingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,90 +1334,98 @@ func (x *StartSignRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartSignRequest.ProtoReflect.Descriptor instead.
-func (*StartSignRequest) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use StartResharingRequest.ProtoReflect.Descriptor instead.
+func (*StartResharingRequest) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *StartSignRequest) GetSessionId() string {
+func (x *StartResharingRequest) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-func (x *StartSignRequest) GetKeyId() string {
+func (x *StartResharingRequest) GetKeyId() string {
 	if x != nil {
 		return x.KeyId
 	}
 	return ""
 }
 
-func (x *StartSignRequest) GetMessage() []byte {
+func (x *StartResharingRequest) GetProtocol() string {
 	if x != nil {
-		return x.Message
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *StartResharingRequest) GetOldNodeIds() []string {
+	if x != nil {
+		return x.OldNodeIds
 	}
 	return nil
 }
 
-func (x *StartSignRequest) GetMessageHex() string {
+func (x *StartResharingRequest) GetOldThreshold() int32 {
 	if x != nil {
-		return x.MessageHex
+		return x.OldThreshold
 	}
-	return ""
+	return 0
 }
 
-func (x *StartSignRequest) GetProtocol() string {
+func (x *StartResharingRequest) GetNewNodeIds() []string {
 	if x != nil {
-		return x.Protocol
+		return x.NewNodeIds
 	}
-	return ""
+	return nil
 }
 
-func (x *StartSignRequest) GetThreshold() int32 {
+func (x *StartResharingRequest) GetNewThreshold() int32 {
 	if x != nil {
-		return x.Threshold
+		return x.NewThreshold
 	}
 	return 0
 }
 
-func (x *StartSignRequest) GetTotalNodes() int32 {
+func (x *StartResharingRequest) GetOldEpoch() int32 {
 	if x != nil {
-		return x.TotalNodes
+		return x.OldEpoch
 	}
 	return 0
 }
 
-func (x *StartSignRequest) GetNodeIds() []string {
+func (x *StartResharingRequest) GetNewEpoch() int32 {
 	if x != nil {
-		return x.NodeIds
+		return x.NewEpoch
 	}
-	return nil
+	return 0
 }
 
-type StartSignResponse struct {
+type StartResharingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Started       bool                   `protobuf:"varint,1,opt,name=started,proto3" json:"started,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	PublicKey     string                 `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"` // hex encoded，用于协调者校验公钥未变化
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartSignResponse) Reset() {
-	*x = StartSignResponse{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[9]
+func (x *StartResharingResponse) Reset() {
+	*x = StartResharingResponse{}
+	mi := &file_mpc_v1_mpc_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartSignResponse) String() string {
+func (x *StartResharingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartSignResponse) ProtoMessage() {}
+func (*StartResharingResponse) ProtoMessage() {}
 
-func (x *StartSignResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[9]
+func (x *StartResharingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpc_v1_mpc_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -758,25 +1436,32 @@ func (x *StartSignResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartSignResponse.ProtoReflect.Descriptor instead.
-func (*StartSignResponse) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use StartResharingResponse.ProtoReflect.Descriptor instead.
+func (*StartResharingResponse) Descriptor() ([]byte, []int) {
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *StartSignResponse) GetStarted() bool {
+func (x *StartResharingResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Started
+		return x.Success
 	}
 	return false
 }
 
-func (x *StartSignResponse) GetMessage() string {
+func (x *StartResharingResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
+func (x *StartResharingResponse) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
 // 签名聚合相关消息
 type AggregateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -787,7 +1472,7 @@ type AggregateRequest struct {
 
 func (x *AggregateRequest) Reset() {
 	*x = AggregateRequest{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[10]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -799,7 +1484,7 @@ func (x *AggregateRequest) String() string {
 func (*AggregateRequest) ProtoMessage() {}
 
 func (x *AggregateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[10]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -812,7 +1497,7 @@ func (x *AggregateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregateRequest.ProtoReflect.Descriptor instead.
 func (*AggregateRequest) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{10}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *AggregateRequest) GetSessionId() string {
@@ -835,7 +1520,7 @@ type AggregateResponse struct {
 
 func (x *AggregateResponse) Reset() {
 	*x = AggregateResponse{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[11]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -847,7 +1532,7 @@ func (x *AggregateResponse) String() string {
 func (*AggregateResponse) ProtoMessage() {}
 
 func (x *AggregateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[11]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +1545,7 @@ func (x *AggregateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregateResponse.ProtoReflect.Descriptor instead.
 func (*AggregateResponse) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{11}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *AggregateResponse) GetSuccess() bool {
@@ -917,7 +1602,7 @@ type SessionMessage struct {
 
 func (x *SessionMessage) Reset() {
 	*x = SessionMessage{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[12]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -929,7 +1614,7 @@ func (x *SessionMessage) String() string {
 func (*SessionMessage) ProtoMessage() {}
 
 func (x *SessionMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[12]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -942,7 +1627,7 @@ func (x *SessionMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SessionMessage.ProtoReflect.Descriptor instead.
 func (*SessionMessage) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{12}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *SessionMessage) GetMessageType() isSessionMessage_MessageType {
@@ -1074,7 +1759,7 @@ type JoinRequest struct {
 
 func (x *JoinRequest) Reset() {
 	*x = JoinRequest{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[13]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1086,7 +1771,7 @@ func (x *JoinRequest) String() string {
 func (*JoinRequest) ProtoMessage() {}
 
 func (x *JoinRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[13]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1099,7 +1784,7 @@ func (x *JoinRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
 func (*JoinRequest) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{13}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *JoinRequest) GetSessionId() string {
@@ -1134,7 +1819,7 @@ type ShareMessage struct {
 
 func (x *ShareMessage) Reset() {
 	*x = ShareMessage{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[14]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1146,7 +1831,7 @@ func (x *ShareMessage) String() string {
 func (*ShareMessage) ProtoMessage() {}
 
 func (x *ShareMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[14]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1159,7 +1844,7 @@ func (x *ShareMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShareMessage.ProtoReflect.Descriptor instead.
 func (*ShareMessage) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{14}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ShareMessage) GetShareData() []byte {
@@ -1198,7 +1883,7 @@ type SessionConfirmation struct {
 
 func (x *SessionConfirmation) Reset() {
 	*x = SessionConfirmation{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[15]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1210,7 +1895,7 @@ func (x *SessionConfirmation) String() string {
 func (*SessionConfirmation) ProtoMessage() {}
 
 func (x *SessionConfirmation) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[15]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1223,7 +1908,7 @@ func (x *SessionConfirmation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SessionConfirmation.ProtoReflect.Descriptor instead.
 func (*SessionConfirmation) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{15}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SessionConfirmation) GetSessionId() string {
@@ -1288,7 +1973,7 @@ type RoundMessage struct {
 
 func (x *RoundMessage) Reset() {
 	*x = RoundMessage{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[16]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1300,7 +1985,7 @@ func (x *RoundMessage) String() string {
 func (*RoundMessage) ProtoMessage() {}
 
 func (x *RoundMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[16]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1313,7 +1998,7 @@ func (x *RoundMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoundMessage.ProtoReflect.Descriptor instead.
 func (*RoundMessage) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{16}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *RoundMessage) GetRound() int32 {
@@ -1362,7 +2047,7 @@ type CompletionMessage struct {
 
 func (x *CompletionMessage) Reset() {
 	*x = CompletionMessage{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[17]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1374,7 +2059,7 @@ func (x *CompletionMessage) String() string {
 func (*CompletionMessage) ProtoMessage() {}
 
 func (x *CompletionMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[17]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1387,7 +2072,7 @@ func (x *CompletionMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompletionMessage.ProtoReflect.Descriptor instead.
 func (*CompletionMessage) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{17}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *CompletionMessage) GetSignature() string {
@@ -1423,7 +2108,7 @@ type ErrorMessage struct {
 
 func (x *ErrorMessage) Reset() {
 	*x = ErrorMessage{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[18]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1435,7 +2120,7 @@ func (x *ErrorMessage) String() string {
 func (*ErrorMessage) ProtoMessage() {}
 
 func (x *ErrorMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[18]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1448,7 +2133,7 @@ func (x *ErrorMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorMessage.ProtoReflect.Descriptor instead.
 func (*ErrorMessage) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{18}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ErrorMessage) GetErrorCode() string {
@@ -1491,7 +2176,7 @@ type HeartbeatRequest struct {
 
 func (x *HeartbeatRequest) Reset() {
 	*x = HeartbeatRequest{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[19]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1503,7 +2188,7 @@ func (x *HeartbeatRequest) String() string {
 func (*HeartbeatRequest) ProtoMessage() {}
 
 func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[19]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1516,7 +2201,7 @@ func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
 func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{19}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *HeartbeatRequest) GetNodeId() string {
@@ -1552,7 +2237,7 @@ type HeartbeatResponse struct {
 
 func (x *HeartbeatResponse) Reset() {
 	*x = HeartbeatResponse{}
-	mi := &file_mpc_v1_mpc_proto_msgTypes[20]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1564,7 +2249,7 @@ func (x *HeartbeatResponse) String() string {
 func (*HeartbeatResponse) ProtoMessage() {}
 
 func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mpc_v1_mpc_proto_msgTypes[20]
+	mi := &file_mpc_v1_mpc_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1577,7 +2262,7 @@ func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
 func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{20}
+	return file_mpc_v1_mpc_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *HeartbeatResponse) GetAlive() bool {
@@ -1659,7 +2344,7 @@ const file_mpc_v1_mpc_proto_rawDesc = "" +
 	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
 	"\n" +
-	"next_round\x18\x03 \x01(\x05R\tnextRound\"\xd5\x01\n" +
+	"next_round\x18\x03 \x01(\x05R\tnextRound\"\xf1\x01\n" +
 	"\x0fStartDKGRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x15\n" +
@@ -1669,10 +2354,11 @@ const file_mpc_v1_mpc_proto_rawDesc = "" +
 	"\tthreshold\x18\x05 \x01(\x05R\tthreshold\x12\x1f\n" +
 	"\vtotal_nodes\x18\x06 \x01(\x05R\n" +
 	"totalNodes\x12\x19\n" +
-	"\bnode_ids\x18\a \x03(\tR\anodeIds\"F\n" +
+	"\bnode_ids\x18\a \x03(\tR\anodeIds\x12\x1a\n" +
+	"\bprotocol\x18\b \x01(\tR\bprotocol\"F\n" +
 	"\x10StartDKGResponse\x12\x18\n" +
 	"\astarted\x18\x01 \x01(\bR\astarted\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xf9\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xe4\x03\n" +
 	"\x10StartSignRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x15\n" +
@@ -1684,10 +2370,68 @@ const file_mpc_v1_mpc_proto_rawDesc = "" +
 	"\tthreshold\x18\x06 \x01(\x05R\tthreshold\x12\x1f\n" +
 	"\vtotal_nodes\x18\a \x01(\x05R\n" +
 	"totalNodes\x12\x19\n" +
-	"\bnode_ids\x18\b \x03(\tR\anodeIds\"G\n" +
+	"\bnode_ids\x18\b \x03(\tR\anodeIds\x12'\n" +
+	"\x0fpresignature_id\x18\t \x01(\tR\x0epresignatureId\x12*\n" +
+	"\x11taproot_key_spend\x18\n" +
+	" \x01(\bR\x0ftaprootKeySpend\x12.\n" +
+	"\x13taproot_merkle_root\x18\v \x01(\fR\x11taprootMerkleRoot\x12'\n" +
+	"\x0fderivation_path\x18\f \x01(\tR\x0ederivationPath\x12\x1d\n" +
+	"\n" +
+	"chain_code\x18\r \x01(\fR\tchainCode\x12\x1c\n" +
+	"\tprehashed\x18\x0e \x01(\bR\tprehashed\"e\n" +
 	"\x11StartSignResponse\x12\x18\n" +
 	"\astarted\x18\x01 \x01(\bR\astarted\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"1\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\tR\tsignature\"\x82\x01\n" +
+	"\x13StartPresignRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x12\x1a\n" +
+	"\bprotocol\x18\x03 \x01(\tR\bprotocol\x12\x19\n" +
+	"\bnode_ids\x18\x04 \x03(\tR\anodeIds\"J\n" +
+	"\x14StartPresignResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"R\n" +
+	"\x18ExportShareBackupRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x1f\n" +
+	"\vshare_epoch\x18\x02 \x01(\x05R\n" +
+	"shareEpoch\"g\n" +
+	"\x19ExportShareBackupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06backup\x18\x03 \x01(\fR\x06backup\"l\n" +
+	"\x14ProveKeyShareRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x1f\n" +
+	"\vshare_epoch\x18\x02 \x01(\x05R\n" +
+	"shareEpoch\x12\x1c\n" +
+	"\tchallenge\x18\x03 \x01(\fR\tchallenge\"a\n" +
+	"\x15ProveKeyShareResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05proof\x18\x03 \x01(\fR\x05proof\"/\n" +
+	"\x16DestroyKeyShareRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"M\n" +
+	"\x17DestroyKeyShareResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb1\x02\n" +
+	"\x15StartResharingRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x12\x1a\n" +
+	"\bprotocol\x18\x03 \x01(\tR\bprotocol\x12 \n" +
+	"\fold_node_ids\x18\x04 \x03(\tR\n" +
+	"oldNodeIds\x12#\n" +
+	"\rold_threshold\x18\x05 \x01(\x05R\foldThreshold\x12 \n" +
+	"\fnew_node_ids\x18\x06 \x03(\tR\n" +
+	"newNodeIds\x12#\n" +
+	"\rnew_threshold\x18\a \x01(\x05R\fnewThreshold\x12\x1b\n" +
+	"\told_epoch\x18\b \x01(\x05R\boldEpoch\x12\x1b\n" +
+	"\tnew_epoch\x18\t \x01(\x05R\bnewEpoch\"k\n" +
+	"\x16StartResharingResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\tR\tpublicKey\"1\n" +
 	"\x10AggregateRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\"\xb2\x01\n" +
@@ -1762,11 +2506,16 @@ const file_mpc_v1_mpc_proto_rawDesc = "" +
 	"\finstructions\x18\x04 \x03(\v2+.mpc.v1.HeartbeatResponse.InstructionsEntryR\finstructions\x1a?\n" +
 	"\x11InstructionsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xdb\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xf3\x05\n" +
 	"\aMPCNode\x12H\n" +
 	"\x12JoinSigningSession\x12\x16.mpc.v1.SessionMessage\x1a\x16.mpc.v1.SessionMessage(\x010\x01\x12=\n" +
 	"\bStartDKG\x12\x17.mpc.v1.StartDKGRequest\x1a\x18.mpc.v1.StartDKGResponse\x12@\n" +
-	"\tStartSign\x12\x18.mpc.v1.StartSignRequest\x1a\x19.mpc.v1.StartSignResponse\x12C\n" +
+	"\tStartSign\x12\x18.mpc.v1.StartSignRequest\x1a\x19.mpc.v1.StartSignResponse\x12O\n" +
+	"\x0eStartResharing\x12\x1d.mpc.v1.StartResharingRequest\x1a\x1e.mpc.v1.StartResharingResponse\x12I\n" +
+	"\fStartPresign\x12\x1b.mpc.v1.StartPresignRequest\x1a\x1c.mpc.v1.StartPresignResponse\x12X\n" +
+	"\x11ExportShareBackup\x12 .mpc.v1.ExportShareBackupRequest\x1a!.mpc.v1.ExportShareBackupResponse\x12L\n" +
+	"\rProveKeyShare\x12\x1c.mpc.v1.ProveKeyShareRequest\x1a\x1d.mpc.v1.ProveKeyShareResponse\x12R\n" +
+	"\x0fDestroyKeyShare\x12\x1e.mpc.v1.DestroyKeyShareRequest\x1a\x1f.mpc.v1.DestroyKeyShareResponse\x12C\n" +
 	"\x14SubmitSignatureShare\x12\x14.mpc.v1.ShareRequest\x1a\x15.mpc.v1.ShareResponse\x12@\n" +
 	"\tHeartbeat\x12\x18.mpc.v1.HeartbeatRequest\x1a\x19.mpc.v1.HeartbeatResponse2\x82\x02\n" +
 	"\x0eMPCCoordinator\x12S\n" +
@@ -1786,60 +2535,80 @@ func file_mpc_v1_mpc_proto_rawDescGZIP() []byte {
 	return file_mpc_v1_mpc_proto_rawDescData
 }
 
-var file_mpc_v1_mpc_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_mpc_v1_mpc_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_mpc_v1_mpc_proto_goTypes = []any{
-	(*CreateSessionRequest)(nil),  // 0: mpc.v1.CreateSessionRequest
-	(*CreateSessionResponse)(nil), // 1: mpc.v1.CreateSessionResponse
-	(*SessionStatusRequest)(nil),  // 2: mpc.v1.SessionStatusRequest
-	(*SessionStatusResponse)(nil), // 3: mpc.v1.SessionStatusResponse
-	(*ShareRequest)(nil),          // 4: mpc.v1.ShareRequest
-	(*ShareResponse)(nil),         // 5: mpc.v1.ShareResponse
-	(*StartDKGRequest)(nil),       // 6: mpc.v1.StartDKGRequest
-	(*StartDKGResponse)(nil),      // 7: mpc.v1.StartDKGResponse
-	(*StartSignRequest)(nil),      // 8: mpc.v1.StartSignRequest
-	(*StartSignResponse)(nil),     // 9: mpc.v1.StartSignResponse
-	(*AggregateRequest)(nil),      // 10: mpc.v1.AggregateRequest
-	(*AggregateResponse)(nil),     // 11: mpc.v1.AggregateResponse
-	(*SessionMessage)(nil),        // 12: mpc.v1.SessionMessage
-	(*JoinRequest)(nil),           // 13: mpc.v1.JoinRequest
-	(*ShareMessage)(nil),          // 14: mpc.v1.ShareMessage
-	(*SessionConfirmation)(nil),   // 15: mpc.v1.SessionConfirmation
-	(*RoundMessage)(nil),          // 16: mpc.v1.RoundMessage
-	(*CompletionMessage)(nil),     // 17: mpc.v1.CompletionMessage
-	(*ErrorMessage)(nil),          // 18: mpc.v1.ErrorMessage
-	(*HeartbeatRequest)(nil),      // 19: mpc.v1.HeartbeatRequest
-	(*HeartbeatResponse)(nil),     // 20: mpc.v1.HeartbeatResponse
-	nil,                           // 21: mpc.v1.HeartbeatRequest.StatusInfoEntry
-	nil,                           // 22: mpc.v1.HeartbeatResponse.InstructionsEntry
+	(*CreateSessionRequest)(nil),      // 0: mpc.v1.CreateSessionRequest
+	(*CreateSessionResponse)(nil),     // 1: mpc.v1.CreateSessionResponse
+	(*SessionStatusRequest)(nil),      // 2: mpc.v1.SessionStatusRequest
+	(*SessionStatusResponse)(nil),     // 3: mpc.v1.SessionStatusResponse
+	(*ShareRequest)(nil),              // 4: mpc.v1.ShareRequest
+	(*ShareResponse)(nil),             // 5: mpc.v1.ShareResponse
+	(*StartDKGRequest)(nil),           // 6: mpc.v1.StartDKGRequest
+	(*StartDKGResponse)(nil),          // 7: mpc.v1.StartDKGResponse
+	(*StartSignRequest)(nil),          // 8: mpc.v1.StartSignRequest
+	(*StartSignResponse)(nil),         // 9: mpc.v1.StartSignResponse
+	(*StartPresignRequest)(nil),       // 10: mpc.v1.StartPresignRequest
+	(*StartPresignResponse)(nil),      // 11: mpc.v1.StartPresignResponse
+	(*ExportShareBackupRequest)(nil),  // 12: mpc.v1.ExportShareBackupRequest
+	(*ExportShareBackupResponse)(nil), // 13: mpc.v1.ExportShareBackupResponse
+	(*ProveKeyShareRequest)(nil),      // 14: mpc.v1.ProveKeyShareRequest
+	(*ProveKeyShareResponse)(nil),     // 15: mpc.v1.ProveKeyShareResponse
+	(*DestroyKeyShareRequest)(nil),    // 16: mpc.v1.DestroyKeyShareRequest
+	(*DestroyKeyShareResponse)(nil),   // 17: mpc.v1.DestroyKeyShareResponse
+	(*StartResharingRequest)(nil),     // 18: mpc.v1.StartResharingRequest
+	(*StartResharingResponse)(nil),    // 19: mpc.v1.StartResharingResponse
+	(*AggregateRequest)(nil),          // 20: mpc.v1.AggregateRequest
+	(*AggregateResponse)(nil),         // 21: mpc.v1.AggregateResponse
+	(*SessionMessage)(nil),            // 22: mpc.v1.SessionMessage
+	(*JoinRequest)(nil),               // 23: mpc.v1.JoinRequest
+	(*ShareMessage)(nil),              // 24: mpc.v1.ShareMessage
+	(*SessionConfirmation)(nil),       // 25: mpc.v1.SessionConfirmation
+	(*RoundMessage)(nil),              // 26: mpc.v1.RoundMessage
+	(*CompletionMessage)(nil),         // 27: mpc.v1.CompletionMessage
+	(*ErrorMessage)(nil),              // 28: mpc.v1.ErrorMessage
+	(*HeartbeatRequest)(nil),          // 29: mpc.v1.HeartbeatRequest
+	(*HeartbeatResponse)(nil),         // 30: mpc.v1.HeartbeatResponse
+	nil,                               // 31: mpc.v1.HeartbeatRequest.StatusInfoEntry
+	nil,                               // 32: mpc.v1.HeartbeatResponse.InstructionsEntry
 }
 var file_mpc_v1_mpc_proto_depIdxs = []int32{
-	13, // 0: mpc.v1.SessionMessage.join_request:type_name -> mpc.v1.JoinRequest
-	14, // 1: mpc.v1.SessionMessage.share_message:type_name -> mpc.v1.ShareMessage
-	19, // 2: mpc.v1.SessionMessage.heartbeat_request:type_name -> mpc.v1.HeartbeatRequest
-	15, // 3: mpc.v1.SessionMessage.confirmation:type_name -> mpc.v1.SessionConfirmation
-	16, // 4: mpc.v1.SessionMessage.round_message:type_name -> mpc.v1.RoundMessage
-	17, // 5: mpc.v1.SessionMessage.completion_message:type_name -> mpc.v1.CompletionMessage
-	18, // 6: mpc.v1.SessionMessage.error_message:type_name -> mpc.v1.ErrorMessage
-	21, // 7: mpc.v1.HeartbeatRequest.status_info:type_name -> mpc.v1.HeartbeatRequest.StatusInfoEntry
-	22, // 8: mpc.v1.HeartbeatResponse.instructions:type_name -> mpc.v1.HeartbeatResponse.InstructionsEntry
-	12, // 9: mpc.v1.MPCNode.JoinSigningSession:input_type -> mpc.v1.SessionMessage
+	23, // 0: mpc.v1.SessionMessage.join_request:type_name -> mpc.v1.JoinRequest
+	24, // 1: mpc.v1.SessionMessage.share_message:type_name -> mpc.v1.ShareMessage
+	29, // 2: mpc.v1.SessionMessage.heartbeat_request:type_name -> mpc.v1.HeartbeatRequest
+	25, // 3: mpc.v1.SessionMessage.confirmation:type_name -> mpc.v1.SessionConfirmation
+	26, // 4: mpc.v1.SessionMessage.round_message:type_name -> mpc.v1.RoundMessage
+	27, // 5: mpc.v1.SessionMessage.completion_message:type_name -> mpc.v1.CompletionMessage
+	28, // 6: mpc.v1.SessionMessage.error_message:type_name -> mpc.v1.ErrorMessage
+	31, // 7: mpc.v1.HeartbeatRequest.status_info:type_name -> mpc.v1.HeartbeatRequest.StatusInfoEntry
+	32, // 8: mpc.v1.HeartbeatResponse.instructions:type_name -> mpc.v1.HeartbeatResponse.InstructionsEntry
+	22, // 9: mpc.v1.MPCNode.JoinSigningSession:input_type -> mpc.v1.SessionMessage
 	6,  // 10: mpc.v1.MPCNode.StartDKG:input_type -> mpc.v1.StartDKGRequest
 	8,  // 11: mpc.v1.MPCNode.StartSign:input_type -> mpc.v1.StartSignRequest
-	4,  // 12: mpc.v1.MPCNode.SubmitSignatureShare:input_type -> mpc.v1.ShareRequest
-	19, // 13: mpc.v1.MPCNode.Heartbeat:input_type -> mpc.v1.HeartbeatRequest
-	0,  // 14: mpc.v1.MPCCoordinator.CreateSigningSession:input_type -> mpc.v1.CreateSessionRequest
-	2,  // 15: mpc.v1.MPCCoordinator.GetSessionStatus:input_type -> mpc.v1.SessionStatusRequest
-	10, // 16: mpc.v1.MPCCoordinator.AggregateSignatures:input_type -> mpc.v1.AggregateRequest
-	12, // 17: mpc.v1.MPCNode.JoinSigningSession:output_type -> mpc.v1.SessionMessage
-	7,  // 18: mpc.v1.MPCNode.StartDKG:output_type -> mpc.v1.StartDKGResponse
-	9,  // 19: mpc.v1.MPCNode.StartSign:output_type -> mpc.v1.StartSignResponse
-	5,  // 20: mpc.v1.MPCNode.SubmitSignatureShare:output_type -> mpc.v1.ShareResponse
-	20, // 21: mpc.v1.MPCNode.Heartbeat:output_type -> mpc.v1.HeartbeatResponse
-	1,  // 22: mpc.v1.MPCCoordinator.CreateSigningSession:output_type -> mpc.v1.CreateSessionResponse
-	3,  // 23: mpc.v1.MPCCoordinator.GetSessionStatus:output_type -> mpc.v1.SessionStatusResponse
-	11, // 24: mpc.v1.MPCCoordinator.AggregateSignatures:output_type -> mpc.v1.AggregateResponse
-	17, // [17:25] is the sub-list for method output_type
-	9,  // [9:17] is the sub-list for method input_type
+	18, // 12: mpc.v1.MPCNode.StartResharing:input_type -> mpc.v1.StartResharingRequest
+	10, // 13: mpc.v1.MPCNode.StartPresign:input_type -> mpc.v1.StartPresignRequest
+	12, // 14: mpc.v1.MPCNode.ExportShareBackup:input_type -> mpc.v1.ExportShareBackupRequest
+	14, // 15: mpc.v1.MPCNode.ProveKeyShare:input_type -> mpc.v1.ProveKeyShareRequest
+	16, // 16: mpc.v1.MPCNode.DestroyKeyShare:input_type -> mpc.v1.DestroyKeyShareRequest
+	4,  // 17: mpc.v1.MPCNode.SubmitSignatureShare:input_type -> mpc.v1.ShareRequest
+	29, // 18: mpc.v1.MPCNode.Heartbeat:input_type -> mpc.v1.HeartbeatRequest
+	0,  // 19: mpc.v1.MPCCoordinator.CreateSigningSession:input_type -> mpc.v1.CreateSessionRequest
+	2,  // 20: mpc.v1.MPCCoordinator.GetSessionStatus:input_type -> mpc.v1.SessionStatusRequest
+	20, // 21: mpc.v1.MPCCoordinator.AggregateSignatures:input_type -> mpc.v1.AggregateRequest
+	22, // 22: mpc.v1.MPCNode.JoinSigningSession:output_type -> mpc.v1.SessionMessage
+	7,  // 23: mpc.v1.MPCNode.StartDKG:output_type -> mpc.v1.StartDKGResponse
+	9,  // 24: mpc.v1.MPCNode.StartSign:output_type -> mpc.v1.StartSignResponse
+	19, // 25: mpc.v1.MPCNode.StartResharing:output_type -> mpc.v1.StartResharingResponse
+	11, // 26: mpc.v1.MPCNode.StartPresign:output_type -> mpc.v1.StartPresignResponse
+	13, // 27: mpc.v1.MPCNode.ExportShareBackup:output_type -> mpc.v1.ExportShareBackupResponse
+	15, // 28: mpc.v1.MPCNode.ProveKeyShare:output_type -> mpc.v1.ProveKeyShareResponse
+	17, // 29: mpc.v1.MPCNode.DestroyKeyShare:output_type -> mpc.v1.DestroyKeyShareResponse
+	5,  // 30: mpc.v1.MPCNode.SubmitSignatureShare:output_type -> mpc.v1.ShareResponse
+	30, // 31: mpc.v1.MPCNode.Heartbeat:output_type -> mpc.v1.HeartbeatResponse
+	1,  // 32: mpc.v1.MPCCoordinator.CreateSigningSession:output_type -> mpc.v1.CreateSessionResponse
+	3,  // 33: mpc.v1.MPCCoordinator.GetSessionStatus:output_type -> mpc.v1.SessionStatusResponse
+	21, // 34: mpc.v1.MPCCoordinator.AggregateSignatures:output_type -> mpc.v1.AggregateResponse
+	22, // [22:35] is the sub-list for method output_type
+	9,  // [9:22] is the sub-list for method input_type
 	9,  // [9:9] is the sub-list for extension type_name
 	9,  // [9:9] is the sub-list for extension extendee
 	0,  // [0:9] is the sub-list for field type_name
@@ -1850,7 +2619,7 @@ func file_mpc_v1_mpc_proto_init() {
 	if File_mpc_v1_mpc_proto != nil {
 		return
 	}
-	file_mpc_v1_mpc_proto_msgTypes[12].OneofWrappers = []any{
+	file_mpc_v1_mpc_proto_msgTypes[22].OneofWrappers = []any{
 		(*SessionMessage_JoinRequest)(nil),
 		(*SessionMessage_ShareMessage)(nil),
 		(*SessionMessage_HeartbeatRequest)(nil),
@@ -1865,7 +2634,7 @@ func file_mpc_v1_mpc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mpc_v1_mpc_proto_rawDesc), len(file_mpc_v1_mpc_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -22,6 +22,11 @@ const (
 	MPCNode_JoinSigningSession_FullMethodName   = "/mpc.v1.MPCNode/JoinSigningSession"
 	MPCNode_StartDKG_FullMethodName             = "/mpc.v1.MPCNode/StartDKG"
 	MPCNode_StartSign_FullMethodName            = "/mpc.v1.MPCNode/StartSign"
+	MPCNode_StartResharing_FullMethodName       = "/mpc.v1.MPCNode/StartResharing"
+	MPCNode_StartPresign_FullMethodName         = "/mpc.v1.MPCNode/StartPresign"
+	MPCNode_ExportShareBackup_FullMethodName    = "/mpc.v1.MPCNode/ExportShareBackup"
+	MPCNode_ProveKeyShare_FullMethodName        = "/mpc.v1.MPCNode/ProveKeyShare"
+	MPCNode_DestroyKeyShare_FullMethodName      = "/mpc.v1.MPCNode/DestroyKeyShare"
 	MPCNode_SubmitSignatureShare_FullMethodName = "/mpc.v1.MPCNode/SubmitSignatureShare"
 	MPCNode_Heartbeat_FullMethodName            = "/mpc.v1.MPCNode/Heartbeat"
 )
@@ -38,6 +43,16 @@ type MPCNodeClient interface {
 	StartDKG(ctx context.Context, in *StartDKGRequest, opts ...grpc.CallOption) (*StartDKGResponse, error)
 	// 启动签名（由协调者调用参与者）
 	StartSign(ctx context.Context, in *StartSignRequest, opts ...grpc.CallOption) (*StartSignResponse, error)
+	// 执行密钥重分享（由协调者调用新旧委员会的所有节点，完成后返回）
+	StartResharing(ctx context.Context, in *StartResharingRequest, opts ...grpc.CallOption) (*StartResharingResponse, error)
+	// 生成预签名（GG20 离线阶段或 FROST nonce 承诺；由协调者调用预签名的所有参与节点，完成后返回）
+	StartPresign(ctx context.Context, in *StartPresignRequest, opts ...grpc.CallOption) (*StartPresignResponse, error)
+	// 导出本节点密钥分片的加密备份（加密到本节点配置的离线恢复公钥）
+	ExportShareBackup(ctx context.Context, in *ExportShareBackupRequest, opts ...grpc.CallOption) (*ExportShareBackupResponse, error)
+	// 证明本节点持有密钥分片（返回公开分片、公开分片向量和对协调者挑战的 Schnorr 知识证明）
+	ProveKeyShare(ctx context.Context, in *ProveKeyShareRequest, opts ...grpc.CallOption) (*ProveKeyShareResponse, error)
+	// 销毁本节点的密钥分片（密钥删除等待期结束后由协调者调用）
+	DestroyKeyShare(ctx context.Context, in *DestroyKeyShareRequest, opts ...grpc.CallOption) (*DestroyKeyShareResponse, error)
 	// 提交签名分片
 	SubmitSignatureShare(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ShareResponse, error)
 	// 心跳检测
@@ -85,6 +100,56 @@ func (c *mPCNodeClient) StartSign(ctx context.Context, in *StartSignRequest, opt
 	return out, nil
 }
 
+func (c *mPCNodeClient) StartResharing(ctx context.Context, in *StartResharingRequest, opts ...grpc.CallOption) (*StartResharingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartResharingResponse)
+	err := c.cc.Invoke(ctx, MPCNode_StartResharing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mPCNodeClient) StartPresign(ctx context.Context, in *StartPresignRequest, opts ...grpc.CallOption) (*StartPresignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartPresignResponse)
+	err := c.cc.Invoke(ctx, MPCNode_StartPresign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mPCNodeClient) ExportShareBackup(ctx context.Context, in *ExportShareBackupRequest, opts ...grpc.CallOption) (*ExportShareBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportShareBackupResponse)
+	err := c.cc.Invoke(ctx, MPCNode_ExportShareBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mPCNodeClient) ProveKeyShare(ctx context.Context, in *ProveKeyShareRequest, opts ...grpc.CallOption) (*ProveKeyShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProveKeyShareResponse)
+	err := c.cc.Invoke(ctx, MPCNode_ProveKeyShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mPCNodeClient) DestroyKeyShare(ctx context.Context, in *DestroyKeyShareRequest, opts ...grpc.CallOption) (*DestroyKeyShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DestroyKeyShareResponse)
+	err := c.cc.Invoke(ctx, MPCNode_DestroyKeyShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mPCNodeClient) SubmitSignatureShare(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*ShareResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ShareResponse)
@@ -117,6 +182,16 @@ type MPCNodeServer interface {
 	StartDKG(context.Context, *StartDKGRequest) (*StartDKGResponse, error)
 	// 启动签名（由协调者调用参与者）
 	StartSign(context.Context, *StartSignRequest) (*StartSignResponse, error)
+	// 执行密钥重分享（由协调者调用新旧委员会的所有节点，完成后返回）
+	StartResharing(context.Context, *StartResharingRequest) (*StartResharingResponse, error)
+	// 生成预签名（GG20 离线阶段或 FROST nonce 承诺；由协调者调用预签名的所有参与节点，完成后返回）
+	StartPresign(context.Context, *StartPresignRequest) (*StartPresignResponse, error)
+	// 导出本节点密钥分片的加密备份（加密到本节点配置的离线恢复公钥）
+	ExportShareBackup(context.Context, *ExportShareBackupRequest) (*ExportShareBackupResponse, error)
+	// 证明本节点持有密钥分片（返回公开分片、公开分片向量和对协调者挑战的 Schnorr 知识证明）
+	ProveKeyShare(context.Context, *ProveKeyShareRequest) (*ProveKeyShareResponse, error)
+	// 销毁本节点的密钥分片（密钥删除等待期结束后由协调者调用）
+	DestroyKeyShare(context.Context, *DestroyKeyShareRequest) (*DestroyKeyShareResponse, error)
 	// 提交签名分片
 	SubmitSignatureShare(context.Context, *ShareRequest) (*ShareResponse, error)
 	// 心跳检测
@@ -140,6 +215,21 @@ func (UnimplementedMPCNodeServer) StartDKG(context.Context, *StartDKGRequest) (*
 func (UnimplementedMPCNodeServer) StartSign(context.Context, *StartSignRequest) (*StartSignResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method StartSign not implemented")
 }
+func (UnimplementedMPCNodeServer) StartResharing(context.Context, *StartResharingRequest) (*StartResharingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartResharing not implemented")
+}
+func (UnimplementedMPCNodeServer) StartPresign(context.Context, *StartPresignRequest) (*StartPresignResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartPresign not implemented")
+}
+func (UnimplementedMPCNodeServer) ExportShareBackup(context.Context, *ExportShareBackupRequest) (*ExportShareBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportShareBackup not implemented")
+}
+func (UnimplementedMPCNodeServer) ProveKeyShare(context.Context, *ProveKeyShareRequest) (*ProveKeyShareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProveKeyShare not implemented")
+}
+func (UnimplementedMPCNodeServer) DestroyKeyShare(context.Context, *DestroyKeyShareRequest) (*DestroyKeyShareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DestroyKeyShare not implemented")
+}
 func (UnimplementedMPCNodeServer) SubmitSignatureShare(context.Context, *ShareRequest) (*ShareResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SubmitSignatureShare not implemented")
 }
@@ -210,6 +300,96 @@ func _MPCNode_StartSign_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MPCNode_StartResharing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartResharingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MPCNodeServer).StartResharing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MPCNode_StartResharing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MPCNodeServer).StartResharing(ctx, req.(*StartResharingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MPCNode_StartPresign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPresignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MPCNodeServer).StartPresign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MPCNode_StartPresign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MPCNodeServer).StartPresign(ctx, req.(*StartPresignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MPCNode_ExportShareBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportShareBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MPCNodeServer).ExportShareBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MPCNode_ExportShareBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MPCNodeServer).ExportShareBackup(ctx, req.(*ExportShareBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MPCNode_ProveKeyShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProveKeyShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MPCNodeServer).ProveKeyShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MPCNode_ProveKeyShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MPCNodeServer).ProveKeyShare(ctx, req.(*ProveKeyShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MPCNode_DestroyKeyShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DestroyKeyShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MPCNodeServer).DestroyKeyShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MPCNode_DestroyKeyShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MPCNodeServer).DestroyKeyShare(ctx, req.(*DestroyKeyShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MPCNode_SubmitSignatureShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ShareRequest)
 	if err := dec(in); err != nil {
@@ -261,6 +441,26 @@ var MPCNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StartSign",
 			Handler:    _MPCNode_StartSign_Handler,
 		},
+		{
+			MethodName: "StartResharing",
+			Handler:    _MPCNode_StartResharing_Handler,
+		},
+		{
+			MethodName: "StartPresign",
+			Handler:    _MPCNode_StartPresign_Handler,
+		},
+		{
+			MethodName: "ExportShareBackup",
+			Handler:    _MPCNode_ExportShareBackup_Handler,
+		},
+		{
+			MethodName: "ProveKeyShare",
+			Handler:    _MPCNode_ProveKeyShare_Handler,
+		},
+		{
+			MethodName: "DestroyKeyShare",
+			Handler:    _MPCNode_DestroyKeyShare_Handler,
+		},
 		{
 			MethodName: "SubmitSignatureShare",
 			Handler:    _MPCNode_SubmitSignatureShare_Handler,
@@ -57,9 +57,9 @@ type CreateKeyResponse struct {
 	PublicKey *string `json:"public_key"`
 
 	// status
-	// Example: Active
+	// Example: Enabled
 	// Required: true
-	// Enum: [Active Inactive PendingDeletion Deleted]
+	// Enum: [Pending Enabled Disabled PendingDeletion Destroying Deleted]
 	Status *string `json:"status"`
 
 	// tags
@@ -183,7 +183,7 @@ var createKeyResponseTypeStatusPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["Active","Inactive","PendingDeletion","Deleted"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["Pending","Enabled","Disabled","PendingDeletion","Destroying","Deleted"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -193,15 +193,21 @@ func init() {
 
 const (
 
-	// CreateKeyResponseStatusActive captures enum value "Active"
-	CreateKeyResponseStatusActive string = "Active"
+	// CreateKeyResponseStatusPending captures enum value "Pending"
+	CreateKeyResponseStatusPending string = "Pending"
 
-	// CreateKeyResponseStatusInactive captures enum value "Inactive"
-	CreateKeyResponseStatusInactive string = "Inactive"
+	// CreateKeyResponseStatusEnabled captures enum value "Enabled"
+	CreateKeyResponseStatusEnabled string = "Enabled"
+
+	// CreateKeyResponseStatusDisabled captures enum value "Disabled"
+	CreateKeyResponseStatusDisabled string = "Disabled"
 
 	// CreateKeyResponseStatusPendingDeletion captures enum value "PendingDeletion"
 	CreateKeyResponseStatusPendingDeletion string = "PendingDeletion"
 
+	// CreateKeyResponseStatusDestroying captures enum value "Destroying"
+	CreateKeyResponseStatusDestroying string = "Destroying"
+
 	// CreateKeyResponseStatusDeleted captures enum value "Deleted"
 	CreateKeyResponseStatusDeleted string = "Deleted"
 )
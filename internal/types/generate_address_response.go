@@ -7,6 +7,7 @@ package types
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -24,6 +25,11 @@ type GenerateAddressResponse struct {
 	// Required: true
 	Address *string `json:"address"`
 
+	// Bitcoin 地址类型（仅 Bitcoin）
+	// Example: p2wpkh
+	// Enum: [p2pkh p2sh-p2wpkh p2wpkh p2tr]
+	AddressType string `json:"address_type,omitempty"`
+
 	// chain type
 	// Example: ethereum
 	// Required: true
@@ -33,6 +39,11 @@ type GenerateAddressResponse struct {
 	// Example: key-1234567890abcdef
 	// Required: true
 	KeyID *string `json:"key_id"`
+
+	// Bitcoin 网络（仅 Bitcoin）
+	// Example: mainnet
+	// Enum: [mainnet testnet signet regtest]
+	Network string `json:"network,omitempty"`
 }
 
 // Validate validates this generate address response
@@ -43,6 +54,10 @@ func (m *GenerateAddressResponse) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateAddressType(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateChainType(formats); err != nil {
 		res = append(res, err)
 	}
@@ -51,6 +66,10 @@ func (m *GenerateAddressResponse) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateNetwork(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -66,6 +85,54 @@ func (m *GenerateAddressResponse) validateAddress(formats strfmt.Registry) error
 	return nil
 }
 
+var generateAddressResponseTypeAddressTypePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["p2pkh","p2sh-p2wpkh","p2wpkh","p2tr"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		generateAddressResponseTypeAddressTypePropEnum = append(generateAddressResponseTypeAddressTypePropEnum, v)
+	}
+}
+
+const (
+
+	// GenerateAddressResponseAddressTypeP2pkh captures enum value "p2pkh"
+	GenerateAddressResponseAddressTypeP2pkh string = "p2pkh"
+
+	// GenerateAddressResponseAddressTypeP2shP2wpkh captures enum value "p2sh-p2wpkh"
+	GenerateAddressResponseAddressTypeP2shP2wpkh string = "p2sh-p2wpkh"
+
+	// GenerateAddressResponseAddressTypeP2wpkh captures enum value "p2wpkh"
+	GenerateAddressResponseAddressTypeP2wpkh string = "p2wpkh"
+
+	// GenerateAddressResponseAddressTypeP2tr captures enum value "p2tr"
+	GenerateAddressResponseAddressTypeP2tr string = "p2tr"
+)
+
+// prop value enum
+func (m *GenerateAddressResponse) validateAddressTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, generateAddressResponseTypeAddressTypePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *GenerateAddressResponse) validateAddressType(formats strfmt.Registry) error {
+	if swag.IsZero(m.AddressType) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateAddressTypeEnum("address_type", "body", m.AddressType); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *GenerateAddressResponse) validateChainType(formats strfmt.Registry) error {
 
 	if err := validate.Required("chain_type", "body", m.ChainType); err != nil {
@@ -84,6 +151,54 @@ func (m *GenerateAddressResponse) validateKeyID(formats strfmt.Registry) error {
 	return nil
 }
 
+var generateAddressResponseTypeNetworkPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["mainnet","testnet","signet","regtest"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		generateAddressResponseTypeNetworkPropEnum = append(generateAddressResponseTypeNetworkPropEnum, v)
+	}
+}
+
+const (
+
+	// GenerateAddressResponseNetworkMainnet captures enum value "mainnet"
+	GenerateAddressResponseNetworkMainnet string = "mainnet"
+
+	// GenerateAddressResponseNetworkTestnet captures enum value "testnet"
+	GenerateAddressResponseNetworkTestnet string = "testnet"
+
+	// GenerateAddressResponseNetworkSignet captures enum value "signet"
+	GenerateAddressResponseNetworkSignet string = "signet"
+
+	// GenerateAddressResponseNetworkRegtest captures enum value "regtest"
+	GenerateAddressResponseNetworkRegtest string = "regtest"
+)
+
+// prop value enum
+func (m *GenerateAddressResponse) validateNetworkEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, generateAddressResponseTypeNetworkPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *GenerateAddressResponse) validateNetwork(formats strfmt.Registry) error {
+	if swag.IsZero(m.Network) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateNetworkEnum("network", "body", m.Network); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ContextValidate validates this generate address response based on context it is used
 func (m *GenerateAddressResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	return nil
@@ -38,6 +38,10 @@ type GetKeyResponse struct {
 	// Required: true
 	Curve *string `json:"curve"`
 
+	// 计划销毁分片的时间（PendingDeletion、Destroying）或实际销毁时间（Deleted）
+	// Format: date-time
+	DeletionDate *strfmt.DateTime `json:"deletion_date,omitempty"`
+
 	// description
 	Description string `json:"description,omitempty"`
 
@@ -89,6 +93,10 @@ func (m *GetKeyResponse) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateDeletionDate(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateKeyID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -158,6 +166,18 @@ func (m *GetKeyResponse) validateCurve(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *GetKeyResponse) validateDeletionDate(formats strfmt.Registry) error {
+	if swag.IsZero(m.DeletionDate) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("deletion_date", "body", "date-time", m.DeletionDate.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *GetKeyResponse) validateKeyID(formats strfmt.Registry) error {
 
 	if err := validate.Required("key_id", "body", m.KeyID); err != nil {
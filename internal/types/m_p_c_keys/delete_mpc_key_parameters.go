@@ -9,15 +9,26 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // NewDeleteMpcKeyParams creates a new DeleteMpcKeyParams object
-// no default values defined in spec.
+// with the default values initialized.
 func NewDeleteMpcKeyParams() DeleteMpcKeyParams {
 
-	return DeleteMpcKeyParams{}
+	var (
+		// initialize parameters with default values
+
+		pendingWindowDaysDefault = int64(30)
+	)
+
+	return DeleteMpcKeyParams{
+		PendingWindowDays: &pendingWindowDaysDefault,
+	}
 }
 
 // DeleteMpcKeyParams contains all the bound params for the delete mpc key operation
@@ -34,6 +45,13 @@ type DeleteMpcKeyParams struct {
 	  In: path
 	*/
 	KeyID string `param:"keyId"`
+	/*删除等待期（天），等待期结束后销毁所有节点的分片
+	  Maximum: 30
+	  Minimum: 7
+	  In: query
+	  Default: 30
+	*/
+	PendingWindowDays *int64 `query:"pending_window_days"`
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -45,11 +63,18 @@ func (o *DeleteMpcKeyParams) BindRequest(r *http.Request, route *middleware.Matc
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
 	rKeyID, rhkKeyID, _ := route.Params.GetOK("keyId")
 	if err := o.bindKeyID(rKeyID, rhkKeyID, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qPendingWindowDays, qhkPendingWindowDays, _ := qs.GetOK("pending_window_days")
+	if err := o.bindPendingWindowDays(qPendingWindowDays, qhkPendingWindowDays, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -63,6 +88,14 @@ func (o *DeleteMpcKeyParams) Validate(formats strfmt.Registry) error {
 	// Required: true
 	// Parameter is provided by construction from the route
 
+	// pending_window_days
+	// Required: false
+	// AllowEmptyValue: false
+
+	if err := o.validatePendingWindowDays(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -83,3 +116,49 @@ func (o *DeleteMpcKeyParams) bindKeyID(rawData []string, hasKey bool, formats st
 
 	return nil
 }
+
+// bindPendingWindowDays binds and validates parameter PendingWindowDays from query.
+func (o *DeleteMpcKeyParams) bindPendingWindowDays(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewDeleteMpcKeyParams()
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("pending_window_days", "query", "int64", raw)
+	}
+	o.PendingWindowDays = &value
+
+	if err := o.validatePendingWindowDays(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validatePendingWindowDays carries on validations for parameter PendingWindowDays
+func (o *DeleteMpcKeyParams) validatePendingWindowDays(formats strfmt.Registry) error {
+
+	// Required: false
+	if o.PendingWindowDays == nil {
+		return nil
+	}
+
+	if err := validate.MinimumInt("pending_window_days", "query", *o.PendingWindowDays, 7, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("pending_window_days", "query", *o.PendingWindowDays, 30, false); err != nil {
+		return err
+	}
+
+	return nil
+}
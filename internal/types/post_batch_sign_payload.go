@@ -33,6 +33,17 @@ type PostBatchSignPayload struct {
 	// messages
 	// Required: true
 	Messages []*PostBatchSignPayloadMessagesItems0 `json:"messages"`
+
+	// der_sighash 格式追加的 sighash 字节，默认 1（SIGHASH_ALL）
+	// Example: 1
+	// Maximum: 255
+	// Minimum: 0
+	SighashType int64 `json:"sighash_type,omitempty"`
+
+	// 所有签名的输出格式，未指定时按链类型选择
+	// Example: rsv
+	// Enum: [der der_sighash compact rsv raw]
+	SignatureFormat string `json:"signature_format,omitempty"`
 }
 
 // Validate validates this post batch sign payload
@@ -47,6 +58,14 @@ func (m *PostBatchSignPayload) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSighashType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSignatureFormat(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -89,6 +108,73 @@ func (m *PostBatchSignPayload) validateMessages(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *PostBatchSignPayload) validateSighashType(formats strfmt.Registry) error {
+	if swag.IsZero(m.SighashType) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("sighash_type", "body", m.SighashType, 0, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("sighash_type", "body", m.SighashType, 255, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var postBatchSignPayloadTypeSignatureFormatPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["der","der_sighash","compact","rsv","raw"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		postBatchSignPayloadTypeSignatureFormatPropEnum = append(postBatchSignPayloadTypeSignatureFormatPropEnum, v)
+	}
+}
+
+const (
+
+	// PostBatchSignPayloadSignatureFormatDer captures enum value "der"
+	PostBatchSignPayloadSignatureFormatDer string = "der"
+
+	// PostBatchSignPayloadSignatureFormatDerSighash captures enum value "der_sighash"
+	PostBatchSignPayloadSignatureFormatDerSighash string = "der_sighash"
+
+	// PostBatchSignPayloadSignatureFormatCompact captures enum value "compact"
+	PostBatchSignPayloadSignatureFormatCompact string = "compact"
+
+	// PostBatchSignPayloadSignatureFormatRsv captures enum value "rsv"
+	PostBatchSignPayloadSignatureFormatRsv string = "rsv"
+
+	// PostBatchSignPayloadSignatureFormatRaw captures enum value "raw"
+	PostBatchSignPayloadSignatureFormatRaw string = "raw"
+)
+
+// prop value enum
+func (m *PostBatchSignPayload) validateSignatureFormatEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, postBatchSignPayloadTypeSignatureFormatPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PostBatchSignPayload) validateSignatureFormat(formats strfmt.Registry) error {
+	if swag.IsZero(m.SignatureFormat) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateSignatureFormatEnum("signature_format", "body", m.SignatureFormat); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ContextValidate validate this post batch sign payload based on the context it is used
 func (m *PostBatchSignPayload) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
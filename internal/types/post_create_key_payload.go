@@ -29,7 +29,7 @@ type PostCreateKeyPayload struct {
 	// chain type
 	// Example: ethereum
 	// Required: true
-	// Enum: [bitcoin ethereum bsc avalanche]
+	// Enum: [bitcoin ethereum bsc avalanche solana]
 	ChainType *string `json:"chain_type"`
 
 	// curve
@@ -42,6 +42,11 @@ type PostCreateKeyPayload struct {
 	// Example: 企业多签钱包密钥
 	Description string `json:"description,omitempty"`
 
+	// ECDSA 密钥使用的门限签名协议，为空时使用默认协议；EdDSA 密钥总是使用 frost
+	// Example: cggmp21
+	// Enum: [gg18 gg20 cggmp21 frost]
+	Protocol string `json:"protocol,omitempty"`
+
 	// tags
 	// Example: {"environment":"production","wallet_type":"multisig"}
 	Tags map[string]string `json:"tags,omitempty"`
@@ -75,6 +80,10 @@ func (m *PostCreateKeyPayload) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateProtocol(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateThreshold(formats); err != nil {
 		res = append(res, err)
 	}
@@ -136,7 +145,7 @@ var postCreateKeyPayloadTypeChainTypePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["bitcoin","ethereum","bsc","avalanche"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["bitcoin","ethereum","bsc","avalanche","solana"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -157,6 +166,9 @@ const (
 
 	// PostCreateKeyPayloadChainTypeAvalanche captures enum value "avalanche"
 	PostCreateKeyPayloadChainTypeAvalanche string = "avalanche"
+
+	// PostCreateKeyPayloadChainTypeSolana captures enum value "solana"
+	PostCreateKeyPayloadChainTypeSolana string = "solana"
 )
 
 // prop value enum
@@ -227,6 +239,54 @@ func (m *PostCreateKeyPayload) validateCurve(formats strfmt.Registry) error {
 	return nil
 }
 
+var postCreateKeyPayloadTypeProtocolPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["gg18","gg20","cggmp21","frost"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		postCreateKeyPayloadTypeProtocolPropEnum = append(postCreateKeyPayloadTypeProtocolPropEnum, v)
+	}
+}
+
+const (
+
+	// PostCreateKeyPayloadProtocolGg18 captures enum value "gg18"
+	PostCreateKeyPayloadProtocolGg18 string = "gg18"
+
+	// PostCreateKeyPayloadProtocolGg20 captures enum value "gg20"
+	PostCreateKeyPayloadProtocolGg20 string = "gg20"
+
+	// PostCreateKeyPayloadProtocolCggmp21 captures enum value "cggmp21"
+	PostCreateKeyPayloadProtocolCggmp21 string = "cggmp21"
+
+	// PostCreateKeyPayloadProtocolFrost captures enum value "frost"
+	PostCreateKeyPayloadProtocolFrost string = "frost"
+)
+
+// prop value enum
+func (m *PostCreateKeyPayload) validateProtocolEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, postCreateKeyPayloadTypeProtocolPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PostCreateKeyPayload) validateProtocol(formats strfmt.Registry) error {
+	if swag.IsZero(m.Protocol) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateProtocolEnum("protocol", "body", m.Protocol); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *PostCreateKeyPayload) validateThreshold(formats strfmt.Registry) error {
 
 	if err := validate.Required("threshold", "body", m.Threshold); err != nil {
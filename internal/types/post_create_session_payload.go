@@ -31,7 +31,7 @@ type PostCreateSessionPayload struct {
 
 	// protocol
 	// Example: gg20
-	// Enum: [gg18 gg20 frost]
+	// Enum: [gg18 gg20 cggmp21 frost]
 	Protocol string `json:"protocol,omitempty"`
 
 	// timeout
@@ -83,7 +83,7 @@ var postCreateSessionPayloadTypeProtocolPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["gg18","gg20","frost"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["gg18","gg20","cggmp21","frost"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -99,6 +99,9 @@ const (
 	// PostCreateSessionPayloadProtocolGg20 captures enum value "gg20"
 	PostCreateSessionPayloadProtocolGg20 string = "gg20"
 
+	// PostCreateSessionPayloadProtocolCggmp21 captures enum value "cggmp21"
+	PostCreateSessionPayloadProtocolCggmp21 string = "cggmp21"
+
 	// PostCreateSessionPayloadProtocolFrost captures enum value "frost"
 	PostCreateSessionPayloadProtocolFrost string = "frost"
 )
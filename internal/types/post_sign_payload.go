@@ -20,25 +20,51 @@ import (
 // swagger:model postSignPayload
 type PostSignPayload struct {
 
+	// 为 true 时异步签名：检查通过后立即返回 202 和签名会话 ID，通过 GET /api/v1/mpc/sign/{sessionId} 查询结果
+	// Example: true
+	Async bool `json:"async,omitempty"`
+
 	// chain type
 	// Example: ethereum
 	ChainType string `json:"chain_type,omitempty"`
 
+	// 非强化 BIP-32 派生路径，设置时使用从根密钥派生的子密钥签名（仅 secp256k1）
+	// Example: m/0/5
+	DerivationPath string `json:"derivation_path,omitempty"`
+
 	// key id
 	// Example: key-1234567890abcdef
 	// Required: true
 	KeyID *string `json:"key_id"`
 
-	// message
+	// 待签名消息，message_type 为 typed_data 时可省略
 	// Example: SGVsbG8gV29ybGQ=
-	// Required: true
 	// Format: byte
-	Message *strfmt.Base64 `json:"message"`
+	Message strfmt.Base64 `json:"message,omitempty"`
 
-	// message type
+	// message 按 EIP-191 personal_sign 加前缀后签名（ECDSA 密钥），typed_data 签名 EIP-712 摘要
 	// Example: transaction
-	// Enum: [transaction message raw]
+	// Enum: [transaction message raw typed_data]
 	MessageType string `json:"message_type,omitempty"`
+
+	// der_sighash 格式追加的 sighash 字节，默认 1（SIGHASH_ALL）
+	// Example: 1
+	// Maximum: 255
+	// Minimum: 0
+	SighashType int64 `json:"sighash_type,omitempty"`
+
+	// 签名输出格式，未指定时按链类型选择（ethereum 为 rsv，其他 ECDSA 为 der，EdDSA/Schnorr 为 raw）
+	// Example: rsv
+	// Enum: [der der_sighash compact rsv raw]
+	SignatureFormat string `json:"signature_format,omitempty"`
+
+	// EIP-712 结构化数据 JSON（eth_signTypedData_v4 格式，包含 types、primaryType、domain 和 message），message_type 为 typed_data 时必填
+	// Example: {"types":{"Person":[{"name":"name","type":"string"}]},"primaryType":"Person","domain":{"name":"Example","chainId":1},"message":{"name":"Bob"}}
+	TypedData string `json:"typed_data,omitempty"`
+
+	// 被签名的未签名交易（EVM 为 raw hex，Bitcoin 为 PSBT base64，Solana 为 raw base64），用于签名前的策略检查；message 须为该交易的签名数据
+	// Example: 0xec098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080018080
+	UnsignedTx string `json:"unsigned_tx,omitempty"`
 }
 
 // Validate validates this post sign payload
@@ -49,11 +75,15 @@ func (m *PostSignPayload) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
-	if err := m.validateMessage(formats); err != nil {
+	if err := m.validateMessageType(formats); err != nil {
 		res = append(res, err)
 	}
 
-	if err := m.validateMessageType(formats); err != nil {
+	if err := m.validateSighashType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSignatureFormat(formats); err != nil {
 		res = append(res, err)
 	}
 
@@ -72,20 +102,11 @@ func (m *PostSignPayload) validateKeyID(formats strfmt.Registry) error {
 	return nil
 }
 
-func (m *PostSignPayload) validateMessage(formats strfmt.Registry) error {
-
-	if err := validate.Required("message", "body", m.Message); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 var postSignPayloadTypeMessageTypePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["transaction","message","raw"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["transaction","message","raw","typed_data"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -103,6 +124,9 @@ const (
 
 	// PostSignPayloadMessageTypeRaw captures enum value "raw"
 	PostSignPayloadMessageTypeRaw string = "raw"
+
+	// PostSignPayloadMessageTypeTypedData captures enum value "typed_data"
+	PostSignPayloadMessageTypeTypedData string = "typed_data"
 )
 
 // prop value enum
@@ -126,6 +150,73 @@ func (m *PostSignPayload) validateMessageType(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *PostSignPayload) validateSighashType(formats strfmt.Registry) error {
+	if swag.IsZero(m.SighashType) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("sighash_type", "body", m.SighashType, 0, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("sighash_type", "body", m.SighashType, 255, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var postSignPayloadTypeSignatureFormatPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["der","der_sighash","compact","rsv","raw"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		postSignPayloadTypeSignatureFormatPropEnum = append(postSignPayloadTypeSignatureFormatPropEnum, v)
+	}
+}
+
+const (
+
+	// PostSignPayloadSignatureFormatDer captures enum value "der"
+	PostSignPayloadSignatureFormatDer string = "der"
+
+	// PostSignPayloadSignatureFormatDerSighash captures enum value "der_sighash"
+	PostSignPayloadSignatureFormatDerSighash string = "der_sighash"
+
+	// PostSignPayloadSignatureFormatCompact captures enum value "compact"
+	PostSignPayloadSignatureFormatCompact string = "compact"
+
+	// PostSignPayloadSignatureFormatRsv captures enum value "rsv"
+	PostSignPayloadSignatureFormatRsv string = "rsv"
+
+	// PostSignPayloadSignatureFormatRaw captures enum value "raw"
+	PostSignPayloadSignatureFormatRaw string = "raw"
+)
+
+// prop value enum
+func (m *PostSignPayload) validateSignatureFormatEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, postSignPayloadTypeSignatureFormatPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PostSignPayload) validateSignatureFormat(formats strfmt.Registry) error {
+	if swag.IsZero(m.SignatureFormat) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateSignatureFormatEnum("signature_format", "body", m.SignatureFormat); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ContextValidate validates this post sign payload based on context it is used
 func (m *PostSignPayload) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	return nil
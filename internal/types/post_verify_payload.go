@@ -20,9 +20,9 @@ import (
 // swagger:model postVerifyPayload
 type PostVerifyPayload struct {
 
-	// algorithm
+	// 签名算法，未指定时按公钥长度推断（32 字节为 EdDSA，否则为 ECDSA）
 	// Example: ECDSA
-	// Enum: [ECDSA EdDSA]
+	// Enum: [ECDSA EdDSA Schnorr]
 	Algorithm string `json:"algorithm,omitempty"`
 
 	// chain type
@@ -40,7 +40,7 @@ type PostVerifyPayload struct {
 	// Required: true
 	PublicKey *string `json:"public_key"`
 
-	// signature
+	// hex 签名，ECDSA 接受 DER、DER+sighash、r||s 和 r||s||v 编码
 	// Example: 0x1234567890abcdef...
 	// Required: true
 	Signature *string `json:"signature"`
@@ -76,7 +76,7 @@ var postVerifyPayloadTypeAlgorithmPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["ECDSA","EdDSA"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["ECDSA","EdDSA","Schnorr"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -91,6 +91,9 @@ const (
 
 	// PostVerifyPayloadAlgorithmEdDSA captures enum value "EdDSA"
 	PostVerifyPayloadAlgorithmEdDSA string = "EdDSA"
+
+	// PostVerifyPayloadAlgorithmSchnorr captures enum value "Schnorr"
+	PostVerifyPayloadAlgorithmSchnorr string = "Schnorr"
 )
 
 // prop value enum
@@ -23,6 +23,9 @@ type SignResponse struct {
 	// Required: true
 	ChainType *string `json:"chain_type"`
 
+	// 实际签名的 32 字节摘要（hex），仅 ECDSA；EIP-191/EIP-712 为 keccak256 摘要，其他消息类型为 SHA-256
+	Digest string `json:"digest,omitempty"`
+
 	// key id
 	// Required: true
 	KeyID *string `json:"key_id"`
@@ -38,18 +41,36 @@ type SignResponse struct {
 	// Required: true
 	PublicKey *string `json:"public_key"`
 
+	// 签名 r 分量（32 字节 hex），EdDSA/Schnorr 为签名前 32 字节
+	R string `json:"r,omitempty"`
+
+	// ECDSA 公钥恢复标识（0-3），仅 ECDSA
+	// Example: 0
+	RecoveryID *int64 `json:"recovery_id,omitempty"`
+
+	// 签名 s 分量（32 字节 hex，ECDSA 已规范化为 low-S），EdDSA/Schnorr 为签名后 32 字节
+	S string `json:"s,omitempty"`
+
 	// session id
 	// Required: true
 	SessionID *string `json:"session_id"`
 
-	// signature
+	// 按 signature_format 编码的签名（hex）
 	// Example: sig-0x1234567890abcdef...
 	// Required: true
 	Signature *string `json:"signature"`
 
+	// signature format
+	// Example: rsv
+	SignatureFormat string `json:"signature_format,omitempty"`
+
 	// signed at
 	// Format: date-time
 	SignedAt strfmt.DateTime `json:"signed_at,omitempty"`
+
+	// 以太坊 v 值（27 + recovery_id），仅 ECDSA
+	// Example: 27
+	V *int64 `json:"v,omitempty"`
 }
 
 // Validate validates this sign response
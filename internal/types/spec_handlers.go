@@ -55,19 +55,44 @@ func (o *SwaggerSpec) initHandlerCache() {
 	o.Handlers["POST"]["/api/v1/auth/register"] = true
 	o.Handlers["PUT"]["/api/v1/push/token"] = true
 	o.Handlers["DELETE"]["/api/v1/mpc/keys/{keyId}"] = true
+	o.Handlers["DELETE"]["/api/v1/mpc/policies/{ruleId}"] = true
+	o.Handlers["DELETE"]["/api/v1/mpc/webhooks/{webhookId}"] = true
+	o.Handlers["GET"]["/api/v1/mpc/approvals/{approvalId}"] = true
+	o.Handlers["GET"]["/api/v1/mpc/approvals"] = true
+	o.Handlers["GET"]["/api/v1/mpc/audit"] = true
 	o.Handlers["GET"]["/api/v1/mpc/keys/{keyId}"] = true
 	o.Handlers["GET"]["/api/v1/mpc/keys"] = true
+	o.Handlers["GET"]["/api/v1/mpc/keys/{keyId}/approvers"] = true
+	o.Handlers["GET"]["/api/v1/mpc/keys/{keyId}/backups"] = true
+	o.Handlers["GET"]["/api/v1/mpc/keys/{keyId}/derive"] = true
+	o.Handlers["GET"]["/api/v1/mpc/keys/{keyId}/refreshes"] = true
+	o.Handlers["GET"]["/api/v1/mpc/keys/{keyId}/validation"] = true
 	o.Handlers["GET"]["/api/v1/mpc/nodes/{nodeId}"] = true
 	o.Handlers["GET"]["/api/v1/mpc/nodes/{nodeId}/health"] = true
 	o.Handlers["GET"]["/api/v1/mpc/nodes"] = true
+	o.Handlers["GET"]["/api/v1/mpc/policies"] = true
 	o.Handlers["GET"]["/api/v1/mpc/sessions/{sessionId}"] = true
+	o.Handlers["GET"]["/api/v1/mpc/sign/{sessionId}"] = true
+	o.Handlers["GET"]["/api/v1/mpc/webhooks/{webhookId}/deliveries"] = true
+	o.Handlers["GET"]["/api/v1/mpc/webhooks"] = true
+	o.Handlers["POST"]["/api/v1/mpc/approvals/{approvalId}/approve"] = true
+	o.Handlers["POST"]["/api/v1/mpc/keys/{keyId}/cancel-deletion"] = true
 	o.Handlers["POST"]["/api/v1/mpc/sessions/{sessionId}/cancel"] = true
 	o.Handlers["POST"]["/api/v1/mpc/keys"] = true
+	o.Handlers["POST"]["/api/v1/mpc/policies"] = true
 	o.Handlers["POST"]["/api/v1/mpc/sessions"] = true
+	o.Handlers["POST"]["/api/v1/mpc/webhooks"] = true
+	o.Handlers["POST"]["/api/v1/mpc/keys/{keyId}/disable"] = true
+	o.Handlers["POST"]["/api/v1/mpc/keys/{keyId}/enable"] = true
 	o.Handlers["POST"]["/api/v1/mpc/keys/{keyId}/address"] = true
+	o.Handlers["POST"]["/api/v1/mpc/keys/{keyId}/backups"] = true
+	o.Handlers["POST"]["/api/v1/mpc/keys/{keyId}/validation"] = true
 	o.Handlers["POST"]["/api/v1/mpc/sessions/{sessionId}/join"] = true
 	o.Handlers["POST"]["/api/v1/mpc/sign/batch"] = true
 	o.Handlers["POST"]["/api/v1/mpc/sign"] = true
 	o.Handlers["POST"]["/api/v1/mpc/verify"] = true
+	o.Handlers["POST"]["/api/v1/mpc/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver"] = true
 	o.Handlers["POST"]["/api/v1/mpc/nodes"] = true
+	o.Handlers["POST"]["/api/v1/mpc/approvals/{approvalId}/reject"] = true
+	o.Handlers["PUT"]["/api/v1/mpc/keys/{keyId}/approvers"] = true
 }
@@ -15,6 +15,7 @@ const (
 	CTXKeyCacheControl  contextKey = "cache_control"
 	CTXKeyRequestID     contextKey = "request_id"
 	CTXKeyDisableLogger contextKey = "disable_logger"
+	CTXKeyClientIP      contextKey = "client_ip"
 )
 
 //nolint:containedctx
@@ -75,3 +76,13 @@ func ShouldDisableLogger(ctx context.Context) bool {
 func DisableLogger(ctx context.Context, shouldDisable bool) context.Context {
 	return context.WithValue(ctx, CTXKeyDisableLogger, shouldDisable)
 }
+
+// ClientIPFromContext returns the IP address of the client of the (HTTP) request, returning an empty string if it is not present.
+func ClientIPFromContext(ctx context.Context) string {
+	ip, ok := ctx.Value(CTXKeyClientIP).(string)
+	if !ok {
+		return ""
+	}
+
+	return ip
+}
@@ -0,0 +1,11 @@
+-- +migrate Up
+-- share_epoch 记录密钥分片所属的 resharing 轮次（0 表示 DKG 生成的初始分片）
+-- node_ids 记录当前持有分片的节点（委员会），签名时只能从中选择参与节点
+ALTER TABLE keys
+    ADD COLUMN share_epoch integer NOT NULL DEFAULT 0,
+    ADD COLUMN node_ids jsonb;
+
+-- +migrate Down
+ALTER TABLE keys
+    DROP COLUMN IF EXISTS node_ids,
+    DROP COLUMN IF EXISTS share_epoch;
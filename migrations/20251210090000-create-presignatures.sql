@@ -0,0 +1,20 @@
+-- +migrate Up
+-- presignatures 记录协调者跟踪的 GG20 预签名（预签名份额本身只保存在参与节点上）
+-- 每个预签名只能使用一次：签名时通过 UPDATE ... FOR UPDATE SKIP LOCKED 原子地标记为 used
+CREATE TABLE presignatures (
+    presignature_id varchar(255) PRIMARY KEY,
+    key_id varchar(255) NOT NULL,
+    node_ids jsonb NOT NULL,
+    share_epoch integer NOT NULL DEFAULT 0,
+    status varchar(50) NOT NULL DEFAULT 'available',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    used_at timestamptz,
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_presignatures_available ON presignatures (key_id, share_epoch, created_at)
+WHERE
+    status = 'available';
+
+-- +migrate Down
+DROP TABLE IF EXISTS presignatures;
@@ -0,0 +1,8 @@
+-- +migrate Up
+-- chain_code 记录 DKG 时生成的 BIP-32 链码（hex），用于从根公钥非强化派生子公钥和地址
+ALTER TABLE keys
+    ADD COLUMN chain_code text NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE keys
+    DROP COLUMN IF EXISTS chain_code;
@@ -0,0 +1,32 @@
+-- +migrate Up
+-- 可识别中止：记录会话失败原因和责任节点
+ALTER TABLE signing_sessions
+    ADD COLUMN failure_reason text NOT NULL DEFAULT '',
+    ADD COLUMN culprits jsonb;
+
+-- fault_score 节点被判定为中止责任方的累计次数，达到阈值后节点被标记为 faulty
+ALTER TABLE nodes
+    ADD COLUMN fault_score integer NOT NULL DEFAULT 0;
+
+-- node_faults 每个会话对同一节点只计一次故障（多个诚实参与方会重复上报同一责任方）
+CREATE TABLE node_faults (
+    node_id varchar(255) NOT NULL,
+    session_id varchar(255) NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (node_id, session_id)
+);
+
+CREATE INDEX idx_node_faults_created_at ON node_faults (created_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_node_faults_created_at;
+
+DROP TABLE IF EXISTS node_faults;
+
+ALTER TABLE nodes
+    DROP COLUMN IF EXISTS fault_score;
+
+ALTER TABLE signing_sessions
+    DROP COLUMN IF EXISTS culprits,
+    DROP COLUMN IF EXISTS failure_reason;
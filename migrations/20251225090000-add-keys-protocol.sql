@@ -0,0 +1,16 @@
+-- +migrate Up
+-- protocol 记录密钥 DKG 使用的协议（gg18 / gg20 / frost / cggmp21），签名、预签名和 resharing 按此选择引擎
+ALTER TABLE keys
+    ADD COLUMN protocol text NOT NULL DEFAULT '';
+
+-- 历史密钥：优先使用 DKG 会话（session_id = key_id）记录的协议，否则按算法推断
+UPDATE keys
+SET protocol = CASE
+    WHEN lower(algorithm) IN ('eddsa', 'schnorr') THEN 'frost'
+    WHEN lower(COALESCE((SELECT s.protocol FROM signing_sessions s WHERE s.session_id = keys.key_id), '')) = 'gg18' THEN 'gg18'
+    ELSE 'gg20'
+END;
+
+-- +migrate Down
+ALTER TABLE keys
+    DROP COLUMN IF EXISTS protocol;
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- key_share_backups 参与节点导出的密钥分片备份（加密到离线恢复公钥，协调者只保存密文和公开分片）
+-- 每个节点每个分片轮次保留一份备份，resharing 后旧轮次的备份不能与新分片混用
+CREATE TABLE key_share_backups (
+    key_id varchar(255) NOT NULL,
+    node_id varchar(255) NOT NULL,
+    share_epoch integer NOT NULL DEFAULT 0,
+    public_share text NOT NULL,
+    recovery_public_key text NOT NULL,
+    backup jsonb NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (key_id, node_id, share_epoch),
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS key_share_backups;
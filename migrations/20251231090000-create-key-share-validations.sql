@@ -0,0 +1,16 @@
+-- +migrate Up
+-- key_share_validations 密钥分片一致性校验的最近一次结果（每个密钥一行，nodes 为各节点的校验结果）
+-- status: healthy（所有节点一致）、degraded（部分节点异常但仍可签名）、broken（一致的分片不足门限）
+CREATE TABLE key_share_validations (
+    key_id varchar(255) PRIMARY KEY,
+    share_epoch integer NOT NULL DEFAULT 0,
+    status varchar(50) NOT NULL,
+    nodes jsonb NOT NULL,
+    validated_at timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_key_share_validations_status ON key_share_validations (status);
+
+-- +migrate Down
+DROP TABLE IF EXISTS key_share_validations;
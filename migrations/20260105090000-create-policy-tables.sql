@@ -0,0 +1,42 @@
+-- +migrate Up
+-- policy_rules 签名前检查的交易策略规则（key_id 为空表示全局规则，chain_type 为空表示对所有链生效）
+-- rule_type: amount_limit、allowlist、denylist、contract_method、time_window、velocity_limit；params 为规则参数
+-- action: 规则命中时的决策，deny 或 require_approval
+CREATE TABLE policy_rules (
+    rule_id varchar(255) PRIMARY KEY,
+    key_id varchar(255),
+    chain_type varchar(50) NOT NULL DEFAULT '',
+    rule_type varchar(50) NOT NULL,
+    action varchar(50) NOT NULL,
+    params jsonb NOT NULL DEFAULT '{}',
+    enabled boolean NOT NULL DEFAULT TRUE,
+    description text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_policy_rules_key_id ON policy_rules (key_id);
+
+-- policy_decisions 每个签名请求的策略决策（allow、deny、require_approval）及决定结果的规则
+-- transfers 为交易中资产转移的数组（to、amount、asset），用于滚动窗口限额统计；
+-- tx_hash 标识未签名交易，同一交易的多次决策（如 Bitcoin 各输入分别签名）在统计中只计一次
+CREATE TABLE policy_decisions (
+    decision_id varchar(255) PRIMARY KEY,
+    key_id varchar(255) NOT NULL,
+    chain_type varchar(50) NOT NULL DEFAULT '',
+    message_type varchar(50) NOT NULL DEFAULT '',
+    decision varchar(50) NOT NULL,
+    rule_id varchar(255),
+    reason text NOT NULL DEFAULT '',
+    transfers jsonb NOT NULL DEFAULT '[]',
+    tx_hash varchar(255),
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_policy_decisions_key_id_created_at ON policy_decisions (key_id, chain_type, created_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS policy_decisions;
+DROP TABLE IF EXISTS policy_rules;
@@ -0,0 +1,48 @@
+-- +migrate Up
+-- key_approval_quorums 密钥的审批法定人数（approvers 中至少 required_approvals 人批准），策略规则未指定审批人时使用
+CREATE TABLE key_approval_quorums (
+    key_id varchar(255) PRIMARY KEY,
+    approvers jsonb NOT NULL DEFAULT '[]',
+    required_approvals integer NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+-- signing_approvals 策略要求审批的签名请求，request 为签名请求，达到法定人数后自动执行，result 为签名结果
+-- status: pending、approved（执行中）、rejected、expired、executed、failed
+CREATE TABLE signing_approvals (
+    approval_id varchar(255) PRIMARY KEY,
+    key_id varchar(255) NOT NULL,
+    decision_id varchar(255) NOT NULL,
+    rule_id varchar(255),
+    reason text NOT NULL DEFAULT '',
+    requested_by varchar(255) NOT NULL DEFAULT '',
+    approvers jsonb NOT NULL DEFAULT '[]',
+    required_approvals integer NOT NULL,
+    request jsonb NOT NULL,
+    status varchar(50) NOT NULL,
+    result jsonb,
+    error text NOT NULL DEFAULT '',
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_signing_approvals_key_id_status ON signing_approvals (key_id, status);
+
+-- signing_approval_votes 审批人的批准或拒绝，每个审批人对同一请求只能投票一次
+CREATE TABLE signing_approval_votes (
+    approval_id varchar(255) NOT NULL,
+    approver_id varchar(255) NOT NULL,
+    vote varchar(50) NOT NULL,
+    comment text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (approval_id, approver_id),
+    FOREIGN KEY (approval_id) REFERENCES signing_approvals (approval_id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS signing_approval_votes;
+DROP TABLE IF EXISTS signing_approvals;
+DROP TABLE IF EXISTS key_approval_quorums;
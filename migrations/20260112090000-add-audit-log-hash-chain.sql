@@ -0,0 +1,14 @@
+-- +migrate Up
+-- 审计日志哈希链：hash 为本条记录内容和 prev_hash 的 SHA-256，prev_hash 为上一条记录（按 id）的 hash
+ALTER TABLE audit_logs
+    ADD COLUMN IF NOT EXISTS prev_hash varchar(64) NOT NULL DEFAULT '';
+
+ALTER TABLE audit_logs
+    ADD COLUMN IF NOT EXISTS hash varchar(64) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE audit_logs
+    DROP COLUMN IF EXISTS hash;
+
+ALTER TABLE audit_logs
+    DROP COLUMN IF EXISTS prev_hash;
@@ -0,0 +1,12 @@
+-- +migrate Up
+-- 密钥生命周期状态：Active 更名为 Enabled，新增 Disabled 和 PendingDeletion
+-- PendingDeletion 的 deletion_date 为计划销毁分片的时间，Deleted 的 deletion_date 为实际销毁时间
+UPDATE keys SET status = 'Enabled' WHERE status = 'Active';
+
+CREATE INDEX idx_keys_pending_deletion ON keys (deletion_date)
+WHERE status = 'PendingDeletion';
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_keys_pending_deletion;
+
+UPDATE keys SET status = 'Active' WHERE status IN ('Enabled', 'Disabled', 'PendingDeletion');
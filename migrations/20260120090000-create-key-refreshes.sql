@@ -0,0 +1,19 @@
+-- +migrate Up
+-- key_refreshes 密钥分片刷新记录：同一委员会和阈值重新分享，公钥不变，成功后 share epoch 加一
+-- status: completed、failed（失败时 new_epoch 与 old_epoch 相同）
+CREATE TABLE key_refreshes (
+    refresh_id varchar(255) PRIMARY KEY,
+    key_id varchar(255) NOT NULL,
+    status varchar(50) NOT NULL,
+    old_epoch integer NOT NULL,
+    new_epoch integer NOT NULL,
+    error text NOT NULL DEFAULT '',
+    started_at timestamptz NOT NULL,
+    completed_at timestamptz NOT NULL,
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_key_refreshes_key_id_started_at ON key_refreshes (key_id, started_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS key_refreshes;
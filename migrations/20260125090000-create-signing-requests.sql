@@ -0,0 +1,18 @@
+-- +migrate Up
+-- signing_requests 异步签名请求，以签名会话 ID 作为查询句柄，签名在后台执行，result 为签名结果
+-- status: pending、completed、failed
+CREATE TABLE signing_requests (
+    session_id varchar(255) PRIMARY KEY,
+    key_id varchar(255) NOT NULL,
+    status varchar(50) NOT NULL,
+    result jsonb,
+    error text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_signing_requests_key_id ON signing_requests (key_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS signing_requests;
@@ -0,0 +1,44 @@
+-- +migrate Up
+-- webhook_endpoints 用户注册的 webhook，event_types 为订阅的事件类型，key_id 不为空时只接收该密钥的事件
+-- secret 为 HMAC-SHA256 签名密钥（投递时需要原文）
+CREATE TABLE webhook_endpoints (
+    webhook_id varchar(255) PRIMARY KEY,
+    owner_id varchar(255) NOT NULL,
+    url text NOT NULL,
+    secret varchar(255) NOT NULL,
+    event_types jsonb NOT NULL DEFAULT '[]',
+    key_id varchar(255),
+    description text NOT NULL DEFAULT '',
+    enabled boolean NOT NULL DEFAULT TRUE,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (key_id) REFERENCES keys (key_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_endpoints_owner_id ON webhook_endpoints (owner_id);
+
+-- webhook_deliveries webhook 发件箱：每个事件对每个订阅的 webhook 一条记录，失败后按指数退避重试
+-- status: pending、succeeded、failed（达到最大重试次数）；重新投递时写入使用相同 event_id 的新记录
+CREATE TABLE webhook_deliveries (
+    delivery_id varchar(255) PRIMARY KEY,
+    webhook_id varchar(255) NOT NULL,
+    event_id varchar(255) NOT NULL,
+    event_type varchar(100) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(50) NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_attempt_at timestamptz,
+    response_status integer NOT NULL DEFAULT 0,
+    last_error text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (webhook_id) REFERENCES webhook_endpoints (webhook_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook_id_created_at ON webhook_deliveries (webhook_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+-- +migrate Down
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
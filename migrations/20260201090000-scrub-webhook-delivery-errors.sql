@@ -0,0 +1,8 @@
+-- +migrate Up
+-- 投递错误不再记录接收方的响应体，清除已记录的响应内容
+UPDATE webhook_deliveries
+SET last_error = substring(last_error FROM '^webhook responded with status [0-9]+')
+WHERE last_error ~ '^webhook responded with status [0-9]+: ';
+
+-- +migrate Down
+-- 已清除的响应内容无法恢复
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- 分片销毁失败的次数和下一次允许重试的时间，删除任务在 SQL 中跳过退避中的密钥，失败的密钥不会占满每批的处理数量
+ALTER TABLE keys ADD COLUMN deletion_attempts integer NOT NULL DEFAULT 0;
+ALTER TABLE keys ADD COLUMN next_deletion_attempt_at timestamptz;
+
+-- 到期待删除和销毁未完成的密钥
+DROP INDEX IF EXISTS idx_keys_pending_deletion;
+CREATE INDEX idx_keys_pending_deletion ON keys (deletion_date)
+WHERE status IN ('PendingDeletion', 'Destroying');
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_keys_pending_deletion;
+CREATE INDEX idx_keys_pending_deletion ON keys (deletion_date)
+WHERE status = 'PendingDeletion';
+
+ALTER TABLE keys DROP COLUMN IF EXISTS next_deletion_attempt_at;
+ALTER TABLE keys DROP COLUMN IF EXISTS deletion_attempts;